@@ -4,16 +4,27 @@ import (
 	"context"
 	"errors"
 	"sort"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/actions/shared"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 
 	"github.com/go-logr/logr"
 )
 
+const (
+	// logsLongPollTimeout is how long tailing reads wait for new runtime logs
+	// when there are none since the requested start time
+	logsLongPollTimeout = 10 * time.Second
+	// logsBatchWindow is how long tailing reads keep collecting runtime logs
+	// once the first one has arrived
+	logsBatchWindow = 250 * time.Millisecond
+)
+
 type AppLogs struct {
 	appRepo   shared.CFAppRepository
 	buildRepo shared.CFBuildRepository
@@ -56,32 +67,42 @@ func (a *AppLogs) Read(ctx context.Context, logger logr.Logger, authInfo authori
 		logLimit = read.Limit
 	}
 
-	runtimeLogs, err := a.podRepo.GetRuntimeLogsForApp(ctx, logger, authInfo, repositories.RuntimeLogsMessage{
+	runtimeLogsMessage := repositories.RuntimeLogsMessage{
 		SpaceGUID:   app.SpaceGUID,
 		AppGUID:     app.GUID,
 		AppRevision: app.Revision,
 		Limit:       logLimit,
-	})
+	}
+	if read.StartTime > 0 {
+		runtimeLogsMessage.SinceTime = tools.PtrTo(time.Unix(0, read.StartTime))
+	}
+
+	runtimeLogs, err := a.podRepo.GetRuntimeLogsForApp(ctx, logger, authInfo, runtimeLogsMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app runtime logs from Kubernetes", "AppGUID", appGUID)
 	}
 
-	logs := append(buildLogs, runtimeLogs...)
+	logs := sinceStartTime(append(buildLogs, runtimeLogs...), read.StartTime)
 
-	sort.Slice(logs, func(i, j int) bool {
-		return logs[i].Timestamp < logs[j].Timestamp
-	})
-
-	// ensure that we didn't exceed the log limit
-	if read.Limit != 0 && int64(len(logs)) > read.Limit {
-		first := int64(len(logs)) - read.Limit
-		logs = logs[first:]
+	// The cf CLI tails logs by polling with the start time set to just after
+	// the last log it received. Rather than having it poll until new logs are
+	// emitted, hold the request until the app instances log something.
+	if len(logs) == 0 && read.StartTime > 0 && !read.Descending {
+		logs, err = a.waitForRuntimeLogs(ctx, logger, authInfo, app, read.StartTime, logLimit)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to follow app runtime logs", "AppGUID", appGUID)
+		}
 	}
 
-	// filter any entries from before the start time
-	if read.StartTime != 0 {
-		first := sort.Search(len(logs), func(i int) bool { return read.StartTime <= logs[i].Timestamp })
-		logs = logs[first:]
+	// ensure that we didn't exceed the log limit. When tailing, the oldest
+	// logs are returned first, as log-cache does. Recent logs are requested
+	// with a start time before the unix epoch.
+	if read.Limit != 0 && int64(len(logs)) > read.Limit {
+		if read.StartTime > 0 && !read.Descending {
+			logs = logs[:read.Limit]
+		} else {
+			logs = logs[int64(len(logs))-read.Limit:]
+		}
 	}
 
 	if read.Descending {
@@ -92,3 +113,58 @@ func (a *AppLogs) Read(ctx context.Context, logger logr.Logger, authInfo authori
 
 	return logs, nil
 }
+
+// waitForRuntimeLogs follows the logs of all the app instances until some
+// are emitted after the start time or the long poll times out. Logs emitted
+// by several instances at about the same time are returned together.
+func (a *AppLogs) waitForRuntimeLogs(ctx context.Context, logger logr.Logger, authInfo authorization.Info, app repositories.AppRecord, startTime int64, limit int64) ([]repositories.LogRecord, error) {
+	streamCtx, cancel := context.WithTimeout(ctx, logsLongPollTimeout)
+	defer cancel()
+
+	stream, err := a.podRepo.StreamRuntimeLogsForApp(streamCtx, logger, authInfo, repositories.StreamRuntimeLogsMessage{
+		SpaceGUID:   app.SpaceGUID,
+		AppGUID:     app.GUID,
+		AppRevision: app.Revision,
+		SinceTime:   tools.PtrTo(time.Unix(0, startTime)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logs := []repositories.LogRecord{}
+	var batchDone <-chan time.Time
+	for {
+		select {
+		case logRecord, ok := <-stream:
+			if !ok {
+				return sinceStartTime(logs, startTime), nil
+			}
+
+			logs = append(logs, logRecord)
+			if int64(len(logs)) >= limit {
+				return sinceStartTime(logs, startTime), nil
+			}
+
+			if batchDone == nil {
+				batchDone = time.After(logsBatchWindow)
+			}
+		case <-batchDone:
+			return sinceStartTime(logs, startTime), nil
+		}
+	}
+}
+
+// sinceStartTime sorts the logs by timestamp and filters out any entries from
+// before the start time
+func sinceStartTime(logs []repositories.LogRecord, startTime int64) []repositories.LogRecord {
+	sort.Slice(logs, func(i, j int) bool {
+		return logs[i].Timestamp < logs[j].Timestamp
+	})
+
+	if startTime == 0 {
+		return logs
+	}
+
+	first := sort.Search(len(logs), func(i int) bool { return startTime <= logs[i].Timestamp })
+	return logs[first:]
+}
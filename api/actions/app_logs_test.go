@@ -15,6 +15,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -34,6 +35,7 @@ var _ = Describe("ReadAppLogs", func() {
 		appLogs *AppLogs
 
 		buildLogs, logs []repositories.LogRecord
+		streamedLogs    chan repositories.LogRecord
 
 		authInfo       authorization.Info
 		requestPayload payloads.LogRead
@@ -85,6 +87,9 @@ var _ = Describe("ReadAppLogs", func() {
 		}
 		podRepo.GetRuntimeLogsForAppReturns(logs, nil)
 
+		streamedLogs = make(chan repositories.LogRecord, 10)
+		podRepo.StreamRuntimeLogsForAppReturns(streamedLogs, nil)
+
 		requestPayload = payloads.LogRead{}
 		authInfo = authorization.Info{Token: "a-token"}
 	})
@@ -129,6 +134,25 @@ var _ = Describe("ReadAppLogs", func() {
 			})
 		})
 
+		When("the start time is set to tail the logs", func() {
+			BeforeEach(func() {
+				requestPayload.StartTime = buildLogs[1].Timestamp
+			})
+
+			It("reads the runtime logs since the start time", func() {
+				Expect(podRepo.GetRuntimeLogsForAppCallCount()).To(Equal(1))
+				_, _, _, message := podRepo.GetRuntimeLogsForAppArgsForCall(0)
+				Expect(message.SinceTime).To(PointTo(BeTemporally("==", time.Unix(0, buildLogs[1].Timestamp))))
+			})
+
+			It("gives us the oldest logs since the start time up to the limit", func() {
+				Expect(returnedErr).NotTo(HaveOccurred())
+				Expect(returnedRecords).To(HaveLen(2))
+				Expect(returnedRecords[0].Message).To(Equal("BuildMessage2"))
+				Expect(returnedRecords[1].Message).To(Equal("AppMessage1"))
+			})
+		})
+
 		When("the build and run logs are chronologically interleaved", func() {
 			BeforeEach(func() {
 				buildLogs[1].Timestamp, logs[0].Timestamp = logs[0].Timestamp, buildLogs[1].Timestamp
@@ -156,14 +180,79 @@ var _ = Describe("ReadAppLogs", func() {
 	})
 
 	When("the start time is newer than any of the log entries", func() {
+		var startTime time.Time
+
 		BeforeEach(func() {
-			requestPayload.StartTime = time.Now().Add(time.Minute).UnixNano()
+			startTime = time.Now().Add(time.Minute)
+			requestPayload.StartTime = startTime.UnixNano()
+			close(streamedLogs)
+		})
+
+		It("follows the runtime logs since the start time", func() {
+			Expect(podRepo.StreamRuntimeLogsForAppCallCount()).To(Equal(1))
+			_, _, actualAuthInfo, message := podRepo.StreamRuntimeLogsForAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(MatchAllFields(Fields{
+				"SpaceGUID":   Equal(spaceGUID),
+				"AppGUID":     Equal(appGUID),
+				"AppRevision": Equal("1"),
+				"SinceTime":   PointTo(BeTemporally("==", startTime)),
+			}))
 		})
 
-		It("returns an empty list", func() {
+		It("returns an empty list when no logs are emitted", func() {
 			Expect(returnedErr).NotTo(HaveOccurred())
 			Expect(returnedRecords).To(BeEmpty())
 		})
+
+		When("the app instances emit logs", func() {
+			BeforeEach(func() {
+				streamedLogs = make(chan repositories.LogRecord, 10)
+				streamedLogs <- repositories.LogRecord{Message: "StreamedMessage2", Timestamp: startTime.Add(2 * time.Second).UnixNano()}
+				streamedLogs <- repositories.LogRecord{Message: "StreamedMessage1", Timestamp: startTime.Add(time.Second).UnixNano()}
+				podRepo.StreamRuntimeLogsForAppReturns(streamedLogs, nil)
+			})
+
+			It("returns the emitted logs in chronological order", func() {
+				Expect(returnedErr).NotTo(HaveOccurred())
+				Expect(returnedRecords).To(HaveExactElements(
+					HaveField("Message", "StreamedMessage1"),
+					HaveField("Message", "StreamedMessage2"),
+				))
+			})
+
+			When("more logs than the limit are emitted", func() {
+				BeforeEach(func() {
+					requestPayload.Limit = 1
+				})
+
+				It("returns as soon as the limit is reached", func() {
+					Expect(returnedErr).NotTo(HaveOccurred())
+					Expect(returnedRecords).To(HaveExactElements(HaveField("Message", "StreamedMessage2")))
+				})
+			})
+		})
+
+		When("the logs are read in descending order", func() {
+			BeforeEach(func() {
+				requestPayload.Descending = true
+			})
+
+			It("does not follow the runtime logs", func() {
+				Expect(podRepo.StreamRuntimeLogsForAppCallCount()).To(BeZero())
+				Expect(returnedRecords).To(BeEmpty())
+			})
+		})
+
+		When("following the runtime logs fails", func() {
+			BeforeEach(func() {
+				podRepo.StreamRuntimeLogsForAppReturns(nil, errors.New("stream-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(returnedErr).To(MatchError("stream-err"))
+			})
+		})
 	})
 
 	When("the start time is the same as the latest log entry", func() {
@@ -26,15 +26,21 @@ type Applier interface {
 	Apply(ctx context.Context, authInfo authorization.Info, spaceGUID string, appInfo payloads.ManifestApplication, appState manifest.AppState) error
 }
 
+//counterfeiter:generate -o fake -fake-name Differ . Differ
+type Differ interface {
+	Diff(appIndex int, current, desired payloads.ManifestApplication) ([]manifest.DiffEntry, error)
+}
+
 type Manifest struct {
 	domainRepo        shared.CFDomainRepository
 	defaultDomainName string
 	stateCollector    StateCollector
 	normalizer        Normalizer
 	applier           Applier
+	differ            Differ
 }
 
-func NewManifest(domainRepo shared.CFDomainRepository, defaultDomainName string, stateCollector StateCollector, normalizer Normalizer, applier Applier,
+func NewManifest(domainRepo shared.CFDomainRepository, defaultDomainName string, stateCollector StateCollector, normalizer Normalizer, applier Applier, differ Differ,
 ) *Manifest {
 	return &Manifest{
 		domainRepo:        domainRepo,
@@ -42,6 +48,7 @@ func NewManifest(domainRepo shared.CFDomainRepository, defaultDomainName string,
 		stateCollector:    stateCollector,
 		normalizer:        normalizer,
 		applier:           applier,
+		differ:            differ,
 	}
 }
 
@@ -66,6 +73,27 @@ func (a *Manifest) Apply(ctx context.Context, authInfo authorization.Info, space
 	return nil
 }
 
+func (a *Manifest) Diff(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifesto payloads.Manifest) ([]manifest.DiffEntry, error) {
+	diff := []manifest.DiffEntry{}
+	for i, appInfo := range manifesto.Applications {
+		appState, err := a.stateCollector.CollectState(ctx, authInfo, appInfo.Name, spaceGUID)
+		if err != nil {
+			return nil, err
+		}
+
+		current := a.normalizer.Normalize(appState.ToManifestApplication(), appState)
+		desired := a.normalizer.Normalize(appInfo, appState)
+
+		appDiff, err := a.differ.Diff(i, current, desired)
+		if err != nil {
+			return nil, err
+		}
+		diff = append(diff, appDiff...)
+	}
+
+	return diff, nil
+}
+
 func (a *Manifest) ensureDefaultDomainConfigured(ctx context.Context, authInfo authorization.Info) error {
 	_, err := a.domainRepo.GetDomainByName(ctx, authInfo, a.defaultDomainName)
 	if err != nil {
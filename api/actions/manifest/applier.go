@@ -21,6 +21,7 @@ type Applier struct {
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
+	sidecarRepo         shared.CFSidecarRepository
 }
 
 func NewApplier(
@@ -30,6 +31,7 @@ func NewApplier(
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
+	sidecarRepo shared.CFSidecarRepository,
 ) *Applier {
 	return &Applier{
 		appRepo:             appRepo,
@@ -38,6 +40,7 @@ func NewApplier(
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
+		sidecarRepo:         sidecarRepo,
 	}
 }
 
@@ -51,6 +54,10 @@ func (a *Applier) Apply(ctx context.Context, authInfo authorization.Info, spaceG
 		return err
 	}
 
+	if err := a.applySidecars(ctx, authInfo, appInfo, appState); err != nil {
+		return err
+	}
+
 	if err := a.applyRoutes(ctx, authInfo, appInfo, appState); err != nil {
 		return err
 	}
@@ -97,6 +104,28 @@ func (a *Applier) applyProcesses(
 	return nil
 }
 
+func (a *Applier) applySidecars(
+	ctx context.Context,
+	authInfo authorization.Info,
+	appInfo payloads.ManifestApplication,
+	appState AppState,
+) error {
+	for _, sidecarInfo := range appInfo.Sidecars {
+		if sidecar, ok := appState.Sidecars[sidecarInfo.Name]; ok {
+			if _, err := a.sidecarRepo.PatchSidecar(ctx, authInfo, sidecarInfo.ToSidecarPatchMessage(sidecar)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := a.sidecarRepo.CreateSidecar(ctx, authInfo, sidecarInfo.ToSidecarCreateMessage(appState.App.GUID, appState.App.SpaceGUID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (a *Applier) applyRoutes(ctx context.Context, authInfo authorization.Info, appInfo payloads.ManifestApplication, appState AppState) error {
 	if appInfo.NoRoute {
 		return a.deleteAppDestinations(ctx, authInfo, appState.App.GUID, appState.Routes)
@@ -107,7 +136,7 @@ func (a *Applier) applyRoutes(ctx context.Context, authInfo authorization.Info,
 
 func (a *Applier) createOrUpdateRoutes(ctx context.Context, authInfo authorization.Info, appInfo payloads.ManifestApplication, appState AppState) error {
 	for _, route := range appInfo.Routes {
-		err := a.createOrUpdateRoute(ctx, authInfo, *route.Route, appState)
+		err := a.createOrUpdateRoute(ctx, authInfo, route, appState)
 		if err != nil {
 			return fmt.Errorf("createOrUpdateRoutes: %w", err)
 		}
@@ -116,7 +145,8 @@ func (a *Applier) createOrUpdateRoutes(ctx context.Context, authInfo authorizati
 	return nil
 }
 
-func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorization.Info, routeString string, appState AppState) error {
+func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorization.Info, route payloads.ManifestRoute, appState AppState) error {
+	routeString := *route.Route
 	if _, routeExists := appState.Routes[routeString]; routeExists {
 		return nil
 	}
@@ -150,6 +180,7 @@ func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorizatio
 		NewDestinations: []repositories.DestinationMessage{{
 			AppGUID:     appState.App.GUID,
 			ProcessType: korifiv1alpha1.ProcessTypeWeb,
+			Protocol:    route.Protocol,
 		}},
 	})
 	if err != nil {
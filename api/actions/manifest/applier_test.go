@@ -25,6 +25,7 @@ var _ = Describe("Applier", func() {
 		routeRepo           *fake.CFRouteRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
+		sidecarRepo         *fake.CFSidecarRepository
 		applier             *manifest.Applier
 		applierErr          error
 		ctx                 context.Context
@@ -40,7 +41,8 @@ var _ = Describe("Applier", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
-		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo)
+		sidecarRepo = new(fake.CFSidecarRepository)
+		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, sidecarRepo)
 		ctx = context.Background()
 		authInfo = authorization.Info{Token: "a-token"}
 		appInfo = payloads.ManifestApplication{
@@ -263,6 +265,94 @@ var _ = Describe("Applier", func() {
 		})
 	})
 
+	Describe("applying sidecars", func() {
+		BeforeEach(func() {
+			appState.App.GUID = "app-guid"
+			appState.App.SpaceGUID = "space-guid"
+			appInfo.Sidecars = []payloads.ManifestApplicationSidecar{
+				{
+					Name:         "log-shipper",
+					Command:      "ship-logs",
+					ProcessTypes: []string{"web"},
+					Memory:       tools.PtrTo("64M"),
+				},
+				{
+					Name:         "config-agent",
+					Command:      "watch-config",
+					ProcessTypes: []string{"web", "worker"},
+				},
+			}
+		})
+
+		It("creates each sidecar", func() {
+			Expect(applierErr).NotTo(HaveOccurred())
+			Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(0))
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(2))
+
+			_, _, createMsg := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(createMsg).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "log-shipper",
+				Command:      "ship-logs",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo[int64](64),
+			}))
+
+			_, _, createMsg = sidecarRepo.CreateSidecarArgsForCall(1)
+			Expect(createMsg).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "config-agent",
+				Command:      "watch-config",
+				ProcessTypes: []string{"web", "worker"},
+			}))
+		})
+
+		When("creating a sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, errors.New("create-sidecar-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(applierErr).To(MatchError("create-sidecar-failed"))
+			})
+		})
+
+		When("a sidecar with the same name exists", func() {
+			BeforeEach(func() {
+				appState.Sidecars = map[string]repositories.SidecarRecord{
+					"config-agent": {GUID: "sidecar-guid", AppGUID: "app-guid", SpaceGUID: "space-guid"},
+				}
+			})
+
+			It("patches that sidecar", func() {
+				Expect(applierErr).NotTo(HaveOccurred())
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+
+				_, _, patchMsg := sidecarRepo.PatchSidecarArgsForCall(0)
+				Expect(patchMsg).To(Equal(repositories.PatchSidecarMessage{
+					GUID:         "sidecar-guid",
+					AppGUID:      "app-guid",
+					SpaceGUID:    "space-guid",
+					Command:      tools.PtrTo("watch-config"),
+					ProcessTypes: []string{"web", "worker"},
+				}))
+			})
+
+			When("patching the sidecar fails", func() {
+				BeforeEach(func() {
+					sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{}, errors.New("sidecar-patch-error"))
+				})
+
+				It("returns the error", func() {
+					Expect(applierErr).To(MatchError("sidecar-patch-error"))
+				})
+			})
+		})
+	})
+
 	Describe("applying routes", func() {
 		BeforeEach(func() {
 			appState.App.GUID = "app-guid"
@@ -317,6 +407,22 @@ var _ = Describe("Applier", func() {
 			}))
 		})
 
+		When("the route has a protocol", func() {
+			BeforeEach(func() {
+				appInfo.Routes[0].Protocol = tools.PtrTo("http2")
+			})
+
+			It("adds a destination with that protocol", func() {
+				Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+				_, _, addDestinationMessage := routeRepo.AddDestinationsToRouteArgsForCall(0)
+				Expect(addDestinationMessage.NewDestinations).To(ConsistOf(repositories.DestinationMessage{
+					AppGUID:     "app-guid",
+					ProcessType: "web",
+					Protocol:    tools.PtrTo("http2"),
+				}))
+			})
+		})
+
 		When("adding the destination to the route fails", func() {
 			BeforeEach(func() {
 				routeRepo.AddDestinationsToRouteReturns(repositories.RouteRecord{}, errors.New("add-route-to-dest-error"))
@@ -0,0 +1,369 @@
+package manifest
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"code.cloudfoundry.org/bytefmt"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DiffOpAdd     = "add"
+	DiffOpRemove  = "remove"
+	DiffOpReplace = "replace"
+)
+
+// ignoredKeys are manifest keys that are either deprecated aliases (already
+// folded into their replacements by the normalizer) or not part of the app
+// state we are able to collect
+var ignoredKeys = []string{"buildpack", "disk-quota", "docker"}
+
+type DiffEntry struct {
+	Op    string
+	Path  string
+	Was   any
+	Value any
+}
+
+type Differ struct{}
+
+func NewDiffer() Differ {
+	return Differ{}
+}
+
+// Diff compares the desired app manifest against the current one and returns
+// JSON-Patch-like entries rooted at /applications/<appIndex>. Only keys set in
+// the desired manifest are compared, as keys missing from it are left
+// untouched when the manifest is applied.
+func (d Differ) Diff(appIndex int, current, desired payloads.ManifestApplication) ([]DiffEntry, error) {
+	currentMap, err := toManifestMap(current)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredMap, err := toManifestMap(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffMaps(fmt.Sprintf("/applications/%d", appIndex), currentMap, desiredMap), nil
+}
+
+// ToManifestApplication represents the app state in manifest form
+func (s AppState) ToManifestApplication() payloads.ManifestApplication {
+	if s.App.GUID == "" {
+		return payloads.ManifestApplication{}
+	}
+
+	app := payloads.ManifestApplication{
+		Name:     s.App.Name,
+		Env:      s.EnvVars,
+		Metadata: toMetadataPatch(s.App.Labels, s.App.Annotations),
+	}
+
+	if s.App.Lifecycle.Type == string(korifiv1alpha1.BuildpackLifecycle) {
+		app.Buildpacks = s.App.Lifecycle.Data.Buildpacks
+	}
+
+	for _, processType := range sortedKeys(s.Processes) {
+		process := s.Processes[processType]
+		manifestProcess := payloads.ManifestApplicationProcess{
+			Type:      process.Type,
+			Instances: &process.DesiredInstances,
+			Memory:    megabytesString(process.MemoryMB),
+			DiskQuota: megabytesString(process.DiskQuotaMB),
+		}
+		if process.Command != "" {
+			manifestProcess.Command = &process.Command
+		}
+		if process.HealthCheck.Type != "" {
+			manifestProcess.HealthCheckType = &process.HealthCheck.Type
+		}
+		if process.HealthCheck.Data.HTTPEndpoint != "" {
+			manifestProcess.HealthCheckHTTPEndpoint = &process.HealthCheck.Data.HTTPEndpoint
+		}
+		if process.HealthCheck.Data.InvocationTimeoutSeconds != 0 {
+			manifestProcess.HealthCheckInvocationTimeout = &process.HealthCheck.Data.InvocationTimeoutSeconds
+		}
+		if process.HealthCheck.Data.TimeoutSeconds != 0 {
+			manifestProcess.Timeout = &process.HealthCheck.Data.TimeoutSeconds
+		}
+		app.Processes = append(app.Processes, manifestProcess)
+	}
+
+	for _, route := range sortedKeys(s.Routes) {
+		app.Routes = append(app.Routes, payloads.ManifestRoute{Route: &route})
+	}
+
+	for _, serviceName := range sortedKeys(s.ServiceBindings) {
+		app.Services = append(app.Services, payloads.ManifestApplicationService{
+			Name:        serviceName,
+			BindingName: s.ServiceBindings[serviceName].Name,
+		})
+	}
+
+	return app
+}
+
+func toMetadataPatch(labels, annotations map[string]string) payloads.MetadataPatch {
+	patch := payloads.MetadataPatch{}
+
+	if len(labels) > 0 {
+		patch.Labels = map[string]*string{}
+		for k, v := range labels {
+			patch.Labels[k] = &v
+		}
+	}
+
+	if len(annotations) > 0 {
+		patch.Annotations = map[string]*string{}
+		for k, v := range annotations {
+			patch.Annotations[k] = &v
+		}
+	}
+
+	return patch
+}
+
+func megabytesString(mb int64) *string {
+	if mb == 0 {
+		return nil
+	}
+
+	value := fmt.Sprintf("%dM", mb)
+	return &value
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+func toManifestMap(app payloads.ManifestApplication) (map[string]any, error) {
+	appBytes, err := yaml.Marshal(app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest application: %w", err)
+	}
+
+	appMap := map[string]any{}
+	if err = yaml.Unmarshal(appBytes, &appMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest application: %w", err)
+	}
+
+	for _, key := range ignoredKeys {
+		delete(appMap, key)
+	}
+
+	if processes, ok := appMap["processes"].([]any); ok {
+		for _, p := range processes {
+			if process, ok := p.(map[string]any); ok {
+				normalizeProcessUnits(process)
+			}
+		}
+	}
+
+	pruned, _ := prune(appMap).(map[string]any)
+	if pruned == nil {
+		pruned = map[string]any{}
+	}
+
+	return pruned, nil
+}
+
+// normalizeProcessUnits expresses memory and disk quotas in megabytes, so
+// that e.g. 1G and 1024M are considered equal
+func normalizeProcessUnits(process map[string]any) {
+	for _, key := range ignoredKeys {
+		delete(process, key)
+	}
+
+	for _, key := range []string{"memory", "disk_quota"} {
+		value, ok := process[key].(string)
+		if !ok {
+			continue
+		}
+
+		mb, err := bytefmt.ToMegabytes(value)
+		if err != nil {
+			continue
+		}
+		process[key] = fmt.Sprintf("%dM", mb)
+	}
+}
+
+// prune drops unset values, i.e. nils, empty strings, false booleans and
+// empty collections
+func prune(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	case map[string]any:
+		pruned := map[string]any{}
+		for key, val := range v {
+			if prunedVal := prune(val); prunedVal != nil {
+				pruned[key] = prunedVal
+			}
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return pruned
+	case []any:
+		pruned := []any{}
+		for _, val := range v {
+			if prunedVal := prune(val); prunedVal != nil {
+				pruned = append(pruned, prunedVal)
+			}
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return pruned
+	}
+
+	return value
+}
+
+func diffValues(path string, current, desired any) []DiffEntry {
+	if current == nil {
+		return []DiffEntry{{Op: DiffOpAdd, Path: path, Value: desired}}
+	}
+
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		if currentValue, ok := current.(map[string]any); ok {
+			return diffMaps(path, currentValue, desiredValue)
+		}
+	case []any:
+		if currentValue, ok := current.([]any); ok {
+			if isProcessesPath(path) {
+				return diffProcesses(path, currentValue, desiredValue)
+			}
+			return diffSlices(path, currentValue, desiredValue)
+		}
+	}
+
+	if reflect.DeepEqual(current, desired) {
+		return nil
+	}
+
+	return []DiffEntry{{Op: DiffOpReplace, Path: path, Was: current, Value: desired}}
+}
+
+func diffMaps(path string, current, desired map[string]any) []DiffEntry {
+	diff := []DiffEntry{}
+	for _, key := range sortedKeys(desired) {
+		diff = append(diff, diffValues(path+"/"+escapePathSegment(key), current[key], desired[key])...)
+	}
+
+	return diff
+}
+
+func isProcessesPath(path string) bool {
+	segments := strings.Split(path, "/")
+	return len(segments) == 4 && segments[1] == "applications" && segments[3] == "processes"
+}
+
+// diffProcesses matches processes by type rather than by position
+func diffProcesses(path string, current, desired []any) []DiffEntry {
+	currentByType := map[string]any{}
+	for _, p := range current {
+		if process, ok := p.(map[string]any); ok {
+			currentByType[fmt.Sprint(process["type"])] = process
+		}
+	}
+
+	diff := []DiffEntry{}
+	for i, p := range desired {
+		process, ok := p.(map[string]any)
+		if !ok {
+			continue
+		}
+		diff = append(diff, diffValues(fmt.Sprintf("%s/%d", path, i), currentByType[fmt.Sprint(process["type"])], process)...)
+	}
+
+	return diff
+}
+
+// diffSlices compares slices element by element. Slices of objects (e.g.
+// routes or services) are treated as unordered, so that reordering them
+// does not produce any diff.
+func diffSlices(path string, current, desired []any) []DiffEntry {
+	if isSliceOfMaps(desired) {
+		current = alignTo(desired, current)
+	}
+
+	diff := []DiffEntry{}
+	for i := 0; i < min(len(current), len(desired)); i++ {
+		diff = append(diff, diffValues(fmt.Sprintf("%s/%d", path, i), current[i], desired[i])...)
+	}
+
+	for i := len(current); i < len(desired); i++ {
+		diff = append(diff, DiffEntry{Op: DiffOpAdd, Path: fmt.Sprintf("%s/%d", path, i), Value: desired[i]})
+	}
+
+	for i := len(current) - 1; i >= len(desired); i-- {
+		diff = append(diff, DiffEntry{Op: DiffOpRemove, Path: fmt.Sprintf("%s/%d", path, i), Was: current[i]})
+	}
+
+	return diff
+}
+
+func isSliceOfMaps(s []any) bool {
+	for _, e := range s {
+		if _, ok := e.(map[string]any); !ok {
+			return false
+		}
+	}
+
+	return len(s) > 0
+}
+
+// alignTo reorders current so that elements that are also in desired end up
+// at the same index. Positions that cannot be filled are left nil, which
+// results in an add operation.
+func alignTo(desired, current []any) []any {
+	remaining := slices.Clone(current)
+	aligned := make([]any, len(desired))
+	matched := make([]bool, len(desired))
+
+	for i, d := range desired {
+		idx := slices.IndexFunc(remaining, func(c any) bool { return reflect.DeepEqual(c, d) })
+		if idx < 0 {
+			continue
+		}
+		aligned[i] = remaining[idx]
+		matched[i] = true
+		remaining = slices.Delete(remaining, idx, idx+1)
+	}
+
+	for i := range desired {
+		if matched[i] || len(remaining) == 0 {
+			continue
+		}
+		aligned[i] = remaining[0]
+		remaining = remaining[1:]
+	}
+
+	return append(aligned, remaining...)
+}
+
+// escapePathSegment escapes a key according to the JSON Pointer spec (RFC 6901)
+func escapePathSegment(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
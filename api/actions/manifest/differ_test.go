@@ -0,0 +1,285 @@
+package manifest_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Differ", func() {
+	var (
+		differ  manifest.Differ
+		current payloads.ManifestApplication
+		desired payloads.ManifestApplication
+		diff    []manifest.DiffEntry
+	)
+
+	BeforeEach(func() {
+		differ = manifest.NewDiffer()
+		current = payloads.ManifestApplication{
+			Name: "my-app",
+			Env:  map[string]string{"FOO": "foo"},
+			Processes: []payloads.ManifestApplicationProcess{{
+				Type:      "web",
+				Instances: tools.PtrTo(1),
+				Memory:    tools.PtrTo("1024M"),
+			}, {
+				Type:      "worker",
+				Instances: tools.PtrTo(2),
+			}},
+			Routes: []payloads.ManifestRoute{
+				{Route: tools.PtrTo("a.example.com")},
+				{Route: tools.PtrTo("b.example.com")},
+			},
+		}
+		desired = current
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		diff, err = differ.Diff(1, current, desired)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns an empty diff for identical apps", func() {
+		Expect(diff).To(BeEmpty())
+	})
+
+	When("a value changes", func() {
+		BeforeEach(func() {
+			desired.Env = map[string]string{"FOO": "bar"}
+		})
+
+		It("returns a replace entry", func() {
+			Expect(diff).To(ConsistOf(manifest.DiffEntry{
+				Op:    "replace",
+				Path:  "/applications/1/env/FOO",
+				Was:   "foo",
+				Value: "bar",
+			}))
+		})
+	})
+
+	When("a value is added", func() {
+		BeforeEach(func() {
+			desired.Env = map[string]string{"FOO": "foo", "BAR": "bar"}
+		})
+
+		It("returns an add entry", func() {
+			Expect(diff).To(ConsistOf(manifest.DiffEntry{
+				Op:    "add",
+				Path:  "/applications/1/env/BAR",
+				Value: "bar",
+			}))
+		})
+	})
+
+	When("a key is not set in the desired manifest", func() {
+		BeforeEach(func() {
+			desired.Env = nil
+		})
+
+		It("ignores it", func() {
+			Expect(diff).To(BeEmpty())
+		})
+	})
+
+	When("the memory is expressed in different units", func() {
+		BeforeEach(func() {
+			desired.Processes = []payloads.ManifestApplicationProcess{{
+				Type:      "web",
+				Instances: tools.PtrTo(1),
+				Memory:    tools.PtrTo("1G"),
+			}}
+		})
+
+		It("considers the values equal", func() {
+			Expect(diff).To(BeEmpty())
+		})
+	})
+
+	When("processes are reordered and changed", func() {
+		BeforeEach(func() {
+			desired.Processes = []payloads.ManifestApplicationProcess{{
+				Type:      "worker",
+				Instances: tools.PtrTo(3),
+			}, {
+				Type:      "web",
+				Instances: tools.PtrTo(1),
+				Memory:    tools.PtrTo("512M"),
+			}}
+		})
+
+		It("matches processes by type", func() {
+			Expect(diff).To(ConsistOf(
+				manifest.DiffEntry{Op: "replace", Path: "/applications/1/processes/0/instances", Was: 2, Value: 3},
+				manifest.DiffEntry{Op: "replace", Path: "/applications/1/processes/1/memory", Was: "1024M", Value: "512M"},
+			))
+		})
+	})
+
+	When("a process is added", func() {
+		BeforeEach(func() {
+			desired.Processes = append(desired.Processes, payloads.ManifestApplicationProcess{
+				Type:      "clock",
+				Instances: tools.PtrTo(1),
+			})
+		})
+
+		It("returns an add entry for the process", func() {
+			Expect(diff).To(ConsistOf(manifest.DiffEntry{
+				Op:    "add",
+				Path:  "/applications/1/processes/2",
+				Value: map[string]any{"type": "clock", "instances": 1},
+			}))
+		})
+	})
+
+	When("routes are reordered", func() {
+		BeforeEach(func() {
+			desired.Routes = []payloads.ManifestRoute{
+				{Route: tools.PtrTo("b.example.com")},
+				{Route: tools.PtrTo("a.example.com")},
+			}
+		})
+
+		It("returns an empty diff", func() {
+			Expect(diff).To(BeEmpty())
+		})
+	})
+
+	When("a route is added", func() {
+		BeforeEach(func() {
+			desired.Routes = []payloads.ManifestRoute{
+				{Route: tools.PtrTo("c.example.com")},
+				{Route: tools.PtrTo("a.example.com")},
+				{Route: tools.PtrTo("b.example.com")},
+			}
+		})
+
+		It("returns an add entry", func() {
+			Expect(diff).To(ConsistOf(manifest.DiffEntry{
+				Op:    "add",
+				Path:  "/applications/1/routes/0",
+				Value: map[string]any{"route": "c.example.com"},
+			}))
+		})
+	})
+
+	When("a route is removed", func() {
+		BeforeEach(func() {
+			desired.Routes = []payloads.ManifestRoute{
+				{Route: tools.PtrTo("b.example.com")},
+			}
+		})
+
+		It("returns a remove entry", func() {
+			Expect(diff).To(ConsistOf(manifest.DiffEntry{
+				Op:   "remove",
+				Path: "/applications/1/routes/1",
+				Was:  map[string]any{"route": "a.example.com"},
+			}))
+		})
+	})
+
+	When("a key contains special characters", func() {
+		BeforeEach(func() {
+			desired.Env = map[string]string{"FOO": "foo", "a/b~c": "x"}
+		})
+
+		It("escapes the path", func() {
+			Expect(diff).To(ConsistOf(manifest.DiffEntry{
+				Op:    "add",
+				Path:  "/applications/1/env/a~1b~0c",
+				Value: "x",
+			}))
+		})
+	})
+})
+
+var _ = Describe("AppState.ToManifestApplication", func() {
+	var appState manifest.AppState
+
+	BeforeEach(func() {
+		appState = manifest.AppState{
+			App: repositories.AppRecord{
+				GUID:        "app-guid",
+				Name:        "my-app",
+				Labels:      map[string]string{"l": "v"},
+				Annotations: map[string]string{"a": "v"},
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Buildpacks: []string{"java"}},
+				},
+			},
+			EnvVars: map[string]string{"FOO": "bar"},
+			Processes: map[string]repositories.ProcessRecord{
+				"worker": {Type: "worker", DesiredInstances: 2, MemoryMB: 256},
+				"web": {
+					Type:             "web",
+					DesiredInstances: 1,
+					MemoryMB:         1024,
+					DiskQuotaMB:      2048,
+					Command:          "start",
+					HealthCheck: repositories.HealthCheck{
+						Type: "http",
+						Data: repositories.HealthCheckData{HTTPEndpoint: "/health"},
+					},
+				},
+			},
+			Routes: map[string]repositories.RouteRecord{
+				"b.example.com": {},
+				"a.example.com": {},
+			},
+			ServiceBindings: map[string]repositories.ServiceBindingRecord{
+				"my-service": {Name: tools.PtrTo("my-binding")},
+			},
+		}
+	})
+
+	It("represents the app state as a manifest application", func() {
+		Expect(appState.ToManifestApplication()).To(Equal(payloads.ManifestApplication{
+			Name:       "my-app",
+			Env:        map[string]string{"FOO": "bar"},
+			Buildpacks: []string{"java"},
+			Metadata: payloads.MetadataPatch{
+				Labels:      map[string]*string{"l": tools.PtrTo("v")},
+				Annotations: map[string]*string{"a": tools.PtrTo("v")},
+			},
+			Processes: []payloads.ManifestApplicationProcess{{
+				Type:                    "web",
+				Instances:               tools.PtrTo(1),
+				Memory:                  tools.PtrTo("1024M"),
+				DiskQuota:               tools.PtrTo("2048M"),
+				Command:                 tools.PtrTo("start"),
+				HealthCheckType:         tools.PtrTo("http"),
+				HealthCheckHTTPEndpoint: tools.PtrTo("/health"),
+			}, {
+				Type:      "worker",
+				Instances: tools.PtrTo(2),
+				Memory:    tools.PtrTo("256M"),
+			}},
+			Routes: []payloads.ManifestRoute{
+				{Route: tools.PtrTo("a.example.com")},
+				{Route: tools.PtrTo("b.example.com")},
+			},
+			Services: []payloads.ManifestApplicationService{{
+				Name:        "my-service",
+				BindingName: tools.PtrTo("my-binding"),
+			}},
+		}))
+	})
+
+	When("the app does not exist", func() {
+		BeforeEach(func() {
+			appState = manifest.AppState{}
+		})
+
+		It("returns an empty manifest application", func() {
+			Expect(appState.ToManifestApplication()).To(Equal(payloads.ManifestApplication{}))
+		})
+	})
+})
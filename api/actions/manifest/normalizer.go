@@ -33,6 +33,7 @@ func (n Normalizer) Normalize(appInfo payloads.ManifestApplication, appState App
 		Metadata:   appInfo.Metadata,
 		Services:   appInfo.Services,
 		Docker:     appInfo.Docker,
+		Sidecars:   appInfo.Sidecars,
 	}
 }
 
@@ -50,6 +50,11 @@ var _ = Describe("Normalizer", func() {
 				Name:        "my-service",
 				BindingName: tools.PtrTo("my-binding"),
 			}},
+			Sidecars: []payloads.ManifestApplicationSidecar{{
+				Name:         "my-sidecar",
+				Command:      "run-sidecar",
+				ProcessTypes: []string{"web"},
+			}},
 		}
 		appState = manifest.AppState{
 			App:       repositories.AppRecord{},
@@ -74,6 +79,7 @@ var _ = Describe("Normalizer", func() {
 				Name:        "my-service",
 				BindingName: tools.PtrTo("my-binding"),
 			}}))
+			Expect(normalizedAppInfo.Sidecars).To(Equal(appInfo.Sidecars))
 		})
 
 		When("no-route is set", func() {
@@ -20,13 +20,16 @@ type StateCollector struct {
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
+	sidecarRepo         shared.CFSidecarRepository
 }
 
 type AppState struct {
 	App             repositories.AppRecord
+	EnvVars         map[string]string
 	Processes       map[string]repositories.ProcessRecord
 	Routes          map[string]repositories.RouteRecord
 	ServiceBindings map[string]repositories.ServiceBindingRecord
+	Sidecars        map[string]repositories.SidecarRecord
 }
 
 func NewStateCollector(
@@ -36,6 +39,7 @@ func NewStateCollector(
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
+	sidecarRepo shared.CFSidecarRepository,
 ) StateCollector {
 	return StateCollector{
 		appRepo:             appRepo,
@@ -44,6 +48,7 @@ func NewStateCollector(
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
+		sidecarRepo:         sidecarRepo,
 	}
 }
 
@@ -56,6 +61,11 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 		return AppState{}, apierrors.ForbiddenAsNotFound(err)
 	}
 
+	appEnv, err := s.appRepo.GetAppEnv(ctx, authInfo, appRecord.GUID)
+	if err != nil {
+		return AppState{}, err
+	}
+
 	existingProcesses, err := s.collectProcesses(ctx, authInfo, appRecord.GUID, spaceGUID)
 	if err != nil {
 		return AppState{}, err
@@ -71,11 +81,18 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 		return AppState{}, err
 	}
 
+	existingSidecars, err := s.collectSidecars(ctx, authInfo, appRecord.GUID, spaceGUID)
+	if err != nil {
+		return AppState{}, err
+	}
+
 	return AppState{
 		App:             appRecord,
+		EnvVars:         appEnv.EnvironmentVariables,
 		Processes:       existingProcesses,
 		Routes:          existingAppRoutes,
 		ServiceBindings: existingServiceBindings,
+		Sidecars:        existingSidecars,
 	}, nil
 }
 
@@ -118,7 +135,7 @@ func (s StateCollector) collectServiceBindings(ctx context.Context, authInfo aut
 	}
 
 	serviceInstanceGUIDSet := map[string]bool{}
-	for _, sb := range serviceBindings {
+	for _, sb := range serviceBindings.Records {
 		serviceInstanceGUIDSet[sb.ServiceInstanceGUID] = true
 	}
 
@@ -133,7 +150,7 @@ func (s StateCollector) collectServiceBindings(ctx context.Context, authInfo aut
 	}
 
 	existingServiceBindings := map[string]repositories.ServiceBindingRecord{}
-	for _, sb := range serviceBindings {
+	for _, sb := range serviceBindings.Records {
 		n, ok := serviceInstanceGUID2Name[sb.ServiceInstanceGUID]
 		if !ok {
 			return nil, fmt.Errorf("no service instance found with guid %q for service binding %q", sb.ServiceInstanceGUID, sb.GUID)
@@ -144,6 +161,23 @@ func (s StateCollector) collectServiceBindings(ctx context.Context, authInfo aut
 	return existingServiceBindings, nil
 }
 
+func (s StateCollector) collectSidecars(ctx context.Context, authInfo authorization.Info, appGUID, spaceGUID string) (map[string]repositories.SidecarRecord, error) {
+	sidecars, err := s.sidecarRepo.ListSidecars(ctx, authInfo, repositories.ListSidecarsMessage{
+		AppGUID:   appGUID,
+		SpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	existingSidecars := map[string]repositories.SidecarRecord{}
+	for _, sc := range sidecars {
+		existingSidecars[sc.Name] = sc
+	}
+
+	return existingSidecars, nil
+}
+
 func unsplitRoute(route repositories.RouteRecord) string {
 	return path.Join(fmt.Sprintf("%s.%s", route.Host, route.Domain.Name), route.Path)
 }
@@ -22,6 +22,7 @@ var _ = Describe("StateCollector", func() {
 		routeRepo           *fake.CFRouteRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
+		sidecarRepo         *fake.CFSidecarRepository
 		stateCollector      manifest.StateCollector
 		appState            manifest.AppState
 		collectStateErr     error
@@ -34,6 +35,7 @@ var _ = Describe("StateCollector", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
+		sidecarRepo = new(fake.CFSidecarRepository)
 		stateCollector = manifest.NewStateCollector(
 			appRepo,
 			domainRepo,
@@ -41,6 +43,7 @@ var _ = Describe("StateCollector", func() {
 			routeRepo,
 			serviceInstanceRepo,
 			serviceBindingRepo,
+			sidecarRepo,
 		)
 	})
 
@@ -100,6 +103,36 @@ var _ = Describe("StateCollector", func() {
 		})
 	})
 
+	Describe("env vars", func() {
+		BeforeEach(func() {
+			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{GUID: "app-guid"}, nil)
+			appRepo.GetAppEnvReturns(repositories.AppEnvRecord{
+				EnvironmentVariables: map[string]string{"FOO": "bar"},
+			}, nil)
+		})
+
+		It("gets the app env", func() {
+			Expect(appRepo.GetAppEnvCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppEnvArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+		})
+
+		It("sets the app env vars in the state", func() {
+			Expect(collectStateErr).NotTo(HaveOccurred())
+			Expect(appState.EnvVars).To(Equal(map[string]string{"FOO": "bar"}))
+		})
+
+		When("getting the app env fails", func() {
+			BeforeEach(func() {
+				appRepo.GetAppEnvReturns(repositories.AppEnvRecord{}, errors.New("get-env-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(collectStateErr).To(MatchError("get-env-err"))
+			})
+		})
+	})
+
 	Describe("processes", func() {
 		BeforeEach(func() {
 			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{GUID: "app-guid"}, nil)
@@ -145,6 +178,43 @@ var _ = Describe("StateCollector", func() {
 		})
 	})
 
+	Describe("sidecars", func() {
+		BeforeEach(func() {
+			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{GUID: "app-guid"}, nil)
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "log-shipper-guid", Name: "log-shipper"},
+				{GUID: "config-agent-guid", Name: "config-agent"},
+			}, nil)
+		})
+
+		It("lists the app sidecars", func() {
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, _, listMsg := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(listMsg).To(Equal(repositories.ListSidecarsMessage{
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+			}))
+		})
+
+		It("constructs the sidecar map using the sidecar name", func() {
+			Expect(collectStateErr).NotTo(HaveOccurred())
+			Expect(appState.Sidecars).To(Equal(map[string]repositories.SidecarRecord{
+				"log-shipper":  {GUID: "log-shipper-guid", Name: "log-shipper"},
+				"config-agent": {GUID: "config-agent-guid", Name: "config-agent"},
+			}))
+		})
+
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(nil, errors.New("list-sidecars-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(collectStateErr).To(MatchError("list-sidecars-error"))
+			})
+		})
+	})
+
 	Describe("routes", func() {
 		var routes []repositories.RouteRecord
 
@@ -204,7 +274,7 @@ var _ = Describe("StateCollector", func() {
 				{GUID: "sb1-guid", ServiceInstanceGUID: "s-guid"},
 				{GUID: "sb2-guid", ServiceInstanceGUID: "s-guid"},
 			}
-			serviceBindingRepo.ListServiceBindingsReturns(serviceBindings, nil)
+			serviceBindingRepo.ListServiceBindingsReturns(repositories.ListResult[repositories.ServiceBindingRecord]{Records: serviceBindings}, nil)
 		})
 
 		It("lists the services for the service bindings", func() {
@@ -231,7 +301,7 @@ var _ = Describe("StateCollector", func() {
 
 		When("listing the service bindings fails", func() {
 			BeforeEach(func() {
-				serviceBindingRepo.ListServiceBindingsReturns(nil, errors.New("list-sb-error"))
+				serviceBindingRepo.ListServiceBindingsReturns(repositories.ListResult[repositories.ServiceBindingRecord]{}, errors.New("list-sb-error"))
 			})
 
 			It("returns the error", func() {
@@ -25,6 +25,7 @@ var _ = Describe("ApplyManifest", func() {
 		stateCollector   *fake.StateCollector
 		normalizer       *fake.Normalizer
 		applier          *fake.Applier
+		differ           *fake.Differ
 
 		appManifest payloads.Manifest
 	)
@@ -34,6 +35,7 @@ var _ = Describe("ApplyManifest", func() {
 		stateCollector = new(fake.StateCollector)
 		normalizer = new(fake.Normalizer)
 		applier = new(fake.Applier)
+		differ = new(fake.Differ)
 
 		stateCollector.CollectStateReturnsOnCall(0, manifest.AppState{
 			App: repositories.AppRecord{
@@ -63,7 +65,7 @@ var _ = Describe("ApplyManifest", func() {
 			}},
 		}
 
-		manifestAction = actions.NewManifest(domainRepository, "my.domain", stateCollector, normalizer, applier)
+		manifestAction = actions.NewManifest(domainRepository, "my.domain", stateCollector, normalizer, applier, differ)
 	})
 
 	JustBeforeEach(func() {
@@ -144,3 +146,111 @@ var _ = Describe("ApplyManifest", func() {
 		})
 	})
 })
+
+var _ = Describe("DiffManifest", func() {
+	var (
+		manifestAction *actions.Manifest
+		diff           []manifest.DiffEntry
+		diffErr        error
+
+		stateCollector *fake.StateCollector
+		normalizer     *fake.Normalizer
+		differ         *fake.Differ
+
+		appManifest payloads.Manifest
+	)
+
+	BeforeEach(func() {
+		stateCollector = new(fake.StateCollector)
+		normalizer = new(fake.Normalizer)
+		differ = new(fake.Differ)
+
+		stateCollector.CollectStateReturnsOnCall(0, manifest.AppState{
+			App: repositories.AppRecord{
+				GUID: "app1-guid",
+				Name: "app1",
+			},
+		}, nil)
+		stateCollector.CollectStateReturnsOnCall(1, manifest.AppState{}, nil)
+
+		normalizer.NormalizeStub = func(appInfo payloads.ManifestApplication, _ manifest.AppState) payloads.ManifestApplication {
+			appInfo.Name = "normalized-" + appInfo.Name
+			return appInfo
+		}
+
+		differ.DiffReturnsOnCall(0, []manifest.DiffEntry{{
+			Op:    "replace",
+			Path:  "/applications/0/env/FOO",
+			Was:   "bar",
+			Value: "baz",
+		}}, nil)
+		differ.DiffReturnsOnCall(1, []manifest.DiffEntry{{
+			Op:    "add",
+			Path:  "/applications/1/name",
+			Value: "normalized-app2",
+		}}, nil)
+
+		appManifest = payloads.Manifest{
+			Applications: []payloads.ManifestApplication{{
+				Name: "app1",
+			}, {
+				Name: "app2",
+			}},
+		}
+
+		manifestAction = actions.NewManifest(new(reposfake.CFDomainRepository), "my.domain", stateCollector, normalizer, new(fake.Applier), differ)
+	})
+
+	JustBeforeEach(func() {
+		diff, diffErr = manifestAction.Diff(context.Background(), authorization.Info{}, "space-guid", appManifest)
+	})
+
+	It("diffs the normalized manifest against the normalized app state", func() {
+		Expect(diffErr).NotTo(HaveOccurred())
+
+		Expect(stateCollector.CollectStateCallCount()).To(Equal(2))
+		_, _, actualAppName, actualSpaceGUID := stateCollector.CollectStateArgsForCall(0)
+		Expect(actualAppName).To(Equal("app1"))
+		Expect(actualSpaceGUID).To(Equal("space-guid"))
+		_, _, actualAppName, actualSpaceGUID = stateCollector.CollectStateArgsForCall(1)
+		Expect(actualAppName).To(Equal("app2"))
+		Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+		Expect(differ.DiffCallCount()).To(Equal(2))
+		actualIndex, actualCurrent, actualDesired := differ.DiffArgsForCall(0)
+		Expect(actualIndex).To(Equal(0))
+		Expect(actualCurrent.Name).To(Equal("normalized-app1"))
+		Expect(actualDesired.Name).To(Equal("normalized-app1"))
+		actualIndex, actualCurrent, actualDesired = differ.DiffArgsForCall(1)
+		Expect(actualIndex).To(Equal(1))
+		Expect(actualCurrent.Name).To(Equal("normalized-"))
+		Expect(actualDesired.Name).To(Equal("normalized-app2"))
+	})
+
+	It("returns the diffs of all apps", func() {
+		Expect(diff).To(Equal([]manifest.DiffEntry{
+			{Op: "replace", Path: "/applications/0/env/FOO", Was: "bar", Value: "baz"},
+			{Op: "add", Path: "/applications/1/name", Value: "normalized-app2"},
+		}))
+	})
+
+	When("collecting the app state fails", func() {
+		BeforeEach(func() {
+			stateCollector.CollectStateReturnsOnCall(0, manifest.AppState{}, errors.New("collect-state-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(diffErr).To(MatchError("collect-state-err"))
+		})
+	})
+
+	When("diffing fails", func() {
+		BeforeEach(func() {
+			differ.DiffReturnsOnCall(0, nil, errors.New("diff-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(diffErr).To(MatchError("diff-err"))
+		})
+	})
+})
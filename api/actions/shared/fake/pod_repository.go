@@ -28,6 +28,22 @@ type PodRepository struct {
 		result1 []repositories.LogRecord
 		result2 error
 	}
+	StreamRuntimeLogsForAppStub        func(context.Context, logr.Logger, authorization.Info, repositories.StreamRuntimeLogsMessage) (<-chan repositories.LogRecord, error)
+	streamRuntimeLogsForAppMutex       sync.RWMutex
+	streamRuntimeLogsForAppArgsForCall []struct {
+		arg1 context.Context
+		arg2 logr.Logger
+		arg3 authorization.Info
+		arg4 repositories.StreamRuntimeLogsMessage
+	}
+	streamRuntimeLogsForAppReturns struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}
+	streamRuntimeLogsForAppReturnsOnCall map[int]struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -99,11 +115,80 @@ func (fake *PodRepository) GetRuntimeLogsForAppReturnsOnCall(i int, result1 []re
 	}{result1, result2}
 }
 
+func (fake *PodRepository) StreamRuntimeLogsForApp(arg1 context.Context, arg2 logr.Logger, arg3 authorization.Info, arg4 repositories.StreamRuntimeLogsMessage) (<-chan repositories.LogRecord, error) {
+	fake.streamRuntimeLogsForAppMutex.Lock()
+	ret, specificReturn := fake.streamRuntimeLogsForAppReturnsOnCall[len(fake.streamRuntimeLogsForAppArgsForCall)]
+	fake.streamRuntimeLogsForAppArgsForCall = append(fake.streamRuntimeLogsForAppArgsForCall, struct {
+		arg1 context.Context
+		arg2 logr.Logger
+		arg3 authorization.Info
+		arg4 repositories.StreamRuntimeLogsMessage
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.StreamRuntimeLogsForAppStub
+	fakeReturns := fake.streamRuntimeLogsForAppReturns
+	fake.recordInvocation("StreamRuntimeLogsForApp", []interface{}{arg1, arg2, arg3, arg4})
+	fake.streamRuntimeLogsForAppMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PodRepository) StreamRuntimeLogsForAppCallCount() int {
+	fake.streamRuntimeLogsForAppMutex.RLock()
+	defer fake.streamRuntimeLogsForAppMutex.RUnlock()
+	return len(fake.streamRuntimeLogsForAppArgsForCall)
+}
+
+func (fake *PodRepository) StreamRuntimeLogsForAppCalls(stub func(context.Context, logr.Logger, authorization.Info, repositories.StreamRuntimeLogsMessage) (<-chan repositories.LogRecord, error)) {
+	fake.streamRuntimeLogsForAppMutex.Lock()
+	defer fake.streamRuntimeLogsForAppMutex.Unlock()
+	fake.StreamRuntimeLogsForAppStub = stub
+}
+
+func (fake *PodRepository) StreamRuntimeLogsForAppArgsForCall(i int) (context.Context, logr.Logger, authorization.Info, repositories.StreamRuntimeLogsMessage) {
+	fake.streamRuntimeLogsForAppMutex.RLock()
+	defer fake.streamRuntimeLogsForAppMutex.RUnlock()
+	argsForCall := fake.streamRuntimeLogsForAppArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *PodRepository) StreamRuntimeLogsForAppReturns(result1 <-chan repositories.LogRecord, result2 error) {
+	fake.streamRuntimeLogsForAppMutex.Lock()
+	defer fake.streamRuntimeLogsForAppMutex.Unlock()
+	fake.StreamRuntimeLogsForAppStub = nil
+	fake.streamRuntimeLogsForAppReturns = struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodRepository) StreamRuntimeLogsForAppReturnsOnCall(i int, result1 <-chan repositories.LogRecord, result2 error) {
+	fake.streamRuntimeLogsForAppMutex.Lock()
+	defer fake.streamRuntimeLogsForAppMutex.Unlock()
+	fake.StreamRuntimeLogsForAppStub = nil
+	if fake.streamRuntimeLogsForAppReturnsOnCall == nil {
+		fake.streamRuntimeLogsForAppReturnsOnCall = make(map[int]struct {
+			result1 <-chan repositories.LogRecord
+			result2 error
+		})
+	}
+	fake.streamRuntimeLogsForAppReturnsOnCall[i] = struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PodRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getRuntimeLogsForAppMutex.RLock()
 	defer fake.getRuntimeLogsForAppMutex.RUnlock()
+	fake.streamRuntimeLogsForAppMutex.RLock()
+	defer fake.streamRuntimeLogsForAppMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
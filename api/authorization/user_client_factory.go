@@ -22,6 +22,7 @@ import (
 type UserK8sClientFactory interface {
 	BuildClient(Info) (client.WithWatch, error)
 	BuildK8sClient(info Info) (k8sclient.Interface, error)
+	BuildRESTConfig(info Info) (*rest.Config, error)
 }
 
 type UnprivilegedClientFactory struct {
@@ -39,28 +40,9 @@ func NewUnprivilegedClientFactory(config *rest.Config, mapper meta.RESTMapper, b
 }
 
 func (f UnprivilegedClientFactory) BuildClient(authInfo Info) (client.WithWatch, error) {
-	config := rest.CopyConfig(f.config)
-
-	switch strings.ToLower(authInfo.Scheme()) {
-	case BearerScheme:
-		config.BearerToken = authInfo.Token
-
-	case CertScheme:
-		certBlock, rst := pem.Decode(authInfo.CertData)
-		if certBlock == nil {
-			return nil, fmt.Errorf("failed to decode cert PEM")
-		}
-
-		keyBlock, _ := pem.Decode(rst)
-		if keyBlock == nil {
-			return nil, fmt.Errorf("failed to decode key PEM")
-		}
-
-		config.CertData = pem.EncodeToMemory(certBlock)
-		config.KeyData = pem.EncodeToMemory(keyBlock)
-
-	default:
-		return nil, apierrors.NewNotAuthenticatedError(errors.New("unsupported Authorization header scheme"))
+	config, err := f.BuildRESTConfig(authInfo)
+	if err != nil {
+		return nil, err
 	}
 
 	userClient, err := client.NewWithWatch(config, client.Options{
@@ -91,6 +73,23 @@ func isForbidden(err error) bool {
 }
 
 func (f UnprivilegedClientFactory) BuildK8sClient(authInfo Info) (k8sclient.Interface, error) {
+	config, err := f.BuildRESTConfig(authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	userK8sClient, err := k8sclient.NewForConfig(config)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, "")
+	}
+
+	return userK8sClient, nil
+}
+
+// BuildRESTConfig returns a copy of the API rest config authenticating as
+// the user, for clients that cannot be built from a client.Client, such as
+// streaming pod exec connections
+func (f UnprivilegedClientFactory) BuildRESTConfig(authInfo Info) (*rest.Config, error) {
 	config := rest.CopyConfig(f.config)
 
 	switch strings.ToLower(authInfo.Scheme()) {
@@ -115,10 +114,5 @@ func (f UnprivilegedClientFactory) BuildK8sClient(authInfo Info) (k8sclient.Inte
 		return nil, apierrors.NewNotAuthenticatedError(errors.New("unsupported Authorization header scheme"))
 	}
 
-	userK8sClient, err := k8sclient.NewForConfig(config)
-	if err != nil {
-		return nil, apierrors.FromK8sError(err, "")
-	}
-
-	return userK8sClient, nil
+	return config, nil
 }
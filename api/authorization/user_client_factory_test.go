@@ -247,4 +247,49 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 			})
 		})
 	})
+
+	Describe("BuildRESTConfig", func() {
+		var (
+			restConfig *rest.Config
+			buildErr   error
+		)
+
+		JustBeforeEach(func() {
+			restConfig, buildErr = clientFactory.BuildRESTConfig(authInfo)
+		})
+
+		When("the user authenticates with a token", func() {
+			BeforeEach(func() {
+				authInfo.Token = "a-token"
+			})
+
+			It("returns a config with the bearer token only", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(restConfig.Host).To(Equal(k8sConfig.Host))
+				Expect(restConfig.BearerToken).To(Equal("a-token"))
+				Expect(restConfig.CertData).To(BeEmpty())
+				Expect(restConfig.KeyData).To(BeEmpty())
+			})
+		})
+
+		When("the user authenticates with a certificate", func() {
+			BeforeEach(func() {
+				cert, key := testhelpers.ObtainClientCert(testEnv, userName)
+				authInfo.CertData = testhelpers.JoinCertAndKey(cert, key)
+			})
+
+			It("returns a config with the client certificate only", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(restConfig.BearerToken).To(BeEmpty())
+				Expect(restConfig.CertData).NotTo(BeEmpty())
+				Expect(restConfig.KeyData).NotTo(BeEmpty())
+			})
+		})
+
+		When("auth info is empty", func() {
+			It("fails", func() {
+				Expect(buildErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+			})
+		})
+	})
 })
@@ -3,6 +3,8 @@ package config
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/tools"
@@ -49,6 +51,30 @@ type (
 		LogLevel        zapcore.Level `yaml:"logLevel"`
 
 		ExperimentalManagedServicesEnabled bool `yaml:"experimentalManagedServicesEnabled"`
+
+		SSHProxy SSHProxyConfig `yaml:"sshProxy"`
+
+		TCPRouterGroup RouterGroupConfig `yaml:"tcpRouterGroup"`
+	}
+
+	// SSHProxyConfig configures the proxy granting SSH access to app instances
+	SSHProxyConfig struct {
+		Enabled bool `yaml:"enabled"`
+		// The port the proxy listens on
+		Port int `yaml:"port"`
+		// The host:port clients use to reach the proxy, advertised in the root info
+		ExternalAddress string `yaml:"externalAddress"`
+		// Path to the PEM encoded private host key of the proxy
+		HostKeyPath string `yaml:"hostKeyPath"`
+	}
+
+	// RouterGroupConfig configures the router group tcp domains are assigned
+	// to. TCP routing is disabled when the router group has no name
+	RouterGroupConfig struct {
+		Name string `yaml:"name"`
+		// The ports tcp routes are allocated from, as a comma separated list
+		// of ports and port ranges, e.g. "1024-1033,2000"
+		ReservablePorts string `yaml:"reservablePorts"`
 	}
 
 	RoleLevel string
@@ -128,6 +154,22 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	if c.SSHProxy.Enabled {
+		if c.SSHProxy.ExternalAddress == "" {
+			return errors.New("SSHProxy requires a value for ExternalAddress")
+		}
+
+		if c.SSHProxy.HostKeyPath == "" {
+			return errors.New("SSHProxy requires a value for HostKeyPath")
+		}
+	}
+
+	if c.TCPRouterGroup.Enabled() {
+		if _, err := parsePorts(c.TCPRouterGroup.ReservablePorts); err != nil {
+			return fmt.Errorf("invalid TCPRouterGroup reservable ports: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -157,3 +199,54 @@ func (c *APIConfig) GenerateK8sClientConfig(k8sClientConfig *rest.Config) *rest.
 
 	return k8sClientConfig
 }
+
+func (c RouterGroupConfig) Enabled() bool {
+	return c.Name != ""
+}
+
+// Ports returns the reservable ports of the router group. The reservable
+// ports are validated when the config is loaded.
+func (c RouterGroupConfig) Ports() []int {
+	ports, _ := parsePorts(c.ReservablePorts)
+	return ports
+}
+
+func parsePorts(reservablePorts string) ([]int, error) {
+	ports := []int{}
+
+	for _, portRange := range strings.Split(reservablePorts, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(portRange), "-", 2)
+
+		first, err := parsePort(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+
+		last := first
+		if len(bounds) == 2 {
+			last, err = parsePort(bounds[1])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if first > last {
+			return nil, fmt.Errorf("invalid port range %q", portRange)
+		}
+
+		for port := first; port <= last; port++ {
+			ports = append(ports, port)
+		}
+	}
+
+	return ports, nil
+}
+
+func parsePort(port string) (int, error) {
+	p, err := strconv.Atoi(strings.TrimSpace(port))
+	if err != nil || p < 1 || p > 65535 {
+		return 0, fmt.Errorf("invalid port %q", port)
+	}
+
+	return p, nil
+}
@@ -197,4 +197,88 @@ var _ = Describe("Config", func() {
 			Expect(cfg.ServerURL).To(Equal("https://api.foo:1234"))
 		})
 	})
+
+	When("the ssh proxy is enabled", func() {
+		BeforeEach(func() {
+			configMap["sshProxy"] = map[string]any{
+				"enabled":         true,
+				"port":            2222,
+				"externalAddress": "ssh.foo:2222",
+				"hostKeyPath":     "/etc/ssh-host-key/key",
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.SSHProxy).To(Equal(config.SSHProxyConfig{
+				Enabled:         true,
+				Port:            2222,
+				ExternalAddress: "ssh.foo:2222",
+				HostKeyPath:     "/etc/ssh-host-key/key",
+			}))
+		})
+
+		When("the external address is not set", func() {
+			BeforeEach(func() {
+				delete(configMap["sshProxy"].(map[string]any), "externalAddress")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("SSHProxy requires a value for ExternalAddress"))
+			})
+		})
+
+		When("the host key path is not set", func() {
+			BeforeEach(func() {
+				delete(configMap["sshProxy"].(map[string]any), "hostKeyPath")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("SSHProxy requires a value for HostKeyPath"))
+			})
+		})
+	})
+
+	When("the tcp router group is configured", func() {
+		BeforeEach(func() {
+			configMap["tcpRouterGroup"] = map[string]any{
+				"name":            "default-tcp",
+				"reservablePorts": "1024-1026, 2000",
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.TCPRouterGroup.Enabled()).To(BeTrue())
+			Expect(cfg.TCPRouterGroup.Name).To(Equal("default-tcp"))
+			Expect(cfg.TCPRouterGroup.Ports()).To(Equal([]int{1024, 1025, 1026, 2000}))
+		})
+
+		When("the reservable ports are invalid", func() {
+			BeforeEach(func() {
+				configMap["tcpRouterGroup"].(map[string]any)["reservablePorts"] = "1026-1024"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("invalid TCPRouterGroup reservable ports")))
+			})
+		})
+
+		When("a reservable port is out of range", func() {
+			BeforeEach(func() {
+				configMap["tcpRouterGroup"].(map[string]any)["reservablePorts"] = "70000"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring(`invalid port "70000"`)))
+			})
+		})
+	})
+
+	When("the tcp router group is not configured", func() {
+		It("disables tcp routing", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.TCPRouterGroup.Enabled()).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,18 @@
+// Package correlation carries the correlation ID of an API request in its
+// context, so that it can be recorded alongside the changes the request makes
+package correlation
+
+import "context"
+
+type key int
+
+var idKey key
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idKey, id)
+}
+
+func IDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(idKey).(string)
+	return id
+}
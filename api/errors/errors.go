@@ -370,6 +370,26 @@ func NewRollingDeployNotSupportedError(runnerName string) RollingDeployNotSuppor
 	}
 }
 
+type FeatureDisabledError struct {
+	apiError
+}
+
+func NewFeatureDisabledError(featureFlagName, customErrorMessage string) FeatureDisabledError {
+	detail := "Feature Disabled: " + featureFlagName
+	if customErrorMessage != "" {
+		detail = "Feature Disabled: " + customErrorMessage
+	}
+
+	return FeatureDisabledError{
+		apiError: apiError{
+			title:      "CF-FeatureDisabled",
+			detail:     detail,
+			code:       330002,
+			httpStatus: http.StatusForbidden,
+		},
+	}
+}
+
 func FromK8sError(err error, resourceType string) error {
 	if webhookValidationError, ok := validation.WebhookErrorToValidationError(err); ok {
 		return NewUnprocessableEntityError(err, webhookValidationError.GetMessage())
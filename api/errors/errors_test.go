@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "github.com/onsi/ginkgo/v2"
@@ -366,3 +367,35 @@ func (e testApiError) Error() string {
 func (e testApiError) Unwrap() error {
 	return nil
 }
+
+var _ = Describe("FeatureDisabledError", func() {
+	var (
+		err                apierrors.ApiError
+		customErrorMessage string
+	)
+
+	BeforeEach(func() {
+		customErrorMessage = ""
+	})
+
+	JustBeforeEach(func() {
+		err = apierrors.NewFeatureDisabledError("task_creation", customErrorMessage)
+	})
+
+	It("names the disabled feature flag", func() {
+		Expect(err.Title()).To(Equal("CF-FeatureDisabled"))
+		Expect(err.Code()).To(Equal(330002))
+		Expect(err.HttpStatus()).To(Equal(http.StatusForbidden))
+		Expect(err.Detail()).To(Equal("Feature Disabled: task_creation"))
+	})
+
+	When("the feature flag has a custom error message", func() {
+		BeforeEach(func() {
+			customErrorMessage = "ask your admin"
+		})
+
+		It("uses the custom error message", func() {
+			Expect(err.Detail()).To(Equal("Feature Disabled: ask your admin"))
+		})
+	})
+})
@@ -59,16 +59,20 @@ type CFAppRepository interface {
 }
 
 type App struct {
-	serverURL        url.URL
-	appRepo          CFAppRepository
-	dropletRepo      CFDropletRepository
-	processRepo      CFProcessRepository
-	processStats     ProcessStats
-	routeRepo        CFRouteRepository
-	domainRepo       CFDomainRepository
-	spaceRepo        CFSpaceRepository
-	packageRepo      CFPackageRepository
-	requestValidator RequestValidator
+	serverURL          url.URL
+	appRepo            CFAppRepository
+	dropletRepo        CFDropletRepository
+	processRepo        CFProcessRepository
+	processStats       ProcessStats
+	routeRepo          CFRouteRepository
+	domainRepo         CFDomainRepository
+	spaceRepo          CFSpaceRepository
+	packageRepo        CFPackageRepository
+	stackRepo          CFStackRepository
+	requestValidator   RequestValidator
+	auditEventRecorder AuditEventRecorder
+	featureFlagChecker FeatureFlagChecker
+	sshEnabled         bool
 }
 
 func NewApp(
@@ -81,19 +85,27 @@ func NewApp(
 	domainRepo CFDomainRepository,
 	spaceRepo CFSpaceRepository,
 	packageRepo CFPackageRepository,
+	stackRepo CFStackRepository,
 	requestValidator RequestValidator,
+	auditEventRecorder AuditEventRecorder,
+	featureFlagChecker FeatureFlagChecker,
+	sshEnabled bool,
 ) *App {
 	return &App{
-		serverURL:        serverURL,
-		appRepo:          appRepo,
-		dropletRepo:      dropletRepo,
-		processRepo:      processRepo,
-		processStats:     processStatsFetcher,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		spaceRepo:        spaceRepo,
-		packageRepo:      packageRepo,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		appRepo:            appRepo,
+		dropletRepo:        dropletRepo,
+		processRepo:        processRepo,
+		processStats:       processStatsFetcher,
+		routeRepo:          routeRepo,
+		domainRepo:         domainRepo,
+		spaceRepo:          spaceRepo,
+		packageRepo:        packageRepo,
+		stackRepo:          stackRepo,
+		requestValidator:   requestValidator,
+		auditEventRecorder: auditEventRecorder,
+		featureFlagChecker: featureFlagChecker,
+		sshEnabled:         sshEnabled,
 	}
 }
 
@@ -128,6 +140,23 @@ func (h *App) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if payload.Lifecycle != nil && payload.Lifecycle.Type == string(korifiv1alpha1.DockerPackage) {
+		if err = h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, korifiv1alpha1.FeatureFlagDiegoDocker); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "docker apps are disabled", "App Name", payload.Name)
+		}
+	}
+
+	if payload.Lifecycle != nil && payload.Lifecycle.Data != nil && payload.Lifecycle.Data.Stack != "" {
+		stacks, err := h.stackNames(r.Context(), authInfo)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch stacks", "App Name", payload.Name)
+		}
+
+		if err = payload.Lifecycle.ValidateStack(stacks); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "invalid stack", "App Name", payload.Name)
+		}
+	}
+
 	appRecord, err := h.appRepo.CreateApp(r.Context(), authInfo, payload.ToAppCreateMessage())
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create app", "App Name", payload.Name)
@@ -142,6 +171,10 @@ func (h *App) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create web process", "App Name", payload.Name)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, appAuditEvent(repositories.AuditEventTypeAppCreate, appRecord, map[string]any{
+		"request": map[string]any{"name": payload.Name},
+	}))
+
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForApp(appRecord, h.serverURL)), nil
 }
 
@@ -237,6 +270,10 @@ func (h *App) setCurrentDroplet(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Error setting current droplet")
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, appAuditEvent(repositories.AuditEventTypeAppDropletMapped, app, map[string]any{
+		"request": map[string]any{"droplet_guid": dropletGUID},
+	}))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForCurrentDroplet(currentDroplet, h.serverURL)), nil
 }
 
@@ -282,6 +319,8 @@ func (h *App) start(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to start app", "AppGUID", appGUID)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, appAuditEvent(repositories.AuditEventTypeAppStart, app, nil))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
@@ -317,6 +356,8 @@ func (h *App) stop(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to stop app", "AppGUID", appGUID)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, appAuditEvent(repositories.AuditEventTypeAppStop, app, nil))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
@@ -408,6 +449,10 @@ func (h *App) scaleProcess(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if err = h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, korifiv1alpha1.FeatureFlagAppScaling); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app scaling is disabled")
+	}
+
 	scaledProcessRecord, err := h.processRepo.ScaleProcess(r.Context(), authInfo, repositories.ScaleProcessMessage{
 		GUID:               process.GUID,
 		SpaceGUID:          app.SpaceGUID,
@@ -417,6 +462,11 @@ func (h *App) scaleProcess(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed due to error from Kubernetes", "appGUID", appGUID)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, appAuditEvent(repositories.AuditEventTypeAppProcessScale, app, map[string]any{
+		"process_type": processType,
+		"request":      scaleRequestData(payload),
+	}))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcess(scaledProcessRecord, h.serverURL)), nil
 }
 
@@ -450,6 +500,8 @@ func (h *App) restart(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to start app", "AppGUID", appGUID)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, appAuditEvent(repositories.AuditEventTypeAppRestart, app, nil))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
@@ -471,6 +523,8 @@ func (h *App) delete(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete app", "AppGUID", appGUID)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, appAuditEvent(repositories.AuditEventTypeAppDeleteRequest, app, nil))
+
 	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(appGUID, presenter.AppDeleteOperation, h.serverURL)), nil
 }
 
@@ -523,6 +577,10 @@ func (h *App) updateEnvVars(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Error updating app environment variables")
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, appAuditEvent(repositories.AuditEventTypeAppUpdate, app, map[string]any{
+		"request": map[string]any{"environment_variables": "[PRIVATE DATA HIDDEN]"},
+	}))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppEnvVars(envVarsRecord, h.serverURL)), nil
 }
 
@@ -623,38 +681,148 @@ func (h *App) update(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if payload.Lifecycle != nil && payload.Lifecycle.Data != nil && payload.Lifecycle.Data.Stack != "" {
+		stacks, err := h.stackNames(r.Context(), authInfo)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch stacks", "AppGUID", appGUID)
+		}
+
+		if err = payload.Lifecycle.ValidateStack(stacks); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "invalid stack", "AppGUID", appGUID)
+		}
+	}
+
 	app, err = h.appRepo.PatchApp(r.Context(), authInfo, payload.ToMessage(appGUID, app.SpaceGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app", "AppGUID", appGUID)
 	}
+
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, appAuditEvent(repositories.AuditEventTypeAppUpdate, app, nil))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
 func (h *App) getSSHEnabled(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-ssh-enabled")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	if !h.sshEnabled {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  "Disabled globally",
+		}), nil
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, app.SpaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch space from Kubernetes", "SpaceGUID", app.SpaceGUID)
+	}
+
+	if !space.AllowSSH {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  fmt.Sprintf("Disabled for space %s", space.Name),
+		}), nil
+	}
+
+	if !app.EnableSSH {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  "Disabled for app",
+		}), nil
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
-		Enabled: false,
-		Reason:  "Disabled globally",
+		Enabled: true,
+		Reason:  "",
 	}), nil
 }
 
 func (h *App) getAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-feature")
+	appGUID := routing.URLParam(r, "guid")
+
+	presentFeature, ok := appFeaturePresenters[routing.URLParam(r, "name")]
+	if !ok {
+		return nil, apierrors.NewNotFoundError(nil, "Feature")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presentFeature(app)), nil
+}
+
+func (h *App) updateAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.update-feature")
+	appGUID := routing.URLParam(r, "guid")
 	featureName := routing.URLParam(r, "name")
+
+	presentFeature, ok := appFeaturePresenters[featureName]
+	if !ok {
+		return nil, apierrors.NewNotFoundError(nil, "Feature")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	var payload payloads.FeaturePatch
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	patchMessage := repositories.PatchAppMessage{
+		AppGUID:   app.GUID,
+		SpaceGUID: app.SpaceGUID,
+	}
 	switch featureName {
 	case "ssh":
-		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-			"name":        "ssh",
-			"description": "Enable SSHing into the app.",
-			"enabled":     false,
-		}), nil
+		patchMessage.EnableSSH = payload.Enabled
 	case "revisions":
-		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-			"name":        "revisions",
-			"description": "Enable versioning of an application",
-			"enabled":     false,
-		}), nil
-	default:
-		return nil, apierrors.NewNotFoundError(nil, "Feature")
+		patchMessage.EnableRevisions = payload.Enabled
+	}
+
+	app, err = h.appRepo.PatchApp(r.Context(), authInfo, patchMessage)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app", "AppGUID", appGUID)
 	}
+
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, appAuditEvent(repositories.AuditEventTypeAppUpdate, app, map[string]any{
+		"request": map[string]any{"feature": featureName, "enabled": *payload.Enabled},
+	}))
+
+	return routing.NewResponse(http.StatusOK).WithBody(presentFeature(app)), nil
+}
+
+var appFeaturePresenters = map[string]func(repositories.AppRecord) presenter.FeatureResponse{
+	"ssh":       presenter.ForAppSSHFeature,
+	"revisions": presenter.ForAppRevisionsFeature,
+}
+
+func (h *App) stackNames(ctx context.Context, authInfo authorization.Info) ([]string, error) {
+	stacks, err := h.stackRepo.ListStacks(ctx, authInfo, repositories.ListStacksMessage{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(stacks))
+	for _, stack := range stacks {
+		names = append(names, stack.Name)
+	}
+
+	return names, nil
 }
 
 func (h *App) UnauthenticatedRoutes() []routing.Route {
@@ -681,6 +849,7 @@ func (h *App) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: AppEnvPath, Handler: h.getEnvironment},
 		{Method: "GET", Pattern: AppPackagesPath, Handler: h.getPackages},
 		{Method: "GET", Pattern: AppFeaturePath, Handler: h.getAppFeature},
+		{Method: "PATCH", Pattern: AppFeaturePath, Handler: h.updateAppFeature},
 		{Method: "PATCH", Pattern: AppPath, Handler: h.update},
 		{Method: "GET", Pattern: AppSSHEnabledPath, Handler: h.getSSHEnabled},
 	}
@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -31,16 +32,19 @@ const (
 
 var _ = Describe("App", func() {
 	var (
-		appRepo          *fake.CFAppRepository
-		dropletRepo      *fake.CFDropletRepository
-		processRepo      *fake.CFProcessRepository
-		processStats     *fake.ProcessStats
-		routeRepo        *fake.CFRouteRepository
-		domainRepo       *fake.CFDomainRepository
-		spaceRepo        *fake.CFSpaceRepository
-		packageRepo      *fake.CFPackageRepository
-		requestValidator *fake.RequestValidator
-		req              *http.Request
+		appRepo            *fake.CFAppRepository
+		dropletRepo        *fake.CFDropletRepository
+		processRepo        *fake.CFProcessRepository
+		processStats       *fake.ProcessStats
+		routeRepo          *fake.CFRouteRepository
+		domainRepo         *fake.CFDomainRepository
+		spaceRepo          *fake.CFSpaceRepository
+		packageRepo        *fake.CFPackageRepository
+		stackRepo          *fake.CFStackRepository
+		requestValidator   *fake.RequestValidator
+		auditEventRecorder *fake.AuditEventRecorder
+		featureFlagChecker *fake.FeatureFlagChecker
+		req                *http.Request
 
 		appRecord repositories.AppRecord
 	)
@@ -54,7 +58,14 @@ var _ = Describe("App", func() {
 		domainRepo = new(fake.CFDomainRepository)
 		spaceRepo = new(fake.CFSpaceRepository)
 		packageRepo = new(fake.CFPackageRepository)
+		stackRepo = new(fake.CFStackRepository)
+		stackRepo.ListStacksReturns([]repositories.StackRecord{
+			{GUID: "cflinuxfs3", Name: "cflinuxfs3"},
+			{GUID: "my-stack", Name: "my-stack"},
+		}, nil)
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 
 		apiHandler := NewApp(
 			*serverURL,
@@ -66,7 +77,11 @@ var _ = Describe("App", func() {
 			domainRepo,
 			spaceRepo,
 			packageRepo,
+			stackRepo,
 			requestValidator,
+			auditEventRecorder,
+			featureFlagChecker,
+			true,
 		)
 
 		appRecord = repositories.AppRecord{
@@ -157,6 +172,24 @@ var _ = Describe("App", func() {
 			req = createHttpRequest("POST", "/v3/apps", strings.NewReader("the-json-body"))
 		})
 
+		It("records an app create audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+				Type: "audit.app.create",
+				Target: repositories.AuditEventTarget{
+					GUID: appGUID,
+					Type: "app",
+					Name: appName,
+				},
+				SpaceGUID: spaceGUID,
+				Data: map[string]any{
+					"request": map[string]any{"name": appName},
+				},
+			}))
+		})
+
 		It("returns the App", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
@@ -208,6 +241,46 @@ var _ = Describe("App", func() {
 						},
 					}))
 			})
+
+			It("validates the stack against the available stacks", func() {
+				Expect(stackRepo.ListStacksCallCount()).To(Equal(1))
+				_, actualAuthInfo, _ := stackRepo.ListStacksArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+			})
+
+			When("the stack does not exist", func() {
+				BeforeEach(func() {
+					payload.Lifecycle.Data.Stack = "unknown-stack"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(appRepo.CreateAppCallCount()).To(BeZero())
+					expectUnprocessableEntityError("Stack must be an existing stack")
+				})
+			})
+
+			When("listing the stacks fails", func() {
+				BeforeEach(func() {
+					stackRepo.ListStacksReturns(nil, errors.New("list-stacks-err"))
+				})
+
+				It("returns an error", func() {
+					Expect(appRepo.CreateAppCallCount()).To(BeZero())
+					expectUnknownError()
+				})
+			})
+
+			When("no stack is given", func() {
+				BeforeEach(func() {
+					payload.Lifecycle.Data.Stack = ""
+					stackRepo.ListStacksReturns(nil, errors.New("builder info not ready"))
+				})
+
+				It("creates the app without fetching the stacks", func() {
+					Expect(stackRepo.ListStacksCallCount()).To(BeZero())
+					Expect(appRepo.CreateAppCallCount()).To(Equal(1))
+				})
+			})
 		})
 
 		When("the app has docker lifecycle", func() {
@@ -227,6 +300,32 @@ var _ = Describe("App", func() {
 						Data: repositories.LifecycleData{},
 					}))
 			})
+
+			It("checks the diego_docker feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlagName := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlagName).To(Equal("diego_docker"))
+			})
+
+			When("docker apps are disabled", func() {
+				BeforeEach(func() {
+					featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("diego_docker", ""))
+				})
+
+				It("returns a feature disabled error", func() {
+					Expect(appRepo.CreateAppCallCount()).To(BeZero())
+					expectFeatureDisabledError("diego_docker")
+				})
+			})
+		})
+
+		It("does not check feature flags", func() {
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(BeZero())
+		})
+
+		It("does not validate the default stack", func() {
+			Expect(stackRepo.ListStacksCallCount()).To(BeZero())
 		})
 
 		It("creates the `web` process", func() {
@@ -354,7 +453,7 @@ var _ = Describe("App", func() {
 			Expect(rr).Should(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps?foo=bar"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "first-test-app-guid"),
 				MatchJSONPath("$.resources[0].state", "STOPPED"),
@@ -501,6 +600,13 @@ var _ = Describe("App", func() {
 			req = createHttpRequest("PATCH", "/v3/apps/"+appGUID, strings.NewReader("the-json-body"))
 		})
 
+		It("records an app update audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.update"))
+			Expect(message.Target.GUID).To(Equal("patched-app-guid"))
+		})
+
 		It("patches the app", func() {
 			Expect(appRepo.PatchAppCallCount()).To(Equal(1))
 			_, _, msg := appRepo.PatchAppArgsForCall(0)
@@ -525,6 +631,32 @@ var _ = Describe("App", func() {
 			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
 		})
 
+		It("validates the stack against the available stacks", func() {
+			Expect(stackRepo.ListStacksCallCount()).To(Equal(1))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				payload.Lifecycle.Data.Stack = "unknown-stack"
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Stack must be an existing stack")
+			})
+		})
+
+		When("the stack is not patched", func() {
+			BeforeEach(func() {
+				payload.Lifecycle.Data.Stack = ""
+			})
+
+			It("does not fetch the stacks", func() {
+				Expect(stackRepo.ListStacksCallCount()).To(BeZero())
+				Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+			})
+		})
+
 		It("returns the App in the response", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
@@ -611,6 +743,16 @@ var _ = Describe("App", func() {
 			req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/relationships/current_droplet", strings.NewReader("the-json-body"))
 		})
 
+		It("records a droplet mapped audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.droplet.mapped"))
+			Expect(message.Target.GUID).To(Equal(appGUID))
+			Expect(message.Data).To(Equal(map[string]any{
+				"request": map[string]any{"droplet_guid": dropletGUID},
+			}))
+		})
+
 		itDoesntSetTheCurrentDroplet := func() {
 			It("doesn't set the current droplet on the app", func() {
 				Expect(appRepo.SetCurrentDropletCallCount()).To(Equal(0))
@@ -763,6 +905,14 @@ var _ = Describe("App", func() {
 			req = createHttpRequest("POST", "/v3/apps/"+appGUID+"/actions/start", nil)
 		})
 
+		It("records an app start audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.start"))
+			Expect(message.Target.GUID).To(Equal(appGUID))
+			Expect(message.SpaceGUID).To(Equal(spaceGUID))
+		})
+
 		It("returns the App in the response with a state of STARTED", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
@@ -825,6 +975,13 @@ var _ = Describe("App", func() {
 			req = createHttpRequest("POST", "/v3/apps/"+appGUID+"/actions/stop", nil)
 		})
 
+		It("records an app stop audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.stop"))
+			Expect(message.Target.GUID).To(Equal(appGUID))
+		})
+
 		It("returns the App in the response with a state of STOPPED", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
@@ -896,7 +1053,7 @@ var _ = Describe("App", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/"+appGUID+"/processes"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/"+appGUID+"/processes?page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "process-1-guid"),
 				MatchJSONPath("$.resources[0].command", "[PRIVATE DATA HIDDEN IN LISTS]"),
@@ -1086,6 +1243,21 @@ var _ = Describe("App", func() {
 			req = createHttpRequest("POST", "/v3/apps/"+appGUID+"/processes/web/actions/scale", strings.NewReader("the-json-body"))
 		})
 
+		It("records a process scale audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.process.scale"))
+			Expect(message.Target.GUID).To(Equal(appGUID))
+			Expect(message.Data).To(Equal(map[string]any{
+				"process_type": "web",
+				"request": map[string]any{
+					"instances":    5,
+					"memory_in_mb": int64(256),
+					"disk_in_mb":   int64(1024),
+				},
+			}))
+		})
+
 		It("gets the app", func() {
 			Expect(appRepo.GetAppCallCount()).To(Equal(1))
 			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
@@ -1184,6 +1356,24 @@ var _ = Describe("App", func() {
 			})
 		})
 
+		When("app scaling is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("app_scaling", ""))
+			})
+
+			It("checks the app_scaling feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlagName := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlagName).To(Equal("app_scaling"))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+				expectFeatureDisabledError("app_scaling")
+			})
+		})
+
 		When("there is an error scaling the app", func() {
 			BeforeEach(func() {
 				processRepo.ScaleProcessReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
@@ -1233,7 +1423,7 @@ var _ = Describe("App", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/"+appGUID+"/routes"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/"+appGUID+"/routes?page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(1)),
 				MatchJSONPath("$.resources[0].guid", "test-route-guid"),
 				MatchJSONPath("$.resources[0].url", "test-route-host.example.org/some_path"),
@@ -1365,6 +1555,13 @@ var _ = Describe("App", func() {
 			req = createHttpRequest("POST", "/v3/apps/"+appGUID+"/actions/restart", nil)
 		})
 
+		It("records an app restart audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.restart"))
+			Expect(message.Target.GUID).To(Equal(appGUID))
+		})
+
 		It("restarts the app", func() {
 			Expect(appRepo.GetAppCallCount()).To(Equal(1))
 			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
@@ -1481,6 +1678,13 @@ var _ = Describe("App", func() {
 			req = createHttpRequest("DELETE", "/v3/apps/"+appGUID, nil)
 		})
 
+		It("records an app delete request audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.delete-request"))
+			Expect(message.Target.GUID).To(Equal(appGUID))
+		})
+
 		It("deletes the app", func() {
 			Expect(appRepo.GetAppCallCount()).To(Equal(1))
 			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
@@ -1570,6 +1774,15 @@ var _ = Describe("App", func() {
 			req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/environment_variables", strings.NewReader("the-json-body"))
 		})
 
+		It("records an app update audit event without the variable values", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.update"))
+			Expect(message.Data).To(Equal(map[string]any{
+				"request": map[string]any{"environment_variables": "[PRIVATE DATA HIDDEN]"},
+			}))
+		})
+
 		It("updates the app environemnt", func() {
 			Expect(appRepo.GetAppCallCount()).To(Equal(1))
 			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
@@ -1670,7 +1883,7 @@ var _ = Describe("App", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/test-app-guid/packages"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/test-app-guid/packages?page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "package-1-guid"),
 				MatchJSONPath("$.resources[0].state", "AWAITING_UPLOAD"),
@@ -1711,47 +1924,173 @@ var _ = Describe("App", func() {
 
 	Describe("GET /v3/apps/GUID/ssh_enabled", func() {
 		BeforeEach(func() {
+			appRecord.EnableSSH = true
+			appRepo.GetAppReturns(appRecord, nil)
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:     spaceGUID,
+				Name:     "my-space",
+				AllowSSH: true,
+			}, nil)
+
 			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/ssh_enabled", nil)
 		})
 
-		It("returns false", func() {
+		It("returns true", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.enabled", BeFalse()),
-				MatchJSONPath("$.reason", Equal("Disabled globally")),
+				MatchJSONPath("$.enabled", BeTrue()),
+				MatchJSONPath("$.reason", BeEmpty()),
 			)))
 		})
+
+		When("ssh is disabled globally", func() {
+			BeforeEach(func() {
+				routerBuilder = routing.NewRouterBuilder()
+				routerBuilder.LoadRoutes(NewApp(
+					*serverURL,
+					appRepo,
+					dropletRepo,
+					processRepo,
+					processStats,
+					routeRepo,
+					domainRepo,
+					spaceRepo,
+					packageRepo,
+					stackRepo,
+					requestValidator,
+					auditEventRecorder,
+					featureFlagChecker,
+					false,
+				))
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled globally")),
+				)))
+			})
+		})
+
+		When("ssh is disabled for the space", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+					GUID:     spaceGUID,
+					Name:     "my-space",
+					AllowSSH: false,
+				}, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled for space my-space")),
+				)))
+			})
+		})
+
+		When("ssh is disabled for the app", func() {
+			BeforeEach(func() {
+				appRecord.EnableSSH = false
+				appRepo.GetAppReturns(appRecord, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled for app")),
+				)))
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("getting the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 
 	Describe("GET /v3/apps/GUID/features", func() {
 		When("feature ssh is called", func() {
 			BeforeEach(func() {
+				appRecord.EnableSSH = true
+				appRepo.GetAppReturns(appRecord, nil)
+
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/ssh", nil)
 			})
 
-			It("returns ssh enabled false", func() {
+			It("returns whether ssh is enabled for the app", func() {
+				Expect(appRepo.GetAppCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualAppGUID).To(Equal(appGUID))
+
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
 					MatchJSONPath("$.name", Equal("ssh")),
 					MatchJSONPath("$.description", Equal("Enable SSHing into the app.")),
-					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.enabled", BeTrue()),
 				)))
 			})
+
+			When("the app is not accessible", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+				})
+
+				It("returns a not found error", func() {
+					expectNotFoundError("App")
+				})
+			})
 		})
 		When("feature revisions is called", func() {
 			BeforeEach(func() {
+				appRecord.EnableRevisions = true
+				appRepo.GetAppReturns(appRecord, nil)
+
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/revisions", nil)
 			})
 
-			It("returns revisions enabled false", func() {
+			It("returns whether revisions are enabled for the app", func() {
+				Expect(appRepo.GetAppCallCount()).To(Equal(1))
+				_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+				Expect(actualAppGUID).To(Equal(appGUID))
+
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
 					MatchJSONPath("$.name", Equal("revisions")),
 					MatchJSONPath("$.description", Equal("Enable versioning of an application")),
-					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.enabled", BeTrue()),
 				)))
 			})
 		})
@@ -1772,6 +2111,108 @@ var _ = Describe("App", func() {
 			})
 		})
 	})
+	Describe("PATCH /v3/apps/GUID/features/ssh", func() {
+		BeforeEach(func() {
+			appRepo.PatchAppReturns(repositories.AppRecord{GUID: appGUID, EnableSSH: false}, nil)
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeaturePatch{
+				Enabled: tools.PtrTo(false),
+			})
+
+			req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/ssh", strings.NewReader("the-json-body"))
+		})
+
+		It("records an app update audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.update"))
+			Expect(message.Data).To(Equal(map[string]any{
+				"request": map[string]any{"feature": "ssh", "enabled": false},
+			}))
+		})
+
+		It("updates the app", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := appRepo.PatchAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.AppGUID).To(Equal(appGUID))
+			Expect(actualMessage.SpaceGUID).To(Equal(spaceGUID))
+			Expect(actualMessage.EnableSSH).To(Equal(tools.PtrTo(false)))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", Equal("ssh")),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the feature is revisions", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppReturns(repositories.AppRecord{GUID: appGUID, EnableRevisions: false}, nil)
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/revisions", strings.NewReader("the-json-body"))
+			})
+
+			It("updates the app", func() {
+				Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+				_, _, actualMessage := appRepo.PatchAppArgsForCall(0)
+				Expect(actualMessage.EnableRevisions).To(Equal(tools.PtrTo(false)))
+				Expect(actualMessage.EnableSSH).To(BeNil())
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.name", Equal("revisions")),
+					MatchJSONPath("$.enabled", BeFalse()),
+				)))
+			})
+		})
+
+		When("the feature is unknown", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/anything-else", strings.NewReader("the-json-body"))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature")
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("patching the app fails", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppReturns(repositories.AppRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
 
 func createHttpRequest(method string, url string, body io.Reader) *http.Request {
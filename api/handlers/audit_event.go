@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AuditEventsPath = "/v3/audit_events"
+	AuditEventPath  = "/v3/audit_events/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFAuditEventRepository . CFAuditEventRepository
+
+type CFAuditEventRepository interface {
+	GetAuditEvent(context.Context, authorization.Info, string) (repositories.AuditEventRecord, error)
+	ListAuditEvents(context.Context, authorization.Info, repositories.ListAuditEventsMessage) ([]repositories.AuditEventRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name AuditEventRecorder . AuditEventRecorder
+
+// AuditEventRecorder records the actions performed by the users. Handlers
+// record an event once an action has succeeded; failing to record it does not
+// fail the request.
+type AuditEventRecorder interface {
+	RecordEvent(context.Context, authorization.Info, repositories.CreateAuditEventMessage)
+}
+
+type AuditEvent struct {
+	serverURL        url.URL
+	auditEventRepo   CFAuditEventRepository
+	requestValidator RequestValidator
+}
+
+func NewAuditEvent(
+	serverURL url.URL,
+	auditEventRepo CFAuditEventRepository,
+	requestValidator RequestValidator,
+) *AuditEvent {
+	return &AuditEvent{
+		serverURL:        serverURL,
+		auditEventRepo:   auditEventRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *AuditEvent) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.get")
+	auditEventGUID := routing.URLParam(r, "guid")
+
+	auditEvent, err := h.auditEventRepo.GetAuditEvent(r.Context(), authInfo, auditEventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch audit event from Kubernetes", "AuditEventGUID", auditEventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAuditEvent(auditEvent, h.serverURL)), nil
+}
+
+func (h *AuditEvent) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.list")
+
+	payload := new(payloads.AuditEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	auditEvents, err := h.auditEventRepo.ListAuditEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch audit events from Kubernetes")
+	}
+
+	h.sortList(auditEvents, payload.OrderBy)
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAuditEvent, auditEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *AuditEvent) sortList(auditEvents []repositories.AuditEventRecord, order string) {
+	sortByOrder(auditEvents, cmp.Or(order, "created_at"), orderBy[repositories.AuditEventRecord]{
+		"created_at": func(a, b repositories.AuditEventRecord) bool { return createdBefore(a.CreatedAt, b.CreatedAt) },
+		"updated_at": func(a, b repositories.AuditEventRecord) bool { return updatedBefore(a.UpdatedAt, b.UpdatedAt) },
+	})
+}
+
+func (h *AuditEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AuditEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AuditEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: AuditEventPath, Handler: h.get},
+	}
+}
+
+func appAuditEvent(eventType string, app repositories.AppRecord, data map[string]any) repositories.CreateAuditEventMessage {
+	return repositories.CreateAuditEventMessage{
+		Type: eventType,
+		Target: repositories.AuditEventTarget{
+			GUID: app.GUID,
+			Type: repositories.AuditEventTargetTypeApp,
+			Name: app.Name,
+		},
+		SpaceGUID: app.SpaceGUID,
+		Data:      data,
+	}
+}
+
+func scaleRequestData(payload payloads.ProcessScale) map[string]any {
+	data := map[string]any{}
+	if payload.Instances != nil {
+		data["instances"] = *payload.Instances
+	}
+	if payload.MemoryMB != nil {
+		data["memory_in_mb"] = *payload.MemoryMB
+	}
+	if payload.DiskMB != nil {
+		data["disk_in_mb"] = *payload.DiskMB
+	}
+
+	return data
+}
+
+// processAuditEvent builds an event for an action on a process, which like in
+// CF targets the app of the process
+func processAuditEvent(eventType string, process repositories.ProcessRecord, data map[string]any) repositories.CreateAuditEventMessage {
+	data["process_guid"] = process.GUID
+	data["process_type"] = process.Type
+
+	return repositories.CreateAuditEventMessage{
+		Type: eventType,
+		Target: repositories.AuditEventTarget{
+			GUID: process.AppGUID,
+			Type: repositories.AuditEventTargetTypeApp,
+		},
+		SpaceGUID: process.SpaceGUID,
+		Data:      data,
+	}
+}
+
+func routeMappingAuditEvent(eventType string, route repositories.RouteRecord, appGUID, processType string) repositories.CreateAuditEventMessage {
+	return repositories.CreateAuditEventMessage{
+		Type: eventType,
+		Target: repositories.AuditEventTarget{
+			GUID: appGUID,
+			Type: repositories.AuditEventTargetTypeApp,
+		},
+		SpaceGUID: route.SpaceGUID,
+		Data: map[string]any{
+			"route_guid":   route.GUID,
+			"process_type": processType,
+		},
+	}
+}
+
+// serviceKeyEventTypes maps service binding events to the events recorded for
+// key bindings, which like in CF are reported as service key events
+var serviceKeyEventTypes = map[string]string{
+	repositories.AuditEventTypeServiceBindingCreate: repositories.AuditEventTypeServiceKeyCreate,
+	repositories.AuditEventTypeServiceBindingDelete: repositories.AuditEventTypeServiceKeyDelete,
+}
+
+func serviceBindingAuditEvent(eventType string, serviceBinding repositories.ServiceBindingRecord) repositories.CreateAuditEventMessage {
+	var name string
+	if serviceBinding.Name != nil {
+		name = *serviceBinding.Name
+	}
+
+	if serviceBinding.Type == repositories.ServiceBindingTypeKey {
+		return repositories.CreateAuditEventMessage{
+			Type: serviceKeyEventTypes[eventType],
+			Target: repositories.AuditEventTarget{
+				GUID: serviceBinding.GUID,
+				Type: repositories.AuditEventTargetTypeServiceKey,
+				Name: name,
+			},
+			SpaceGUID: serviceBinding.SpaceGUID,
+			Data: map[string]any{
+				"service_instance_guid": serviceBinding.ServiceInstanceGUID,
+			},
+		}
+	}
+
+	return repositories.CreateAuditEventMessage{
+		Type: eventType,
+		Target: repositories.AuditEventTarget{
+			GUID: serviceBinding.GUID,
+			Type: repositories.AuditEventTargetTypeServiceBinding,
+			Name: name,
+		},
+		SpaceGUID: serviceBinding.SpaceGUID,
+		Data: map[string]any{
+			"app_guid":              serviceBinding.AppGUID,
+			"service_instance_guid": serviceBinding.ServiceInstanceGUID,
+		},
+	}
+}
+
+// roleAuditEvent builds an event for a role granted to or revoked from a user,
+// e.g. `audit.user.space_developer_add`
+func roleAuditEvent(action string, role repositories.RoleRecord) repositories.CreateAuditEventMessage {
+	return repositories.CreateAuditEventMessage{
+		Type: fmt.Sprintf("audit.user.%s_%s", role.Type, action),
+		Target: repositories.AuditEventTarget{
+			GUID: role.User,
+			Type: repositories.AuditEventTargetTypeUser,
+			Name: role.User,
+		},
+		SpaceGUID:        role.Space,
+		OrganizationGUID: role.Org,
+		Data: map[string]any{
+			"role_guid": role.GUID,
+		},
+	}
+}
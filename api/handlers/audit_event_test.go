@@ -0,0 +1,151 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEvent", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		auditEventRepo   *fake.CFAuditEventRepository
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		auditEventRepo = new(fake.CFAuditEventRepository)
+
+		apiHandler := handlers.NewAuditEvent(*serverURL, auditEventRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/audit_events/{guid}", func() {
+		BeforeEach(func() {
+			auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{
+				GUID: "audit-event-guid",
+				Type: "audit.app.start",
+			}, nil)
+
+			req = createHttpRequest("GET", "/v3/audit_events/audit-event-guid", nil)
+		})
+
+		It("returns the audit event", func() {
+			Expect(auditEventRepo.GetAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := auditEventRepo.GetAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("audit-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "audit-event-guid"),
+				MatchJSONPath("$.type", "audit.app.start"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/audit_events/audit-event-guid"),
+			)))
+		})
+
+		When("the audit event is not accessible", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AuditEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AuditEventResourceType)
+			})
+		})
+
+		When("getting the audit event fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/audit_events", func() {
+		var payload *payloads.AuditEventList
+
+		BeforeEach(func() {
+			now := time.Now()
+			auditEventRepo.ListAuditEventsReturns([]repositories.AuditEventRecord{
+				{GUID: "event-2", CreatedAt: now},
+				{GUID: "event-1", CreatedAt: now.Add(-time.Minute)},
+			}, nil)
+
+			payload = &payloads.AuditEventList{
+				Types:      "audit.app.start",
+				SpaceGUIDs: spaceGUID,
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			req = createHttpRequest("GET", "/v3/audit_events?types=audit.app.start&space_guids="+spaceGUID, nil)
+		})
+
+		It("lists the audit events ordered by creation time", func() {
+			Expect(auditEventRepo.ListAuditEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRepo.ListAuditEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Types).To(ConsistOf("audit.app.start"))
+			Expect(message.SpaceGUIDs).To(ConsistOf(spaceGUID))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "event-1"),
+				MatchJSONPath("$.resources[1].guid", "event-2"),
+			)))
+		})
+
+		When("ordering by descending creation time", func() {
+			BeforeEach(func() {
+				payload.OrderBy = "-created_at"
+			})
+
+			It("returns the latest event first", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.resources[0].guid", "event-2"),
+					MatchJSONPath("$.resources[1].guid", "event-1"),
+				)))
+			})
+		})
+
+		When("the request query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("boom")
+			})
+		})
+
+		When("listing the audit events fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.ListAuditEventsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
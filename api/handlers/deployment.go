@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"net/http"
@@ -18,8 +19,10 @@ import (
 )
 
 const (
-	DeploymentsPath = "/v3/deployments"
-	DeploymentPath  = "/v3/deployments/{guid}"
+	DeploymentsPath        = "/v3/deployments"
+	DeploymentPath         = "/v3/deployments/{guid}"
+	DeploymentCancelPath   = "/v3/deployments/{guid}/actions/cancel"
+	DeploymentContinuePath = "/v3/deployments/{guid}/actions/continue"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDeploymentRepository . CFDeploymentRepository
@@ -27,6 +30,9 @@ const (
 type CFDeploymentRepository interface {
 	GetDeployment(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)
 	CreateDeployment(context.Context, authorization.Info, repositories.CreateDeploymentMessage) (repositories.DeploymentRecord, error)
+	ListDeployments(context.Context, authorization.Info, repositories.ListDeploymentsMessage) ([]repositories.DeploymentRecord, error)
+	CancelDeployment(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)
+	ContinueDeployment(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)
 }
 
 //counterfeiter:generate -o fake -fake-name RunnerInfoRepository . RunnerInfoRepository
@@ -106,6 +112,68 @@ func (h *Deployment) get(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDeployment(deployment, h.serverURL)), nil
 }
 
+func (h *Deployment) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.deployment.list")
+
+	payload := new(payloads.DeploymentList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	deployments, err := h.deploymentRepo.ListDeployments(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch deployments from Kubernetes")
+	}
+
+	h.sortList(deployments, payload.OrderBy)
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForDeployment, deployments, h.serverURL, *r.URL)), nil
+}
+
+func (h *Deployment) sortList(deployments []repositories.DeploymentRecord, order string) {
+	sortByOrder(deployments, cmp.Or(order, "created_at"), orderBy[repositories.DeploymentRecord]{
+		"created_at": func(a, b repositories.DeploymentRecord) bool { return createdBefore(a.CreatedAt, b.CreatedAt) },
+		"updated_at": func(a, b repositories.DeploymentRecord) bool { return updatedBefore(a.UpdatedAt, b.UpdatedAt) },
+	})
+}
+
+func (h *Deployment) cancel(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.deployment.cancel")
+
+	deploymentGUID := routing.URLParam(r, "guid")
+
+	if _, err := h.deploymentRepo.GetDeployment(r.Context(), authInfo, deploymentGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting deployment in repository")
+	}
+
+	deployment, err := h.deploymentRepo.CancelDeployment(r.Context(), authInfo, deploymentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error canceling deployment in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDeployment(deployment, h.serverURL)), nil
+}
+
+func (h *Deployment) continueDeployment(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.deployment.continue")
+
+	deploymentGUID := routing.URLParam(r, "guid")
+
+	if _, err := h.deploymentRepo.GetDeployment(r.Context(), authInfo, deploymentGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting deployment in repository")
+	}
+
+	deployment, err := h.deploymentRepo.ContinueDeployment(r.Context(), authInfo, deploymentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error continuing deployment in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDeployment(deployment, h.serverURL)), nil
+}
+
 func (h *Deployment) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -113,6 +181,9 @@ func (h *Deployment) UnauthenticatedRoutes() []routing.Route {
 func (h *Deployment) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: DeploymentPath, Handler: h.get},
+		{Method: "GET", Pattern: DeploymentsPath, Handler: h.list},
 		{Method: "POST", Pattern: DeploymentsPath, Handler: h.create},
+		{Method: "POST", Pattern: DeploymentCancelPath, Handler: h.cancel},
+		{Method: "POST", Pattern: DeploymentContinuePath, Handler: h.continueDeployment},
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers"
@@ -213,4 +214,205 @@ var _ = Describe("Deployment", func() {
 			})
 		})
 	})
+
+	Describe("GET /v3/deployments", func() {
+		var payload *payloads.DeploymentList
+
+		BeforeEach(func() {
+			deploymentsRepo.ListDeploymentsReturns([]repositories.DeploymentRecord{
+				{GUID: "deployment-2", AppGUID: appGUID, CreatedAt: time.UnixMilli(2000)},
+				{GUID: "deployment-1", AppGUID: appGUID, CreatedAt: time.UnixMilli(1000)},
+			}, nil)
+
+			payload = &payloads.DeploymentList{
+				AppGUIDs: appGUID,
+				States:   []string{"DEPLOYED"},
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			req = createHttpRequest("GET", "/v3/deployments?app_guids="+appGUID, nil)
+		})
+
+		It("lists the deployments ordered by creation time", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "deployment-1"),
+				MatchJSONPath("$.resources[0].relationships.app.data.guid", appGUID),
+				MatchJSONPath("$.resources[1].guid", "deployment-2"),
+			)))
+		})
+
+		It("lists the deployments with the repository", func() {
+			Expect(deploymentsRepo.ListDeploymentsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := deploymentsRepo.ListDeploymentsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListDeploymentsMessage{
+				AppGUIDs: []string{appGUID},
+				States:   []repositories.DeploymentState{repositories.DeploymentStateDeployed},
+			}))
+		})
+
+		When("ordering by descending creation time", func() {
+			BeforeEach(func() {
+				payload.OrderBy = "-created_at"
+			})
+
+			It("returns the most recent deployment first", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.resources[0].guid", "deployment-2"),
+					MatchJSONPath("$.resources[1].guid", "deployment-1"),
+				)))
+			})
+		})
+
+		When("the request query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("boom")
+			})
+		})
+
+		When("listing the deployments fails", func() {
+			BeforeEach(func() {
+				deploymentsRepo.ListDeploymentsReturns(nil, errors.New("list-deployments-error"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/deployments/{guid}/actions/cancel", func() {
+		BeforeEach(func() {
+			deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{
+				GUID:    "deployment-guid",
+				AppGUID: appGUID,
+				State:   repositories.DeploymentStateCanceling,
+				Status: repositories.DeploymentStatus{
+					Value:  repositories.DeploymentStatusValueActive,
+					Reason: repositories.DeploymentStatusReasonCanceling,
+				},
+			}, nil)
+			req = createHttpRequest("POST", "/v3/deployments/deployment-guid/actions/cancel", nil)
+		})
+
+		It("returns the canceled deployment", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "deployment-guid"),
+				MatchJSONPath("$.state", "CANCELING"),
+				MatchJSONPath("$.status.value", "ACTIVE"),
+				MatchJSONPath("$.status.reason", "CANCELING"),
+			)))
+		})
+
+		It("cancels the deployment with the repository", func() {
+			Expect(deploymentsRepo.CancelDeploymentCallCount()).To(Equal(1))
+			_, actualAuthInfo, deploymentGUID := deploymentsRepo.CancelDeploymentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(deploymentGUID).To(Equal("deployment-guid"))
+		})
+
+		When("getting the deployment is forbidden", func() {
+			BeforeEach(func() {
+				deploymentsRepo.GetDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewForbiddenError(nil, repositories.DeploymentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DeploymentResourceType)
+			})
+
+			It("does not cancel the deployment", func() {
+				Expect(deploymentsRepo.CancelDeploymentCallCount()).To(BeZero())
+			})
+		})
+
+		When("canceling the deployment is forbidden", func() {
+			BeforeEach(func() {
+				deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewForbiddenError(nil, repositories.DeploymentResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("the deployment cannot be canceled", func() {
+			BeforeEach(func() {
+				deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "cannot cancel"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("cannot cancel")
+			})
+		})
+	})
+
+	Describe("POST /v3/deployments/{guid}/actions/continue", func() {
+		BeforeEach(func() {
+			deploymentsRepo.ContinueDeploymentReturns(repositories.DeploymentRecord{
+				GUID:     "deployment-guid",
+				AppGUID:  appGUID,
+				Strategy: repositories.DeploymentStrategyCanary,
+				State:    repositories.DeploymentStateDeploying,
+				Status: repositories.DeploymentStatus{
+					Value:  repositories.DeploymentStatusValueActive,
+					Reason: repositories.DeploymentStatusReasonDeploying,
+				},
+			}, nil)
+			req = createHttpRequest("POST", "/v3/deployments/deployment-guid/actions/continue", nil)
+		})
+
+		It("returns the continued deployment", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "deployment-guid"),
+				MatchJSONPath("$.strategy", "canary"),
+				MatchJSONPath("$.status.value", "ACTIVE"),
+				MatchJSONPath("$.status.reason", "DEPLOYING"),
+			)))
+		})
+
+		It("continues the deployment with the repository", func() {
+			Expect(deploymentsRepo.ContinueDeploymentCallCount()).To(Equal(1))
+			_, actualAuthInfo, deploymentGUID := deploymentsRepo.ContinueDeploymentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(deploymentGUID).To(Equal("deployment-guid"))
+		})
+
+		When("getting the deployment is forbidden", func() {
+			BeforeEach(func() {
+				deploymentsRepo.GetDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewForbiddenError(nil, repositories.DeploymentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DeploymentResourceType)
+			})
+
+			It("does not continue the deployment", func() {
+				Expect(deploymentsRepo.ContinueDeploymentCallCount()).To(BeZero())
+			})
+		})
+
+		When("the deployment cannot be continued", func() {
+			BeforeEach(func() {
+				deploymentsRepo.ContinueDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "cannot continue"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("cannot continue")
+			})
+		})
+	})
 })
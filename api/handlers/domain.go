@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/config"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
@@ -17,8 +19,10 @@ import (
 )
 
 const (
-	DomainsPath = "/v3/domains"
-	DomainPath  = "/v3/domains/{guid}"
+	DomainsPath             = "/v3/domains"
+	DomainPath              = "/v3/domains/{guid}"
+	DomainSharedOrgsRelPath = "/v3/domains/{guid}/relationships/shared_organizations"
+	DomainSharedOrgRelPath  = "/v3/domains/{guid}/relationships/shared_organizations/{org_guid}"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDomainRepository . CFDomainRepository
@@ -30,23 +34,28 @@ type CFDomainRepository interface {
 	UpdateDomain(context.Context, authorization.Info, repositories.UpdateDomainMessage) (repositories.DomainRecord, error)
 	ListDomains(context.Context, authorization.Info, repositories.ListDomainsMessage) ([]repositories.DomainRecord, error)
 	DeleteDomain(context.Context, authorization.Info, string) error
+	ShareDomain(context.Context, authorization.Info, repositories.ShareDomainMessage) ([]string, error)
+	UnshareDomain(context.Context, authorization.Info, repositories.UnshareDomainMessage) error
 }
 
 type Domain struct {
 	serverURL        url.URL
 	requestValidator RequestValidator
 	domainRepo       CFDomainRepository
+	tcpRouterGroup   config.RouterGroupConfig
 }
 
 func NewDomain(
 	serverURL url.URL,
 	requestValidator RequestValidator,
 	domainRepo CFDomainRepository,
+	tcpRouterGroup config.RouterGroupConfig,
 ) *Domain {
 	return &Domain{
 		serverURL:        serverURL,
 		requestValidator: requestValidator,
 		domainRepo:       domainRepo,
+		tcpRouterGroup:   tcpRouterGroup,
 	}
 }
 
@@ -65,6 +74,11 @@ func (h *Domain) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierr, apierr.Detail())
 	}
 
+	if domainCreateMessage.RouterGroupGUID != "" && (!h.tcpRouterGroup.Enabled() || domainCreateMessage.RouterGroupGUID != h.tcpRouterGroup.Name) {
+		apierr := apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Router group with guid '%s' not found.", domainCreateMessage.RouterGroupGUID))
+		return nil, apierrors.LogAndReturn(logger, apierr, apierr.Detail())
+	}
+
 	domain, err := h.domainRepo.CreateDomain(r.Context(), authInfo, domainCreateMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating domain in repository")
@@ -125,9 +139,18 @@ func (h *Domain) list(r *http.Request) (*routing.Response, error) { //nolint:dup
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch domain(s) from Kubernetes")
 	}
 
+	h.sortList(domainList, domainListFilter.OrderBy)
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForDomain, domainList, h.serverURL, *r.URL)), nil
 }
 
+func (h *Domain) sortList(domains []repositories.DomainRecord, order string) {
+	sortByOrder(domains, order, orderBy[repositories.DomainRecord]{
+		"created_at": func(a, b repositories.DomainRecord) bool { return createdBefore(a.CreatedAt, b.CreatedAt) },
+		"updated_at": func(a, b repositories.DomainRecord) bool { return updatedBefore(a.UpdatedAt, b.UpdatedAt) },
+	})
+}
+
 func (h *Domain) delete(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.domain.delete")
@@ -145,6 +168,53 @@ func (h *Domain) delete(r *http.Request) (*routing.Response, error) {
 	), nil
 }
 
+func (h *Domain) share(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.domain.share")
+
+	domainGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.DomainShare
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.domainRepo.GetDomain(r.Context(), authInfo, domainGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting domain in repository")
+	}
+
+	orgGUIDs, err := h.domainRepo.ShareDomain(r.Context(), authInfo, payload.ToMessage(domainGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error sharing domain in repository", "domainGUID", domainGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDomainSharedOrganizations(domainGUID, orgGUIDs, h.serverURL)), nil
+}
+
+func (h *Domain) unshare(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.domain.unshare")
+
+	domainGUID := routing.URLParam(r, "guid")
+	orgGUID := routing.URLParam(r, "org_guid")
+
+	_, err := h.domainRepo.GetDomain(r.Context(), authInfo, domainGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting domain in repository")
+	}
+
+	err = h.domainRepo.UnshareDomain(r.Context(), authInfo, repositories.UnshareDomainMessage{
+		GUID:             domainGUID,
+		OrganizationGUID: orgGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error unsharing domain in repository", "domainGUID", domainGUID, "orgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
 func (h *Domain) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -156,5 +226,7 @@ func (h *Domain) AuthenticatedRoutes() []routing.Route {
 		{Method: "PATCH", Pattern: DomainPath, Handler: h.update},
 		{Method: "GET", Pattern: DomainsPath, Handler: h.list},
 		{Method: "DELETE", Pattern: DomainPath, Handler: h.delete},
+		{Method: "POST", Pattern: DomainSharedOrgsRelPath, Handler: h.share},
+		{Method: "DELETE", Pattern: DomainSharedOrgRelPath, Handler: h.unshare},
 	}
 }
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
+	"code.cloudfoundry.org/korifi/api/config"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -32,6 +34,10 @@ var _ = Describe("Domain", func() {
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			config.RouterGroupConfig{
+				Name:            "default-tcp",
+				ReservablePorts: "1024-1033",
+			},
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -108,11 +114,52 @@ var _ = Describe("Domain", func() {
 
 		When("the decoded payload is not valid", func() {
 			BeforeEach(func() {
-				payload.Internal = true
+				payload.Relationships = &payloads.DomainRelationships{
+					SharedOrganizations: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "org-guid"}},
+					},
+				}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Error converting domain payload to repository message: domains cannot be shared with other organizations unless they are scoped to an organization")
+			})
+		})
+
+		When("the domain is assigned to the tcp router group", func() {
+			BeforeEach(func() {
+				payload.RouterGroup = &payloads.DomainRouterGroup{GUID: "default-tcp"}
+				domainRepo.CreateDomainReturns(repositories.DomainRecord{
+					Name:            "my.domain",
+					GUID:            "domain-guid",
+					RouterGroupGUID: "default-tcp",
+				}, nil)
+			})
+
+			It("creates a tcp domain", func() {
+				Expect(domainRepo.CreateDomainCallCount()).To(Equal(1))
+				_, _, createMessage := domainRepo.CreateDomainArgsForCall(0)
+				Expect(createMessage.RouterGroupGUID).To(Equal("default-tcp"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.router_group.guid", "default-tcp"),
+					MatchJSONPath("$.supported_protocols", ConsistOf("tcp")),
+				)))
+			})
+		})
+
+		When("the router group does not exist", func() {
+			BeforeEach(func() {
+				payload.RouterGroup = &payloads.DomainRouterGroup{GUID: "another-router-group"}
 			})
 
 			It("returns an error", func() {
-				expectUnprocessableEntityError("Error converting domain payload to repository message: internal domains are not supported")
+				expectUnprocessableEntityError("Router group with guid 'another-router-group' not found.")
+			})
+
+			It("does not create the domain", func() {
+				Expect(domainRepo.CreateDomainCallCount()).To(BeZero())
 			})
 		})
 
@@ -296,13 +343,34 @@ var _ = Describe("Domain", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/domains"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/domains?page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(1)),
 				MatchJSONPath("$.resources[0].guid", "test-domain-guid"),
 				MatchJSONPath("$.resources[0].supported_protocols", ConsistOf("http")),
 			)))
 		})
 
+		When("the domains are ordered", func() {
+			BeforeEach(func() {
+				domainRepo.ListDomainsReturns([]repositories.DomainRecord{
+					{GUID: "domain-1", CreatedAt: time.UnixMilli(1000)},
+					{GUID: "domain-2", CreatedAt: time.UnixMilli(2000)},
+					{GUID: "domain-3", CreatedAt: time.UnixMilli(3000)},
+				}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.DomainList{
+					OrderBy: "-created_at",
+				})
+			})
+
+			It("returns the domains in the requested order", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(
+					MatchJSONPath("$.resources[*].guid", []any{"domain-3", "domain-2", "domain-1"}),
+				))
+			})
+		})
+
 		When("no domain exists", func() {
 			BeforeEach(func() {
 				domainRepo.ListDomainsReturns([]repositories.DomainRecord{}, nil)
@@ -365,4 +433,114 @@ var _ = Describe("Domain", func() {
 			})
 		})
 	})
+
+	Describe("POST /v3/domains/:guid/relationships/shared_organizations", func() {
+		var payload *payloads.DomainShare
+
+		BeforeEach(func() {
+			payload = &payloads.DomainShare{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "org-guid"}},
+				},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
+
+			domainRepo.ShareDomainReturns([]string{"org-guid", "another-org-guid"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/domains/domain-guid/relationships/shared_organizations", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("shares the domain", func() {
+			Expect(domainRepo.GetDomainCallCount()).To(Equal(1))
+			_, _, actualDomainGUID := domainRepo.GetDomainArgsForCall(0)
+			Expect(actualDomainGUID).To(Equal("domain-guid"))
+
+			Expect(domainRepo.ShareDomainCallCount()).To(Equal(1))
+			_, actualAuthInfo, shareMessage := domainRepo.ShareDomainArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(shareMessage).To(Equal(repositories.ShareDomainMessage{
+				GUID:              "domain-guid",
+				OrganizationGUIDs: []string{"org-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("org-guid", "another-org-guid")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/domains/domain-guid/relationships/shared_organizations"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the domain is not accessible", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(nil, repositories.DomainResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DomainResourceType)
+			})
+		})
+
+		When("sharing the domain fails", func() {
+			BeforeEach(func() {
+				domainRepo.ShareDomainReturns(nil, errors.New("share-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/domains/:guid/relationships/shared_organizations/:org_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/domains/domain-guid/relationships/shared_organizations/org-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unshares the domain", func() {
+			Expect(domainRepo.UnshareDomainCallCount()).To(Equal(1))
+			_, actualAuthInfo, unshareMessage := domainRepo.UnshareDomainArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(unshareMessage).To(Equal(repositories.UnshareDomainMessage{
+				GUID:             "domain-guid",
+				OrganizationGUID: "org-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the domain is not accessible", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(nil, repositories.DomainResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DomainResourceType)
+			})
+		})
+
+		When("unsharing the domain fails", func() {
+			BeforeEach(func() {
+				domainRepo.UnshareDomainReturns(errors.New("unshare-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
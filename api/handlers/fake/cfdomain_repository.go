@@ -84,6 +84,34 @@ type CFDomainRepository struct {
 		result1 []repositories.DomainRecord
 		result2 error
 	}
+	ShareDomainStub        func(context.Context, authorization.Info, repositories.ShareDomainMessage) ([]string, error)
+	shareDomainMutex       sync.RWMutex
+	shareDomainArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareDomainMessage
+	}
+	shareDomainReturns struct {
+		result1 []string
+		result2 error
+	}
+	shareDomainReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	UnshareDomainStub        func(context.Context, authorization.Info, repositories.UnshareDomainMessage) error
+	unshareDomainMutex       sync.RWMutex
+	unshareDomainArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareDomainMessage
+	}
+	unshareDomainReturns struct {
+		result1 error
+	}
+	unshareDomainReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateDomainStub        func(context.Context, authorization.Info, repositories.UpdateDomainMessage) (repositories.DomainRecord, error)
 	updateDomainMutex       sync.RWMutex
 	updateDomainArgsForCall []struct {
@@ -430,6 +458,135 @@ func (fake *CFDomainRepository) ListDomainsReturnsOnCall(i int, result1 []reposi
 	}{result1, result2}
 }
 
+func (fake *CFDomainRepository) ShareDomain(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ShareDomainMessage) ([]string, error) {
+	fake.shareDomainMutex.Lock()
+	ret, specificReturn := fake.shareDomainReturnsOnCall[len(fake.shareDomainArgsForCall)]
+	fake.shareDomainArgsForCall = append(fake.shareDomainArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareDomainMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ShareDomainStub
+	fakeReturns := fake.shareDomainReturns
+	fake.recordInvocation("ShareDomain", []interface{}{arg1, arg2, arg3})
+	fake.shareDomainMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDomainRepository) ShareDomainCallCount() int {
+	fake.shareDomainMutex.RLock()
+	defer fake.shareDomainMutex.RUnlock()
+	return len(fake.shareDomainArgsForCall)
+}
+
+func (fake *CFDomainRepository) ShareDomainCalls(stub func(context.Context, authorization.Info, repositories.ShareDomainMessage) ([]string, error)) {
+	fake.shareDomainMutex.Lock()
+	defer fake.shareDomainMutex.Unlock()
+	fake.ShareDomainStub = stub
+}
+
+func (fake *CFDomainRepository) ShareDomainArgsForCall(i int) (context.Context, authorization.Info, repositories.ShareDomainMessage) {
+	fake.shareDomainMutex.RLock()
+	defer fake.shareDomainMutex.RUnlock()
+	argsForCall := fake.shareDomainArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDomainRepository) ShareDomainReturns(result1 []string, result2 error) {
+	fake.shareDomainMutex.Lock()
+	defer fake.shareDomainMutex.Unlock()
+	fake.ShareDomainStub = nil
+	fake.shareDomainReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDomainRepository) ShareDomainReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.shareDomainMutex.Lock()
+	defer fake.shareDomainMutex.Unlock()
+	fake.ShareDomainStub = nil
+	if fake.shareDomainReturnsOnCall == nil {
+		fake.shareDomainReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.shareDomainReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDomainRepository) UnshareDomain(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UnshareDomainMessage) error {
+	fake.unshareDomainMutex.Lock()
+	ret, specificReturn := fake.unshareDomainReturnsOnCall[len(fake.unshareDomainArgsForCall)]
+	fake.unshareDomainArgsForCall = append(fake.unshareDomainArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareDomainMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UnshareDomainStub
+	fakeReturns := fake.unshareDomainReturns
+	fake.recordInvocation("UnshareDomain", []interface{}{arg1, arg2, arg3})
+	fake.unshareDomainMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFDomainRepository) UnshareDomainCallCount() int {
+	fake.unshareDomainMutex.RLock()
+	defer fake.unshareDomainMutex.RUnlock()
+	return len(fake.unshareDomainArgsForCall)
+}
+
+func (fake *CFDomainRepository) UnshareDomainCalls(stub func(context.Context, authorization.Info, repositories.UnshareDomainMessage) error) {
+	fake.unshareDomainMutex.Lock()
+	defer fake.unshareDomainMutex.Unlock()
+	fake.UnshareDomainStub = stub
+}
+
+func (fake *CFDomainRepository) UnshareDomainArgsForCall(i int) (context.Context, authorization.Info, repositories.UnshareDomainMessage) {
+	fake.unshareDomainMutex.RLock()
+	defer fake.unshareDomainMutex.RUnlock()
+	argsForCall := fake.unshareDomainArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDomainRepository) UnshareDomainReturns(result1 error) {
+	fake.unshareDomainMutex.Lock()
+	defer fake.unshareDomainMutex.Unlock()
+	fake.UnshareDomainStub = nil
+	fake.unshareDomainReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFDomainRepository) UnshareDomainReturnsOnCall(i int, result1 error) {
+	fake.unshareDomainMutex.Lock()
+	defer fake.unshareDomainMutex.Unlock()
+	fake.UnshareDomainStub = nil
+	if fake.unshareDomainReturnsOnCall == nil {
+		fake.unshareDomainReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unshareDomainReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CFDomainRepository) UpdateDomain(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateDomainMessage) (repositories.DomainRecord, error) {
 	fake.updateDomainMutex.Lock()
 	ret, specificReturn := fake.updateDomainReturnsOnCall[len(fake.updateDomainArgsForCall)]
@@ -509,6 +666,10 @@ func (fake *CFDomainRepository) Invocations() map[string][][]interface{} {
 	defer fake.getDomainByNameMutex.RUnlock()
 	fake.listDomainsMutex.RLock()
 	defer fake.listDomainsMutex.RUnlock()
+	fake.shareDomainMutex.RLock()
+	defer fake.shareDomainMutex.RUnlock()
+	fake.unshareDomainMutex.RLock()
+	defer fake.unshareDomainMutex.RUnlock()
 	fake.updateDomainMutex.RLock()
 	defer fake.updateDomainMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -11,6 +11,21 @@ import (
 )
 
 type CFServicePlanRepository struct {
+	GetPlanStub        func(context.Context, authorization.Info, string) (repositories.ServicePlanResource, error)
+	getPlanMutex       sync.RWMutex
+	getPlanArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getPlanReturns struct {
+		result1 repositories.ServicePlanResource
+		result2 error
+	}
+	getPlanReturnsOnCall map[int]struct {
+		result1 repositories.ServicePlanResource
+		result2 error
+	}
 	ListPlansStub        func(context.Context, authorization.Info) ([]repositories.ServicePlanResource, error)
 	listPlansMutex       sync.RWMutex
 	listPlansArgsForCall []struct {
@@ -29,6 +44,72 @@ type CFServicePlanRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CFServicePlanRepository) GetPlan(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.ServicePlanResource, error) {
+	fake.getPlanMutex.Lock()
+	ret, specificReturn := fake.getPlanReturnsOnCall[len(fake.getPlanArgsForCall)]
+	fake.getPlanArgsForCall = append(fake.getPlanArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetPlanStub
+	fakeReturns := fake.getPlanReturns
+	fake.recordInvocation("GetPlan", []interface{}{arg1, arg2, arg3})
+	fake.getPlanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServicePlanRepository) GetPlanCallCount() int {
+	fake.getPlanMutex.RLock()
+	defer fake.getPlanMutex.RUnlock()
+	return len(fake.getPlanArgsForCall)
+}
+
+func (fake *CFServicePlanRepository) GetPlanCalls(stub func(context.Context, authorization.Info, string) (repositories.ServicePlanResource, error)) {
+	fake.getPlanMutex.Lock()
+	defer fake.getPlanMutex.Unlock()
+	fake.GetPlanStub = stub
+}
+
+func (fake *CFServicePlanRepository) GetPlanArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getPlanMutex.RLock()
+	defer fake.getPlanMutex.RUnlock()
+	argsForCall := fake.getPlanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServicePlanRepository) GetPlanReturns(result1 repositories.ServicePlanResource, result2 error) {
+	fake.getPlanMutex.Lock()
+	defer fake.getPlanMutex.Unlock()
+	fake.GetPlanStub = nil
+	fake.getPlanReturns = struct {
+		result1 repositories.ServicePlanResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) GetPlanReturnsOnCall(i int, result1 repositories.ServicePlanResource, result2 error) {
+	fake.getPlanMutex.Lock()
+	defer fake.getPlanMutex.Unlock()
+	fake.GetPlanStub = nil
+	if fake.getPlanReturnsOnCall == nil {
+		fake.getPlanReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServicePlanResource
+			result2 error
+		})
+	}
+	fake.getPlanReturnsOnCall[i] = struct {
+		result1 repositories.ServicePlanResource
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFServicePlanRepository) ListPlans(arg1 context.Context, arg2 authorization.Info) ([]repositories.ServicePlanResource, error) {
 	fake.listPlansMutex.Lock()
 	ret, specificReturn := fake.listPlansReturnsOnCall[len(fake.listPlansArgsForCall)]
@@ -97,6 +178,8 @@ func (fake *CFServicePlanRepository) ListPlansReturnsOnCall(i int, result1 []rep
 func (fake *CFServicePlanRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getPlanMutex.RLock()
+	defer fake.getPlanMutex.RUnlock()
 	fake.listPlansMutex.RLock()
 	defer fake.listPlansMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -56,7 +56,7 @@ type CFTaskRepository struct {
 		result1 repositories.TaskRecord
 		result2 error
 	}
-	ListTasksStub        func(context.Context, authorization.Info, repositories.ListTaskMessage) ([]repositories.TaskRecord, error)
+	ListTasksStub        func(context.Context, authorization.Info, repositories.ListTaskMessage) (repositories.ListResult[repositories.TaskRecord], error)
 	listTasksMutex       sync.RWMutex
 	listTasksArgsForCall []struct {
 		arg1 context.Context
@@ -64,11 +64,11 @@ type CFTaskRepository struct {
 		arg3 repositories.ListTaskMessage
 	}
 	listTasksReturns struct {
-		result1 []repositories.TaskRecord
+		result1 repositories.ListResult[repositories.TaskRecord]
 		result2 error
 	}
 	listTasksReturnsOnCall map[int]struct {
-		result1 []repositories.TaskRecord
+		result1 repositories.ListResult[repositories.TaskRecord]
 		result2 error
 	}
 	PatchTaskMetadataStub        func(context.Context, authorization.Info, repositories.PatchTaskMetadataMessage) (repositories.TaskRecord, error)
@@ -288,7 +288,7 @@ func (fake *CFTaskRepository) GetTaskReturnsOnCall(i int, result1 repositories.T
 	}{result1, result2}
 }
 
-func (fake *CFTaskRepository) ListTasks(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListTaskMessage) ([]repositories.TaskRecord, error) {
+func (fake *CFTaskRepository) ListTasks(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListTaskMessage) (repositories.ListResult[repositories.TaskRecord], error) {
 	fake.listTasksMutex.Lock()
 	ret, specificReturn := fake.listTasksReturnsOnCall[len(fake.listTasksArgsForCall)]
 	fake.listTasksArgsForCall = append(fake.listTasksArgsForCall, struct {
@@ -315,7 +315,7 @@ func (fake *CFTaskRepository) ListTasksCallCount() int {
 	return len(fake.listTasksArgsForCall)
 }
 
-func (fake *CFTaskRepository) ListTasksCalls(stub func(context.Context, authorization.Info, repositories.ListTaskMessage) ([]repositories.TaskRecord, error)) {
+func (fake *CFTaskRepository) ListTasksCalls(stub func(context.Context, authorization.Info, repositories.ListTaskMessage) (repositories.ListResult[repositories.TaskRecord], error)) {
 	fake.listTasksMutex.Lock()
 	defer fake.listTasksMutex.Unlock()
 	fake.ListTasksStub = stub
@@ -328,28 +328,28 @@ func (fake *CFTaskRepository) ListTasksArgsForCall(i int) (context.Context, auth
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *CFTaskRepository) ListTasksReturns(result1 []repositories.TaskRecord, result2 error) {
+func (fake *CFTaskRepository) ListTasksReturns(result1 repositories.ListResult[repositories.TaskRecord], result2 error) {
 	fake.listTasksMutex.Lock()
 	defer fake.listTasksMutex.Unlock()
 	fake.ListTasksStub = nil
 	fake.listTasksReturns = struct {
-		result1 []repositories.TaskRecord
+		result1 repositories.ListResult[repositories.TaskRecord]
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *CFTaskRepository) ListTasksReturnsOnCall(i int, result1 []repositories.TaskRecord, result2 error) {
+func (fake *CFTaskRepository) ListTasksReturnsOnCall(i int, result1 repositories.ListResult[repositories.TaskRecord], result2 error) {
 	fake.listTasksMutex.Lock()
 	defer fake.listTasksMutex.Unlock()
 	fake.ListTasksStub = nil
 	if fake.listTasksReturnsOnCall == nil {
 		fake.listTasksReturnsOnCall = make(map[int]struct {
-			result1 []repositories.TaskRecord
+			result1 repositories.ListResult[repositories.TaskRecord]
 			result2 error
 		})
 	}
 	fake.listTasksReturnsOnCall[i] = struct {
-		result1 []repositories.TaskRecord
+		result1 repositories.ListResult[repositories.TaskRecord]
 		result2 error
 	}{result1, result2}
 }
@@ -0,0 +1,174 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FeatureFlag", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		featureFlagRepo  *fake.CFFeatureFlagRepository
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		featureFlagRepo = new(fake.CFFeatureFlagRepository)
+
+		apiHandler := handlers.NewFeatureFlag(*serverURL, requestValidator, featureFlagRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/feature_flags", func() {
+		BeforeEach(func() {
+			featureFlagRepo.ListFeatureFlagsReturns([]repositories.FeatureFlagRecord{
+				{Name: "app_scaling", Enabled: true},
+				{Name: "task_creation", Enabled: false},
+			}, nil)
+
+			req = createHttpRequest("GET", "/v3/feature_flags", nil)
+		})
+
+		It("lists the feature flags", func() {
+			Expect(featureFlagRepo.ListFeatureFlagsCallCount()).To(Equal(1))
+			_, actualAuthInfo := featureFlagRepo.ListFeatureFlagsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].name", "app_scaling"),
+				MatchJSONPath("$.resources[0].enabled", BeTrue()),
+				MatchJSONPath("$.resources[1].name", "task_creation"),
+				MatchJSONPath("$.resources[1].enabled", BeFalse()),
+			)))
+		})
+
+		When("listing the feature flags fails", func() {
+			BeforeEach(func() {
+				featureFlagRepo.ListFeatureFlagsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/feature_flags/{name}", func() {
+		BeforeEach(func() {
+			featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{
+				Name:    "diego_docker",
+				Enabled: true,
+			}, nil)
+
+			req = createHttpRequest("GET", "/v3/feature_flags/diego_docker", nil)
+		})
+
+		It("returns the feature flag", func() {
+			Expect(featureFlagRepo.GetFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := featureFlagRepo.GetFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal("diego_docker"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "diego_docker"),
+				MatchJSONPath("$.enabled", BeTrue()),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/feature_flags/diego_docker"),
+			)))
+		})
+
+		When("the feature flag does not exist", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.FeatureFlagResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/feature_flags/{name}", func() {
+		var payload *payloads.FeatureFlagPatch
+
+		BeforeEach(func() {
+			payload = &payloads.FeatureFlagPatch{
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("no tasks"),
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
+
+			featureFlagRepo.PatchFeatureFlagReturns(repositories.FeatureFlagRecord{
+				Name:               "task_creation",
+				Enabled:            false,
+				CustomErrorMessage: "no tasks",
+			}, nil)
+
+			req = createHttpRequest("PATCH", "/v3/feature_flags/task_creation", strings.NewReader("the-json-body"))
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("patches the feature flag", func() {
+			Expect(featureFlagRepo.PatchFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := featureFlagRepo.PatchFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.PatchFeatureFlagMessage{
+				Name:               "task_creation",
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("no tasks"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "task_creation"),
+				MatchJSONPath("$.enabled", BeFalse()),
+				MatchJSONPath("$.custom_error_message", "no tasks"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("boom")
+			})
+		})
+
+		When("the user is not an admin", func() {
+			BeforeEach(func() {
+				featureFlagRepo.PatchFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewForbiddenError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+})
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	IsolationSegmentsPath                = "/v3/isolation_segments"
+	IsolationSegmentPath                 = "/v3/isolation_segments/{guid}"
+	IsolationSegmentOrganizationsRelPath = "/v3/isolation_segments/{guid}/relationships/organizations"
+	IsolationSegmentOrganizationRelPath  = "/v3/isolation_segments/{guid}/relationships/organizations/{org_guid}"
+	SpaceIsolationSegmentRelPath         = "/v3/spaces/{guid}/relationships/isolation_segment"
+)
+
+//counterfeiter:generate -o fake -fake-name CFIsolationSegmentRepository . CFIsolationSegmentRepository
+
+type CFIsolationSegmentRepository interface {
+	CreateIsolationSegment(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	GetIsolationSegment(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)
+	ListIsolationSegments(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)
+	PatchIsolationSegment(context.Context, authorization.Info, repositories.PatchIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	EntitleIsolationSegment(context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) ([]string, error)
+	RevokeIsolationSegment(context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) error
+	DeleteIsolationSegment(context.Context, authorization.Info, string) error
+	GetSpaceIsolationSegment(context.Context, authorization.Info, string) (string, error)
+	AssignSpaceIsolationSegment(context.Context, authorization.Info, repositories.AssignSpaceIsolationSegmentMessage) (string, error)
+}
+
+type IsolationSegment struct {
+	serverURL            url.URL
+	requestValidator     RequestValidator
+	isolationSegmentRepo CFIsolationSegmentRepository
+}
+
+func NewIsolationSegment(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	isolationSegmentRepo CFIsolationSegmentRepository,
+) *IsolationSegment {
+	return &IsolationSegment{
+		serverURL:            serverURL,
+		requestValidator:     requestValidator,
+		isolationSegmentRepo: isolationSegmentRepo,
+	}
+}
+
+func (h *IsolationSegment) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.create")
+
+	var payload payloads.IsolationSegmentCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.CreateIsolationSegment(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create isolation segment")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list")
+
+	payload := new(payloads.IsolationSegmentList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode request query parameters")
+	}
+
+	isolationSegments, err := h.isolationSegmentRepo.ListIsolationSegments(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list isolation segments")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForIsolationSegment, isolationSegments, h.serverURL, *r.URL)), nil
+}
+
+func (h *IsolationSegment) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.update")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentPatch
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.PatchIsolationSegment(r.Context(), authInfo, payload.ToMessage(isolationSegmentGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to patch isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.delete")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	err = h.isolationSegmentRepo.DeleteIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) listOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list-orgs")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrganizations(isolationSegmentGUID, isolationSegment.OrganizationGUIDs, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) entitleOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.entitle-orgs")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentEntitle
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	orgGUIDs, err := h.isolationSegmentRepo.EntitleIsolationSegment(r.Context(), authInfo, payload.ToMessage(isolationSegmentGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to entitle isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrganizations(isolationSegmentGUID, orgGUIDs, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) revokeOrg(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.revoke-org")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+	orgGUID := routing.URLParam(r, "org_guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	err = h.isolationSegmentRepo.RevokeIsolationSegment(r.Context(), authInfo, repositories.RevokeIsolationSegmentMessage{
+		GUID:             isolationSegmentGUID,
+		OrganizationGUID: orgGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to revoke isolation segment", "guid", isolationSegmentGUID, "orgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) getForSpace(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get-for-space")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	isolationSegmentGUID, err := h.isolationSegmentRepo.GetSpaceIsolationSegment(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space isolation segment", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegment(spaceGUID, isolationSegmentGUID, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) assignToSpace(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.assign-to-space")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SpaceIsolationSegmentPatch
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetSpaceIsolationSegment(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space isolation segment", "spaceGUID", spaceGUID)
+	}
+
+	isolationSegmentGUID, err := h.isolationSegmentRepo.AssignSpaceIsolationSegment(r.Context(), authInfo, payload.ToMessage(spaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to assign space isolation segment", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegment(spaceGUID, isolationSegmentGUID, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *IsolationSegment) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: IsolationSegmentsPath, Handler: h.create},
+		{Method: "GET", Pattern: IsolationSegmentsPath, Handler: h.list},
+		{Method: "GET", Pattern: IsolationSegmentPath, Handler: h.get},
+		{Method: "PATCH", Pattern: IsolationSegmentPath, Handler: h.update},
+		{Method: "DELETE", Pattern: IsolationSegmentPath, Handler: h.delete},
+		{Method: "GET", Pattern: IsolationSegmentOrganizationsRelPath, Handler: h.listOrgs},
+		{Method: "POST", Pattern: IsolationSegmentOrganizationsRelPath, Handler: h.entitleOrgs},
+		{Method: "DELETE", Pattern: IsolationSegmentOrganizationRelPath, Handler: h.revokeOrg},
+		{Method: "GET", Pattern: SpaceIsolationSegmentRelPath, Handler: h.getForSpace},
+		{Method: "PATCH", Pattern: SpaceIsolationSegmentRelPath, Handler: h.assignToSpace},
+	}
+}
@@ -0,0 +1,437 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsolationSegment", func() {
+	var (
+		apiHandler           *handlers.IsolationSegment
+		isolationSegmentRepo *fake.CFIsolationSegmentRepository
+		requestValidator     *fake.RequestValidator
+		req                  *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		isolationSegmentRepo = new(fake.CFIsolationSegmentRepository)
+		apiHandler = handlers.NewIsolationSegment(
+			*serverURL,
+			requestValidator,
+			isolationSegmentRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+			GUID:              "segment-guid",
+			Name:              "my-segment",
+			OrganizationGUIDs: []string{"org-1"},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/isolation_segments", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentCreate{
+				Name: "my-segment",
+			})
+
+			isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "segment-guid",
+				Name: "my-segment",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/isolation_segments", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the isolation segment", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(isolationSegmentRepo.CreateIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := isolationSegmentRepo.CreateIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateIsolationSegmentMessage{
+				Name: "my-segment",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "segment-guid"),
+				MatchJSONPath("$.name", "my-segment"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/segment-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the isolation segment fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments/segment-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the isolation segment", func() {
+			Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := isolationSegmentRepo.GetIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("segment-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "segment-guid"),
+				MatchJSONPath("$.name", "my-segment"),
+			)))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewForbiddenError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments", func() {
+		BeforeEach(func() {
+			isolationSegmentRepo.ListIsolationSegmentsReturns([]repositories.IsolationSegmentRecord{
+				{GUID: "segment-1"},
+				{GUID: "segment-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.IsolationSegmentList{
+				OrganizationGUIDs: "org-1",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the isolation segments", func() {
+			Expect(isolationSegmentRepo.ListIsolationSegmentsCallCount()).To(Equal(1))
+			_, _, listMessage := isolationSegmentRepo.ListIsolationSegmentsArgsForCall(0)
+			Expect(listMessage).To(Equal(repositories.ListIsolationSegmentsMessage{
+				GUIDs:             []string{},
+				Names:             []string{},
+				OrganizationGUIDs: []string{"org-1"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/isolation_segments?page=1&per_page=50"),
+				MatchJSONPath("$.resources[*].guid", ConsistOf("segment-1", "segment-2")),
+			)))
+		})
+
+		When("listing the isolation segments fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.ListIsolationSegmentsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/isolation_segments/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentPatch{
+				Name: tools.PtrTo("new-name"),
+			})
+
+			isolationSegmentRepo.PatchIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "segment-guid",
+				Name: "new-name",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/isolation_segments/segment-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("patches the isolation segment", func() {
+			Expect(isolationSegmentRepo.PatchIsolationSegmentCallCount()).To(Equal(1))
+			_, _, patchMessage := isolationSegmentRepo.PatchIsolationSegmentArgsForCall(0)
+			Expect(patchMessage).To(Equal(repositories.PatchIsolationSegmentMessage{
+				GUID: "segment-guid",
+				Name: tools.PtrTo("new-name"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+				Expect(isolationSegmentRepo.PatchIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/isolation_segments/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/isolation_segments/segment-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the isolation segment", func() {
+			Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(Equal(1))
+			_, _, actualGUID := isolationSegmentRepo.DeleteIsolationSegmentArgsForCall(0)
+			Expect(actualGUID).To(Equal("segment-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("organizations are entitled to the isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.DeleteIsolationSegmentReturns(apierrors.NewUnprocessableEntityError(nil, "Revoke the Organization entitlements for your Isolation Segment."))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Revoke the Organization entitlements for your Isolation Segment.")
+			})
+		})
+
+		When("the user cannot get the isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewForbiddenError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+				Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/:guid/relationships/organizations", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments/segment-guid/relationships/organizations", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the entitled organizations", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("org-1")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/segment-guid/relationships/organizations"),
+			)))
+		})
+	})
+
+	Describe("POST /v3/isolation_segments/:guid/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentEntitle{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "org-2"}},
+				},
+			})
+
+			isolationSegmentRepo.EntitleIsolationSegmentReturns([]string{"org-1", "org-2"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/isolation_segments/segment-guid/relationships/organizations", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("entitles the organizations to the isolation segment", func() {
+			Expect(isolationSegmentRepo.EntitleIsolationSegmentCallCount()).To(Equal(1))
+			_, _, entitleMessage := isolationSegmentRepo.EntitleIsolationSegmentArgsForCall(0)
+			Expect(entitleMessage).To(Equal(repositories.EntitleIsolationSegmentMessage{
+				GUID:              "segment-guid",
+				OrganizationGUIDs: []string{"org-2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data[*].guid", ConsistOf("org-1", "org-2"))))
+		})
+
+		When("an organization does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.EntitleIsolationSegmentReturns(nil, apierrors.NewUnprocessableEntityError(nil, "Organization with guid 'org-2' does not exist, or you do not have access to it."))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Organization with guid 'org-2' does not exist, or you do not have access to it.")
+			})
+		})
+	})
+
+	Describe("DELETE /v3/isolation_segments/:guid/relationships/organizations/:org_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/isolation_segments/segment-guid/relationships/organizations/org-1", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("revokes the entitlement of the organization", func() {
+			Expect(isolationSegmentRepo.RevokeIsolationSegmentCallCount()).To(Equal(1))
+			_, _, revokeMessage := isolationSegmentRepo.RevokeIsolationSegmentArgsForCall(0)
+			Expect(revokeMessage).To(Equal(repositories.RevokeIsolationSegmentMessage{
+				GUID:             "segment-guid",
+				OrganizationGUID: "org-1",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("a space in the organization is assigned the isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.RevokeIsolationSegmentReturns(apierrors.NewUnprocessableEntityError(nil, "space is assigned"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("space is assigned")
+			})
+		})
+	})
+
+	Describe("GET /v3/spaces/:guid/relationships/isolation_segment", func() {
+		BeforeEach(func() {
+			isolationSegmentRepo.GetSpaceIsolationSegmentReturns("segment-guid", nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/spaces/space-guid/relationships/isolation_segment", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the isolation segment of the space", func() {
+			Expect(isolationSegmentRepo.GetSpaceIsolationSegmentCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := isolationSegmentRepo.GetSpaceIsolationSegmentArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data.guid", "segment-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"),
+			)))
+		})
+
+		When("the user cannot get the space", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetSpaceIsolationSegmentReturns("", apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/spaces/:guid/relationships/isolation_segment", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceIsolationSegmentPatch{
+				Data: &payloads.RelationshipData{GUID: "segment-guid"},
+			})
+
+			isolationSegmentRepo.AssignSpaceIsolationSegmentReturns("segment-guid", nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/spaces/space-guid/relationships/isolation_segment", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("assigns the isolation segment to the space", func() {
+			Expect(isolationSegmentRepo.AssignSpaceIsolationSegmentCallCount()).To(Equal(1))
+			_, _, assignMessage := isolationSegmentRepo.AssignSpaceIsolationSegmentArgsForCall(0)
+			Expect(assignMessage).To(Equal(repositories.AssignSpaceIsolationSegmentMessage{
+				SpaceGUID:            "space-guid",
+				IsolationSegmentGUID: "segment-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data.guid", "segment-guid")))
+		})
+
+		When("the isolation segment is unassigned", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceIsolationSegmentPatch{})
+				isolationSegmentRepo.AssignSpaceIsolationSegmentReturns("", nil)
+			})
+
+			It("returns a null relationship", func() {
+				_, _, assignMessage := isolationSegmentRepo.AssignSpaceIsolationSegmentArgsForCall(0)
+				Expect(assignMessage.IsolationSegmentGUID).To(BeEmpty())
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data", BeNil())))
+			})
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetSpaceIsolationSegmentReturns("", apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+				Expect(isolationSegmentRepo.AssignSpaceIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+
+		When("the organization is not entitled to the isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.AssignSpaceIsolationSegmentReturns("", apierrors.NewUnprocessableEntityError(nil, "not entitled"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("not entitled")
+			})
+		})
+	})
+})
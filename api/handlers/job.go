@@ -26,6 +26,12 @@ const (
 	DomainDeleteJobType        = "domain.delete"
 	RoleDeleteJobType          = "role.delete"
 	ServiceBrokerCreateJobType = "service_broker.create"
+	OrgQuotaDeleteJobType      = "organization_quota.delete"
+	SpaceQuotaDeleteJobType    = "space_quota.delete"
+	SecurityGroupDeleteJobType = "security_group.delete"
+
+	ServiceInstanceCreateJobType = "service_instance.create"
+	ServiceInstanceDeleteJobType = "service_instance.delete"
 
 	JobTimeoutDuration = 120.0
 )
@@ -189,6 +195,17 @@ func (h *Job) handleStateJob(ctx context.Context, repository StateRepository, jo
 			h.serverURL,
 		), nil
 
+	case model.CFResourceStatusFailed:
+		return presenter.ForJob(job,
+			[]presenter.JobResponseError{{
+				Code:   10008,
+				Detail: state.Details,
+				Title:  "CF-UnprocessableEntity",
+			}},
+			presenter.StateFailed,
+			h.serverURL,
+		), nil
+
 	default:
 		return presenter.ForJob(job,
 			[]presenter.JobResponseError{},
@@ -192,6 +192,23 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		When("the resource state is Failed", func() {
+			BeforeEach(func() {
+				stateRepo.GetStateReturns(model.CFResourceState{
+					Status:  model.CFResourceStatusFailed,
+					Details: "broker said no",
+				}, nil)
+			})
+
+			It("returns a failed status with the failure details", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.state", "FAILED"),
+					MatchJSONPath("$.errors[0].detail", "broker said no"),
+					MatchJSONPath("$.errors[0].title", "CF-UnprocessableEntity"),
+				)))
+			})
+		})
+
 		When("the user does not have permission to see the resource", func() {
 			BeforeEach(func() {
 				stateRepo.GetStateReturns(model.CFResourceState{}, fmt.Errorf("wrapped err: %w", apierrors.NewForbiddenError(nil, "foo")))
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	NetworkPoliciesPath       = "/networking/v1/external/policies"
+	NetworkPoliciesDeletePath = "/networking/v1/external/policies/delete"
+
+	invalidNetworkPolicyAppsDetail = "One or more applications cannot be found or accessed."
+)
+
+//counterfeiter:generate -o fake -fake-name CFNetworkPolicyRepository . CFNetworkPolicyRepository
+
+type CFNetworkPolicyRepository interface {
+	CreateNetworkPolicy(context.Context, authorization.Info, repositories.CreateNetworkPolicyMessage) (repositories.NetworkPolicyRecord, error)
+	ListNetworkPolicies(context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) ([]repositories.NetworkPolicyRecord, error)
+	DeleteNetworkPolicy(context.Context, authorization.Info, repositories.DeleteNetworkPolicyMessage) error
+}
+
+// NetworkPolicy serves the subset of the CF policy server external API the
+// cf cli uses to manage container-to-container network policies
+type NetworkPolicy struct {
+	requestValidator  RequestValidator
+	networkPolicyRepo CFNetworkPolicyRepository
+	appRepo           CFAppRepository
+}
+
+func NewNetworkPolicy(
+	requestValidator RequestValidator,
+	networkPolicyRepo CFNetworkPolicyRepository,
+	appRepo CFAppRepository,
+) *NetworkPolicy {
+	return &NetworkPolicy{
+		requestValidator:  requestValidator,
+		networkPolicyRepo: networkPolicyRepo,
+		appRepo:           appRepo,
+	}
+}
+
+func (h *NetworkPolicy) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.network-policy.create")
+
+	var payload payloads.NetworkPolicies
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	for _, policy := range payload.Policies {
+		sourceApp, err := h.getApp(r.Context(), authInfo, policy.Source.ID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to get source app", "appGUID", policy.Source.ID)
+		}
+
+		destinationApp, err := h.getApp(r.Context(), authInfo, policy.Destination.ID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to get destination app", "appGUID", policy.Destination.ID)
+		}
+
+		_, err = h.networkPolicyRepo.CreateNetworkPolicy(r.Context(), authInfo, policy.ToCreateMessage(sourceApp.SpaceGUID, destinationApp.SpaceGUID))
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to create network policy")
+		}
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{}), nil
+}
+
+func (h *NetworkPolicy) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.network-policy.list")
+
+	payload := new(payloads.NetworkPolicyList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode request query parameters")
+	}
+
+	policies, err := h.networkPolicyRepo.ListNetworkPolicies(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list network policies")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForNetworkPolicies(policies)), nil
+}
+
+func (h *NetworkPolicy) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.network-policy.delete")
+
+	var payload payloads.NetworkPolicies
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	for _, policy := range payload.Policies {
+		_, err := h.getApp(r.Context(), authInfo, policy.Source.ID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to get source app", "appGUID", policy.Source.ID)
+		}
+
+		destinationApp, err := h.getApp(r.Context(), authInfo, policy.Destination.ID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to get destination app", "appGUID", policy.Destination.ID)
+		}
+
+		err = h.networkPolicyRepo.DeleteNetworkPolicy(r.Context(), authInfo, policy.ToDeleteMessage(destinationApp.SpaceGUID))
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to delete network policy")
+		}
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{}), nil
+}
+
+func (h *NetworkPolicy) getApp(ctx context.Context, authInfo authorization.Info, appGUID string) (repositories.AppRecord, error) {
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	return app, apierrors.AsUnprocessableEntity(err, invalidNetworkPolicyAppsDetail, apierrors.NotFoundError{}, apierrors.ForbiddenError{})
+}
+
+func (h *NetworkPolicy) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *NetworkPolicy) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: NetworkPoliciesPath, Handler: h.create},
+		{Method: "GET", Pattern: NetworkPoliciesPath, Handler: h.list},
+		{Method: "POST", Pattern: NetworkPoliciesDeletePath, Handler: h.delete},
+	}
+}
@@ -0,0 +1,217 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicy", func() {
+	var (
+		requestValidator  *fake.RequestValidator
+		networkPolicyRepo *fake.CFNetworkPolicyRepository
+		appRepo           *fake.CFAppRepository
+		req               *http.Request
+		policiesPayload   payloads.NetworkPolicies
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		networkPolicyRepo = new(fake.CFNetworkPolicyRepository)
+		appRepo = new(fake.CFAppRepository)
+
+		appRepo.GetAppStub = func(_ context.Context, _ authorization.Info, appGUID string) (repositories.AppRecord, error) {
+			return repositories.AppRecord{GUID: appGUID, SpaceGUID: appGUID + "-space"}, nil
+		}
+
+		policiesPayload = payloads.NetworkPolicies{
+			Policies: []payloads.NetworkPolicy{{
+				Source: payloads.NetworkPolicySource{ID: "source-app"},
+				Destination: payloads.NetworkPolicyDestination{
+					ID:       "destination-app",
+					Protocol: "tcp",
+					Ports:    payloads.NetworkPolicyPorts{Start: 8080, End: 8090},
+				},
+			}},
+		}
+		requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&policiesPayload)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.LoadRoutes(handlers.NewNetworkPolicy(requestValidator, networkPolicyRepo, appRepo))
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /networking/v1/external/policies", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/networking/v1/external/policies", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the policy in the space of the destination app", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(2))
+			_, actualAuthInfo, _ := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(networkPolicyRepo.CreateNetworkPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := networkPolicyRepo.CreateNetworkPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateNetworkPolicyMessage{
+				SourceAppGUID:        "source-app",
+				SourceSpaceGUID:      "source-app-space",
+				DestinationAppGUID:   "destination-app",
+				DestinationSpaceGUID: "destination-app-space",
+				Protocol:             "tcp",
+				StartPort:            8080,
+				EndPort:              8090,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{}`)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				Expect(networkPolicyRepo.CreateNetworkPolicyCallCount()).To(BeZero())
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("an app cannot be accessed", func() {
+			BeforeEach(func() {
+				appRepo.GetAppStub = nil
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an error", func() {
+				Expect(networkPolicyRepo.CreateNetworkPolicyCallCount()).To(BeZero())
+				expectUnprocessableEntityError("One or more applications cannot be found or accessed.")
+			})
+		})
+
+		When("creating the policy fails", func() {
+			BeforeEach(func() {
+				networkPolicyRepo.CreateNetworkPolicyReturns(repositories.NetworkPolicyRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /networking/v1/external/policies", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.NetworkPolicyList{
+				IDs: "app-1,app-2",
+			})
+			networkPolicyRepo.ListNetworkPoliciesReturns([]repositories.NetworkPolicyRecord{{
+				SourceAppGUID:      "app-1",
+				DestinationAppGUID: "app-2",
+				Protocol:           "udp",
+				StartPort:          53,
+				EndPort:            53,
+			}}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/networking/v1/external/policies?id=app-1,app-2", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the policies of the apps", func() {
+			Expect(networkPolicyRepo.ListNetworkPoliciesCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := networkPolicyRepo.ListNetworkPoliciesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.AppGUIDs).To(ConsistOf("app-1", "app-2"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+				"total_policies": 1,
+				"policies": [{
+					"source": {"id": "app-1"},
+					"destination": {"id": "app-2", "protocol": "udp", "ports": {"start": 53, "end": 53}}
+				}]
+			}`)))
+		})
+
+		When("listing the policies fails", func() {
+			BeforeEach(func() {
+				networkPolicyRepo.ListNetworkPoliciesReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /networking/v1/external/policies/delete", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/networking/v1/external/policies/delete", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the policy from the space of the destination app", func() {
+			Expect(networkPolicyRepo.DeleteNetworkPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := networkPolicyRepo.DeleteNetworkPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.DeleteNetworkPolicyMessage{
+				SourceAppGUID:        "source-app",
+				DestinationAppGUID:   "destination-app",
+				DestinationSpaceGUID: "destination-app-space",
+				Protocol:             "tcp",
+				StartPort:            8080,
+				EndPort:              8090,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{}`)))
+		})
+
+		When("an app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppStub = nil
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an error", func() {
+				Expect(networkPolicyRepo.DeleteNetworkPolicyCallCount()).To(BeZero())
+				expectUnprocessableEntityError("One or more applications cannot be found or accessed.")
+			})
+		})
+
+		When("deleting the policy fails", func() {
+			BeforeEach(func() {
+				networkPolicyRepo.DeleteNetworkPolicyReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
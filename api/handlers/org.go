@@ -130,6 +130,8 @@ func (h *Org) list(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to fetch orgs")
 	}
 
+	h.sortList(orgs, listFilter.OrderBy)
+
 	resp := routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForOrg, orgs, h.apiBaseURL, *r.URL))
 	notAfter, certParsed := decodePEMNotAfter(authInfo.CertData)
 
@@ -140,6 +142,14 @@ func (h *Org) list(r *http.Request) (*routing.Response, error) {
 	return resp, nil
 }
 
+func (h *Org) sortList(orgs []repositories.OrgRecord, order string) {
+	sortByOrder(orgs, order, orderBy[repositories.OrgRecord]{
+		"created_at": func(a, b repositories.OrgRecord) bool { return createdBefore(a.CreatedAt, b.CreatedAt) },
+		"updated_at": func(a, b repositories.OrgRecord) bool { return updatedBefore(a.UpdatedAt, b.UpdatedAt) },
+		"name":       func(a, b repositories.OrgRecord) bool { return a.Name < b.Name },
+	})
+}
+
 func (h *Org) listDomains(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org.list-domains")
@@ -155,7 +165,10 @@ func (h *Org) listDomains(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Unable to parse request query parameters")
 	}
 
-	domainList, err := h.domainRepo.ListDomains(r.Context(), authInfo, domainListFilter.ToMessage())
+	listMessage := domainListFilter.ToMessage()
+	listMessage.OrganizationGUID = orgGUID
+
+	domainList, err := h.domainRepo.ListDomains(r.Context(), authInfo, listMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch domain(s) from Kubernetes")
 	}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	OrgQuotasPath                = "/v3/organization_quotas"
+	OrgQuotaPath                 = "/v3/organization_quotas/{guid}"
+	OrgQuotaOrganizationsRelPath = "/v3/organization_quotas/{guid}/relationships/organizations"
+)
+
+//counterfeiter:generate -o fake -fake-name CFOrgQuotaRepository . CFOrgQuotaRepository
+
+type CFOrgQuotaRepository interface {
+	CreateOrgQuota(context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	GetOrgQuota(context.Context, authorization.Info, string) (repositories.OrgQuotaRecord, error)
+	ListOrgQuotas(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)
+	PatchOrgQuota(context.Context, authorization.Info, repositories.PatchOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	ApplyOrgQuota(context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) ([]string, error)
+	DeleteOrgQuota(context.Context, authorization.Info, string) error
+}
+
+type OrgQuota struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	orgQuotaRepo     CFOrgQuotaRepository
+}
+
+func NewOrgQuota(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	orgQuotaRepo CFOrgQuotaRepository,
+) *OrgQuota {
+	return &OrgQuota{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		orgQuotaRepo:     orgQuotaRepo,
+	}
+}
+
+func (h *OrgQuota) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.create")
+
+	var payload payloads.OrgQuotaCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	orgQuota, err := h.orgQuotaRepo.CreateOrgQuota(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create org quota")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.get")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	orgQuota, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get org quota", "guid", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.list")
+
+	payload := new(payloads.OrgQuotaList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode request query parameters")
+	}
+
+	orgQuotas, err := h.orgQuotaRepo.ListOrgQuotas(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list org quotas")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForOrgQuota, orgQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *OrgQuota) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.update")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.OrgQuotaPatch
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get org quota", "guid", orgQuotaGUID)
+	}
+
+	orgQuota, err := h.orgQuotaRepo.PatchOrgQuota(r.Context(), authInfo, payload.ToMessage(orgQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to patch org quota", "guid", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) applyToOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.apply-to-orgs")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.OrgQuotaApply
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get org quota", "guid", orgQuotaGUID)
+	}
+
+	orgGUIDs, err := h.orgQuotaRepo.ApplyOrgQuota(r.Context(), authInfo, payload.ToMessage(orgQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to apply org quota", "guid", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuotaOrganizations(orgQuotaGUID, orgGUIDs, h.serverURL)), nil
+}
+
+func (h *OrgQuota) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.delete")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	_, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get org quota", "guid", orgQuotaGUID)
+	}
+
+	err = h.orgQuotaRepo.DeleteOrgQuota(r.Context(), authInfo, orgQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete org quota", "guid", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(orgQuotaGUID, presenter.OrgQuotaDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *OrgQuota) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *OrgQuota) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: OrgQuotasPath, Handler: h.create},
+		{Method: "GET", Pattern: OrgQuotasPath, Handler: h.list},
+		{Method: "GET", Pattern: OrgQuotaPath, Handler: h.get},
+		{Method: "PATCH", Pattern: OrgQuotaPath, Handler: h.update},
+		{Method: "DELETE", Pattern: OrgQuotaPath, Handler: h.delete},
+		{Method: "POST", Pattern: OrgQuotaOrganizationsRelPath, Handler: h.applyToOrgs},
+	}
+}
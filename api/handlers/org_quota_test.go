@@ -0,0 +1,338 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OrgQuota", func() {
+	var (
+		apiHandler       *handlers.OrgQuota
+		orgQuotaRepo     *fake.CFOrgQuotaRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		orgQuotaRepo = new(fake.CFOrgQuotaRepository)
+		apiHandler = handlers.NewOrgQuota(
+			*serverURL,
+			requestValidator,
+			orgQuotaRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{
+			GUID: "quota-guid",
+			Name: "my-quota",
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/organization_quotas", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaCreate{
+				Name: "my-quota",
+				QuotaLimits: payloads.QuotaLimits{
+					Apps: payloads.AppsQuota{
+						TotalMemoryInMB: payloads.QuotaLimit{IsSet: true, Value: tools.PtrTo[int64](1024)},
+					},
+				},
+				Relationships: &payloads.OrgQuotaRelationships{
+					Organizations: payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "org-guid"}},
+					},
+				},
+			})
+
+			orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID: "quota-guid",
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					TotalMemoryMB: tools.PtrTo[int64](1024),
+				},
+				OrganizationGUIDs: []string{"org-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/organization_quotas", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the org quota", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := orgQuotaRepo.CreateOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateOrgQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					TotalMemoryMB: tools.PtrTo[int64](1024),
+				},
+				OrganizationGUIDs: []string{"org-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.apps.total_memory_in_mb", BeEquivalentTo(1024)),
+				MatchJSONPath("$.relationships.organizations.data[0].guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/organization_quotas/quota-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the org quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/organization_quotas/quota-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the org quota", func() {
+			Expect(orgQuotaRepo.GetOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := orgQuotaRepo.GetOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.name", "my-quota"),
+			)))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas", func() {
+		BeforeEach(func() {
+			orgQuotaRepo.ListOrgQuotasReturns([]repositories.OrgQuotaRecord{
+				{GUID: "quota-1"},
+				{GUID: "quota-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OrgQuotaList{
+				Names: "q1,q2",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/organization_quotas", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the org quotas", func() {
+			Expect(orgQuotaRepo.ListOrgQuotasCallCount()).To(Equal(1))
+			_, _, listMessage := orgQuotaRepo.ListOrgQuotasArgsForCall(0)
+			Expect(listMessage).To(Equal(repositories.ListOrgQuotasMessage{
+				GUIDs:             []string{},
+				Names:             []string{"q1", "q2"},
+				OrganizationGUIDs: []string{},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/organization_quotas?page=1&per_page=50"),
+				MatchJSONPath("$.resources[*].guid", ConsistOf("quota-1", "quota-2")),
+			)))
+		})
+
+		When("listing the org quotas fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.ListOrgQuotasReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/organization_quotas/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaPatch{
+				Name: tools.PtrTo("new-name"),
+				QuotaLimits: payloads.QuotaLimits{
+					Routes: payloads.RoutesQuota{
+						TotalRoutes: payloads.QuotaLimit{IsSet: true},
+					},
+				},
+			})
+
+			orgQuotaRepo.PatchOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID: "quota-guid",
+				Name: "new-name",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/organization_quotas/quota-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("patches the org quota", func() {
+			Expect(orgQuotaRepo.PatchOrgQuotaCallCount()).To(Equal(1))
+			_, _, patchMessage := orgQuotaRepo.PatchOrgQuotaArgsForCall(0)
+			Expect(patchMessage).To(Equal(repositories.PatchOrgQuotaMessage{
+				GUID: "quota-guid",
+				Name: tools.PtrTo("new-name"),
+				Limits: repositories.QuotaLimitsPatch{
+					TotalRoutes: &repositories.QuotaLimitPatch{},
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the org quota does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+				Expect(orgQuotaRepo.PatchOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("patching the org quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.PatchOrgQuotaReturns(repositories.OrgQuotaRecord{}, errors.New("patch-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/organization_quotas/:guid/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaApply{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "org-2"}},
+				},
+			})
+
+			orgQuotaRepo.ApplyOrgQuotaReturns([]string{"org-1", "org-2"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/organization_quotas/quota-guid/relationships/organizations", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("applies the org quota to the organizations", func() {
+			Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(Equal(1))
+			_, _, applyMessage := orgQuotaRepo.ApplyOrgQuotaArgsForCall(0)
+			Expect(applyMessage).To(Equal(repositories.ApplyOrgQuotaMessage{
+				GUID:              "quota-guid",
+				OrganizationGUIDs: []string{"org-2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("org-1", "org-2")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/organization_quotas/quota-guid/relationships/organizations"),
+			)))
+		})
+
+		When("an organization does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.ApplyOrgQuotaReturns(nil, apierrors.NewUnprocessableEntityError(nil, "Organization with guid 'org-2' does not exist, or you do not have access to it."))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Organization with guid 'org-2' does not exist, or you do not have access to it.")
+			})
+		})
+	})
+
+	Describe("DELETE /v3/organization_quotas/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/organization_quotas/quota-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the org quota", func() {
+			Expect(orgQuotaRepo.DeleteOrgQuotaCallCount()).To(Equal(1))
+			_, _, actualGUID := orgQuotaRepo.DeleteOrgQuotaArgsForCall(0)
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/organization_quota.delete~quota-guid"))
+		})
+
+		When("the org quota is still applied to organizations", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.DeleteOrgQuotaReturns(apierrors.NewUnprocessableEntityError(nil, "This quota is applied to one or more organizations. Remove this quota from all organizations before deleting."))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("This quota is applied to one or more organizations. Remove this quota from all organizations before deleting.")
+			})
+		})
+
+		When("the user cannot get the org quota", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+				Expect(orgQuotaRepo.DeleteOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -3,6 +3,7 @@ package handlers_test
 import (
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"time"
 
@@ -157,7 +158,7 @@ var _ = Describe("Org", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/organizations?names=a,b"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/organizations?names=a,b&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "a-l-i-c-e"),
 				MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/organizations/a-l-i-c-e"),
@@ -182,6 +183,49 @@ var _ = Describe("Org", func() {
 			})
 		})
 
+		Describe("Order results", func() {
+			BeforeEach(func() {
+				orgRepo.ListOrgsReturns([]repositories.OrgRecord{
+					{
+						GUID:      "1",
+						Name:      "first-test-org",
+						CreatedAt: time.UnixMilli(3000),
+						UpdatedAt: tools.PtrTo(time.UnixMilli(4000)),
+					},
+					{
+						GUID:      "2",
+						Name:      "second-test-org",
+						CreatedAt: time.UnixMilli(2000),
+						UpdatedAt: tools.PtrTo(time.UnixMilli(6000)),
+					},
+					{
+						GUID:      "3",
+						Name:      "third-test-org",
+						CreatedAt: time.UnixMilli(1000),
+						UpdatedAt: tools.PtrTo(time.UnixMilli(5000)),
+					},
+				}, nil)
+			})
+
+			DescribeTable("ordering results", func(orderBy string, expectedOrder ...any) {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OrgList{
+					OrderBy: orderBy,
+				})
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/v3/organizations?order_by=whatever", nil)
+				Expect(err).NotTo(HaveOccurred())
+				rr = httptest.NewRecorder()
+				routerBuilder.Build().ServeHTTP(rr, req)
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.resources[*].guid", expectedOrder)))
+			},
+				Entry("created_at ASC", "created_at", "3", "2", "1"),
+				Entry("created_at DESC", "-created_at", "1", "2", "3"),
+				Entry("updated_at ASC", "updated_at", "1", "3", "2"),
+				Entry("updated_at DESC", "-updated_at", "2", "3", "1"),
+				Entry("name ASC", "name", "1", "2", "3"),
+				Entry("name DESC", "-name", "3", "2", "1"),
+			)
+		})
+
 		When("fetching the orgs fails", func() {
 			BeforeEach(func() {
 				orgRepo.ListOrgsReturns(nil, errors.New("boom!"))
@@ -381,10 +425,14 @@ var _ = Describe("Org", func() {
 			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
 			Expect(actualReq.URL.String()).To(HaveSuffix(requestURL))
 
+			Expect(domainRepo.ListDomainsCallCount()).To(Equal(1))
+			_, _, listMessage := domainRepo.ListDomainsArgsForCall(0)
+			Expect(listMessage.OrganizationGUID).To(Equal("org-guid"))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/organizations/org-guid/domains"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/organizations/org-guid/domains?page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(1)),
 				MatchJSONPath("$.resources[0].guid", "domain-guid"),
 				MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/domains/domain-guid"),
@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -48,6 +49,7 @@ type Package struct {
 	dropletRepo         CFDropletRepository
 	imageRepo           ImageRepository
 	requestValidator    RequestValidator
+	featureFlagChecker  FeatureFlagChecker
 	registrySecretNames []string
 }
 
@@ -58,6 +60,7 @@ func NewPackage(
 	dropletRepo CFDropletRepository,
 	imageRepo ImageRepository,
 	requestValidator RequestValidator,
+	featureFlagChecker FeatureFlagChecker,
 	registrySecretNames []string,
 ) *Package {
 	return &Package{
@@ -68,6 +71,7 @@ func NewPackage(
 		imageRepo:           imageRepo,
 		registrySecretNames: registrySecretNames,
 		requestValidator:    requestValidator,
+		featureFlagChecker:  featureFlagChecker,
 	}
 }
 
@@ -143,6 +147,12 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if payload.Type == string(korifiv1alpha1.DockerPackage) {
+		if err = h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, korifiv1alpha1.FeatureFlagDiegoDocker); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "docker packages are disabled", "App GUID", appRecord.GUID)
+		}
+	}
+
 	record, err := h.packageRepo.CreatePackage(r.Context(), authInfo, payload.ToMessage(appRecord))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating package with repository")
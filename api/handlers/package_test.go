@@ -31,6 +31,7 @@ var _ = Describe("Package", func() {
 		dropletRepo                 *fake.CFDropletRepository
 		imageRepo                   *fake.ImageRepository
 		requestValidator            *fake.RequestValidator
+		featureFlagChecker          *fake.FeatureFlagChecker
 		packageImagePullSecretNames []string
 
 		packageGUID string
@@ -46,6 +47,7 @@ var _ = Describe("Package", func() {
 		dropletRepo = new(fake.CFDropletRepository)
 		imageRepo = new(fake.ImageRepository)
 		requestValidator = new(fake.RequestValidator)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 		packageImagePullSecretNames = []string{"package-image-pull-secret"}
 
 		packageGUID = generateGUID("package")
@@ -61,6 +63,7 @@ var _ = Describe("Package", func() {
 			dropletRepo,
 			imageRepo,
 			requestValidator,
+			featureFlagChecker,
 			packageImagePullSecretNames,
 		)
 
@@ -173,7 +176,7 @@ var _ = Describe("Package", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/packages?foo=bar"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/packages?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", packageGUID),
 				MatchJSONPath("$.resources[0].state", Equal("AWAITING_UPLOAD")),
@@ -385,6 +388,47 @@ var _ = Describe("Package", func() {
 			})
 		}
 
+		It("does not check feature flags", func() {
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(BeZero())
+		})
+
+		When("the package type is docker", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageCreate{
+					Type: "docker",
+					Relationships: &payloads.PackageRelationships{
+						App: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: appGUID,
+							},
+						},
+					},
+					Data: &payloads.PackageData{
+						Image: "some/image",
+					},
+				})
+			})
+
+			It("checks the diego_docker feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlagName := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlagName).To(Equal("diego_docker"))
+			})
+
+			When("docker packages are disabled", func() {
+				BeforeEach(func() {
+					featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("diego_docker", ""))
+				})
+
+				It("returns a feature disabled error", func() {
+					expectFeatureDisabledError("diego_docker")
+				})
+
+				itDoesntCreateAPackage()
+			})
+		})
+
 		When("the app doesn't exist", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(errors.New("NotFound"), repositories.AppResourceType))
@@ -828,7 +872,7 @@ var _ = Describe("Package", func() {
 
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/packages/"+packageGUID+"/droplets?not=used"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/packages/"+packageGUID+"/droplets?not=used&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(1)),
 				MatchJSONPath("$.resources[0].guid", Equal(dropletGUID)),
 				MatchJSONPath("$.resources[0].state", Equal("STAGED")),
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"net/url"
 
@@ -13,16 +12,16 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
 
 const (
-	ProcessPath         = "/v3/processes/{guid}"
-	ProcessSidecarsPath = "/v3/processes/{guid}/sidecars"
-	ProcessScalePath    = "/v3/processes/{guid}/actions/scale"
-	ProcessStatsPath    = "/v3/processes/{guid}/stats"
-	ProcessesPath       = "/v3/processes"
+	ProcessPath      = "/v3/processes/{guid}"
+	ProcessScalePath = "/v3/processes/{guid}/actions/scale"
+	ProcessStatsPath = "/v3/processes/{guid}/stats"
+	ProcessesPath    = "/v3/processes"
 )
 
 //counterfeiter:generate -o fake -fake-name CFProcessRepository . CFProcessRepository
@@ -41,10 +40,12 @@ type ProcessStats interface {
 }
 
 type Process struct {
-	serverURL        url.URL
-	processRepo      CFProcessRepository
-	processStats     ProcessStats
-	requestValidator RequestValidator
+	serverURL          url.URL
+	processRepo        CFProcessRepository
+	processStats       ProcessStats
+	requestValidator   RequestValidator
+	auditEventRecorder AuditEventRecorder
+	featureFlagChecker FeatureFlagChecker
 }
 
 func NewProcess(
@@ -52,12 +53,16 @@ func NewProcess(
 	processRepo CFProcessRepository,
 	processStatsFetcher ProcessStats,
 	requestValidator RequestValidator,
+	auditEventRecorder AuditEventRecorder,
+	featureFlagChecker FeatureFlagChecker,
 ) *Process {
 	return &Process{
-		serverURL:        serverURL,
-		processRepo:      processRepo,
-		processStats:     processStatsFetcher,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		processRepo:        processRepo,
+		processStats:       processStatsFetcher,
+		requestValidator:   requestValidator,
+		auditEventRecorder: auditEventRecorder,
+		featureFlagChecker: featureFlagChecker,
 	}
 }
 
@@ -75,34 +80,6 @@ func (h *Process) get(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcess(process, h.serverURL)), nil
 }
 
-func (h *Process) getSidecars(r *http.Request) (*routing.Response, error) {
-	authInfo, _ := authorization.InfoFromContext(r.Context())
-	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.get-sidecars")
-
-	processGUID := routing.URLParam(r, "guid")
-
-	_, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
-	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
-	}
-
-	return routing.NewResponse(http.StatusOK).WithBody(map[string]interface{}{
-		"pagination": map[string]interface{}{
-			"total_results": 0,
-			"total_pages":   1,
-			"first": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"last": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"next":     nil,
-			"previous": nil,
-		},
-		"resources": []string{},
-	}), nil
-}
-
 func (h *Process) scale(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.scale")
@@ -119,6 +96,10 @@ func (h *Process) scale(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.ForbiddenAsNotFound(err)
 	}
 
+	if err = h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, korifiv1alpha1.FeatureFlagAppScaling); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app scaling is disabled", "processGUID", processGUID)
+	}
+
 	processRecord, err := h.processRepo.ScaleProcess(r.Context(), authInfo, repositories.ScaleProcessMessage{
 		GUID:               process.GUID,
 		SpaceGUID:          process.SpaceGUID,
@@ -128,6 +109,10 @@ func (h *Process) scale(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to scale process", "processGUID", processGUID)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, processAuditEvent(repositories.AuditEventTypeAppProcessScale, processRecord, map[string]any{
+		"request": scaleRequestData(payload),
+	}))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcess(processRecord, h.serverURL)), nil
 }
 
@@ -160,9 +145,18 @@ func (h *Process) list(r *http.Request) (*routing.Response, error) { //nolint:du
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch processes(s) from Kubernetes")
 	}
 
+	h.sortList(processList, processListFilter.OrderBy)
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessList(processList, h.serverURL, *r.URL)), nil
 }
 
+func (h *Process) sortList(processes []repositories.ProcessRecord, order string) {
+	sortByOrder(processes, order, orderBy[repositories.ProcessRecord]{
+		"created_at": func(a, b repositories.ProcessRecord) bool { return createdBefore(a.CreatedAt, b.CreatedAt) },
+		"updated_at": func(a, b repositories.ProcessRecord) bool { return updatedBefore(a.UpdatedAt, b.UpdatedAt) },
+	})
+}
+
 func (h *Process) update(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.update")
@@ -184,6 +178,8 @@ func (h *Process) update(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch process from Kubernetes", "ProcessGUID", processGUID)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, processAuditEvent(repositories.AuditEventTypeAppProcessUpdate, updatedProcess, map[string]any{}))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcess(updatedProcess, h.serverURL)), nil
 }
 
@@ -194,7 +190,6 @@ func (h *Process) UnauthenticatedRoutes() []routing.Route {
 func (h *Process) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: ProcessPath, Handler: h.get},
-		{Method: "GET", Pattern: ProcessSidecarsPath, Handler: h.getSidecars},
 		{Method: "POST", Pattern: ProcessScalePath, Handler: h.scale},
 		{Method: "GET", Pattern: ProcessStatsPath, Handler: h.getStats},
 		{Method: "GET", Pattern: ProcessesPath, Handler: h.list},
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/actions"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -20,21 +21,27 @@ import (
 
 var _ = Describe("Process", func() {
 	var (
-		processRepo      *fake.CFProcessRepository
-		processStats     *fake.ProcessStats
-		requestValidator *fake.RequestValidator
+		processRepo        *fake.CFProcessRepository
+		processStats       *fake.ProcessStats
+		requestValidator   *fake.RequestValidator
+		auditEventRecorder *fake.AuditEventRecorder
+		featureFlagChecker *fake.FeatureFlagChecker
 	)
 
 	BeforeEach(func() {
 		processRepo = new(fake.CFProcessRepository)
 		processStats = new(fake.ProcessStats)
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 
 		apiHandler := NewProcess(
 			*serverURL,
 			processRepo,
 			processStats,
 			requestValidator,
+			auditEventRecorder,
+			featureFlagChecker,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -88,51 +95,6 @@ var _ = Describe("Process", func() {
 		})
 	})
 
-	Describe("the GET /v3/processes/:guid/sidecars endpoint", func() {
-		BeforeEach(func() {
-			processRepo.GetProcessReturns(repositories.ProcessRecord{}, nil)
-		})
-
-		JustBeforeEach(func() {
-			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/processes/process-guid/sidecars", nil)
-			Expect(err).NotTo(HaveOccurred())
-			routerBuilder.Build().ServeHTTP(rr, req)
-		})
-
-		It("returns the empty list of sidecars", func() {
-			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
-			_, actualAuthInfo, _ := processRepo.GetProcessArgsForCall(0)
-			Expect(actualAuthInfo).To(Equal(authInfo))
-
-			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
-			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.total_results", BeZero()),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes/process-guid/sidecars"),
-			)))
-		})
-
-		When("the process isn't accessible to the user", func() {
-			BeforeEach(func() {
-				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
-			})
-
-			It("returns an error", func() {
-				expectNotFoundError("Process")
-			})
-		})
-
-		When("there is some other error fetching the process", func() {
-			BeforeEach(func() {
-				processRepo.GetProcessReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
-			})
-
-			It("returns an error", func() {
-				expectUnknownError()
-			})
-		})
-	})
-
 	Describe("the POST /v3/processes/:guid/actions/scale endpoint", func() {
 		BeforeEach(func() {
 			processRepo.GetProcessReturns(repositories.ProcessRecord{
@@ -141,7 +103,10 @@ var _ = Describe("Process", func() {
 			}, nil)
 
 			processRepo.ScaleProcessReturns(repositories.ProcessRecord{
-				GUID: "process-guid",
+				GUID:      "process-guid",
+				SpaceGUID: spaceGUID,
+				AppGUID:   appGUID,
+				Type:      "web",
 			}, nil)
 
 			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ProcessScale{
@@ -157,6 +122,29 @@ var _ = Describe("Process", func() {
 			routerBuilder.Build().ServeHTTP(rr, req)
 		})
 
+		It("records a process scale audit event on the app", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+				Type: "audit.app.process.scale",
+				Target: repositories.AuditEventTarget{
+					GUID: appGUID,
+					Type: "app",
+				},
+				SpaceGUID: spaceGUID,
+				Data: map[string]any{
+					"process_guid": "process-guid",
+					"process_type": "web",
+					"request": map[string]any{
+						"instances":    3,
+						"memory_in_mb": int64(512),
+						"disk_in_mb":   int64(256),
+					},
+				},
+			}))
+		})
+
 		It("scales the process", func() {
 			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
 			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
@@ -200,6 +188,24 @@ var _ = Describe("Process", func() {
 			})
 		})
 
+		When("app scaling is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("app_scaling", ""))
+			})
+
+			It("checks the app_scaling feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlagName := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlagName).To(Equal("app_scaling"))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+				expectFeatureDisabledError("app_scaling")
+			})
+		})
+
 		When("the user does not have permissions to get the process", func() {
 			BeforeEach(func() {
 				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, "Process"))
@@ -316,7 +322,7 @@ var _ = Describe("Process", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes?page=1&per_page=50"),
 				MatchJSONPath("$.resources[0].guid", "process-guid"),
 			)))
 		})
@@ -337,6 +343,27 @@ var _ = Describe("Process", func() {
 			})
 		})
 
+		When("the processes are ordered", func() {
+			BeforeEach(func() {
+				processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+					{GUID: "process-1", CreatedAt: time.UnixMilli(1000)},
+					{GUID: "process-2", CreatedAt: time.UnixMilli(2000)},
+					{GUID: "process-3", CreatedAt: time.UnixMilli(3000)},
+				}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ProcessList{
+					OrderBy: "-created_at",
+				})
+			})
+
+			It("returns the processes in the requested order", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(
+					MatchJSONPath("$.resources[*].guid", []any{"process-3", "process-2", "process-1"}),
+				))
+			})
+		})
+
 		When("the request body is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boo"))
@@ -365,7 +392,9 @@ var _ = Describe("Process", func() {
 			}, nil)
 
 			processRepo.PatchProcessReturns(repositories.ProcessRecord{
-				GUID: "process-guid",
+				GUID:    "process-guid",
+				AppGUID: appGUID,
+				Type:    "web",
 			}, nil)
 
 			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ProcessPatch{
@@ -391,6 +420,14 @@ var _ = Describe("Process", func() {
 			routerBuilder.Build().ServeHTTP(rr, req)
 		})
 
+		It("records a process update audit event on the app", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.process.update"))
+			Expect(message.Target.GUID).To(Equal(appGUID))
+			Expect(message.Data).To(HaveKeyWithValue("process_guid", "process-guid"))
+		})
+
 		It("updates the process", func() {
 			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
 			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
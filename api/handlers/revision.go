@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	RevisionPath             = "/v3/revisions/{guid}"
+	AppRevisionsPath         = "/v3/apps/{guid}/revisions"
+	AppDeployedRevisionsPath = "/v3/apps/{guid}/revisions/deployed"
+)
+
+//counterfeiter:generate -o fake -fake-name CFRevisionRepository . CFRevisionRepository
+
+type CFRevisionRepository interface {
+	GetRevision(context.Context, authorization.Info, string) (repositories.RevisionRecord, error)
+	ListRevisions(context.Context, authorization.Info, repositories.ListRevisionsMessage) ([]repositories.RevisionRecord, error)
+}
+
+type Revision struct {
+	serverURL        url.URL
+	revisionRepo     CFRevisionRepository
+	appRepo          CFAppRepository
+	requestValidator RequestValidator
+}
+
+func NewRevision(
+	serverURL url.URL,
+	revisionRepo CFRevisionRepository,
+	appRepo CFAppRepository,
+	requestValidator RequestValidator,
+) *Revision {
+	return &Revision{
+		serverURL:        serverURL,
+		revisionRepo:     revisionRepo,
+		appRepo:          appRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *Revision) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.get")
+	revisionGUID := routing.URLParam(r, "guid")
+
+	revision, err := h.revisionRepo.GetRevision(r.Context(), authInfo, revisionGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch revision from Kubernetes", "RevisionGUID", revisionGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRevision(revision, h.serverURL)), nil
+}
+
+func (h *Revision) listForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.list-for-app")
+	appGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.RevisionList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if _, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	revisions, err := h.revisionRepo.ListRevisions(r.Context(), authInfo, payload.ToMessage(appGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch revisions from Kubernetes", "AppGUID", appGUID)
+	}
+
+	h.sortList(revisions, payload.OrderBy)
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRevision, revisions, h.serverURL, *r.URL)), nil
+}
+
+// listDeployedForApp returns the revision the app instances are running,
+// which is the latest revision of a started app
+func (h *Revision) listDeployedForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.list-deployed-for-app")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	deployed := []repositories.RevisionRecord{}
+	if app.State == repositories.StartedState {
+		revisions, err := h.revisionRepo.ListRevisions(r.Context(), authInfo, repositories.ListRevisionsMessage{AppGUIDs: []string{appGUID}})
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch revisions from Kubernetes", "AppGUID", appGUID)
+		}
+
+		h.sortList(revisions, "-version")
+		if len(revisions) > 0 {
+			deployed = append(deployed, revisions[0])
+		}
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRevision, deployed, h.serverURL, *r.URL)), nil
+}
+
+func (h *Revision) sortList(revisions []repositories.RevisionRecord, order string) {
+	sortByOrder(revisions, cmp.Or(order, "version"), orderBy[repositories.RevisionRecord]{
+		"version":    func(a, b repositories.RevisionRecord) bool { return a.Version < b.Version },
+		"created_at": func(a, b repositories.RevisionRecord) bool { return createdBefore(a.CreatedAt, b.CreatedAt) },
+		"updated_at": func(a, b repositories.RevisionRecord) bool { return updatedBefore(a.UpdatedAt, b.UpdatedAt) },
+	})
+}
+
+func (h *Revision) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Revision) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: RevisionPath, Handler: h.get},
+		{Method: "GET", Pattern: AppRevisionsPath, Handler: h.listForApp},
+		{Method: "GET", Pattern: AppDeployedRevisionsPath, Handler: h.listDeployedForApp},
+	}
+}
@@ -0,0 +1,230 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revision", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		revisionRepo     *fake.CFRevisionRepository
+		appRepo          *fake.CFAppRepository
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		revisionRepo = new(fake.CFRevisionRepository)
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      appGUID,
+			SpaceGUID: spaceGUID,
+			State:     repositories.StartedState,
+		}, nil)
+
+		apiHandler := handlers.NewRevision(*serverURL, revisionRepo, appRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/revisions/{guid}", func() {
+		BeforeEach(func() {
+			revisionRepo.GetRevisionReturns(repositories.RevisionRecord{
+				GUID:    "revision-guid",
+				AppGUID: appGUID,
+				Version: 2,
+			}, nil)
+
+			req = createHttpRequest("GET", "/v3/revisions/revision-guid", nil)
+		})
+
+		It("returns the revision", func() {
+			Expect(revisionRepo.GetRevisionCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := revisionRepo.GetRevisionArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("revision-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "revision-guid"),
+				MatchJSONPath("$.version", BeEquivalentTo(2)),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/revisions/revision-guid"),
+			)))
+		})
+
+		When("the revision is not accessible", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RevisionResourceType)
+			})
+		})
+
+		When("getting the revision fails", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/revisions", func() {
+		var payload *payloads.RevisionList
+
+		BeforeEach(func() {
+			revisionRepo.ListRevisionsReturns([]repositories.RevisionRecord{
+				{GUID: "revision-2", AppGUID: appGUID, Version: 2},
+				{GUID: "revision-1", AppGUID: appGUID, Version: 1},
+			}, nil)
+
+			payload = &payloads.RevisionList{Versions: []int{1, 2}}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/revisions?versions=1,2", nil)
+		})
+
+		It("lists the app revisions ordered by version", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(revisionRepo.ListRevisionsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := revisionRepo.ListRevisionsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListRevisionsMessage{
+				AppGUIDs: []string{appGUID},
+				Versions: []int{1, 2},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "revision-1"),
+				MatchJSONPath("$.resources[1].guid", "revision-2"),
+			)))
+		})
+
+		When("ordering by descending version", func() {
+			BeforeEach(func() {
+				payload.OrderBy = "-version"
+			})
+
+			It("returns the latest revision first", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.resources[0].guid", "revision-2"),
+					MatchJSONPath("$.resources[1].guid", "revision-1"),
+				)))
+			})
+		})
+
+		When("the request query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("boom")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+				Expect(revisionRepo.ListRevisionsCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing the revisions fails", func() {
+			BeforeEach(func() {
+				revisionRepo.ListRevisionsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/revisions/deployed", func() {
+		BeforeEach(func() {
+			revisionRepo.ListRevisionsReturns([]repositories.RevisionRecord{
+				{GUID: "revision-1", AppGUID: appGUID, Version: 1},
+				{GUID: "revision-3", AppGUID: appGUID, Version: 3},
+				{GUID: "revision-2", AppGUID: appGUID, Version: 2},
+			}, nil)
+
+			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/revisions/deployed", nil)
+		})
+
+		It("returns the latest revision", func() {
+			Expect(revisionRepo.ListRevisionsCallCount()).To(Equal(1))
+			_, _, message := revisionRepo.ListRevisionsArgsForCall(0)
+			Expect(message.AppGUIDs).To(ConsistOf(appGUID))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "revision-3"),
+			)))
+		})
+
+		When("the app is stopped", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{
+					GUID:  appGUID,
+					State: repositories.StoppedState,
+				}, nil)
+			})
+
+			It("returns an empty list", func() {
+				Expect(revisionRepo.ListRevisionsCallCount()).To(BeZero())
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.resources", BeEmpty())))
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("listing the revisions fails", func() {
+			BeforeEach(func() {
+				revisionRepo.ListRevisionsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
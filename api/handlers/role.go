@@ -31,16 +31,18 @@ type CFRoleRepository interface {
 }
 
 type Role struct {
-	apiBaseURL       url.URL
-	roleRepo         CFRoleRepository
-	requestValidator RequestValidator
+	apiBaseURL         url.URL
+	roleRepo           CFRoleRepository
+	requestValidator   RequestValidator
+	auditEventRecorder AuditEventRecorder
 }
 
-func NewRole(apiBaseURL url.URL, roleRepo CFRoleRepository, requestValidator RequestValidator) *Role {
+func NewRole(apiBaseURL url.URL, roleRepo CFRoleRepository, requestValidator RequestValidator, auditEventRecorder AuditEventRecorder) *Role {
 	return &Role{
-		apiBaseURL:       apiBaseURL,
-		roleRepo:         roleRepo,
-		requestValidator: requestValidator,
+		apiBaseURL:         apiBaseURL,
+		roleRepo:           roleRepo,
+		requestValidator:   requestValidator,
+		auditEventRecorder: auditEventRecorder,
 	}
 }
 
@@ -61,6 +63,8 @@ func (h *Role) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create role", "Role Type", role.Type, "Space", role.Space, "User", role.User)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, roleAuditEvent("add", record))
+
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForRole(record, h.apiBaseURL)), nil
 }
 
@@ -136,6 +140,8 @@ func (h *Role) delete(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete role", "RoleGUID", roleGUID)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, roleAuditEvent("remove", role))
+
 	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(roleGUID, presenter.RoleDeleteOperation, h.apiBaseURL)), nil
 }
 
@@ -26,16 +26,18 @@ const (
 
 var _ = Describe("Role", func() {
 	var (
-		apiHandler       *handlers.Role
-		roleRepo         *fake.CFRoleRepository
-		requestValidator *fake.RequestValidator
+		apiHandler         *handlers.Role
+		roleRepo           *fake.CFRoleRepository
+		requestValidator   *fake.RequestValidator
+		auditEventRecorder *fake.AuditEventRecorder
 	)
 
 	BeforeEach(func() {
 		roleRepo = new(fake.CFRoleRepository)
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 
-		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator)
+		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator, auditEventRecorder)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -43,7 +45,12 @@ var _ = Describe("Role", func() {
 		var roleCreate *payloads.RoleCreate
 
 		BeforeEach(func() {
-			roleRepo.CreateRoleReturns(repositories.RoleRecord{GUID: "role-guid"}, nil)
+			roleRepo.CreateRoleReturns(repositories.RoleRecord{
+				GUID:  "role-guid",
+				Type:  "space_developer",
+				Space: "my-space",
+				User:  "my-user",
+			}, nil)
 			roleCreate = &payloads.RoleCreate{
 				Type: "space_developer",
 				Relationships: payloads.RoleRelationships{
@@ -69,6 +76,24 @@ var _ = Describe("Role", func() {
 			routerBuilder.Build().ServeHTTP(rr, req)
 		})
 
+		It("records a role add audit event on the user", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+				Type: "audit.user.space_developer_add",
+				Target: repositories.AuditEventTarget{
+					GUID: "my-user",
+					Type: "user",
+					Name: "my-user",
+				},
+				SpaceGUID: "my-space",
+				Data: map[string]any{
+					"role_guid": "role-guid",
+				},
+			}))
+		})
+
 		It("creates the role", func() {
 			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
 			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
@@ -186,7 +211,7 @@ var _ = Describe("Role", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/roles?foo=bar"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/roles?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "role-1"),
 				MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/roles/role-1"),
@@ -271,8 +296,10 @@ var _ = Describe("Role", func() {
 		BeforeEach(func() {
 			roleRepo.GetRoleReturns(repositories.RoleRecord{
 				GUID:  "role-guid",
+				Type:  "space_developer",
 				Space: "my-space",
 				Org:   "",
+				User:  "my-user",
 			}, nil)
 		})
 
@@ -282,6 +309,14 @@ var _ = Describe("Role", func() {
 			routerBuilder.Build().ServeHTTP(rr, req)
 		})
 
+		It("records a role remove audit event on the user", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.user.space_developer_remove"))
+			Expect(message.Target.GUID).To(Equal("my-user"))
+			Expect(message.SpaceGUID).To(Equal("my-space"))
+		})
+
 		It("deletes the role", func() {
 			Expect(roleRepo.GetRoleCallCount()).To(Equal(1))
 			_, actualAuthInfo, actualRoleGuid := roleRepo.GetRoleArgsForCall(0)
@@ -13,17 +13,23 @@ const (
 )
 
 type Root struct {
-	baseURL url.URL
+	baseURL        url.URL
+	appSSH         *presenter.AppSSH
+	routingEnabled bool
 }
 
-func NewRoot(baseURL url.URL) *Root {
+// NewRoot builds the root handler. appSSH is nil when the SSH proxy is
+// disabled. routingEnabled advertises the routing API serving router groups.
+func NewRoot(baseURL url.URL, appSSH *presenter.AppSSH, routingEnabled bool) *Root {
 	return &Root{
-		baseURL: baseURL,
+		baseURL:        baseURL,
+		appSSH:         appSSH,
+		routingEnabled: routingEnabled,
 	}
 }
 
 func (h *Root) get(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL, h.appSSH, h.routingEnabled)), nil
 }
 
 func (h *Root) UnauthenticatedRoutes() []routing.Route {
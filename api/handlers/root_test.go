@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/presenter"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -11,14 +12,20 @@ import (
 )
 
 var _ = Describe("Root", func() {
-	var req *http.Request
+	var (
+		req            *http.Request
+		appSSH         *presenter.AppSSH
+		routingEnabled bool
+	)
 
 	BeforeEach(func() {
-		apiHandler := handlers.NewRoot(*serverURL)
-		routerBuilder.LoadRoutes(apiHandler)
+		appSSH = nil
+		routingEnabled = false
 	})
 
 	JustBeforeEach(func() {
+		apiHandler := handlers.NewRoot(*serverURL, appSSH, routingEnabled)
+		routerBuilder.LoadRoutes(apiHandler)
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
@@ -36,7 +43,42 @@ var _ = Describe("Root", func() {
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.links.self.href", "https://api.example.org"),
 				MatchJSONPath("$.links.cloud_controller_v3.href", "https://api.example.org/v3"),
+				MatchJSONPath("$.links.network_policy_v1.href", "https://api.example.org/networking"),
+				MatchJSONPath("$.links.app_ssh", BeNil()),
+				MatchJSONPath("$.links.routing", BeNil()),
 			)))
 		})
+
+		When("routing is enabled", func() {
+			BeforeEach(func() {
+				routingEnabled = true
+			})
+
+			It("advertises the routing api", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(
+					MatchJSONPath("$.links.routing.href", "https://api.example.org/routing"),
+				))
+			})
+		})
+
+		When("the ssh proxy is enabled", func() {
+			BeforeEach(func() {
+				appSSH = &presenter.AppSSH{
+					Address:            "ssh.example.org:2222",
+					HostKeyFingerprint: "the-fingerprint",
+					OAuthClient:        "ssh-proxy",
+				}
+			})
+
+			It("advertises it", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.links.app_ssh.href", "ssh.example.org:2222"),
+					MatchJSONPath("$.links.app_ssh.meta.host_key_fingerprint", "the-fingerprint"),
+					MatchJSONPath("$.links.app_ssh.meta.oauth_client", "ssh-proxy"),
+				)))
+			})
+		})
 	})
 })
@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/config"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
@@ -20,13 +25,17 @@ const (
 	RoutesPath            = "/v3/routes"
 	RouteDestinationsPath = "/v3/routes/{guid}/destinations"
 	RouteDestinationPath  = "/v3/routes/{guid}/destinations/{destination_guid}"
+
+	// maxTCPPortAttempts bounds the attempts to create a tcp route on a free
+	// port that is taken in the meantime
+	maxTCPPortAttempts = 5
 )
 
 //counterfeiter:generate -o fake -fake-name CFRouteRepository . CFRouteRepository
 
 type CFRouteRepository interface {
 	GetRoute(context.Context, authorization.Info, string) (repositories.RouteRecord, error)
-	ListRoutes(context.Context, authorization.Info, repositories.ListRoutesMessage) ([]repositories.RouteRecord, error)
+	ListRoutes(context.Context, authorization.Info, repositories.ListRoutesMessage) (repositories.ListResult[repositories.RouteRecord], error)
 	ListRoutesForApp(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)
 	CreateRoute(context.Context, authorization.Info, repositories.CreateRouteMessage) (repositories.RouteRecord, error)
 	DeleteRoute(context.Context, authorization.Info, repositories.DeleteRouteMessage) error
@@ -36,12 +45,14 @@ type CFRouteRepository interface {
 }
 
 type Route struct {
-	serverURL        url.URL
-	routeRepo        CFRouteRepository
-	domainRepo       CFDomainRepository
-	appRepo          CFAppRepository
-	spaceRepo        CFSpaceRepository
-	requestValidator RequestValidator
+	serverURL          url.URL
+	routeRepo          CFRouteRepository
+	domainRepo         CFDomainRepository
+	appRepo            CFAppRepository
+	spaceRepo          CFSpaceRepository
+	requestValidator   RequestValidator
+	auditEventRecorder AuditEventRecorder
+	tcpRouterGroup     config.RouterGroupConfig
 }
 
 func NewRoute(
@@ -51,14 +62,18 @@ func NewRoute(
 	appRepo CFAppRepository,
 	spaceRepo CFSpaceRepository,
 	requestValidator RequestValidator,
+	auditEventRecorder AuditEventRecorder,
+	tcpRouterGroup config.RouterGroupConfig,
 ) *Route {
 	return &Route{
-		serverURL:        serverURL,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		appRepo:          appRepo,
-		spaceRepo:        spaceRepo,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		routeRepo:          routeRepo,
+		domainRepo:         domainRepo,
+		appRepo:            appRepo,
+		spaceRepo:          spaceRepo,
+		requestValidator:   requestValidator,
+		auditEventRecorder: auditEventRecorder,
+		tcpRouterGroup:     tcpRouterGroup,
 	}
 }
 
@@ -90,7 +105,12 @@ func (h *Route) list(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch routes from Kubernetes")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRoute, routes, h.serverURL, *r.URL)), nil
+	routes = sortListResult(routes, routeListFilter.OrderBy, routeListFilter.Pagination, orderBy[repositories.RouteRecord]{
+		"created_at": func(a, b repositories.RouteRecord) bool { return createdBefore(a.CreatedAt, b.CreatedAt) },
+		"updated_at": func(a, b repositories.RouteRecord) bool { return updatedBefore(a.UpdatedAt, b.UpdatedAt) },
+	})
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForListResult(presenter.ForRoute, routes, h.serverURL, *r.URL)), nil
 }
 
 func (h *Route) listDestinations(r *http.Request) (*routing.Response, error) {
@@ -147,7 +167,15 @@ func (h *Route) create(r *http.Request) (*routing.Response, error) {
 	}
 
 	createRouteMessage := payload.ToMessage(domain.Namespace, domain.Name)
-	responseRouteRecord, err := h.routeRepo.CreateRoute(r.Context(), authInfo, createRouteMessage)
+
+	var responseRouteRecord repositories.RouteRecord
+	if domain.RouterGroupGUID != "" {
+		responseRouteRecord, err = h.createTCPRoute(r.Context(), authInfo, domain, createRouteMessage)
+	} else if payload.Host == "" {
+		err = apierrors.NewUnprocessableEntityError(nil, "host cannot be blank")
+	} else {
+		responseRouteRecord, err = h.routeRepo.CreateRoute(r.Context(), authInfo, createRouteMessage)
+	}
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create route", "Route Host", payload.Host)
 	}
@@ -157,6 +185,70 @@ func (h *Route) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForRoute(responseRouteRecord, h.serverURL)), nil
 }
 
+// createTCPRoute creates the route on the requested port or, when no port is
+// requested, on a random reservable port of the router group that is not used
+// by any route of the domain. As routes the user cannot see and concurrent
+// requests may take the picked port, creation is retried a bounded number of
+// times on another free port.
+func (h *Route) createTCPRoute(ctx context.Context, authInfo authorization.Info, domain repositories.DomainRecord, message repositories.CreateRouteMessage) (repositories.RouteRecord, error) {
+	if !h.tcpRouterGroup.Enabled() || domain.RouterGroupGUID != h.tcpRouterGroup.Name {
+		return repositories.RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Router group with guid '%s' not found.", domain.RouterGroupGUID))
+	}
+
+	ports := h.tcpRouterGroup.Ports()
+	if message.Port != 0 {
+		if !slices.Contains(ports, message.Port) {
+			return repositories.RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Port %d is not available on router group '%s'.", message.Port, h.tcpRouterGroup.Name))
+		}
+
+		return h.routeRepo.CreateRoute(ctx, authInfo, message)
+	}
+
+	freePorts, err := h.freeTCPPorts(ctx, authInfo, domain, ports)
+	if err != nil {
+		return repositories.RouteRecord{}, err
+	}
+
+	for range maxTCPPortAttempts {
+		if len(freePorts) == 0 {
+			break
+		}
+
+		i := rand.Intn(len(freePorts))
+		message.Port = freePorts[i]
+		freePorts = slices.Delete(freePorts, i, i+1)
+
+		route, err := h.routeRepo.CreateRoute(ctx, authInfo, message)
+		if !errors.As(err, new(apierrors.UniquenessError)) {
+			return route, err
+		}
+	}
+
+	return repositories.RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("There are no more ports available for router group: %s. Please contact your administrator for more information.", h.tcpRouterGroup.Name))
+}
+
+// freeTCPPorts returns the given ports that are not used by any route of the
+// domain
+func (h *Route) freeTCPPorts(ctx context.Context, authInfo authorization.Info, domain repositories.DomainRecord, ports []int) ([]int, error) {
+	routes, err := h.routeRepo.ListRoutes(ctx, authInfo, repositories.ListRoutesMessage{
+		DomainGUIDs: []string{domain.GUID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usedPorts := map[int]bool{}
+	for _, route := range routes.Records {
+		if route.Port != nil {
+			usedPorts[*route.Port] = true
+		}
+	}
+
+	return slices.DeleteFunc(slices.Clone(ports), func(port int) bool {
+		return usedPorts[port]
+	}), nil
+}
+
 func (h *Route) insertDestinations(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.insert-destinations")
@@ -180,6 +272,10 @@ func (h *Route) insertDestinations(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to add destination on route", "Route GUID", routeRecord.GUID)
 	}
 
+	for _, destination := range destinationListCreateMessage.NewDestinations {
+		h.auditEventRecorder.RecordEvent(r.Context(), authInfo, routeMappingAuditEvent(repositories.AuditEventTypeAppMapRoute, routeRecord, destination.AppGUID, destination.ProcessType))
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteDestinations(responseRouteRecord, h.serverURL)), nil
 }
 
@@ -206,6 +302,12 @@ func (h *Route) deleteDestination(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to remove destination from route", "Route GUID", routeRecord.GUID, "Destination GUID", destinationGUID)
 	}
 
+	for _, destination := range routeRecord.Destinations {
+		if destination.GUID == destinationGUID {
+			h.auditEventRecorder.RecordEvent(r.Context(), authInfo, routeMappingAuditEvent(repositories.AuditEventTypeAppUnmapRoute, routeRecord, destination.AppGUID, destination.ProcessType))
+		}
+	}
+
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
@@ -248,26 +350,26 @@ func (h *Route) lookupRouteAndDomain(ctx context.Context, logger logr.Logger, au
 	return route, nil
 }
 
-func (h *Route) lookupRouteAndDomainList(ctx context.Context, authInfo authorization.Info, message repositories.ListRoutesMessage) ([]repositories.RouteRecord, error) {
-	routeRecords, err := h.routeRepo.ListRoutes(ctx, authInfo, message)
+func (h *Route) lookupRouteAndDomainList(ctx context.Context, authInfo authorization.Info, message repositories.ListRoutesMessage) (repositories.ListResult[repositories.RouteRecord], error) {
+	routes, err := h.routeRepo.ListRoutes(ctx, authInfo, message)
 	if err != nil {
-		return []repositories.RouteRecord{}, err
+		return repositories.ListResult[repositories.RouteRecord]{}, err
 	}
 
 	domainRecords := make(map[string]repositories.DomainRecord)
-	for i, routeRecord := range routeRecords {
+	for i, routeRecord := range routes.Records {
 		domainRecord, ok := domainRecords[routeRecord.Domain.GUID]
 		if !ok {
 			domainRecord, err = h.domainRepo.GetDomain(ctx, authInfo, routeRecord.Domain.GUID)
 			if err != nil {
-				return []repositories.RouteRecord{}, err
+				return repositories.ListResult[repositories.RouteRecord]{}, err
 			}
 			domainRecords[routeRecord.Domain.GUID] = domainRecord
 		}
-		routeRecords[i].Domain = domainRecord
+		routes.Records[i].Domain = domainRecord
 	}
 
-	return routeRecords, nil
+	return routes, nil
 }
 
 //nolint:dupl
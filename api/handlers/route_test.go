@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"code.cloudfoundry.org/korifi/api/config"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -21,11 +23,12 @@ import (
 
 var _ = Describe("Route", func() {
 	var (
-		routeRepo        *fake.CFRouteRepository
-		domainRepo       *fake.CFDomainRepository
-		appRepo          *fake.CFAppRepository
-		spaceRepo        *fake.CFSpaceRepository
-		requestValidator *fake.RequestValidator
+		routeRepo          *fake.CFRouteRepository
+		domainRepo         *fake.CFDomainRepository
+		appRepo            *fake.CFAppRepository
+		spaceRepo          *fake.CFSpaceRepository
+		requestValidator   *fake.RequestValidator
+		auditEventRecorder *fake.AuditEventRecorder
 
 		requestMethod string
 		requestPath   string
@@ -65,6 +68,7 @@ var _ = Describe("Route", func() {
 		}, nil)
 
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 
 		apiHandler := NewRoute(
 			*serverURL,
@@ -73,6 +77,8 @@ var _ = Describe("Route", func() {
 			appRepo,
 			spaceRepo,
 			requestValidator,
+			auditEventRecorder,
+			config.RouterGroupConfig{Name: "default-tcp", ReservablePorts: "1024-1033"},
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -157,16 +163,18 @@ var _ = Describe("Route", func() {
 			otherRouteRecord := routeRecord
 			otherRouteRecord.GUID = "other-test-route-guid"
 			otherRouteRecord.Host = "other-test-route-host"
-			routeRepo.ListRoutesReturns([]repositories.RouteRecord{
-				routeRecord,
-				otherRouteRecord,
+			routeRepo.ListRoutesReturns(repositories.ListResult[repositories.RouteRecord]{
+				PageInfo: repositories.PageInfo{TotalResults: 2, Page: 1, PerPage: 50},
+				Records:  []repositories.RouteRecord{routeRecord, otherRouteRecord},
 			}, nil)
 
 			requestMethod = http.MethodGet
 			requestPath = "/v3/routes?foo=bar"
 			requestBody = ""
 
-			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouteList{})
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouteList{
+				Pagination: payloads.Pagination{Page: 1, PerPage: 50},
+			})
 		})
 
 		It("returns the routes list", func() {
@@ -175,8 +183,9 @@ var _ = Describe("Route", func() {
 			Expect(actualReq.URL.String()).To(HaveSuffix("/v3/routes?foo=bar"))
 
 			Expect(routeRepo.ListRoutesCallCount()).To(Equal(1))
-			_, actualAuthInfo, _ := routeRepo.ListRoutesArgsForCall(0)
+			_, actualAuthInfo, message := routeRepo.ListRoutesArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Pagination).To(Equal(repositories.Pagination{Page: 1, PerPage: 50}))
 
 			Expect(domainRepo.GetDomainCallCount()).To(Equal(1))
 			_, actualAuthInfo, actualDomainGUID := domainRepo.GetDomainArgsForCall(0)
@@ -187,7 +196,7 @@ var _ = Describe("Route", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/routes?foo=bar"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/routes?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources[0].guid", "test-route-guid"),
 				MatchJSONPath("$.resources[0].url", "test-route-host.example.org/some_path"),
 				MatchJSONPath("$.resources[1].guid", "other-test-route-guid"),
@@ -218,9 +227,43 @@ var _ = Describe("Route", func() {
 			})
 		})
 
+		When("the routes are ordered", func() {
+			BeforeEach(func() {
+				routeRecords := []repositories.RouteRecord{}
+				for i, guid := range []string{"route-1", "route-2", "route-3"} {
+					record := routeRecord
+					record.GUID = guid
+					record.CreatedAt = time.UnixMilli(int64(1000 * (i + 1)))
+					routeRecords = append(routeRecords, record)
+				}
+				routeRepo.ListRoutesReturns(repositories.ListResult[repositories.RouteRecord]{
+					PageInfo: repositories.PageInfo{TotalResults: 3, Page: 1, PerPage: 3},
+					Records:  routeRecords,
+				}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouteList{
+					OrderBy:    "-created_at",
+					Pagination: payloads.Pagination{Page: 1, PerPage: 2},
+				})
+			})
+
+			It("lists all routes and returns the requested page of the sorted list", func() {
+				Expect(routeRepo.ListRoutesCallCount()).To(Equal(1))
+				_, _, message := routeRepo.ListRoutesArgsForCall(0)
+				Expect(message.Pagination).To(Equal(repositories.Pagination{}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(3)),
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources[*].guid", []any{"route-3", "route-2"}),
+				)))
+			})
+		})
+
 		When("there is a failure Listing Routes", func() {
 			BeforeEach(func() {
-				routeRepo.ListRoutesReturns([]repositories.RouteRecord{}, errors.New("unknown!"))
+				routeRepo.ListRoutesReturns(repositories.ListResult[repositories.RouteRecord]{}, errors.New("unknown!"))
 			})
 
 			It("returns an error", func() {
@@ -250,6 +293,8 @@ var _ = Describe("Route", func() {
 	})
 
 	Describe("the POST /v3/routes endpoint", func() {
+		var payload payloads.RouteCreate
+
 		BeforeEach(func() {
 			requestMethod = http.MethodPost
 			requestPath = "/v3/routes"
@@ -267,7 +312,7 @@ var _ = Describe("Route", func() {
 
 			requestBody = "the-json-body"
 
-			payload := payloads.RouteCreate{
+			payload = payloads.RouteCreate{
 				Host: "test-route-host",
 				Path: "/test-route-path",
 				Relationships: &payloads.RouteRelationships{
@@ -392,6 +437,155 @@ var _ = Describe("Route", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("the host is blank", func() {
+			BeforeEach(func() {
+				payload.Host = ""
+			})
+
+			It("returns an error", func() {
+				Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+				expectUnprocessableEntityError("host cannot be blank")
+			})
+		})
+
+		When("the domain is assigned to a router group", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{
+					GUID:            "test-domain-guid",
+					Name:            "tcp.example.org",
+					RouterGroupGUID: "default-tcp",
+				}, nil)
+				payload.Host = ""
+				payload.Path = ""
+			})
+
+			It("creates the route on a reservable port", func() {
+				Expect(routeRepo.ListRoutesCallCount()).To(Equal(1))
+				_, actualAuthInfo, message := routeRepo.ListRoutesArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(message.DomainGUIDs).To(ConsistOf("test-domain-guid"))
+
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+				_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+				Expect(createRouteMessage.Port).To(SatisfyAll(BeNumerically(">=", 1024), BeNumerically("<=", 1033)))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			When("some ports are used by routes of the domain", func() {
+				BeforeEach(func() {
+					var routes []repositories.RouteRecord
+					for port := 1024; port <= 1032; port++ {
+						routes = append(routes, repositories.RouteRecord{Port: tools.PtrTo(port)})
+					}
+					routeRepo.ListRoutesReturns(repositories.ListResult[repositories.RouteRecord]{Records: routes}, nil)
+				})
+
+				It("creates the route on a free port", func() {
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+					_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+					Expect(createRouteMessage.Port).To(Equal(1033))
+
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				})
+			})
+
+			When("all ports are used by routes of the domain", func() {
+				BeforeEach(func() {
+					var routes []repositories.RouteRecord
+					for port := 1024; port <= 1033; port++ {
+						routes = append(routes, repositories.RouteRecord{Port: tools.PtrTo(port)})
+					}
+					routeRepo.ListRoutesReturns(repositories.ListResult[repositories.RouteRecord]{Records: routes}, nil)
+				})
+
+				It("returns an error", func() {
+					Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+					expectUnprocessableEntityError("There are no more ports available for router group: default-tcp. Please contact your administrator for more information.")
+				})
+			})
+
+			When("listing the routes of the domain fails", func() {
+				BeforeEach(func() {
+					routeRepo.ListRoutesReturns(repositories.ListResult[repositories.RouteRecord]{}, errors.New("list-err"))
+				})
+
+				It("returns an error", func() {
+					Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+					expectUnknownError()
+				})
+			})
+
+			When("the picked ports are taken in the meantime", func() {
+				BeforeEach(func() {
+					routeRepo.CreateRouteReturnsOnCall(0, repositories.RouteRecord{}, apierrors.NewUniquenessError(nil, "taken"))
+					routeRepo.CreateRouteReturnsOnCall(1, repositories.RouteRecord{}, apierrors.NewUniquenessError(nil, "taken"))
+				})
+
+				It("retries on another free port", func() {
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(3))
+					ports := map[int]bool{}
+					for i := 0; i < 3; i++ {
+						_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(i)
+						ports[createRouteMessage.Port] = true
+					}
+					Expect(ports).To(HaveLen(3))
+
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				})
+			})
+
+			When("the picked ports keep being taken", func() {
+				BeforeEach(func() {
+					routeRepo.CreateRouteReturns(repositories.RouteRecord{}, apierrors.NewUniquenessError(nil, "taken"))
+				})
+
+				It("gives up after a bounded number of attempts", func() {
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(5))
+					expectUnprocessableEntityError("There are no more ports available for router group: default-tcp. Please contact your administrator for more information.")
+				})
+			})
+
+			When("a port is requested", func() {
+				BeforeEach(func() {
+					payload.Port = tools.PtrTo(1025)
+				})
+
+				It("creates the route on that port", func() {
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+					_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+					Expect(createRouteMessage.Port).To(Equal(1025))
+
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				})
+
+				When("the port is not reservable", func() {
+					BeforeEach(func() {
+						payload.Port = tools.PtrTo(8080)
+					})
+
+					It("returns an error", func() {
+						Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+						expectUnprocessableEntityError("Port 8080 is not available on router group 'default-tcp'.")
+					})
+				})
+			})
+
+			When("the router group is unknown", func() {
+				BeforeEach(func() {
+					domainRepo.GetDomainReturns(repositories.DomainRecord{
+						GUID:            "test-domain-guid",
+						RouterGroupGUID: "other-tcp",
+					}, nil)
+				})
+
+				It("returns an error", func() {
+					Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+					expectUnprocessableEntityError("Router group with guid 'other-tcp' not found.")
+				})
+			})
+		})
 	})
 
 	Describe("the PATCH /v3/routes/:guid endpoint", func() {
@@ -582,6 +776,27 @@ var _ = Describe("Route", func() {
 			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
 		})
 
+		It("records a map route audit event for each app", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(2))
+			_, actualAuthInfo, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+				Type: "audit.app.map-route",
+				Target: repositories.AuditEventTarget{
+					GUID: "app-1-guid",
+					Type: "app",
+				},
+				SpaceGUID: "test-space-guid",
+				Data: map[string]any{
+					"route_guid":   "test-route-guid",
+					"process_type": "web",
+				},
+			}))
+			_, _, message = auditEventRecorder.RecordEventArgsForCall(1)
+			Expect(message.Target.GUID).To(Equal("app-2-guid"))
+			Expect(message.Data).To(HaveKeyWithValue("process_type", "queue"))
+		})
+
 		It("adds the destinations to the route", func() {
 			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
 			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
@@ -687,6 +902,30 @@ var _ = Describe("Route", func() {
 			requestBody = ""
 		})
 
+		It("does not record an audit event for an unknown destination", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(BeZero())
+		})
+
+		When("the destination is mapped to an app", func() {
+			BeforeEach(func() {
+				routeRecord.Destinations[0].AppGUID = "app-1-guid"
+				routeRecord.Destinations[0].ProcessType = "web"
+				routeRepo.GetRouteReturns(routeRecord, nil)
+				requestPath = "/v3/routes/test-route-guid/destinations/dest-1-guid"
+			})
+
+			It("records an unmap route audit event", func() {
+				Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+				_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+				Expect(message.Type).To(Equal("audit.app.unmap-route"))
+				Expect(message.Target.GUID).To(Equal("app-1-guid"))
+				Expect(message.Data).To(Equal(map[string]any{
+					"route_guid":   "test-route-guid",
+					"process_type": "web",
+				}))
+			})
+		})
+
 		It("deletes the destination", func() {
 			Expect(routeRepo.GetRouteCallCount()).To(Equal(1))
 			_, actualAuthInfo, actualRouteGUID := routeRepo.GetRouteArgsForCall(0)
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/config"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	RouterGroupsPath = "/routing/v1/router_groups"
+	RouterGroupPath  = "/routing/v1/router_groups/{guid}"
+
+	RouterGroupResourceType = "Router Group"
+)
+
+// RouterGroup serves the subset of the CF routing API the cf cli needs to
+// create tcp domains. The only router group is the configured tcp one.
+type RouterGroup struct {
+	requestValidator RequestValidator
+	tcpRouterGroup   config.RouterGroupConfig
+}
+
+func NewRouterGroup(
+	requestValidator RequestValidator,
+	tcpRouterGroup config.RouterGroupConfig,
+) *RouterGroup {
+	return &RouterGroup{
+		requestValidator: requestValidator,
+		tcpRouterGroup:   tcpRouterGroup,
+	}
+}
+
+func (h *RouterGroup) list(r *http.Request) (*routing.Response, error) {
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.router-group.list")
+
+	payload := new(payloads.RouterGroupList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode request query parameters")
+	}
+
+	routerGroups := []presenter.RouterGroupResponse{}
+	if h.tcpRouterGroup.Enabled() && (payload.Name == "" || payload.Name == h.tcpRouterGroup.Name) {
+		routerGroups = append(routerGroups, presenter.ForRouterGroup(h.tcpRouterGroup))
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(routerGroups), nil
+}
+
+func (h *RouterGroup) get(r *http.Request) (*routing.Response, error) {
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.router-group.get")
+
+	routerGroupGUID := routing.URLParam(r, "guid")
+
+	if !h.tcpRouterGroup.Enabled() || routerGroupGUID != h.tcpRouterGroup.Name {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewNotFoundError(nil, RouterGroupResourceType), "router group not found", "guid", routerGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouterGroup(h.tcpRouterGroup)), nil
+}
+
+func (h *RouterGroup) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *RouterGroup) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: RouterGroupsPath, Handler: h.list},
+		{Method: "GET", Pattern: RouterGroupPath, Handler: h.get},
+	}
+}
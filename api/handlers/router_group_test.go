@@ -0,0 +1,107 @@
+package handlers_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/config"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterGroup", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		tcpRouterGroup   config.RouterGroupConfig
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouterGroupList{})
+		tcpRouterGroup = config.RouterGroupConfig{
+			Name:            "default-tcp",
+			ReservablePorts: "1024-1033",
+		}
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.LoadRoutes(handlers.NewRouterGroup(requestValidator, tcpRouterGroup))
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /routing/v1/router_groups", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/routing/v1/router_groups", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the tcp router group", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`[{
+				"guid": "default-tcp",
+				"name": "default-tcp",
+				"type": "tcp",
+				"reservable_ports": "1024-1033"
+			}]`)))
+		})
+
+		When("filtering by another name", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouterGroupList{
+					Name: "another-router-group",
+				})
+			})
+
+			It("returns an empty list", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`[]`)))
+			})
+		})
+
+		When("tcp routing is disabled", func() {
+			BeforeEach(func() {
+				tcpRouterGroup = config.RouterGroupConfig{}
+			})
+
+			It("returns an empty list", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`[]`)))
+			})
+		})
+	})
+
+	Describe("GET /routing/v1/router_groups/{guid}", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/routing/v1/router_groups/default-tcp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the tcp router group", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "default-tcp"),
+				MatchJSONPath("$.type", "tcp"),
+			)))
+		})
+
+		When("the router group does not exist", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", "/routing/v1/router_groups/another-router-group", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Router Group")
+			})
+		})
+	})
+})
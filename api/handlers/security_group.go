@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	SecurityGroupsPath                = "/v3/security_groups"
+	SecurityGroupPath                 = "/v3/security_groups/{guid}"
+	SecurityGroupRunningSpacesRelPath = "/v3/security_groups/{guid}/relationships/running_spaces"
+	SecurityGroupRunningSpaceRelPath  = "/v3/security_groups/{guid}/relationships/running_spaces/{space_guid}"
+	SecurityGroupStagingSpacesRelPath = "/v3/security_groups/{guid}/relationships/staging_spaces"
+	SecurityGroupStagingSpaceRelPath  = "/v3/security_groups/{guid}/relationships/staging_spaces/{space_guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSecurityGroupRepository . CFSecurityGroupRepository
+
+type CFSecurityGroupRepository interface {
+	CreateSecurityGroup(context.Context, authorization.Info, repositories.CreateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	GetSecurityGroup(context.Context, authorization.Info, string) (repositories.SecurityGroupRecord, error)
+	ListSecurityGroups(context.Context, authorization.Info, repositories.ListSecurityGroupsMessage) ([]repositories.SecurityGroupRecord, error)
+	PatchSecurityGroup(context.Context, authorization.Info, repositories.PatchSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	BindSecurityGroup(context.Context, authorization.Info, repositories.BindSecurityGroupMessage) ([]string, error)
+	UnbindSecurityGroup(context.Context, authorization.Info, repositories.UnbindSecurityGroupMessage) error
+	DeleteSecurityGroup(context.Context, authorization.Info, string) error
+}
+
+type SecurityGroup struct {
+	serverURL         url.URL
+	requestValidator  RequestValidator
+	securityGroupRepo CFSecurityGroupRepository
+}
+
+func NewSecurityGroup(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	securityGroupRepo CFSecurityGroupRepository,
+) *SecurityGroup {
+	return &SecurityGroup{
+		serverURL:         serverURL,
+		requestValidator:  requestValidator,
+		securityGroupRepo: securityGroupRepo,
+	}
+}
+
+func (h *SecurityGroup) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.create")
+
+	var payload payloads.SecurityGroupCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	securityGroup, err := h.securityGroupRepo.CreateSecurityGroup(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create security group")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.get")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	securityGroup, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.list")
+
+	payload := new(payloads.SecurityGroupList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode request query parameters")
+	}
+
+	securityGroups, err := h.securityGroupRepo.ListSecurityGroups(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list security groups")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSecurityGroup, securityGroups, h.serverURL, *r.URL)), nil
+}
+
+func (h *SecurityGroup) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.update")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SecurityGroupPatch
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", securityGroupGUID)
+	}
+
+	securityGroup, err := h.securityGroupRepo.PatchSecurityGroup(r.Context(), authInfo, payload.ToMessage(securityGroupGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to patch security group", "guid", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) bindRunningSpaces(r *http.Request) (*routing.Response, error) {
+	return h.bindSpaces(r, repositories.SecurityGroupRunningWorkload)
+}
+
+func (h *SecurityGroup) bindStagingSpaces(r *http.Request) (*routing.Response, error) {
+	return h.bindSpaces(r, repositories.SecurityGroupStagingWorkload)
+}
+
+func (h *SecurityGroup) bindSpaces(r *http.Request, workload string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.bind-spaces").WithValues("workload", workload)
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SecurityGroupBind
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", securityGroupGUID)
+	}
+
+	spaceGUIDs, err := h.securityGroupRepo.BindSecurityGroup(r.Context(), authInfo, payload.ToMessage(securityGroupGUID, workload))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to bind security group", "guid", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupSpaces(securityGroupGUID, workload, spaceGUIDs, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) unbindRunningSpace(r *http.Request) (*routing.Response, error) {
+	return h.unbindSpace(r, repositories.SecurityGroupRunningWorkload)
+}
+
+func (h *SecurityGroup) unbindStagingSpace(r *http.Request) (*routing.Response, error) {
+	return h.unbindSpace(r, repositories.SecurityGroupStagingWorkload)
+}
+
+func (h *SecurityGroup) unbindSpace(r *http.Request, workload string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.unbind-space").WithValues("workload", workload)
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", securityGroupGUID)
+	}
+
+	err = h.securityGroupRepo.UnbindSecurityGroup(r.Context(), authInfo, repositories.UnbindSecurityGroupMessage{
+		GUID:      securityGroupGUID,
+		Workload:  workload,
+		SpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to unbind security group", "guid", securityGroupGUID, "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *SecurityGroup) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.delete")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", securityGroupGUID)
+	}
+
+	err = h.securityGroupRepo.DeleteSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete security group", "guid", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(securityGroupGUID, presenter.SecurityGroupDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *SecurityGroup) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SecurityGroup) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: SecurityGroupsPath, Handler: h.create},
+		{Method: "GET", Pattern: SecurityGroupsPath, Handler: h.list},
+		{Method: "GET", Pattern: SecurityGroupPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SecurityGroupPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SecurityGroupPath, Handler: h.delete},
+		{Method: "POST", Pattern: SecurityGroupRunningSpacesRelPath, Handler: h.bindRunningSpaces},
+		{Method: "DELETE", Pattern: SecurityGroupRunningSpaceRelPath, Handler: h.unbindRunningSpace},
+		{Method: "POST", Pattern: SecurityGroupStagingSpacesRelPath, Handler: h.bindStagingSpaces},
+		{Method: "DELETE", Pattern: SecurityGroupStagingSpaceRelPath, Handler: h.unbindStagingSpace},
+	}
+}
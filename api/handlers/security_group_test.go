@@ -0,0 +1,414 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecurityGroup", func() {
+	var (
+		apiHandler        *handlers.SecurityGroup
+		securityGroupRepo *fake.CFSecurityGroupRepository
+		requestValidator  *fake.RequestValidator
+		req               *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		securityGroupRepo = new(fake.CFSecurityGroupRepository)
+		apiHandler = handlers.NewSecurityGroup(
+			*serverURL,
+			requestValidator,
+			securityGroupRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{
+			GUID: "security-group-guid",
+			Name: "my-security-group",
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/security_groups", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupCreate{
+				Name:            "my-security-group",
+				GloballyEnabled: payloads.SecurityGroupWorkloads{Staging: true},
+				Rules: []payloads.SecurityGroupRule{
+					{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "443"},
+				},
+				Relationships: &payloads.SecurityGroupRelationships{
+					RunningSpaces: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+					},
+				},
+			})
+
+			securityGroupRepo.CreateSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID:            "security-group-guid",
+				Name:            "my-security-group",
+				GloballyEnabled: repositories.SecurityGroupWorkloads{Staging: true},
+				Rules: []repositories.SecurityGroupRule{
+					{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "443"},
+				},
+				RunningSpaceGUIDs: []string{"space-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/security_groups", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the security group", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(securityGroupRepo.CreateSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := securityGroupRepo.CreateSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateSecurityGroupMessage{
+				Name:            "my-security-group",
+				GloballyEnabled: repositories.SecurityGroupWorkloads{Staging: true},
+				Rules: []repositories.SecurityGroupRule{
+					{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "443"},
+				},
+				RunningSpaceGUIDs: []string{"space-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "security-group-guid"),
+				MatchJSONPath("$.globally_enabled.staging", true),
+				MatchJSONPath("$.rules[0].destination", "10.0.0.0/8"),
+				MatchJSONPath("$.relationships.running_spaces.data[0].guid", "space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/security-group-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.CreateSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/security_groups/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/security_groups/security-group-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the security group", func() {
+			Expect(securityGroupRepo.GetSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := securityGroupRepo.GetSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("security-group-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "security-group-guid"),
+				MatchJSONPath("$.name", "my-security-group"),
+			)))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/security_groups", func() {
+		BeforeEach(func() {
+			securityGroupRepo.ListSecurityGroupsReturns([]repositories.SecurityGroupRecord{
+				{GUID: "security-group-1"},
+				{GUID: "security-group-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SecurityGroupList{
+				Names:                  "sg1,sg2",
+				GloballyEnabledRunning: "true",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/security_groups", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the security groups", func() {
+			Expect(securityGroupRepo.ListSecurityGroupsCallCount()).To(Equal(1))
+			_, _, listMessage := securityGroupRepo.ListSecurityGroupsArgsForCall(0)
+			Expect(listMessage).To(Equal(repositories.ListSecurityGroupsMessage{
+				GUIDs:                  []string{},
+				Names:                  []string{"sg1", "sg2"},
+				GloballyEnabledRunning: tools.PtrTo(true),
+				RunningSpaceGUIDs:      []string{},
+				StagingSpaceGUIDs:      []string{},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/security_groups?page=1&per_page=50"),
+				MatchJSONPath("$.resources[*].guid", ConsistOf("security-group-1", "security-group-2")),
+			)))
+		})
+
+		When("listing the security groups fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.ListSecurityGroupsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/security_groups/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupPatch{
+				Name: tools.PtrTo("new-name"),
+				GloballyEnabled: &payloads.SecurityGroupWorkloadsPatch{
+					Running: tools.PtrTo(true),
+				},
+			})
+
+			securityGroupRepo.PatchSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID: "security-group-guid",
+				Name: "new-name",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/security_groups/security-group-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("patches the security group", func() {
+			Expect(securityGroupRepo.PatchSecurityGroupCallCount()).To(Equal(1))
+			_, _, patchMessage := securityGroupRepo.PatchSecurityGroupArgsForCall(0)
+			Expect(patchMessage).To(Equal(repositories.PatchSecurityGroupMessage{
+				GUID:                   "security-group-guid",
+				Name:                   tools.PtrTo("new-name"),
+				GloballyEnabledRunning: tools.PtrTo(true),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the security group does not exist", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.PatchSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("patching the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.PatchSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("patch-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/security_groups/:guid/relationships/running_spaces", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupBind{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-2"}},
+				},
+			})
+
+			securityGroupRepo.BindSecurityGroupReturns([]string{"space-1", "space-2"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/security_groups/security-group-guid/relationships/running_spaces", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("binds the security group to the spaces for running", func() {
+			Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+			_, _, bindMessage := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(bindMessage).To(Equal(repositories.BindSecurityGroupMessage{
+				GUID:       "security-group-guid",
+				Workload:   repositories.SecurityGroupRunningWorkload,
+				SpaceGUIDs: []string{"space-2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("space-1", "space-2")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/security-group-guid/relationships/running_spaces"),
+			)))
+		})
+
+		When("a space does not exist", func() {
+			BeforeEach(func() {
+				securityGroupRepo.BindSecurityGroupReturns(nil, apierrors.NewUnprocessableEntityError(nil, "Space with guid 'space-2' does not exist, or you do not have access to it."))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Space with guid 'space-2' does not exist, or you do not have access to it.")
+			})
+		})
+
+		When("the security group does not exist", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /v3/security_groups/:guid/relationships/staging_spaces", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupBind{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-1"}},
+				},
+			})
+
+			securityGroupRepo.BindSecurityGroupReturns([]string{"space-1"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/security_groups/security-group-guid/relationships/staging_spaces", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("binds the security group to the spaces for staging", func() {
+			Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+			_, _, bindMessage := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(bindMessage.Workload).To(Equal(repositories.SecurityGroupStagingWorkload))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/security-group-guid/relationships/staging_spaces"),
+			))
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/:guid/relationships/running_spaces/:space_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/security_groups/security-group-guid/relationships/running_spaces/space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unbinds the security group from the space for running", func() {
+			Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+			_, _, unbindMessage := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(unbindMessage).To(Equal(repositories.UnbindSecurityGroupMessage{
+				GUID:      "security-group-guid",
+				Workload:  repositories.SecurityGroupRunningWorkload,
+				SpaceGUID: "space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("unbinding fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.UnbindSecurityGroupReturns(errors.New("unbind-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/:guid/relationships/staging_spaces/:space_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/security_groups/security-group-guid/relationships/staging_spaces/space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unbinds the security group from the space for staging", func() {
+			Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+			_, _, unbindMessage := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(unbindMessage.Workload).To(Equal(repositories.SecurityGroupStagingWorkload))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/security_groups/security-group-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the security group", func() {
+			Expect(securityGroupRepo.DeleteSecurityGroupCallCount()).To(Equal(1))
+			_, _, actualGUID := securityGroupRepo.DeleteSecurityGroupArgsForCall(0)
+			Expect(actualGUID).To(Equal("security-group-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/security_group.delete~security-group-guid"))
+		})
+
+		When("the user cannot get the security group", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.DeleteSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+	})
+})
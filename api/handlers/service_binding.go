@@ -16,8 +16,9 @@ import (
 )
 
 const (
-	ServiceBindingsPath = "/v3/service_credential_bindings"
-	ServiceBindingPath  = "/v3/service_credential_bindings/{guid}"
+	ServiceBindingsPath       = "/v3/service_credential_bindings"
+	ServiceBindingPath        = "/v3/service_credential_bindings/{guid}"
+	ServiceBindingDetailsPath = "/v3/service_credential_bindings/{guid}/details"
 )
 
 type ServiceBinding struct {
@@ -26,24 +27,27 @@ type ServiceBinding struct {
 	serviceInstanceRepo CFServiceInstanceRepository
 	serverURL           url.URL
 	requestValidator    RequestValidator
+	auditEventRecorder  AuditEventRecorder
 }
 
 //counterfeiter:generate -o fake -fake-name CFServiceBindingRepository . CFServiceBindingRepository
 type CFServiceBindingRepository interface {
 	CreateServiceBinding(context.Context, authorization.Info, repositories.CreateServiceBindingMessage) (repositories.ServiceBindingRecord, error)
 	DeleteServiceBinding(context.Context, authorization.Info, string) error
-	ListServiceBindings(context.Context, authorization.Info, repositories.ListServiceBindingsMessage) ([]repositories.ServiceBindingRecord, error)
+	ListServiceBindings(context.Context, authorization.Info, repositories.ListServiceBindingsMessage) (repositories.ListResult[repositories.ServiceBindingRecord], error)
 	GetServiceBinding(context.Context, authorization.Info, string) (repositories.ServiceBindingRecord, error)
+	GetServiceBindingDetails(context.Context, authorization.Info, string) (repositories.ServiceBindingDetailsRecord, error)
 	UpdateServiceBinding(context.Context, authorization.Info, repositories.UpdateServiceBindingMessage) (repositories.ServiceBindingRecord, error)
 }
 
-func NewServiceBinding(serverURL url.URL, serviceBindingRepo CFServiceBindingRepository, appRepo CFAppRepository, serviceInstanceRepo CFServiceInstanceRepository, requestValidator RequestValidator) *ServiceBinding {
+func NewServiceBinding(serverURL url.URL, serviceBindingRepo CFServiceBindingRepository, appRepo CFAppRepository, serviceInstanceRepo CFServiceInstanceRepository, requestValidator RequestValidator, auditEventRecorder AuditEventRecorder) *ServiceBinding {
 	return &ServiceBinding{
 		appRepo:             appRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
 		serverURL:           serverURL,
 		requestValidator:    requestValidator,
+		auditEventRecorder:  auditEventRecorder,
 	}
 }
 
@@ -56,30 +60,34 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
-	app, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
-	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.AppResourceType)
-	}
-
 	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, payload.Relationships.ServiceInstance.Data.GUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceInstanceResourceType)
 	}
 
-	if app.SpaceGUID != serviceInstance.SpaceGUID {
-		return nil, apierrors.LogAndReturn(
-			logger,
-			apierrors.NewUnprocessableEntityError(nil, "The service instance and the app are in different spaces"),
-			"App and ServiceInstance in different spaces", "App GUID", app.GUID,
-			"ServiceInstance GUID", serviceInstance.GUID,
-		)
+	if !payload.IsKey() {
+		app, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.AppResourceType)
+		}
+
+		if app.SpaceGUID != serviceInstance.SpaceGUID {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, "The service instance and the app are in different spaces"),
+				"App and ServiceInstance in different spaces", "App GUID", app.GUID,
+				"ServiceInstance GUID", serviceInstance.GUID,
+			)
+		}
 	}
 
-	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(r.Context(), authInfo, payload.ToMessage(app.SpaceGUID))
+	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(r.Context(), authInfo, payload.ToMessage(serviceInstance.SpaceGUID))
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "failed to create ServiceBinding", "App GUID", app.GUID, "ServiceInstance GUID", serviceInstance.GUID)
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create ServiceBinding", "Type", payload.Type, "ServiceInstance GUID", serviceInstance.GUID)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, serviceBindingAuditEvent(repositories.AuditEventTypeServiceBindingCreate, serviceBinding))
+
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceBinding(serviceBinding, h.serverURL)), nil
 }
 
@@ -89,11 +97,18 @@ func (h *ServiceBinding) delete(r *http.Request) (*routing.Response, error) {
 
 	serviceBindingGUID := routing.URLParam(r, "guid")
 
-	err := h.serviceBindingRepo.DeleteServiceBinding(r.Context(), authInfo, serviceBindingGUID)
+	serviceBinding, err := h.serviceBindingRepo.GetServiceBinding(r.Context(), authInfo, serviceBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service binding", "guid", serviceBindingGUID)
+	}
+
+	err = h.serviceBindingRepo.DeleteServiceBinding(r.Context(), authInfo, serviceBindingGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "error when deleting service binding", "guid", serviceBindingGUID)
 	}
 
+	h.auditEventRecorder.RecordEvent(r.Context(), authInfo, serviceBindingAuditEvent(repositories.AuditEventTypeServiceBindingDelete, serviceBinding))
+
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
@@ -111,12 +126,20 @@ func (h *ServiceBinding) list(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to list "+repositories.ServiceBindingResourceType)
 	}
 
+	serviceBindingList = sortListResult(serviceBindingList, listFilter.OrderBy, listFilter.Pagination, orderBy[repositories.ServiceBindingRecord]{
+		"created_at": func(a, b repositories.ServiceBindingRecord) bool { return createdBefore(a.CreatedAt, b.CreatedAt) },
+		"updated_at": func(a, b repositories.ServiceBindingRecord) bool { return updatedBefore(a.UpdatedAt, b.UpdatedAt) },
+		"name":       func(a, b repositories.ServiceBindingRecord) bool { return nameBefore(a.Name, b.Name) },
+	})
+
 	var appRecords []repositories.AppRecord
-	if listFilter.Include != "" && len(serviceBindingList) > 0 {
+	if listFilter.Include != "" && len(serviceBindingList.Records) > 0 {
 		listAppsMessage := repositories.ListAppsMessage{}
 
-		for _, serviceBinding := range serviceBindingList {
-			listAppsMessage.Guids = append(listAppsMessage.Guids, serviceBinding.AppGUID)
+		for _, serviceBinding := range serviceBindingList.Records {
+			if serviceBinding.AppGUID != "" {
+				listAppsMessage.Guids = append(listAppsMessage.Guids, serviceBinding.AppGUID)
+			}
 		}
 
 		appRecords, err = h.appRepo.ListApps(r.Context(), authInfo, listAppsMessage)
@@ -165,6 +188,19 @@ func (h *ServiceBinding) get(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBinding(serviceBinding, h.serverURL)), nil
 }
 
+func (h *ServiceBinding) getDetails(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-binding.get-details")
+
+	serviceBindingGUID := routing.URLParam(r, "guid")
+
+	serviceBindingDetails, err := h.serviceBindingRepo.GetServiceBindingDetails(r.Context(), authInfo, serviceBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting service binding details in repository")
+	}
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBindingDetails(serviceBindingDetails)), nil
+}
+
 func (h *ServiceBinding) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -176,5 +212,6 @@ func (h *ServiceBinding) AuthenticatedRoutes() []routing.Route {
 		{Method: "DELETE", Pattern: ServiceBindingPath, Handler: h.delete},
 		{Method: "PATCH", Pattern: ServiceBindingPath, Handler: h.update},
 		{Method: "GET", Pattern: ServiceBindingPath, Handler: h.get},
+		{Method: "GET", Pattern: ServiceBindingDetailsPath, Handler: h.getDetails},
 	}
 }
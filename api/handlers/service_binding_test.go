@@ -27,12 +27,16 @@ var _ = Describe("ServiceBinding", func() {
 		appRepo             *fake.CFAppRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		requestValidator    *fake.RequestValidator
+		auditEventRecorder  *fake.AuditEventRecorder
 	)
 
 	BeforeEach(func() {
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
 		serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{
-			GUID: "service-binding-guid",
+			GUID:                "service-binding-guid",
+			AppGUID:             "app-guid",
+			ServiceInstanceGUID: "service-instance-guid",
+			SpaceGUID:           "space-guid",
 		}, nil)
 
 		appRepo = new(fake.CFAppRepository)
@@ -48,6 +52,7 @@ var _ = Describe("ServiceBinding", func() {
 		}, nil)
 
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 
 		apiHandler := NewServiceBinding(
 			*serverURL,
@@ -55,6 +60,7 @@ var _ = Describe("ServiceBinding", func() {
 			appRepo,
 			serviceInstanceRepo,
 			requestValidator,
+			auditEventRecorder,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -75,7 +81,11 @@ var _ = Describe("ServiceBinding", func() {
 			requestBody = "the-json-body"
 
 			serviceBindingRepo.CreateServiceBindingReturns(repositories.ServiceBindingRecord{
-				GUID: "service-binding-guid",
+				GUID:                "service-binding-guid",
+				Name:                tools.PtrTo("my-binding"),
+				AppGUID:             "app-guid",
+				ServiceInstanceGUID: "service-instance-guid",
+				SpaceGUID:           "space-guid",
 			}, nil)
 
 			payload = payloads.ServiceBindingCreate{
@@ -96,6 +106,25 @@ var _ = Describe("ServiceBinding", func() {
 			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
 		})
 
+		It("records a service binding create audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+				Type: "audit.service_binding.create",
+				Target: repositories.AuditEventTarget{
+					GUID: "service-binding-guid",
+					Type: "service_binding",
+					Name: "my-binding",
+				},
+				SpaceGUID: "space-guid",
+				Data: map[string]any{
+					"app_guid":              "app-guid",
+					"service_instance_guid": "service-instance-guid",
+				},
+			}))
+		})
+
 		It("creates a service binding", func() {
 			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
 			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
@@ -126,6 +155,58 @@ var _ = Describe("ServiceBinding", func() {
 			)))
 		})
 
+		When("the binding is a key", func() {
+			BeforeEach(func() {
+				payload.Type = "key"
+				payload.Name = tools.PtrTo("my-key")
+				payload.Relationships.App = nil
+
+				serviceBindingRepo.CreateServiceBindingReturns(repositories.ServiceBindingRecord{
+					GUID:                "service-binding-guid",
+					Type:                "key",
+					Name:                tools.PtrTo("my-key"),
+					ServiceInstanceGUID: "service-instance-guid",
+					SpaceGUID:           "space-guid",
+				}, nil)
+			})
+
+			It("creates a key binding in the space of the service instance", func() {
+				Expect(appRepo.GetAppCallCount()).To(BeZero())
+
+				Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+				_, _, createServiceBindingMessage := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+				Expect(createServiceBindingMessage).To(Equal(repositories.CreateServiceBindingMessage{
+					Type:                "key",
+					Name:                tools.PtrTo("my-key"),
+					ServiceInstanceGUID: "service-instance-guid",
+					SpaceGUID:           "space-guid",
+				}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.type", "key"),
+					MatchJSONPath("$.name", "my-key"),
+				)))
+			})
+
+			It("records a service key create audit event", func() {
+				Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+				_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+				Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+					Type: "audit.service_key.create",
+					Target: repositories.AuditEventTarget{
+						GUID: "service-binding-guid",
+						Type: "service_key",
+						Name: "my-key",
+					},
+					SpaceGUID: "space-guid",
+					Data: map[string]any{
+						"service_instance_guid": "service-instance-guid",
+					},
+				}))
+			})
+		})
+
 		When("the request body is invalid json", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -238,14 +319,58 @@ var _ = Describe("ServiceBinding", func() {
 		})
 	})
 
+	Describe("GET /v3/service_credential_bindings/{guid}/details", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_credential_bindings/service-binding-guid/details"
+			requestBody = ""
+
+			serviceBindingRepo.GetServiceBindingDetailsReturns(repositories.ServiceBindingDetailsRecord{
+				Credentials: map[string]any{"username": "admin"},
+			}, nil)
+		})
+
+		It("returns the service binding credentials", func() {
+			Expect(serviceBindingRepo.GetServiceBindingDetailsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceBindingRepo.GetServiceBindingDetailsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-binding-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.credentials.username", "admin")))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingDetailsReturns(repositories.ServiceBindingDetailsRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceBindingResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.ServiceBindingResourceType)
+			})
+		})
+
+		When("getting the details fails", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingDetailsReturns(repositories.ServiceBindingDetailsRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("GET /v3/service_credential_bindings", func() {
 		BeforeEach(func() {
 			requestMethod = http.MethodGet
 			requestBody = ""
 			requestPath = "/v3/service_credential_bindings?foo=bar"
 
-			serviceBindingRepo.ListServiceBindingsReturns([]repositories.ServiceBindingRecord{
-				{GUID: "service-binding-guid", AppGUID: "app-guid"},
+			serviceBindingRepo.ListServiceBindingsReturns(repositories.ListResult[repositories.ServiceBindingRecord]{
+				PageInfo: repositories.PageInfo{TotalResults: 1, Page: 1, PerPage: 50},
+				Records:  []repositories.ServiceBindingRecord{{GUID: "service-binding-guid", AppGUID: "app-guid"}},
 			}, nil)
 			appRepo.ListAppsReturns([]repositories.AppRecord{{Name: "some-app-name"}}, nil)
 
@@ -272,14 +397,45 @@ var _ = Describe("ServiceBinding", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_credential_bindings?foo=bar"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_credential_bindings?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources[0].guid", "service-binding-guid"),
 			)))
 		})
 
+		When("the service bindings are ordered by name", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.ListServiceBindingsReturns(repositories.ListResult[repositories.ServiceBindingRecord]{
+					PageInfo: repositories.PageInfo{TotalResults: 3, Page: 1, PerPage: 3},
+					Records: []repositories.ServiceBindingRecord{
+						{GUID: "binding-b", Name: tools.PtrTo("b")},
+						{GUID: "binding-c", Name: tools.PtrTo("c")},
+						{GUID: "binding-a", Name: tools.PtrTo("a")},
+					},
+				}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceBindingList{
+					OrderBy:    "name",
+					Pagination: payloads.Pagination{Page: 1, PerPage: 2},
+				})
+			})
+
+			It("lists all service bindings and returns the requested page of the sorted list", func() {
+				Expect(serviceBindingRepo.ListServiceBindingsCallCount()).To(Equal(1))
+				_, _, message := serviceBindingRepo.ListServiceBindingsArgsForCall(0)
+				Expect(message.Pagination).To(Equal(repositories.Pagination{}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(3)),
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources[*].guid", []any{"binding-a", "binding-b"}),
+				)))
+			})
+		})
+
 		When("there is an error fetching service binding", func() {
 			BeforeEach(func() {
-				serviceBindingRepo.ListServiceBindingsReturns([]repositories.ServiceBindingRecord{}, errors.New("unknown"))
+				serviceBindingRepo.ListServiceBindingsReturns(repositories.ListResult[repositories.ServiceBindingRecord]{}, errors.New("unknown"))
 			})
 
 			It("returns an error", func() {
@@ -329,6 +485,36 @@ var _ = Describe("ServiceBinding", func() {
 			_, _, guid := serviceBindingRepo.DeleteServiceBindingArgsForCall(0)
 			Expect(guid).To(Equal("service-binding-guid"))
 		})
+
+		It("records a service binding delete audit event", func() {
+			Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRecorder.RecordEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.service_binding.delete"))
+			Expect(message.Target.GUID).To(Equal("service-binding-guid"))
+			Expect(message.SpaceGUID).To(Equal("space-guid"))
+		})
+
+		When("the service binding is not accessible", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceBindingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceBindingResourceType)
+				Expect(serviceBindingRepo.DeleteServiceBindingCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the service binding fails", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.DeleteServiceBindingReturns(errors.New("boom"))
+			})
+
+			It("returns an error and does not record an audit event", func() {
+				expectUnknownError()
+				Expect(auditEventRecorder.RecordEventCallCount()).To(BeZero())
+			})
+		})
 	})
 
 	Describe("PATCH /v3/service_credential_bindings/:guid", func() {
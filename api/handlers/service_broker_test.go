@@ -126,7 +126,7 @@ var _ = Describe("ServiceBroker", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_brokers"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_brokers?page=1&per_page=50"),
 				MatchJSONPath("$.resources[0].guid", "broker-guid"),
 				MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/service_brokers/broker-guid"),
 			)))
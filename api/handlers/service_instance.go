@@ -9,6 +9,7 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"code.cloudfoundry.org/korifi/api/presenter"
 
@@ -37,20 +38,27 @@ type ServiceInstance struct {
 	serverURL           url.URL
 	serviceInstanceRepo CFServiceInstanceRepository
 	spaceRepo           CFSpaceRepository
+	servicePlanRepo     CFServicePlanRepository
 	requestValidator    RequestValidator
+
+	managedServicesEnabled bool
 }
 
 func NewServiceInstance(
 	serverURL url.URL,
 	serviceInstanceRepo CFServiceInstanceRepository,
 	spaceRepo CFSpaceRepository,
+	servicePlanRepo CFServicePlanRepository,
 	requestValidator RequestValidator,
+	managedServicesEnabled bool,
 ) *ServiceInstance {
 	return &ServiceInstance{
-		serverURL:           serverURL,
-		serviceInstanceRepo: serviceInstanceRepo,
-		spaceRepo:           spaceRepo,
-		requestValidator:    requestValidator,
+		serverURL:              serverURL,
+		serviceInstanceRepo:    serviceInstanceRepo,
+		spaceRepo:              spaceRepo,
+		servicePlanRepo:        servicePlanRepo,
+		requestValidator:       requestValidator,
+		managedServicesEnabled: managedServicesEnabled,
 	}
 }
 
@@ -64,6 +72,14 @@ func (h *ServiceInstance) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if payload.Type == korifiv1alpha1.ManagedType && !h.managedServicesEnabled {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewInvalidRequestError(nil, "Experimental managed services support is not enabled"),
+			"Managed service instances are disabled",
+		)
+	}
+
 	spaceGUID := payload.Relationships.Space.Data.GUID
 	_, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
 	if err != nil {
@@ -75,11 +91,29 @@ func (h *ServiceInstance) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if payload.Type == korifiv1alpha1.ManagedType {
+		planGUID := payload.Relationships.ServicePlanGUID()
+		_, err = h.servicePlanRepo.GetPlan(r.Context(), authInfo, planGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, "Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.", apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+				"Failed to get service plan",
+				"planGUID", planGUID,
+			)
+		}
+	}
+
 	serviceInstanceRecord, err := h.serviceInstanceRepo.CreateServiceInstance(r.Context(), authInfo, payload.ToServiceInstanceCreateMessage())
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create service instance", "Service Instance Name", serviceInstanceRecord.Name)
 	}
 
+	if serviceInstanceRecord.Type == korifiv1alpha1.ManagedType {
+		return routing.NewResponse(http.StatusAccepted).
+			WithHeader("Location", presenter.JobURLForRedirects(serviceInstanceRecord.GUID, presenter.ServiceInstanceCreateOperation, h.serverURL)), nil
+	}
+
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceInstance(serviceInstanceRecord, h.serverURL)), nil
 }
 
@@ -99,6 +133,14 @@ func (h *ServiceInstance) patch(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance")
 	}
 
+	if serviceInstance.Type == korifiv1alpha1.ManagedType && payload.Credentials != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Credentials can only be updated for user-provided service instances"),
+			"credentials cannot be set on a managed service instance",
+		)
+	}
+
 	patchMessage := payload.ToServiceInstancePatchMessage(serviceInstance.SpaceGUID, serviceInstance.GUID)
 	serviceInstance, err = h.serviceInstanceRepo.PatchServiceInstance(r.Context(), authInfo, patchMessage)
 	if err != nil {
@@ -166,6 +208,11 @@ func (h *ServiceInstance) delete(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "error when deleting service instance", "guid", serviceInstanceGUID)
 	}
 
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		return routing.NewResponse(http.StatusAccepted).
+			WithHeader("Location", presenter.JobURLForRedirects(serviceInstanceGUID, presenter.ServiceInstanceDeleteOperation, h.serverURL)), nil
+	}
+
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
@@ -23,10 +23,12 @@ var _ = Describe("ServiceInstance", func() {
 	var (
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		spaceRepo           *fake.CFSpaceRepository
+		servicePlanRepo     *fake.CFServicePlanRepository
 		requestValidator    *fake.RequestValidator
 
-		reqMethod string
-		reqPath   string
+		managedServicesEnabled bool
+		reqMethod              string
+		reqPath                string
 	)
 
 	BeforeEach(func() {
@@ -38,21 +40,26 @@ var _ = Describe("ServiceInstance", func() {
 
 		spaceRepo = new(fake.CFSpaceRepository)
 
+		servicePlanRepo = new(fake.CFServicePlanRepository)
+
 		requestValidator = new(fake.RequestValidator)
 
+		managedServicesEnabled = true
+		reqMethod = http.MethodGet
+		reqPath = "/v3/service_instances"
+	})
+
+	JustBeforeEach(func() {
 		apiHandler := NewServiceInstance(
 			*serverURL,
 			serviceInstanceRepo,
 			spaceRepo,
+			servicePlanRepo,
 			requestValidator,
+			managedServicesEnabled,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 
-		reqMethod = http.MethodGet
-		reqPath = "/v3/service_instances"
-	})
-
-	JustBeforeEach(func() {
 		req, err := http.NewRequestWithContext(ctx, reqMethod, reqPath, strings.NewReader("the-json-body"))
 		Expect(err).NotTo(HaveOccurred())
 		routerBuilder.Build().ServeHTTP(rr, req)
@@ -147,6 +154,98 @@ var _ = Describe("ServiceInstance", func() {
 				expectUnknownError()
 			})
 		})
+
+		It("does not check the service plan", func() {
+			Expect(servicePlanRepo.GetPlanCallCount()).To(BeZero())
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstanceCreate{
+					Name: "service-instance-name",
+					Type: "managed",
+					Parameters: map[string]any{
+						"foo": "bar",
+					},
+					Relationships: &payloads.ServiceInstanceRelationships{
+						Space: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: "space-guid",
+							},
+						},
+						ServicePlan: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: "plan-guid",
+							},
+						},
+					},
+				})
+
+				serviceInstanceRepo.CreateServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID: "service-instance-guid",
+					Type: "managed",
+				}, nil)
+			})
+
+			It("creates a managed CFServiceInstance", func() {
+				Expect(servicePlanRepo.GetPlanCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualPlanGUID := servicePlanRepo.GetPlanArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualPlanGUID).To(Equal("plan-guid"))
+
+				Expect(serviceInstanceRepo.CreateServiceInstanceCallCount()).To(Equal(1))
+				_, _, actualCreate := serviceInstanceRepo.CreateServiceInstanceArgsForCall(0)
+				Expect(actualCreate).To(Equal(repositories.CreateServiceInstanceMessage{
+					Name:      "service-instance-name",
+					SpaceGUID: "space-guid",
+					Type:      "managed",
+					PlanGUID:  "plan-guid",
+					Parameters: map[string]any{
+						"foo": "bar",
+					},
+				}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/service_instance.create~service-instance-guid"))
+			})
+
+			When("the service plan does not exist", func() {
+				BeforeEach(func() {
+					servicePlanRepo.GetPlanReturns(
+						repositories.ServicePlanResource{},
+						apierrors.NewNotFoundError(errors.New("not found"), repositories.ServicePlanResourceType),
+					)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.")
+				})
+			})
+
+			When("getting the service plan fails", func() {
+				BeforeEach(func() {
+					servicePlanRepo.GetPlanReturns(repositories.ServicePlanResource{}, errors.New("get-plan-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+
+			When("experimental managed services are disabled", func() {
+				BeforeEach(func() {
+					managedServicesEnabled = false
+				})
+
+				It("returns an error", func() {
+					expectErrorResponse(http.StatusBadRequest, "CF-InvalidRequest", "Experimental managed services support is not enabled", 10004)
+				})
+
+				It("does not create the service instance", func() {
+					Expect(serviceInstanceRepo.CreateServiceInstanceCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("GET /v3/service_instances", func() {
@@ -175,7 +274,7 @@ var _ = Describe("ServiceInstance", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_instances?foo=bar"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_instances?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources[0].guid", "service-inst-guid-1"),
 				MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/service_instances/service-inst-guid-1"),
 				MatchJSONPath("$.resources[1].guid", "service-inst-guid-2"),
@@ -203,7 +302,7 @@ var _ = Describe("ServiceInstance", func() {
 			})
 
 			It("correctly sets query parameters in response pagination links", func() {
-				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_instances?foo=bar")))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_instances?foo=bar&page=1&per_page=50")))
 			})
 		})
 
@@ -323,6 +422,34 @@ var _ = Describe("ServiceInstance", func() {
 			)))
 		})
 
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      "managed",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error as credentials are set", func() {
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Credentials can only be updated for user-provided service instances")
+			})
+
+			When("credentials are not set", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+						Name: tools.PtrTo("new-name"),
+					})
+				})
+
+				It("patches the service instance", func() {
+					Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(1))
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				})
+			})
+		})
+
 		When("decoding the payload fails", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
@@ -386,6 +513,22 @@ var _ = Describe("ServiceInstance", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
 		})
 
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      "managed",
+				}, nil)
+			})
+
+			It("returns a job to track the deprovisioning", func() {
+				Expect(serviceInstanceRepo.DeleteServiceInstanceCallCount()).To(Equal(1))
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/service_instance.delete~service-instance-guid"))
+			})
+		})
+
 		When("getting the service instance fails with not found", func() {
 			BeforeEach(func() {
 				serviceInstanceRepo.GetServiceInstanceReturns(
@@ -21,6 +21,7 @@ const (
 //counterfeiter:generate -o fake -fake-name CFServicePlanRepository . CFServicePlanRepository
 type CFServicePlanRepository interface {
 	ListPlans(context.Context, authorization.Info) ([]repositories.ServicePlanResource, error)
+	GetPlan(context.Context, authorization.Info, string) (repositories.ServicePlanResource, error)
 }
 
 type ServicePlan struct {
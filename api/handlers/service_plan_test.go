@@ -59,7 +59,7 @@ var _ = Describe("ServicePlan", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_plans"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_plans?page=1&per_page=50"),
 				MatchJSONPath("$.resources[0].guid", "plan-guid"),
 				MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/service_plans/plan-guid"),
 				MatchJSONPath("$.resources[0].links.service_offering.href", "https://api.example.org/v3/service_offerings/service-offering-guid"),
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppSidecarsPath     = "/v3/apps/{guid}/sidecars"
+	ProcessSidecarsPath = "/v3/processes/{guid}/sidecars"
+	SidecarPath         = "/v3/sidecars/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSidecarRepository . CFSidecarRepository
+
+type CFSidecarRepository interface {
+	CreateSidecar(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)
+	GetSidecar(context.Context, authorization.Info, string) (repositories.SidecarRecord, error)
+	ListSidecars(context.Context, authorization.Info, repositories.ListSidecarsMessage) ([]repositories.SidecarRecord, error)
+	PatchSidecar(context.Context, authorization.Info, repositories.PatchSidecarMessage) (repositories.SidecarRecord, error)
+	DeleteSidecar(context.Context, authorization.Info, repositories.DeleteSidecarMessage) error
+}
+
+type Sidecar struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	sidecarRepo      CFSidecarRepository
+	appRepo          CFAppRepository
+	processRepo      CFProcessRepository
+}
+
+func NewSidecar(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	sidecarRepo CFSidecarRepository,
+	appRepo CFAppRepository,
+	processRepo CFProcessRepository,
+) *Sidecar {
+	return &Sidecar{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		sidecarRepo:      sidecarRepo,
+		appRepo:          appRepo,
+		processRepo:      processRepo,
+	}
+}
+
+func (h *Sidecar) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.create")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SidecarCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "appGUID", appGUID)
+	}
+
+	if err = h.validateSidecarMemory(r.Context(), authInfo, app.GUID, payload.ProcessTypes, payload.MemoryInMB); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "invalid sidecar memory", "appGUID", appGUID)
+	}
+
+	sidecar, err := h.sidecarRepo.CreateSidecar(r.Context(), authInfo, payload.ToMessage(app.GUID, app.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create sidecar", "appGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.get")
+
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	sidecar, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get sidecar", "guid", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) listForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.list-for-app")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.SidecarList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode request query parameters")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "appGUID", appGUID)
+	}
+
+	sidecars, err := h.sidecarRepo.ListSidecars(r.Context(), authInfo, repositories.ListSidecarsMessage{
+		AppGUID:   app.GUID,
+		SpaceGUID: app.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list sidecars", "appGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSidecar, sidecars, h.serverURL, *r.URL)), nil
+}
+
+func (h *Sidecar) listForProcess(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.list-for-process")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.SidecarList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode request query parameters")
+	}
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get process", "processGUID", processGUID)
+	}
+
+	sidecars, err := h.sidecarRepo.ListSidecars(r.Context(), authInfo, repositories.ListSidecarsMessage{
+		AppGUID:      process.AppGUID,
+		SpaceGUID:    process.SpaceGUID,
+		ProcessTypes: []string{process.Type},
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list sidecars", "processGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSidecar, sidecars, h.serverURL, *r.URL)), nil
+}
+
+func (h *Sidecar) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.update")
+
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SidecarUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sidecar, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get sidecar", "guid", sidecarGUID)
+	}
+
+	processTypes := sidecar.ProcessTypes
+	if payload.ProcessTypes != nil {
+		processTypes = payload.ProcessTypes
+	}
+	memoryMB := sidecar.MemoryMB
+	if payload.MemoryInMB != nil {
+		memoryMB = payload.MemoryInMB
+	}
+	if err = h.validateSidecarMemory(r.Context(), authInfo, sidecar.AppGUID, processTypes, memoryMB); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "invalid sidecar memory", "guid", sidecarGUID)
+	}
+
+	sidecar, err = h.sidecarRepo.PatchSidecar(r.Context(), authInfo, payload.ToMessage(sidecar))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to patch sidecar", "guid", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.delete")
+
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	sidecar, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get sidecar", "guid", sidecarGUID)
+	}
+
+	err = h.sidecarRepo.DeleteSidecar(r.Context(), authInfo, repositories.DeleteSidecarMessage{
+		GUID:      sidecar.GUID,
+		AppGUID:   sidecar.AppGUID,
+		SpaceGUID: sidecar.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete sidecar", "guid", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+// validateSidecarMemory checks that the sidecar memory fits in the memory of
+// every process it runs with, as sidecars share the memory of their process
+func (h *Sidecar) validateSidecarMemory(ctx context.Context, authInfo authorization.Info, appGUID string, processTypes []string, memoryMB *int64) error {
+	if memoryMB == nil {
+		return nil
+	}
+
+	processes, err := h.processRepo.ListProcesses(ctx, authInfo, repositories.ListProcessesMessage{AppGUIDs: []string{appGUID}})
+	if err != nil {
+		return err
+	}
+
+	for _, process := range processes {
+		if slices.Contains(processTypes, process.Type) && *memoryMB >= process.MemoryMB {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("The memory allocation defined is too large to run with the dependent %q process", process.Type))
+		}
+	}
+
+	return nil
+}
+
+func (h *Sidecar) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Sidecar) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: AppSidecarsPath, Handler: h.create},
+		{Method: "GET", Pattern: AppSidecarsPath, Handler: h.listForApp},
+		{Method: "GET", Pattern: ProcessSidecarsPath, Handler: h.listForProcess},
+		{Method: "GET", Pattern: SidecarPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SidecarPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SidecarPath, Handler: h.delete},
+	}
+}
@@ -0,0 +1,450 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sidecar", func() {
+	var (
+		apiHandler       *handlers.Sidecar
+		sidecarRepo      *fake.CFSidecarRepository
+		appRepo          *fake.CFAppRepository
+		processRepo      *fake.CFProcessRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		sidecarRepo = new(fake.CFSidecarRepository)
+		appRepo = new(fake.CFAppRepository)
+		processRepo = new(fake.CFProcessRepository)
+		apiHandler = handlers.NewSidecar(
+			*serverURL,
+			requestValidator,
+			sidecarRepo,
+			appRepo,
+			processRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "app-guid",
+			SpaceGUID: "space-guid",
+		}, nil)
+
+		sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{
+			GUID:      "sidecar-guid",
+			Name:      "log-shipper",
+			AppGUID:   "app-guid",
+			SpaceGUID: "space-guid",
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/apps/:guid/sidecars", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarCreate{
+				Name:         "log-shipper",
+				Command:      "ship-logs",
+				ProcessTypes: []string{"web"},
+				MemoryInMB:   tools.PtrTo[int64](64),
+			})
+
+			sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{
+				GUID:    "sidecar-guid",
+				Name:    "log-shipper",
+				AppGUID: "app-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/apps/app-guid/sidecars", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the sidecar", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "log-shipper",
+				Command:      "ship-logs",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo[int64](64),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.name", "log-shipper"),
+				MatchJSONPath("$.relationships.app.data.guid", "app-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("the sidecar memory does not fit in the memory of a dependent process", func() {
+			BeforeEach(func() {
+				processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+					{Type: "worker", MemoryMB: 32},
+					{Type: "web", MemoryMB: 64},
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(processRepo.ListProcessesCallCount()).To(Equal(1))
+				_, _, listMessage := processRepo.ListProcessesArgsForCall(0)
+				Expect(listMessage.AppGUIDs).To(ConsistOf("app-guid"))
+
+				expectUnprocessableEntityError(`The memory allocation defined is too large to run with the dependent "web" process`)
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing the app processes fails", func() {
+			BeforeEach(func() {
+				processRepo.ListProcessesReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/:guid/sidecars", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SidecarList{})
+
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "sidecar-1"},
+				{GUID: "sidecar-2"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/sidecars", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the app sidecars", func() {
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, _, listMessage := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(listMessage).To(Equal(repositories.ListSidecarsMessage{
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/app-guid/sidecars?page=1&per_page=50"),
+				MatchJSONPath("$.resources[*].guid", ConsistOf("sidecar-1", "sidecar-2")),
+			)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/processes/:guid/sidecars", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SidecarList{})
+
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:      "process-guid",
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+				Type:      "web",
+			}, nil)
+
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "sidecar-1"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/processes/process-guid/sidecars", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the sidecars of the process type", func() {
+			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualProcessGUID).To(Equal("process-guid"))
+
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, _, listMessage := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(listMessage).To(Equal(repositories.ListSidecarsMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				ProcessTypes: []string{"web"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes/process-guid/sidecars?page=1&per_page=50"),
+				MatchJSONPath("$.resources[0].guid", "sidecar-1"),
+			)))
+		})
+
+		When("the process is not accessible", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.ProcessResourceType)
+			})
+		})
+
+		When("there is some other error fetching the process", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/sidecars/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/sidecars/sidecar-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the sidecar", func() {
+			Expect(sidecarRepo.GetSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := sidecarRepo.GetSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sidecar-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.name", "log-shipper"),
+			)))
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/sidecars/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarUpdate{
+				Command: tools.PtrTo("ship-logs --verbose"),
+			})
+
+			sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{
+				GUID:    "sidecar-guid",
+				Command: "ship-logs --verbose",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/sidecars/sidecar-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("patches the sidecar", func() {
+			Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, patchMessage := sidecarRepo.PatchSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(patchMessage).To(Equal(repositories.PatchSidecarMessage{
+				GUID:      "sidecar-guid",
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+				Command:   tools.PtrTo("ship-logs --verbose"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.command", "ship-logs --verbose")))
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		It("does not check the memory of the processes", func() {
+			Expect(processRepo.ListProcessesCallCount()).To(BeZero())
+		})
+
+		When("the sidecar memory is updated", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarUpdate{
+					MemoryInMB: tools.PtrTo[int64](256),
+				})
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{
+					GUID:         "sidecar-guid",
+					AppGUID:      "app-guid",
+					SpaceGUID:    "space-guid",
+					ProcessTypes: []string{"worker"},
+				}, nil)
+				processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+					{Type: "web", MemoryMB: 128},
+					{Type: "worker", MemoryMB: 512},
+				}, nil)
+			})
+
+			It("checks it against the processes the sidecar runs with", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+			})
+
+			When("the sidecar does not fit in the memory of its processes", func() {
+				BeforeEach(func() {
+					processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+						{Type: "worker", MemoryMB: 256},
+					}, nil)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError(`The memory allocation defined is too large to run with the dependent "worker" process`)
+					Expect(sidecarRepo.PatchSidecarCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("patching the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{}, errors.New("patch-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/sidecars/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/sidecars/sidecar-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the sidecar", func() {
+			Expect(sidecarRepo.DeleteSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, deleteMessage := sidecarRepo.DeleteSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(deleteMessage).To(Equal(repositories.DeleteSidecarMessage{
+				GUID:      "sidecar-guid",
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+			})
+		})
+
+		When("deleting the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.DeleteSidecarReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
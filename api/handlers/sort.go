@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+// orderBy maps the fields a list can be ordered by to a function telling
+// whether a record comes before another one in ascending order
+type orderBy[T any] map[string]func(a, b T) bool
+
+// sortByOrder sorts records as requested by the order_by query parameter,
+// where a leading dash reverses the order. Records keep their order when the
+// requested field is not supported.
+func sortByOrder[T any](records []T, order string, fields orderBy[T]) {
+	field, descending := strings.CutPrefix(order, "-")
+	less, ok := fields[field]
+	if !ok {
+		return
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if descending {
+			return less(records[j], records[i])
+		}
+		return less(records[i], records[j])
+	})
+}
+
+// sortListResult sorts a list the repository returned in full, as requested
+// by the order_by query parameter, and selects the requested page of it.
+// Lists that are not ordered are already paginated by the repository.
+func sortListResult[T any](result repositories.ListResult[T], order string, pagination payloads.Pagination, fields orderBy[T]) repositories.ListResult[T] {
+	if order == "" {
+		return result
+	}
+
+	sortByOrder(result.Records, order, fields)
+
+	page, perPage := pagination.PageOrDefault(), pagination.PerPageOrDefault()
+	start := min((page-1)*perPage, len(result.Records))
+
+	return repositories.ListResult[T]{
+		PageInfo: repositories.PageInfo{
+			TotalResults: len(result.Records),
+			Page:         page,
+			PerPage:      perPage,
+		},
+		Records: result.Records[start:min(start+perPage, len(result.Records))],
+	}
+}
+
+func createdBefore(a, b time.Time) bool {
+	return timePtrAfter(&b, &a)
+}
+
+func updatedBefore(a, b *time.Time) bool {
+	return timePtrAfter(b, a)
+}
+
+// nameBefore orders records by their optional name, records without a name
+// coming first
+func nameBefore(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	return *a < *b
+}
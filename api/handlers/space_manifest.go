@@ -5,11 +5,14 @@ import (
 	"net/http"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -20,28 +23,38 @@ const (
 )
 
 type SpaceManifest struct {
-	serverURL        url.URL
-	manifestApplier  ManifestApplier
-	spaceRepo        CFSpaceRepository
-	requestValidator RequestValidator
+	serverURL          url.URL
+	manifestApplier    ManifestApplier
+	spaceRepo          CFSpaceRepository
+	appRepo            CFAppRepository
+	requestValidator   RequestValidator
+	auditEventRecorder AuditEventRecorder
+	featureFlagChecker FeatureFlagChecker
 }
 
 //counterfeiter:generate -o fake -fake-name ManifestApplier . ManifestApplier
 type ManifestApplier interface {
 	Apply(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) error
+	Diff(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) ([]manifest.DiffEntry, error)
 }
 
 func NewSpaceManifest(
 	serverURL url.URL,
 	manifestApplier ManifestApplier,
 	spaceRepo CFSpaceRepository,
+	appRepo CFAppRepository,
 	requestValidator RequestValidator,
+	auditEventRecorder AuditEventRecorder,
+	featureFlagChecker FeatureFlagChecker,
 ) *SpaceManifest {
 	return &SpaceManifest{
-		serverURL:        serverURL,
-		manifestApplier:  manifestApplier,
-		spaceRepo:        spaceRepo,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		manifestApplier:    manifestApplier,
+		spaceRepo:          spaceRepo,
+		appRepo:            appRepo,
+		requestValidator:   requestValidator,
+		auditEventRecorder: auditEventRecorder,
+		featureFlagChecker: featureFlagChecker,
 	}
 }
 
@@ -66,14 +79,77 @@ func (h *SpaceManifest) apply(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if hasDockerApplications(manifest) {
+		if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, korifiv1alpha1.FeatureFlagDiegoDocker); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "docker apps are disabled")
+		}
+	}
+
+	if scalesApplications(manifest) {
+		if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, korifiv1alpha1.FeatureFlagAppScaling); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "app scaling is disabled")
+		}
+	}
+
 	if err := h.manifestApplier.Apply(r.Context(), authInfo, spaceGUID, manifest); err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error applying manifest")
 	}
 
+	h.recordApplyManifestEvents(r.Context(), logger, authInfo, spaceGUID, manifest)
+
 	return routing.NewResponse(http.StatusAccepted).
 		WithHeader("Location", presenter.JobURLForRedirects(spaceGUID, presenter.SpaceApplyManifestOperation, h.serverURL)), nil
 }
 
+func hasDockerApplications(manifest payloads.Manifest) bool {
+	for _, app := range manifest.Applications {
+		if app.Docker != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func scalesApplications(manifest payloads.Manifest) bool {
+	for _, app := range manifest.Applications {
+		if app.Instances != nil || app.Memory != nil || app.DiskQuota != nil || app.AltDiskQuota != nil {
+			return true
+		}
+
+		for _, process := range app.Processes {
+			if process.Instances != nil || process.Memory != nil || process.DiskQuota != nil || process.AltDiskQuota != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// recordApplyManifestEvents records an event for each of the applied apps.
+// The manifest has already been applied, so failing to find the apps is only
+// logged.
+func (h *SpaceManifest) recordApplyManifestEvents(ctx context.Context, logger logr.Logger, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) {
+	appNames := []string{}
+	for _, app := range manifest.Applications {
+		appNames = append(appNames, app.Name)
+	}
+
+	apps, err := h.appRepo.ListApps(ctx, authInfo, repositories.ListAppsMessage{
+		Names:      appNames,
+		SpaceGuids: []string{spaceGUID},
+	})
+	if err != nil {
+		logger.Info("failed to list the applied apps, not recording audit events", "reason", err)
+		return
+	}
+
+	for _, app := range apps {
+		h.auditEventRecorder.RecordEvent(ctx, authInfo, appAuditEvent(repositories.AuditEventTypeAppApplyManifest, app, nil))
+	}
+}
+
 func (h *SpaceManifest) diff(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-manifest.diff")
@@ -84,5 +160,15 @@ func (h *SpaceManifest) diff(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "guid", spaceGUID)
 	}
 
-	return routing.NewResponse(http.StatusAccepted).WithBody(map[string]interface{}{"diff": []string{}}), nil
+	var appManifest payloads.Manifest
+	if err := h.requestValidator.DecodeAndValidateYAMLPayload(r, &appManifest); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	diff, err := h.manifestApplier.Diff(r.Context(), authInfo, spaceGUID, appManifest)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error diffing manifest")
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithBody(presenter.ForManifestDiff(diff)), nil
 }
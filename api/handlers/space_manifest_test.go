@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -19,11 +20,14 @@ import (
 
 var _ = Describe("SpaceManifest", func() {
 	var (
-		manifestApplier  *fake.ManifestApplier
-		spaceRepo        *fake.CFSpaceRepository
-		requestValidator *fake.RequestValidator
-		requestMethod    string
-		requestPath      string
+		manifestApplier    *fake.ManifestApplier
+		spaceRepo          *fake.CFSpaceRepository
+		appRepo            *fake.CFAppRepository
+		requestValidator   *fake.RequestValidator
+		auditEventRecorder *fake.AuditEventRecorder
+		featureFlagChecker *fake.FeatureFlagChecker
+		requestMethod      string
+		requestPath        string
 	)
 
 	BeforeEach(func() {
@@ -32,13 +36,19 @@ var _ = Describe("SpaceManifest", func() {
 
 		manifestApplier = new(fake.ManifestApplier)
 		spaceRepo = new(fake.CFSpaceRepository)
+		appRepo = new(fake.CFAppRepository)
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 
 		apiHandler := NewSpaceManifest(
 			*serverURL,
 			manifestApplier,
 			spaceRepo,
+			appRepo,
 			requestValidator,
+			auditEventRecorder,
+			featureFlagChecker,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -106,6 +116,117 @@ var _ = Describe("SpaceManifest", func() {
 			Expect(payload.Applications[0].Processes[0].Timeout).To(PointTo(Equal(int64(10))))
 		})
 
+		When("the applied apps are listed", func() {
+			BeforeEach(func() {
+				appRepo.ListAppsReturns([]repositories.AppRecord{{
+					GUID:      "app1-guid",
+					Name:      "app1",
+					SpaceGUID: spaceGUID,
+				}}, nil)
+			})
+
+			It("records an apply manifest audit event for each app", func() {
+				Expect(appRepo.ListAppsCallCount()).To(Equal(1))
+				_, actualAuthInfo, message := appRepo.ListAppsArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(message).To(Equal(repositories.ListAppsMessage{
+					Names:      []string{"app1"},
+					SpaceGuids: []string{spaceGUID},
+				}))
+
+				Expect(auditEventRecorder.RecordEventCallCount()).To(Equal(1))
+				_, actualAuthInfo, auditEventMessage := auditEventRecorder.RecordEventArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(auditEventMessage).To(Equal(repositories.CreateAuditEventMessage{
+					Type: "audit.app.apply_manifest",
+					Target: repositories.AuditEventTarget{
+						GUID: "app1-guid",
+						Type: "app",
+						Name: "app1",
+					},
+					SpaceGUID: spaceGUID,
+				}))
+			})
+		})
+
+		When("listing the applied apps fails", func() {
+			BeforeEach(func() {
+				appRepo.ListAppsReturns(nil, errors.New("boom"))
+			})
+
+			It("still succeeds without recording audit events", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(auditEventRecorder.RecordEventCallCount()).To(BeZero())
+			})
+		})
+
+		It("checks the app_scaling feature flag", func() {
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualFlagName := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFlagName).To(Equal("app_scaling"))
+		})
+
+		When("app scaling is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("app_scaling", ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(manifestApplier.ApplyCallCount()).To(BeZero())
+				expectFeatureDisabledError("app_scaling")
+			})
+		})
+
+		When("the manifest does not scale any app", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateYAMLPayloadStub = decodeAndValidatePayloadStub(&payloads.Manifest{
+					Version: 1,
+					Applications: []payloads.ManifestApplication{{
+						Name: "app1",
+						Processes: []payloads.ManifestApplicationProcess{{
+							Type:    "web",
+							Command: tools.PtrTo("start-web.sh"),
+						}},
+					}},
+				})
+			})
+
+			It("does not check feature flags", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(BeZero())
+			})
+		})
+
+		When("the manifest contains docker apps", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateYAMLPayloadStub = decodeAndValidatePayloadStub(&payloads.Manifest{
+					Version: 1,
+					Applications: []payloads.ManifestApplication{{
+						Name:   "app1",
+						Docker: map[string]any{"image": "some/image"},
+					}},
+				})
+			})
+
+			It("checks the diego_docker feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlagName := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlagName).To(Equal("diego_docker"))
+			})
+
+			When("docker apps are disabled", func() {
+				BeforeEach(func() {
+					featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("diego_docker", ""))
+				})
+
+				It("returns a feature disabled error", func() {
+					Expect(manifestApplier.ApplyCallCount()).To(BeZero())
+					expectFeatureDisabledError("diego_docker")
+				})
+			})
+		})
+
 		When("the manifest is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateYAMLPayloadReturns(errors.New("boom"))
@@ -120,16 +241,81 @@ var _ = Describe("SpaceManifest", func() {
 	Describe("POST /v3/spaces/{spaceGUID}/manifest_diff", func() {
 		BeforeEach(func() {
 			requestPath = "/v3/spaces/test-space-guid/manifest_diff"
+			requestValidator.DecodeAndValidateYAMLPayloadStub = decodeAndValidatePayloadStub(&payloads.Manifest{
+				Version: 1,
+				Applications: []payloads.ManifestApplication{{
+					Name:   "app1",
+					Memory: tools.PtrTo("128M"),
+				}},
+			})
+			manifestApplier.DiffReturns([]manifest.DiffEntry{{
+				Op:    "replace",
+				Path:  "/applications/0/processes/0/memory",
+				Was:   "256M",
+				Value: "128M",
+			}}, nil)
 		})
 
-		It("returns 202 with an empty diff", func() {
+		It("returns 202 with the diff", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
-				"diff": []
+				"diff": [{
+					"op": "replace",
+					"path": "/applications/0/processes/0/memory",
+					"was": "256M",
+					"value": "128M"
+				}]
 			}`)))
 		})
 
+		It("diffs the decoded manifest", func() {
+			Expect(requestValidator.DecodeAndValidateYAMLPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateYAMLPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-yaml-body"))
+
+			Expect(manifestApplier.DiffCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID, payload := manifestApplier.DiffArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("test-space-guid"))
+			Expect(payload.Applications).To(HaveLen(1))
+			Expect(payload.Applications[0].Name).To(Equal("app1"))
+			Expect(payload.Applications[0].Memory).To(PointTo(Equal("128M")))
+		})
+
+		When("there is no diff", func() {
+			BeforeEach(func() {
+				manifestApplier.DiffReturns(nil, nil)
+			})
+
+			It("returns 202 with an empty diff", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+					"diff": []
+				}`)))
+			})
+		})
+
+		When("the manifest is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateYAMLPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("diffing the manifest fails", func() {
+			BeforeEach(func() {
+				manifestApplier.DiffReturns(nil, errors.New("diff-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
 		When("getting the space errors", func() {
 			BeforeEach(func() {
 				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("foo"))
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	SpaceQuotasPath         = "/v3/space_quotas"
+	SpaceQuotaPath          = "/v3/space_quotas/{guid}"
+	SpaceQuotaSpacesRelPath = "/v3/space_quotas/{guid}/relationships/spaces"
+	SpaceQuotaSpaceRelPath  = "/v3/space_quotas/{guid}/relationships/spaces/{space_guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSpaceQuotaRepository . CFSpaceQuotaRepository
+
+type CFSpaceQuotaRepository interface {
+	CreateSpaceQuota(context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	GetSpaceQuota(context.Context, authorization.Info, string) (repositories.SpaceQuotaRecord, error)
+	ListSpaceQuotas(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)
+	PatchSpaceQuota(context.Context, authorization.Info, repositories.PatchSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	ApplySpaceQuota(context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) ([]string, error)
+	RemoveSpaceQuota(context.Context, authorization.Info, repositories.RemoveSpaceQuotaMessage) error
+	DeleteSpaceQuota(context.Context, authorization.Info, string) error
+}
+
+type SpaceQuota struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	spaceQuotaRepo   CFSpaceQuotaRepository
+}
+
+func NewSpaceQuota(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	spaceQuotaRepo CFSpaceQuotaRepository,
+) *SpaceQuota {
+	return &SpaceQuota{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		spaceQuotaRepo:   spaceQuotaRepo,
+	}
+}
+
+func (h *SpaceQuota) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.create")
+
+	var payload payloads.SpaceQuotaCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.CreateSpaceQuota(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, "Invalid organization. Ensure the organization exists and you have access to it.", apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+			"failed to create space quota",
+		)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.get")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	spaceQuota, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.list")
+
+	payload := new(payloads.SpaceQuotaList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode request query parameters")
+	}
+
+	spaceQuotas, err := h.spaceQuotaRepo.ListSpaceQuotas(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list space quotas")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSpaceQuota, spaceQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *SpaceQuota) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.update")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SpaceQuotaPatch
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", spaceQuotaGUID)
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.PatchSpaceQuota(r.Context(), authInfo, payload.ToMessage(spaceQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to patch space quota", "guid", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) applyToSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.apply-to-spaces")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SpaceQuotaApply
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", spaceQuotaGUID)
+	}
+
+	spaceGUIDs, err := h.spaceQuotaRepo.ApplySpaceQuota(r.Context(), authInfo, payload.ToMessage(spaceQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to apply space quota", "guid", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuotaSpaces(spaceQuotaGUID, spaceGUIDs, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) removeFromSpace(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.remove-from-space")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	_, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", spaceQuotaGUID)
+	}
+
+	err = h.spaceQuotaRepo.RemoveSpaceQuota(r.Context(), authInfo, repositories.RemoveSpaceQuotaMessage{
+		GUID:      spaceQuotaGUID,
+		SpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to remove space quota", "guid", spaceQuotaGUID, "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *SpaceQuota) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.delete")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	_, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", spaceQuotaGUID)
+	}
+
+	err = h.spaceQuotaRepo.DeleteSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete space quota", "guid", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(spaceQuotaGUID, presenter.SpaceQuotaDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *SpaceQuota) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SpaceQuota) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: SpaceQuotasPath, Handler: h.create},
+		{Method: "GET", Pattern: SpaceQuotasPath, Handler: h.list},
+		{Method: "GET", Pattern: SpaceQuotaPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SpaceQuotaPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SpaceQuotaPath, Handler: h.delete},
+		{Method: "POST", Pattern: SpaceQuotaSpacesRelPath, Handler: h.applyToSpaces},
+		{Method: "DELETE", Pattern: SpaceQuotaSpaceRelPath, Handler: h.removeFromSpace},
+	}
+}
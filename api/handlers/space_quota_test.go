@@ -0,0 +1,358 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpaceQuota", func() {
+	var (
+		apiHandler       *handlers.SpaceQuota
+		spaceQuotaRepo   *fake.CFSpaceQuotaRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		spaceQuotaRepo = new(fake.CFSpaceQuotaRepository)
+		apiHandler = handlers.NewSpaceQuota(
+			*serverURL,
+			requestValidator,
+			spaceQuotaRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+			GUID:             "quota-guid",
+			Name:             "my-quota",
+			OrganizationGUID: "org-guid",
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/space_quotas", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaCreate{
+				Name: "my-quota",
+				QuotaLimits: payloads.QuotaLimits{
+					Apps: payloads.AppsQuota{
+						PerAppTasks: payloads.QuotaLimit{IsSet: true, Value: tools.PtrTo[int64](2)},
+					},
+				},
+				Relationships: &payloads.SpaceQuotaRelationships{
+					Organization: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "org-guid"},
+					},
+				},
+			})
+
+			spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID: "quota-guid",
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					AppTasks: tools.PtrTo[int64](2),
+				},
+				OrganizationGUID: "org-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/space_quotas", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the space quota", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := spaceQuotaRepo.CreateSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateSpaceQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					AppTasks: tools.PtrTo[int64](2),
+				},
+				OrganizationGUID: "org-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.apps.per_app_tasks", BeEquivalentTo(2)),
+				MatchJSONPath("$.relationships.organization.data.guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/space_quotas/quota-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the organization does not exist", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Invalid organization. Ensure the organization exists and you have access to it.")
+			})
+		})
+
+		When("creating the space quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/space_quotas/quota-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the space quota", func() {
+			Expect(spaceQuotaRepo.GetSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := spaceQuotaRepo.GetSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.links.organization.href", "https://api.example.org/v3/organizations/org-guid"),
+			)))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SpaceQuotaResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas", func() {
+		BeforeEach(func() {
+			spaceQuotaRepo.ListSpaceQuotasReturns([]repositories.SpaceQuotaRecord{
+				{GUID: "quota-1"},
+				{GUID: "quota-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SpaceQuotaList{
+				SpaceGUIDs: "s1",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/space_quotas", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the space quotas", func() {
+			Expect(spaceQuotaRepo.ListSpaceQuotasCallCount()).To(Equal(1))
+			_, _, listMessage := spaceQuotaRepo.ListSpaceQuotasArgsForCall(0)
+			Expect(listMessage).To(Equal(repositories.ListSpaceQuotasMessage{
+				GUIDs:             []string{},
+				Names:             []string{},
+				OrganizationGUIDs: []string{},
+				SpaceGUIDs:        []string{"s1"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/space_quotas?page=1&per_page=50"),
+				MatchJSONPath("$.resources[*].guid", ConsistOf("quota-1", "quota-2")),
+			)))
+		})
+
+		When("listing the space quotas fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.ListSpaceQuotasReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/space_quotas/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaPatch{
+				QuotaLimits: payloads.QuotaLimits{
+					Apps: payloads.AppsQuota{
+						TotalInstances: payloads.QuotaLimit{IsSet: true, Value: tools.PtrTo[int64](4)},
+					},
+				},
+			})
+
+			spaceQuotaRepo.PatchSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID: "quota-guid",
+				Limits: repositories.QuotaLimits{
+					TotalAppInstances: tools.PtrTo[int64](4),
+				},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/space_quotas/quota-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("patches the space quota", func() {
+			Expect(spaceQuotaRepo.PatchSpaceQuotaCallCount()).To(Equal(1))
+			_, _, patchMessage := spaceQuotaRepo.PatchSpaceQuotaArgsForCall(0)
+			Expect(patchMessage).To(Equal(repositories.PatchSpaceQuotaMessage{
+				GUID: "quota-guid",
+				Limits: repositories.QuotaLimitsPatch{
+					TotalAppInstances: &repositories.QuotaLimitPatch{Value: tools.PtrTo[int64](4)},
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.apps.total_instances", BeEquivalentTo(4))))
+		})
+
+		When("the space quota does not exist", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SpaceQuotaResourceType)
+				Expect(spaceQuotaRepo.PatchSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /v3/space_quotas/:guid/relationships/spaces", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaApply{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+				},
+			})
+
+			spaceQuotaRepo.ApplySpaceQuotaReturns([]string{"space-guid"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/space_quotas/quota-guid/relationships/spaces", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("applies the space quota to the spaces", func() {
+			Expect(spaceQuotaRepo.ApplySpaceQuotaCallCount()).To(Equal(1))
+			_, _, applyMessage := spaceQuotaRepo.ApplySpaceQuotaArgsForCall(0)
+			Expect(applyMessage).To(Equal(repositories.ApplySpaceQuotaMessage{
+				GUID:       "quota-guid",
+				SpaceGUIDs: []string{"space-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/space_quotas/quota-guid/relationships/spaces"),
+			)))
+		})
+
+		When("applying the space quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.ApplySpaceQuotaReturns(nil, errors.New("apply-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/space_quotas/:guid/relationships/spaces/:space_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/space_quotas/quota-guid/relationships/spaces/space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes the space quota from the space", func() {
+			Expect(spaceQuotaRepo.RemoveSpaceQuotaCallCount()).To(Equal(1))
+			_, _, removeMessage := spaceQuotaRepo.RemoveSpaceQuotaArgsForCall(0)
+			Expect(removeMessage).To(Equal(repositories.RemoveSpaceQuotaMessage{
+				GUID:      "quota-guid",
+				SpaceGUID: "space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.RemoveSpaceQuotaReturns(apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+	})
+
+	Describe("DELETE /v3/space_quotas/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/space_quotas/quota-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the space quota", func() {
+			Expect(spaceQuotaRepo.DeleteSpaceQuotaCallCount()).To(Equal(1))
+			_, _, actualGUID := spaceQuotaRepo.DeleteSpaceQuotaArgsForCall(0)
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/space_quota.delete~quota-guid"))
+		})
+
+		When("deleting the space quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.DeleteSpaceQuotaReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
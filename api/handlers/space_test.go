@@ -3,7 +3,9 @@ package handlers_test
 import (
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers"
@@ -157,7 +159,7 @@ var _ = Describe("Space", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/spaces?foo=bar"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/spaces?foo=bar&page=1&per_page=50"),
 				MatchJSONPath("$.resources", HaveLen(2)),
 				MatchJSONPath("$.resources[0].guid", "test-space-1-guid"),
 				MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/spaces/test-space-1-guid"),
@@ -165,6 +167,67 @@ var _ = Describe("Space", func() {
 			)))
 		})
 
+		When("a page is requested", func() {
+			BeforeEach(func() {
+				requestPath += "&per_page=1&page=2"
+			})
+
+			It("returns the requested page only", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+					MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+					MatchJSONPath("$.pagination.previous.href", "https://api.example.org/v3/spaces?foo=bar&page=1&per_page=1"),
+					MatchJSONPath("$.pagination.next", BeNil()),
+					MatchJSONPath("$.resources", HaveLen(1)),
+					MatchJSONPath("$.resources[0].guid", "test-space-2-guid"),
+				)))
+			})
+		})
+
+		Describe("Order results", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{
+					{
+						GUID:      "1",
+						Name:      "first-test-space",
+						CreatedAt: time.UnixMilli(3000),
+						UpdatedAt: tools.PtrTo(time.UnixMilli(4000)),
+					},
+					{
+						GUID:      "2",
+						Name:      "second-test-space",
+						CreatedAt: time.UnixMilli(2000),
+						UpdatedAt: tools.PtrTo(time.UnixMilli(6000)),
+					},
+					{
+						GUID:      "3",
+						Name:      "third-test-space",
+						CreatedAt: time.UnixMilli(1000),
+						UpdatedAt: tools.PtrTo(time.UnixMilli(5000)),
+					},
+				}, nil)
+			})
+
+			DescribeTable("ordering results", func(orderBy string, expectedOrder ...any) {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SpaceList{
+					OrderBy: orderBy,
+				})
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/v3/spaces?order_by=whatever", nil)
+				Expect(err).NotTo(HaveOccurred())
+				rr = httptest.NewRecorder()
+				routerBuilder.Build().ServeHTTP(rr, req)
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.resources[*].guid", expectedOrder)))
+			},
+				Entry("created_at ASC", "created_at", "3", "2", "1"),
+				Entry("created_at DESC", "-created_at", "1", "2", "3"),
+				Entry("updated_at ASC", "updated_at", "1", "3", "2"),
+				Entry("updated_at DESC", "-updated_at", "2", "3", "1"),
+				Entry("name ASC", "name", "1", "2", "3"),
+				Entry("name DESC", "-name", "3", "2", "1"),
+			)
+		})
+
 		When("fetching the spaces fails", func() {
 			BeforeEach(func() {
 				spaceRepo.ListSpacesReturns(nil, errors.New("boom!"))
@@ -358,4 +421,133 @@ var _ = Describe("Space", func() {
 			})
 		})
 	})
+	Describe("get the ssh feature of a space", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath += "/the-space-guid/features/ssh"
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				Name:     "the-space",
+				GUID:     "the-space-guid",
+				AllowSSH: true,
+			}, nil)
+		})
+
+		It("returns whether ssh is allowed in the space", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, info, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(info).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.description", "Enable SSHing into apps in the space."),
+				MatchJSONPath("$.enabled", BeTrue()),
+			)))
+		})
+
+		When("the feature is not ssh", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/spaces/the-space-guid/features/revisions"
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature")
+			})
+		})
+
+		When("getting the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+	})
+
+	Describe("update the ssh feature of a space", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath += "/the-space-guid/features/ssh"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeaturePatch{
+				Enabled: tools.PtrTo(false),
+			})
+
+			spaceRepo.PatchSpaceFeaturesReturns(repositories.SpaceRecord{
+				Name:     "the-space",
+				GUID:     "the-space-guid",
+				AllowSSH: false,
+			}, nil)
+		})
+
+		It("updates the space", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(Equal(1))
+			_, info, actualMessage := spaceRepo.PatchSpaceFeaturesArgsForCall(0)
+			Expect(info).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchSpaceFeaturesMessage{
+				GUID:     "the-space-guid",
+				OrgGUID:  "the-org-guid",
+				AllowSSH: tools.PtrTo(false),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the feature is not ssh", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/spaces/the-space-guid/features/revisions"
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature")
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(BeZero())
+			})
+		})
+
+		When("getting the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(BeZero())
+			})
+		})
+
+		When("patching the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.PatchSpaceFeaturesReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
 })
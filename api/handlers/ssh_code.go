@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	OAuthAuthorizePath = "/oauth/authorize"
+)
+
+//counterfeiter:generate -o fake -fake-name SSHCodeIssuer . SSHCodeIssuer
+
+type SSHCodeIssuer interface {
+	Issue(context.Context, authorization.Info) (string, error)
+}
+
+// SSHCode issues the one-time codes the cf cli exchanges for an SSH session
+// with the SSH proxy. It mimics the UAA authorization endpoint, which
+// redirects to the login page with the code as a query parameter.
+type SSHCode struct {
+	apiBaseURL       url.URL
+	codeIssuer       SSHCodeIssuer
+	requestValidator RequestValidator
+}
+
+func NewSSHCode(apiBaseURL url.URL, codeIssuer SSHCodeIssuer, requestValidator RequestValidator) *SSHCode {
+	return &SSHCode{
+		apiBaseURL:       apiBaseURL,
+		codeIssuer:       codeIssuer,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *SSHCode) authorize(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.ssh-code.authorize")
+
+	payload := new(payloads.SSHCodeRequest)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if payload.ClientID != sshproxy.OAuthClient {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(errors.New("unsupported client"), "client_id must be "+sshproxy.OAuthClient),
+			"unsupported client", "clientID", payload.ClientID,
+		)
+	}
+
+	code, err := h.codeIssuer.Issue(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to issue ssh code")
+	}
+
+	location := h.apiBaseURL
+	location.Path = "/login"
+	location.RawQuery = url.Values{"code": []string{code}}.Encode()
+
+	return routing.NewResponse(http.StatusFound).WithHeader("Location", location.String()), nil
+}
+
+func (h *SSHCode) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SSHCode) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: OAuthAuthorizePath, Handler: h.authorize},
+	}
+}
@@ -0,0 +1,91 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SSHCode", func() {
+	var (
+		codeIssuer       *fake.SSHCodeIssuer
+		requestValidator *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		codeIssuer = new(fake.SSHCodeIssuer)
+		codeIssuer.IssueReturns("the-code", nil)
+
+		requestValidator = new(fake.RequestValidator)
+		requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SSHCodeRequest{
+			ResponseType: "code",
+			ClientID:     "ssh-proxy",
+		})
+
+		apiHandler := handlers.NewSSHCode(*serverURL, codeIssuer, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/oauth/authorize?response_type=code&client_id=ssh-proxy", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	It("redirects to the login page with a one-time code", func() {
+		Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+		actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+		Expect(actualReq.URL.String()).To(HaveSuffix("response_type=code&client_id=ssh-proxy"))
+
+		Expect(codeIssuer.IssueCallCount()).To(Equal(1))
+		_, actualAuthInfo := codeIssuer.IssueArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "a-token"}))
+
+		Expect(rr).To(HaveHTTPStatus(http.StatusFound))
+		Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/login?code=the-code"))
+	})
+
+	When("the request is invalid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError("oops")
+			Expect(codeIssuer.IssueCallCount()).To(BeZero())
+		})
+	})
+
+	When("the code is requested for another client", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SSHCodeRequest{
+				ResponseType: "code",
+				ClientID:     "cf",
+			})
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError("client_id must be ssh-proxy")
+			Expect(codeIssuer.IssueCallCount()).To(BeZero())
+		})
+	})
+
+	When("issuing the code fails", func() {
+		BeforeEach(func() {
+			codeIssuer.IssueReturns("", errors.New("boom"))
+		})
+
+		It("returns an error", func() {
+			expectUnknownError()
+		})
+	})
+})
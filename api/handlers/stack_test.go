@@ -0,0 +1,127 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stack", func() {
+	var (
+		stackRepo        *fake.CFStackRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		stackRepo = new(fake.CFStackRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := handlers.NewStack(*serverURL, stackRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/stacks", func() {
+		BeforeEach(func() {
+			stackRepo.ListStacksReturns([]repositories.StackRecord{
+				{GUID: "stack-1", Name: "stack-1", Default: true},
+				{GUID: "stack-2", Name: "stack-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.StackList{
+				Names: "stack-1,stack-2",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/stacks", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the stacks", func() {
+			Expect(stackRepo.ListStacksCallCount()).To(Equal(1))
+			_, actualAuthInfo, listMessage := stackRepo.ListStacksArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(listMessage).To(Equal(repositories.ListStacksMessage{
+				Names: []string{"stack-1", "stack-2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/stacks?page=1&per_page=50"),
+				MatchJSONPath("$.resources[*].guid", ConsistOf("stack-1", "stack-2")),
+				MatchJSONPath("$.resources[0].default", BeTrue()),
+			)))
+		})
+
+		When("decoding the query parameters fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("listing the stacks fails", func() {
+			BeforeEach(func() {
+				stackRepo.ListStacksReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/stacks/:guid", func() {
+		BeforeEach(func() {
+			stackRepo.GetStackReturns(repositories.StackRecord{
+				GUID: "stack-1",
+				Name: "stack-1",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/stacks/stack-1", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the stack", func() {
+			Expect(stackRepo.GetStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := stackRepo.GetStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("stack-1"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "stack-1"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/stacks/stack-1"),
+			)))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.StackResourceType)
+			})
+		})
+	})
+})
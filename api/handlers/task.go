@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/routing"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"github.com/go-logr/logr"
 )
 
@@ -27,16 +28,17 @@ const (
 type CFTaskRepository interface {
 	CreateTask(context.Context, authorization.Info, repositories.CreateTaskMessage) (repositories.TaskRecord, error)
 	GetTask(context.Context, authorization.Info, string) (repositories.TaskRecord, error)
-	ListTasks(context.Context, authorization.Info, repositories.ListTaskMessage) ([]repositories.TaskRecord, error)
+	ListTasks(context.Context, authorization.Info, repositories.ListTaskMessage) (repositories.ListResult[repositories.TaskRecord], error)
 	CancelTask(context.Context, authorization.Info, string) (repositories.TaskRecord, error)
 	PatchTaskMetadata(ctx context.Context, info authorization.Info, message repositories.PatchTaskMetadataMessage) (repositories.TaskRecord, error)
 }
 
 type Task struct {
-	serverURL        url.URL
-	appRepo          CFAppRepository
-	taskRepo         CFTaskRepository
-	requestValidator RequestValidator
+	serverURL          url.URL
+	appRepo            CFAppRepository
+	taskRepo           CFTaskRepository
+	requestValidator   RequestValidator
+	featureFlagChecker FeatureFlagChecker
 }
 
 func NewTask(
@@ -44,12 +46,14 @@ func NewTask(
 	appRepo CFAppRepository,
 	taskRepo CFTaskRepository,
 	requestValidator RequestValidator,
+	featureFlagChecker FeatureFlagChecker,
 ) *Task {
 	return &Task{
-		serverURL:        serverURL,
-		taskRepo:         taskRepo,
-		appRepo:          appRepo,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		taskRepo:           taskRepo,
+		appRepo:            appRepo,
+		requestValidator:   requestValidator,
+		featureFlagChecker: featureFlagChecker,
 	}
 }
 
@@ -71,12 +75,18 @@ func (h *Task) list(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.task.list")
 
-	tasks, err := h.taskRepo.ListTasks(r.Context(), authInfo, repositories.ListTaskMessage{})
+	taskListFilter := new(payloads.TaskList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, taskListFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	tasks, err := h.taskRepo.ListTasks(r.Context(), authInfo, taskListFilter.ToMessage())
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to list tasks")
 	}
+	tasks = h.sortList(tasks, taskListFilter)
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForTask, tasks, h.serverURL, *r.URL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForListResult(presenter.ForTask, tasks, h.serverURL, *r.URL)), nil
 }
 
 func (h *Task) create(r *http.Request) (*routing.Response, error) {
@@ -95,6 +105,10 @@ func (h *Task) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "error finding app", "appGUID", appGUID)
 	}
 
+	if err = h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, korifiv1alpha1.FeatureFlagTaskCreation); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "task creation is disabled", "appGUID", appGUID)
+	}
+
 	if !appRecord.IsStaged {
 		return nil, apierrors.LogAndReturn(
 			logger,
@@ -133,8 +147,16 @@ func (h *Task) listForApp(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to list tasks")
 	}
+	tasks = h.sortList(tasks, taskListFilter)
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForListResult(presenter.ForTask, tasks, h.serverURL, *r.URL)), nil
+}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForTask, tasks, h.serverURL, *r.URL)), nil
+func (h *Task) sortList(tasks repositories.ListResult[repositories.TaskRecord], taskListFilter *payloads.TaskList) repositories.ListResult[repositories.TaskRecord] {
+	return sortListResult(tasks, taskListFilter.OrderBy, taskListFilter.Pagination, orderBy[repositories.TaskRecord]{
+		"created_at": func(a, b repositories.TaskRecord) bool { return createdBefore(a.CreatedAt, b.CreatedAt) },
+		"updated_at": func(a, b repositories.TaskRecord) bool { return updatedBefore(a.UpdatedAt, b.UpdatedAt) },
+	})
 }
 
 func (h *Task) cancel(r *http.Request) (*routing.Response, error) {
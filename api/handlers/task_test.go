@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/tools"
@@ -22,11 +23,12 @@ import (
 
 var _ = Describe("Task", func() {
 	var (
-		requestMethod    string
-		requestPath      string
-		appRepo          *fake.CFAppRepository
-		taskRepo         *fake.CFTaskRepository
-		requestValidator *fake.RequestValidator
+		requestMethod      string
+		requestPath        string
+		appRepo            *fake.CFAppRepository
+		taskRepo           *fake.CFTaskRepository
+		requestValidator   *fake.RequestValidator
+		featureFlagChecker *fake.FeatureFlagChecker
 	)
 
 	BeforeEach(func() {
@@ -48,8 +50,9 @@ var _ = Describe("Task", func() {
 		}, nil)
 
 		requestValidator = new(fake.RequestValidator)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 
-		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, requestValidator)
+		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, requestValidator, featureFlagChecker)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -143,6 +146,24 @@ var _ = Describe("Task", func() {
 			})
 		})
 
+		When("task creation is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("task_creation", ""))
+			})
+
+			It("checks the task_creation feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlagName := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlagName).To(Equal("task_creation"))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(taskRepo.CreateTaskCallCount()).To(BeZero())
+				expectFeatureDisabledError("task_creation")
+			})
+		})
+
 		When("the user cannot create tasks", func() {
 			BeforeEach(func() {
 				taskRepo.CreateTaskReturns(repositories.TaskRecord{}, apierrors.NewForbiddenError(nil, repositories.TaskResourceType))
@@ -176,23 +197,33 @@ var _ = Describe("Task", func() {
 
 	Describe("listing tasks", func() {
 		BeforeEach(func() {
-			taskRepo.ListTasksReturns([]repositories.TaskRecord{
-				{GUID: "guid-1"},
-				{GUID: "guid-2"},
+			taskRepo.ListTasksReturns(repositories.ListResult[repositories.TaskRecord]{
+				PageInfo: repositories.PageInfo{TotalResults: 2, Page: 1, PerPage: 50},
+				Records:  []repositories.TaskRecord{{GUID: "guid-1"}, {GUID: "guid-2"}},
 			}, nil)
 		})
 
 		Describe("GET /v3/tasks", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.TaskList{
+					Pagination: payloads.Pagination{Page: 2, PerPage: 1},
+				})
+			})
+
 			It("lists the tasks", func() {
+				Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+
 				Expect(taskRepo.ListTasksCallCount()).To(Equal(1))
 				_, info, listMsg := taskRepo.ListTasksArgsForCall(0)
 				Expect(info).To(Equal(authInfo))
-				Expect(listMsg).To(Equal(repositories.ListTaskMessage{}))
+				Expect(listMsg).To(Equal(repositories.ListTaskMessage{
+					Pagination: repositories.Pagination{Page: 2, PerPage: 1},
+				}))
 
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
-					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/tasks"),
+					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/tasks?page=1&per_page=50"),
 					MatchJSONPath("$.resources", HaveLen(2)),
 					MatchJSONPath("$.resources[0].guid", "guid-1"),
 					MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/tasks/guid-1"),
@@ -200,15 +231,57 @@ var _ = Describe("Task", func() {
 				)))
 			})
 
+			When("the tasks are ordered", func() {
+				BeforeEach(func() {
+					taskRepo.ListTasksReturns(repositories.ListResult[repositories.TaskRecord]{
+						PageInfo: repositories.PageInfo{TotalResults: 3, Page: 1, PerPage: 3},
+						Records: []repositories.TaskRecord{
+							{GUID: "guid-1", CreatedAt: time.UnixMilli(1000)},
+							{GUID: "guid-2", CreatedAt: time.UnixMilli(2000)},
+							{GUID: "guid-3", CreatedAt: time.UnixMilli(3000)},
+						},
+					}, nil)
+
+					requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.TaskList{
+						OrderBy:    "-created_at",
+						Pagination: payloads.Pagination{Page: 1, PerPage: 2},
+					})
+				})
+
+				It("lists all tasks and returns the requested page of the sorted list", func() {
+					Expect(taskRepo.ListTasksCallCount()).To(Equal(1))
+					_, _, listMsg := taskRepo.ListTasksArgsForCall(0)
+					Expect(listMsg.Pagination).To(Equal(repositories.Pagination{}))
+
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.pagination.total_results", BeEquivalentTo(3)),
+						MatchJSONPath("$.pagination.total_pages", BeEquivalentTo(2)),
+						MatchJSONPath("$.resources[*].guid", []any{"guid-3", "guid-2"}),
+					)))
+				})
+			})
+
 			When("listing tasks fails", func() {
 				BeforeEach(func() {
-					taskRepo.ListTasksReturns(nil, errors.New("list-err"))
+					taskRepo.ListTasksReturns(repositories.ListResult[repositories.TaskRecord]{}, errors.New("list-err"))
 				})
 
 				It("returns an Internal Server Error", func() {
 					expectUnknownError()
 				})
 			})
+
+			When("the request is invalid", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					Expect(taskRepo.ListTasksCallCount()).To(BeZero())
+					expectUnknownError()
+				})
+			})
 		})
 
 		Describe("GET /v3/apps/{app-guid}/tasks", func() {
@@ -232,7 +305,7 @@ var _ = Describe("Task", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
-					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/the-app-guid/tasks?foo=bar"),
+					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/the-app-guid/tasks?foo=bar&page=1&per_page=50"),
 					MatchJSONPath("$.resources", HaveLen(2)),
 					MatchJSONPath("$.resources[0].guid", "guid-1"),
 					MatchJSONPath("$.resources[0].links.self.href", "https://api.example.org/v3/tasks/guid-1"),
@@ -296,7 +369,7 @@ var _ = Describe("Task", func() {
 
 			When("listing tasks fails", func() {
 				BeforeEach(func() {
-					taskRepo.ListTasksReturns(nil, errors.New("list-err"))
+					taskRepo.ListTasksReturns(repositories.ListResult[repositories.TaskRecord]{}, errors.New("list-err"))
 				})
 
 				It("returns an Internal Server Error", func() {
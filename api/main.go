@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -19,9 +20,11 @@ import (
 	"code.cloudfoundry.org/korifi/api/middleware"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/conditions"
 	"code.cloudfoundry.org/korifi/api/routing"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/image"
@@ -31,6 +34,7 @@ import (
 
 	chiMiddlewares "github.com/go-chi/chi/middleware"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"golang.org/x/crypto/ssh"
 	"k8s.io/apimachinery/pkg/util/cache"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/dynamic"
@@ -145,6 +149,7 @@ func main() {
 		userClientFactory,
 	)
 	appRepo := repositories.NewAppRepo(
+		cfg.RootNamespace,
 		namespaceRetriever,
 		userClientFactory,
 		nsPermissions,
@@ -162,12 +167,50 @@ func main() {
 	)
 	domainRepo := repositories.NewDomainRepo(
 		userClientFactory,
+		privilegedCRClient,
+		namespaceRetriever,
+		nsPermissions,
+		cfg.RootNamespace,
+	)
+	orgQuotaRepo := repositories.NewOrgQuotaRepo(
+		cfg.RootNamespace,
+		userClientFactory,
+		nsPermissions,
+	)
+	spaceQuotaRepo := repositories.NewSpaceQuotaRepo(
+		namespaceRetriever,
+		orgRepo,
+		userClientFactory,
+		nsPermissions,
+	)
+	securityGroupRepo := repositories.NewSecurityGroupRepo(
+		cfg.RootNamespace,
+		namespaceRetriever,
+		userClientFactory,
+	)
+	isolationSegmentRepo := repositories.NewIsolationSegmentRepo(
+		cfg.RootNamespace,
 		namespaceRetriever,
+		userClientFactory,
+	)
+	envVarGroupRepo := repositories.NewEnvVarGroupRepo(
 		cfg.RootNamespace,
+		userClientFactory,
+	)
+	featureFlagRepo := repositories.NewFeatureFlagRepo(
+		cfg.RootNamespace,
+		privilegedCRClient,
+		userClientFactory,
 	)
 	deploymentRepo := repositories.NewDeploymentRepo(
 		userClientFactory,
 		namespaceRetriever,
+		nsPermissions,
+	)
+	revisionRepo := repositories.NewRevisionRepo(
+		userClientFactory,
+		namespaceRetriever,
+		nsPermissions,
 	)
 	buildRepo := repositories.NewBuildRepo(
 		namespaceRetriever,
@@ -202,6 +245,19 @@ func main() {
 		userClientFactory,
 		cfg.RootNamespace,
 	)
+	stackRepo := repositories.NewStackRepository(cfg.BuilderName,
+		userClientFactory,
+		cfg.RootNamespace,
+		cfg.DefaultLifecycleConfig.Stack,
+	)
+	sidecarRepo := repositories.NewSidecarRepo(
+		userClientFactory,
+		nsPermissions,
+	)
+	networkPolicyRepo := repositories.NewNetworkPolicyRepo(
+		userClientFactory,
+		nsPermissions,
+	)
 	roleRepo := repositories.NewRoleRepo(
 		userClientFactory,
 		spaceRepo,
@@ -229,14 +285,22 @@ func main() {
 	serviceBrokerRepo := repositories.NewServiceBrokerRepo(userClientFactory, cfg.RootNamespace)
 	serviceOfferingRepo := repositories.NewServiceOfferingRepo(userClientFactory, cfg.RootNamespace)
 	servicePlanRepo := repositories.NewServicePlanRepo(userClientFactory, cfg.RootNamespace)
+	auditEventRepo := repositories.NewAuditEventRepo(
+		privilegedCRClient,
+		namespaceRetriever,
+		nsPermissions,
+		cachingIdentityProvider,
+		cfg.RootNamespace,
+	)
 
 	processStats := actions.NewProcessStats(processRepo, appRepo, metricsRepo)
 	manifest := actions.NewManifest(
 		domainRepo,
 		cfg.DefaultDomainName,
-		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, sidecarRepo),
 		manifest.NewNormalizer(cfg.DefaultDomainName),
-		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, sidecarRepo),
+		manifest.NewDiffer(),
 	)
 	appLogs := actions.NewAppLogs(appRepo, buildRepo, podRepo)
 
@@ -268,9 +332,47 @@ func main() {
 		),
 	)
 
+	var appSSH *presenter.AppSSH
+	if cfg.SSHProxy.Enabled {
+		hostKey := loadSSHHostKey(cfg.SSHProxy.HostKeyPath)
+		codeStore := sshproxy.NewCodeStore(privilegedCRClient, cfg.RootNamespace, sshproxy.DefaultCodeValidity)
+		go codeStore.CollectGarbage(ctrl.LoggerInto(context.Background(), ctrl.Log), sshproxy.DefaultCodeValidity)
+		routerBuilder.LoadRoutes(handlers.NewSSHCode(*serverURL, codeStore, requestValidator))
+
+		appSSH = &presenter.AppSSH{
+			Address:            cfg.SSHProxy.ExternalAddress,
+			HostKeyFingerprint: sshproxy.HostKeyFingerprint(hostKey.PublicKey()),
+			OAuthClient:        sshproxy.OAuthClient,
+		}
+
+		sshServer := sshproxy.NewServer(
+			hostKey,
+			codeStore,
+			processRepo,
+			appRepo,
+			spaceRepo,
+			podRepo,
+			sshproxy.NewWebsocketExecutor(userClientFactory),
+			ctrl.Log.WithName("ssh-proxy"),
+		)
+		go func() {
+			sshListener, err2 := net.Listen("tcp", fmt.Sprintf(":%d", cfg.SSHProxy.Port))
+			if err2 != nil {
+				ctrl.Log.Error(err2, "error listening for ssh connections")
+				os.Exit(1)
+			}
+
+			ctrl.Log.Info("ssh proxy listening on " + sshListener.Addr().String())
+			if err2 = sshServer.Serve(context.Background(), sshListener); err2 != nil {
+				ctrl.Log.Error(err2, "error serving ssh")
+				os.Exit(1)
+			}
+		}()
+	}
+
 	apiHandlers := []routing.Routable{
 		handlers.NewRootV3(*serverURL),
-		handlers.NewRoot(*serverURL),
+		handlers.NewRoot(*serverURL, appSSH, cfg.TCPRouterGroup.Enabled()),
 		handlers.NewInfoV3(
 			*serverURL,
 			cfg.InfoConfig,
@@ -286,7 +388,11 @@ func main() {
 			domainRepo,
 			spaceRepo,
 			packageRepo,
+			stackRepo,
 			requestValidator,
+			auditEventRepo,
+			featureFlagRepo,
+			cfg.SSHProxy.Enabled,
 		),
 		handlers.NewRoute(
 			*serverURL,
@@ -295,6 +401,8 @@ func main() {
 			appRepo,
 			spaceRepo,
 			requestValidator,
+			auditEventRepo,
+			cfg.TCPRouterGroup,
 		),
 		handlers.NewServiceRouteBinding(
 			*serverURL,
@@ -306,6 +414,7 @@ func main() {
 			dropletRepo,
 			imageRepo,
 			requestValidator,
+			featureFlagRepo,
 			cfg.PackageRegistrySecretNames,
 		),
 		handlers.NewBuild(
@@ -325,11 +434,48 @@ func main() {
 			processRepo,
 			processStats,
 			requestValidator,
+			auditEventRepo,
+			featureFlagRepo,
 		),
 		handlers.NewDomain(
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			cfg.TCPRouterGroup,
+		),
+		handlers.NewRouterGroup(
+			requestValidator,
+			cfg.TCPRouterGroup,
+		),
+		handlers.NewOrgQuota(
+			*serverURL,
+			requestValidator,
+			orgQuotaRepo,
+		),
+		handlers.NewSpaceQuota(
+			*serverURL,
+			requestValidator,
+			spaceQuotaRepo,
+		),
+		handlers.NewSecurityGroup(
+			*serverURL,
+			requestValidator,
+			securityGroupRepo,
+		),
+		handlers.NewIsolationSegment(
+			*serverURL,
+			requestValidator,
+			isolationSegmentRepo,
+		),
+		handlers.NewEnvVarGroup(
+			*serverURL,
+			requestValidator,
+			envVarGroupRepo,
+		),
+		handlers.NewFeatureFlag(
+			*serverURL,
+			requestValidator,
+			featureFlagRepo,
 		),
 		handlers.NewDeployment(
 			*serverURL,
@@ -338,6 +484,17 @@ func main() {
 			runnerInfoRepo,
 			cfg.RunnerName,
 		),
+		handlers.NewRevision(
+			*serverURL,
+			revisionRepo,
+			appRepo,
+			requestValidator,
+		),
+		handlers.NewAuditEvent(
+			*serverURL,
+			auditEventRepo,
+			requestValidator,
+		),
 		handlers.NewJob(
 			*serverURL,
 			map[string]handlers.DeletionRepository{
@@ -347,9 +504,17 @@ func main() {
 				handlers.RouteDeleteJobType:  routeRepo,
 				handlers.DomainDeleteJobType: domainRepo,
 				handlers.RoleDeleteJobType:   roleRepo,
+
+				handlers.OrgQuotaDeleteJobType:   orgQuotaRepo,
+				handlers.SpaceQuotaDeleteJobType: spaceQuotaRepo,
+
+				handlers.SecurityGroupDeleteJobType: securityGroupRepo,
+
+				handlers.ServiceInstanceDeleteJobType: serviceInstanceRepo,
 			},
 			map[string]handlers.StateRepository{
-				handlers.ServiceBrokerCreateJobType: serviceBrokerRepo,
+				handlers.ServiceBrokerCreateJobType:   serviceBrokerRepo,
+				handlers.ServiceInstanceCreateJobType: serviceInstanceRepo,
 			},
 			500*time.Millisecond,
 		),
@@ -376,12 +541,16 @@ func main() {
 			*serverURL,
 			manifest,
 			spaceRepo,
+			appRepo,
 			requestValidator,
+			auditEventRepo,
+			featureFlagRepo,
 		),
 		handlers.NewRole(
 			*serverURL,
 			roleRepo,
 			requestValidator,
+			auditEventRepo,
 		),
 		handlers.NewWhoAmI(cachingIdentityProvider, *serverURL),
 		handlers.NewUser(*serverURL),
@@ -390,11 +559,30 @@ func main() {
 			buildpackRepo,
 			requestValidator,
 		),
+		handlers.NewStack(
+			*serverURL,
+			stackRepo,
+			requestValidator,
+		),
+		handlers.NewSidecar(
+			*serverURL,
+			requestValidator,
+			sidecarRepo,
+			appRepo,
+			processRepo,
+		),
+		handlers.NewNetworkPolicy(
+			requestValidator,
+			networkPolicyRepo,
+			appRepo,
+		),
 		handlers.NewServiceInstance(
 			*serverURL,
 			serviceInstanceRepo,
 			spaceRepo,
+			servicePlanRepo,
 			requestValidator,
+			cfg.ExperimentalManagedServicesEnabled,
 		),
 		handlers.NewServiceBinding(
 			*serverURL,
@@ -402,12 +590,14 @@ func main() {
 			appRepo,
 			serviceInstanceRepo,
 			requestValidator,
+			auditEventRepo,
 		),
 		handlers.NewTask(
 			*serverURL,
 			appRepo,
 			taskRepo,
 			requestValidator,
+			featureFlagRepo,
 		),
 		handlers.NewOAuth(
 			*serverURL,
@@ -485,6 +675,20 @@ func main() {
 	}
 }
 
+func loadSSHHostKey(hostKeyPath string) ssh.Signer {
+	hostKeyBytes, err := os.ReadFile(hostKeyPath)
+	if err != nil {
+		panic(fmt.Sprintf("could not read ssh host key: %v", err))
+	}
+
+	hostKey, err := ssh.ParsePrivateKey(hostKeyBytes)
+	if err != nil {
+		panic(fmt.Sprintf("could not parse ssh host key: %v", err))
+	}
+
+	return hostKey
+}
+
 func wireIdentityProvider(client client.Client, restConfig *rest.Config) authorization.IdentityProvider {
 	tokenReviewer := authorization.NewTokenReviewer(client)
 	certInspector := authorization.NewCertInspector(restConfig)
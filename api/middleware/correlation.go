@@ -3,6 +3,8 @@ package middleware
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/korifi/api/correlation"
+
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 )
@@ -18,7 +20,7 @@ func Correlation(logger logr.Logger) func(http.Handler) http.Handler {
 			}
 
 			l := logger.WithValues("correlation-id", id)
-			r = r.WithContext(logr.NewContext(r.Context(), l))
+			r = r.WithContext(correlation.NewContext(logr.NewContext(r.Context(), l), id))
 
 			w.Header().Add(CorrelationIDHeader, id)
 
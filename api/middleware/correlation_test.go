@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/correlation"
 	"code.cloudfoundry.org/korifi/api/middleware"
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
@@ -13,7 +14,7 @@ import (
 
 func handler(w http.ResponseWriter, r *http.Request) {
 	logger := logr.FromContextOrDiscard(r.Context())
-	logger.Info("hello")
+	logger.Info("hello", "context-correlation-id", correlation.IDFromContext(r.Context()))
 }
 
 var _ = Describe("Correlation", func() {
@@ -44,6 +45,11 @@ var _ = Describe("Correlation", func() {
 		Expect(buf.String()).To(ContainSubstring(`"correlation-id":"` + corrID + `"`))
 	})
 
+	It("stores the correlation ID in the request context", func() {
+		corrID := rr.Header().Get("X-Correlation-Id")
+		Expect(buf.String()).To(ContainSubstring(`"context-correlation-id":"` + corrID + `"`))
+	})
+
 	When("correlation ID is passed in a header", func() {
 		BeforeEach(func() {
 			requestHeaders.Set("X-Correlation-Id", "my-corr-id")
@@ -87,11 +87,13 @@ type AppList struct {
 	SpaceGuids    string
 	OrderBy       string
 	LabelSelector string
+	Pagination
 }
 
 func (a AppList) Validate() error {
 	return jellidation.ValidateStruct(&a,
 		jellidation.Field(&a.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "name", "state")),
+		jellidation.Field(&a.Pagination),
 	)
 }
 
@@ -114,7 +116,7 @@ func (a *AppList) DecodeFromURLValues(values url.Values) error {
 	a.SpaceGuids = values.Get("space_guids")
 	a.OrderBy = values.Get("order_by")
 	a.LabelSelector = values.Get("label_selector")
-	return nil
+	return a.Pagination.DecodeFromURLValues(values)
 }
 
 type AppPatchEnvVars struct {
@@ -31,6 +31,7 @@ var _ = Describe("AppList", func() {
 			Entry("order_by state", "order_by=state", payloads.AppList{OrderBy: "state"}),
 			Entry("order_by -state", "order_by=-state", payloads.AppList{OrderBy: "-state"}),
 			Entry("label_selector=foo", "label_selector=foo", payloads.AppList{LabelSelector: "foo"}),
+			Entry("page and per_page", "page=2&per_page=10", payloads.AppList{Pagination: payloads.Pagination{Page: 2, PerPage: 10}}),
 		)
 
 		DescribeTable("invalid query",
@@ -39,6 +40,8 @@ var _ = Describe("AppList", func() {
 				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
 			},
 			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("negative page", "page=-1", "page: must be no less than 1"),
+			Entry("per_page too large", "per_page=5001", "per_page: must be no greater than 5000"),
 		)
 	})
 
@@ -0,0 +1,100 @@
+package payloads
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/jellydator/validation"
+)
+
+type AuditEventList struct {
+	Types             string
+	TargetGUIDs       string
+	SpaceGUIDs        string
+	OrganizationGUIDs string
+	CreatedAts        repositories.TimestampFilter
+	OrderBy           string
+	Pagination
+}
+
+func (l AuditEventList) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.OrderBy, payload_validation.OneOfOrderBy("created_at", "updated_at")),
+		validation.Field(&l.Pagination),
+	)
+}
+
+func (l *AuditEventList) ToMessage() repositories.ListAuditEventsMessage {
+	return repositories.ListAuditEventsMessage{
+		Types:             parse.ArrayParam(l.Types),
+		TargetGUIDs:       parse.ArrayParam(l.TargetGUIDs),
+		SpaceGUIDs:        parse.ArrayParam(l.SpaceGUIDs),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+		CreatedAts:        l.CreatedAts,
+	}
+}
+
+func (l *AuditEventList) SupportedKeys() []string {
+	return []string{
+		"types",
+		"target_guids",
+		"space_guids",
+		"organization_guids",
+		"created_ats",
+		"created_ats[lt]",
+		"created_ats[lte]",
+		"created_ats[gt]",
+		"created_ats[gte]",
+		"order_by",
+		"per_page",
+		"page",
+	}
+}
+
+func (l *AuditEventList) DecodeFromURLValues(values url.Values) error {
+	l.Types = values.Get("types")
+	l.TargetGUIDs = values.Get("target_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.OrderBy = values.Get("order_by")
+
+	for _, v := range parse.ArrayParam(values.Get("created_ats")) {
+		createdAt, err := parseTimestamp("created_ats", v)
+		if err != nil {
+			return err
+		}
+		l.CreatedAts.Equal = append(l.CreatedAts.Equal, createdAt)
+	}
+
+	for key, bound := range map[string]**time.Time{
+		"created_ats[lt]":  &l.CreatedAts.Before,
+		"created_ats[lte]": &l.CreatedAts.BeforeOrEqual,
+		"created_ats[gt]":  &l.CreatedAts.After,
+		"created_ats[gte]": &l.CreatedAts.AfterOrEqual,
+	} {
+		if !values.Has(key) {
+			continue
+		}
+
+		t, err := parseTimestamp(key, values.Get(key))
+		if err != nil {
+			return err
+		}
+		*bound = &t
+	}
+
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func parseTimestamp(key, value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC3339 timestamp: %w", key, err)
+	}
+
+	return t, nil
+}
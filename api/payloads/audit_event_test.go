@@ -0,0 +1,80 @@
+package payloads_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventList", func() {
+	timestamp := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedAuditEventList payloads.AuditEventList) {
+				actualAuditEventList, decodeErr := decodeQuery[payloads.AuditEventList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualAuditEventList).To(Equal(expectedAuditEventList))
+			},
+			Entry("types", "types=audit.app.start,audit.app.stop", payloads.AuditEventList{Types: "audit.app.start,audit.app.stop"}),
+			Entry("target_guids", "target_guids=t1,t2", payloads.AuditEventList{TargetGUIDs: "t1,t2"}),
+			Entry("space_guids", "space_guids=s1,s2", payloads.AuditEventList{SpaceGUIDs: "s1,s2"}),
+			Entry("organization_guids", "organization_guids=o1,o2", payloads.AuditEventList{OrganizationGUIDs: "o1,o2"}),
+			Entry("created_ats", "created_ats=2024-05-01T10:30:00Z", payloads.AuditEventList{
+				CreatedAts: repositories.TimestampFilter{Equal: []time.Time{timestamp}},
+			}),
+			Entry("created_ats[lt]", "created_ats[lt]=2024-05-01T10:30:00Z", payloads.AuditEventList{
+				CreatedAts: repositories.TimestampFilter{Before: tools.PtrTo(timestamp)},
+			}),
+			Entry("created_ats[lte]", "created_ats[lte]=2024-05-01T10:30:00Z", payloads.AuditEventList{
+				CreatedAts: repositories.TimestampFilter{BeforeOrEqual: tools.PtrTo(timestamp)},
+			}),
+			Entry("created_ats[gt]", "created_ats[gt]=2024-05-01T10:30:00Z", payloads.AuditEventList{
+				CreatedAts: repositories.TimestampFilter{After: tools.PtrTo(timestamp)},
+			}),
+			Entry("created_ats[gte]", "created_ats[gte]=2024-05-01T10:30:00Z", payloads.AuditEventList{
+				CreatedAts: repositories.TimestampFilter{AfterOrEqual: tools.PtrTo(timestamp)},
+			}),
+			Entry("order_by created_at", "order_by=created_at", payloads.AuditEventList{OrderBy: "created_at"}),
+			Entry("order_by -updated_at", "order_by=-updated_at", payloads.AuditEventList{OrderBy: "-updated_at"}),
+			Entry("page and per_page", "page=2&per_page=10", payloads.AuditEventList{Pagination: payloads.Pagination{Page: 2, PerPage: 10}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.AuditEventList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid created_ats", "created_ats=yesterday", "created_ats must be an RFC3339 timestamp"),
+			Entry("invalid created_ats[gt]", "created_ats[gt]=yesterday", "created_ats[gt] must be an RFC3339 timestamp"),
+			Entry("invalid order_by", "order_by=type", "value must be one of"),
+			Entry("per_page too large", "per_page=5001", "per_page: must be no greater than 5000"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			auditEventList := payloads.AuditEventList{
+				Types:             "audit.app.start",
+				TargetGUIDs:       "t1",
+				SpaceGUIDs:        "s1",
+				OrganizationGUIDs: "o1",
+				CreatedAts:        repositories.TimestampFilter{After: tools.PtrTo(timestamp)},
+				OrderBy:           "created_at",
+			}
+			Expect(auditEventList.ToMessage()).To(Equal(repositories.ListAuditEventsMessage{
+				Types:             []string{"audit.app.start"},
+				TargetGUIDs:       []string{"t1"},
+				SpaceGUIDs:        []string{"s1"},
+				OrganizationGUIDs: []string{"o1"},
+				CreatedAts:        repositories.TimestampFilter{After: tools.PtrTo(timestamp)},
+			}))
+		})
+	})
+})
@@ -9,6 +9,7 @@ import (
 
 type BuildpackList struct {
 	OrderBy string
+	Pagination
 }
 
 func (d BuildpackList) SupportedKeys() []string {
@@ -17,11 +18,12 @@ func (d BuildpackList) SupportedKeys() []string {
 
 func (d *BuildpackList) DecodeFromURLValues(values url.Values) error {
 	d.OrderBy = values.Get("order_by")
-	return nil
+	return d.Pagination.DecodeFromURLValues(values)
 }
 
 func (d BuildpackList) Validate() error {
 	return jellidation.ValidateStruct(&d,
 		jellidation.Field(&d.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "position")),
+		jellidation.Field(&d.Pagination),
 	)
 }
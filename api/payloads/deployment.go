@@ -1,6 +1,10 @@
 package payloads
 
 import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
@@ -9,21 +13,44 @@ type DropletGUID struct {
 	Guid string `json:"guid"`
 }
 
+type RevisionGUID struct {
+	Guid string `json:"guid"`
+}
+
+func (g RevisionGUID) Validate() error {
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.Guid, validation.Required))
+}
+
 type DeploymentCreate struct {
 	Droplet       DropletGUID              `json:"droplet"`
+	Revision      *RevisionGUID            `json:"revision"`
+	Strategy      string                   `json:"strategy"`
 	Relationships *DeploymentRelationships `json:"relationships"`
 }
 
 func (c DeploymentCreate) Validate() error {
 	return validation.ValidateStruct(&c,
+		validation.Field(&c.Revision, validation.When(c.Droplet.Guid != "", validation.Nil.Error("cannot be set together with droplet"))),
+		validation.Field(&c.Strategy, payload_validation.OneOf(
+			string(repositories.DeploymentStrategyRolling),
+			string(repositories.DeploymentStrategyCanary),
+		)),
 		validation.Field(&c.Relationships, validation.NotNil))
 }
 
 func (c *DeploymentCreate) ToMessage() repositories.CreateDeploymentMessage {
-	return repositories.CreateDeploymentMessage{
+	message := repositories.CreateDeploymentMessage{
 		AppGUID:     c.Relationships.App.Data.GUID,
 		DropletGUID: c.Droplet.Guid,
+		Strategy:    repositories.DeploymentStrategy(c.Strategy),
 	}
+
+	if c.Revision != nil {
+		message.RevisionGUID = c.Revision.Guid
+	}
+
+	return message
 }
 
 type DeploymentRelationships struct {
@@ -34,3 +61,48 @@ func (r DeploymentRelationships) Validate() error {
 	return validation.ValidateStruct(&r,
 		validation.Field(&r.App, validation.NotNil))
 }
+
+type DeploymentList struct {
+	AppGUIDs string
+	States   []string
+	OrderBy  string
+	Pagination
+}
+
+func (l DeploymentList) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.States, validation.Each(payload_validation.OneOf(
+			string(repositories.DeploymentStateDeploying),
+			string(repositories.DeploymentStateDeployed),
+			string(repositories.DeploymentStateCanceling),
+			string(repositories.DeploymentStateCanceled),
+		))),
+		validation.Field(&l.OrderBy, payload_validation.OneOfOrderBy("created_at", "updated_at")),
+		validation.Field(&l.Pagination),
+	)
+}
+
+func (l *DeploymentList) ToMessage() repositories.ListDeploymentsMessage {
+	var states []repositories.DeploymentState
+	for _, state := range l.States {
+		states = append(states, repositories.DeploymentState(state))
+	}
+
+	return repositories.ListDeploymentsMessage{
+		AppGUIDs: parse.ArrayParam(l.AppGUIDs),
+		States:   states,
+	}
+}
+
+func (l *DeploymentList) SupportedKeys() []string {
+	return []string{"app_guids", "states", "order_by", "per_page", "page"}
+}
+
+func (l *DeploymentList) DecodeFromURLValues(values url.Values) error {
+	l.AppGUIDs = values.Get("app_guids")
+	if states := values.Get("states"); states != "" {
+		l.States = parse.ArrayParam(states)
+	}
+	l.OrderBy = values.Get("order_by")
+	return l.Pagination.DecodeFromURLValues(values)
+}
@@ -86,11 +86,69 @@ var _ = Describe("DeploymentCreate", func() {
 				expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
 			})
 		})
+
+		When("the strategy is canary", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "canary"
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+		})
+
+		When("a revision is specified instead of a droplet", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+		})
+
+		When("both a droplet and a revision are specified", func() {
+			BeforeEach(func() {
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "revision cannot be set together with droplet")
+			})
+		})
+
+		When("the revision guid is empty", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "revision.guid cannot be blank")
+			})
+		})
+
+		When("the strategy is invalid", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "blue-green"
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "strategy value must be one of: rolling, canary")
+			})
+		})
 	})
 
 	Describe("ToMessage", func() {
 		var createMessage repositories.CreateDeploymentMessage
 
+		BeforeEach(func() {
+			createDeployment.Strategy = "canary"
+		})
+
 		JustBeforeEach(func() {
 			createMessage = createDeployment.ToMessage()
 		})
@@ -99,6 +157,61 @@ var _ = Describe("DeploymentCreate", func() {
 			Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
 				AppGUID:     "the-app",
 				DropletGUID: "the-droplet",
+				Strategy:    repositories.DeploymentStrategyCanary,
+			}))
+		})
+
+		When("a revision is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("sets the revision guid", func() {
+				Expect(createMessage.DropletGUID).To(BeEmpty())
+				Expect(createMessage.RevisionGUID).To(Equal("the-revision"))
+			})
+		})
+	})
+})
+
+var _ = Describe("DeploymentList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedDeploymentList payloads.DeploymentList) {
+				actualDeploymentList, decodeErr := decodeQuery[payloads.DeploymentList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualDeploymentList).To(Equal(expectedDeploymentList))
+			},
+			Entry("app_guids", "app_guids=app1,app2", payloads.DeploymentList{AppGUIDs: "app1,app2"}),
+			Entry("states", "states=DEPLOYING,CANCELED", payloads.DeploymentList{States: []string{"DEPLOYING", "CANCELED"}}),
+			Entry("order_by created_at", "order_by=created_at", payloads.DeploymentList{OrderBy: "created_at"}),
+			Entry("order_by -updated_at", "order_by=-updated_at", payloads.DeploymentList{OrderBy: "-updated_at"}),
+			Entry("page and per_page", "page=2&per_page=10", payloads.DeploymentList{Pagination: payloads.Pagination{Page: 2, PerPage: 10}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.DeploymentList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid state", "states=DEPLOYING,FOO", "value must be one of"),
+			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("per_page too large", "per_page=5001", "per_page: must be no greater than 5000"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			deploymentList := payloads.DeploymentList{
+				AppGUIDs: "app1,app2",
+				States:   []string{"DEPLOYING", "CANCELED"},
+				OrderBy:  "created_at",
+			}
+			Expect(deploymentList.ToMessage()).To(Equal(repositories.ListDeploymentsMessage{
+				AppGUIDs: []string{"app1", "app2"},
+				States:   []repositories.DeploymentState{repositories.DeploymentStateDeploying, repositories.DeploymentStateCanceled},
 			}))
 		})
 	})
@@ -11,36 +11,98 @@ import (
 )
 
 type DomainCreate struct {
-	Name          string                  `json:"name"`
-	Internal      bool                    `json:"internal"`
-	Metadata      Metadata                `json:"metadata"`
-	Relationships map[string]Relationship `json:"relationships"`
+	Name          string               `json:"name"`
+	Internal      bool                 `json:"internal"`
+	RouterGroup   *DomainRouterGroup   `json:"router_group"`
+	Metadata      Metadata             `json:"metadata"`
+	Relationships *DomainRelationships `json:"relationships"`
+}
+
+type DomainRouterGroup struct {
+	GUID string `json:"guid"`
+}
+
+func (g DomainRouterGroup) Validate() error {
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.GUID, validation.Required),
+	)
+}
+
+type DomainRelationships struct {
+	Organization        *Relationship       `json:"organization"`
+	SharedOrganizations *ToManyRelationship `json:"shared_organizations"`
+}
+
+func (r DomainRelationships) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Organization),
+		validation.Field(&r.SharedOrganizations),
+	)
 }
 
 func (c DomainCreate) Validate() error {
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.Name, payload_validation.StrictlyRequired),
+		validation.Field(&c.RouterGroup),
 		validation.Field(&c.Metadata),
 		validation.Field(&c.Relationships),
 	)
 }
 
 func (c *DomainCreate) ToMessage() (repositories.CreateDomainMessage, error) {
-	if c.Internal {
-		return repositories.CreateDomainMessage{}, errors.New("internal domains are not supported")
-	}
-
-	if len(c.Relationships) > 0 {
-		return repositories.CreateDomainMessage{}, errors.New("private domains are not supported")
-	}
-
-	return repositories.CreateDomainMessage{
-		Name: c.Name,
+	message := repositories.CreateDomainMessage{
+		Name:     c.Name,
+		Internal: c.Internal,
 		Metadata: repositories.Metadata{
 			Labels:      c.Metadata.Labels,
 			Annotations: c.Metadata.Annotations,
 		},
-	}, nil
+	}
+
+	if c.RouterGroup != nil {
+		message.RouterGroupGUID = c.RouterGroup.GUID
+	}
+
+	if message.RouterGroupGUID != "" && message.Internal {
+		return repositories.CreateDomainMessage{}, errors.New("internal domains cannot be assigned to a router group")
+	}
+
+	if c.Relationships == nil {
+		return message, nil
+	}
+
+	if c.Relationships.Organization != nil {
+		message.OrganizationGUID = c.Relationships.Organization.Data.GUID
+	}
+
+	if c.Relationships.SharedOrganizations != nil {
+		message.SharedOrganizationGUIDs = c.Relationships.SharedOrganizations.GUIDs()
+	}
+
+	if message.OrganizationGUID == "" && len(message.SharedOrganizationGUIDs) > 0 {
+		return repositories.CreateDomainMessage{}, errors.New("domains cannot be shared with other organizations unless they are scoped to an organization")
+	}
+
+	if message.OrganizationGUID != "" && message.Internal {
+		return repositories.CreateDomainMessage{}, errors.New("internal domains cannot be scoped to an organization")
+	}
+
+	if message.OrganizationGUID != "" && message.RouterGroupGUID != "" {
+		return repositories.CreateDomainMessage{}, errors.New("domains scoped to an organization cannot be assigned to a router group")
+	}
+
+	return message, nil
+}
+
+type DomainShare struct {
+	ToManyRelationship
+}
+
+func (s DomainShare) ToMessage(domainGUID string) repositories.ShareDomainMessage {
+	return repositories.ShareDomainMessage{
+		GUID:              domainGUID,
+		OrganizationGUIDs: s.GUIDs(),
+	}
 }
 
 type DomainUpdate struct {
@@ -64,7 +126,16 @@ func (c DomainUpdate) Validate() error {
 }
 
 type DomainList struct {
-	Names string
+	Names   string
+	OrderBy string
+	Pagination
+}
+
+func (d DomainList) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.OrderBy, payload_validation.OneOfOrderBy("created_at", "updated_at")),
+		validation.Field(&d.Pagination),
+	)
 }
 
 func (d *DomainList) ToMessage() repositories.ListDomainsMessage {
@@ -74,10 +145,11 @@ func (d *DomainList) ToMessage() repositories.ListDomainsMessage {
 }
 
 func (d *DomainList) SupportedKeys() []string {
-	return []string{"names", "per_page", "page"}
+	return []string{"names", "order_by", "per_page", "page"}
 }
 
 func (d *DomainList) DecodeFromURLValues(values url.Values) error {
 	d.Names = values.Get("names")
-	return nil
+	d.OrderBy = values.Get("order_by")
+	return d.Pagination.DecodeFromURLValues(values)
 }
@@ -68,10 +68,20 @@ var _ = Describe("DomainCreate", func() {
 			})
 		})
 
+		When("the router group has no guid", func() {
+			BeforeEach(func() {
+				createPayload.RouterGroup = &payloads.DomainRouterGroup{}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+			})
+		})
+
 		When("relationship is invalid", func() {
 			BeforeEach(func() {
-				createPayload.Relationships = map[string]payloads.Relationship{
-					"foo": {Data: nil},
+				createPayload.Relationships = &payloads.DomainRelationships{
+					Organization: &payloads.Relationship{Data: nil},
 				}
 			})
 
@@ -118,25 +128,127 @@ var _ = Describe("DomainCreate", func() {
 				createPayload.Internal = true
 			})
 
-			It("errors", func() {
-				Expect(err).To(MatchError(ContainSubstring("internal domains are not supported")))
+			It("returns an internal domain create message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.Internal).To(BeTrue())
+			})
+		})
+
+		When("the payload has a router group", func() {
+			BeforeEach(func() {
+				createPayload.RouterGroup = &payloads.DomainRouterGroup{GUID: "default-tcp"}
+			})
+
+			It("returns a tcp domain create message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.RouterGroupGUID).To(Equal("default-tcp"))
+			})
+
+			When("the domain is internal", func() {
+				BeforeEach(func() {
+					createPayload.Internal = true
+				})
+
+				It("errors", func() {
+					Expect(err).To(MatchError(ContainSubstring("internal domains cannot be assigned to a router group")))
+				})
+			})
+
+			When("the domain is scoped to an organization", func() {
+				BeforeEach(func() {
+					createPayload.Relationships = &payloads.DomainRelationships{
+						Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+					}
+				})
+
+				It("errors", func() {
+					Expect(err).To(MatchError(ContainSubstring("domains scoped to an organization cannot be assigned to a router group")))
+				})
 			})
 		})
 
-		When("the payload has relationships", func() {
+		When("the payload has an organization relationship", func() {
 			BeforeEach(func() {
-				createPayload.Relationships = map[string]payloads.Relationship{
-					"foo": {},
+				createPayload.Relationships = &payloads.DomainRelationships{
+					Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+					SharedOrganizations: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "shared-org-guid"}},
+					},
+				}
+			})
+
+			It("returns a private domain create message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.OrganizationGUID).To(Equal("org-guid"))
+				Expect(createMessage.SharedOrganizationGUIDs).To(ConsistOf("shared-org-guid"))
+			})
+
+			When("the domain is internal", func() {
+				BeforeEach(func() {
+					createPayload.Internal = true
+				})
+
+				It("errors", func() {
+					Expect(err).To(MatchError(ContainSubstring("internal domains cannot be scoped to an organization")))
+				})
+			})
+		})
+
+		When("the payload has shared organizations but no organization", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = &payloads.DomainRelationships{
+					SharedOrganizations: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "shared-org-guid"}},
+					},
 				}
 			})
 
 			It("errors", func() {
-				Expect(err).To(MatchError(ContainSubstring("private domains are not supported")))
+				Expect(err).To(MatchError(ContainSubstring("domains cannot be shared with other organizations unless they are scoped to an organization")))
 			})
 		})
 	})
 })
 
+var _ = Describe("DomainShare", func() {
+	var (
+		sharePayload        payloads.DomainShare
+		decodedSharePayload *payloads.DomainShare
+		validatorErr        error
+	)
+
+	BeforeEach(func() {
+		decodedSharePayload = new(payloads.DomainShare)
+		sharePayload = payloads.DomainShare{
+			ToManyRelationship: payloads.ToManyRelationship{
+				Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(sharePayload), decodedSharePayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedSharePayload.ToMessage("domain-guid")).To(Equal(repositories.ShareDomainMessage{
+			GUID:              "domain-guid",
+			OrganizationGUIDs: []string{"org-1", "org-2"},
+		}))
+	})
+
+	When("the data is missing", func() {
+		BeforeEach(func() {
+			sharePayload.Data = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data is required")
+		})
+	})
+})
+
 var _ = Describe("DomainUpdate", func() {
 	var (
 		updatePayload        payloads.DomainUpdate
@@ -194,6 +306,25 @@ var _ = Describe("DomainList", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(domainList.Names).To(Equal("foo,bar"))
 		})
+
+		It("decodes order_by", func() {
+			domainList := payloads.DomainList{}
+			req, err := http.NewRequest("GET", "http://foo.com/bar?order_by=-created_at", nil)
+			Expect(err).NotTo(HaveOccurred())
+			err = validator.DecodeAndValidateURLValues(req, &domainList)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(domainList.OrderBy).To(Equal("-created_at"))
+		})
+
+		It("rejects an invalid order_by", func() {
+			domainList := payloads.DomainList{}
+			req, err := http.NewRequest("GET", "http://foo.com/bar?order_by=name", nil)
+			Expect(err).NotTo(HaveOccurred())
+			err = validator.DecodeAndValidateURLValues(req, &domainList)
+
+			Expect(err).To(MatchError(ContainSubstring("value must be one of")))
+		})
 	})
 
 	Describe("ToMessage", func() {
@@ -0,0 +1,76 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("EnvVarGroupPatch", func() {
+	var (
+		payload        payloads.EnvVarGroupPatch
+		decodedPayload *payloads.EnvVarGroupPatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.EnvVarGroupPatch{
+			Var: map[string]*string{
+				"foo": tools.PtrTo("bar"),
+				"baz": nil,
+			},
+		}
+
+		decodedPayload = new(payloads.EnvVarGroupPatch)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	When("var is not set", func() {
+		BeforeEach(func() {
+			payload.Var = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "var cannot be blank")
+		})
+	})
+
+	When("it contains a 'PORT' key", func() {
+		BeforeEach(func() {
+			payload.Var["PORT"] = tools.PtrTo("2222")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "value PORT is not allowed")
+		})
+	})
+
+	When("it contains a key with prefix 'VCAP_'", func() {
+		BeforeEach(func() {
+			payload.Var["VCAP_foo"] = tools.PtrTo("bar")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "prefix VCAP_ is not allowed")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			message := payload.ToMessage("running")
+			Expect(message.Name).To(Equal("running"))
+			Expect(message.Var).To(Equal(payload.Var))
+		})
+	})
+})
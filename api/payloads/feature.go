@@ -0,0 +1,15 @@
+package payloads
+
+import (
+	jellidation "github.com/jellydator/validation"
+)
+
+type FeaturePatch struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (p FeaturePatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Enabled, jellidation.NotNil),
+	)
+}
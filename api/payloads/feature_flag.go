@@ -0,0 +1,18 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type FeatureFlagPatch struct {
+	Enabled            *bool   `json:"enabled"`
+	CustomErrorMessage *string `json:"custom_error_message"`
+}
+
+func (p FeatureFlagPatch) ToMessage(name string) repositories.PatchFeatureFlagMessage {
+	return repositories.PatchFeatureFlagMessage{
+		Name:               name,
+		Enabled:            p.Enabled,
+		CustomErrorMessage: p.CustomErrorMessage,
+	}
+}
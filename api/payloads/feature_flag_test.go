@@ -0,0 +1,45 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("FeatureFlagPatch", func() {
+	var (
+		payload        payloads.FeatureFlagPatch
+		decodedPayload *payloads.FeatureFlagPatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.FeatureFlagPatch{
+			Enabled:            tools.PtrTo(false),
+			CustomErrorMessage: tools.PtrTo("not today"),
+		}
+
+		decodedPayload = new(payloads.FeatureFlagPatch)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			message := payload.ToMessage("task_creation")
+			Expect(message.Name).To(Equal("task_creation"))
+			Expect(message.Enabled).To(Equal(tools.PtrTo(false)))
+			Expect(message.CustomErrorMessage).To(Equal(tools.PtrTo("not today")))
+		})
+	})
+})
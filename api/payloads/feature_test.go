@@ -0,0 +1,42 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FeaturePatch", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.FeaturePatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.FeaturePatch)
+		requestBody = map[string]any{
+			"enabled": false,
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.Enabled).To(Equal(tools.PtrTo(false)))
+	})
+
+	When("enabled is missing", func() {
+		BeforeEach(func() {
+			delete(requestBody, "enabled")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "enabled is required")
+		})
+	})
+})
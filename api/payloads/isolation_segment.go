@@ -0,0 +1,109 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type IsolationSegmentCreate struct {
+	Name string `json:"name"`
+}
+
+func (c IsolationSegmentCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+	)
+}
+
+func (c IsolationSegmentCreate) ToMessage() repositories.CreateIsolationSegmentMessage {
+	return repositories.CreateIsolationSegmentMessage{
+		Name: c.Name,
+	}
+}
+
+type IsolationSegmentPatch struct {
+	Name *string `json:"name"`
+}
+
+func (p IsolationSegmentPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Name, jellidation.NilOrNotEmpty),
+	)
+}
+
+func (p IsolationSegmentPatch) ToMessage(guid string) repositories.PatchIsolationSegmentMessage {
+	return repositories.PatchIsolationSegmentMessage{
+		GUID: guid,
+		Name: p.Name,
+	}
+}
+
+type IsolationSegmentEntitle struct {
+	ToManyRelationship
+}
+
+func (e IsolationSegmentEntitle) ToMessage(guid string) repositories.EntitleIsolationSegmentMessage {
+	return repositories.EntitleIsolationSegmentMessage{
+		GUID:              guid,
+		OrganizationGUIDs: e.GUIDs(),
+	}
+}
+
+type IsolationSegmentList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+	Pagination
+}
+
+func (l IsolationSegmentList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *IsolationSegmentList) ToMessage() repositories.ListIsolationSegmentsMessage {
+	return repositories.ListIsolationSegmentsMessage{
+		GUIDs:             parse.ArrayParam(l.GUIDs),
+		Names:             parse.ArrayParam(l.Names),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+	}
+}
+
+func (l *IsolationSegmentList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "per_page", "page"}
+}
+
+func (l *IsolationSegmentList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+// SpaceIsolationSegmentPatch assigns an isolation segment to a space. Unlike
+// Relationship, the data may be null, which unassigns the isolation segment
+type SpaceIsolationSegmentPatch struct {
+	Data *RelationshipData `json:"data"`
+}
+
+func (p SpaceIsolationSegmentPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Data),
+	)
+}
+
+func (p SpaceIsolationSegmentPatch) ToMessage(spaceGUID string) repositories.AssignSpaceIsolationSegmentMessage {
+	message := repositories.AssignSpaceIsolationSegmentMessage{
+		SpaceGUID: spaceGUID,
+	}
+
+	if p.Data != nil {
+		message.IsolationSegmentGUID = p.Data.GUID
+	}
+
+	return message
+}
@@ -0,0 +1,198 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsolationSegmentCreate", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.IsolationSegmentCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentCreate)
+		requestBody = map[string]any{
+			"name": "my-segment",
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage()).To(Equal(repositories.CreateIsolationSegmentMessage{
+			Name: "my-segment",
+		}))
+	})
+
+	When("the name is missing", func() {
+		BeforeEach(func() {
+			delete(requestBody, "name")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentPatch", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.IsolationSegmentPatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentPatch)
+		requestBody = map[string]any{
+			"name": "new-name",
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("returns a patch message", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("segment-guid")).To(Equal(repositories.PatchIsolationSegmentMessage{
+			GUID: "segment-guid",
+			Name: tools.PtrTo("new-name"),
+		}))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			requestBody["name"] = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentEntitle", func() {
+	var (
+		decodedPayload *payloads.IsolationSegmentEntitle
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentEntitle)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(map[string]any{
+			"data": []map[string]any{{"guid": "org-1"}, {"guid": "org-2"}},
+		}), decodedPayload)
+	})
+
+	It("returns an entitle message", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("segment-guid")).To(Equal(repositories.EntitleIsolationSegmentMessage{
+			GUID:              "segment-guid",
+			OrganizationGUIDs: []string{"org-1", "org-2"},
+		}))
+	})
+})
+
+var _ = Describe("IsolationSegmentList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedIsolationSegmentList payloads.IsolationSegmentList) {
+				actualIsolationSegmentList, decodeErr := decodeQuery[payloads.IsolationSegmentList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualIsolationSegmentList).To(Equal(expectedIsolationSegmentList))
+			},
+			Entry("guids", "guids=g1,g2", payloads.IsolationSegmentList{GUIDs: "g1,g2"}),
+			Entry("names", "names=n1,n2", payloads.IsolationSegmentList{Names: "n1,n2"}),
+			Entry("organization_guids", "organization_guids=o1,o2", payloads.IsolationSegmentList{OrganizationGUIDs: "o1,o2"}),
+			Entry("page and per_page", "page=2&per_page=10", payloads.IsolationSegmentList{Pagination: payloads.Pagination{Page: 2, PerPage: 10}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.IsolationSegmentList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+			Entry("per_page too large", "per_page=5001", "per_page: must be no greater than 5000"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			isolationSegmentList := payloads.IsolationSegmentList{
+				GUIDs:             "g1,g2",
+				Names:             "n1,n2",
+				OrganizationGUIDs: "o1,o2",
+			}
+			Expect(isolationSegmentList.ToMessage()).To(Equal(repositories.ListIsolationSegmentsMessage{
+				GUIDs:             []string{"g1", "g2"},
+				Names:             []string{"n1", "n2"},
+				OrganizationGUIDs: []string{"o1", "o2"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("SpaceIsolationSegmentPatch", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.SpaceIsolationSegmentPatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SpaceIsolationSegmentPatch)
+		requestBody = map[string]any{
+			"data": map[string]any{"guid": "segment-guid"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("returns an assign message", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("space-guid")).To(Equal(repositories.AssignSpaceIsolationSegmentMessage{
+			SpaceGUID:            "space-guid",
+			IsolationSegmentGUID: "segment-guid",
+		}))
+	})
+
+	When("the data is null", func() {
+		BeforeEach(func() {
+			requestBody["data"] = nil
+		})
+
+		It("returns a message unassigning the isolation segment", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.ToMessage("space-guid")).To(Equal(repositories.AssignSpaceIsolationSegmentMessage{
+				SpaceGUID: "space-guid",
+			}))
+		})
+	})
+
+	When("the guid is empty", func() {
+		BeforeEach(func() {
+			requestBody["data"] = map[string]any{"guid": ""}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+})
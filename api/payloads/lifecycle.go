@@ -2,7 +2,9 @@ package payloads
 
 import (
 	"fmt"
+	"slices"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	jellidation "github.com/jellydator/validation"
 )
@@ -38,6 +40,17 @@ func (l Lifecycle) Validate() error {
 	)
 }
 
+// ValidateStack checks that the stack of a buildpack lifecycle is one of the
+// stacks supported by the builder. The stacks are only known at runtime, which
+// is why this is not part of Validate
+func (l Lifecycle) ValidateStack(stacks []string) error {
+	if l.Type != "buildpack" || l.Data == nil {
+		return nil
+	}
+
+	return validateStack(l.Data.Stack, stacks)
+}
+
 type LifecycleData struct {
 	Buildpacks []string `json:"buildpacks,omitempty"`
 	Stack      string   `json:"stack,omitempty"`
@@ -69,3 +82,21 @@ type LifecycleDataPatch struct {
 	Buildpacks *[]string `json:"buildpacks"`
 	Stack      string    `json:"stack"`
 }
+
+// ValidateStack checks that the stack set by the patch, if any, is one of the
+// stacks supported by the builder
+func (p LifecyclePatch) ValidateStack(stacks []string) error {
+	if p.Data == nil {
+		return nil
+	}
+
+	return validateStack(p.Data.Stack, stacks)
+}
+
+func validateStack(stack string, stacks []string) error {
+	if stack == "" || slices.Contains(stacks, stack) {
+		return nil
+	}
+
+	return apierrors.NewUnprocessableEntityError(fmt.Errorf("stack %q is not one of %v", stack, stacks), "Stack must be an existing stack")
+}
@@ -119,6 +119,47 @@ var _ = Describe("Lifecycle", func() {
 		})
 	})
 
+	Describe("ValidateStack", func() {
+		var stacks []string
+
+		BeforeEach(func() {
+			stacks = []string{"baz", "qux"}
+			payload = payloads.Lifecycle{
+				Type: "buildpack",
+				Data: &payloads.LifecycleData{
+					Stack: "baz",
+				},
+			}
+		})
+
+		It("succeeds when the stack exists", func() {
+			Expect(payload.ValidateStack(stacks)).To(Succeed())
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				payload.Data.Stack = "unknown"
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(payload.ValidateStack(stacks), "Stack must be an existing stack")
+			})
+		})
+
+		When("the lifecycle is docker", func() {
+			BeforeEach(func() {
+				payload = payloads.Lifecycle{
+					Type: "docker",
+					Data: &payloads.LifecycleData{},
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(payload.ValidateStack(stacks)).To(Succeed())
+			})
+		})
+	})
+
 	Describe("unsupported lifecycle type", func() {
 		BeforeEach(func() {
 			payload = payloads.Lifecycle{
@@ -189,4 +230,24 @@ var _ = Describe("LifecyclePatch", func() {
 			Expect(decodedPayload.Type).To(BeEmpty())
 		})
 	})
+
+	Describe("ValidateStack", func() {
+		It("succeeds when the stack is not patched", func() {
+			Expect(payload.ValidateStack([]string{"baz"})).To(Succeed())
+		})
+
+		When("the stack is patched", func() {
+			BeforeEach(func() {
+				payload.Data.Stack = "baz"
+			})
+
+			It("succeeds when the stack exists", func() {
+				Expect(payload.ValidateStack([]string{"baz"})).To(Succeed())
+			})
+
+			It("returns an error when the stack does not exist", func() {
+				expectUnprocessableEntityError(payload.ValidateStack([]string{"qux"}), "Stack must be an existing stack")
+			})
+		})
+	})
 })
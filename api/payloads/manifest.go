@@ -46,6 +46,7 @@ type ManifestApplication struct {
 	Metadata  MetadataPatch                `json:"metadata" yaml:"metadata"`
 	Services  []ManifestApplicationService `json:"services" yaml:"services"`
 	Docker    any                          `json:"docker,omitempty" yaml:"docker,omitempty"`
+	Sidecars  []ManifestApplicationSidecar `json:"sidecars" yaml:"sidecars"`
 }
 
 // TODO: Why is kebab-case used everywhere anyway and we have a deprecated field that claims to use
@@ -67,6 +68,13 @@ type ManifestApplicationProcess struct {
 	Timeout                      *int64  `json:"timeout" yaml:"timeout"`
 }
 
+type ManifestApplicationSidecar struct {
+	Name         string   `json:"name" yaml:"name"`
+	Command      string   `json:"command" yaml:"command"`
+	ProcessTypes []string `json:"process_types" yaml:"process_types"`
+	Memory       *string  `json:"memory" yaml:"memory"`
+}
+
 type ManifestApplicationService struct {
 	Name        string  `json:"name" yaml:"name"`
 	BindingName *string `json:"binding_name" yaml:"binding_name"`
@@ -88,7 +96,8 @@ func (s *ManifestApplicationService) UnmarshalYAML(value *yaml.Node) error {
 }
 
 type ManifestRoute struct {
-	Route *string `json:"route" yaml:"route"`
+	Route    *string `json:"route" yaml:"route"`
+	Protocol *string `json:"protocol" yaml:"protocol"`
 }
 
 func (a ManifestApplication) ToAppCreateMessage(spaceGUID string) repositories.CreateAppMessage {
@@ -215,6 +224,38 @@ func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID
 	return message
 }
 
+func (s ManifestApplicationSidecar) ToSidecarCreateMessage(appGUID, spaceGUID string) repositories.CreateSidecarMessage {
+	return repositories.CreateSidecarMessage{
+		AppGUID:      appGUID,
+		SpaceGUID:    spaceGUID,
+		Name:         s.Name,
+		Command:      s.Command,
+		ProcessTypes: s.ProcessTypes,
+		MemoryMB:     s.memoryMB(),
+	}
+}
+
+func (s ManifestApplicationSidecar) ToSidecarPatchMessage(sidecar repositories.SidecarRecord) repositories.PatchSidecarMessage {
+	return repositories.PatchSidecarMessage{
+		GUID:         sidecar.GUID,
+		AppGUID:      sidecar.AppGUID,
+		SpaceGUID:    sidecar.SpaceGUID,
+		Command:      tools.PtrTo(s.Command),
+		ProcessTypes: s.ProcessTypes,
+		MemoryMB:     s.memoryMB(),
+	}
+}
+
+func (s ManifestApplicationSidecar) memoryMB() *int64 {
+	if s.Memory == nil {
+		return nil
+	}
+
+	// error ignored intentionally, since the manifest yaml is validated in handlers
+	memoryMB, _ := bytefmt.ToMegabytes(*s.Memory)
+	return tools.PtrTo(int64(memoryMB))
+}
+
 func (m Manifest) Validate() error {
 	return validation.ValidateStruct(&m,
 		validation.Field(&m.Applications))
@@ -233,6 +274,7 @@ func (a ManifestApplication) Validate() error {
 		validation.Field(&a.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.Processes),
 		validation.Field(&a.Routes),
+		validation.Field(&a.Sidecars),
 		validation.Field(&a.Docker, validation.When(len(a.Buildpacks) > 0 || a.Buildpack != nil,
 			validation.Nil.Error("must be blank when buildpacks are specified"),
 		)),
@@ -257,7 +299,18 @@ func (m ManifestRoute) Validate() error {
 		`^(?:https?://|tcp://)?(?:(?:[\w-]+\.)|(?:[*]\.))+\w+(?:\:\d+)?(?:/.*)*(?:\.\w+)?$`,
 	)
 	return validation.ValidateStruct(&m,
-		validation.Field(&m.Route, validation.Match(routeRegex).Error("is not a valid route")))
+		validation.Field(&m.Route, validation.Match(routeRegex).Error("is not a valid route")),
+		validation.Field(&m.Protocol, validation.In("http1", "http2").Error("value must be one of: http1, http2")),
+	)
+}
+
+func (s ManifestApplicationSidecar) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, validation.Required),
+		validation.Field(&s.Command, validation.Required),
+		validation.Field(&s.ProcessTypes, validation.Required, validation.Each(validation.Required)),
+		validation.Field(&s.Memory, validation.By(validateAmountWithUnit)),
+	)
 }
 
 func (s ManifestApplicationService) Validate() error {
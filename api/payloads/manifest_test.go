@@ -738,6 +738,131 @@ var _ = Describe("Manifest payload", func() {
 				expectUnprocessableEntityError(validateErr, "route is not a valid route")
 			})
 		})
+
+		When("the protocol is http2", func() {
+			BeforeEach(func() {
+				testManifestRoute.Protocol = tools.PtrTo("http2")
+			})
+
+			It("validates the struct", func() {
+				Expect(validateErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the protocol is not supported", func() {
+			BeforeEach(func() {
+				testManifestRoute.Protocol = tools.PtrTo("tcp")
+			})
+
+			It("returns a validation error", func() {
+				expectUnprocessableEntityError(validateErr, "protocol value must be one of: http1, http2")
+			})
+		})
+	})
+
+	Describe("ManifestApplicationSidecar", func() {
+		var testManifestSidecar ManifestApplicationSidecar
+
+		BeforeEach(func() {
+			testManifestSidecar = ManifestApplicationSidecar{
+				Name:         "log-shipper",
+				Command:      "ship-logs",
+				ProcessTypes: []string{"web", "worker"},
+				Memory:       tools.PtrTo("64M"),
+			}
+		})
+
+		Describe("Validate", func() {
+			var validateErr error
+
+			JustBeforeEach(func() {
+				validateErr = validator.DecodeAndValidateYAMLPayload(createYAMLRequest(testManifestSidecar), &ManifestApplicationSidecar{})
+			})
+
+			It("validates the struct", func() {
+				Expect(validateErr).NotTo(HaveOccurred())
+			})
+
+			When("the name is not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Name = ""
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "name cannot be blank")
+				})
+			})
+
+			When("the command is not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Command = ""
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "command cannot be blank")
+				})
+			})
+
+			When("the process types are not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.ProcessTypes = nil
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "process_types cannot be blank")
+				})
+			})
+
+			When("the memory doesn't supply a unit", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Memory = tools.PtrTo("64")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "memory must use a supported unit (B, K, KB, M, m, MB, mb, G, g, GB, gb, T, t, TB or tb)")
+				})
+			})
+		})
+
+		Describe("ToSidecarCreateMessage", func() {
+			It("converts the memory to megabytes", func() {
+				Expect(testManifestSidecar.ToSidecarCreateMessage("app-guid", "space-guid")).To(Equal(repositories.CreateSidecarMessage{
+					AppGUID:      "app-guid",
+					SpaceGUID:    "space-guid",
+					Name:         "log-shipper",
+					Command:      "ship-logs",
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     tools.PtrTo[int64](64),
+				}))
+			})
+
+			When("the memory is not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Memory = nil
+				})
+
+				It("leaves the memory unset", func() {
+					Expect(testManifestSidecar.ToSidecarCreateMessage("app-guid", "space-guid").MemoryMB).To(BeNil())
+				})
+			})
+		})
+
+		Describe("ToSidecarPatchMessage", func() {
+			It("updates the existing sidecar", func() {
+				Expect(testManifestSidecar.ToSidecarPatchMessage(repositories.SidecarRecord{
+					GUID:      "sidecar-guid",
+					AppGUID:   "app-guid",
+					SpaceGUID: "space-guid",
+				})).To(Equal(repositories.PatchSidecarMessage{
+					GUID:         "sidecar-guid",
+					AppGUID:      "app-guid",
+					SpaceGUID:    "space-guid",
+					Command:      tools.PtrTo("ship-logs"),
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     tools.PtrTo[int64](64),
+				}))
+			})
+		})
 	})
 
 	Describe("ManifestApplicationService", func() {
@@ -0,0 +1,113 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	jellidation "github.com/jellydator/validation"
+)
+
+// NetworkPolicies is the body of both the create and the delete requests of
+// the policy API
+type NetworkPolicies struct {
+	Policies []NetworkPolicy `json:"policies"`
+}
+
+func (p NetworkPolicies) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Policies, jellidation.Required),
+	)
+}
+
+type NetworkPolicy struct {
+	Source      NetworkPolicySource      `json:"source"`
+	Destination NetworkPolicyDestination `json:"destination"`
+}
+
+func (p NetworkPolicy) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Source),
+		jellidation.Field(&p.Destination),
+	)
+}
+
+type NetworkPolicySource struct {
+	ID string `json:"id"`
+}
+
+func (s NetworkPolicySource) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.ID, jellidation.Required),
+	)
+}
+
+type NetworkPolicyDestination struct {
+	ID       string             `json:"id"`
+	Protocol string             `json:"protocol"`
+	Ports    NetworkPolicyPorts `json:"ports"`
+}
+
+func (d NetworkPolicyDestination) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.ID, jellidation.Required),
+		jellidation.Field(&d.Protocol, jellidation.Required, validation.OneOf(korifiv1alpha1.NetworkPolicyProtocolTCP, korifiv1alpha1.NetworkPolicyProtocolUDP)),
+		jellidation.Field(&d.Ports),
+	)
+}
+
+type NetworkPolicyPorts struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+func (p NetworkPolicyPorts) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Start, jellidation.Required, jellidation.Min(int32(1)), jellidation.Max(int32(65535))),
+		jellidation.Field(&p.End, jellidation.Required, jellidation.Min(p.Start).Error("must be no less than start"), jellidation.Max(int32(65535))),
+	)
+}
+
+func (p NetworkPolicy) ToCreateMessage(sourceSpaceGUID, destinationSpaceGUID string) repositories.CreateNetworkPolicyMessage {
+	return repositories.CreateNetworkPolicyMessage{
+		SourceAppGUID:        p.Source.ID,
+		SourceSpaceGUID:      sourceSpaceGUID,
+		DestinationAppGUID:   p.Destination.ID,
+		DestinationSpaceGUID: destinationSpaceGUID,
+		Protocol:             p.Destination.Protocol,
+		StartPort:            p.Destination.Ports.Start,
+		EndPort:              p.Destination.Ports.End,
+	}
+}
+
+func (p NetworkPolicy) ToDeleteMessage(destinationSpaceGUID string) repositories.DeleteNetworkPolicyMessage {
+	return repositories.DeleteNetworkPolicyMessage{
+		SourceAppGUID:        p.Source.ID,
+		DestinationAppGUID:   p.Destination.ID,
+		DestinationSpaceGUID: destinationSpaceGUID,
+		Protocol:             p.Destination.Protocol,
+		StartPort:            p.Destination.Ports.Start,
+		EndPort:              p.Destination.Ports.End,
+	}
+}
+
+type NetworkPolicyList struct {
+	IDs string
+}
+
+func (l NetworkPolicyList) SupportedKeys() []string {
+	return []string{"id"}
+}
+
+func (l *NetworkPolicyList) DecodeFromURLValues(values url.Values) error {
+	l.IDs = values.Get("id")
+	return nil
+}
+
+func (l NetworkPolicyList) ToMessage() repositories.ListNetworkPoliciesMessage {
+	return repositories.ListNetworkPoliciesMessage{
+		AppGUIDs: parse.ArrayParam(l.IDs),
+	}
+}
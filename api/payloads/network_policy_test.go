@@ -0,0 +1,133 @@
+package payloads_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicies", func() {
+	var (
+		policy         map[string]any
+		requestBody    map[string]any
+		decodedPayload *payloads.NetworkPolicies
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.NetworkPolicies)
+		policy = map[string]any{
+			"source": map[string]any{"id": "source-app"},
+			"destination": map[string]any{
+				"id":       "destination-app",
+				"protocol": "tcp",
+				"ports":    map[string]any{"start": 8080, "end": 8090},
+			},
+		}
+		requestBody = map[string]any{
+			"policies": []any{policy},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.Policies).To(HaveLen(1))
+		Expect(decodedPayload.Policies[0].ToCreateMessage("source-space", "destination-space")).To(Equal(repositories.CreateNetworkPolicyMessage{
+			SourceAppGUID:        "source-app",
+			SourceSpaceGUID:      "source-space",
+			DestinationAppGUID:   "destination-app",
+			DestinationSpaceGUID: "destination-space",
+			Protocol:             "tcp",
+			StartPort:            8080,
+			EndPort:              8090,
+		}))
+		Expect(decodedPayload.Policies[0].ToDeleteMessage("destination-space")).To(Equal(repositories.DeleteNetworkPolicyMessage{
+			SourceAppGUID:        "source-app",
+			DestinationAppGUID:   "destination-app",
+			DestinationSpaceGUID: "destination-space",
+			Protocol:             "tcp",
+			StartPort:            8080,
+			EndPort:              8090,
+		}))
+	})
+
+	When("there are no policies", func() {
+		BeforeEach(func() {
+			requestBody["policies"] = []any{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "policies cannot be blank")
+		})
+	})
+
+	When("the source id is missing", func() {
+		BeforeEach(func() {
+			policy["source"] = map[string]any{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "source.id cannot be blank")
+		})
+	})
+
+	When("the destination id is missing", func() {
+		BeforeEach(func() {
+			delete(policy["destination"].(map[string]any), "id")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "destination.id cannot be blank")
+		})
+	})
+
+	When("the protocol is not supported", func() {
+		BeforeEach(func() {
+			policy["destination"].(map[string]any)["protocol"] = "icmp"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "destination.protocol value must be one of: tcp, udp")
+		})
+	})
+
+	When("the start port is out of range", func() {
+		BeforeEach(func() {
+			policy["destination"].(map[string]any)["ports"] = map[string]any{"start": 70000, "end": 70000}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "start must be no greater than 65535")
+		})
+	})
+
+	When("the end port is less than the start port", func() {
+		BeforeEach(func() {
+			policy["destination"].(map[string]any)["ports"] = map[string]any{"start": 8090, "end": 8080}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "end must be no less than start")
+		})
+	})
+})
+
+var _ = Describe("NetworkPolicyList", func() {
+	It("decodes the app guids", func() {
+		req, err := http.NewRequest("GET", "http://foo.com/bar?id=app-1,app-2", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		actualPayload := new(payloads.NetworkPolicyList)
+		Expect(validator.DecodeAndValidateURLValues(req, actualPayload)).To(Succeed())
+		Expect(actualPayload.ToMessage()).To(Equal(repositories.ListNetworkPoliciesMessage{
+			AppGUIDs: []string{"app-1", "app-2"},
+		}))
+	})
+})
@@ -4,8 +4,9 @@ import (
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
-	"github.com/jellydator/validation"
+	jellidation "github.com/jellydator/validation"
 )
 
 type OrgCreate struct {
@@ -15,8 +16,8 @@ type OrgCreate struct {
 }
 
 func (p OrgCreate) Validate() error {
-	return validation.ValidateStruct(&p,
-		validation.Field(&p.Name, validation.Required),
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Name, jellidation.Required),
 	)
 }
 
@@ -34,8 +35,8 @@ type OrgPatch struct {
 }
 
 func (p OrgPatch) Validate() error {
-	return validation.ValidateStruct(&p,
-		validation.Field(&p.Metadata),
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Metadata),
 	)
 }
 
@@ -50,7 +51,16 @@ func (p OrgPatch) ToMessage(orgGUID string) repositories.PatchOrgMetadataMessage
 }
 
 type OrgList struct {
-	Names string
+	Names   string
+	OrderBy string
+	Pagination
+}
+
+func (d OrgList) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "name")),
+		jellidation.Field(&d.Pagination),
+	)
 }
 
 func (d *OrgList) ToMessage() repositories.ListOrgsMessage {
@@ -65,5 +75,6 @@ func (d *OrgList) SupportedKeys() []string {
 
 func (d *OrgList) DecodeFromURLValues(values url.Values) error {
 	d.Names = values.Get("names")
-	return nil
+	d.OrderBy = values.Get("order_by")
+	return d.Pagination.DecodeFromURLValues(values)
 }
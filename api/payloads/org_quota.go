@@ -0,0 +1,109 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type OrgQuotaCreate struct {
+	Name string `json:"name"`
+	QuotaLimits
+	Relationships *OrgQuotaRelationships `json:"relationships"`
+}
+
+func (c OrgQuotaCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.QuotaLimits),
+		jellidation.Field(&c.Relationships),
+	)
+}
+
+func (c OrgQuotaCreate) ToMessage() repositories.CreateOrgQuotaMessage {
+	message := repositories.CreateOrgQuotaMessage{
+		Name:   c.Name,
+		Limits: c.QuotaLimits.toQuotaLimits(),
+	}
+
+	if c.Relationships != nil {
+		message.OrganizationGUIDs = c.Relationships.Organizations.GUIDs()
+	}
+
+	return message
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+func (r OrgQuotaRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Organizations),
+	)
+}
+
+type OrgQuotaPatch struct {
+	Name *string `json:"name"`
+	QuotaLimits
+}
+
+func (p OrgQuotaPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&p.QuotaLimits),
+	)
+}
+
+func (p OrgQuotaPatch) ToMessage(guid string) repositories.PatchOrgQuotaMessage {
+	return repositories.PatchOrgQuotaMessage{
+		GUID:   guid,
+		Name:   p.Name,
+		Limits: p.QuotaLimits.toQuotaLimitsPatch(),
+	}
+}
+
+type OrgQuotaApply struct {
+	ToManyRelationship
+}
+
+func (a OrgQuotaApply) ToMessage(guid string) repositories.ApplyOrgQuotaMessage {
+	return repositories.ApplyOrgQuotaMessage{
+		GUID:              guid,
+		OrganizationGUIDs: a.GUIDs(),
+	}
+}
+
+type OrgQuotaList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+	Pagination
+}
+
+func (l OrgQuotaList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *OrgQuotaList) ToMessage() repositories.ListOrgQuotasMessage {
+	return repositories.ListOrgQuotasMessage{
+		GUIDs:             parse.ArrayParam(l.GUIDs),
+		Names:             parse.ArrayParam(l.Names),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+	}
+}
+
+func (l *OrgQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "per_page", "page"}
+}
+
+func (l *OrgQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	return l.Pagination.DecodeFromURLValues(values)
+}
@@ -0,0 +1,225 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OrgQuotaCreate", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.OrgQuotaCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.OrgQuotaCreate)
+		requestBody = map[string]any{
+			"name": "my-quota",
+			"apps": map[string]any{
+				"total_memory_in_mb":       1024,
+				"per_process_memory_in_mb": nil,
+				"total_instances":          10,
+				"per_app_tasks":            5,
+			},
+			"services": map[string]any{
+				"paid_services_allowed":   true,
+				"total_service_instances": 3,
+			},
+			"routes": map[string]any{
+				"total_routes": 4,
+			},
+			"relationships": map[string]any{
+				"organizations": map[string]any{
+					"data": []map[string]any{{"guid": "org-guid"}},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage()).To(Equal(repositories.CreateOrgQuotaMessage{
+			Name: "my-quota",
+			Limits: repositories.QuotaLimits{
+				TotalMemoryMB:         tools.PtrTo[int64](1024),
+				TotalAppInstances:     tools.PtrTo[int64](10),
+				AppTasks:              tools.PtrTo[int64](5),
+				TotalServiceInstances: tools.PtrTo[int64](3),
+				TotalRoutes:           tools.PtrTo[int64](4),
+			},
+			OrganizationGUIDs: []string{"org-guid"},
+		}))
+	})
+
+	When("the name is missing", func() {
+		BeforeEach(func() {
+			delete(requestBody, "name")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("a limit is negative", func() {
+		BeforeEach(func() {
+			requestBody["routes"] = map[string]any{"total_routes": -1}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be no less than 0")
+		})
+	})
+
+	When("an unsupported limit is set", func() {
+		BeforeEach(func() {
+			requestBody["routes"] = map[string]any{"total_reserved_ports": 1}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, `unknown field "total_reserved_ports"`)
+		})
+	})
+
+	When("the organizations relationship has no data", func() {
+		BeforeEach(func() {
+			requestBody["relationships"] = map[string]any{
+				"organizations": map[string]any{},
+			}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data is required")
+		})
+	})
+
+	When("there are no relationships", func() {
+		BeforeEach(func() {
+			delete(requestBody, "relationships")
+		})
+
+		It("does not apply the quota to any organization", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.ToMessage().OrganizationGUIDs).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaPatch", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.OrgQuotaPatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.OrgQuotaPatch)
+		requestBody = map[string]any{
+			"name": "new-name",
+			"apps": map[string]any{
+				"total_memory_in_mb": 2048,
+				"total_instances":    nil,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("only patches the limits present in the request", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("quota-guid")).To(Equal(repositories.PatchOrgQuotaMessage{
+			GUID: "quota-guid",
+			Name: tools.PtrTo("new-name"),
+			Limits: repositories.QuotaLimitsPatch{
+				TotalMemoryMB:     &repositories.QuotaLimitPatch{Value: tools.PtrTo[int64](2048)},
+				TotalAppInstances: &repositories.QuotaLimitPatch{},
+			},
+		}))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			requestBody["name"] = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaApply", func() {
+	var (
+		decodedPayload *payloads.OrgQuotaApply
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.OrgQuotaApply)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(map[string]any{
+			"data": []map[string]any{{"guid": "org-1"}, {"guid": "org-2"}},
+		}), decodedPayload)
+	})
+
+	It("returns an apply message", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("quota-guid")).To(Equal(repositories.ApplyOrgQuotaMessage{
+			GUID:              "quota-guid",
+			OrganizationGUIDs: []string{"org-1", "org-2"},
+		}))
+	})
+})
+
+var _ = Describe("OrgQuotaList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedOrgQuotaList payloads.OrgQuotaList) {
+				actualOrgQuotaList, decodeErr := decodeQuery[payloads.OrgQuotaList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualOrgQuotaList).To(Equal(expectedOrgQuotaList))
+			},
+			Entry("guids", "guids=g1,g2", payloads.OrgQuotaList{GUIDs: "g1,g2"}),
+			Entry("names", "names=n1,n2", payloads.OrgQuotaList{Names: "n1,n2"}),
+			Entry("organization_guids", "organization_guids=o1,o2", payloads.OrgQuotaList{OrganizationGUIDs: "o1,o2"}),
+			Entry("page and per_page", "page=2&per_page=10", payloads.OrgQuotaList{Pagination: payloads.Pagination{Page: 2, PerPage: 10}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.OrgQuotaList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+			Entry("per_page too large", "per_page=5001", "per_page: must be no greater than 5000"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			orgQuotaList := payloads.OrgQuotaList{
+				GUIDs:             "g1,g2",
+				Names:             "n1,n2",
+				OrganizationGUIDs: "o1,o2",
+			}
+			Expect(orgQuotaList.ToMessage()).To(Equal(repositories.ListOrgQuotasMessage{
+				GUIDs:             []string{"g1", "g2"},
+				Names:             []string{"n1", "n2"},
+				OrganizationGUIDs: []string{"o1", "o2"},
+			}))
+		})
+	})
+})
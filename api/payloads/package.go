@@ -94,6 +94,7 @@ type PackageList struct {
 	AppGUIDs string
 	States   string
 	OrderBy  string
+	Pagination
 }
 
 func (p *PackageList) ToMessage() repositories.ListPackagesMessage {
@@ -113,7 +114,7 @@ func (p *PackageList) DecodeFromURLValues(values url.Values) error {
 	p.AppGUIDs = values.Get("app_guids")
 	p.States = values.Get("states")
 	p.OrderBy = values.Get("order_by")
-	return nil
+	return p.Pagination.DecodeFromURLValues(values)
 }
 
 func (p PackageList) Validate() error {
@@ -125,10 +126,13 @@ func (p PackageList) Validate() error {
 
 	return jellidation.ValidateStruct(&p,
 		jellidation.Field(&p.OrderBy, validation.OneOf(allowed...)),
+		jellidation.Field(&p.Pagination),
 	)
 }
 
-type PackageListDroplets struct{}
+type PackageListDroplets struct {
+	Pagination
+}
 
 func (p *PackageListDroplets) ToMessage(packageGUIDs []string) repositories.ListDropletsMessage {
 	return repositories.ListDropletsMessage{
@@ -141,5 +145,5 @@ func (p *PackageListDroplets) SupportedKeys() []string {
 }
 
 func (p *PackageListDroplets) DecodeFromURLValues(values url.Values) error {
-	return nil
+	return p.Pagination.DecodeFromURLValues(values)
 }
@@ -0,0 +1,77 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+const (
+	DefaultPage    = 1
+	DefaultPerPage = 50
+	MaxPerPage     = 5000
+)
+
+// Pagination holds the page and per_page query parameters supported by all
+// list endpoints. It is meant to be embedded in list payloads, so that
+// validation errors are reported against the query parameter names.
+type Pagination struct {
+	Page    int64 `json:"page"`
+	PerPage int64 `json:"per_page"`
+}
+
+func (p *Pagination) DecodeFromURLValues(values url.Values) error {
+	var err error
+	if p.Page, err = getInt(values, "page"); err != nil {
+		return err
+	}
+	if p.PerPage, err = getInt(values, "per_page"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p Pagination) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Page, jellidation.Min(int64(1))),
+		jellidation.Field(&p.PerPage, jellidation.Min(int64(1)), jellidation.Max(int64(MaxPerPage))),
+	)
+}
+
+// PageOrDefault returns the requested page, or the first page when none was requested
+func (p Pagination) PageOrDefault() int {
+	if p.Page < 1 {
+		return DefaultPage
+	}
+	return int(p.Page)
+}
+
+// PerPageOrDefault returns the requested page size, capped to MaxPerPage, or
+// DefaultPerPage when none was requested
+func (p Pagination) PerPageOrDefault() int {
+	if p.PerPage < 1 {
+		return DefaultPerPage
+	}
+	return int(min(p.PerPage, MaxPerPage))
+}
+
+// ToMessage selects the requested page, for repositories that paginate lists
+// while listing them
+func (p Pagination) ToMessage() repositories.Pagination {
+	return repositories.Pagination{
+		Page:    p.PageOrDefault(),
+		PerPage: p.PerPageOrDefault(),
+	}
+}
+
+// orderedPagination selects the page the repository returns. Lists ordered by
+// order_by are sorted and paginated by the handlers, so the repository has to
+// return all of their records.
+func orderedPagination(p Pagination, orderBy string) repositories.Pagination {
+	if orderBy != "" {
+		return repositories.Pagination{}
+	}
+
+	return p.ToMessage()
+}
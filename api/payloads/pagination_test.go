@@ -0,0 +1,53 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pagination", func() {
+	DescribeTable("valid query",
+		func(query string, expectedPagination payloads.Pagination) {
+			actualTaskList, decodeErr := decodeQuery[payloads.TaskList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(actualTaskList.Pagination).To(Equal(expectedPagination))
+		},
+		Entry("no pagination", "", payloads.Pagination{}),
+		Entry("page", "page=3", payloads.Pagination{Page: 3}),
+		Entry("per_page", "per_page=5000", payloads.Pagination{PerPage: 5000}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.TaskList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("non-numeric page", "page=first", "invalid syntax"),
+		Entry("negative page", "page=-2", "page: must be no less than 1"),
+		Entry("negative per_page", "per_page=-2", "per_page: must be no less than 1"),
+		Entry("per_page too large", "per_page=5001", "per_page: must be no greater than 5000"),
+	)
+
+	Describe("defaults", func() {
+		It("defaults to the first page with 50 results per page", func() {
+			pagination := payloads.Pagination{}
+			Expect(pagination.PageOrDefault()).To(Equal(1))
+			Expect(pagination.PerPageOrDefault()).To(Equal(50))
+		})
+
+		It("returns the requested values", func() {
+			pagination := payloads.Pagination{Page: 4, PerPage: 20}
+			Expect(pagination.PageOrDefault()).To(Equal(4))
+			Expect(pagination.PerPageOrDefault()).To(Equal(20))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("selects the requested page, falling back to the defaults", func() {
+			Expect(payloads.Pagination{Page: 4}.ToMessage()).To(Equal(repositories.Pagination{Page: 4, PerPage: 50}))
+		})
+	})
+})
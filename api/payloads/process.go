@@ -4,6 +4,7 @@ import (
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
@@ -49,6 +50,15 @@ func (p ProcessScale) ToRecord() repositories.ProcessScaleValues {
 
 type ProcessList struct {
 	AppGUIDs string
+	OrderBy  string
+	Pagination
+}
+
+func (p ProcessList) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.OrderBy, payload_validation.OneOfOrderBy("created_at", "updated_at")),
+		validation.Field(&p.Pagination),
+	)
 }
 
 func (p *ProcessList) ToMessage() repositories.ListProcessesMessage {
@@ -58,12 +68,13 @@ func (p *ProcessList) ToMessage() repositories.ListProcessesMessage {
 }
 
 func (p *ProcessList) SupportedKeys() []string {
-	return []string{"app_guids", "per_page", "page"}
+	return []string{"app_guids", "order_by", "per_page", "page"}
 }
 
 func (p *ProcessList) DecodeFromURLValues(values url.Values) error {
 	p.AppGUIDs = values.Get("app_guids")
-	return nil
+	p.OrderBy = values.Get("order_by")
+	return p.Pagination.DecodeFromURLValues(values)
 }
 
 func (p ProcessPatch) ToProcessPatchMessage(processGUID, spaceGUID string) repositories.PatchProcessMessage {
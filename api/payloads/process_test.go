@@ -14,15 +14,25 @@ var _ = Describe("ProcessList", func() {
 	Describe("decodes from url values", func() {
 		It("succeeds", func() {
 			processList := payloads.ProcessList{}
-			req, err := http.NewRequest("GET", "http://foo.com/bar?app_guids=app_guid", nil)
+			req, err := http.NewRequest("GET", "http://foo.com/bar?app_guids=app_guid&order_by=updated_at", nil)
 			Expect(err).NotTo(HaveOccurred())
 			err = validator.DecodeAndValidateURLValues(req, &processList)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(processList).To(Equal(payloads.ProcessList{
 				AppGUIDs: "app_guid",
+				OrderBy:  "updated_at",
 			}))
 		})
+
+		It("rejects an invalid order_by", func() {
+			processList := payloads.ProcessList{}
+			req, err := http.NewRequest("GET", "http://foo.com/bar?order_by=name", nil)
+			Expect(err).NotTo(HaveOccurred())
+			err = validator.DecodeAndValidateURLValues(req, &processList)
+
+			Expect(err).To(MatchError(ContainSubstring("value must be one of")))
+		})
 	})
 })
 
@@ -0,0 +1,109 @@
+package payloads
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+// QuotaLimit is a quota limit in a request body. Setting the limit to null
+// removes it, i.e. makes the resource unlimited, while omitting it leaves the
+// limit unchanged on patch.
+type QuotaLimit struct {
+	IsSet bool
+	Value *int64
+}
+
+func (l *QuotaLimit) UnmarshalJSON(data []byte) error {
+	l.IsSet = true
+	return json.Unmarshal(data, &l.Value)
+}
+
+func (l QuotaLimit) Validate() error {
+	return jellidation.Validate(l.Value, jellidation.Min(int64(0)))
+}
+
+func (l QuotaLimit) toPatch() *repositories.QuotaLimitPatch {
+	if !l.IsSet {
+		return nil
+	}
+
+	return &repositories.QuotaLimitPatch{Value: l.Value}
+}
+
+type AppsQuota struct {
+	TotalMemoryInMB      QuotaLimit `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB QuotaLimit `json:"per_process_memory_in_mb"`
+	TotalInstances       QuotaLimit `json:"total_instances"`
+	PerAppTasks          QuotaLimit `json:"per_app_tasks"`
+}
+
+func (q AppsQuota) Validate() error {
+	return jellidation.ValidateStruct(&q,
+		jellidation.Field(&q.TotalMemoryInMB),
+		jellidation.Field(&q.PerProcessMemoryInMB),
+		jellidation.Field(&q.TotalInstances),
+		jellidation.Field(&q.PerAppTasks),
+	)
+}
+
+type ServicesQuota struct {
+	// PaidServicesAllowed is accepted for compatibility with the CF CLI, but
+	// service plans are never paid in Korifi
+	PaidServicesAllowed   *bool      `json:"paid_services_allowed"`
+	TotalServiceInstances QuotaLimit `json:"total_service_instances"`
+}
+
+func (q ServicesQuota) Validate() error {
+	return jellidation.ValidateStruct(&q,
+		jellidation.Field(&q.TotalServiceInstances),
+	)
+}
+
+type RoutesQuota struct {
+	TotalRoutes QuotaLimit `json:"total_routes"`
+}
+
+func (q RoutesQuota) Validate() error {
+	return jellidation.ValidateStruct(&q,
+		jellidation.Field(&q.TotalRoutes),
+	)
+}
+
+// QuotaLimits holds the limit sections shared by org and space quota payloads
+type QuotaLimits struct {
+	Apps     AppsQuota     `json:"apps"`
+	Services ServicesQuota `json:"services"`
+	Routes   RoutesQuota   `json:"routes"`
+}
+
+func (l QuotaLimits) toQuotaLimits() repositories.QuotaLimits {
+	return repositories.QuotaLimits{
+		TotalMemoryMB:         l.Apps.TotalMemoryInMB.Value,
+		InstanceMemoryMB:      l.Apps.PerProcessMemoryInMB.Value,
+		TotalAppInstances:     l.Apps.TotalInstances.Value,
+		AppTasks:              l.Apps.PerAppTasks.Value,
+		TotalServiceInstances: l.Services.TotalServiceInstances.Value,
+		TotalRoutes:           l.Routes.TotalRoutes.Value,
+	}
+}
+
+func (l QuotaLimits) toQuotaLimitsPatch() repositories.QuotaLimitsPatch {
+	return repositories.QuotaLimitsPatch{
+		TotalMemoryMB:         l.Apps.TotalMemoryInMB.toPatch(),
+		InstanceMemoryMB:      l.Apps.PerProcessMemoryInMB.toPatch(),
+		TotalAppInstances:     l.Apps.TotalInstances.toPatch(),
+		AppTasks:              l.Apps.PerAppTasks.toPatch(),
+		TotalServiceInstances: l.Services.TotalServiceInstances.toPatch(),
+		TotalRoutes:           l.Routes.TotalRoutes.toPatch(),
+	}
+}
+
+func (l QuotaLimits) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Apps),
+		jellidation.Field(&l.Services),
+		jellidation.Field(&l.Routes),
+	)
+}
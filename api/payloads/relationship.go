@@ -23,3 +23,21 @@ func (r RelationshipData) Validate() error {
 		validation.Field(&r.GUID, validation.Required),
 	)
 }
+
+type ToManyRelationship struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (r ToManyRelationship) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Data, validation.NotNil),
+	)
+}
+
+func (r ToManyRelationship) GUIDs() []string {
+	guids := make([]string, 0, len(r.Data))
+	for _, d := range r.Data {
+		guids = append(guids, d.GUID)
+	}
+	return guids
+}
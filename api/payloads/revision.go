@@ -0,0 +1,47 @@
+package payloads
+
+import (
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/jellydator/validation"
+)
+
+type RevisionList struct {
+	Versions []int
+	OrderBy  string
+	Pagination
+}
+
+func (l RevisionList) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.OrderBy, payload_validation.OneOfOrderBy("created_at", "updated_at", "version")),
+		validation.Field(&l.Pagination),
+	)
+}
+
+func (l *RevisionList) ToMessage(appGUID string) repositories.ListRevisionsMessage {
+	return repositories.ListRevisionsMessage{
+		AppGUIDs: []string{appGUID},
+		Versions: l.Versions,
+	}
+}
+
+func (l *RevisionList) SupportedKeys() []string {
+	return []string{"versions", "order_by", "per_page", "page"}
+}
+
+func (l *RevisionList) DecodeFromURLValues(values url.Values) error {
+	for _, v := range parse.ArrayParam(values.Get("versions")) {
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		l.Versions = append(l.Versions, version)
+	}
+	l.OrderBy = values.Get("order_by")
+	return l.Pagination.DecodeFromURLValues(values)
+}
@@ -0,0 +1,49 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RevisionList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedRevisionList payloads.RevisionList) {
+				actualRevisionList, decodeErr := decodeQuery[payloads.RevisionList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualRevisionList).To(Equal(expectedRevisionList))
+			},
+			Entry("versions", "versions=1,3", payloads.RevisionList{Versions: []int{1, 3}}),
+			Entry("order_by version", "order_by=version", payloads.RevisionList{OrderBy: "version"}),
+			Entry("order_by -created_at", "order_by=-created_at", payloads.RevisionList{OrderBy: "-created_at"}),
+			Entry("page and per_page", "page=2&per_page=10", payloads.RevisionList{Pagination: payloads.Pagination{Page: 2, PerPage: 10}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.RevisionList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("non-integer version", "versions=1,two", "invalid syntax"),
+			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("per_page too large", "per_page=5001", "per_page: must be no greater than 5000"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			revisionList := payloads.RevisionList{
+				Versions: []int{1, 3},
+				OrderBy:  "version",
+			}
+			Expect(revisionList.ToMessage("app-guid")).To(Equal(repositories.ListRevisionsMessage{
+				AppGUIDs: []string{"app-guid"},
+				Versions: []int{1, 3},
+			}))
+		})
+	})
+})
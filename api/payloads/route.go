@@ -13,22 +13,29 @@ import (
 type RouteCreate struct {
 	Host          string              `json:"host"`
 	Path          string              `json:"path"`
+	Port          *int                `json:"port"`
 	Relationships *RouteRelationships `json:"relationships"`
 	Metadata      Metadata            `json:"metadata"`
 }
 
 func (p RouteCreate) Validate() error {
 	return jellidation.ValidateStruct(&p,
-		jellidation.Field(&p.Host, jellidation.Required),
+		jellidation.Field(&p.Port, jellidation.Min(1), jellidation.Max(65535)),
 		jellidation.Field(&p.Relationships, jellidation.NotNil),
 		jellidation.Field(&p.Metadata),
 	)
 }
 
 func (p RouteCreate) ToMessage(domainNamespace, domainName string) repositories.CreateRouteMessage {
+	var port int
+	if p.Port != nil {
+		port = *p.Port
+	}
+
 	return repositories.CreateRouteMessage{
 		Host:            p.Host,
 		Path:            p.Path,
+		Port:            port,
 		SpaceGUID:       p.Relationships.Space.Data.GUID,
 		DomainGUID:      p.Relationships.Domain.Data.GUID,
 		DomainNamespace: domainNamespace,
@@ -56,6 +63,15 @@ type RouteList struct {
 	DomainGUIDs string
 	Hosts       string
 	Paths       string
+	OrderBy     string
+	Pagination
+}
+
+func (p RouteList) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+		jellidation.Field(&p.Pagination),
+	)
 }
 
 func (p RouteList) ToMessage() repositories.ListRoutesMessage {
@@ -65,11 +81,12 @@ func (p RouteList) ToMessage() repositories.ListRoutesMessage {
 		DomainGUIDs: parse.ArrayParam(p.DomainGUIDs),
 		Hosts:       parse.ArrayParam(p.Hosts),
 		Paths:       parse.ArrayParam(p.Paths),
+		Pagination:  orderedPagination(p.Pagination, p.OrderBy),
 	}
 }
 
 func (p RouteList) SupportedKeys() []string {
-	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "per_page", "page"}
+	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "order_by", "per_page", "page"}
 }
 
 func (p *RouteList) DecodeFromURLValues(values url.Values) error {
@@ -78,7 +95,8 @@ func (p *RouteList) DecodeFromURLValues(values url.Values) error {
 	p.DomainGUIDs = values.Get("domain_guids")
 	p.Hosts = values.Get("hosts")
 	p.Paths = values.Get("paths")
-	return nil
+	p.OrderBy = values.Get("order_by")
+	return p.Pagination.DecodeFromURLValues(values)
 }
 
 type RoutePatch struct {
@@ -121,7 +139,7 @@ type RouteDestination struct {
 func (r RouteDestination) Validate() error {
 	return jellidation.ValidateStruct(&r,
 		jellidation.Field(&r.App),
-		jellidation.Field(&r.Protocol, validation.OneOf("http1")),
+		jellidation.Field(&r.Protocol, validation.OneOf("http1", "http2")),
 	)
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -21,7 +22,7 @@ var _ = Describe("RouteList", func() {
 
 		BeforeEach(func() {
 			routeList = payloads.RouteList{}
-			params = "app_guids=app_guid&space_guids=space_guid&domain_guids=domain_guid&hosts=host&paths=path"
+			params = "app_guids=app_guid&space_guids=space_guid&domain_guids=domain_guid&hosts=host&paths=path&order_by=-created_at"
 		})
 
 		JustBeforeEach(func() {
@@ -38,9 +39,20 @@ var _ = Describe("RouteList", func() {
 				DomainGUIDs: "domain_guid",
 				Hosts:       "host",
 				Paths:       "path",
+				OrderBy:     "-created_at",
 			}))
 		})
 
+		When("it contains an invalid order_by", func() {
+			BeforeEach(func() {
+				params = "order_by=name"
+			})
+
+			It("fails", func() {
+				Expect(decodeErr).To(MatchError(ContainSubstring("value must be one of")))
+			})
+		})
+
 		When("it contains an invalid key", func() {
 			BeforeEach(func() {
 				params = "foo=bar"
@@ -53,6 +65,20 @@ var _ = Describe("RouteList", func() {
 	})
 })
 
+var _ = Describe("RouteList ToMessage", func() {
+	It("selects the requested page", func() {
+		routeList := payloads.RouteList{Pagination: payloads.Pagination{Page: 3, PerPage: 10}}
+		Expect(routeList.ToMessage().Pagination).To(Equal(repositories.Pagination{Page: 3, PerPage: 10}))
+	})
+
+	When("the list is ordered", func() {
+		It("lists all routes, so that they can be sorted", func() {
+			routeList := payloads.RouteList{OrderBy: "created_at", Pagination: payloads.Pagination{Page: 3, PerPage: 10}}
+			Expect(routeList.ToMessage().Pagination).To(Equal(repositories.Pagination{}))
+		})
+	})
+})
+
 var _ = Describe("RouteCreate", func() {
 	var (
 		createPayload payloads.RouteCreate
@@ -100,9 +126,32 @@ var _ = Describe("RouteCreate", func() {
 			createPayload.Host = ""
 		})
 
+		It("succeeds, as tcp routes have no host", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the port is set", func() {
+		BeforeEach(func() {
+			createPayload.Host = ""
+			createPayload.Path = ""
+			createPayload.Port = tools.PtrTo(1024)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(routeCreate.ToMessage("domain-ns", "domain-guid").Port).To(Equal(1024))
+		})
+	})
+
+	When("the port is out of range", func() {
+		BeforeEach(func() {
+			createPayload.Port = tools.PtrTo(65536)
+		})
+
 		It("fails", func() {
 			Expect(apiError).To(HaveOccurred())
-			Expect(apiError.Detail()).To(ContainSubstring("host cannot be blank"))
+			Expect(apiError.Detail()).To(ContainSubstring("port must be no greater than 65535"))
 		})
 	})
 
@@ -254,14 +303,24 @@ var _ = Describe("Add destination", func() {
 		})
 	})
 
-	When("protocol is not http1", func() {
+	When("protocol is http2", func() {
+		BeforeEach(func() {
+			addPayload.Destinations[1].Protocol = tools.PtrTo("http2")
+		})
+
+		It("succeeds", func() {
+			Expect(apiError).NotTo(HaveOccurred())
+		})
+	})
+
+	When("protocol is not supported", func() {
 		BeforeEach(func() {
 			addPayload.Destinations[1].Protocol = tools.PtrTo("http")
 		})
 
 		It("fails", func() {
 			Expect(apiError).To(HaveOccurred())
-			Expect(apiError.Detail()).To(ContainSubstring("value must be one of: http1"))
+			Expect(apiError.Detail()).To(ContainSubstring("value must be one of: http1, http2"))
 		})
 	})
 })
@@ -0,0 +1,18 @@
+package payloads
+
+import (
+	"net/url"
+)
+
+type RouterGroupList struct {
+	Name string
+}
+
+func (l RouterGroupList) SupportedKeys() []string {
+	return []string{"name"}
+}
+
+func (l *RouterGroupList) DecodeFromURLValues(values url.Values) error {
+	l.Name = values.Get("name")
+	return nil
+}
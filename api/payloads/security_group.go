@@ -0,0 +1,229 @@
+package payloads
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools/securitygroups"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SecurityGroupRule struct {
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Ports       string `json:"ports"`
+	Type        *int32 `json:"type"`
+	Code        *int32 `json:"code"`
+	Description string `json:"description"`
+	Log         bool   `json:"log"`
+}
+
+func (r SecurityGroupRule) Validate() error {
+	isPortProtocol := r.Protocol == "tcp" || r.Protocol == "udp"
+	isICMP := r.Protocol == "icmp"
+
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Protocol, jellidation.Required, payload_validation.OneOf("tcp", "udp", "icmp", "all")),
+		jellidation.Field(&r.Destination, jellidation.Required, jellidation.By(func(value any) error {
+			if _, err := securitygroups.ParseDestination(value.(string)); err != nil {
+				return errors.New("must be a valid IP address, CIDR or IP range")
+			}
+			return nil
+		})),
+		jellidation.Field(&r.Ports,
+			jellidation.When(isPortProtocol, jellidation.Required, jellidation.By(func(value any) error {
+				if _, err := securitygroups.ParsePorts(value.(string)); err != nil {
+					return errors.New("must be a valid port, port range or comma separated list of ports")
+				}
+				return nil
+			})),
+			jellidation.When(!isPortProtocol, jellidation.Empty.Error("is only allowed for protocols tcp and udp")),
+		),
+		jellidation.Field(&r.Type,
+			jellidation.When(isICMP, jellidation.NotNil, jellidation.Min(int32(-1)), jellidation.Max(int32(255))),
+			jellidation.When(!isICMP, jellidation.Nil.Error("is only allowed for protocol icmp")),
+		),
+		jellidation.Field(&r.Code,
+			jellidation.When(isICMP, jellidation.NotNil, jellidation.Min(int32(-1)), jellidation.Max(int32(255))),
+			jellidation.When(!isICMP, jellidation.Nil.Error("is only allowed for protocol icmp")),
+		),
+	)
+}
+
+func (r SecurityGroupRule) toRecord() repositories.SecurityGroupRule {
+	return repositories.SecurityGroupRule{
+		Protocol:    r.Protocol,
+		Destination: r.Destination,
+		Ports:       r.Ports,
+		Type:        r.Type,
+		Code:        r.Code,
+		Description: r.Description,
+		Log:         r.Log,
+	}
+}
+
+func toSecurityGroupRules(rules []SecurityGroupRule) []repositories.SecurityGroupRule {
+	records := make([]repositories.SecurityGroupRule, 0, len(rules))
+	for _, rule := range rules {
+		records = append(records, rule.toRecord())
+	}
+	return records
+}
+
+type SecurityGroupWorkloads struct {
+	Running bool `json:"running"`
+	Staging bool `json:"staging"`
+}
+
+type SecurityGroupRelationships struct {
+	RunningSpaces *ToManyRelationship `json:"running_spaces"`
+	StagingSpaces *ToManyRelationship `json:"staging_spaces"`
+}
+
+func (r SecurityGroupRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.RunningSpaces),
+		jellidation.Field(&r.StagingSpaces),
+	)
+}
+
+type SecurityGroupCreate struct {
+	Name            string                      `json:"name"`
+	GloballyEnabled SecurityGroupWorkloads      `json:"globally_enabled"`
+	Rules           []SecurityGroupRule         `json:"rules"`
+	Relationships   *SecurityGroupRelationships `json:"relationships"`
+}
+
+func (c SecurityGroupCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.Rules),
+		jellidation.Field(&c.Relationships),
+	)
+}
+
+func (c SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage {
+	message := repositories.CreateSecurityGroupMessage{
+		Name: c.Name,
+		GloballyEnabled: repositories.SecurityGroupWorkloads{
+			Running: c.GloballyEnabled.Running,
+			Staging: c.GloballyEnabled.Staging,
+		},
+		Rules: toSecurityGroupRules(c.Rules),
+	}
+
+	if c.Relationships != nil {
+		if c.Relationships.RunningSpaces != nil {
+			message.RunningSpaceGUIDs = c.Relationships.RunningSpaces.GUIDs()
+		}
+		if c.Relationships.StagingSpaces != nil {
+			message.StagingSpaceGUIDs = c.Relationships.StagingSpaces.GUIDs()
+		}
+	}
+
+	return message
+}
+
+type SecurityGroupWorkloadsPatch struct {
+	Running *bool `json:"running"`
+	Staging *bool `json:"staging"`
+}
+
+type SecurityGroupPatch struct {
+	Name            *string                      `json:"name"`
+	GloballyEnabled *SecurityGroupWorkloadsPatch `json:"globally_enabled"`
+	Rules           *[]SecurityGroupRule         `json:"rules"`
+}
+
+func (p SecurityGroupPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&p.Rules),
+	)
+}
+
+func (p SecurityGroupPatch) ToMessage(guid string) repositories.PatchSecurityGroupMessage {
+	message := repositories.PatchSecurityGroupMessage{
+		GUID: guid,
+		Name: p.Name,
+	}
+
+	if p.GloballyEnabled != nil {
+		message.GloballyEnabledRunning = p.GloballyEnabled.Running
+		message.GloballyEnabledStaging = p.GloballyEnabled.Staging
+	}
+
+	if p.Rules != nil {
+		rules := toSecurityGroupRules(*p.Rules)
+		message.Rules = &rules
+	}
+
+	return message
+}
+
+type SecurityGroupBind struct {
+	ToManyRelationship
+}
+
+func (b SecurityGroupBind) ToMessage(guid, workload string) repositories.BindSecurityGroupMessage {
+	return repositories.BindSecurityGroupMessage{
+		GUID:       guid,
+		Workload:   workload,
+		SpaceGUIDs: b.GUIDs(),
+	}
+}
+
+type SecurityGroupList struct {
+	GUIDs                  string
+	Names                  string
+	GloballyEnabledRunning string
+	GloballyEnabledStaging string
+	RunningSpaceGUIDs      string
+	StagingSpaceGUIDs      string
+	Pagination
+}
+
+func (l SecurityGroupList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.GloballyEnabledRunning, payload_validation.OneOf("true", "false")),
+		jellidation.Field(&l.GloballyEnabledStaging, payload_validation.OneOf("true", "false")),
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *SecurityGroupList) ToMessage() repositories.ListSecurityGroupsMessage {
+	return repositories.ListSecurityGroupsMessage{
+		GUIDs:                  parse.ArrayParam(l.GUIDs),
+		Names:                  parse.ArrayParam(l.Names),
+		GloballyEnabledRunning: parseOptionalBool(l.GloballyEnabledRunning),
+		GloballyEnabledStaging: parseOptionalBool(l.GloballyEnabledStaging),
+		RunningSpaceGUIDs:      parse.ArrayParam(l.RunningSpaceGUIDs),
+		StagingSpaceGUIDs:      parse.ArrayParam(l.StagingSpaceGUIDs),
+	}
+}
+
+func parseOptionalBool(value string) *bool {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+func (l *SecurityGroupList) SupportedKeys() []string {
+	return []string{"guids", "names", "globally_enabled_running", "globally_enabled_staging", "running_space_guids", "staging_space_guids", "per_page", "page"}
+}
+
+func (l *SecurityGroupList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.GloballyEnabledRunning = values.Get("globally_enabled_running")
+	l.GloballyEnabledStaging = values.Get("globally_enabled_staging")
+	l.RunningSpaceGUIDs = values.Get("running_space_guids")
+	l.StagingSpaceGUIDs = values.Get("staging_space_guids")
+	return l.Pagination.DecodeFromURLValues(values)
+}
@@ -0,0 +1,226 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecurityGroupCreate", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.SecurityGroupCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SecurityGroupCreate)
+		requestBody = map[string]any{
+			"name": "my-security-group",
+			"globally_enabled": map[string]any{
+				"running": true,
+			},
+			"rules": []map[string]any{
+				{"protocol": "tcp", "destination": "10.0.0.0/8", "ports": "80,443", "description": "web"},
+				{"protocol": "icmp", "destination": "10.0.0.1-10.0.0.9", "type": 8, "code": 0},
+				{"protocol": "all", "destination": "192.168.0.1", "log": true},
+			},
+			"relationships": map[string]any{
+				"running_spaces": map[string]any{
+					"data": []map[string]any{{"guid": "space-1"}},
+				},
+				"staging_spaces": map[string]any{
+					"data": []map[string]any{{"guid": "space-2"}},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage()).To(Equal(repositories.CreateSecurityGroupMessage{
+			Name:            "my-security-group",
+			GloballyEnabled: repositories.SecurityGroupWorkloads{Running: true},
+			Rules: []repositories.SecurityGroupRule{
+				{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "80,443", Description: "web"},
+				{Protocol: "icmp", Destination: "10.0.0.1-10.0.0.9", Type: tools.PtrTo[int32](8), Code: tools.PtrTo[int32](0)},
+				{Protocol: "all", Destination: "192.168.0.1", Log: true},
+			},
+			RunningSpaceGUIDs: []string{"space-1"},
+			StagingSpaceGUIDs: []string{"space-2"},
+		}))
+	})
+
+	When("the name is missing", func() {
+		BeforeEach(func() {
+			delete(requestBody, "name")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("there are no rules and relationships", func() {
+		BeforeEach(func() {
+			delete(requestBody, "rules")
+			delete(requestBody, "relationships")
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			message := decodedPayload.ToMessage()
+			Expect(message.Rules).To(BeEmpty())
+			Expect(message.RunningSpaceGUIDs).To(BeEmpty())
+			Expect(message.StagingSpaceGUIDs).To(BeEmpty())
+		})
+	})
+
+	DescribeTable("invalid rules",
+		func(rule map[string]any, expectedErrMsg string) {
+			requestBody["rules"] = []map[string]any{rule}
+			Expect(validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), new(payloads.SecurityGroupCreate))).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unsupported protocol", map[string]any{"protocol": "sctp", "destination": "10.0.0.1"}, "value must be one of: tcp, udp, icmp, all"),
+		Entry("missing destination", map[string]any{"protocol": "all"}, "destination: cannot be blank"),
+		Entry("invalid destination", map[string]any{"protocol": "all", "destination": "example.com"}, "must be a valid IP address, CIDR or IP range"),
+		Entry("missing tcp ports", map[string]any{"protocol": "tcp", "destination": "10.0.0.1"}, "ports: cannot be blank"),
+		Entry("invalid ports", map[string]any{"protocol": "udp", "destination": "10.0.0.1", "ports": "70000"}, "must be a valid port, port range or comma separated list of ports"),
+		Entry("ports with protocol all", map[string]any{"protocol": "all", "destination": "10.0.0.1", "ports": "80"}, "is only allowed for protocols tcp and udp"),
+		Entry("missing icmp type", map[string]any{"protocol": "icmp", "destination": "10.0.0.1", "code": 0}, "type: is required"),
+		Entry("icmp code out of range", map[string]any{"protocol": "icmp", "destination": "10.0.0.1", "type": 0, "code": 256}, "code: must be no greater than 255"),
+		Entry("icmp type with tcp", map[string]any{"protocol": "tcp", "destination": "10.0.0.1", "ports": "80", "type": 0}, "is only allowed for protocol icmp"),
+	)
+})
+
+var _ = Describe("SecurityGroupPatch", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.SecurityGroupPatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SecurityGroupPatch)
+		requestBody = map[string]any{
+			"globally_enabled": map[string]any{
+				"staging": false,
+			},
+			"rules": []map[string]any{},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("only patches the fields present in the request", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("security-group-guid")).To(Equal(repositories.PatchSecurityGroupMessage{
+			GUID:                   "security-group-guid",
+			GloballyEnabledStaging: tools.PtrTo(false),
+			Rules:                  &[]repositories.SecurityGroupRule{},
+		}))
+	})
+
+	When("the rules are omitted", func() {
+		BeforeEach(func() {
+			delete(requestBody, "rules")
+		})
+
+		It("does not patch the rules", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.ToMessage("security-group-guid").Rules).To(BeNil())
+		})
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			requestBody["name"] = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("a rule is invalid", func() {
+		BeforeEach(func() {
+			requestBody["rules"] = []map[string]any{{"protocol": "tcp", "destination": "10.0.0.1"}}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "ports cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("SecurityGroupBind", func() {
+	It("returns a bind message", func() {
+		decodedPayload := new(payloads.SecurityGroupBind)
+		Expect(validator.DecodeAndValidateJSONPayload(createJSONRequest(map[string]any{
+			"data": []map[string]any{{"guid": "space-1"}},
+		}), decodedPayload)).To(Succeed())
+
+		Expect(decodedPayload.ToMessage("security-group-guid", repositories.SecurityGroupStagingWorkload)).To(Equal(repositories.BindSecurityGroupMessage{
+			GUID:       "security-group-guid",
+			Workload:   repositories.SecurityGroupStagingWorkload,
+			SpaceGUIDs: []string{"space-1"},
+		}))
+	})
+})
+
+var _ = Describe("SecurityGroupList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedSecurityGroupList payloads.SecurityGroupList) {
+				actualSecurityGroupList, decodeErr := decodeQuery[payloads.SecurityGroupList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualSecurityGroupList).To(Equal(expectedSecurityGroupList))
+			},
+			Entry("guids", "guids=g1,g2", payloads.SecurityGroupList{GUIDs: "g1,g2"}),
+			Entry("names", "names=n1,n2", payloads.SecurityGroupList{Names: "n1,n2"}),
+			Entry("globally_enabled_running", "globally_enabled_running=true", payloads.SecurityGroupList{GloballyEnabledRunning: "true"}),
+			Entry("globally_enabled_staging", "globally_enabled_staging=false", payloads.SecurityGroupList{GloballyEnabledStaging: "false"}),
+			Entry("running_space_guids", "running_space_guids=s1,s2", payloads.SecurityGroupList{RunningSpaceGUIDs: "s1,s2"}),
+			Entry("staging_space_guids", "staging_space_guids=s1,s2", payloads.SecurityGroupList{StagingSpaceGUIDs: "s1,s2"}),
+			Entry("page and per_page", "page=2&per_page=10", payloads.SecurityGroupList{Pagination: payloads.Pagination{Page: 2, PerPage: 10}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.SecurityGroupList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+			Entry("invalid globally_enabled_running", "globally_enabled_running=maybe", "value must be one of: true, false"),
+			Entry("per_page too large", "per_page=5001", "per_page: must be no greater than 5000"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			securityGroupList := payloads.SecurityGroupList{
+				GUIDs:                  "g1,g2",
+				Names:                  "n1,n2",
+				GloballyEnabledStaging: "false",
+				RunningSpaceGUIDs:      "s1",
+				StagingSpaceGUIDs:      "s2",
+			}
+			Expect(securityGroupList.ToMessage()).To(Equal(repositories.ListSecurityGroupsMessage{
+				GUIDs:                  []string{"g1", "g2"},
+				Names:                  []string{"n1", "n2"},
+				GloballyEnabledStaging: tools.PtrTo(false),
+				RunningSpaceGUIDs:      []string{"s1"},
+				StagingSpaceGUIDs:      []string{"s2"},
+			}))
+		})
+	})
+})
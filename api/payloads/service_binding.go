@@ -16,18 +16,53 @@ type ServiceBindingCreate struct {
 }
 
 func (p ServiceBindingCreate) ToMessage(spaceGUID string) repositories.CreateServiceBindingMessage {
+	var appGUID string
+	if p.Relationships.App != nil {
+		appGUID = p.Relationships.App.Data.GUID
+	}
+
+	bindingType := p.Type
+	if bindingType == "" {
+		bindingType = repositories.ServiceBindingTypeApp
+	}
+
 	return repositories.CreateServiceBindingMessage{
+		Type:                bindingType,
 		Name:                p.Name,
 		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
-		AppGUID:             p.Relationships.App.Data.GUID,
+		AppGUID:             appGUID,
 		SpaceGUID:           spaceGUID,
 	}
 }
 
+func (p ServiceBindingCreate) IsKey() bool {
+	return p.Type == repositories.ServiceBindingTypeKey
+}
+
 func (p ServiceBindingCreate) Validate() error {
 	return jellidation.ValidateStruct(&p,
-		jellidation.Field(&p.Type, validation.OneOf("app")),
-		jellidation.Field(&p.Relationships, jellidation.NotNil),
+		jellidation.Field(&p.Type, validation.OneOf(repositories.ServiceBindingTypeApp, repositories.ServiceBindingTypeKey)),
+		jellidation.Field(&p.Name, jellidation.When(p.IsKey(), jellidation.Required)),
+		jellidation.Field(&p.Relationships, jellidation.NotNil, jellidation.By(p.validateAppRelationship)),
+	)
+}
+
+// validateAppRelationship requires app bindings to reference an app and key
+// bindings not to
+func (p ServiceBindingCreate) validateAppRelationship(value any) error {
+	relationships, ok := value.(*ServiceBindingRelationships)
+	if !ok || relationships == nil {
+		return nil
+	}
+
+	if p.IsKey() {
+		return jellidation.ValidateStruct(relationships,
+			jellidation.Field(&relationships.App, jellidation.Nil.Error("must be blank for key bindings")),
+		)
+	}
+
+	return jellidation.ValidateStruct(relationships,
+		jellidation.Field(&relationships.App, jellidation.NotNil),
 	)
 }
 
@@ -38,36 +73,50 @@ type ServiceBindingRelationships struct {
 
 func (r ServiceBindingRelationships) Validate() error {
 	return jellidation.ValidateStruct(&r,
-		jellidation.Field(&r.App, jellidation.NotNil),
+		jellidation.Field(&r.App),
 		jellidation.Field(&r.ServiceInstance, jellidation.NotNil),
 	)
 }
 
 type ServiceBindingList struct {
+	Type                 string
 	AppGUIDs             string
 	ServiceInstanceGUIDs string
 	Include              string
 	LabelSelector        string
+	OrderBy              string
+	Pagination
+}
+
+func (l ServiceBindingList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "name")),
+		jellidation.Field(&l.Pagination),
+	)
 }
 
 func (l *ServiceBindingList) ToMessage() repositories.ListServiceBindingsMessage {
 	return repositories.ListServiceBindingsMessage{
+		Types:                parse.ArrayParam(l.Type),
 		ServiceInstanceGUIDs: parse.ArrayParam(l.ServiceInstanceGUIDs),
 		AppGUIDs:             parse.ArrayParam(l.AppGUIDs),
 		LabelSelector:        l.LabelSelector,
+		Pagination:           orderedPagination(l.Pagination, l.OrderBy),
 	}
 }
 
 func (l *ServiceBindingList) SupportedKeys() []string {
-	return []string{"app_guids", "service_instance_guids", "include", "type", "per_page", "page", "label_selector"}
+	return []string{"app_guids", "service_instance_guids", "include", "type", "order_by", "per_page", "page", "label_selector"}
 }
 
 func (l *ServiceBindingList) DecodeFromURLValues(values url.Values) error {
+	l.Type = values.Get("type")
 	l.AppGUIDs = values.Get("app_guids")
 	l.ServiceInstanceGUIDs = values.Get("service_instance_guids")
 	l.Include = values.Get("include")
 	l.LabelSelector = values.Get("label_selector")
-	return nil
+	l.OrderBy = values.Get("order_by")
+	return l.Pagination.DecodeFromURLValues(values)
 }
 
 type ServiceBindingUpdate struct {
@@ -18,10 +18,21 @@ var _ = Describe("ServiceBindingList", func() {
 			Expect(decodeErr).NotTo(HaveOccurred())
 			Expect(*actualServiceBindingList).To(Equal(expectedServiceBindingList))
 		},
+		Entry("type", "type=key", payloads.ServiceBindingList{Type: "key"}),
 		Entry("app_guids", "app_guids=app_guid", payloads.ServiceBindingList{AppGUIDs: "app_guid"}),
 		Entry("service_instance_guids", "service_instance_guids=si_guid", payloads.ServiceBindingList{ServiceInstanceGUIDs: "si_guid"}),
 		Entry("include", "include=include", payloads.ServiceBindingList{Include: "include"}),
 		Entry("label_selector=foo", "label_selector=foo", payloads.ServiceBindingList{LabelSelector: "foo"}),
+		Entry("order_by", "order_by=name", payloads.ServiceBindingList{OrderBy: "name"}),
+		Entry("order_by descending", "order_by=-created_at", payloads.ServiceBindingList{OrderBy: "-created_at"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.ServiceBindingList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid order_by", "order_by=something", "value must be one of"),
 	)
 
 	Describe("ToMessage", func() {
@@ -32,10 +43,12 @@ var _ = Describe("ServiceBindingList", func() {
 
 		BeforeEach(func() {
 			payload = payloads.ServiceBindingList{
+				Type:                 "key",
 				AppGUIDs:             "app1,app2",
 				ServiceInstanceGUIDs: "s1,s2",
 				Include:              "include",
 				LabelSelector:        "foo=bar",
+				Pagination:           payloads.Pagination{Page: 3, PerPage: 10},
 			}
 		})
 
@@ -45,11 +58,23 @@ var _ = Describe("ServiceBindingList", func() {
 
 		It("returns a list service bindings message", func() {
 			Expect(message).To(Equal(repositories.ListServiceBindingsMessage{
+				Types:                []string{"key"},
 				AppGUIDs:             []string{"app1", "app2"},
 				ServiceInstanceGUIDs: []string{"s1", "s2"},
 				LabelSelector:        "foo=bar",
+				Pagination:           repositories.Pagination{Page: 3, PerPage: 10},
 			}))
 		})
+
+		When("the list is ordered", func() {
+			BeforeEach(func() {
+				payload.OrderBy = "name"
+			})
+
+			It("lists all service bindings, so that they can be sorted", func() {
+				Expect(message.Pagination).To(Equal(repositories.Pagination{}))
+			})
+		})
 	})
 })
 
@@ -90,14 +115,69 @@ var _ = Describe("ServiceBindingCreate", func() {
 		Expect(serviceBindingCreate).To(gstruct.PointTo(Equal(createPayload)))
 	})
 
-	When(`the type is "key"`, func() {
+	It("converts to an app binding message", func() {
+		Expect(serviceBindingCreate.ToMessage("space-guid")).To(Equal(repositories.CreateServiceBindingMessage{
+			Type:                "app",
+			ServiceInstanceGUID: "service-instance-guid",
+			AppGUID:             "app-guid",
+			SpaceGUID:           "space-guid",
+		}))
+	})
+
+	When("the type is invalid", func() {
 		BeforeEach(func() {
-			createPayload.Type = "key"
+			createPayload.Type = "foo"
 		})
 
 		It("fails", func() {
 			Expect(apiError).To(HaveOccurred())
-			Expect(apiError.Detail()).To(ContainSubstring("type value must be one of: app"))
+			Expect(apiError.Detail()).To(ContainSubstring("type value must be one of: app, key"))
+		})
+	})
+
+	When(`the type is "key"`, func() {
+		BeforeEach(func() {
+			createPayload.Type = "key"
+			createPayload.Name = tools.PtrTo("my-key")
+			createPayload.Relationships.App = nil
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceBindingCreate).To(gstruct.PointTo(Equal(createPayload)))
+		})
+
+		It("converts to a key binding message", func() {
+			Expect(serviceBindingCreate.ToMessage("space-guid")).To(Equal(repositories.CreateServiceBindingMessage{
+				Type:                "key",
+				Name:                tools.PtrTo("my-key"),
+				ServiceInstanceGUID: "service-instance-guid",
+				SpaceGUID:           "space-guid",
+			}))
+		})
+
+		When("the name is missing", func() {
+			BeforeEach(func() {
+				createPayload.Name = nil
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("name cannot be blank"))
+			})
+		})
+
+		When("an app relationship is specified", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.App = &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "app-guid"},
+				}
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("relationships.app must be blank for key bindings"))
+			})
 		})
 	})
 
@@ -46,15 +46,16 @@ func (c ServiceBrokerCreate) ToCreateServiceBrokerMessage() repositories.CreateS
 
 type ServiceBrokerList struct {
 	Names string
+	Pagination
 }
 
 func (b *ServiceBrokerList) DecodeFromURLValues(values url.Values) error {
 	b.Names = values.Get("names")
-	return nil
+	return b.Pagination.DecodeFromURLValues(values)
 }
 
 func (b *ServiceBrokerList) SupportedKeys() []string {
-	return []string{"names"}
+	return []string{"names", "per_page", "page"}
 }
 
 func (b *ServiceBrokerList) ToMessage() repositories.ListServiceBrokerMessage {
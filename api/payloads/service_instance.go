@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	jellidation "github.com/jellydator/validation"
 )
 
@@ -18,6 +19,7 @@ type ServiceInstanceCreate struct {
 	Type          string                        `json:"type"`
 	Tags          []string                      `json:"tags"`
 	Credentials   map[string]any                `json:"credentials"`
+	Parameters    map[string]any                `json:"parameters"`
 	Relationships *ServiceInstanceRelationships `json:"relationships"`
 	Metadata      Metadata                      `json:"metadata"`
 }
@@ -42,11 +44,26 @@ func validateTagLength(tags any) error {
 }
 
 func (c ServiceInstanceCreate) Validate() error {
+	relationshipsRule := jellidation.By(func(value any) error {
+		relationships, ok := value.(*ServiceInstanceRelationships)
+		if !ok {
+			return fmt.Errorf("%T is not supported, ServiceInstanceRelationships is expected", value)
+		}
+
+		if c.Type == korifiv1alpha1.ManagedType {
+			return relationships.ValidateManagedRelationships()
+		}
+
+		return relationships.ValidateUserProvidedRelationships()
+	})
+
 	return jellidation.ValidateStruct(&c,
 		jellidation.Field(&c.Name, jellidation.Required),
-		jellidation.Field(&c.Type, jellidation.Required, validation.OneOf("user-provided")),
+		jellidation.Field(&c.Type, jellidation.Required, validation.OneOf(korifiv1alpha1.UserProvidedType, korifiv1alpha1.ManagedType)),
 		jellidation.Field(&c.Tags, jellidation.By(validateTagLength)),
-		jellidation.Field(&c.Relationships, jellidation.NotNil),
+		jellidation.Field(&c.Credentials, jellidation.When(c.Type == korifiv1alpha1.ManagedType, jellidation.Nil.Error("must be blank for managed service instances"))),
+		jellidation.Field(&c.Parameters, jellidation.When(c.Type != korifiv1alpha1.ManagedType, jellidation.Nil.Error("must be blank for user-provided service instances"))),
+		jellidation.Field(&c.Relationships, jellidation.NotNil, relationshipsRule),
 		jellidation.Field(&c.Metadata),
 	)
 }
@@ -57,6 +74,8 @@ func (p ServiceInstanceCreate) ToServiceInstanceCreateMessage() repositories.Cre
 		SpaceGUID:   p.Relationships.Space.Data.GUID,
 		Credentials: p.Credentials,
 		Type:        p.Type,
+		PlanGUID:    p.Relationships.ServicePlanGUID(),
+		Parameters:  p.Parameters,
 		Tags:        p.Tags,
 		Labels:      p.Metadata.Labels,
 		Annotations: p.Metadata.Annotations,
@@ -64,24 +83,55 @@ func (p ServiceInstanceCreate) ToServiceInstanceCreateMessage() repositories.Cre
 }
 
 type ServiceInstanceRelationships struct {
-	Space *Relationship `json:"space"`
+	Space       *Relationship `json:"space"`
+	ServicePlan *Relationship `json:"service_plan"`
 }
 
 func (r ServiceInstanceRelationships) Validate() error {
 	return jellidation.ValidateStruct(&r,
 		jellidation.Field(&r.Space, jellidation.NotNil),
+		jellidation.Field(&r.ServicePlan),
 	)
 }
 
+func (r ServiceInstanceRelationships) ValidateManagedRelationships() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.ServicePlan, jellidation.NotNil),
+	)
+}
+
+func (r ServiceInstanceRelationships) ValidateUserProvidedRelationships() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.ServicePlan, jellidation.Nil.Error("must be blank for user-provided service instances")),
+	)
+}
+
+func (r ServiceInstanceRelationships) ServicePlanGUID() string {
+	if r.ServicePlan == nil || r.ServicePlan.Data == nil {
+		return ""
+	}
+
+	return r.ServicePlan.Data.GUID
+}
+
+// managedServiceInstanceUpdateNotSupported is reported for the fields that
+// can only be applied by updating a managed service instance in its broker,
+// which is not supported
+const managedServiceInstanceUpdateNotSupported = "cannot be updated, updating managed service instances in their broker is not supported"
+
 type ServiceInstancePatch struct {
-	Name        *string         `json:"name,omitempty"`
-	Tags        *[]string       `json:"tags,omitempty"`
-	Credentials *map[string]any `json:"credentials,omitempty"`
-	Metadata    MetadataPatch   `json:"metadata"`
+	Name          *string                       `json:"name,omitempty"`
+	Tags          *[]string                     `json:"tags,omitempty"`
+	Credentials   *map[string]any               `json:"credentials,omitempty"`
+	Parameters    *map[string]any               `json:"parameters,omitempty"`
+	Relationships *ServiceInstanceRelationships `json:"relationships,omitempty"`
+	Metadata      MetadataPatch                 `json:"metadata"`
 }
 
 func (p ServiceInstancePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Parameters, jellidation.Nil.Error(managedServiceInstanceUpdateNotSupported)),
+		jellidation.Field(&p.Relationships, jellidation.Nil.Error(managedServiceInstanceUpdateNotSupported)),
 		jellidation.Field(&p.Metadata),
 	)
 }
@@ -134,11 +184,13 @@ type ServiceInstanceList struct {
 	SpaceGUIDs    string
 	OrderBy       string
 	LabelSelector string
+	Pagination
 }
 
 func (l ServiceInstanceList) Validate() error {
 	return jellidation.ValidateStruct(&l,
 		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "name", "updated_at")),
+		jellidation.Field(&l.Pagination),
 	)
 }
 
@@ -165,5 +217,5 @@ func (l *ServiceInstanceList) DecodeFromURLValues(values url.Values) error {
 	l.GUIDs = values.Get("guids")
 	l.OrderBy = values.Get("order_by")
 	l.LabelSelector = values.Get("label_selector")
-	return nil
+	return l.Pagination.DecodeFromURLValues(values)
 }
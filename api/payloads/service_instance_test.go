@@ -141,7 +141,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 		})
 
 		It("returns an appropriate error", func() {
-			expectUnprocessableEntityError(validatorErr, "type value must be one of: user-provided")
+			expectUnprocessableEntityError(validatorErr, "type value must be one of: user-provided, managed")
 		})
 	})
 
@@ -180,6 +180,81 @@ var _ = Describe("ServiceInstanceCreate", func() {
 		})
 	})
 
+	When("a service plan relationship is set for a user-provided instance", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.ServicePlan = &payloads.Relationship{
+				Data: &payloads.RelationshipData{GUID: "plan-guid"},
+			}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be blank for user-provided service instances")
+		})
+	})
+
+	When("parameters are set for a user-provided instance", func() {
+		BeforeEach(func() {
+			createPayload.Parameters = map[string]any{"foo": "bar"}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be blank for user-provided service instances")
+		})
+	})
+
+	When("the service instance is managed", func() {
+		BeforeEach(func() {
+			createPayload.Type = "managed"
+			createPayload.Credentials = nil
+			createPayload.Parameters = map[string]any{"foo": "bar"}
+			createPayload.Relationships.ServicePlan = &payloads.Relationship{
+				Data: &payloads.RelationshipData{GUID: "plan-guid"},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceInstanceCreate).To(PointTo(Equal(createPayload)))
+		})
+
+		It("sets the plan guid and parameters in the repo message", func() {
+			msg := serviceInstanceCreate.ToServiceInstanceCreateMessage()
+			Expect(msg.Type).To(Equal("managed"))
+			Expect(msg.PlanGUID).To(Equal("plan-guid"))
+			Expect(msg.Parameters).To(Equal(map[string]any{"foo": "bar"}))
+		})
+
+		When("the service plan relationship is not set", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.ServicePlan = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "service_plan is required")
+			})
+		})
+
+		When("the service plan relationship data is not set", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.ServicePlan.Data = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "data is required")
+			})
+		})
+
+		When("credentials are set", func() {
+			BeforeEach(func() {
+				createPayload.Credentials = map[string]any{"foo": "bar"}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "must be blank for managed service instances")
+			})
+		})
+	})
+
 	Context("ToServiceInstanceCreateMessage()", func() {
 		It("converts to repo message correctly", func() {
 			msg := serviceInstanceCreate.ToServiceInstanceCreateMessage()
@@ -310,6 +385,28 @@ var _ = Describe("ServiceInstancePatch", func() {
 		})
 	})
 
+	When("parameters are set", func() {
+		BeforeEach(func() {
+			patchPayload.Parameters = &map[string]any{"foo": "bar"}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "parameters cannot be updated, updating managed service instances in their broker is not supported")
+		})
+	})
+
+	When("the service plan is set", func() {
+		BeforeEach(func() {
+			patchPayload.Relationships = &payloads.ServiceInstanceRelationships{
+				ServicePlan: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "plan-guid"}},
+			}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships cannot be updated, updating managed service instances in their broker is not supported")
+		})
+	})
+
 	Context("ToServiceInstancePatchMessage", func() {
 		It("converts to repo message correctly", func() {
 			msg := serviceInstancePatch.ToServiceInstancePatchMessage("space-guid", "app-guid")
@@ -0,0 +1,81 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SidecarCreate struct {
+	Name         string   `json:"name"`
+	Command      string   `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryInMB   *int64   `json:"memory_in_mb"`
+}
+
+func (c SidecarCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.Command, jellidation.Required),
+		jellidation.Field(&c.ProcessTypes, jellidation.Required, jellidation.Each(jellidation.Required)),
+		jellidation.Field(&c.MemoryInMB, jellidation.Min(int64(1)), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+	)
+}
+
+func (c SidecarCreate) ToMessage(appGUID, spaceGUID string) repositories.CreateSidecarMessage {
+	return repositories.CreateSidecarMessage{
+		AppGUID:      appGUID,
+		SpaceGUID:    spaceGUID,
+		Name:         c.Name,
+		Command:      c.Command,
+		ProcessTypes: c.ProcessTypes,
+		MemoryMB:     c.MemoryInMB,
+	}
+}
+
+type SidecarUpdate struct {
+	Name         *string  `json:"name"`
+	Command      *string  `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryInMB   *int64   `json:"memory_in_mb"`
+}
+
+func (u SidecarUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.Command, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.ProcessTypes, jellidation.NilOrNotEmpty, jellidation.Each(jellidation.Required)),
+		jellidation.Field(&u.MemoryInMB, jellidation.Min(int64(1)), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+	)
+}
+
+func (u SidecarUpdate) ToMessage(sidecar repositories.SidecarRecord) repositories.PatchSidecarMessage {
+	return repositories.PatchSidecarMessage{
+		GUID:         sidecar.GUID,
+		AppGUID:      sidecar.AppGUID,
+		SpaceGUID:    sidecar.SpaceGUID,
+		Name:         u.Name,
+		Command:      u.Command,
+		ProcessTypes: u.ProcessTypes,
+		MemoryMB:     u.MemoryInMB,
+	}
+}
+
+type SidecarList struct {
+	Pagination
+}
+
+func (l SidecarList) SupportedKeys() []string {
+	return []string{"per_page", "page"}
+}
+
+func (l *SidecarList) DecodeFromURLValues(values url.Values) error {
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l SidecarList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Pagination),
+	)
+}
@@ -0,0 +1,136 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SidecarCreate", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.SidecarCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SidecarCreate)
+		requestBody = map[string]any{
+			"name":          "log-shipper",
+			"command":       "ship-logs",
+			"process_types": []string{"web", "worker"},
+			"memory_in_mb":  64,
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("app-guid", "space-guid")).To(Equal(repositories.CreateSidecarMessage{
+			AppGUID:      "app-guid",
+			SpaceGUID:    "space-guid",
+			Name:         "log-shipper",
+			Command:      "ship-logs",
+			ProcessTypes: []string{"web", "worker"},
+			MemoryMB:     tools.PtrTo[int64](64),
+		}))
+	})
+
+	When("the name is missing", func() {
+		BeforeEach(func() {
+			delete(requestBody, "name")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the command is missing", func() {
+		BeforeEach(func() {
+			delete(requestBody, "command")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "command cannot be blank")
+		})
+	})
+
+	When("the process types are empty", func() {
+		BeforeEach(func() {
+			requestBody["process_types"] = []string{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "process_types cannot be blank")
+		})
+	})
+
+	When("the memory is not positive", func() {
+		BeforeEach(func() {
+			requestBody["memory_in_mb"] = 0
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "memory_in_mb must be no less than 1")
+		})
+	})
+})
+
+var _ = Describe("SidecarUpdate", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.SidecarUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SidecarUpdate)
+		requestBody = map[string]any{
+			"command": "ship-logs --verbose",
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage(repositories.SidecarRecord{
+			GUID:      "sidecar-guid",
+			AppGUID:   "app-guid",
+			SpaceGUID: "space-guid",
+		})).To(Equal(repositories.PatchSidecarMessage{
+			GUID:      "sidecar-guid",
+			AppGUID:   "app-guid",
+			SpaceGUID: "space-guid",
+			Command:   tools.PtrTo("ship-logs --verbose"),
+		}))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			requestBody["name"] = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the process types are empty", func() {
+		BeforeEach(func() {
+			requestBody["process_types"] = []string{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "process_types cannot be blank")
+		})
+	})
+})
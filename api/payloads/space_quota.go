@@ -0,0 +1,115 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SpaceQuotaCreate struct {
+	Name string `json:"name"`
+	QuotaLimits
+	Relationships *SpaceQuotaRelationships `json:"relationships"`
+}
+
+func (c SpaceQuotaCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.QuotaLimits),
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+	)
+}
+
+func (c SpaceQuotaCreate) ToMessage() repositories.CreateSpaceQuotaMessage {
+	message := repositories.CreateSpaceQuotaMessage{
+		Name:             c.Name,
+		Limits:           c.QuotaLimits.toQuotaLimits(),
+		OrganizationGUID: c.Relationships.Organization.Data.GUID,
+	}
+
+	if c.Relationships.Spaces != nil {
+		message.SpaceGUIDs = c.Relationships.Spaces.GUIDs()
+	}
+
+	return message
+}
+
+type SpaceQuotaRelationships struct {
+	Organization *Relationship       `json:"organization"`
+	Spaces       *ToManyRelationship `json:"spaces"`
+}
+
+func (r SpaceQuotaRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Organization, jellidation.NotNil),
+		jellidation.Field(&r.Spaces),
+	)
+}
+
+type SpaceQuotaPatch struct {
+	Name *string `json:"name"`
+	QuotaLimits
+}
+
+func (p SpaceQuotaPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&p.QuotaLimits),
+	)
+}
+
+func (p SpaceQuotaPatch) ToMessage(guid string) repositories.PatchSpaceQuotaMessage {
+	return repositories.PatchSpaceQuotaMessage{
+		GUID:   guid,
+		Name:   p.Name,
+		Limits: p.QuotaLimits.toQuotaLimitsPatch(),
+	}
+}
+
+type SpaceQuotaApply struct {
+	ToManyRelationship
+}
+
+func (a SpaceQuotaApply) ToMessage(guid string) repositories.ApplySpaceQuotaMessage {
+	return repositories.ApplySpaceQuotaMessage{
+		GUID:       guid,
+		SpaceGUIDs: a.GUIDs(),
+	}
+}
+
+type SpaceQuotaList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+	SpaceGUIDs        string
+	Pagination
+}
+
+func (l SpaceQuotaList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *SpaceQuotaList) ToMessage() repositories.ListSpaceQuotasMessage {
+	return repositories.ListSpaceQuotasMessage{
+		GUIDs:             parse.ArrayParam(l.GUIDs),
+		Names:             parse.ArrayParam(l.Names),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+		SpaceGUIDs:        parse.ArrayParam(l.SpaceGUIDs),
+	}
+}
+
+func (l *SpaceQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "space_guids", "per_page", "page"}
+}
+
+func (l *SpaceQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	return l.Pagination.DecodeFromURLValues(values)
+}
@@ -0,0 +1,214 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpaceQuotaCreate", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.SpaceQuotaCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SpaceQuotaCreate)
+		requestBody = map[string]any{
+			"name": "my-quota",
+			"apps": map[string]any{
+				"total_memory_in_mb":       512,
+				"per_process_memory_in_mb": 256,
+			},
+			"routes": map[string]any{
+				"total_routes": nil,
+			},
+			"relationships": map[string]any{
+				"organization": map[string]any{
+					"data": map[string]any{"guid": "org-guid"},
+				},
+				"spaces": map[string]any{
+					"data": []map[string]any{{"guid": "space-guid"}},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage()).To(Equal(repositories.CreateSpaceQuotaMessage{
+			Name: "my-quota",
+			Limits: repositories.QuotaLimits{
+				TotalMemoryMB:    tools.PtrTo[int64](512),
+				InstanceMemoryMB: tools.PtrTo[int64](256),
+			},
+			OrganizationGUID: "org-guid",
+			SpaceGUIDs:       []string{"space-guid"},
+		}))
+	})
+
+	When("the name is missing", func() {
+		BeforeEach(func() {
+			delete(requestBody, "name")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("a limit is negative", func() {
+		BeforeEach(func() {
+			requestBody["apps"] = map[string]any{"per_app_tasks": -1}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be no less than 0")
+		})
+	})
+
+	When("the relationships are missing", func() {
+		BeforeEach(func() {
+			delete(requestBody, "relationships")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the organization relationship is missing", func() {
+		BeforeEach(func() {
+			requestBody["relationships"] = map[string]any{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "organization is required")
+		})
+	})
+
+	When("the spaces relationship is missing", func() {
+		BeforeEach(func() {
+			requestBody["relationships"] = map[string]any{
+				"organization": map[string]any{
+					"data": map[string]any{"guid": "org-guid"},
+				},
+			}
+		})
+
+		It("does not apply the quota to any space", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.ToMessage().SpaceGUIDs).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("SpaceQuotaPatch", func() {
+	var (
+		requestBody    map[string]any
+		decodedPayload *payloads.SpaceQuotaPatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SpaceQuotaPatch)
+		requestBody = map[string]any{
+			"services": map[string]any{
+				"total_service_instances": 7,
+			},
+			"routes": map[string]any{
+				"total_routes": nil,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(requestBody), decodedPayload)
+	})
+
+	It("only patches the limits present in the request", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("quota-guid")).To(Equal(repositories.PatchSpaceQuotaMessage{
+			GUID: "quota-guid",
+			Limits: repositories.QuotaLimitsPatch{
+				TotalServiceInstances: &repositories.QuotaLimitPatch{Value: tools.PtrTo[int64](7)},
+				TotalRoutes:           &repositories.QuotaLimitPatch{},
+			},
+		}))
+	})
+
+	When("a limit is negative", func() {
+		BeforeEach(func() {
+			requestBody["apps"] = map[string]any{"total_instances": -3}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be no less than 0")
+		})
+	})
+})
+
+var _ = Describe("SpaceQuotaApply", func() {
+	It("returns an apply message", func() {
+		decodedPayload := new(payloads.SpaceQuotaApply)
+		Expect(validator.DecodeAndValidateJSONPayload(createJSONRequest(map[string]any{
+			"data": []map[string]any{{"guid": "space-1"}},
+		}), decodedPayload)).To(Succeed())
+
+		Expect(decodedPayload.ToMessage("quota-guid")).To(Equal(repositories.ApplySpaceQuotaMessage{
+			GUID:       "quota-guid",
+			SpaceGUIDs: []string{"space-1"},
+		}))
+	})
+})
+
+var _ = Describe("SpaceQuotaList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedSpaceQuotaList payloads.SpaceQuotaList) {
+				actualSpaceQuotaList, decodeErr := decodeQuery[payloads.SpaceQuotaList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualSpaceQuotaList).To(Equal(expectedSpaceQuotaList))
+			},
+			Entry("guids", "guids=g1,g2", payloads.SpaceQuotaList{GUIDs: "g1,g2"}),
+			Entry("names", "names=n1,n2", payloads.SpaceQuotaList{Names: "n1,n2"}),
+			Entry("organization_guids", "organization_guids=o1,o2", payloads.SpaceQuotaList{OrganizationGUIDs: "o1,o2"}),
+			Entry("space_guids", "space_guids=s1,s2", payloads.SpaceQuotaList{SpaceGUIDs: "s1,s2"}),
+			Entry("page and per_page", "page=2&per_page=10", payloads.SpaceQuotaList{Pagination: payloads.Pagination{Page: 2, PerPage: 10}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.SpaceQuotaList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+			Entry("per_page too large", "per_page=5001", "per_page: must be no greater than 5000"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			spaceQuotaList := payloads.SpaceQuotaList{
+				GUIDs:             "g1,g2",
+				Names:             "n1,n2",
+				OrganizationGUIDs: "o1,o2",
+				SpaceGUIDs:        "s1,s2",
+			}
+			Expect(spaceQuotaList.ToMessage()).To(Equal(repositories.ListSpaceQuotasMessage{
+				GUIDs:             []string{"g1", "g2"},
+				Names:             []string{"n1", "n2"},
+				OrganizationGUIDs: []string{"o1", "o2"},
+				SpaceGUIDs:        []string{"s1", "s2"},
+			}))
+		})
+	})
+})
@@ -148,9 +148,20 @@ var _ = Describe("Space", func() {
 				Entry("names", "names=name", payloads.SpaceList{Names: "name"}),
 				Entry("guids", "guids=guid", payloads.SpaceList{GUIDs: "guid"}),
 				Entry("organization_guids", "organization_guids=org-guid", payloads.SpaceList{OrganizationGUIDs: "org-guid"}),
-				Entry("order_by", "order_by=something", payloads.SpaceList{}),
-				Entry("per_page", "per_page=few", payloads.SpaceList{}),
-				Entry("page", "page=3", payloads.SpaceList{}),
+				Entry("order_by", "order_by=name", payloads.SpaceList{OrderBy: "name"}),
+				Entry("order_by descending", "order_by=-created_at", payloads.SpaceList{OrderBy: "-created_at"}),
+				Entry("per_page", "per_page=10", payloads.SpaceList{Pagination: payloads.Pagination{PerPage: 10}}),
+				Entry("page", "page=3", payloads.SpaceList{Pagination: payloads.Pagination{Page: 3}}),
+			)
+
+			DescribeTable("invalid query",
+				func(query string, expectedErrMsg string) {
+					_, decodeErr := decodeQuery[payloads.SpaceList](query)
+					Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+				},
+				Entry("invalid order_by", "order_by=something", "value must be one of"),
+				Entry("non-numeric per_page", "per_page=few", "invalid syntax"),
+				Entry("per_page too large", "per_page=5001", "per_page: must be no greater than 5000"),
 			)
 		})
 
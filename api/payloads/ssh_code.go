@@ -0,0 +1,32 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	jellidation "github.com/jellydator/validation"
+)
+
+// SSHCodeRequest mirrors the UAA authorization request the cf cli sends to
+// obtain a one-time SSH code
+type SSHCodeRequest struct {
+	ResponseType string `json:"response_type"`
+	ClientID     string `json:"client_id"`
+}
+
+func (r SSHCodeRequest) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.ResponseType, jellidation.Required, validation.OneOf("code")),
+		jellidation.Field(&r.ClientID, jellidation.Required),
+	)
+}
+
+func (r *SSHCodeRequest) SupportedKeys() []string {
+	return []string{"response_type", "client_id", "grant_type", "scope", "state"}
+}
+
+func (r *SSHCodeRequest) DecodeFromURLValues(values url.Values) error {
+	r.ResponseType = values.Get("response_type")
+	r.ClientID = values.Get("client_id")
+	return nil
+}
@@ -0,0 +1,32 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SSHCodeRequest", func() {
+	DescribeTable("valid query",
+		func(query string, expectedRequest payloads.SSHCodeRequest) {
+			actualRequest, decodeErr := decodeQuery[payloads.SSHCodeRequest](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualRequest).To(Equal(expectedRequest))
+		},
+		Entry("code request", "response_type=code&client_id=ssh-proxy", payloads.SSHCodeRequest{ResponseType: "code", ClientID: "ssh-proxy"}),
+		Entry("ignored parameters", "response_type=code&client_id=ssh-proxy&grant_type=authorization_code&scope=openid&state=s",
+			payloads.SSHCodeRequest{ResponseType: "code", ClientID: "ssh-proxy"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.SSHCodeRequest](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("missing response type", "client_id=ssh-proxy", "response_type: cannot be blank"),
+		Entry("unsupported response type", "response_type=token&client_id=ssh-proxy", "value must be one of: code"),
+		Entry("missing client id", "response_type=code", "client_id: cannot be blank"),
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+	)
+})
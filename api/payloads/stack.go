@@ -0,0 +1,35 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type StackList struct {
+	Names string
+	Pagination
+}
+
+func (l StackList) SupportedKeys() []string {
+	return []string{"names", "per_page", "page"}
+}
+
+func (l *StackList) DecodeFromURLValues(values url.Values) error {
+	l.Names = values.Get("names")
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l StackList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l StackList) ToMessage() repositories.ListStacksMessage {
+	return repositories.ListStacksMessage{
+		Names: parse.ArrayParam(l.Names),
+	}
+}
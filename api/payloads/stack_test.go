@@ -0,0 +1,39 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StackList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedStackList payloads.StackList) {
+				actualStackList, decodeErr := decodeQuery[payloads.StackList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualStackList).To(Equal(expectedStackList))
+			},
+			Entry("names", "names=n1,n2", payloads.StackList{Names: "n1,n2"}),
+			Entry("page and per_page", "page=2&per_page=10", payloads.StackList{Pagination: payloads.Pagination{Page: 2, PerPage: 10}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.StackList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			Expect(payloads.StackList{Names: "n1,n2"}.ToMessage()).To(Equal(repositories.ListStacksMessage{
+				Names: []string{"n1", "n2"},
+			}))
+		})
+	})
+})
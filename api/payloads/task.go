@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
@@ -32,16 +33,26 @@ func (p TaskCreate) ToMessage(appRecord repositories.AppRecord) repositories.Cre
 
 type TaskList struct {
 	SequenceIDs []int64
+	OrderBy     string
+	Pagination
+}
+
+func (t TaskList) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.OrderBy, payload_validation.OneOfOrderBy("created_at", "updated_at")),
+		validation.Field(&t.Pagination),
+	)
 }
 
 func (t *TaskList) ToMessage() repositories.ListTaskMessage {
 	return repositories.ListTaskMessage{
 		SequenceIDs: t.SequenceIDs,
+		Pagination:  orderedPagination(t.Pagination, t.OrderBy),
 	}
 }
 
 func (t *TaskList) SupportedKeys() []string {
-	return []string{"sequence_ids", "per_page", "page"}
+	return []string{"sequence_ids", "order_by", "per_page", "page"}
 }
 
 func (a *TaskList) DecodeFromURLValues(values url.Values) error {
@@ -60,7 +71,8 @@ func (a *TaskList) DecodeFromURLValues(values url.Values) error {
 	}
 
 	a.SequenceIDs = ids
-	return nil
+	a.OrderBy = values.Get("order_by")
+	return a.Pagination.DecodeFromURLValues(values)
 }
 
 type TaskUpdate struct {
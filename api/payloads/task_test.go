@@ -34,7 +34,23 @@ var _ = Describe("TaskList", func() {
 		Entry("empty sequence_ids", "sequence_ids=", payloads.TaskList{}, ""),
 		Entry("empty sequence_id", "sequence_ids=1,,3", payloads.TaskList{SequenceIDs: []int64{1, 3}}, ""),
 		Entry("invalid sequence_ids", "sequence_ids=1,two,3", payloads.TaskList{}, "invalid syntax"),
+		Entry("order_by", "order_by=-updated_at", payloads.TaskList{OrderBy: "-updated_at"}, ""),
+		Entry("invalid order_by", "order_by=name", payloads.TaskList{}, "value must be one of"),
 	)
+
+	Describe("ToMessage", func() {
+		It("selects the requested page", func() {
+			taskList := payloads.TaskList{Pagination: payloads.Pagination{Page: 3, PerPage: 10}}
+			Expect(taskList.ToMessage().Pagination).To(Equal(repositories.Pagination{Page: 3, PerPage: 10}))
+		})
+
+		When("the list is ordered", func() {
+			It("lists all tasks, so that they can be sorted", func() {
+				taskList := payloads.TaskList{OrderBy: "created_at", Pagination: payloads.Pagination{Page: 3, PerPage: 10}}
+				Expect(taskList.ToMessage().Pagination).To(Equal(repositories.Pagination{}))
+			})
+		})
+	})
 })
 
 var _ = Describe("TaskCreate", func() {
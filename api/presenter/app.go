@@ -173,8 +173,8 @@ type AppEnvResponse struct {
 func ForAppEnv(envVarRecord repositories.AppEnvRecord) AppEnvResponse {
 	return AppEnvResponse{
 		EnvironmentVariables: envVarRecord.EnvironmentVariables,
-		StagingEnvJSON:       map[string]string{},
-		RunningEnvJSON:       map[string]string{},
+		StagingEnvJSON:       emptyMapIfNil(envVarRecord.StagingEnv),
+		RunningEnvJSON:       emptyMapIfNil(envVarRecord.RunningEnv),
 		SystemEnvJSON:        emptyMapToAnyIfEmpty(envVarRecord.SystemEnv),
 		ApplicationEnvJSON:   emptyMapToAnyIfEmpty(envVarRecord.AppEnv),
 	}
@@ -192,3 +192,25 @@ type AppSSHEnabled struct {
 	Enabled bool   `json:"enabled"`
 	Reason  string `json:"reason"`
 }
+
+type FeatureResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func ForAppSSHFeature(app repositories.AppRecord) FeatureResponse {
+	return FeatureResponse{
+		Name:        "ssh",
+		Description: "Enable SSHing into the app.",
+		Enabled:     app.EnableSSH,
+	}
+}
+
+func ForAppRevisionsFeature(app repositories.AppRecord) FeatureResponse {
+	return FeatureResponse{
+		Name:        "revisions",
+		Description: "Enable versioning of an application",
+		Enabled:     app.EnableRevisions,
+	}
+}
@@ -227,6 +227,8 @@ var _ = Describe("App", func() {
 						"application_name": "my-app",
 					},
 				},
+				StagingEnv: map[string]string{"STAGING_VAR": "staging-val"},
+				RunningEnv: map[string]string{"RUNNING_VAR": "running-val"},
 			}
 		})
 
@@ -239,8 +241,12 @@ var _ = Describe("App", func() {
 
 		It("returns the expected output", func() {
 			Expect(output).To(MatchJSON(`{
-				"staging_env_json": {},
-				"running_env_json": {},
+				"staging_env_json": {
+					"STAGING_VAR": "staging-val"
+				},
+				"running_env_json": {
+					"RUNNING_VAR": "running-val"
+				},
 				"environment_variables": {
 					"VAR": "VAL"
 				},
@@ -278,6 +284,18 @@ var _ = Describe("App", func() {
 				Expect(output).To(MatchJSONPath("$.application_env_json", Not(BeNil())))
 			})
 		})
+
+		When("the environment variable groups are nil", func() {
+			BeforeEach(func() {
+				record.StagingEnv = nil
+				record.RunningEnv = nil
+			})
+
+			It("returns empty maps", func() {
+				Expect(output).To(MatchJSONPath("$.staging_env_json", Not(BeNil())))
+				Expect(output).To(MatchJSONPath("$.running_env_json", Not(BeNil())))
+			})
+		})
 	})
 
 	Describe("App Env Vars", func() {
@@ -0,0 +1,79 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	auditEventsBase = "/v3/audit_events"
+)
+
+type AuditEventResponse struct {
+	GUID          string                   `json:"guid"`
+	CreatedAt     string                   `json:"created_at"`
+	UpdatedAt     string                   `json:"updated_at"`
+	Type          string                   `json:"type"`
+	Actor         AuditEventActorResponse  `json:"actor"`
+	Target        AuditEventTargetResponse `json:"target"`
+	Data          map[string]any           `json:"data"`
+	Space         *RelationshipData        `json:"space"`
+	Organization  *RelationshipData        `json:"organization"`
+	CorrelationID string                   `json:"correlation_id"`
+	Links         AuditEventLinks          `json:"links"`
+}
+
+type AuditEventActorResponse struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventTargetResponse struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAuditEvent(auditEvent repositories.AuditEventRecord, baseURL url.URL) AuditEventResponse {
+	var space *RelationshipData
+	if auditEvent.SpaceGUID != "" {
+		space = &RelationshipData{GUID: auditEvent.SpaceGUID}
+	}
+
+	var organization *RelationshipData
+	if auditEvent.OrganizationGUID != "" {
+		organization = &RelationshipData{GUID: auditEvent.OrganizationGUID}
+	}
+
+	return AuditEventResponse{
+		GUID:      auditEvent.GUID,
+		CreatedAt: formatTimestamp(&auditEvent.CreatedAt),
+		UpdatedAt: formatTimestamp(auditEvent.UpdatedAt),
+		Type:      auditEvent.Type,
+		Actor: AuditEventActorResponse{
+			GUID: auditEvent.Actor.GUID,
+			Type: auditEvent.Actor.Type,
+			Name: auditEvent.Actor.Name,
+		},
+		Target: AuditEventTargetResponse{
+			GUID: auditEvent.Target.GUID,
+			Type: auditEvent.Target.Type,
+			Name: auditEvent.Target.Name,
+		},
+		Data:          emptyMapIfNil(auditEvent.Data),
+		Space:         space,
+		Organization:  organization,
+		CorrelationID: auditEvent.CorrelationID,
+		Links: AuditEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(auditEventsBase, auditEvent.GUID).build(),
+			},
+		},
+	}
+}
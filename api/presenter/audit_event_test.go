@@ -0,0 +1,107 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Events", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.AuditEventRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.AuditEventRecord{
+			GUID: "audit-event-guid",
+			Type: "audit.app.create",
+			Actor: repositories.AuditEventActor{
+				GUID: "alice",
+				Type: "user",
+				Name: "alice",
+			},
+			Target: repositories.AuditEventTarget{
+				GUID: "app-guid",
+				Type: "app",
+				Name: "my-app",
+			},
+			SpaceGUID:        "space-guid",
+			OrganizationGUID: "org-guid",
+			CorrelationID:    "correlation-id",
+			Data:             map[string]any{"request": map[string]any{"name": "my-app"}},
+			CreatedAt:        time.UnixMilli(1000),
+			UpdatedAt:        tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForAuditEvent(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected audit event json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "audit-event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"type": "audit.app.create",
+			"actor": {
+				"guid": "alice",
+				"type": "user",
+				"name": "alice"
+			},
+			"target": {
+				"guid": "app-guid",
+				"type": "app",
+				"name": "my-app"
+			},
+			"data": {
+				"request": {
+					"name": "my-app"
+				}
+			},
+			"space": {
+				"guid": "space-guid"
+			},
+			"organization": {
+				"guid": "org-guid"
+			},
+			"correlation_id": "correlation-id",
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/audit_events/audit-event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the event is not in a space", func() {
+		BeforeEach(func() {
+			record.SpaceGUID = ""
+			record.Data = nil
+		})
+
+		It("presents a null space and empty data", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.space", BeNil()),
+				MatchJSONPath("$.organization.guid", "org-guid"),
+				MatchJSONPath("$.data", BeEmpty()),
+			))
+		})
+	})
+})
@@ -19,21 +19,30 @@ type DropletGUID struct {
 	Guid string `json:"guid"`
 }
 type DeploymentResponse struct {
-	GUID          string           `json:"guid"`
-	Status        DeploymentStatus `json:"status"`
-	Droplet       DropletGUID      `json:"droplet"`
-	Relationships Relationships    `json:"relationships"`
-	Links         DeploymentLinks  `json:"links"`
+	GUID            string           `json:"guid"`
+	State           string           `json:"state"`
+	Strategy        string           `json:"strategy"`
+	Status          DeploymentStatus `json:"status"`
+	Droplet         DropletGUID      `json:"droplet"`
+	PreviousDroplet DropletGUID      `json:"previous_droplet"`
+	CreatedAt       string           `json:"created_at"`
+	UpdatedAt       string           `json:"updated_at"`
+	Relationships   Relationships    `json:"relationships"`
+	Links           DeploymentLinks  `json:"links"`
 }
 
 type DeploymentLinks struct {
-	Self Link `json:"self"`
-	App  Link `json:"app"`
+	Self     Link  `json:"self"`
+	App      Link  `json:"app"`
+	Cancel   *Link `json:"cancel,omitempty"`
+	Continue *Link `json:"continue,omitempty"`
 }
 
 func ForDeployment(responseDeployment repositories.DeploymentRecord, baseURL url.URL) DeploymentResponse {
-	return DeploymentResponse{
-		GUID: responseDeployment.GUID,
+	response := DeploymentResponse{
+		GUID:     responseDeployment.GUID,
+		State:    string(responseDeployment.State),
+		Strategy: string(responseDeployment.Strategy),
 		Status: DeploymentStatus{
 			Value:  string(responseDeployment.Status.Value),
 			Reason: string(responseDeployment.Status.Reason),
@@ -41,10 +50,15 @@ func ForDeployment(responseDeployment repositories.DeploymentRecord, baseURL url
 		Droplet: DropletGUID{
 			Guid: responseDeployment.DropletGUID,
 		},
+		PreviousDroplet: DropletGUID{
+			Guid: responseDeployment.PreviousDropletGUID,
+		},
+		CreatedAt: formatTimestamp(&responseDeployment.CreatedAt),
+		UpdatedAt: formatTimestamp(responseDeployment.UpdatedAt),
 		Relationships: map[string]Relationship{
 			"app": {
 				Data: &RelationshipData{
-					GUID: responseDeployment.GUID,
+					GUID: responseDeployment.AppGUID,
 				},
 			},
 		},
@@ -53,8 +67,24 @@ func ForDeployment(responseDeployment repositories.DeploymentRecord, baseURL url
 				HRef: buildURL(baseURL).appendPath(deploymentsBase, responseDeployment.GUID).build(),
 			},
 			App: Link{
-				HRef: buildURL(baseURL).appendPath(appsBase, responseDeployment.GUID).build(),
+				HRef: buildURL(baseURL).appendPath(appsBase, responseDeployment.AppGUID).build(),
 			},
 		},
 	}
+
+	if responseDeployment.Status.Value == repositories.DeploymentStatusValueActive {
+		response.Links.Cancel = &Link{
+			HRef:   buildURL(baseURL).appendPath(deploymentsBase, responseDeployment.GUID, "actions", "cancel").build(),
+			Method: "POST",
+		}
+	}
+
+	if responseDeployment.Status.Reason == repositories.DeploymentStatusReasonPaused {
+		response.Links.Continue = &Link{
+			HRef:   buildURL(baseURL).appendPath(deploymentsBase, responseDeployment.GUID, "actions", "continue").build(),
+			Method: "POST",
+		}
+	}
+
+	return response
 }
@@ -3,9 +3,12 @@ package presenter_test
 import (
 	"encoding/json"
 	"net/url"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -23,11 +26,17 @@ var _ = Describe("Deployments", func() {
 		baseURL, err = url.Parse("https://api.example.org")
 		Expect(err).NotTo(HaveOccurred())
 		record = repositories.DeploymentRecord{
-			GUID:        "app-guid",
-			DropletGUID: "droplet-guid",
+			GUID:                "deployment-guid",
+			AppGUID:             "app-guid",
+			DropletGUID:         "droplet-guid",
+			PreviousDropletGUID: "previous-droplet-guid",
+			Strategy:            "rolling",
+			CreatedAt:           time.UnixMilli(1000),
+			UpdatedAt:           tools.PtrTo(time.UnixMilli(2000)),
+			State:               "DEPLOYED",
 			Status: repositories.DeploymentStatus{
-				Value:  "deployment-status-value",
-				Reason: "deployment-status-reason",
+				Value:  "FINALIZED",
+				Reason: "DEPLOYED",
 			},
 		}
 	})
@@ -41,14 +50,21 @@ var _ = Describe("Deployments", func() {
 
 	It("produces expected deployment json", func() {
 		Expect(output).To(MatchJSON(`{
-			"guid": "app-guid",
+			"guid": "deployment-guid",
+			"state": "DEPLOYED",
+			"strategy": "rolling",
 			"status": {
-				"value": "deployment-status-value",
-				"reason": "deployment-status-reason"
+				"value": "FINALIZED",
+				"reason": "DEPLOYED"
 			},
 			"droplet": {
 				"guid": "droplet-guid"
 			},
+			"previous_droplet": {
+				"guid": "previous-droplet-guid"
+			},
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
 			"relationships": {
 				"app": {
 					"data": {
@@ -58,7 +74,7 @@ var _ = Describe("Deployments", func() {
 			},
 			"links": {
 				"self": {
-					"href": "https://api.example.org/v3/deployments/app-guid"
+					"href": "https://api.example.org/v3/deployments/deployment-guid"
 				},
 				"app": {
 					"href": "https://api.example.org/v3/apps/app-guid"
@@ -66,4 +82,44 @@ var _ = Describe("Deployments", func() {
 			}
 		}`))
 	})
+
+	When("the deployment is active", func() {
+		BeforeEach(func() {
+			record.State = repositories.DeploymentStateDeploying
+			record.Status = repositories.DeploymentStatus{
+				Value:  repositories.DeploymentStatusValueActive,
+				Reason: repositories.DeploymentStatusReasonDeploying,
+			}
+		})
+
+		It("includes the cancel link", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.links.cancel.href", "https://api.example.org/v3/deployments/deployment-guid/actions/cancel"),
+				MatchJSONPath("$.links.cancel.method", "POST"),
+			))
+		})
+
+		It("does not include the continue link", func() {
+			Expect(output).NotTo(ContainSubstring("continue"))
+		})
+	})
+
+	When("the canary deployment is paused", func() {
+		BeforeEach(func() {
+			record.Strategy = repositories.DeploymentStrategyCanary
+			record.State = repositories.DeploymentStateDeploying
+			record.Status = repositories.DeploymentStatus{
+				Value:  repositories.DeploymentStatusValueActive,
+				Reason: repositories.DeploymentStatusReasonPaused,
+			}
+		})
+
+		It("includes the continue link", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.strategy", "canary"),
+				MatchJSONPath("$.links.continue.href", "https://api.example.org/v3/deployments/deployment-guid/actions/continue"),
+				MatchJSONPath("$.links.continue.method", "POST"),
+			))
+		})
+	})
 })
@@ -11,11 +11,11 @@ const (
 )
 
 type DomainResponse struct {
-	Name               string   `json:"name"`
-	GUID               string   `json:"guid"`
-	Internal           bool     `json:"internal"`
-	RouterGroup        *string  `json:"router_group"`
-	SupportedProtocols []string `json:"supported_protocols"`
+	Name               string             `json:"name"`
+	GUID               string             `json:"guid"`
+	Internal           bool               `json:"internal"`
+	RouterGroup        *DomainRouterGroup `json:"router_group"`
+	SupportedProtocols []string           `json:"supported_protocols"`
 
 	CreatedAt     string              `json:"created_at"`
 	UpdatedAt     string              `json:"updated_at"`
@@ -24,6 +24,10 @@ type DomainResponse struct {
 	Links         DomainLinks         `json:"links"`
 }
 
+type DomainRouterGroup struct {
+	GUID string `json:"guid"`
+}
+
 type DomainLinks struct {
 	Self              Link  `json:"self"`
 	RouteReservations Link  `json:"route_reservations"`
@@ -31,23 +35,15 @@ type DomainLinks struct {
 }
 
 type DomainRelationships struct {
-	Organization        `json:"organization"`
-	SharedOrganizations `json:"shared_organizations"`
-}
-
-type Organization struct {
-	Data *string `json:"data"`
-}
-
-type SharedOrganizations struct {
-	Data []string `json:"data"`
+	Organization        Relationship       `json:"organization"`
+	SharedOrganizations ToManyRelationship `json:"shared_organizations"`
 }
 
 func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL) DomainResponse {
-	return DomainResponse{
+	response := DomainResponse{
 		Name:               responseDomain.Name,
 		GUID:               responseDomain.GUID,
-		Internal:           false,
+		Internal:           responseDomain.Internal,
 		RouterGroup:        nil,
 		SupportedProtocols: []string{"http"},
 		CreatedAt:          formatTimestamp(&responseDomain.CreatedAt),
@@ -58,11 +54,9 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL) Domain
 			Annotations: emptyMapIfNil(responseDomain.Annotations),
 		},
 		Relationships: DomainRelationships{
-			Organization: Organization{
-				Data: nil,
-			},
-			SharedOrganizations: SharedOrganizations{
-				Data: []string{},
+			Organization: forDomainOrganization(responseDomain),
+			SharedOrganizations: ToManyRelationship{
+				Data: toManyRelationshipData(responseDomain.SharedOrganizationGUIDs),
 			},
 		},
 		Links: DomainLinks{
@@ -75,4 +69,35 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL) Domain
 			RouterGroup: nil,
 		},
 	}
+
+	if responseDomain.RouterGroupGUID != "" {
+		response.RouterGroup = &DomainRouterGroup{GUID: responseDomain.RouterGroupGUID}
+		response.SupportedProtocols = []string{"tcp"}
+		response.Links.RouterGroup = &Link{
+			HRef: buildURL(baseURL).appendPath(routerGroupsBase, responseDomain.RouterGroupGUID).build(),
+		}
+	}
+
+	return response
+}
+
+func forDomainOrganization(responseDomain repositories.DomainRecord) Relationship {
+	if responseDomain.OrganizationGUID == "" {
+		return Relationship{}
+	}
+
+	return Relationship{
+		Data: &RelationshipData{GUID: responseDomain.OrganizationGUID},
+	}
+}
+
+func ForDomainSharedOrganizations(domainGUID string, orgGUIDs []string, baseURL url.URL) ToManyRelationshipResponse {
+	return ToManyRelationshipResponse{
+		Data: toManyRelationshipData(orgGUIDs),
+		Links: ToManyRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(domainsBase, domainGUID, "relationships", "shared_organizations").build(),
+			},
+		},
+	}
 }
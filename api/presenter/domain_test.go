@@ -82,6 +82,40 @@ var _ = Describe("Domains", func() {
 		}`))
 	})
 
+	When("the domain is internal", func() {
+		BeforeEach(func() {
+			record.Internal = true
+		})
+
+		It("presents the domain as internal", func() {
+			Expect(output).To(MatchJSONPath("$.internal", BeTrue()))
+		})
+	})
+
+	When("the domain is private", func() {
+		BeforeEach(func() {
+			record.OrganizationGUID = "org-guid"
+			record.SharedOrganizationGUIDs = []string{"shared-org-guid"}
+		})
+
+		It("presents the domain organizations", func() {
+			Expect(output).To(MatchJSONPath("$.relationships.organization.data.guid", "org-guid"))
+			Expect(output).To(MatchJSONPath("$.relationships.shared_organizations.data[*].guid", ConsistOf("shared-org-guid")))
+		})
+	})
+
+	When("the domain is assigned to a router group", func() {
+		BeforeEach(func() {
+			record.RouterGroupGUID = "default-tcp"
+		})
+
+		It("presents the domain as a tcp domain", func() {
+			Expect(output).To(MatchJSONPath("$.router_group.guid", "default-tcp"))
+			Expect(output).To(MatchJSONPath("$.supported_protocols", ConsistOf("tcp")))
+			Expect(output).To(MatchJSONPath("$.links.router_group.href", "https://api.example.org/routing/v1/router_groups/default-tcp"))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
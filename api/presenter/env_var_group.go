@@ -0,0 +1,35 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	envVarGroupsBase = "/v3/environment_variable_groups"
+)
+
+type EnvVarGroupResponse struct {
+	UpdatedAt string            `json:"updated_at"`
+	Name      string            `json:"name"`
+	Var       map[string]string `json:"var"`
+	Links     EnvVarGroupLinks  `json:"links"`
+}
+
+type EnvVarGroupLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForEnvVarGroup(envVarGroup repositories.EnvVarGroupRecord, baseURL url.URL) EnvVarGroupResponse {
+	return EnvVarGroupResponse{
+		UpdatedAt: formatTimestamp(envVarGroup.UpdatedAt),
+		Name:      envVarGroup.Name,
+		Var:       emptyMapIfNil(envVarGroup.Var),
+		Links: EnvVarGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(envVarGroupsBase, envVarGroup.Name).build(),
+			},
+		},
+	}
+}
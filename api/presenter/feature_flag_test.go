@@ -0,0 +1,67 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature Flags", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.FeatureFlagRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		updatedAt := time.UnixMilli(2000).UTC()
+		record = repositories.FeatureFlagRecord{
+			Name:               "task_creation",
+			Enabled:            false,
+			CustomErrorMessage: "no tasks for you",
+			UpdatedAt:          &updatedAt,
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForFeatureFlag(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"name": "task_creation",
+			"enabled": false,
+			"updated_at": "1970-01-01T00:00:02Z",
+			"custom_error_message": "no tasks for you",
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/feature_flags/task_creation"
+				}
+			}
+		}`))
+	})
+
+	When("the feature flag has no custom error message", func() {
+		BeforeEach(func() {
+			record.CustomErrorMessage = ""
+		})
+
+		It("returns a null custom error message", func() {
+			Expect(output).To(MatchJSONPath("$.custom_error_message", BeNil()))
+		})
+	})
+})
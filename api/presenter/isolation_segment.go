@@ -0,0 +1,77 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	isolationSegmentsBase = "/v3/isolation_segments"
+)
+
+type IsolationSegmentResponse struct {
+	GUID      string                `json:"guid"`
+	CreatedAt string                `json:"created_at"`
+	UpdatedAt string                `json:"updated_at"`
+	Name      string                `json:"name"`
+	Links     IsolationSegmentLinks `json:"links"`
+}
+
+type IsolationSegmentLinks struct {
+	Self          Link `json:"self"`
+	Organizations Link `json:"organizations"`
+}
+
+func ForIsolationSegment(record repositories.IsolationSegmentRecord, baseURL url.URL) IsolationSegmentResponse {
+	return IsolationSegmentResponse{
+		GUID:      record.GUID,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Name:      record.Name,
+		Links: IsolationSegmentLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID).build(),
+			},
+			Organizations: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+func ForIsolationSegmentOrganizations(isolationSegmentGUID string, orgGUIDs []string, baseURL url.URL) ToManyRelationshipResponse {
+	return ToManyRelationshipResponse{
+		Data: toManyRelationshipData(orgGUIDs),
+		Links: ToManyRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentGUID, "relationships", "organizations").build(),
+			},
+		},
+	}
+}
+
+type SpaceIsolationSegmentResponse struct {
+	Relationship `json:",inline"`
+	Links        SpaceIsolationSegmentLinks `json:"links"`
+}
+
+type SpaceIsolationSegmentLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForSpaceIsolationSegment(spaceGUID string, isolationSegmentGUID string, baseURL url.URL) SpaceIsolationSegmentResponse {
+	response := SpaceIsolationSegmentResponse{
+		Links: SpaceIsolationSegmentLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spacesBase, spaceGUID, "relationships", "isolation_segment").build(),
+			},
+		},
+	}
+
+	if isolationSegmentGUID != "" {
+		response.Data = &RelationshipData{GUID: isolationSegmentGUID}
+	}
+
+	return response
+}
@@ -0,0 +1,126 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Isolation Segments", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForIsolationSegment", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForIsolationSegment(repositories.IsolationSegmentRecord{
+				GUID:              "segment-guid",
+				Name:              "my-segment",
+				OrganizationGUIDs: []string{"org-1"},
+				CreatedAt:         time.UnixMilli(1000),
+				UpdatedAt:         tools.PtrTo(time.UnixMilli(2000)),
+			}, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected isolation segment json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "segment-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "my-segment",
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid"
+					},
+					"organizations": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid/organizations"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForIsolationSegmentOrganizations", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForIsolationSegmentOrganizations("segment-guid", []string{"org-1"}, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected relationship json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [
+					{ "guid": "org-1" }
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid/relationships/organizations"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForSpaceIsolationSegment", func() {
+		var isolationSegmentGUID string
+
+		BeforeEach(func() {
+			isolationSegmentGUID = "segment-guid"
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForSpaceIsolationSegment("space-guid", isolationSegmentGUID, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected relationship json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": {
+					"guid": "segment-guid"
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"
+					}
+				}
+			}`))
+		})
+
+		When("the space has no isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentGUID = ""
+			})
+
+			It("renders a null relationship", func() {
+				Expect(output).To(MatchJSON(`{
+					"data": null,
+					"links": {
+						"self": {
+							"href": "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"
+						}
+					}
+				}`))
+			})
+		})
+	})
+})
@@ -24,6 +24,12 @@ const (
 	DomainDeleteOperation        = "domain.delete"
 	RoleDeleteOperation          = "role.delete"
 	ServiceBrokerCreateOperation = "service_broker.create"
+	OrgQuotaDeleteOperation      = "organization_quota.delete"
+	SpaceQuotaDeleteOperation    = "space_quota.delete"
+	SecurityGroupDeleteOperation = "security_group.delete"
+
+	ServiceInstanceCreateOperation = "service_instance.create"
+	ServiceInstanceDeleteOperation = "service_instance.delete"
 )
 
 var (
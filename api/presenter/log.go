@@ -14,9 +14,10 @@ type LogCacheReadResponseEnvelopes struct {
 }
 
 type LogCacheReadResponseBatch struct {
-	Timestamp int64                   `json:"timestamp"`
-	Log       LogCacheReadResponseLog `json:"log"`
-	Tags      map[string]string       `json:"tags,omitempty"`
+	Timestamp  int64                   `json:"timestamp"`
+	InstanceID string                  `json:"instance_id,omitempty"`
+	Log        LogCacheReadResponseLog `json:"log"`
+	Tags       map[string]string       `json:"tags,omitempty"`
 }
 
 type LogCacheReadResponseLog struct {
@@ -28,7 +29,8 @@ func ForLogs(logRecords []repositories.LogRecord) LogCacheReadResponse {
 	envelopes := make([]LogCacheReadResponseBatch, 0, len(logRecords))
 	for _, logRecord := range logRecords {
 		batch := LogCacheReadResponseBatch{
-			Timestamp: logRecord.Timestamp,
+			Timestamp:  logRecord.Timestamp,
+			InstanceID: logRecord.InstanceIndex,
 			Log: LogCacheReadResponseLog{
 				Payload: []byte(logRecord.Message),
 				Type:    loggregator_v2.Log_OUT,
@@ -19,8 +19,9 @@ var _ = Describe("LogCache", func() {
 	BeforeEach(func() {
 		records = []repositories.LogRecord{
 			{
-				Message:   "message-1",
-				Timestamp: 123,
+				Message:       "message-1",
+				Timestamp:     123,
+				InstanceIndex: "1",
 				Tags: map[string]string{
 					"foo": "bar",
 				},
@@ -45,6 +46,7 @@ var _ = Describe("LogCache", func() {
 				"batch": [
 					{
 						"timestamp": 123,
+						"instance_id": "1",
 						"log": {
 							"payload": "bWVzc2FnZS0x",
 							"type": 0
@@ -0,0 +1,66 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/presenter"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifest Diff", func() {
+	var (
+		output []byte
+		diff   []manifest.DiffEntry
+	)
+
+	BeforeEach(func() {
+		diff = []manifest.DiffEntry{
+			{Op: "add", Path: "/applications/0/routes/1", Value: map[string]any{"route": "foo.example.com"}},
+			{Op: "remove", Path: "/applications/0/buildpacks/0", Was: "java_buildpack"},
+			{Op: "replace", Path: "/applications/0/processes/0/instances", Was: 1, Value: 0},
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForManifestDiff(diff)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("renders the diff entries", func() {
+		Expect(output).To(MatchJSON(`{
+			"diff": [
+				{
+					"op": "add",
+					"path": "/applications/0/routes/1",
+					"value": {"route": "foo.example.com"}
+				},
+				{
+					"op": "remove",
+					"path": "/applications/0/buildpacks/0",
+					"was": "java_buildpack"
+				},
+				{
+					"op": "replace",
+					"path": "/applications/0/processes/0/instances",
+					"was": 1,
+					"value": 0
+				}
+			]
+		}`))
+	})
+
+	When("there is no diff", func() {
+		BeforeEach(func() {
+			diff = nil
+		})
+
+		It("renders an empty diff", func() {
+			Expect(output).To(MatchJSON(`{"diff": []}`))
+		})
+	})
+})
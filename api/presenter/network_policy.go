@@ -0,0 +1,56 @@
+package presenter
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type NetworkPoliciesResponse struct {
+	TotalPolicies int                     `json:"total_policies"`
+	Policies      []NetworkPolicyResponse `json:"policies"`
+}
+
+type NetworkPolicyResponse struct {
+	Source      NetworkPolicySource      `json:"source"`
+	Destination NetworkPolicyDestination `json:"destination"`
+}
+
+type NetworkPolicySource struct {
+	ID string `json:"id"`
+}
+
+type NetworkPolicyDestination struct {
+	ID       string             `json:"id"`
+	Protocol string             `json:"protocol"`
+	Ports    NetworkPolicyPorts `json:"ports"`
+}
+
+type NetworkPolicyPorts struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+// ForNetworkPolicies presents the policies the way the CF policy server does,
+// i.e. without links or pagination
+func ForNetworkPolicies(records []repositories.NetworkPolicyRecord) NetworkPoliciesResponse {
+	policies := make([]NetworkPolicyResponse, 0, len(records))
+	for _, record := range records {
+		policies = append(policies, NetworkPolicyResponse{
+			Source: NetworkPolicySource{
+				ID: record.SourceAppGUID,
+			},
+			Destination: NetworkPolicyDestination{
+				ID:       record.DestinationAppGUID,
+				Protocol: record.Protocol,
+				Ports: NetworkPolicyPorts{
+					Start: record.StartPort,
+					End:   record.EndPort,
+				},
+			},
+		})
+	}
+
+	return NetworkPoliciesResponse{
+		TotalPolicies: len(policies),
+		Policies:      policies,
+	}
+}
@@ -0,0 +1,66 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicies", func() {
+	var (
+		records []repositories.NetworkPolicyRecord
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		records = []repositories.NetworkPolicyRecord{{
+			SourceAppGUID:      "source-app",
+			DestinationAppGUID: "destination-app",
+			Protocol:           "tcp",
+			StartPort:          8080,
+			EndPort:            8090,
+		}}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForNetworkPolicies(records))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected network policies json", func() {
+		Expect(output).To(MatchJSON(`{
+			"total_policies": 1,
+			"policies": [{
+				"source": {
+					"id": "source-app"
+				},
+				"destination": {
+					"id": "destination-app",
+					"protocol": "tcp",
+					"ports": {
+						"start": 8080,
+						"end": 8090
+					}
+				}
+			}]
+		}`))
+	})
+
+	When("there are no policies", func() {
+		BeforeEach(func() {
+			records = nil
+		})
+
+		It("presents an empty list", func() {
+			Expect(output).To(MatchJSON(`{
+				"total_policies": 0,
+				"policies": []
+			}`))
+		})
+	})
+})
@@ -0,0 +1,69 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	orgQuotasBase = "/v3/organization_quotas"
+)
+
+type OrgQuotaResponse struct {
+	GUID          string                `json:"guid"`
+	CreatedAt     string                `json:"created_at"`
+	UpdatedAt     string                `json:"updated_at"`
+	Name          string                `json:"name"`
+	Apps          AppsQuotaResponse     `json:"apps"`
+	Services      ServicesQuotaResponse `json:"services"`
+	Routes        RoutesQuotaResponse   `json:"routes"`
+	Domains       DomainsQuotaResponse  `json:"domains"`
+	Relationships OrgQuotaRelationships `json:"relationships"`
+	Links         OrgQuotaLinks         `json:"links"`
+}
+
+type DomainsQuotaResponse struct {
+	TotalDomains *int64 `json:"total_domains"`
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+type OrgQuotaLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForOrgQuota(record repositories.OrgQuotaRecord, baseURL url.URL) OrgQuotaResponse {
+	return OrgQuotaResponse{
+		GUID:      record.GUID,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Name:      record.Name,
+		Apps:      forAppsQuota(record.Limits),
+		Services:  forServicesQuota(record.Limits),
+		Routes:    forRoutesQuota(record.Limits),
+		Relationships: OrgQuotaRelationships{
+			Organizations: ToManyRelationship{
+				Data: toManyRelationshipData(record.OrganizationGUIDs),
+			},
+		},
+		Links: OrgQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgQuotasBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForOrgQuotaOrganizations(quotaGUID string, orgGUIDs []string, baseURL url.URL) ToManyRelationshipResponse {
+	return ToManyRelationshipResponse{
+		Data: toManyRelationshipData(orgGUIDs),
+		Links: ToManyRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgQuotasBase, quotaGUID, "relationships", "organizations").build(),
+			},
+		},
+	}
+}
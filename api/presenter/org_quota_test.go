@@ -0,0 +1,128 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Org Quotas", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForOrgQuota", func() {
+		var record repositories.OrgQuotaRecord
+
+		BeforeEach(func() {
+			record = repositories.OrgQuotaRecord{
+				GUID: "quota-guid",
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					TotalMemoryMB:         tools.PtrTo[int64](1024),
+					InstanceMemoryMB:      tools.PtrTo[int64](256),
+					TotalAppInstances:     tools.PtrTo[int64](10),
+					TotalServiceInstances: tools.PtrTo[int64](3),
+				},
+				OrganizationGUIDs: []string{"org-1", "org-2"},
+				CreatedAt:         time.UnixMilli(1000),
+				UpdatedAt:         tools.PtrTo(time.UnixMilli(2000)),
+			}
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForOrgQuota(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected org quota json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "quota-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "my-quota",
+				"apps": {
+					"total_memory_in_mb": 1024,
+					"per_process_memory_in_mb": 256,
+					"log_rate_limit_in_bytes_per_second": null,
+					"total_instances": 10,
+					"per_app_tasks": null
+				},
+				"services": {
+					"paid_services_allowed": true,
+					"total_service_instances": 3,
+					"total_service_keys": null
+				},
+				"routes": {
+					"total_routes": null,
+					"total_reserved_ports": null
+				},
+				"domains": {
+					"total_domains": null
+				},
+				"relationships": {
+					"organizations": {
+						"data": [
+							{ "guid": "org-1" },
+							{ "guid": "org-2" }
+						]
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/organization_quotas/quota-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the quota is not applied to any organization", func() {
+			BeforeEach(func() {
+				record.OrganizationGUIDs = nil
+			})
+
+			It("renders an empty organizations relationship", func() {
+				Expect(output).To(MatchJSONPath("$.relationships.organizations.data", BeEmpty()))
+			})
+		})
+	})
+
+	Describe("ForOrgQuotaOrganizations", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForOrgQuotaOrganizations("quota-guid", []string{"org-1"}, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected relationship json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [
+					{ "guid": "org-1" }
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/organization_quotas/quota-guid/relationships/organizations"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package presenter
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type AppsQuotaResponse struct {
+	TotalMemoryInMB              *int64 `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB         *int64 `json:"per_process_memory_in_mb"`
+	LogRateLimitInBytesPerSecond *int64 `json:"log_rate_limit_in_bytes_per_second"`
+	TotalInstances               *int64 `json:"total_instances"`
+	PerAppTasks                  *int64 `json:"per_app_tasks"`
+}
+
+type ServicesQuotaResponse struct {
+	PaidServicesAllowed   bool   `json:"paid_services_allowed"`
+	TotalServiceInstances *int64 `json:"total_service_instances"`
+	TotalServiceKeys      *int64 `json:"total_service_keys"`
+}
+
+type RoutesQuotaResponse struct {
+	TotalRoutes        *int64 `json:"total_routes"`
+	TotalReservedPorts *int64 `json:"total_reserved_ports"`
+}
+
+type ToManyRelationship struct {
+	Data []RelationshipData `json:"data"`
+}
+
+type ToManyRelationshipResponse struct {
+	Data  []RelationshipData      `json:"data"`
+	Links ToManyRelationshipLinks `json:"links"`
+}
+
+type ToManyRelationshipLinks struct {
+	Self Link `json:"self"`
+}
+
+func forAppsQuota(limits repositories.QuotaLimits) AppsQuotaResponse {
+	return AppsQuotaResponse{
+		TotalMemoryInMB:      limits.TotalMemoryMB,
+		PerProcessMemoryInMB: limits.InstanceMemoryMB,
+		TotalInstances:       limits.TotalAppInstances,
+		PerAppTasks:          limits.AppTasks,
+	}
+}
+
+func forServicesQuota(limits repositories.QuotaLimits) ServicesQuotaResponse {
+	return ServicesQuotaResponse{
+		PaidServicesAllowed:   true,
+		TotalServiceInstances: limits.TotalServiceInstances,
+	}
+}
+
+func forRoutesQuota(limits repositories.QuotaLimits) RoutesQuotaResponse {
+	return RoutesQuotaResponse{
+		TotalRoutes: limits.TotalRoutes,
+	}
+}
+
+func toManyRelationshipData(guids []string) []RelationshipData {
+	data := []RelationshipData{}
+	for _, guid := range guids {
+		data = append(data, RelationshipData{GUID: guid})
+	}
+	return data
+}
@@ -0,0 +1,78 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	revisionsBase = "/v3/revisions"
+)
+
+type RevisionResponse struct {
+	GUID          string                             `json:"guid"`
+	Version       int                                `json:"version"`
+	Droplet       DropletGUID                        `json:"droplet"`
+	Processes     map[string]RevisionProcessResponse `json:"processes"`
+	Sidecars      []any                              `json:"sidecars"`
+	Description   string                             `json:"description"`
+	Deployable    bool                               `json:"deployable"`
+	CreatedAt     string                             `json:"created_at"`
+	UpdatedAt     string                             `json:"updated_at"`
+	Relationships Relationships                      `json:"relationships"`
+	Metadata      Metadata                           `json:"metadata"`
+	Links         RevisionLinks                      `json:"links"`
+}
+
+type RevisionProcessResponse struct {
+	Command *string `json:"command"`
+}
+
+type RevisionLinks struct {
+	Self Link `json:"self"`
+	App  Link `json:"app"`
+}
+
+func ForRevision(revision repositories.RevisionRecord, baseURL url.URL) RevisionResponse {
+	processes := map[string]RevisionProcessResponse{}
+	for processType, command := range revision.Processes {
+		processes[processType] = RevisionProcessResponse{}
+		if command != "" {
+			processes[processType] = RevisionProcessResponse{Command: &command}
+		}
+	}
+
+	return RevisionResponse{
+		GUID:    revision.GUID,
+		Version: revision.Version,
+		Droplet: DropletGUID{
+			Guid: revision.DropletGUID,
+		},
+		Processes:   processes,
+		Sidecars:    []any{},
+		Description: revision.Description,
+		Deployable:  revision.DropletGUID != "",
+		CreatedAt:   formatTimestamp(&revision.CreatedAt),
+		UpdatedAt:   formatTimestamp(revision.UpdatedAt),
+		Relationships: Relationships{
+			"app": Relationship{
+				Data: &RelationshipData{
+					GUID: revision.AppGUID,
+				},
+			},
+		},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(revision.Labels),
+			Annotations: emptyMapIfNil(revision.Annotations),
+		},
+		Links: RevisionLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, revision.GUID).build(),
+			},
+			App: Link{
+				HRef: buildURL(baseURL).appendPath(appsBase, revision.AppGUID).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revisions", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.RevisionRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.RevisionRecord{
+			GUID:        "revision-guid",
+			AppGUID:     "app-guid",
+			Version:     3,
+			DropletGUID: "droplet-guid",
+			Description: "New droplet deployed.",
+			Processes: map[string]string{
+				"web":    "",
+				"worker": "bundle exec work",
+			},
+			Labels:      map[string]string{"foo": "bar"},
+			Annotations: map[string]string{"bar": "baz"},
+			CreatedAt:   time.UnixMilli(1000),
+			UpdatedAt:   tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForRevision(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected revision json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "revision-guid",
+			"version": 3,
+			"droplet": {
+				"guid": "droplet-guid"
+			},
+			"processes": {
+				"web": {
+					"command": null
+				},
+				"worker": {
+					"command": "bundle exec work"
+				}
+			},
+			"sidecars": [],
+			"description": "New droplet deployed.",
+			"deployable": true,
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"relationships": {
+				"app": {
+					"data": {
+						"guid": "app-guid"
+					}
+				}
+			},
+			"metadata": {
+				"labels": {
+					"foo": "bar"
+				},
+				"annotations": {
+					"bar": "baz"
+				}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/revisions/revision-guid"
+				},
+				"app": {
+					"href": "https://api.example.org/v3/apps/app-guid"
+				}
+			}
+		}`))
+	})
+})
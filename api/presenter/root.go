@@ -8,7 +8,16 @@ type APILink struct {
 }
 
 type APILinkMeta struct {
-	Version string `json:"version"`
+	Version            string `json:"version"`
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty"`
+	OAuthClient        string `json:"oauth_client,omitempty"`
+}
+
+// AppSSH describes the SSH proxy advertised to the cf cli
+type AppSSH struct {
+	Address            string
+	HostKeyFingerprint string
+	OAuthClient        string
 }
 
 type RootResponse struct {
@@ -18,8 +27,10 @@ type RootResponse struct {
 
 const V3APIVersion = "3.117.0+cf-k8s"
 
-func ForRoot(baseURL url.URL) RootResponse {
-	return RootResponse{
+// ForRoot builds the root response. The routing link is only advertised when
+// a TCP router group is configured.
+func ForRoot(baseURL url.URL, appSSH *AppSSH, routingEnabled bool) RootResponse {
+	response := RootResponse{
 		Links: map[string]*APILink{
 			"self": {
 				Link: Link{
@@ -37,7 +48,11 @@ func ForRoot(baseURL url.URL) RootResponse {
 				},
 			},
 			"network_policy_v0": nil,
-			"network_policy_v1": nil,
+			"network_policy_v1": {
+				Link: Link{
+					HRef: buildURL(baseURL).appendPath("networking").build(),
+				},
+			},
 			"login": {
 				Link: Link{
 					HRef: buildURL(baseURL).build(),
@@ -57,6 +72,28 @@ func ForRoot(baseURL url.URL) RootResponse {
 		},
 		CFOnK8s: true,
 	}
+
+	if appSSH != nil {
+		response.Links["app_ssh"] = &APILink{
+			Link: Link{
+				HRef: appSSH.Address,
+			},
+			Meta: APILinkMeta{
+				HostKeyFingerprint: appSSH.HostKeyFingerprint,
+				OAuthClient:        appSSH.OAuthClient,
+			},
+		}
+	}
+
+	if routingEnabled {
+		response.Links["routing"] = &APILink{
+			Link: Link{
+				HRef: buildURL(baseURL).appendPath("routing").build(),
+			},
+		}
+	}
+
+	return response
 }
 
 type RootV3Response struct {
@@ -12,19 +12,23 @@ import (
 
 var _ = Describe("Root endpoints", func() {
 	var (
-		baseURL *url.URL
-		output  []byte
+		baseURL        *url.URL
+		appSSH         *presenter.AppSSH
+		routingEnabled bool
+		output         []byte
 	)
 
 	BeforeEach(func() {
 		var err error
 		baseURL, err = url.Parse("https://api.example.org")
 		Expect(err).NotTo(HaveOccurred())
+		appSSH = nil
+		routingEnabled = false
 	})
 
 	Context("/", func() {
 		JustBeforeEach(func() {
-			response := presenter.ForRoot(*baseURL)
+			response := presenter.ForRoot(*baseURL, appSSH, routingEnabled)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -58,7 +62,12 @@ var _ = Describe("Root endpoints", func() {
 							}
 					},
 					"network_policy_v0": null,
-					"network_policy_v1": null,
+					"network_policy_v1": {
+							"href": "https://api.example.org/networking",
+							"meta": {
+									"version": ""
+							}
+					},
 					"routing": null,
 					"self": {
 							"href": "https://api.example.org",
@@ -71,6 +80,41 @@ var _ = Describe("Root endpoints", func() {
 				"cf_on_k8s": true
 			}`))
 		})
+
+		When("the ssh proxy is enabled", func() {
+			BeforeEach(func() {
+				appSSH = &presenter.AppSSH{
+					Address:            "ssh.example.org:2222",
+					HostKeyFingerprint: "the-fingerprint",
+					OAuthClient:        "ssh-proxy",
+				}
+			})
+
+			It("includes the app_ssh link", func() {
+				var root map[string]any
+				Expect(json.Unmarshal(output, &root)).To(Succeed())
+				Expect(root).To(HaveKeyWithValue("links", HaveKeyWithValue("app_ssh", Equal(map[string]any{
+					"href": "ssh.example.org:2222",
+					"meta": map[string]any{
+						"version":              "",
+						"host_key_fingerprint": "the-fingerprint",
+						"oauth_client":         "ssh-proxy",
+					},
+				}))))
+			})
+		})
+
+		When("routing is enabled", func() {
+			BeforeEach(func() {
+				routingEnabled = true
+			})
+
+			It("includes the routing link", func() {
+				var root map[string]any
+				Expect(json.Unmarshal(output, &root)).To(Succeed())
+				Expect(root).To(HaveKeyWithValue("links", HaveKeyWithValue("routing", HaveKeyWithValue("href", "https://api.example.org/routing"))))
+			})
+		})
 	})
 
 	Context("/v3", func() {
@@ -69,6 +69,7 @@ func ForRoute(route repositories.RouteRecord, baseURL url.URL) RouteResponse {
 	return RouteResponse{
 		GUID:      route.GUID,
 		Protocol:  route.Protocol,
+		Port:      route.Port,
 		Host:      route.Host,
 		Path:      route.Path,
 		URL:       routeURL(route),
@@ -142,6 +143,10 @@ func ForRouteDestinations(route repositories.RouteRecord, baseURL url.URL) Route
 }
 
 func routeURL(route repositories.RouteRecord) string {
+	if route.Port != nil {
+		return fmt.Sprintf("%s:%d", route.Domain.Name, *route.Port)
+	}
+
 	if route.Host != "" {
 		return fmt.Sprintf("%s.%s%s", route.Host, route.Domain.Name, route.Path)
 	} else {
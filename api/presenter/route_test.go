@@ -143,6 +143,23 @@ var _ = Describe("Route", func() {
 				Expect(output).To(MatchJSONPath("$.url", "example.org/some_path"))
 			})
 		})
+
+		When("the route is a tcp route", func() {
+			BeforeEach(func() {
+				record.Host = ""
+				record.Path = ""
+				record.Protocol = "tcp"
+				record.Port = tools.PtrTo(1024)
+			})
+
+			It("presents the port", func() {
+				Expect(output).To(SatisfyAll(
+					MatchJSONPath("$.port", BeEquivalentTo(1024)),
+					MatchJSONPath("$.protocol", "tcp"),
+					MatchJSONPath("$.url", "example.org:1024"),
+				))
+			})
+		})
 	})
 
 	Describe("destinations", func() {
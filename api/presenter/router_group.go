@@ -0,0 +1,30 @@
+package presenter
+
+import (
+	"code.cloudfoundry.org/korifi/api/config"
+)
+
+const (
+	routerGroupsBase = "/routing/v1/router_groups"
+	routerGroupTCP   = "tcp"
+)
+
+// RouterGroupResponse follows the format of the CF routing API rather than
+// the V3 API
+type RouterGroupResponse struct {
+	GUID            string `json:"guid"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	ReservablePorts string `json:"reservable_ports"`
+}
+
+// ForRouterGroup presents a router group. Router groups are configured
+// rather than persisted, so their guid is their name.
+func ForRouterGroup(routerGroup config.RouterGroupConfig) RouterGroupResponse {
+	return RouterGroupResponse{
+		GUID:            routerGroup.Name,
+		Name:            routerGroup.Name,
+		Type:            routerGroupTCP,
+		ReservablePorts: routerGroup.ReservablePorts,
+	}
+}
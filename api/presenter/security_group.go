@@ -0,0 +1,97 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	securityGroupsBase = "/v3/security_groups"
+)
+
+type SecurityGroupResponse struct {
+	GUID            string                         `json:"guid"`
+	CreatedAt       string                         `json:"created_at"`
+	UpdatedAt       string                         `json:"updated_at"`
+	Name            string                         `json:"name"`
+	GloballyEnabled SecurityGroupWorkloadsResponse `json:"globally_enabled"`
+	Rules           []SecurityGroupRuleResponse    `json:"rules"`
+	Relationships   SecurityGroupRelationships     `json:"relationships"`
+	Links           SecurityGroupLinks             `json:"links"`
+}
+
+type SecurityGroupWorkloadsResponse struct {
+	Running bool `json:"running"`
+	Staging bool `json:"staging"`
+}
+
+type SecurityGroupRuleResponse struct {
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Ports       string `json:"ports,omitempty"`
+	Type        *int32 `json:"type,omitempty"`
+	Code        *int32 `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
+	Log         bool   `json:"log"`
+}
+
+type SecurityGroupRelationships struct {
+	RunningSpaces ToManyRelationship `json:"running_spaces"`
+	StagingSpaces ToManyRelationship `json:"staging_spaces"`
+}
+
+type SecurityGroupLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForSecurityGroup(record repositories.SecurityGroupRecord, baseURL url.URL) SecurityGroupResponse {
+	rules := make([]SecurityGroupRuleResponse, 0, len(record.Rules))
+	for _, rule := range record.Rules {
+		rules = append(rules, SecurityGroupRuleResponse{
+			Protocol:    rule.Protocol,
+			Destination: rule.Destination,
+			Ports:       rule.Ports,
+			Type:        rule.Type,
+			Code:        rule.Code,
+			Description: rule.Description,
+			Log:         rule.Log,
+		})
+	}
+
+	return SecurityGroupResponse{
+		GUID:      record.GUID,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Name:      record.Name,
+		GloballyEnabled: SecurityGroupWorkloadsResponse{
+			Running: record.GloballyEnabled.Running,
+			Staging: record.GloballyEnabled.Staging,
+		},
+		Rules: rules,
+		Relationships: SecurityGroupRelationships{
+			RunningSpaces: ToManyRelationship{
+				Data: toManyRelationshipData(record.RunningSpaceGUIDs),
+			},
+			StagingSpaces: ToManyRelationship{
+				Data: toManyRelationshipData(record.StagingSpaceGUIDs),
+			},
+		},
+		Links: SecurityGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(securityGroupsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForSecurityGroupSpaces(securityGroupGUID string, workload string, spaceGUIDs []string, baseURL url.URL) ToManyRelationshipResponse {
+	return ToManyRelationshipResponse{
+		Data: toManyRelationshipData(spaceGUIDs),
+		Links: ToManyRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(securityGroupsBase, securityGroupGUID, "relationships", workload+"_spaces").build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Security Groups", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForSecurityGroup", func() {
+		var record repositories.SecurityGroupRecord
+
+		BeforeEach(func() {
+			record = repositories.SecurityGroupRecord{
+				GUID:            "security-group-guid",
+				Name:            "my-security-group",
+				GloballyEnabled: repositories.SecurityGroupWorkloads{Running: true},
+				Rules: []repositories.SecurityGroupRule{
+					{Protocol: "tcp", Destination: "10.10.10.0/24", Ports: "443,80", Log: true},
+					{Protocol: "icmp", Destination: "10.10.10.0/24", Type: tools.PtrTo[int32](8), Code: tools.PtrTo[int32](0), Description: "ping"},
+				},
+				RunningSpaceGUIDs: []string{},
+				StagingSpaceGUIDs: []string{"space-1"},
+				CreatedAt:         time.UnixMilli(1000),
+				UpdatedAt:         tools.PtrTo(time.UnixMilli(2000)),
+			}
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForSecurityGroup(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected security group json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "security-group-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "my-security-group",
+				"globally_enabled": {
+					"running": true,
+					"staging": false
+				},
+				"rules": [
+					{
+						"protocol": "tcp",
+						"destination": "10.10.10.0/24",
+						"ports": "443,80",
+						"log": true
+					},
+					{
+						"protocol": "icmp",
+						"destination": "10.10.10.0/24",
+						"type": 8,
+						"code": 0,
+						"description": "ping",
+						"log": false
+					}
+				],
+				"relationships": {
+					"running_spaces": {
+						"data": []
+					},
+					"staging_spaces": {
+						"data": [{"guid": "space-1"}]
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/security_groups/security-group-guid"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForSecurityGroupSpaces", func() {
+		It("produces the expected relationship json", func() {
+			response := presenter.ForSecurityGroupSpaces("security-group-guid", "staging", []string{"space-1"}, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{
+				"data": [{"guid": "space-1"}],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/security_groups/security-group-guid/relationships/staging_spaces"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -31,13 +31,31 @@ type ServiceBindingLastOperationResponse struct {
 }
 
 type ServiceBindingLinks struct {
-	App             Link `json:"app"`
-	ServiceInstance Link `json:"service_instance"`
-	Self            Link `json:"self"`
-	Details         Link `json:"details"`
+	App             *Link `json:"app,omitempty"`
+	ServiceInstance Link  `json:"service_instance"`
+	Self            Link  `json:"self"`
+	Details         Link  `json:"details"`
+}
+
+type ServiceBindingDetailsResponse struct {
+	Credentials    map[string]any `json:"credentials"`
+	SyslogDrainURL *string        `json:"syslog_drain_url"`
+	VolumeMounts   []string       `json:"volume_mounts"`
 }
 
 func ForServiceBinding(record repositories.ServiceBindingRecord, baseURL url.URL) ServiceBindingResponse {
+	relationships := Relationships{
+		"service_instance": {&RelationshipData{record.ServiceInstanceGUID}},
+	}
+
+	var appLink *Link
+	if record.Type != repositories.ServiceBindingTypeKey {
+		relationships["app"] = Relationship{&RelationshipData{record.AppGUID}}
+		appLink = &Link{
+			HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
+		}
+	}
+
 	return ServiceBindingResponse{
 		GUID:      record.GUID,
 		Type:      record.Type,
@@ -51,14 +69,9 @@ func ForServiceBinding(record repositories.ServiceBindingRecord, baseURL url.URL
 			CreatedAt:   formatTimestamp(&record.LastOperation.CreatedAt),
 			UpdatedAt:   formatTimestamp(record.LastOperation.UpdatedAt),
 		},
-		Relationships: map[string]Relationship{
-			"app":              {&RelationshipData{record.AppGUID}},
-			"service_instance": {&RelationshipData{record.ServiceInstanceGUID}},
-		},
+		Relationships: relationships,
 		Links: ServiceBindingLinks{
-			App: Link{
-				HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
-			},
+			App: appLink,
 			ServiceInstance: Link{
 				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, record.ServiceInstanceGUID).build(),
 			},
@@ -76,8 +89,15 @@ func ForServiceBinding(record repositories.ServiceBindingRecord, baseURL url.URL
 	}
 }
 
-func ForServiceBindingList(serviceBindingRecords []repositories.ServiceBindingRecord, appRecords []repositories.AppRecord, baseURL, requestURL url.URL) ListResponse[ServiceBindingResponse] {
-	ret := ForList(ForServiceBinding, serviceBindingRecords, baseURL, requestURL)
+func ForServiceBindingDetails(record repositories.ServiceBindingDetailsRecord) ServiceBindingDetailsResponse {
+	return ServiceBindingDetailsResponse{
+		Credentials:  emptyMapIfNil(record.Credentials),
+		VolumeMounts: []string{},
+	}
+}
+
+func ForServiceBindingList(serviceBindings repositories.ListResult[repositories.ServiceBindingRecord], appRecords []repositories.AppRecord, baseURL, requestURL url.URL) ListResponse[ServiceBindingResponse] {
+	ret := ForListResult(ForServiceBinding, serviceBindings, baseURL, requestURL)
 	if len(appRecords) > 0 {
 		appData := IncludedData{}
 		for _, appRecord := range appRecords {
@@ -127,6 +127,39 @@ var _ = Describe("Service Binding", func() {
 				Expect(output).To(MatchJSONPath("$.metadata.annotations", Not(BeNil())))
 			})
 		})
+
+		When("the binding is a key", func() {
+			BeforeEach(func() {
+				record.Type = "key"
+				record.AppGUID = ""
+			})
+
+			It("does not reference an app", func() {
+				Expect(output).To(SatisfyAll(
+					MatchJSONPath("$.type", "key"),
+					MatchJSONPath("$.relationships.service_instance.data.guid", "service-instance-guid"),
+					MatchJSONPath("$.relationships", Not(HaveKey("app"))),
+					MatchJSONPath("$.links", Not(HaveKey("app"))),
+				))
+			})
+		})
+	})
+
+	Describe("ForServiceBindingDetails", func() {
+		It("returns the expected JSON", func() {
+			response := presenter.ForServiceBindingDetails(repositories.ServiceBindingDetailsRecord{
+				Credentials: map[string]any{"username": "admin"},
+			})
+			output, err := json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{
+				"credentials": {
+					"username": "admin"
+				},
+				"syslog_drain_url": null,
+				"volume_mounts": []
+			}`))
+		})
 	})
 
 	Describe("ForServiceBindingList", func() {
@@ -150,7 +183,10 @@ var _ = Describe("Service Binding", func() {
 		})
 
 		JustBeforeEach(func() {
-			response := presenter.ForServiceBindingList([]repositories.ServiceBindingRecord{record, otherRecord}, []repositories.AppRecord{app}, *baseURL, *requestURL)
+			response := presenter.ForServiceBindingList(repositories.ListResult[repositories.ServiceBindingRecord]{
+				PageInfo: repositories.PageInfo{TotalResults: 2, Page: 1, PerPage: 50},
+				Records:  []repositories.ServiceBindingRecord{record, otherRecord},
+			}, []repositories.AppRecord{app}, *baseURL, *requestURL)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
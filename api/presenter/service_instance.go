@@ -4,6 +4,8 @@ import (
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/services"
 )
 
 const (
@@ -36,11 +38,12 @@ type lastOperation struct {
 }
 
 type ServiceInstanceLinks struct {
-	Self                      Link `json:"self"`
-	Space                     Link `json:"space"`
-	Credentials               Link `json:"credentials"`
-	ServiceCredentialBindings Link `json:"service_credential_bindings"`
-	ServiceRouteBindings      Link `json:"service_route_bindings"`
+	Self                      Link  `json:"self"`
+	Space                     Link  `json:"space"`
+	Credentials               *Link `json:"credentials,omitempty"`
+	ServicePlan               *Link `json:"service_plan,omitempty"`
+	ServiceCredentialBindings Link  `json:"service_credential_bindings"`
+	ServiceRouteBindings      Link  `json:"service_route_bindings"`
 }
 
 func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL) ServiceInstanceResponse {
@@ -49,7 +52,21 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 		lastOperationType = "create"
 	}
 
-	return ServiceInstanceResponse{
+	lastOperationState := services.LastOperationStateSucceeded
+	lastOperationDescription := "Operation succeeded"
+	switch {
+	case serviceInstanceRecord.LastOperation.Type != "":
+		lastOperationType = serviceInstanceRecord.LastOperation.Type
+		lastOperationState = serviceInstanceRecord.LastOperation.State
+		lastOperationDescription = serviceInstanceRecord.LastOperation.Description
+	case serviceInstanceRecord.Type == korifiv1alpha1.ManagedType:
+		// the controller has not picked up the managed instance yet
+		lastOperationType = services.LastOperationTypeCreate
+		lastOperationState = services.LastOperationStateInitial
+		lastOperationDescription = ""
+	}
+
+	response := ServiceInstanceResponse{
 		Name: serviceInstanceRecord.Name,
 		GUID: serviceInstanceRecord.GUID,
 		Type: serviceInstanceRecord.Type,
@@ -57,8 +74,8 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 		LastOperation: lastOperation{
 			CreatedAt:   formatTimestamp(&serviceInstanceRecord.CreatedAt),
 			UpdatedAt:   formatTimestamp(serviceInstanceRecord.UpdatedAt),
-			Description: "Operation succeeded",
-			State:       "succeeded",
+			Description: lastOperationDescription,
+			State:       lastOperationState,
 			Type:        lastOperationType,
 		},
 		CreatedAt: formatTimestamp(&serviceInstanceRecord.CreatedAt),
@@ -81,9 +98,6 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 			Space: Link{
 				HRef: buildURL(baseURL).appendPath(spacesBase, serviceInstanceRecord.SpaceGUID).build(),
 			},
-			Credentials: Link{
-				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "credentials").build(),
-			},
 			ServiceCredentialBindings: Link{
 				HRef: buildURL(baseURL).appendPath(serviceCredentialBindingsBase).setQuery("service_instance_guids=" + serviceInstanceRecord.GUID).build(),
 			},
@@ -92,4 +106,20 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 			},
 		},
 	}
+	if serviceInstanceRecord.Type == korifiv1alpha1.ManagedType {
+		response.Relationships["service_plan"] = Relationship{
+			Data: &RelationshipData{
+				GUID: serviceInstanceRecord.PlanGUID,
+			},
+		}
+		response.Links.ServicePlan = &Link{
+			HRef: buildURL(baseURL).appendPath(servicePlansBase, serviceInstanceRecord.PlanGUID).build(),
+		}
+	} else {
+		response.Links.Credentials = &Link{
+			HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "credentials").build(),
+		}
+	}
+
+	return response
 }
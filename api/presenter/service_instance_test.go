@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/services"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
@@ -104,6 +105,49 @@ var _ = Describe("Service Instance", func() {
 		}`))
 	})
 
+	When("the service instance is managed", func() {
+		BeforeEach(func() {
+			record.Type = "managed"
+			record.SecretName = ""
+			record.PlanGUID = "plan-guid"
+		})
+
+		It("includes the service plan relationship", func() {
+			Expect(output).To(MatchJSONPath("$.relationships.service_plan.data.guid", "plan-guid"))
+			Expect(output).To(MatchJSONPath("$.links.service_plan.href", "https://api.example.org/v3/service_plans/plan-guid"))
+		})
+
+		It("does not include the credentials link", func() {
+			Expect(output).To(MatchJSONPath("$.links", Not(HaveKey("credentials"))))
+		})
+
+		It("presents an initial create last operation", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.last_operation.type", "create"),
+				MatchJSONPath("$.last_operation.state", "initial"),
+				MatchJSONPath("$.last_operation.description", ""),
+			))
+		})
+
+		When("the instance has a last operation", func() {
+			BeforeEach(func() {
+				record.LastOperation = services.LastOperation{
+					Type:        "create",
+					State:       "in progress",
+					Description: "provisioning",
+				}
+			})
+
+			It("presents the last operation", func() {
+				Expect(output).To(SatisfyAll(
+					MatchJSONPath("$.last_operation.type", "create"),
+					MatchJSONPath("$.last_operation.state", "in progress"),
+					MatchJSONPath("$.last_operation.description", "provisioning"),
+				))
+			})
+		})
+	})
+
 	When("create and update times are the same", func() {
 		BeforeEach(func() {
 			record.UpdatedAt = &record.CreatedAt
@@ -1,9 +1,14 @@
 package presenter
 
 import (
+	"fmt"
 	"net/url"
 	"path"
+	"strings"
 	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 )
 
 type Lifecycle struct {
@@ -43,12 +48,12 @@ type ListResponse[T any] struct {
 }
 
 type PaginationData struct {
-	TotalResults int     `json:"total_results"`
-	TotalPages   int     `json:"total_pages"`
-	First        PageRef `json:"first"`
-	Last         PageRef `json:"last"`
-	Next         *int    `json:"next"`
-	Previous     *int    `json:"previous"`
+	TotalResults int      `json:"total_results"`
+	TotalPages   int      `json:"total_pages"`
+	First        PageRef  `json:"first"`
+	Last         PageRef  `json:"last"`
+	Next         *PageRef `json:"next"`
+	Previous     *PageRef `json:"previous"`
 }
 
 type IncludedData struct {
@@ -62,22 +67,80 @@ type PageRef struct {
 type itemPresenter[T, S any] func(T, url.URL) S
 
 func ForList[T, S any](itemPresenter itemPresenter[T, S], resources []T, baseURL, requestURL url.URL) ListResponse[S] {
+	var pagination payloads.Pagination
+	if err := pagination.DecodeFromURLValues(requestURL.Query()); err != nil {
+		// list payloads reject invalid pagination parameters, so this can
+		// only happen for endpoints that do not validate them
+		pagination = payloads.Pagination{}
+	}
+	page, perPage := pagination.PageOrDefault(), pagination.PerPageOrDefault()
+
+	return ForListResult(itemPresenter, repositories.ListResult[T]{
+		PageInfo: repositories.PageInfo{
+			TotalResults: len(resources),
+			Page:         page,
+			PerPage:      perPage,
+		},
+		Records: pageOf(resources, page, perPage),
+	}, baseURL, requestURL)
+}
+
+// ForListResult presents a page of a list that has been paginated by the
+// repository
+func ForListResult[T, S any](itemPresenter itemPresenter[T, S], result repositories.ListResult[T], baseURL, requestURL url.URL) ListResponse[S] {
+	page, perPage := result.PageInfo.Page, max(result.PageInfo.PerPage, 1)
+	totalPages := max(1, (result.PageInfo.TotalResults+perPage-1)/perPage)
+
 	presenters := []S{}
-	for _, resource := range resources {
+	for _, resource := range result.Records {
 		presenters = append(presenters, itemPresenter(resource, baseURL))
 	}
+
+	paginationData := PaginationData{
+		TotalResults: result.PageInfo.TotalResults,
+		TotalPages:   totalPages,
+		First:        pageRef(baseURL, requestURL, 1, perPage),
+		Last:         pageRef(baseURL, requestURL, totalPages, perPage),
+	}
+	if page < totalPages {
+		next := pageRef(baseURL, requestURL, page+1, perPage)
+		paginationData.Next = &next
+	}
+	if page > 1 {
+		previous := pageRef(baseURL, requestURL, min(page-1, totalPages), perPage)
+		paginationData.Previous = &previous
+	}
+
 	return ListResponse[S]{
-		PaginationData: PaginationData{
-			TotalResults: len(resources),
-			TotalPages:   1,
-			First: PageRef{
-				HREF: buildURL(baseURL).appendPath(requestURL.Path).setQuery(requestURL.RawQuery).build(),
-			},
-			Last: PageRef{
-				HREF: buildURL(baseURL).appendPath(requestURL.Path).setQuery(requestURL.RawQuery).build(),
-			},
-		},
-		Resources: presenters,
+		PaginationData: paginationData,
+		Resources:      presenters,
+	}
+}
+
+func pageOf[T any](resources []T, page, perPage int) []T {
+	start := (page - 1) * perPage
+	if start >= len(resources) {
+		return nil
+	}
+
+	return resources[start:min(start+perPage, len(resources))]
+}
+
+// pageRef links to the given page, keeping all other query parameters of the
+// request untouched
+func pageRef(baseURL, requestURL url.URL, page, perPage int) PageRef {
+	query := []string{}
+	for _, param := range strings.Split(requestURL.RawQuery, "&") {
+		key, _, _ := strings.Cut(param, "=")
+		if key == "" || key == "page" || key == "per_page" {
+			continue
+		}
+		query = append(query, param)
+	}
+	query = append(query, fmt.Sprintf("page=%d", page), fmt.Sprintf("per_page=%d", perPage))
+
+	return PageRef{
+		HREF: buildURL(baseURL).appendPath(requestURL.Path).setQuery(strings.Join(query, "&")).build(),
 	}
 }
 
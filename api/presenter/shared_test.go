@@ -8,6 +8,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 )
 
 type (
@@ -61,10 +63,10 @@ var _ = Describe("Shared", func() {
 					"total_results": 2,
 					"total_pages": 1,
 					"first": {
-						"href": "https://api.example.org/v3/records?foo=bar"
+						"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=50"
 					},
 					"last": {
-						"href": "https://api.example.org/v3/records?foo=bar"
+						"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=50"
 					},
 					"next": null,
 					"previous": null
@@ -82,6 +84,75 @@ var _ = Describe("Shared", func() {
 			}`))
 		})
 
+		When("the records span multiple pages", func() {
+			BeforeEach(func() {
+				var err error
+				requestURL, err = url.Parse("https://api.example.org/v3/records?foo=bar&per_page=2&page=2")
+				Expect(err).NotTo(HaveOccurred())
+
+				records = []record{{N: 1}, {N: 2}, {N: 3}, {N: 4}, {N: 5}}
+			})
+
+			It("returns the requested page", func() {
+				Expect(output).To(MatchJSON(`{
+					"pagination": {
+						"total_results": 5,
+						"total_pages": 3,
+						"first": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=2"
+						},
+						"last": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=3&per_page=2"
+						},
+						"next": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=3&per_page=2"
+						},
+						"previous": {
+							"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=2"
+						}
+					},
+					"resources": [
+						{
+							"m": 3,
+							"u": "https://api.example.org"
+						},
+						{
+							"m": 4,
+							"u": "https://api.example.org"
+						}
+					]
+				}`))
+			})
+
+			When("the last page is requested", func() {
+				BeforeEach(func() {
+					var err error
+					requestURL, err = url.Parse("https://api.example.org/v3/records?per_page=2&page=3")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns the remaining records and no next page", func() {
+					Expect(output).To(MatchJSONPath("$.resources[*].m", ConsistOf(BeEquivalentTo(5))))
+					Expect(output).To(MatchJSONPath("$.pagination.next", BeNil()))
+					Expect(output).To(MatchJSONPath("$.pagination.previous.href", "https://api.example.org/v3/records?page=2&per_page=2"))
+				})
+			})
+
+			When("a page beyond the last one is requested", func() {
+				BeforeEach(func() {
+					var err error
+					requestURL, err = url.Parse("https://api.example.org/v3/records?per_page=2&page=7")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns no records and links back to the last page", func() {
+					Expect(output).To(MatchJSONPath("$.resources", BeEmpty()))
+					Expect(output).To(MatchJSONPath("$.pagination.next", BeNil()))
+					Expect(output).To(MatchJSONPath("$.pagination.previous.href", "https://api.example.org/v3/records?page=3&per_page=2"))
+				})
+			})
+		})
+
 		When("records are empty", func() {
 			BeforeEach(func() {
 				records = nil
@@ -93,10 +164,10 @@ var _ = Describe("Shared", func() {
 						"total_results": 0,
 						"total_pages": 1,
 						"first": {
-							"href": "https://api.example.org/v3/records?foo=bar"
+							"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=50"
 						},
 						"last": {
-							"href": "https://api.example.org/v3/records?foo=bar"
+							"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=50"
 						},
 						"next": null,
 						"previous": null
@@ -106,4 +177,65 @@ var _ = Describe("Shared", func() {
 			})
 		})
 	})
+
+	Describe("ForListResult", func() {
+		var (
+			result     repositories.ListResult[record]
+			baseURL    *url.URL
+			requestURL *url.URL
+			output     []byte
+		)
+
+		BeforeEach(func() {
+			var err error
+			baseURL, err = url.Parse("https://api.example.org")
+			Expect(err).NotTo(HaveOccurred())
+
+			requestURL, err = url.Parse("https://api.example.org/v3/records?foo=bar&per_page=2&page=2")
+			Expect(err).NotTo(HaveOccurred())
+
+			result = repositories.ListResult[record]{
+				PageInfo: repositories.PageInfo{TotalResults: 5, Page: 2, PerPage: 2},
+				Records:  []record{{N: 3}, {N: 4}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForListResult(forRecord, result, *baseURL, *requestURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("presents the page without slicing it again", func() {
+			Expect(output).To(MatchJSON(`{
+				"pagination": {
+					"total_results": 5,
+					"total_pages": 3,
+					"first": {
+						"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=2"
+					},
+					"last": {
+						"href": "https://api.example.org/v3/records?foo=bar&page=3&per_page=2"
+					},
+					"next": {
+						"href": "https://api.example.org/v3/records?foo=bar&page=3&per_page=2"
+					},
+					"previous": {
+						"href": "https://api.example.org/v3/records?foo=bar&page=1&per_page=2"
+					}
+				},
+				"resources": [
+					{
+						"m": 3,
+						"u": "https://api.example.org"
+					},
+					{
+						"m": 4,
+						"u": "https://api.example.org"
+					}
+				]
+			}`))
+		})
+	})
 })
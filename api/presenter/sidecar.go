@@ -0,0 +1,39 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type SidecarResponse struct {
+	GUID          string        `json:"guid"`
+	Name          string        `json:"name"`
+	Command       string        `json:"command"`
+	ProcessTypes  []string      `json:"process_types"`
+	MemoryInMB    *int64        `json:"memory_in_mb"`
+	Origin        string        `json:"origin"`
+	Relationships Relationships `json:"relationships"`
+	CreatedAt     string        `json:"created_at"`
+	UpdatedAt     string        `json:"updated_at"`
+}
+
+func ForSidecar(sidecarRecord repositories.SidecarRecord, _ url.URL) SidecarResponse {
+	return SidecarResponse{
+		GUID:         sidecarRecord.GUID,
+		Name:         sidecarRecord.Name,
+		Command:      sidecarRecord.Command,
+		ProcessTypes: sidecarRecord.ProcessTypes,
+		MemoryInMB:   sidecarRecord.MemoryMB,
+		Origin:       sidecarRecord.Origin,
+		Relationships: Relationships{
+			"app": Relationship{
+				Data: &RelationshipData{
+					GUID: sidecarRecord.AppGUID,
+				},
+			},
+		},
+		CreatedAt: formatTimestamp(&sidecarRecord.CreatedAt),
+		UpdatedAt: formatTimestamp(sidecarRecord.UpdatedAt),
+	}
+}
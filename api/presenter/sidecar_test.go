@@ -0,0 +1,79 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sidecars", func() {
+	var (
+		baseURL *url.URL
+		record  repositories.SidecarRecord
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		record = repositories.SidecarRecord{
+			GUID:         "sidecar-guid",
+			Name:         "log-shipper",
+			Command:      "ship-logs",
+			ProcessTypes: []string{"web", "worker"},
+			MemoryMB:     tools.PtrTo[int64](64),
+			Origin:       "user",
+			AppGUID:      "app-guid",
+			SpaceGUID:    "space-guid",
+			CreatedAt:    time.UnixMilli(1000),
+			UpdatedAt:    tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForSidecar(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected sidecar json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "sidecar-guid",
+			"name": "log-shipper",
+			"command": "ship-logs",
+			"process_types": ["web", "worker"],
+			"memory_in_mb": 64,
+			"origin": "user",
+			"relationships": {
+				"app": {
+					"data": {
+						"guid": "app-guid"
+					}
+				}
+			},
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z"
+		}`))
+	})
+
+	When("the sidecar has no memory of its own", func() {
+		BeforeEach(func() {
+			record.MemoryMB = nil
+		})
+
+		It("renders a null memory", func() {
+			Expect(output).To(MatchJSONPath("$.memory_in_mb", BeNil()))
+		})
+	})
+})
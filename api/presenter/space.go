@@ -53,3 +53,11 @@ func ForSpace(space repositories.SpaceRecord, apiBaseURL url.URL) SpaceResponse
 		},
 	}
 }
+
+func ForSpaceSSHFeature(space repositories.SpaceRecord) FeatureResponse {
+	return FeatureResponse{
+		Name:        "ssh",
+		Description: "Enable SSHing into apps in the space.",
+		Enabled:     space.AllowSSH,
+	}
+}
@@ -0,0 +1,72 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	spaceQuotasBase = "/v3/space_quotas"
+)
+
+type SpaceQuotaResponse struct {
+	GUID          string                  `json:"guid"`
+	CreatedAt     string                  `json:"created_at"`
+	UpdatedAt     string                  `json:"updated_at"`
+	Name          string                  `json:"name"`
+	Apps          AppsQuotaResponse       `json:"apps"`
+	Services      ServicesQuotaResponse   `json:"services"`
+	Routes        RoutesQuotaResponse     `json:"routes"`
+	Relationships SpaceQuotaRelationships `json:"relationships"`
+	Links         SpaceQuotaLinks         `json:"links"`
+}
+
+type SpaceQuotaRelationships struct {
+	Organization Relationship       `json:"organization"`
+	Spaces       ToManyRelationship `json:"spaces"`
+}
+
+type SpaceQuotaLinks struct {
+	Self         Link `json:"self"`
+	Organization Link `json:"organization"`
+}
+
+func ForSpaceQuota(record repositories.SpaceQuotaRecord, baseURL url.URL) SpaceQuotaResponse {
+	return SpaceQuotaResponse{
+		GUID:      record.GUID,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Name:      record.Name,
+		Apps:      forAppsQuota(record.Limits),
+		Services:  forServicesQuota(record.Limits),
+		Routes:    forRoutesQuota(record.Limits),
+		Relationships: SpaceQuotaRelationships{
+			Organization: Relationship{
+				Data: &RelationshipData{GUID: record.OrganizationGUID},
+			},
+			Spaces: ToManyRelationship{
+				Data: toManyRelationshipData(record.SpaceGUIDs),
+			},
+		},
+		Links: SpaceQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spaceQuotasBase, record.GUID).build(),
+			},
+			Organization: Link{
+				HRef: buildURL(baseURL).appendPath(orgsBase, record.OrganizationGUID).build(),
+			},
+		},
+	}
+}
+
+func ForSpaceQuotaSpaces(quotaGUID string, spaceGUIDs []string, baseURL url.URL) ToManyRelationshipResponse {
+	return ToManyRelationshipResponse{
+		Data: toManyRelationshipData(spaceGUIDs),
+		Links: ToManyRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spaceQuotasBase, quotaGUID, "relationships", "spaces").build(),
+			},
+		},
+	}
+}
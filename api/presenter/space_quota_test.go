@@ -0,0 +1,113 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Space Quotas", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForSpaceQuota", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForSpaceQuota(repositories.SpaceQuotaRecord{
+				GUID: "quota-guid",
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					AppTasks:    tools.PtrTo[int64](5),
+					TotalRoutes: tools.PtrTo[int64](0),
+				},
+				OrganizationGUID: "org-guid",
+				SpaceGUIDs:       []string{"space-guid"},
+				CreatedAt:        time.UnixMilli(1000),
+				UpdatedAt:        tools.PtrTo(time.UnixMilli(2000)),
+			}, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected space quota json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "quota-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "my-quota",
+				"apps": {
+					"total_memory_in_mb": null,
+					"per_process_memory_in_mb": null,
+					"log_rate_limit_in_bytes_per_second": null,
+					"total_instances": null,
+					"per_app_tasks": 5
+				},
+				"services": {
+					"paid_services_allowed": true,
+					"total_service_instances": null,
+					"total_service_keys": null
+				},
+				"routes": {
+					"total_routes": 0,
+					"total_reserved_ports": null
+				},
+				"relationships": {
+					"organization": {
+						"data": { "guid": "org-guid" }
+					},
+					"spaces": {
+						"data": [
+							{ "guid": "space-guid" }
+						]
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/space_quotas/quota-guid"
+					},
+					"organization": {
+						"href": "https://api.example.org/v3/organizations/org-guid"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForSpaceQuotaSpaces", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForSpaceQuotaSpaces("quota-guid", []string{"space-1", "space-2"}, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected relationship json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [
+					{ "guid": "space-1" },
+					{ "guid": "space-2" }
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/space_quotas/quota-guid/relationships/spaces"
+					}
+				}
+			}`))
+		})
+	})
+})
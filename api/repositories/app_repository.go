@@ -38,6 +38,7 @@ const (
 )
 
 type AppRepo struct {
+	rootNamespace        string
 	namespaceRetriever   NamespaceRetriever
 	userClientFactory    authorization.UserK8sClientFactory
 	namespacePermissions *authorization.NamespacePermissions
@@ -45,12 +46,14 @@ type AppRepo struct {
 }
 
 func NewAppRepo(
+	rootNamespace string,
 	namespaceRetriever NamespaceRetriever,
 	userClientFactory authorization.UserK8sClientFactory,
 	authPerms *authorization.NamespacePermissions,
 	appAwaiter Awaiter[*korifiv1alpha1.CFApp],
 ) *AppRepo {
 	return &AppRepo{
+		rootNamespace:        rootNamespace,
 		namespaceRetriever:   namespaceRetriever,
 		userClientFactory:    userClientFactory,
 		namespacePermissions: authPerms,
@@ -73,6 +76,8 @@ type AppRecord struct {
 	UpdatedAt             *time.Time
 	DeletedAt             *time.Time
 	IsStaged              bool
+	EnableSSH             bool
+	EnableRevisions       bool
 	envSecretName         string
 	vcapServiceSecretName string
 	vcapAppSecretName     string
@@ -113,6 +118,8 @@ type AppEnvRecord struct {
 	EnvironmentVariables map[string]string
 	SystemEnv            map[string]interface{}
 	AppEnv               map[string]interface{}
+	StagingEnv           map[string]string
+	RunningEnv           map[string]string
 }
 
 type CurrentDropletRecord struct {
@@ -135,6 +142,8 @@ type PatchAppMessage struct {
 	Name                 string
 	Lifecycle            *LifecyclePatch
 	EnvironmentVariables map[string]string
+	EnableSSH            *bool
+	EnableRevisions      *bool
 	MetadataPatch
 }
 
@@ -324,13 +333,15 @@ func (f *AppRepo) ListApps(ctx context.Context, authInfo authorization.Info, mes
 
 	var filteredApps []korifiv1alpha1.CFApp
 	spaceGUIDSet := NewSet(message.SpaceGuids...)
-	for ns := range nsList {
+	for _, ns := range orderedNamespaces(nsList) {
 		if len(spaceGUIDSet) > 0 && !spaceGUIDSet.Includes(ns) {
 			continue
 		}
 
 		appList := &korifiv1alpha1.CFAppList{}
-		err := userClient.List(ctx, appList, client.InNamespace(ns), &client.ListOptions{LabelSelector: labelSelector})
+		err := listInChunks(ctx, userClient, appList, func(l *korifiv1alpha1.CFAppList) {
+			filteredApps = append(filteredApps, Filter(l.Items, preds...)...)
+		}, client.InNamespace(ns), &client.ListOptions{LabelSelector: labelSelector})
 
 		if k8serrors.IsForbidden(err) {
 			continue
@@ -338,8 +349,6 @@ func (f *AppRepo) ListApps(ctx context.Context, authInfo authorization.Info, mes
 		if err != nil {
 			return []AppRecord{}, fmt.Errorf("failed to list apps in namespace %s: %w", ns, apierrors.FromK8sError(err, AppResourceType))
 		}
-
-		filteredApps = append(filteredApps, Filter(appList.Items, preds...)...)
 	}
 
 	appRecords := returnAppList(filteredApps)
@@ -532,12 +541,24 @@ func (f *AppRepo) GetAppEnv(ctx context.Context, authInfo authorization.Info, ap
 		return AppEnvRecord{}, err
 	}
 
+	stagingEnvMap, err := getEnvVarGroupVars(ctx, userClient, f.rootNamespace, korifiv1alpha1.StagingEnvVarGroupName)
+	if err != nil {
+		return AppEnvRecord{}, err
+	}
+
+	runningEnvMap, err := getEnvVarGroupVars(ctx, userClient, f.rootNamespace, korifiv1alpha1.RunningEnvVarGroupName)
+	if err != nil {
+		return AppEnvRecord{}, err
+	}
+
 	appEnvRecord := AppEnvRecord{
 		AppGUID:              appGUID,
 		SpaceGUID:            app.SpaceGUID,
 		EnvironmentVariables: appEnvVarMap,
 		SystemEnv:            systemEnvMap,
 		AppEnv:               appEnvMap,
+		StagingEnv:           stagingEnvMap,
+		RunningEnv:           runningEnvMap,
 	}
 
 	return appEnvRecord, nil
@@ -632,6 +653,8 @@ func (m *CreateAppMessage) toCFApp() korifiv1alpha1.CFApp {
 					Stack:      m.Lifecycle.Data.Stack,
 				},
 			},
+			EnableSSH:       true,
+			EnableRevisions: true,
 		},
 	}
 }
@@ -655,6 +678,14 @@ func (m *PatchAppMessage) Apply(app *korifiv1alpha1.CFApp) {
 		}
 	}
 
+	if m.EnableSSH != nil {
+		app.Spec.EnableSSH = *m.EnableSSH
+	}
+
+	if m.EnableRevisions != nil {
+		app.Spec.EnableRevisions = *m.EnableRevisions
+	}
+
 	m.MetadataPatch.Apply(app)
 }
 
@@ -680,6 +711,8 @@ func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) AppRecord {
 		UpdatedAt:             getLastUpdatedTime(&cfApp),
 		DeletedAt:             golangTime(cfApp.DeletionTimestamp),
 		IsStaged:              meta.IsStatusConditionTrue(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady),
+		EnableSSH:             cfApp.Spec.EnableSSH,
+		EnableRevisions:       cfApp.Spec.EnableRevisions,
 		envSecretName:         cfApp.Spec.EnvSecretName,
 		vcapServiceSecretName: cfApp.Status.VCAPServicesSecretName,
 		vcapAppSecretName:     cfApp.Status.VCAPApplicationSecretName,
@@ -54,7 +54,7 @@ var _ = Describe("AppRepository", func() {
 			korifiv1alpha1.CFAppList,
 			*korifiv1alpha1.CFAppList,
 		]{}
-		appRepo = NewAppRepo(namespaceRetriever, userClientFactory, nsPerms, appAwaiter)
+		appRepo = NewAppRepo(rootNamespace, namespaceRetriever, userClientFactory, nsPerms, appAwaiter)
 
 		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space1"))
@@ -519,6 +519,24 @@ var _ = Describe("AppRepository", func() {
 				Expect(createdAppRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
 			})
 
+			It("enables ssh for the app", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(createdAppRecord.EnableSSH).To(BeTrue())
+
+				createdCFApp := new(korifiv1alpha1.CFApp)
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdAppRecord.GUID, Namespace: cfSpace.Name}, createdCFApp)).To(Succeed())
+				Expect(createdCFApp.Spec.EnableSSH).To(BeTrue())
+			})
+
+			It("enables revisions for the app", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(createdAppRecord.EnableRevisions).To(BeTrue())
+
+				createdCFApp := new(korifiv1alpha1.CFApp)
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdAppRecord.GUID, Namespace: cfSpace.Name}, createdCFApp)).To(Succeed())
+				Expect(createdCFApp.Spec.EnableRevisions).To(BeTrue())
+			})
+
 			When("no environment variables are given", func() {
 				BeforeEach(func() {
 					appCreateMessage.EnvironmentVariables = nil
@@ -678,6 +696,30 @@ var _ = Describe("AppRepository", func() {
 				}))
 			})
 
+			When("enabling ssh", func() {
+				BeforeEach(func() {
+					appPatchMessage.EnableSSH = tools.PtrTo(true)
+				})
+
+				It("enables ssh for the app", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(patchedAppRecord.EnableSSH).To(BeTrue())
+					Expect(cfApp.Spec.EnableSSH).To(BeTrue())
+				})
+			})
+
+			When("enabling revisions", func() {
+				BeforeEach(func() {
+					appPatchMessage.EnableRevisions = tools.PtrTo(true)
+				})
+
+				It("enables revisions for the app", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(patchedAppRecord.EnableRevisions).To(BeTrue())
+					Expect(cfApp.Spec.EnableRevisions).To(BeTrue())
+				})
+			})
+
 			Describe("patching labels and annotations", func() {
 				BeforeEach(func() {
 					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
@@ -1394,6 +1436,35 @@ var _ = Describe("AppRepository", func() {
 				Expect(appEnvRecord.AppEnv).To(BeEmpty())
 			})
 
+			When("environment variable groups are set", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      korifiv1alpha1.StagingEnvVarGroupName,
+						},
+						Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+							Var: map[string]string{"HTTP_PROXY": "http://staging-proxy"},
+						},
+					})).To(Succeed())
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      korifiv1alpha1.RunningEnvVarGroupName,
+						},
+						Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+							Var: map[string]string{"APM_ENDPOINT": "http://apm"},
+						},
+					})).To(Succeed())
+				})
+
+				It("returns the environment variable groups", func() {
+					Expect(getAppEnvErr).NotTo(HaveOccurred())
+					Expect(appEnvRecord.StagingEnv).To(Equal(map[string]string{"HTTP_PROXY": "http://staging-proxy"}))
+					Expect(appEnvRecord.RunningEnv).To(Equal(map[string]string{"APM_ENDPOINT": "http://apm"}))
+				})
+			})
+
 			When("the app has a service-binding secret", func() {
 				var (
 					vcapServiceSecretDataByte map[string][]byte
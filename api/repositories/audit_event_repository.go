@@ -0,0 +1,366 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/correlation"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=get;list;create,namespace=ROOT_NAMESPACE
+
+const AuditEventResourceType = "Audit Event"
+
+const (
+	AuditEventActorTypeUser           = "user"
+	AuditEventActorTypeServiceAccount = "service_account"
+
+	AuditEventTargetTypeApp            = "app"
+	AuditEventTargetTypeServiceBinding = "service_binding"
+	AuditEventTargetTypeServiceKey     = "service_key"
+	AuditEventTargetTypeUser           = "user"
+
+	AuditEventTypeAppCreate            = "audit.app.create"
+	AuditEventTypeAppUpdate            = "audit.app.update"
+	AuditEventTypeAppStart             = "audit.app.start"
+	AuditEventTypeAppStop              = "audit.app.stop"
+	AuditEventTypeAppRestart           = "audit.app.restart"
+	AuditEventTypeAppDeleteRequest     = "audit.app.delete-request"
+	AuditEventTypeAppDropletMapped     = "audit.app.droplet.mapped"
+	AuditEventTypeAppProcessScale      = "audit.app.process.scale"
+	AuditEventTypeAppProcessUpdate     = "audit.app.process.update"
+	AuditEventTypeAppMapRoute          = "audit.app.map-route"
+	AuditEventTypeAppUnmapRoute        = "audit.app.unmap-route"
+	AuditEventTypeAppApplyManifest     = "audit.app.apply_manifest"
+	AuditEventTypeServiceBindingCreate = "audit.service_binding.create"
+	AuditEventTypeServiceBindingDelete = "audit.service_binding.delete"
+	AuditEventTypeServiceKeyCreate     = "audit.service_key.create"
+	AuditEventTypeServiceKeyDelete     = "audit.service_key.delete"
+)
+
+// AuditEventRepo stores audit events in the root namespace. Users cannot
+// access the root namespace, so events are read with the privileged client
+// and only returned to users with a role in the space (or the org for org
+// level events) of the event target.
+type AuditEventRepo struct {
+	privilegedClient     client.Client
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
+	identityProvider     authorization.IdentityProvider
+	rootNamespace        string
+}
+
+type AuditEventActor struct {
+	GUID string
+	Type string
+	Name string
+}
+
+type AuditEventTarget struct {
+	GUID string
+	Type string
+	Name string
+}
+
+type AuditEventRecord struct {
+	GUID             string
+	Type             string
+	Actor            AuditEventActor
+	Target           AuditEventTarget
+	SpaceGUID        string
+	OrganizationGUID string
+	CorrelationID    string
+	Data             map[string]any
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+type CreateAuditEventMessage struct {
+	Type      string
+	Target    AuditEventTarget
+	SpaceGUID string
+	// OrganizationGUID is looked up from the space when not set
+	OrganizationGUID string
+	Data             map[string]any
+}
+
+type ListAuditEventsMessage struct {
+	Types             []string
+	TargetGUIDs       []string
+	SpaceGUIDs        []string
+	OrganizationGUIDs []string
+	CreatedAts        TimestampFilter
+}
+
+// TimestampFilter matches timestamps equal to any of the Equal timestamps and
+// within the bounds set by the other fields. Timestamps are compared with a
+// one second precision, as they are presented by the API.
+type TimestampFilter struct {
+	Equal         []time.Time
+	Before        *time.Time
+	BeforeOrEqual *time.Time
+	After         *time.Time
+	AfterOrEqual  *time.Time
+}
+
+func (f TimestampFilter) Matches(t time.Time) bool {
+	t = t.Truncate(time.Second)
+
+	if len(f.Equal) > 0 {
+		equal := false
+		for _, e := range f.Equal {
+			equal = equal || t.Equal(e.Truncate(time.Second))
+		}
+		if !equal {
+			return false
+		}
+	}
+
+	if f.Before != nil && !t.Before(f.Before.Truncate(time.Second)) {
+		return false
+	}
+	if f.BeforeOrEqual != nil && t.After(f.BeforeOrEqual.Truncate(time.Second)) {
+		return false
+	}
+	if f.After != nil && !t.After(f.After.Truncate(time.Second)) {
+		return false
+	}
+	if f.AfterOrEqual != nil && t.Before(f.AfterOrEqual.Truncate(time.Second)) {
+		return false
+	}
+
+	return true
+}
+
+func NewAuditEventRepo(
+	privilegedClient client.Client,
+	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
+	identityProvider authorization.IdentityProvider,
+	rootNamespace string,
+) *AuditEventRepo {
+	return &AuditEventRepo{
+		privilegedClient:     privilegedClient,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
+		identityProvider:     identityProvider,
+		rootNamespace:        rootNamespace,
+	}
+}
+
+// RecordEvent creates an audit event on behalf of the user. Failing to
+// record an event does not fail the action it records, so errors are only
+// logged.
+func (r *AuditEventRepo) RecordEvent(ctx context.Context, authInfo authorization.Info, message CreateAuditEventMessage) {
+	if _, err := r.CreateAuditEvent(ctx, authInfo, message); err != nil {
+		logr.FromContextOrDiscard(ctx).Info("failed to record audit event", "type", message.Type, "target", message.Target.GUID, "reason", err)
+	}
+}
+
+func (r *AuditEventRepo) CreateAuditEvent(ctx context.Context, authInfo authorization.Info, message CreateAuditEventMessage) (AuditEventRecord, error) {
+	identity, err := r.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	orgGUID := message.OrganizationGUID
+	if orgGUID == "" && message.SpaceGUID != "" {
+		orgGUID, err = r.namespaceRetriever.NamespaceFor(ctx, message.SpaceGUID, SpaceResourceType)
+		if err != nil {
+			return AuditEventRecord{}, fmt.Errorf("failed to get the org of space %q: %w", message.SpaceGUID, err)
+		}
+	}
+
+	var data *runtime.RawExtension
+	if len(message.Data) > 0 {
+		rawData, err := json.Marshal(message.Data)
+		if err != nil {
+			return AuditEventRecord{}, fmt.Errorf("failed to marshal audit event data: %w", err)
+		}
+		data = &runtime.RawExtension{Raw: rawData}
+	}
+
+	auditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFAuditEventTypeLabelKey:       message.Type,
+				korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: message.Target.GUID,
+				korifiv1alpha1.CFAuditEventSpaceGUIDLabelKey:  message.SpaceGUID,
+				korifiv1alpha1.CFAuditEventOrgGUIDLabelKey:    orgGUID,
+			},
+		},
+		Spec: korifiv1alpha1.CFAuditEventSpec{
+			Type: message.Type,
+			Actor: korifiv1alpha1.AuditEventActor{
+				GUID: identity.Name,
+				Type: actorType(identity),
+				Name: identity.Name,
+			},
+			Target: korifiv1alpha1.AuditEventTarget{
+				GUID: message.Target.GUID,
+				Type: message.Target.Type,
+				Name: message.Target.Name,
+			},
+			SpaceGUID:        message.SpaceGUID,
+			OrganizationGUID: orgGUID,
+			CorrelationID:    correlation.IDFromContext(ctx),
+			Data:             data,
+		},
+	}
+
+	if err = r.privilegedClient.Create(ctx, auditEvent); err != nil {
+		return AuditEventRecord{}, apierrors.FromK8sError(err, AuditEventResourceType)
+	}
+
+	return auditEventToRecord(auditEvent), nil
+}
+
+func (r *AuditEventRepo) GetAuditEvent(ctx context.Context, authInfo authorization.Info, guid string) (AuditEventRecord, error) {
+	auditEvent := &korifiv1alpha1.CFAuditEvent{}
+	err := r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, auditEvent)
+	if err != nil {
+		return AuditEventRecord{}, apierrors.FromK8sError(err, AuditEventResourceType)
+	}
+
+	visible, err := r.visibilityFilter(ctx, authInfo)
+	if err != nil {
+		return AuditEventRecord{}, err
+	}
+
+	record := auditEventToRecord(auditEvent)
+	if !visible(record) {
+		return AuditEventRecord{}, apierrors.NewNotFoundError(nil, AuditEventResourceType)
+	}
+
+	return record, nil
+}
+
+func (r *AuditEventRepo) ListAuditEvents(ctx context.Context, authInfo authorization.Info, message ListAuditEventsMessage) ([]AuditEventRecord, error) {
+	visible, err := r.visibilityFilter(ctx, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := message.labelSelector()
+	if err != nil {
+		// audit events are only labelled with valid label values, so none
+		// of them can match the filters
+		return []AuditEventRecord{}, nil
+	}
+
+	preds := []func(AuditEventRecord) bool{
+		visible,
+		func(e AuditEventRecord) bool { return message.CreatedAts.Matches(e.CreatedAt) },
+	}
+
+	records := []AuditEventRecord{}
+	err = listInChunks(ctx, r.privilegedClient, &korifiv1alpha1.CFAuditEventList{}, func(l *korifiv1alpha1.CFAuditEventList) {
+		chunk := make([]AuditEventRecord, 0, len(l.Items))
+		for i := range l.Items {
+			chunk = append(chunk, auditEventToRecord(&l.Items[i]))
+		}
+		records = append(records, Filter(chunk, preds...)...)
+	}, client.InNamespace(r.rootNamespace), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	return records, nil
+}
+
+// labelSelector selects the audit events matching the filters of the
+// message, so that the other events are not loaded from the root namespace
+func (m ListAuditEventsMessage) labelSelector() (labels.Selector, error) {
+	selector := labels.Everything()
+	for key, values := range map[string][]string{
+		korifiv1alpha1.CFAuditEventTypeLabelKey:       m.Types,
+		korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: m.TargetGUIDs,
+		korifiv1alpha1.CFAuditEventSpaceGUIDLabelKey:  m.SpaceGUIDs,
+		korifiv1alpha1.CFAuditEventOrgGUIDLabelKey:    m.OrganizationGUIDs,
+	} {
+		if len(values) == 0 {
+			continue
+		}
+
+		requirement, err := labels.NewRequirement(key, selection.In, values)
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*requirement)
+	}
+
+	return selector, nil
+}
+
+func (r *AuditEventRepo) visibilityFilter(ctx context.Context, authInfo authorization.Info) (func(AuditEventRecord) bool, error) {
+	spaceNamespaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	orgNamespaces, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	return func(e AuditEventRecord) bool {
+		if e.SpaceGUID != "" {
+			return spaceNamespaces[e.SpaceGUID]
+		}
+
+		return orgNamespaces[e.OrganizationGUID]
+	}, nil
+}
+
+func actorType(identity authorization.Identity) string {
+	if identity.Kind == rbacv1.ServiceAccountKind {
+		return AuditEventActorTypeServiceAccount
+	}
+
+	return AuditEventActorTypeUser
+}
+
+func auditEventToRecord(auditEvent *korifiv1alpha1.CFAuditEvent) AuditEventRecord {
+	data := map[string]any{}
+	if auditEvent.Spec.Data != nil {
+		// the data is always marshalled from a map when the event is created
+		_ = json.Unmarshal(auditEvent.Spec.Data.Raw, &data)
+	}
+
+	return AuditEventRecord{
+		GUID: auditEvent.Name,
+		Type: auditEvent.Spec.Type,
+		Actor: AuditEventActor{
+			GUID: auditEvent.Spec.Actor.GUID,
+			Type: auditEvent.Spec.Actor.Type,
+			Name: auditEvent.Spec.Actor.Name,
+		},
+		Target: AuditEventTarget{
+			GUID: auditEvent.Spec.Target.GUID,
+			Type: auditEvent.Spec.Target.Type,
+			Name: auditEvent.Spec.Target.Name,
+		},
+		SpaceGUID:        auditEvent.Spec.SpaceGUID,
+		OrganizationGUID: auditEvent.Spec.OrganizationGUID,
+		CorrelationID:    auditEvent.Spec.CorrelationID,
+		Data:             data,
+		CreatedAt:        auditEvent.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(auditEvent),
+	}
+}
@@ -0,0 +1,264 @@
+package repositories_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/correlation"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AuditEventRepository", func() {
+	var (
+		auditEventRepo *repositories.AuditEventRepo
+		cfOrg          *korifiv1alpha1.CFOrg
+		cfSpace        *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space1"))
+
+		auditEventRepo = repositories.NewAuditEventRepo(k8sClient, namespaceRetriever, nsPerms, idProvider, rootNamespace)
+	})
+
+	Describe("CreateAuditEvent", func() {
+		var (
+			auditEvent repositories.AuditEventRecord
+			createErr  error
+		)
+
+		JustBeforeEach(func() {
+			auditEvent, createErr = auditEventRepo.CreateAuditEvent(correlation.NewContext(ctx, "the-correlation-id"), authInfo, repositories.CreateAuditEventMessage{
+				Type: "audit.app.create",
+				Target: repositories.AuditEventTarget{
+					GUID: "app-guid",
+					Type: "app",
+					Name: "my-app",
+				},
+				SpaceGUID: cfSpace.Name,
+				Data:      map[string]any{"request": map[string]any{"name": "my-app"}},
+			})
+		})
+
+		It("creates the audit event in the root namespace", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+
+			Expect(auditEvent.Type).To(Equal("audit.app.create"))
+			Expect(auditEvent.Actor).To(Equal(repositories.AuditEventActor{
+				GUID: userName,
+				Type: repositories.AuditEventActorTypeUser,
+				Name: userName,
+			}))
+			Expect(auditEvent.Target).To(Equal(repositories.AuditEventTarget{
+				GUID: "app-guid",
+				Type: "app",
+				Name: "my-app",
+			}))
+			Expect(auditEvent.SpaceGUID).To(Equal(cfSpace.Name))
+			Expect(auditEvent.OrganizationGUID).To(Equal(cfOrg.Name))
+			Expect(auditEvent.CorrelationID).To(Equal("the-correlation-id"))
+			Expect(auditEvent.Data).To(Equal(map[string]any{"request": map[string]any{"name": "my-app"}}))
+			Expect(auditEvent.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
+
+			cfAuditEvent := &korifiv1alpha1.CFAuditEvent{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: auditEvent.GUID}, cfAuditEvent)).To(Succeed())
+			Expect(cfAuditEvent.Labels).To(SatisfyAll(
+				HaveKeyWithValue(korifiv1alpha1.CFAuditEventTypeLabelKey, "audit.app.create"),
+				HaveKeyWithValue(korifiv1alpha1.CFAuditEventTargetGUIDLabelKey, "app-guid"),
+				HaveKeyWithValue(korifiv1alpha1.CFAuditEventSpaceGUIDLabelKey, cfSpace.Name),
+				HaveKeyWithValue(korifiv1alpha1.CFAuditEventOrgGUIDLabelKey, cfOrg.Name),
+			))
+		})
+	})
+
+	Describe("GetAuditEvent", func() {
+		var (
+			auditEventGUID string
+			getErr         error
+		)
+
+		BeforeEach(func() {
+			auditEvent, err := auditEventRepo.CreateAuditEvent(ctx, authInfo, repositories.CreateAuditEventMessage{
+				Type:      "audit.app.start",
+				Target:    repositories.AuditEventTarget{GUID: "app-guid", Type: "app"},
+				SpaceGUID: cfSpace.Name,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			auditEventGUID = auditEvent.GUID
+		})
+
+		JustBeforeEach(func() {
+			_, getErr = auditEventRepo.GetAuditEvent(ctx, authInfo, auditEventGUID)
+		})
+
+		It("returns a not found error as the user is not authorized in the space", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the audit event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the audit event does not exist", func() {
+			BeforeEach(func() {
+				auditEventGUID = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListAuditEvents", func() {
+		var (
+			startEventGUID string
+			stopEventGUID  string
+			orgEventGUID   string
+			message        repositories.ListAuditEventsMessage
+			eventGUIDs     []string
+			listErr        error
+		)
+
+		createEvent := func(message repositories.CreateAuditEventMessage) string {
+			GinkgoHelper()
+
+			auditEvent, err := auditEventRepo.CreateAuditEvent(ctx, authInfo, message)
+			Expect(err).NotTo(HaveOccurred())
+			return auditEvent.GUID
+		}
+
+		BeforeEach(func() {
+			startEventGUID = createEvent(repositories.CreateAuditEventMessage{
+				Type:      "audit.app.start",
+				Target:    repositories.AuditEventTarget{GUID: "app-1", Type: "app"},
+				SpaceGUID: cfSpace.Name,
+			})
+			stopEventGUID = createEvent(repositories.CreateAuditEventMessage{
+				Type:      "audit.app.stop",
+				Target:    repositories.AuditEventTarget{GUID: "app-2", Type: "app"},
+				SpaceGUID: cfSpace.Name,
+			})
+			orgEventGUID = createEvent(repositories.CreateAuditEventMessage{
+				Type:             "audit.user.organization_user_add",
+				Target:           repositories.AuditEventTarget{GUID: "user-1", Type: "user"},
+				OrganizationGUID: cfOrg.Name,
+			})
+
+			message = repositories.ListAuditEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			var auditEvents []repositories.AuditEventRecord
+			auditEvents, listErr = auditEventRepo.ListAuditEvents(ctx, authInfo, message)
+
+			eventGUIDs = nil
+			for _, e := range auditEvents {
+				eventGUIDs = append(eventGUIDs, e.GUID)
+			}
+		})
+
+		It("returns an empty list as the user is not authorized in the org or space", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(eventGUIDs).To(BeEmpty())
+		})
+
+		When("the user is authorized in the org", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			})
+
+			It("returns the org events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(eventGUIDs).To(ConsistOf(orgEventGUID))
+			})
+
+			When("the user is authorized in the space", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+				})
+
+				It("returns all the events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(eventGUIDs).To(ConsistOf(startEventGUID, stopEventGUID, orgEventGUID))
+				})
+
+				When("filtering by types", func() {
+					BeforeEach(func() {
+						message.Types = []string{"audit.app.start"}
+					})
+
+					It("returns the events of that type", func() {
+						Expect(eventGUIDs).To(ConsistOf(startEventGUID))
+					})
+				})
+
+				When("filtering by target guids", func() {
+					BeforeEach(func() {
+						message.TargetGUIDs = []string{"app-2"}
+					})
+
+					It("returns the events of the target", func() {
+						Expect(eventGUIDs).To(ConsistOf(stopEventGUID))
+					})
+				})
+
+				When("filtering by space guids", func() {
+					BeforeEach(func() {
+						message.SpaceGUIDs = []string{cfSpace.Name}
+					})
+
+					It("returns the events in the space", func() {
+						Expect(eventGUIDs).To(ConsistOf(startEventGUID, stopEventGUID))
+					})
+				})
+
+				When("filtering by organization guids", func() {
+					BeforeEach(func() {
+						message.OrganizationGUIDs = []string{cfOrg.Name}
+					})
+
+					It("returns the events in the org and its spaces", func() {
+						Expect(eventGUIDs).To(ConsistOf(startEventGUID, stopEventGUID, orgEventGUID))
+					})
+				})
+
+				When("filtering by a value that cannot be a label value", func() {
+					BeforeEach(func() {
+						message.TargetGUIDs = []string{"not a guid!"}
+					})
+
+					It("returns an empty list", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(eventGUIDs).To(BeEmpty())
+					})
+				})
+
+				When("filtering by created_ats", func() {
+					BeforeEach(func() {
+						message.CreatedAts.Before = tools.PtrTo(time.Now().Add(-time.Hour))
+					})
+
+					It("returns the events created in the interval", func() {
+						Expect(eventGUIDs).To(BeEmpty())
+					})
+				})
+			})
+		})
+	})
+})
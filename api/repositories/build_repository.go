@@ -32,6 +32,8 @@ const (
 
 	BuildResourceType    = "Build"
 	stagingLogSourceType = "STG"
+	// staging runs in a single instance
+	stagingLogInstanceIndex = "0"
 )
 
 type BuildRecord struct {
@@ -52,10 +54,11 @@ type BuildRecord struct {
 }
 
 type LogRecord struct {
-	Message   string
-	Timestamp int64
-	Header    string
-	Tags      map[string]string
+	Message       string
+	Timestamp     int64
+	Header        string
+	InstanceIndex string
+	Tags          map[string]string
 }
 
 type BuildRepo struct {
@@ -145,10 +148,12 @@ func (b *BuildRepo) GetBuildLogs(ctx context.Context, authInfo authorization.Inf
 		logLine, logTime, _ := parseRFC3339NanoTime(log)
 
 		toReturn = append(toReturn, LogRecord{
-			Message:   logLine,
-			Timestamp: logTime,
+			Message:       logLine,
+			Timestamp:     logTime,
+			InstanceIndex: stagingLogInstanceIndex,
 			Tags: map[string]string{
-				"source_type": stagingLogSourceType,
+				"source_type":     stagingLogSourceType,
+				"source_instance": stagingLogInstanceIndex,
 			},
 		})
 	}
@@ -494,6 +494,53 @@ var _ = Describe("BuildRepository", func() {
 			})
 		})
 	})
+
+	Describe("GetBuildLogs", func() {
+		var (
+			buildGUID string
+			logs      []repositories.LogRecord
+			logsErr   error
+		)
+
+		BeforeEach(func() {
+			buildGUID = uuid.NewString()
+			buildRepo = repositories.NewBuildRepo(namespaceRetriever, newLogsClientFactory(
+				"2024-01-02T03:04:05.000000000Z staging\n",
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "space-guid",
+						Name:      "build-pod",
+						Labels: map[string]string{
+							repositories.BuildWorkloadLabelKey: buildGUID,
+						},
+					},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{{
+							Name:  "build",
+							State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+						}},
+					},
+				},
+			))
+		})
+
+		JustBeforeEach(func() {
+			logs, logsErr = buildRepo.GetBuildLogs(ctx, authInfo, "space-guid", buildGUID)
+		})
+
+		It("tags the logs as staging logs", func() {
+			Expect(logsErr).NotTo(HaveOccurred())
+			Expect(logs).To(ConsistOf(repositories.LogRecord{
+				Message:       "staging",
+				Timestamp:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixNano(),
+				InstanceIndex: "0",
+				Tags: map[string]string{
+					"source_type":     "STG",
+					"source_instance": "0",
+				},
+			}))
+		})
+	})
 })
 
 func cleanupBuild(ctx context.Context, buildGUID, namespace string) error {
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -41,27 +42,36 @@ func NewBuildpackRepository(builderName string, userClientFactory authorization.
 }
 
 func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo authorization.Info) ([]BuildpackRecord, error) {
-	var builderInfo korifiv1alpha1.BuilderInfo
-
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build user client: %w", err)
 	}
 
-	err = userClient.Get(
+	builderInfo, err := getReadyBuilderInfo(ctx, userClient, r.rootNamespace, r.builderName, BuildpackResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	return builderInfoToBuildpackRecords(builderInfo), nil
+}
+
+func getReadyBuilderInfo(ctx context.Context, userClient client.Client, rootNamespace, builderName, resourceType string) (korifiv1alpha1.BuilderInfo, error) {
+	var builderInfo korifiv1alpha1.BuilderInfo
+
+	err := userClient.Get(
 		ctx,
 		types.NamespacedName{
-			Namespace: r.rootNamespace,
-			Name:      r.builderName,
+			Namespace: rootNamespace,
+			Name:      builderName,
 		},
 		&builderInfo,
 	)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not found in namespace %q", r.builderName, r.rootNamespace))
+			return korifiv1alpha1.BuilderInfo{}, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not found in namespace %q", builderName, rootNamespace))
 		}
 
-		return nil, apierrors.FromK8sError(err, BuildpackResourceType)
+		return korifiv1alpha1.BuilderInfo{}, apierrors.FromK8sError(err, resourceType)
 	}
 
 	if !meta.IsStatusConditionTrue(builderInfo.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
@@ -76,10 +86,10 @@ func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo autho
 			conditionNotReadyMessage = "resource not reconciled"
 		}
 
-		return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready: %s", r.builderName, conditionNotReadyMessage))
+		return korifiv1alpha1.BuilderInfo{}, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready: %s", builderName, conditionNotReadyMessage))
 	}
 
-	return builderInfoToBuildpackRecords(builderInfo), nil
+	return builderInfo, nil
 }
 
 func builderInfoToBuildpackRecords(info korifiv1alpha1.BuilderInfo) []BuildpackRecord {
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -12,26 +13,52 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/version"
 	"github.com/go-logr/logr"
+	"github.com/google/uuid"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const DeploymentResourceType = "Deployment"
 
 type DeploymentRepo struct {
-	userClientFactory  authorization.UserK8sClientFactory
-	namespaceRetriever NamespaceRetriever
+	userClientFactory    authorization.UserK8sClientFactory
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
 }
 
 type DeploymentRecord struct {
-	GUID        string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	DropletGUID string
-	Status      DeploymentStatus
+	GUID                string
+	SpaceGUID           string
+	AppGUID             string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+	DropletGUID         string
+	PreviousDropletGUID string
+	Strategy            DeploymentStrategy
+	State               DeploymentState
+	Status              DeploymentStatus
 }
 
+type DeploymentStrategy string
+
+const (
+	DeploymentStrategyRolling DeploymentStrategy = "rolling"
+	DeploymentStrategyCanary  DeploymentStrategy = "canary"
+)
+
+type DeploymentState string
+
+const (
+	DeploymentStateDeploying DeploymentState = "DEPLOYING"
+	DeploymentStateDeployed  DeploymentState = "DEPLOYED"
+	DeploymentStateCanceling DeploymentState = "CANCELING"
+	DeploymentStateCanceled  DeploymentState = "CANCELED"
+)
+
 type DeploymentStatusValue string
 
 const (
@@ -42,8 +69,12 @@ const (
 type DeploymentStatusReason string
 
 const (
-	DeploymentStatusReasonDeploying DeploymentStatusReason = "DEPLOYING"
-	DeploymentStatusReasonDeployed  DeploymentStatusReason = "DEPLOYED"
+	DeploymentStatusReasonDeploying  DeploymentStatusReason = "DEPLOYING"
+	DeploymentStatusReasonPaused     DeploymentStatusReason = "PAUSED"
+	DeploymentStatusReasonDeployed   DeploymentStatusReason = "DEPLOYED"
+	DeploymentStatusReasonCanceling  DeploymentStatusReason = "CANCELING"
+	DeploymentStatusReasonCanceled   DeploymentStatusReason = "CANCELED"
+	DeploymentStatusReasonSuperseded DeploymentStatusReason = "SUPERSEDED"
 )
 
 type DeploymentStatus struct {
@@ -54,20 +85,61 @@ type DeploymentStatus struct {
 type CreateDeploymentMessage struct {
 	AppGUID     string
 	DropletGUID string
+	// RevisionGUID rolls the app back to the droplet, environment variables
+	// and process commands recorded by a revision
+	RevisionGUID string
+	Strategy     DeploymentStrategy
+}
+
+type ListDeploymentsMessage struct {
+	AppGUIDs []string
+	States   []DeploymentState
 }
 
 func NewDeploymentRepo(
 	userClientFactory authorization.UserK8sClientFactory,
 	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
 ) *DeploymentRepo {
 	return &DeploymentRepo{
-		userClientFactory:  userClientFactory,
-		namespaceRetriever: namespaceRetriever,
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
 	}
 }
 
 func (r *DeploymentRepo) GetDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, deploymentGUID, DeploymentResourceType)
+	if errors.As(err, new(apierrors.NotFoundError)) {
+		return r.getLegacyDeployment(ctx, authInfo, deploymentGUID, err)
+	}
+	if err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("get-deployment failed to create user client: %w", err)
+	}
+
+	deployment := &korifiv1alpha1.CFDeployment{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: deploymentGUID}, deployment)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return deploymentToRecord(deployment), nil
+}
+
+// getLegacyDeployment returns the deployment with the given guid as created
+// by earlier versions of Korifi, which used the app guid as deployment guid
+// and did not record deployments. notFoundErr is returned if there is no such
+// app.
+func (r *DeploymentRepo) getLegacyDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string, notFoundErr error) (DeploymentRecord, error) {
 	ns, err := r.namespaceRetriever.NamespaceFor(ctx, deploymentGUID, AppResourceType)
+	if errors.As(err, new(apierrors.NotFoundError)) {
+		return DeploymentRecord{}, notFoundErr
+	}
 	if err != nil {
 		return DeploymentRecord{}, err
 	}
@@ -83,10 +155,48 @@ func (r *DeploymentRepo) GetDeployment(ctx context.Context, authInfo authorizati
 		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
 	}
 
-	return appToDeploymentRecord(app), nil
+	return legacyDeploymentToRecord(app), nil
+}
+
+func (r *DeploymentRepo) ListDeployments(ctx context.Context, authInfo authorization.Info, message ListDeploymentsMessage) ([]DeploymentRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	preds := []func(DeploymentRecord) bool{
+		SetPredicate(message.AppGUIDs, func(d DeploymentRecord) string { return d.AppGUID }),
+		SetPredicate(message.States, func(d DeploymentRecord) DeploymentState { return d.State }),
+	}
+
+	var deployments []korifiv1alpha1.CFDeployment
+	for _, ns := range orderedNamespaces(nsList) {
+		deploymentList := &korifiv1alpha1.CFDeploymentList{}
+		err := listInChunks(ctx, userClient, deploymentList, func(l *korifiv1alpha1.CFDeploymentList) {
+			deployments = append(deployments, l.Items...)
+		}, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deployments in namespace %s: %w", ns, apierrors.FromK8sError(err, DeploymentResourceType))
+		}
+	}
+
+	records := []DeploymentRecord{}
+	for i := range deployments {
+		records = append(records, deploymentToRecord(&deployments[i]))
+	}
+
+	return Filter(records, preds...), nil
 }
 
-func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authorization.Info, message CreateDeploymentMessage) (DeploymentRecord, error) {
+func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authorization.Info, message CreateDeploymentMessage) (_ DeploymentRecord, err error) {
 	ns, err := r.namespaceRetriever.NamespaceFor(ctx, message.AppGUID, AppResourceType)
 	if err != nil {
 		return DeploymentRecord{}, err
@@ -103,51 +213,335 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
 	}
 
-	if err = ensureSupport(ctx, userClient, app); err != nil {
+	strategy := message.Strategy
+	if strategy == "" {
+		strategy = DeploymentStrategyRolling
+	}
+
+	if err = ensureSupport(ctx, userClient, app, strategy); err != nil {
 		return DeploymentRecord{}, err
 	}
 
-	dropletGUID := app.Spec.CurrentDropletRef.Name
+	previousDropletGUID := app.Spec.CurrentDropletRef.Name
+	dropletGUID := previousDropletGUID
 	if message.DropletGUID != "" {
 		dropletGUID = message.DropletGUID
 	}
 
+	rollbackRevisionName := ""
+	if message.RevisionGUID != "" {
+		var revision *korifiv1alpha1.CFRevision
+		revision, err = getRollbackRevision(ctx, userClient, app, message.RevisionGUID)
+		if err != nil {
+			return DeploymentRecord{}, err
+		}
+		rollbackRevisionName = revision.Name
+		dropletGUID = revision.Spec.DropletRef.Name
+	}
+
+	appDeployments := &korifiv1alpha1.CFDeploymentList{}
+	err = userClient.List(ctx, appDeployments, client.InNamespace(ns), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: app.Name})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
 	appRev := app.Annotations[korifiv1alpha1.CFAppRevisionKey]
-	newRev, err := bumpAppRev(appRev)
+	newRev, err := nextAppRev(appRev, appDeployments.Items)
 	if err != nil {
 		return DeploymentRecord{}, fmt.Errorf("expected app-rev to be an integer: %w", err)
 	}
 
 	err = k8s.PatchResource(ctx, userClient, app, func() {
-		app.Spec.CurrentDropletRef.Name = dropletGUID
+		app.Spec.DesiredState = korifiv1alpha1.StartedState
 		if app.Annotations == nil {
 			app.Annotations = map[string]string{}
 		}
+
+		// no revision is recorded while the app is rolled back, until the
+		// deployment controller has restored the config of the revision
+		if rollbackRevisionName != "" {
+			app.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey] = rollbackRevisionName
+		}
+
+		// a canary deployment runs the new revision next to the current one
+		// and only moves the app to it when it is continued
+		if strategy == DeploymentStrategyCanary {
+			app.Spec.Canary = &korifiv1alpha1.CanarySpec{
+				DropletRef: corev1.LocalObjectReference{Name: dropletGUID},
+				Revision:   newRev,
+			}
+			return
+		}
+
+		app.Spec.Canary = nil
+		app.Spec.CurrentDropletRef.Name = dropletGUID
 		app.Annotations[korifiv1alpha1.CFAppRevisionKey] = newRev
-		app.Spec.DesiredState = korifiv1alpha1.StartedState
 	})
 	if err != nil {
 		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
 	}
 
-	return appToDeploymentRecord(app), nil
+	if rollbackRevisionName != "" {
+		// the app is not rolled back if it cannot be deployed, so it must
+		// not wait for the revision to be rolled out
+		defer func() {
+			if err != nil {
+				clearRollbackRevision(ctx, userClient, app)
+			}
+		}()
+	}
+
+	deployment := &korifiv1alpha1.CFDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: app.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFDeploymentSpec{
+			AppRef:              corev1.LocalObjectReference{Name: app.Name},
+			DropletRef:          corev1.LocalObjectReference{Name: dropletGUID},
+			Revision:            newRev,
+			RollbackRevisionRef: corev1.LocalObjectReference{Name: rollbackRevisionName},
+			PreviousDropletRef:  corev1.LocalObjectReference{Name: previousDropletGUID},
+			PreviousRevision:    appRev,
+			Strategy:            korifiv1alpha1.DeploymentStrategy(strategy),
+		},
+	}
+	err = userClient.Create(ctx, deployment)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return deploymentToRecord(deployment), nil
+}
+
+// getRollbackRevision returns the revision the app is rolled back to. The
+// deployment controller restores the environment variables and process
+// commands of the revision once the app runs the deployment revision.
+func getRollbackRevision(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp, revisionGUID string) (*korifiv1alpha1.CFRevision, error) {
+	revision := &korifiv1alpha1.CFRevision{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: revisionGUID}, revision)
+	if k8serrors.IsNotFound(err) || (err == nil && revision.Spec.AppRef.Name != app.Name) {
+		return nil, apierrors.NewUnprocessableEntityError(err, "The revision does not exist or does not belong to the app")
+	}
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: revision.Spec.EnvSecretName}, &corev1.Secret{})
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	return revision, nil
+}
+
+func clearRollbackRevision(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp) {
+	err := k8s.PatchResource(ctx, userClient, app, func() {
+		delete(app.Annotations, korifiv1alpha1.CFAppRollbackRevisionKey)
+	})
+	if err != nil {
+		logr.FromContextOrDiscard(ctx).Info("failed to clear the rollback revision of the app", "app", app.Name, "reason", err)
+	}
+}
+
+// CancelDeployment rolls the app back to the droplet and revision it was
+// running before the deployment
+func (r *DeploymentRepo) CancelDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, deploymentGUID, DeploymentResourceType)
+	if err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("cancel-deployment failed to create user client: %w", err)
+	}
+
+	deployment := &korifiv1alpha1.CFDeployment{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: deploymentGUID}, deployment)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	record := deploymentToRecord(deployment)
+	if record.Status.Value == DeploymentStatusValueFinalized {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+			"Cannot cancel a deployment with status: %s and reason: %s", record.Status.Value, record.Status.Reason,
+		))
+	}
+
+	if deployment.Spec.PreviousDropletRef.Name == "" {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "Cannot cancel a deployment without a previous droplet to roll back to")
+	}
+
+	app := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: deployment.Spec.AppRef.Name}, app)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, app, func() {
+		app.Spec.Canary = nil
+		app.Spec.CurrentDropletRef.Name = deployment.Spec.PreviousDropletRef.Name
+		if app.Annotations == nil {
+			app.Annotations = map[string]string{}
+		}
+		app.Annotations[korifiv1alpha1.CFAppRevisionKey] = deployment.Spec.PreviousRevision
+		if deployment.Spec.RollbackRevisionRef.Name != "" {
+			delete(app.Annotations, korifiv1alpha1.CFAppRollbackRevisionKey)
+		}
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, deployment, func() {
+		deployment.Spec.Canceled = true
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return deploymentToRecord(deployment), nil
+}
+
+// ContinueDeployment moves the app to the revision run by the canary
+// instances of a paused canary deployment
+func (r *DeploymentRepo) ContinueDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, deploymentGUID, DeploymentResourceType)
+	if err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("continue-deployment failed to create user client: %w", err)
+	}
+
+	deployment := &korifiv1alpha1.CFDeployment{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: deploymentGUID}, deployment)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	record := deploymentToRecord(deployment)
+	if record.Status.Reason != DeploymentStatusReasonPaused {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+			"Cannot continue a deployment with status: %s and reason: %s", record.Status.Value, record.Status.Reason,
+		))
+	}
+
+	app := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: deployment.Spec.AppRef.Name}, app)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, app, func() {
+		app.Spec.Canary = nil
+		app.Spec.CurrentDropletRef.Name = deployment.Spec.DropletRef.Name
+		if app.Annotations == nil {
+			app.Annotations = map[string]string{}
+		}
+		app.Annotations[korifiv1alpha1.CFAppRevisionKey] = deployment.Spec.Revision
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, deployment, func() {
+		deployment.Spec.Continued = true
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return deploymentToRecord(deployment), nil
 }
 
-func bumpAppRev(appRev string) (string, error) {
-	r, err := strconv.Atoi(appRev)
+// nextAppRev returns a revision that has not been used by any of the app
+// deployments yet, so that a deployment can tell whether the app has been
+// deployed again after it has been canceled
+func nextAppRev(appRev string, deployments []korifiv1alpha1.CFDeployment) (string, error) {
+	maxRev, err := strconv.Atoi(appRev)
 	if err != nil {
 		return "", err
 	}
 
-	return strconv.Itoa(r + 1), nil
+	for _, d := range deployments {
+		rev, err := strconv.Atoi(d.Spec.Revision)
+		if err != nil {
+			continue
+		}
+		maxRev = max(maxRev, rev)
+	}
+
+	return strconv.Itoa(maxRev + 1), nil
+}
+
+func deploymentToRecord(deployment *korifiv1alpha1.CFDeployment) DeploymentRecord {
+	record := DeploymentRecord{
+		GUID:                deployment.Name,
+		SpaceGUID:           deployment.Namespace,
+		AppGUID:             deployment.Spec.AppRef.Name,
+		CreatedAt:           deployment.CreationTimestamp.Time,
+		UpdatedAt:           getLastUpdatedTime(deployment),
+		DropletGUID:         deployment.Spec.DropletRef.Name,
+		PreviousDropletGUID: deployment.Spec.PreviousDropletRef.Name,
+		Strategy:            DeploymentStrategyRolling,
+		State:               DeploymentStateDeploying,
+		Status: DeploymentStatus{
+			Value:  DeploymentStatusValueActive,
+			Reason: DeploymentStatusReasonDeploying,
+		},
+	}
+
+	if deployment.Spec.Strategy != "" {
+		record.Strategy = DeploymentStrategy(deployment.Spec.Strategy)
+	}
+
+	finalizedCondition := meta.FindStatusCondition(deployment.Status.Conditions, korifiv1alpha1.DeploymentFinalizedConditionType)
+
+	if deployment.Spec.Canceled {
+		record.State = DeploymentStateCanceling
+		record.Status.Reason = DeploymentStatusReasonCanceling
+	} else if !deployment.Spec.Continued && finalizedCondition != nil && finalizedCondition.Reason == korifiv1alpha1.DeploymentPausedReason {
+		record.Status.Reason = DeploymentStatusReasonPaused
+	}
+
+	if finalizedCondition == nil || finalizedCondition.Status != metav1.ConditionTrue {
+		return record
+	}
+
+	record.Status.Value = DeploymentStatusValueFinalized
+	switch finalizedCondition.Reason {
+	case korifiv1alpha1.DeploymentCanceledReason:
+		record.State = DeploymentStateCanceled
+		record.Status.Reason = DeploymentStatusReasonCanceled
+	case korifiv1alpha1.DeploymentSupersededReason:
+		record.State = DeploymentStateDeployed
+		record.Status.Reason = DeploymentStatusReasonSuperseded
+	default:
+		record.State = DeploymentStateDeployed
+		record.Status.Reason = DeploymentStatusReasonDeployed
+	}
+
+	return record
 }
 
-func appToDeploymentRecord(cfApp *korifiv1alpha1.CFApp) DeploymentRecord {
-	deploymentRecord := DeploymentRecord{
+func legacyDeploymentToRecord(cfApp *korifiv1alpha1.CFApp) DeploymentRecord {
+	record := DeploymentRecord{
 		GUID:        cfApp.Name,
+		SpaceGUID:   cfApp.Namespace,
+		AppGUID:     cfApp.Name,
 		CreatedAt:   cfApp.CreationTimestamp.Time,
 		UpdatedAt:   getLastUpdatedTime(cfApp),
 		DropletGUID: cfApp.Spec.CurrentDropletRef.Name,
+		Strategy:    DeploymentStrategyRolling,
+		State:       DeploymentStateDeploying,
 		Status: DeploymentStatus{
 			Value:  DeploymentStatusValueActive,
 			Reason: DeploymentStatusReasonDeploying,
@@ -155,16 +549,17 @@ func appToDeploymentRecord(cfApp *korifiv1alpha1.CFApp) DeploymentRecord {
 	}
 
 	if meta.IsStatusConditionTrue(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
-		deploymentRecord.Status = DeploymentStatus{
+		record.State = DeploymentStateDeployed
+		record.Status = DeploymentStatus{
 			Value:  DeploymentStatusValueFinalized,
 			Reason: DeploymentStatusReasonDeployed,
 		}
 	}
 
-	return deploymentRecord
+	return record
 }
 
-func ensureSupport(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp) error {
+func ensureSupport(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp, strategy DeploymentStrategy) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("repo.deployment.ensureSupport")
 
 	var appWorkloadsList korifiv1alpha1.AppWorkloadList
@@ -190,7 +585,7 @@ func ensureSupport(ctx context.Context, userClient client.Client, app *korifiv1a
 				"version", appWorkload.Annotations[version.KorifiCreationVersionKey],
 			)
 		}
-		return apierrors.NewUnprocessableEntityError(nil, "App instances created with an older version of Korifi can't use the rolling strategy. Please restart/restage/re-push app before using the rolling strategy")
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("App instances created with an older version of Korifi can't use the %[1]s strategy. Please restart/restage/re-push app before using the %[1]s strategy", strategy))
 	}
 
 	return nil
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/version"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,25 +45,27 @@ var _ = Describe("DeploymentRepository", func() {
 			},
 		})).To(Succeed())
 
-		deploymentRepo = repositories.NewDeploymentRepo(userClientFactory, namespaceRetriever)
+		deploymentRepo = repositories.NewDeploymentRepo(userClientFactory, namespaceRetriever, nsPerms)
 	})
 
 	Describe("GetDeployment", func() {
 		var (
-			deployment repositories.DeploymentRecord
-			getErr     error
-			cfAppGUID  string
+			cfDeployment   *korifiv1alpha1.CFDeployment
+			deployment     repositories.DeploymentRecord
+			getErr         error
+			deploymentGUID string
 		)
 
 		BeforeEach(func() {
-			cfAppGUID = cfApp.Name
+			cfDeployment = createDeployment(cfApp, "2", "1", false)
+			deploymentGUID = cfDeployment.Name
 		})
 
 		JustBeforeEach(func() {
-			deployment, getErr = deploymentRepo.GetDeployment(ctx, authInfo, cfAppGUID)
+			deployment, getErr = deploymentRepo.GetDeployment(ctx, authInfo, deploymentGUID)
 		})
 
-		It("returns a forbidden error (as the user is not allowed to get apps)", func() {
+		It("returns a forbidden error (as the user is not allowed to get deployments)", func() {
 			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
 		})
 
@@ -75,42 +78,193 @@ var _ = Describe("DeploymentRepository", func() {
 			It("fetches the deployment", func() {
 				Expect(getErr).NotTo(HaveOccurred())
 
-				Expect(deployment.GUID).To(Equal(cfApp.Name))
-				Expect(deployment.DropletGUID).To(Equal(cfApp.Spec.CurrentDropletRef.Name))
+				Expect(deployment.GUID).To(Equal(cfDeployment.Name))
+				Expect(deployment.AppGUID).To(Equal(cfApp.Name))
+				Expect(deployment.SpaceGUID).To(Equal(cfSpace.Name))
+				Expect(deployment.DropletGUID).To(Equal("new-droplet"))
+				Expect(deployment.PreviousDropletGUID).To(Equal("old-droplet"))
+				Expect(deployment.Strategy).To(Equal(repositories.DeploymentStrategyRolling))
+				Expect(deployment.State).To(Equal(repositories.DeploymentStateDeploying))
 				Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
 				Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeploying))
 				Expect(deployment.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
 				Expect(deployment.UpdatedAt).To(gstruct.PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
 			})
 
-			When("the app is ready", func() {
+			When("the deployment is canceled", func() {
 				BeforeEach(func() {
-					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
-						meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
-							Type:   korifiv1alpha1.StatusConditionReady,
-							Status: metav1.ConditionTrue,
-							Reason: "ready",
+					Expect(k8s.PatchResource(ctx, k8sClient, cfDeployment, func() {
+						cfDeployment.Spec.Canceled = true
+					})).To(Succeed())
+				})
+
+				It("returns a canceling deployment", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+
+					Expect(deployment.State).To(Equal(repositories.DeploymentStateCanceling))
+					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
+					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonCanceling))
+				})
+			})
+
+			When("the deployment is a paused canary deployment", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfDeployment, func() {
+						cfDeployment.Spec.Strategy = korifiv1alpha1.DeploymentStrategyCanary
+						meta.SetStatusCondition(&cfDeployment.Status.Conditions, metav1.Condition{
+							Type:   korifiv1alpha1.DeploymentFinalizedConditionType,
+							Status: metav1.ConditionFalse,
+							Reason: korifiv1alpha1.DeploymentPausedReason,
 						})
 					})).To(Succeed())
 				})
 
-				It("returns a finalized deployment", func() {
+				It("returns a paused deployment", func() {
 					Expect(getErr).NotTo(HaveOccurred())
 
-					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
-					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeployed))
+					Expect(deployment.Strategy).To(Equal(repositories.DeploymentStrategyCanary))
+					Expect(deployment.State).To(Equal(repositories.DeploymentStateDeploying))
+					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
+					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonPaused))
 				})
 			})
 
-			When("the app does not exist", func() {
+			DescribeTable("finalized deployments",
+				func(reason string, expectedState repositories.DeploymentState, expectedReason repositories.DeploymentStatusReason) {
+					finalizeDeployment(cfDeployment, reason)
+
+					deployment, getErr = deploymentRepo.GetDeployment(ctx, authInfo, deploymentGUID)
+					Expect(getErr).NotTo(HaveOccurred())
+
+					Expect(deployment.State).To(Equal(expectedState))
+					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
+					Expect(deployment.Status.Reason).To(Equal(expectedReason))
+				},
+				Entry("deployed", korifiv1alpha1.DeploymentDeployedReason, repositories.DeploymentStateDeployed, repositories.DeploymentStatusReasonDeployed),
+				Entry("canceled", korifiv1alpha1.DeploymentCanceledReason, repositories.DeploymentStateCanceled, repositories.DeploymentStatusReasonCanceled),
+				Entry("superseded", korifiv1alpha1.DeploymentSupersededReason, repositories.DeploymentStateDeployed, repositories.DeploymentStatusReasonSuperseded),
+			)
+
+			When("the deployment does not exist", func() {
 				BeforeEach(func() {
-					cfAppGUID = "i-do-not-exist"
+					deploymentGUID = "i-do-not-exist"
 				})
 
 				It("returns a not found error", func() {
 					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
 				})
 			})
+
+			When("the deployment guid is the guid of the app, as for deployments created by earlier versions", func() {
+				BeforeEach(func() {
+					deploymentGUID = cfApp.Name
+				})
+
+				It("returns a deployment of the current droplet of the app", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+
+					Expect(deployment.GUID).To(Equal(cfApp.Name))
+					Expect(deployment.AppGUID).To(Equal(cfApp.Name))
+					Expect(deployment.SpaceGUID).To(Equal(cfSpace.Name))
+					Expect(deployment.DropletGUID).To(Equal(cfApp.Spec.CurrentDropletRef.Name))
+					Expect(deployment.Strategy).To(Equal(repositories.DeploymentStrategyRolling))
+					Expect(deployment.State).To(Equal(repositories.DeploymentStateDeploying))
+					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
+					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeploying))
+				})
+
+				When("the app is ready", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+							meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
+								Type:   korifiv1alpha1.StatusConditionReady,
+								Status: metav1.ConditionTrue,
+								Reason: "Ready",
+							})
+						})).To(Succeed())
+					})
+
+					It("returns a deployed deployment", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+
+						Expect(deployment.State).To(Equal(repositories.DeploymentStateDeployed))
+						Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
+						Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeployed))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("ListDeployments", func() {
+		var (
+			otherApp        *korifiv1alpha1.CFApp
+			deployment1     *korifiv1alpha1.CFDeployment
+			deployment2     *korifiv1alpha1.CFDeployment
+			otherDeployment *korifiv1alpha1.CFDeployment
+			message         repositories.ListDeploymentsMessage
+			deploymentGUIDs []string
+			listErr         error
+		)
+
+		BeforeEach(func() {
+			otherApp = createApp(cfSpace.Name)
+
+			deployment1 = createDeployment(cfApp, "2", "1", false)
+			deployment2 = createDeployment(cfApp, "3", "2", false)
+			finalizeDeployment(deployment1, korifiv1alpha1.DeploymentSupersededReason)
+			otherDeployment = createDeployment(otherApp, "2", "1", true)
+
+			message = repositories.ListDeploymentsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			var deployments []repositories.DeploymentRecord
+			deployments, listErr = deploymentRepo.ListDeployments(ctx, authInfo, message)
+
+			deploymentGUIDs = nil
+			for _, d := range deployments {
+				deploymentGUIDs = append(deploymentGUIDs, d.GUID)
+			}
+		})
+
+		It("returns an empty list as the user is not authorized in the space", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(deploymentGUIDs).To(BeEmpty())
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("lists all the deployments in the space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(deploymentGUIDs).To(ConsistOf(deployment1.Name, deployment2.Name, otherDeployment.Name))
+			})
+
+			When("filtering by app guids", func() {
+				BeforeEach(func() {
+					message.AppGUIDs = []string{cfApp.Name}
+				})
+
+				It("returns the deployment history of the app", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(deploymentGUIDs).To(ConsistOf(deployment1.Name, deployment2.Name))
+				})
+			})
+
+			When("filtering by states", func() {
+				BeforeEach(func() {
+					message.States = []repositories.DeploymentState{repositories.DeploymentStateDeployed, repositories.DeploymentStateCanceling}
+				})
+
+				It("returns the deployments in the given states", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(deploymentGUIDs).To(ConsistOf(deployment1.Name, otherDeployment.Name))
+				})
+			})
 		})
 	})
 
@@ -144,8 +298,13 @@ var _ = Describe("DeploymentRepository", func() {
 			It("creates the deployment", func() {
 				Expect(createErr).NotTo(HaveOccurred())
 
-				Expect(deployment.GUID).To(Equal(cfApp.Name))
+				Expect(deployment.GUID).NotTo(BeEmpty())
+				Expect(deployment.GUID).NotTo(Equal(cfApp.Name))
+				Expect(deployment.AppGUID).To(Equal(cfApp.Name))
+				Expect(deployment.SpaceGUID).To(Equal(cfSpace.Name))
 				Expect(deployment.DropletGUID).To(Equal(cfApp.Spec.CurrentDropletRef.Name))
+				Expect(deployment.PreviousDropletGUID).To(Equal(cfApp.Spec.CurrentDropletRef.Name))
+				Expect(deployment.State).To(Equal(repositories.DeploymentStateDeploying))
 				Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
 				Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeploying))
 				Expect(deployment.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
@@ -174,22 +333,81 @@ var _ = Describe("DeploymentRepository", func() {
 				Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(currentDropletGUID))
 			})
 
-			When("the app is ready", func() {
+			It("stores the deployment", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfDeployment := &korifiv1alpha1.CFDeployment{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: deployment.GUID}, cfDeployment)).To(Succeed())
+				Expect(cfDeployment.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name))
+				Expect(cfDeployment.Spec).To(Equal(korifiv1alpha1.CFDeploymentSpec{
+					AppRef:             corev1.LocalObjectReference{Name: cfApp.Name},
+					DropletRef:         corev1.LocalObjectReference{Name: cfApp.Spec.CurrentDropletRef.Name},
+					Revision:           "2",
+					PreviousDropletRef: corev1.LocalObjectReference{Name: cfApp.Spec.CurrentDropletRef.Name},
+					PreviousRevision:   "1",
+					Strategy:           korifiv1alpha1.DeploymentStrategyRolling,
+				}))
+			})
+
+			When("the app runs a canary", func() {
 				BeforeEach(func() {
-					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
-						meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
-							Type:   korifiv1alpha1.StatusConditionReady,
-							Status: metav1.ConditionTrue,
-							Reason: "ready",
-						})
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.Canary = &korifiv1alpha1.CanarySpec{
+							DropletRef: corev1.LocalObjectReference{Name: "canary-droplet"},
+							Revision:   "2",
+						}
 					})).To(Succeed())
 				})
 
-				It("creates a finalized deployment", func() {
+				It("removes the canary from the app", func() {
 					Expect(createErr).NotTo(HaveOccurred())
 
-					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
-					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeployed))
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.Canary).To(BeNil())
+				})
+			})
+
+			When("the deployment strategy is canary", func() {
+				BeforeEach(func() {
+					createDeploymentMessage.DropletGUID = "canary-droplet"
+					createDeploymentMessage.Strategy = repositories.DeploymentStrategyCanary
+				})
+
+				It("creates a canary deployment", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.Strategy).To(Equal(repositories.DeploymentStrategyCanary))
+					Expect(deployment.DropletGUID).To(Equal("canary-droplet"))
+
+					cfDeployment := &korifiv1alpha1.CFDeployment{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: deployment.GUID}, cfDeployment)).To(Succeed())
+					Expect(cfDeployment.Spec.Strategy).To(Equal(korifiv1alpha1.DeploymentStrategyCanary))
+					Expect(cfDeployment.Spec.Revision).To(Equal("2"))
+				})
+
+				It("runs the new droplet as a canary of the app", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					currentDropletGUID := cfApp.Spec.CurrentDropletRef.Name
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.Canary).To(gstruct.PointTo(Equal(korifiv1alpha1.CanarySpec{
+						DropletRef: corev1.LocalObjectReference{Name: "canary-droplet"},
+						Revision:   "2",
+					})))
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(currentDropletGUID))
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, "1"))
+				})
+			})
+
+			When("the app has already been deployed with a later revision", func() {
+				BeforeEach(func() {
+					createDeployment(cfApp, "5", "1", false)
+				})
+
+				It("bumps the app-rev annotation past the revisions of the existing deployments", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, "6"))
 				})
 			})
 
@@ -204,8 +422,121 @@ var _ = Describe("DeploymentRepository", func() {
 				It("sets the new droplet guid on the app", func() {
 					Expect(createErr).NotTo(HaveOccurred())
 
+					previousDropletGUID := cfApp.Spec.CurrentDropletRef.Name
 					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
 					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(newDropletGUID))
+
+					Expect(deployment.DropletGUID).To(Equal(newDropletGUID))
+					Expect(deployment.PreviousDropletGUID).To(Equal(previousDropletGUID))
+				})
+			})
+
+			When("revision guid is set on the create message", func() {
+				var (
+					cfRevision *korifiv1alpha1.CFRevision
+					cfProcess  *korifiv1alpha1.CFProcess
+				)
+
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfApp.Namespace,
+							Name:      cfApp.Spec.EnvSecretName,
+						},
+						StringData: map[string]string{"FOO": "new-foo", "BAR": "bar"},
+					})).To(Succeed())
+					cfProcess = createProcessCR(ctx, k8sClient, uuid.NewString(), cfSpace.Name, cfApp.Name)
+
+					cfRevision = createRevision(cfApp, 1, "revision-droplet", map[string]string{"FOO": "foo"})
+					createDeploymentMessage.RevisionGUID = cfRevision.Name
+				})
+
+				It("deploys the droplet of the revision", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.DropletGUID).To(Equal("revision-droplet"))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal("revision-droplet"))
+				})
+
+				It("annotates the app with the revision it is rolled back to", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRollbackRevisionKey, cfRevision.Name))
+				})
+
+				It("references the revision from the deployment", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					cfDeployment := &korifiv1alpha1.CFDeployment{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: deployment.GUID}, cfDeployment)).To(Succeed())
+					Expect(cfDeployment.Spec.RollbackRevisionRef.Name).To(Equal(cfRevision.Name))
+				})
+
+				It("leaves restoring the environment variables and process commands to the controllers", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					envSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: cfApp.Spec.EnvSecretName}, envSecret)).To(Succeed())
+					Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("new-foo"), "BAR": []byte("bar")}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					Expect(cfProcess.Spec.Command).NotTo(Equal("revision-command"))
+				})
+
+				When("the revision env secret does not exist", func() {
+					BeforeEach(func() {
+						Expect(k8sClient.Delete(ctx, &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: cfApp.Namespace,
+								Name:      cfRevision.Spec.EnvSecretName,
+							},
+						})).To(Succeed())
+					})
+
+					It("does not touch the app", func() {
+						Expect(createErr).To(HaveOccurred())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						Expect(cfApp.Spec.CurrentDropletRef.Name).NotTo(Equal("revision-droplet"))
+						Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppRollbackRevisionKey))
+					})
+				})
+
+				When("the app cannot be deployed", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+							cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "not-a-number"
+						})).To(Succeed())
+					})
+
+					It("does not leave the app annotated with the revision", func() {
+						Expect(createErr).To(HaveOccurred())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppRollbackRevisionKey))
+					})
+				})
+
+				When("the revision belongs to another app", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.RevisionGUID = createRevision(createApp(cfSpace.Name), 1, "other-droplet", nil).Name
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+
+				When("the revision does not exist", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.RevisionGUID = "i-do-not-exist"
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
 				})
 			})
 
@@ -234,8 +565,272 @@ var _ = Describe("DeploymentRepository", func() {
 
 				It("returns an error", func() {
 					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(createErr.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring("can't use the rolling strategy"))
+				})
+
+				When("the deployment strategy is canary", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.Strategy = repositories.DeploymentStrategyCanary
+					})
+
+					It("returns an error mentioning the canary strategy", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(createErr.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring("can't use the canary strategy"))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("CancelDeployment", func() {
+		var (
+			cfDeployment   *korifiv1alpha1.CFDeployment
+			deployment     repositories.DeploymentRecord
+			deploymentGUID string
+			cancelErr      error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+				cfApp.Spec.CurrentDropletRef.Name = "new-droplet"
+				cfApp.Annotations[CFAppRevisionKey] = "2"
+			})).To(Succeed())
+
+			cfDeployment = createDeployment(cfApp, "2", "1", false)
+			deploymentGUID = cfDeployment.Name
+		})
+
+		JustBeforeEach(func() {
+			deployment, cancelErr = deploymentRepo.CancelDeployment(ctx, authInfo, deploymentGUID)
+		})
+
+		It("returns a forbidden error (as the user is not allowed to get deployments)", func() {
+			Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns a canceling deployment", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+
+				Expect(deployment.GUID).To(Equal(cfDeployment.Name))
+				Expect(deployment.State).To(Equal(repositories.DeploymentStateCanceling))
+				Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
+				Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonCanceling))
+			})
+
+			It("marks the deployment as canceled", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDeployment), cfDeployment)).To(Succeed())
+				Expect(cfDeployment.Spec.Canceled).To(BeTrue())
+			})
+
+			It("rolls the app back to the previous droplet and revision", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal("old-droplet"))
+				Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, "1"))
+			})
+
+			When("the deployment rolls the app back to a revision", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfDeployment, func() {
+						cfDeployment.Spec.RollbackRevisionRef.Name = "the-revision"
+					})).To(Succeed())
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey] = "the-revision"
+					})).To(Succeed())
+				})
+
+				It("does not leave the app annotated with the revision", func() {
+					Expect(cancelErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppRollbackRevisionKey))
+				})
+			})
+
+			When("the app runs the deployment canary", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.Canary = &korifiv1alpha1.CanarySpec{
+							DropletRef: corev1.LocalObjectReference{Name: "new-droplet"},
+							Revision:   "2",
+						}
+					})).To(Succeed())
+				})
+
+				It("removes the canary from the app", func() {
+					Expect(cancelErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.Canary).To(BeNil())
+				})
+			})
+
+			When("the deployment is finalized", func() {
+				BeforeEach(func() {
+					finalizeDeployment(cfDeployment, korifiv1alpha1.DeploymentDeployedReason)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(cancelErr).To(MatchError(ContainSubstring("status: FINALIZED and reason: DEPLOYED")))
+				})
+
+				It("does not roll the app back", func() {
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal("new-droplet"))
+				})
+			})
+
+			When("the deployment does not exist", func() {
+				BeforeEach(func() {
+					deploymentGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ContinueDeployment", func() {
+		var (
+			cfDeployment   *korifiv1alpha1.CFDeployment
+			deployment     repositories.DeploymentRecord
+			deploymentGUID string
+			continueErr    error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+				cfApp.Spec.CurrentDropletRef.Name = "old-droplet"
+				cfApp.Spec.Canary = &korifiv1alpha1.CanarySpec{
+					DropletRef: corev1.LocalObjectReference{Name: "new-droplet"},
+					Revision:   "2",
+				}
+			})).To(Succeed())
+
+			cfDeployment = createDeployment(cfApp, "2", "1", false)
+			Expect(k8s.Patch(ctx, k8sClient, cfDeployment, func() {
+				meta.SetStatusCondition(&cfDeployment.Status.Conditions, metav1.Condition{
+					Type:   korifiv1alpha1.DeploymentFinalizedConditionType,
+					Status: metav1.ConditionFalse,
+					Reason: korifiv1alpha1.DeploymentPausedReason,
+				})
+			})).To(Succeed())
+			deploymentGUID = cfDeployment.Name
+		})
+
+		JustBeforeEach(func() {
+			deployment, continueErr = deploymentRepo.ContinueDeployment(ctx, authInfo, deploymentGUID)
+		})
+
+		It("returns a forbidden error (as the user is not allowed to get deployments)", func() {
+			Expect(continueErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns a deploying deployment", func() {
+				Expect(continueErr).NotTo(HaveOccurred())
+
+				Expect(deployment.GUID).To(Equal(cfDeployment.Name))
+				Expect(deployment.State).To(Equal(repositories.DeploymentStateDeploying))
+				Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
+				Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeploying))
+			})
+
+			It("marks the deployment as continued", func() {
+				Expect(continueErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDeployment), cfDeployment)).To(Succeed())
+				Expect(cfDeployment.Spec.Continued).To(BeTrue())
+			})
+
+			It("moves the app to the canary droplet and revision", func() {
+				Expect(continueErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Canary).To(BeNil())
+				Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal("new-droplet"))
+				Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, "2"))
+			})
+
+			When("the deployment is not paused", func() {
+				BeforeEach(func() {
+					finalizeDeployment(cfDeployment, korifiv1alpha1.DeploymentDeployedReason)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(continueErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(continueErr).To(MatchError(ContainSubstring("status: FINALIZED and reason: DEPLOYED")))
+				})
+
+				It("does not move the app to the canary", func() {
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal("old-droplet"))
+				})
+			})
+
+			When("the deployment does not exist", func() {
+				BeforeEach(func() {
+					deploymentGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(continueErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
 				})
 			})
 		})
 	})
 })
+
+func createDeployment(cfApp *korifiv1alpha1.CFApp, revision, previousRevision string, canceled bool) *korifiv1alpha1.CFDeployment {
+	GinkgoHelper()
+
+	cfDeployment := &korifiv1alpha1.CFDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFDeploymentSpec{
+			AppRef:             corev1.LocalObjectReference{Name: cfApp.Name},
+			DropletRef:         corev1.LocalObjectReference{Name: "new-droplet"},
+			Revision:           revision,
+			PreviousDropletRef: corev1.LocalObjectReference{Name: "old-droplet"},
+			PreviousRevision:   previousRevision,
+			Canceled:           canceled,
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfDeployment)).To(Succeed())
+
+	return cfDeployment
+}
+
+func finalizeDeployment(cfDeployment *korifiv1alpha1.CFDeployment, reason string) {
+	GinkgoHelper()
+
+	Expect(k8s.Patch(ctx, k8sClient, cfDeployment, func() {
+		meta.SetStatusCondition(&cfDeployment.Status.Conditions, metav1.Condition{
+			Type:   korifiv1alpha1.DeploymentFinalizedConditionType,
+			Status: metav1.ConditionTrue,
+			Reason: reason,
+		})
+	})).To(Succeed())
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/google/uuid"
 
+	authv1 "k8s.io/api/authorization/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,38 +23,55 @@ const (
 	DomainResourceType = "Domain"
 )
 
+// DomainRepo manages the CFDomains in the root namespace. Org managers only
+// have read access to the root namespace, therefore private domains of the
+// orgs they manage are written with the privileged client.
 type DomainRepo struct {
 	userClientFactory  authorization.UserK8sClientFactory
+	privilegedClient   client.Client
 	namespaceRetriever NamespaceRetriever
+	nsPerms            *authorization.NamespacePermissions
 	rootNamespace      string
 }
 
 func NewDomainRepo(
 	userClientFactory authorization.UserK8sClientFactory,
+	privilegedClient client.Client,
 	namespaceRetriever NamespaceRetriever,
+	nsPerms *authorization.NamespacePermissions,
 	rootNamespace string,
 ) *DomainRepo {
 	return &DomainRepo{
 		userClientFactory:  userClientFactory,
+		privilegedClient:   privilegedClient,
 		namespaceRetriever: namespaceRetriever,
+		nsPerms:            nsPerms,
 		rootNamespace:      rootNamespace,
 	}
 }
 
 type DomainRecord struct {
-	Name        string
-	GUID        string
-	Labels      map[string]string
-	Annotations map[string]string
-	Namespace   string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	DeletedAt   *time.Time
+	Name                    string
+	GUID                    string
+	Internal                bool
+	OrganizationGUID        string
+	SharedOrganizationGUIDs []string
+	RouterGroupGUID         string
+	Labels                  map[string]string
+	Annotations             map[string]string
+	Namespace               string
+	CreatedAt               time.Time
+	UpdatedAt               *time.Time
+	DeletedAt               *time.Time
 }
 
 type CreateDomainMessage struct {
-	Name     string
-	Metadata Metadata
+	Name                    string
+	Internal                bool
+	OrganizationGUID        string
+	SharedOrganizationGUIDs []string
+	RouterGroupGUID         string
+	Metadata                Metadata
 }
 
 type UpdateDomainMessage struct {
@@ -62,6 +81,19 @@ type UpdateDomainMessage struct {
 
 type ListDomainsMessage struct {
 	Names []string
+	// When set, only the domains available to the organization are listed,
+	// i.e. shared domains and private domains owned by or shared with it
+	OrganizationGUID string
+}
+
+type ShareDomainMessage struct {
+	GUID              string
+	OrganizationGUIDs []string
+}
+
+type UnshareDomainMessage struct {
+	GUID             string
+	OrganizationGUID string
 }
 
 func (r *DomainRepo) GetDomain(ctx context.Context, authInfo authorization.Info, domainGUID string) (DomainRecord, error) {
@@ -81,6 +113,15 @@ func (r *DomainRepo) GetDomain(ctx context.Context, authInfo authorization.Info,
 		return DomainRecord{}, fmt.Errorf("get-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
 
+	authorizedOrgs, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("get-domain failed to get authorized orgs: %w", err)
+	}
+
+	if !isDomainVisible(*domain, authorizedOrgs) {
+		return DomainRecord{}, apierrors.NewNotFoundError(fmt.Errorf("domain %q not found", domainGUID), DomainResourceType)
+	}
+
 	return cfDomainToDomainRecord(domain), nil
 }
 
@@ -90,6 +131,23 @@ func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.In
 		return DomainRecord{}, fmt.Errorf("create-domain failed to create user client: %w", err)
 	}
 
+	orgGUIDs := slices.Clone(message.SharedOrganizationGUIDs)
+	if message.OrganizationGUID != "" {
+		orgGUIDs = append(orgGUIDs, message.OrganizationGUID)
+	}
+	err = r.validateOrgsExist(ctx, userClient, orgGUIDs)
+	if err != nil {
+		return DomainRecord{}, err
+	}
+
+	var domainClient client.Client = userClient
+	if message.OrganizationGUID != "" {
+		domainClient, err = r.clientForOrgs(ctx, userClient, orgGUIDs...)
+		if err != nil {
+			return DomainRecord{}, err
+		}
+	}
+
 	cfDomain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        uuid.NewString(),
@@ -98,11 +156,15 @@ func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.In
 			Annotations: message.Metadata.Annotations,
 		},
 		Spec: korifiv1alpha1.CFDomainSpec{
-			Name: message.Name,
+			Name:                message.Name,
+			Internal:            message.Internal,
+			OrganizationGUID:    message.OrganizationGUID,
+			SharedOrganizations: message.SharedOrganizationGUIDs,
+			RouterGroup:         message.RouterGroupGUID,
 		},
 	}
 
-	err = userClient.Create(ctx, cfDomain)
+	err = domainClient.Create(ctx, cfDomain)
 	if err != nil {
 		return DomainRecord{}, fmt.Errorf("create-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
@@ -154,7 +216,18 @@ func (r *DomainRepo) ListDomains(ctx context.Context, authInfo authorization.Inf
 		return []DomainRecord{}, fmt.Errorf("failed to list domains in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	filtered := Filter(cfdomainList.Items, SetPredicate(message.Names, func(s korifiv1alpha1.CFDomain) string { return s.Spec.Name }))
+	authorizedOrgs, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return []DomainRecord{}, fmt.Errorf("list-domain failed to get authorized orgs: %w", err)
+	}
+
+	filtered := Filter(cfdomainList.Items,
+		SetPredicate(message.Names, func(s korifiv1alpha1.CFDomain) string { return s.Spec.Name }),
+		func(d korifiv1alpha1.CFDomain) bool { return isDomainVisible(d, authorizedOrgs) },
+		func(d korifiv1alpha1.CFDomain) bool {
+			return message.OrganizationGUID == "" || isDomainAvailableInOrg(d, message.OrganizationGUID)
+		},
+	)
 
 	sort.Slice(filtered, func(i, j int) bool {
 		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
@@ -178,20 +251,137 @@ func (r *DomainRepo) GetDomainByName(ctx context.Context, authInfo authorization
 	return domainRecords[0], nil
 }
 
+// ShareDomain shares the private domain with the orgs and returns all the orgs
+// the domain is shared with
+func (r *DomainRepo) ShareDomain(ctx context.Context, authInfo authorization.Info, message ShareDomainMessage) ([]string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("share-domain failed to create user client: %w", err)
+	}
+
+	cfDomain, err := r.getPrivateDomain(ctx, userClient, message.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, orgGUID := range message.OrganizationGUIDs {
+		if orgGUID == cfDomain.Spec.OrganizationGUID {
+			return nil, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to share domain %s with organization %s as it already owns this domain.", cfDomain.Spec.Name, orgGUID))
+		}
+	}
+
+	err = r.validateOrgsExist(ctx, userClient, message.OrganizationGUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	domainClient, err := r.clientForOrgs(ctx, userClient, append([]string{cfDomain.Spec.OrganizationGUID}, message.OrganizationGUIDs...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = k8s.PatchResource(ctx, domainClient, cfDomain, func() {
+		for _, orgGUID := range message.OrganizationGUIDs {
+			if !slices.Contains(cfDomain.Spec.SharedOrganizations, orgGUID) {
+				cfDomain.Spec.SharedOrganizations = append(cfDomain.Spec.SharedOrganizations, orgGUID)
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to share domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return cfDomainToDomainRecord(cfDomain).SharedOrganizationGUIDs, nil
+}
+
+func (r *DomainRepo) UnshareDomain(ctx context.Context, authInfo authorization.Info, message UnshareDomainMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("unshare-domain failed to create user client: %w", err)
+	}
+
+	cfDomain, err := r.getPrivateDomain(ctx, userClient, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(cfDomain.Spec.SharedOrganizations, message.OrganizationGUID) {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to unshare domain from organization with guid '%s'. Ensure the domain is shared to this organization.", message.OrganizationGUID))
+	}
+
+	// managers of either org can unshare the domain
+	domainClient, err := r.clientForOrgs(ctx, userClient, cfDomain.Spec.OrganizationGUID)
+	if err != nil {
+		return err
+	}
+	if domainClient != r.privilegedClient {
+		domainClient, err = r.clientForOrgs(ctx, userClient, message.OrganizationGUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = k8s.PatchResource(ctx, domainClient, cfDomain, func() {
+		cfDomain.Spec.SharedOrganizations = slices.DeleteFunc(cfDomain.Spec.SharedOrganizations, func(orgGUID string) bool {
+			return orgGUID == message.OrganizationGUID
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unshare domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return nil
+}
+
+func (r *DomainRepo) getPrivateDomain(ctx context.Context, userClient client.Client, domainGUID string) (*korifiv1alpha1.CFDomain, error) {
+	cfDomain := &korifiv1alpha1.CFDomain{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: domainGUID}, cfDomain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	if cfDomain.Spec.OrganizationGUID == "" {
+		return nil, apierrors.NewUnprocessableEntityError(nil, "Domains can not be shared with other organizations unless they are scoped to an organization.")
+	}
+
+	return cfDomain, nil
+}
+
+func (r *DomainRepo) validateOrgsExist(ctx context.Context, userClient client.Client, orgGUIDs []string) error {
+	for _, orgGUID := range orgGUIDs {
+		err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, new(korifiv1alpha1.CFOrg))
+		if err != nil {
+			if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+				return apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Organization with guid '%s' does not exist, or you do not have access to it.", orgGUID))
+			}
+			return fmt.Errorf("failed to get org: %w", apierrors.FromK8sError(err, OrgResourceType))
+		}
+	}
+
+	return nil
+}
+
 func (r *DomainRepo) DeleteDomain(ctx context.Context, authInfo authorization.Info, domainGUID string) error {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return fmt.Errorf("delete-domain failed to create user client: %w", err)
 	}
 
-	cfDomain := &korifiv1alpha1.CFDomain{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      domainGUID,
-		},
+	cfDomain := &korifiv1alpha1.CFDomain{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: domainGUID}, cfDomain)
+	if err != nil {
+		return apierrors.FromK8sError(err, DomainResourceType)
 	}
 
-	err = userClient.Delete(ctx, cfDomain)
+	var domainClient client.Client = userClient
+	if cfDomain.Spec.OrganizationGUID != "" {
+		domainClient, err = r.clientForOrgs(ctx, userClient, cfDomain.Spec.OrganizationGUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = domainClient.Delete(ctx, cfDomain)
 	if err != nil {
 		return apierrors.FromK8sError(err, DomainResourceType)
 	}
@@ -199,6 +389,44 @@ func (r *DomainRepo) DeleteDomain(ctx context.Context, authInfo authorization.In
 	return nil
 }
 
+// clientForOrgs returns the privileged client when the user manages all the
+// orgs, and the user client otherwise, so that admins keep using their own
+// permissions and everyone else gets a forbidden error
+func (r *DomainRepo) clientForOrgs(ctx context.Context, userClient client.Client, orgGUIDs ...string) (client.Client, error) {
+	for _, orgGUID := range orgGUIDs {
+		isManager, err := r.isOrgManager(ctx, userClient, orgGUID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isManager {
+			return userClient, nil
+		}
+	}
+
+	return r.privilegedClient, nil
+}
+
+// isOrgManager returns whether the user is a manager of the org. Creating
+// spaces is what only org managers (and admins) are allowed to do in an org
+func (r *DomainRepo) isOrgManager(ctx context.Context, userClient client.Client, orgGUID string) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: orgGUID,
+				Verb:      "create",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfspaces",
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("failed to create self subject access review: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
 func (r *DomainRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, domainGUID string) (*time.Time, error) {
 	domain, err := r.GetDomain(ctx, authInfo, domainGUID)
 	return domain.DeletedAt, err
@@ -215,13 +443,36 @@ func returnDomainList(domainList []korifiv1alpha1.CFDomain) []DomainRecord {
 
 func cfDomainToDomainRecord(cfDomain *korifiv1alpha1.CFDomain) DomainRecord {
 	return DomainRecord{
-		Name:        cfDomain.Spec.Name,
-		GUID:        cfDomain.Name,
-		Namespace:   cfDomain.Namespace,
-		CreatedAt:   cfDomain.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(cfDomain),
-		DeletedAt:   golangTime(cfDomain.DeletionTimestamp),
-		Labels:      cfDomain.Labels,
-		Annotations: cfDomain.Annotations,
+		Name:                    cfDomain.Spec.Name,
+		GUID:                    cfDomain.Name,
+		Internal:                cfDomain.Spec.Internal,
+		OrganizationGUID:        cfDomain.Spec.OrganizationGUID,
+		SharedOrganizationGUIDs: slices.Clone(cfDomain.Spec.SharedOrganizations),
+		RouterGroupGUID:         cfDomain.Spec.RouterGroup,
+		Namespace:               cfDomain.Namespace,
+		CreatedAt:               cfDomain.CreationTimestamp.Time,
+		UpdatedAt:               getLastUpdatedTime(cfDomain),
+		DeletedAt:               golangTime(cfDomain.DeletionTimestamp),
+		Labels:                  cfDomain.Labels,
+		Annotations:             cfDomain.Annotations,
 	}
 }
+
+// isDomainVisible returns whether the domain is visible to a user authorized
+// in the orgs. Private domains are only visible to the users of the orgs
+// owning them or the domain is shared with
+func isDomainVisible(cfDomain korifiv1alpha1.CFDomain, authorizedOrgs map[string]bool) bool {
+	if cfDomain.Spec.OrganizationGUID == "" || authorizedOrgs[cfDomain.Spec.OrganizationGUID] {
+		return true
+	}
+
+	return slices.ContainsFunc(cfDomain.Spec.SharedOrganizations, func(orgGUID string) bool {
+		return authorizedOrgs[orgGUID]
+	})
+}
+
+func isDomainAvailableInOrg(cfDomain korifiv1alpha1.CFDomain, orgGUID string) bool {
+	return cfDomain.Spec.OrganizationGUID == "" ||
+		cfDomain.Spec.OrganizationGUID == orgGUID ||
+		slices.Contains(cfDomain.Spec.SharedOrganizations, orgGUID)
+}
@@ -44,7 +44,7 @@ var _ = Describe("DomainRepository", func() {
 		}
 		Expect(k8sClient.Create(ctx, cfDomain)).To(Succeed())
 
-		domainRepo = NewDomainRepo(userClientFactory, namespaceRetriever, rootNamespace)
+		domainRepo = NewDomainRepo(userClientFactory, k8sClient, namespaceRetriever, nsPerms, rootNamespace)
 	})
 
 	AfterEach(func() {
@@ -140,6 +140,113 @@ var _ = Describe("DomainRepository", func() {
 				Expect(createdCFDomain.Labels).To(HaveKeyWithValue("foo", "bar"))
 				Expect(createdCFDomain.Annotations).To(HaveKeyWithValue("bar", "baz"))
 			})
+
+			When("the domain is internal", func() {
+				BeforeEach(func() {
+					domainCreate.Internal = true
+				})
+
+				It("creates an internal domain", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.Internal).To(BeTrue())
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.Internal).To(BeTrue())
+				})
+			})
+
+			When("the domain is assigned to a router group", func() {
+				BeforeEach(func() {
+					domainCreate.RouterGroupGUID = "default-tcp"
+				})
+
+				It("creates a tcp domain", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.RouterGroupGUID).To(Equal("default-tcp"))
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.RouterGroup).To(Equal("default-tcp"))
+				})
+			})
+
+			When("the domain is private", func() {
+				var ownerOrg, sharedOrg *korifiv1alpha1.CFOrg
+
+				BeforeEach(func() {
+					ownerOrg = createOrgWithCleanup(ctx, prefixedGUID("owner-org"))
+					sharedOrg = createOrgWithCleanup(ctx, prefixedGUID("shared-org"))
+					domainCreate.OrganizationGUID = ownerOrg.Name
+					domainCreate.SharedOrganizationGUIDs = []string{sharedOrg.Name}
+				})
+
+				It("creates a domain owned by the org", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.OrganizationGUID).To(Equal(ownerOrg.Name))
+					Expect(createdDomain.SharedOrganizationGUIDs).To(ConsistOf(sharedOrg.Name))
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.OrganizationGUID).To(Equal(ownerOrg.Name))
+					Expect(createdCFDomain.Spec.SharedOrganizations).To(ConsistOf(sharedOrg.Name))
+				})
+
+				When("the org does not exist", func() {
+					BeforeEach(func() {
+						domainCreate.OrganizationGUID = "i-do-not-exist"
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+		})
+
+		When("the user is an org manager", func() {
+			var ownerOrg, sharedOrg *korifiv1alpha1.CFOrg
+
+			BeforeEach(func() {
+				ownerOrg = createOrgWithCleanup(ctx, prefixedGUID("owner-org"))
+				sharedOrg = createOrgWithCleanup(ctx, prefixedGUID("shared-org"))
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, orgManagerRole.Name, ownerOrg.Name)
+				createRoleBinding(ctx, userName, orgManagerRole.Name, sharedOrg.Name)
+			})
+
+			It("cannot create a shared domain", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the domain is private to the managed orgs", func() {
+				BeforeEach(func() {
+					domainCreate.OrganizationGUID = ownerOrg.Name
+					domainCreate.SharedOrganizationGUIDs = []string{sharedOrg.Name}
+				})
+
+				It("creates the domain", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.OrganizationGUID).To(Equal(ownerOrg.Name))
+					Expect(createdCFDomain.Spec.SharedOrganizations).To(ConsistOf(sharedOrg.Name))
+				})
+			})
+
+			When("the domain is shared with an org the user does not manage", func() {
+				BeforeEach(func() {
+					otherOrg := createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+					createRoleBinding(ctx, userName, orgUserRole.Name, otherOrg.Name)
+					domainCreate.OrganizationGUID = ownerOrg.Name
+					domainCreate.SharedOrganizationGUIDs = []string{otherOrg.Name}
+				})
+
+				It("returns a forbidden error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+				})
+			})
 		})
 	})
 
@@ -298,6 +405,255 @@ var _ = Describe("DomainRepository", func() {
 				Expect(domainRecords).To(BeEmpty())
 			})
 		})
+
+		When("there are private domains", func() {
+			var (
+				ownerOrg          *korifiv1alpha1.CFOrg
+				sharedOrg         *korifiv1alpha1.CFOrg
+				privateDomain     *korifiv1alpha1.CFDomain
+				sharedWithDomain  *korifiv1alpha1.CFDomain
+				otherOrgsDomain   *korifiv1alpha1.CFDomain
+				privateDomainGUID string
+			)
+
+			createPrivateDomain := func(orgGUID string, sharedOrgGUIDs ...string) *korifiv1alpha1.CFDomain {
+				GinkgoHelper()
+
+				domain := &korifiv1alpha1.CFDomain{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace,
+					},
+					Spec: korifiv1alpha1.CFDomainSpec{
+						Name:                prefixedGUID("private") + ".com",
+						OrganizationGUID:    orgGUID,
+						SharedOrganizations: sharedOrgGUIDs,
+					},
+				}
+				Expect(k8sClient.Create(ctx, domain)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), domain))).To(Succeed())
+				})
+
+				return domain
+			}
+
+			BeforeEach(func() {
+				ownerOrg = createOrgWithCleanup(ctx, prefixedGUID("owner-org"))
+				sharedOrg = createOrgWithCleanup(ctx, prefixedGUID("shared-org"))
+				otherOrg := createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+
+				privateDomain = createPrivateDomain(ownerOrg.Name)
+				privateDomainGUID = privateDomain.Name
+				sharedWithDomain = createPrivateDomain(otherOrg.Name, sharedOrg.Name)
+				otherOrgsDomain = createPrivateDomain(otherOrg.Name)
+
+				createRoleBinding(ctx, userName, orgUserRole.Name, ownerOrg.Name)
+				createRoleBinding(ctx, userName, orgUserRole.Name, sharedOrg.Name)
+			})
+
+			It("returns the private domains of the orgs the user is authorized in", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+
+				guids := []string{}
+				for _, d := range domainRecords {
+					guids = append(guids, d.GUID)
+				}
+				Expect(guids).To(ContainElements(domainGUID, privateDomainGUID, sharedWithDomain.Name))
+				Expect(guids).NotTo(ContainElement(otherOrgsDomain.Name))
+			})
+
+			When("filtering by organization", func() {
+				BeforeEach(func() {
+					domainListMessage.OrganizationGUID = sharedOrg.Name
+				})
+
+				It("returns the shared domains and the domains shared with the org", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+
+					guids := []string{}
+					for _, d := range domainRecords {
+						guids = append(guids, d.GUID)
+					}
+					Expect(guids).To(ContainElements(domainGUID, sharedWithDomain.Name))
+					Expect(guids).NotTo(ContainElement(privateDomainGUID))
+				})
+			})
+		})
+	})
+
+	Describe("ShareDomain", func() {
+		var (
+			ownerOrg   *korifiv1alpha1.CFOrg
+			sharedOrg  *korifiv1alpha1.CFOrg
+			message    ShareDomainMessage
+			sharedOrgs []string
+			shareErr   error
+		)
+
+		BeforeEach(func() {
+			ownerOrg = createOrgWithCleanup(ctx, prefixedGUID("owner-org"))
+			sharedOrg = createOrgWithCleanup(ctx, prefixedGUID("shared-org"))
+
+			Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+				cfDomain.Spec.OrganizationGUID = ownerOrg.Name
+			})).To(Succeed())
+
+			message = ShareDomainMessage{
+				GUID:              domainGUID,
+				OrganizationGUIDs: []string{sharedOrg.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			sharedOrgs, shareErr = domainRepo.ShareDomain(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CFAdmin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("shares the domain with the org", func() {
+				Expect(shareErr).NotTo(HaveOccurred())
+				Expect(sharedOrgs).To(ConsistOf(sharedOrg.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), cfDomain)).To(Succeed())
+				Expect(cfDomain.Spec.SharedOrganizations).To(ConsistOf(sharedOrg.Name))
+			})
+
+			When("the org does not exist", func() {
+				BeforeEach(func() {
+					message.OrganizationGUIDs = []string{"i-do-not-exist"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("sharing the domain with its owner", func() {
+				BeforeEach(func() {
+					message.OrganizationGUIDs = []string{ownerOrg.Name}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the domain is not private", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+						cfDomain.Spec.OrganizationGUID = ""
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+
+		When("the user manages the owner org", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, orgManagerRole.Name, ownerOrg.Name)
+				createRoleBinding(ctx, userName, orgUserRole.Name, sharedOrg.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user also manages the org the domain is shared with", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgManagerRole.Name, sharedOrg.Name)
+				})
+
+				It("shares the domain with the org", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(sharedOrgs).To(ConsistOf(sharedOrg.Name))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), cfDomain)).To(Succeed())
+					Expect(cfDomain.Spec.SharedOrganizations).To(ConsistOf(sharedOrg.Name))
+				})
+			})
+		})
+	})
+
+	Describe("UnshareDomain", func() {
+		var (
+			message    UnshareDomainMessage
+			unshareErr error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+				cfDomain.Spec.OrganizationGUID = "owner-org-guid"
+				cfDomain.Spec.SharedOrganizations = []string{"shared-org-guid", "another-org-guid"}
+			})).To(Succeed())
+
+			message = UnshareDomainMessage{
+				GUID:             domainGUID,
+				OrganizationGUID: "shared-org-guid",
+			}
+		})
+
+		JustBeforeEach(func() {
+			unshareErr = domainRepo.UnshareDomain(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CFAdmin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("unshares the domain from the org", func() {
+				Expect(unshareErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), cfDomain)).To(Succeed())
+				Expect(cfDomain.Spec.SharedOrganizations).To(ConsistOf("another-org-guid"))
+			})
+
+			When("the domain is not shared with the org", func() {
+				BeforeEach(func() {
+					message.OrganizationGUID = "not-shared-org-guid"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+
+		When("the user manages the org the domain is shared with", func() {
+			BeforeEach(func() {
+				sharedOrg := createOrgWithCleanup(ctx, prefixedGUID("shared-org"))
+				Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+					cfDomain.Spec.SharedOrganizations = []string{sharedOrg.Name, "another-org-guid"}
+				})).To(Succeed())
+				message.OrganizationGUID = sharedOrg.Name
+
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, orgManagerRole.Name, sharedOrg.Name)
+			})
+
+			It("unshares the domain from the org", func() {
+				Expect(unshareErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), cfDomain)).To(Succeed())
+				Expect(cfDomain.Spec.SharedOrganizations).To(ConsistOf("another-org-guid"))
+			})
+		})
 	})
 
 	Describe("GetDomainByName", func() {
@@ -376,6 +732,27 @@ var _ = Describe("DomainRepository", func() {
 				}).Should(Succeed())
 			})
 		})
+
+		When("the user manages the org owning the domain", func() {
+			BeforeEach(func() {
+				ownerOrg := createOrgWithCleanup(ctx, prefixedGUID("owner-org"))
+				Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+					cfDomain.Spec.OrganizationGUID = ownerOrg.Name
+				})).To(Succeed())
+
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, orgManagerRole.Name, ownerOrg.Name)
+			})
+
+			It("deletes the domain", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), &korifiv1alpha1.CFDomain{})
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
 	})
 
 	Describe("GetDeletedAt", func() {
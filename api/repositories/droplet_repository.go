@@ -149,27 +149,30 @@ func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.I
 		return []DropletRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
-	var allBuilds []korifiv1alpha1.CFBuild
-	for ns := range namespaces {
-		err := userClient.List(ctx, buildList, client.InNamespace(ns))
+	preds := []func(korifiv1alpha1.CFBuild) bool{
+		func(a korifiv1alpha1.CFBuild) bool {
+			return getConditionValue(&a.Status.Conditions, StagingConditionType) == metav1.ConditionFalse
+		},
+		func(a korifiv1alpha1.CFBuild) bool {
+			return getConditionValue(&a.Status.Conditions, SucceededConditionType) == metav1.ConditionTrue
+		},
+		SetPredicate(message.PackageGUIDs, func(s korifiv1alpha1.CFBuild) string { return s.Spec.PackageRef.Name }),
+	}
+
+	var stagedBuilds []korifiv1alpha1.CFBuild
+	for _, ns := range orderedNamespaces(namespaces) {
+		err := listInChunks(ctx, userClient, buildList, func(l *korifiv1alpha1.CFBuildList) {
+			stagedBuilds = append(stagedBuilds, Filter(l.Items, preds...)...)
+		}, client.InNamespace(ns))
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
 		if err != nil {
 			return []DropletRecord{}, apierrors.FromK8sError(err, BuildResourceType)
 		}
-		allBuilds = append(allBuilds, buildList.Items...)
 	}
 
-	return returnDropletList(Filter(allBuilds,
-		func(a korifiv1alpha1.CFBuild) bool {
-			return getConditionValue(&a.Status.Conditions, StagingConditionType) == metav1.ConditionFalse
-		},
-		func(a korifiv1alpha1.CFBuild) bool {
-			return getConditionValue(&a.Status.Conditions, SucceededConditionType) == metav1.ConditionTrue
-		},
-		SetPredicate(message.PackageGUIDs, func(s korifiv1alpha1.CFBuild) string { return s.Spec.PackageRef.Name }),
-	)), nil
+	return returnDropletList(stagedBuilds), nil
 }
 
 type UpdateDropletMessage struct {
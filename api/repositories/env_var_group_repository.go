@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const EnvVarGroupResourceType = "Environment Variable Group"
+
+type EnvVarGroupRecord struct {
+	Name      string
+	Var       map[string]string
+	UpdatedAt *time.Time
+}
+
+type PatchEnvVarGroupMessage struct {
+	Name string
+	Var  map[string]*string
+}
+
+type EnvVarGroupRepo struct {
+	rootNamespace     string
+	userClientFactory authorization.UserK8sClientFactory
+}
+
+func NewEnvVarGroupRepo(
+	rootNamespace string,
+	userClientFactory authorization.UserK8sClientFactory,
+) *EnvVarGroupRepo {
+	return &EnvVarGroupRepo{
+		rootNamespace:     rootNamespace,
+		userClientFactory: userClientFactory,
+	}
+}
+
+// GetEnvVarGroup returns the environment variable group with the given name.
+// Groups that have never been set are returned empty
+func (r *EnvVarGroupRepo) GetEnvVarGroup(ctx context.Context, authInfo authorization.Info, name string) (EnvVarGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	envVarGroup := &korifiv1alpha1.CFEnvVarGroup{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: name}, envVarGroup)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return EnvVarGroupRecord{Name: name, Var: map[string]string{}}, nil
+		}
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to get environment variable group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	return cfEnvVarGroupToRecord(envVarGroup), nil
+}
+
+// PatchEnvVarGroup merges the given variables into the group. Variables set to
+// nil are removed from the group
+func (r *EnvVarGroupRepo) PatchEnvVarGroup(ctx context.Context, authInfo authorization.Info, message PatchEnvVarGroupMessage) (EnvVarGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	envVarGroup := &korifiv1alpha1.CFEnvVarGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.Name,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, userClient, envVarGroup, func() error {
+		if envVarGroup.Spec.Var == nil {
+			envVarGroup.Spec.Var = map[string]string{}
+		}
+
+		for name, value := range message.Var {
+			if value == nil {
+				delete(envVarGroup.Spec.Var, name)
+				continue
+			}
+			envVarGroup.Spec.Var[name] = *value
+		}
+
+		return nil
+	})
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to patch environment variable group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	return cfEnvVarGroupToRecord(envVarGroup), nil
+}
+
+// getEnvVarGroupVars returns the variables of the environment variable group,
+// or none if the group has never been set or the user cannot see it
+func getEnvVarGroupVars(ctx context.Context, userClient client.Client, rootNamespace, name string) (map[string]string, error) {
+	envVarGroup := &korifiv1alpha1.CFEnvVarGroup{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: name}, envVarGroup)
+	if err != nil {
+		if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to get the %s environment variable group: %w", name, apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	if envVarGroup.Spec.Var == nil {
+		return map[string]string{}, nil
+	}
+
+	return envVarGroup.Spec.Var, nil
+}
+
+func cfEnvVarGroupToRecord(envVarGroup *korifiv1alpha1.CFEnvVarGroup) EnvVarGroupRecord {
+	vars := envVarGroup.Spec.Var
+	if vars == nil {
+		vars = map[string]string{}
+	}
+
+	return EnvVarGroupRecord{
+		Name:      envVarGroup.Name,
+		Var:       vars,
+		UpdatedAt: getLastUpdatedTime(envVarGroup),
+	}
+}
@@ -0,0 +1,128 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("EnvVarGroupRepository", func() {
+	var envVarGroupRepo *repositories.EnvVarGroupRepo
+
+	BeforeEach(func() {
+		envVarGroupRepo = repositories.NewEnvVarGroupRepo(rootNamespace, userClientFactory)
+	})
+
+	Describe("GetEnvVarGroup", func() {
+		var (
+			envVarGroup repositories.EnvVarGroupRecord
+			getErr      error
+		)
+
+		JustBeforeEach(func() {
+			envVarGroup, getErr = envVarGroupRepo.GetEnvVarGroup(ctx, authInfo, korifiv1alpha1.RunningEnvVarGroupName)
+		})
+
+		It("returns an empty group", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(envVarGroup.Name).To(Equal(korifiv1alpha1.RunningEnvVarGroupName))
+			Expect(envVarGroup.Var).To(BeEmpty())
+			Expect(envVarGroup.UpdatedAt).To(BeNil())
+		})
+
+		When("the group has been set", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.RunningEnvVarGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+						Var: map[string]string{"HTTP_PROXY": "http://proxy"},
+					},
+				})).To(Succeed())
+			})
+
+			It("returns the group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(envVarGroup.Name).To(Equal(korifiv1alpha1.RunningEnvVarGroupName))
+				Expect(envVarGroup.Var).To(Equal(map[string]string{"HTTP_PROXY": "http://proxy"}))
+				Expect(envVarGroup.UpdatedAt).NotTo(BeNil())
+			})
+		})
+	})
+
+	Describe("PatchEnvVarGroup", func() {
+		var (
+			message     repositories.PatchEnvVarGroupMessage
+			envVarGroup repositories.EnvVarGroupRecord
+			patchErr    error
+		)
+
+		BeforeEach(func() {
+			message = repositories.PatchEnvVarGroupMessage{
+				Name: korifiv1alpha1.StagingEnvVarGroupName,
+				Var: map[string]*string{
+					"HTTP_PROXY": tools.PtrTo("http://proxy"),
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			envVarGroup, patchErr = envVarGroupRepo.PatchEnvVarGroup(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the group", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(envVarGroup.Name).To(Equal(korifiv1alpha1.StagingEnvVarGroupName))
+				Expect(envVarGroup.Var).To(Equal(map[string]string{"HTTP_PROXY": "http://proxy"}))
+
+				cfEnvVarGroup := new(korifiv1alpha1.CFEnvVarGroup)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: korifiv1alpha1.StagingEnvVarGroupName}, cfEnvVarGroup)).To(Succeed())
+				Expect(cfEnvVarGroup.Spec.Var).To(Equal(map[string]string{"HTTP_PROXY": "http://proxy"}))
+			})
+
+			When("the group already exists", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      korifiv1alpha1.StagingEnvVarGroupName,
+						},
+						Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+							Var: map[string]string{
+								"NO_PROXY": "localhost",
+								"APM":      "http://apm",
+							},
+						},
+					})).To(Succeed())
+
+					message.Var["NO_PROXY"] = nil
+				})
+
+				It("merges the variables into the group", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(envVarGroup.Var).To(Equal(map[string]string{
+						"HTTP_PROXY": "http://proxy",
+						"APM":        "http://apm",
+					}))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	authv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cffeatureflags,verbs=get;list,namespace=ROOT_NAMESPACE
+
+const FeatureFlagResourceType = "Feature Flag"
+
+// featureFlagDefaults holds the supported feature flags and the value they
+// take until an admin sets them. Flags guarding behaviour korifi always had
+// default to enabled so that introducing them changes nothing.
+var featureFlagDefaults = map[string]bool{
+	korifiv1alpha1.FeatureFlagDiegoDocker:  true,
+	korifiv1alpha1.FeatureFlagAppScaling:   true,
+	korifiv1alpha1.FeatureFlagTaskCreation: true,
+}
+
+type FeatureFlagRecord struct {
+	Name               string
+	Enabled            bool
+	CustomErrorMessage string
+	UpdatedAt          *time.Time
+}
+
+type PatchFeatureFlagMessage struct {
+	Name               string
+	Enabled            *bool
+	CustomErrorMessage *string
+}
+
+type FeatureFlagRepo struct {
+	rootNamespace     string
+	privilegedClient  client.Client
+	userClientFactory authorization.UserK8sClientFactory
+}
+
+func NewFeatureFlagRepo(
+	rootNamespace string,
+	privilegedClient client.Client,
+	userClientFactory authorization.UserK8sClientFactory,
+) *FeatureFlagRepo {
+	return &FeatureFlagRepo{
+		rootNamespace:     rootNamespace,
+		privilegedClient:  privilegedClient,
+		userClientFactory: userClientFactory,
+	}
+}
+
+// ListFeatureFlags returns all the supported feature flags. Feature flags are
+// visible to every authenticated user, therefore they are read with the
+// privileged client
+func (r *FeatureFlagRepo) ListFeatureFlags(ctx context.Context, authInfo authorization.Info) ([]FeatureFlagRecord, error) {
+	cfFeatureFlags := new(korifiv1alpha1.CFFeatureFlagList)
+	err := r.privilegedClient.List(ctx, cfFeatureFlags, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	setFlags := map[string]korifiv1alpha1.CFFeatureFlag{}
+	for _, cfFeatureFlag := range cfFeatureFlags.Items {
+		setFlags[cfFeatureFlag.Name] = cfFeatureFlag
+	}
+
+	records := []FeatureFlagRecord{}
+	for name, enabled := range featureFlagDefaults {
+		cfFeatureFlag, ok := setFlags[name]
+		if !ok {
+			records = append(records, FeatureFlagRecord{Name: name, Enabled: enabled})
+			continue
+		}
+		records = append(records, cfFeatureFlagToRecord(cfFeatureFlag))
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Name < records[j].Name
+	})
+
+	return records, nil
+}
+
+func (r *FeatureFlagRepo) GetFeatureFlag(ctx context.Context, authInfo authorization.Info, name string) (FeatureFlagRecord, error) {
+	enabled, ok := featureFlagDefaults[name]
+	if !ok {
+		return FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, FeatureFlagResourceType)
+	}
+
+	cfFeatureFlag := new(korifiv1alpha1.CFFeatureFlag)
+	err := r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: name}, cfFeatureFlag)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return FeatureFlagRecord{Name: name, Enabled: enabled}, nil
+		}
+		return FeatureFlagRecord{}, fmt.Errorf("failed to get feature flag %q: %w", name, apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return cfFeatureFlagToRecord(*cfFeatureFlag), nil
+}
+
+func (r *FeatureFlagRepo) PatchFeatureFlag(ctx context.Context, authInfo authorization.Info, message PatchFeatureFlagMessage) (FeatureFlagRecord, error) {
+	enabled, ok := featureFlagDefaults[message.Name]
+	if !ok {
+		return FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, FeatureFlagResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfFeatureFlag := &korifiv1alpha1.CFFeatureFlag{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.Name,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, userClient, cfFeatureFlag, func() error {
+		if cfFeatureFlag.CreationTimestamp.IsZero() {
+			cfFeatureFlag.Spec.Enabled = enabled
+		}
+		if message.Enabled != nil {
+			cfFeatureFlag.Spec.Enabled = *message.Enabled
+		}
+		if message.CustomErrorMessage != nil {
+			cfFeatureFlag.Spec.CustomErrorMessage = *message.CustomErrorMessage
+		}
+		return nil
+	})
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to patch feature flag %q: %w", message.Name, apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return cfFeatureFlagToRecord(*cfFeatureFlag), nil
+}
+
+// CheckFeatureFlag returns a FeatureDisabledError when the feature flag is
+// disabled. Like in CF, admins are not subject to feature flags; a user is
+// considered an admin when allowed to change the feature flags
+func (r *FeatureFlagRepo) CheckFeatureFlag(ctx context.Context, authInfo authorization.Info, name string) error {
+	featureFlag, err := r.GetFeatureFlag(ctx, authInfo, name)
+	if err != nil {
+		return err
+	}
+
+	if featureFlag.Enabled {
+		return nil
+	}
+
+	isAdmin, err := r.canIPatchFeatureFlags(ctx, authInfo)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin {
+		return nil
+	}
+
+	return apierrors.NewFeatureDisabledError(featureFlag.Name, featureFlag.CustomErrorMessage)
+}
+
+func (r *FeatureFlagRepo) canIPatchFeatureFlags(ctx context.Context, authInfo authorization.Info) (bool, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return false, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: r.rootNamespace,
+				Verb:      "patch",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cffeatureflags",
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("failed to create self subject access review: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func cfFeatureFlagToRecord(cfFeatureFlag korifiv1alpha1.CFFeatureFlag) FeatureFlagRecord {
+	return FeatureFlagRecord{
+		Name:               cfFeatureFlag.Name,
+		Enabled:            cfFeatureFlag.Spec.Enabled,
+		CustomErrorMessage: cfFeatureFlag.Spec.CustomErrorMessage,
+		UpdatedAt:          getLastUpdatedTime(&cfFeatureFlag),
+	}
+}
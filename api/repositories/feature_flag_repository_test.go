@@ -0,0 +1,208 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("FeatureFlagRepository", func() {
+	var featureFlagRepo *repositories.FeatureFlagRepo
+
+	BeforeEach(func() {
+		featureFlagRepo = repositories.NewFeatureFlagRepo(rootNamespace, k8sClient, userClientFactory)
+	})
+
+	createFeatureFlag := func(name string, enabled bool, customErrorMessage string) {
+		GinkgoHelper()
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFFeatureFlag{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      name,
+			},
+			Spec: korifiv1alpha1.CFFeatureFlagSpec{
+				Enabled:            enabled,
+				CustomErrorMessage: customErrorMessage,
+			},
+		})).To(Succeed())
+	}
+
+	Describe("ListFeatureFlags", func() {
+		var (
+			featureFlags []repositories.FeatureFlagRecord
+			listErr      error
+		)
+
+		BeforeEach(func() {
+			createFeatureFlag(korifiv1alpha1.FeatureFlagTaskCreation, false, "no tasks")
+		})
+
+		JustBeforeEach(func() {
+			featureFlags, listErr = featureFlagRepo.ListFeatureFlags(ctx, authInfo)
+		})
+
+		It("returns all feature flags ordered by name", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(featureFlags).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{"Name": Equal("app_scaling"), "Enabled": BeTrue()}),
+				MatchFields(IgnoreExtras, Fields{"Name": Equal("diego_docker"), "Enabled": BeTrue()}),
+				MatchFields(IgnoreExtras, Fields{
+					"Name":               Equal("task_creation"),
+					"Enabled":            BeFalse(),
+					"CustomErrorMessage": Equal("no tasks"),
+					"UpdatedAt":          Not(BeNil()),
+				}),
+			))
+		})
+	})
+
+	Describe("GetFeatureFlag", func() {
+		var (
+			flagName    string
+			featureFlag repositories.FeatureFlagRecord
+			getErr      error
+		)
+
+		BeforeEach(func() {
+			flagName = korifiv1alpha1.FeatureFlagAppScaling
+		})
+
+		JustBeforeEach(func() {
+			featureFlag, getErr = featureFlagRepo.GetFeatureFlag(ctx, authInfo, flagName)
+		})
+
+		It("returns the default value", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(featureFlag.Name).To(Equal(korifiv1alpha1.FeatureFlagAppScaling))
+			Expect(featureFlag.Enabled).To(BeTrue())
+			Expect(featureFlag.UpdatedAt).To(BeNil())
+		})
+
+		When("the feature flag has been set", func() {
+			BeforeEach(func() {
+				createFeatureFlag(korifiv1alpha1.FeatureFlagAppScaling, false, "")
+			})
+
+			It("returns the set value", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(featureFlag.Enabled).To(BeFalse())
+				Expect(featureFlag.UpdatedAt).NotTo(BeNil())
+			})
+		})
+
+		When("the feature flag is not supported", func() {
+			BeforeEach(func() {
+				flagName = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("PatchFeatureFlag", func() {
+		var (
+			message     repositories.PatchFeatureFlagMessage
+			featureFlag repositories.FeatureFlagRecord
+			patchErr    error
+		)
+
+		BeforeEach(func() {
+			message = repositories.PatchFeatureFlagMessage{
+				Name:               korifiv1alpha1.FeatureFlagDiegoDocker,
+				CustomErrorMessage: tools.PtrTo("no docker"),
+			}
+		})
+
+		JustBeforeEach(func() {
+			featureFlag, patchErr = featureFlagRepo.PatchFeatureFlag(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the feature flag keeping its default value", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(featureFlag.Name).To(Equal(korifiv1alpha1.FeatureFlagDiegoDocker))
+				Expect(featureFlag.Enabled).To(BeTrue())
+				Expect(featureFlag.CustomErrorMessage).To(Equal("no docker"))
+
+				cfFeatureFlag := new(korifiv1alpha1.CFFeatureFlag)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: korifiv1alpha1.FeatureFlagDiegoDocker}, cfFeatureFlag)).To(Succeed())
+				Expect(cfFeatureFlag.Spec.Enabled).To(BeTrue())
+				Expect(cfFeatureFlag.Spec.CustomErrorMessage).To(Equal("no docker"))
+			})
+
+			When("the feature flag already exists", func() {
+				BeforeEach(func() {
+					createFeatureFlag(korifiv1alpha1.FeatureFlagDiegoDocker, true, "")
+					message.Enabled = tools.PtrTo(false)
+				})
+
+				It("updates it", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(featureFlag.Enabled).To(BeFalse())
+					Expect(featureFlag.CustomErrorMessage).To(Equal("no docker"))
+				})
+			})
+
+			When("the feature flag is not supported", func() {
+				BeforeEach(func() {
+					message.Name = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("CheckFeatureFlag", func() {
+		var checkErr error
+
+		JustBeforeEach(func() {
+			checkErr = featureFlagRepo.CheckFeatureFlag(ctx, authInfo, korifiv1alpha1.FeatureFlagTaskCreation)
+		})
+
+		It("succeeds", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+		})
+
+		When("the feature flag is disabled", func() {
+			BeforeEach(func() {
+				createFeatureFlag(korifiv1alpha1.FeatureFlagTaskCreation, false, "")
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(checkErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.FeatureDisabledError{}))
+				Expect(checkErr.(apierrors.FeatureDisabledError).Detail()).To(Equal("Feature Disabled: task_creation"))
+			})
+
+			When("the user is an admin", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("succeeds", func() {
+					Expect(checkErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,390 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	IsolationSegmentResourceType = "Isolation Segment"
+)
+
+type IsolationSegmentRecord struct {
+	GUID              string
+	Name              string
+	OrganizationGUIDs []string
+	CreatedAt         time.Time
+	UpdatedAt         *time.Time
+}
+
+type CreateIsolationSegmentMessage struct {
+	Name string
+}
+
+type ListIsolationSegmentsMessage struct {
+	GUIDs             []string
+	Names             []string
+	OrganizationGUIDs []string
+}
+
+type PatchIsolationSegmentMessage struct {
+	GUID string
+	Name *string
+}
+
+type EntitleIsolationSegmentMessage struct {
+	GUID              string
+	OrganizationGUIDs []string
+}
+
+type RevokeIsolationSegmentMessage struct {
+	GUID             string
+	OrganizationGUID string
+}
+
+type AssignSpaceIsolationSegmentMessage struct {
+	SpaceGUID string
+	// The guid of the isolation segment to assign to the space. The isolation
+	// segment of the space is unassigned when empty
+	IsolationSegmentGUID string
+}
+
+type IsolationSegmentRepo struct {
+	rootNamespace      string
+	namespaceRetriever NamespaceRetriever
+	userClientFactory  authorization.UserK8sClientFactory
+}
+
+func NewIsolationSegmentRepo(
+	rootNamespace string,
+	namespaceRetriever NamespaceRetriever,
+	userClientFactory authorization.UserK8sClientFactory,
+) *IsolationSegmentRepo {
+	return &IsolationSegmentRepo{
+		rootNamespace:      rootNamespace,
+		namespaceRetriever: namespaceRetriever,
+		userClientFactory:  userClientFactory,
+	}
+}
+
+func (r *IsolationSegmentRepo) CreateIsolationSegment(ctx context.Context, authInfo authorization.Info, message CreateIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+			DisplayName: message.Name,
+		},
+	}
+
+	err = userClient.Create(ctx, cfIsolationSegment)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to create isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return cfIsolationSegmentToIsolationSegmentRecord(cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) GetIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) (IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment, err := r.getIsolationSegment(ctx, userClient, guid)
+	if err != nil {
+		return IsolationSegmentRecord{}, err
+	}
+
+	return cfIsolationSegmentToIsolationSegmentRecord(cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) ListIsolationSegments(ctx context.Context, authInfo authorization.Info, message ListIsolationSegmentsMessage) ([]IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegmentList := new(korifiv1alpha1.CFIsolationSegmentList)
+	err = userClient.List(ctx, cfIsolationSegmentList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []IsolationSegmentRecord{}, nil
+		}
+		return nil, fmt.Errorf("failed to list isolation segments: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	orgGUIDs := NewSet(message.OrganizationGUIDs...)
+	preds := []func(korifiv1alpha1.CFIsolationSegment) bool{
+		SetPredicate(message.GUIDs, func(s korifiv1alpha1.CFIsolationSegment) string { return s.Name }),
+		SetPredicate(message.Names, func(s korifiv1alpha1.CFIsolationSegment) string { return s.Spec.DisplayName }),
+		func(s korifiv1alpha1.CFIsolationSegment) bool {
+			if len(orgGUIDs) == 0 {
+				return true
+			}
+			for _, orgGUID := range s.Spec.Organizations {
+				if orgGUIDs.Includes(orgGUID) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+
+	filtered := Filter(cfIsolationSegmentList.Items, preds...)
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
+	})
+
+	records := make([]IsolationSegmentRecord, 0, len(filtered))
+	for i := range filtered {
+		records = append(records, cfIsolationSegmentToIsolationSegmentRecord(&filtered[i]))
+	}
+
+	return records, nil
+}
+
+func (r *IsolationSegmentRepo) PatchIsolationSegment(ctx context.Context, authInfo authorization.Info, message PatchIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment, err := r.getIsolationSegment(ctx, userClient, message.GUID)
+	if err != nil {
+		return IsolationSegmentRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfIsolationSegment, func() {
+		if message.Name != nil {
+			cfIsolationSegment.Spec.DisplayName = *message.Name
+		}
+	})
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to patch isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return cfIsolationSegmentToIsolationSegmentRecord(cfIsolationSegment), nil
+}
+
+// EntitleIsolationSegment entitles the orgs to the isolation segment and
+// returns all the orgs entitled to it
+func (r *IsolationSegmentRepo) EntitleIsolationSegment(ctx context.Context, authInfo authorization.Info, message EntitleIsolationSegmentMessage) ([]string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment, err := r.getIsolationSegment(ctx, userClient, message.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, orgGUID := range message.OrganizationGUIDs {
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, new(korifiv1alpha1.CFOrg))
+		if err != nil {
+			if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+				return nil, apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Organization with guid '%s' does not exist, or you do not have access to it.", orgGUID))
+			}
+			return nil, fmt.Errorf("failed to get org: %w", apierrors.FromK8sError(err, OrgResourceType))
+		}
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfIsolationSegment, func() {
+		for _, orgGUID := range message.OrganizationGUIDs {
+			if !slices.Contains(cfIsolationSegment.Spec.Organizations, orgGUID) {
+				cfIsolationSegment.Spec.Organizations = append(cfIsolationSegment.Spec.Organizations, orgGUID)
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to entitle isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return cfIsolationSegmentToIsolationSegmentRecord(cfIsolationSegment).OrganizationGUIDs, nil
+}
+
+// RevokeIsolationSegment revokes the entitlement of the org to the isolation
+// segment. Entitlements cannot be revoked while spaces in the org are assigned
+// the isolation segment
+func (r *IsolationSegmentRepo) RevokeIsolationSegment(ctx context.Context, authInfo authorization.Info, message RevokeIsolationSegmentMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment, err := r.getIsolationSegment(ctx, userClient, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(cfIsolationSegment.Spec.Organizations, message.OrganizationGUID) {
+		return nil
+	}
+
+	cfSpaceList := new(korifiv1alpha1.CFSpaceList)
+	err = userClient.List(ctx, cfSpaceList, client.InNamespace(message.OrganizationGUID))
+	if err != nil {
+		return fmt.Errorf("failed to list spaces: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	for _, cfSpace := range cfSpaceList.Items {
+		if cfSpace.Spec.IsolationSegmentRef.Name == message.GUID {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+				"Cannot remove the entitlement of organization '%s' because space '%s' is assigned this isolation segment.",
+				message.OrganizationGUID,
+				cfSpace.Name,
+			))
+		}
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfIsolationSegment, func() {
+		cfIsolationSegment.Spec.Organizations = slices.DeleteFunc(cfIsolationSegment.Spec.Organizations, func(orgGUID string) bool {
+			return orgGUID == message.OrganizationGUID
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return nil
+}
+
+func (r *IsolationSegmentRepo) DeleteIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment, err := r.getIsolationSegment(ctx, userClient, guid)
+	if err != nil {
+		return err
+	}
+
+	if len(cfIsolationSegment.Spec.Organizations) > 0 {
+		return apierrors.NewUnprocessableEntityError(nil, "Revoke the Organization entitlements for your Isolation Segment.")
+	}
+
+	err = userClient.Delete(ctx, cfIsolationSegment)
+	if err != nil {
+		return fmt.Errorf("failed to delete isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return nil
+}
+
+// GetSpaceIsolationSegment returns the guid of the isolation segment
+// assigned to the space, or an empty string if there is none
+func (r *IsolationSegmentRepo) GetSpaceIsolationSegment(ctx context.Context, authInfo authorization.Info, spaceGUID string) (string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpace, err := r.getSpace(ctx, userClient, spaceGUID)
+	if err != nil {
+		return "", err
+	}
+
+	return cfSpace.Spec.IsolationSegmentRef.Name, nil
+}
+
+// AssignSpaceIsolationSegment assigns the isolation segment to the space and
+// returns the guid of the isolation segment assigned to it. Only isolation
+// segments the org of the space is entitled to can be assigned
+func (r *IsolationSegmentRepo) AssignSpaceIsolationSegment(ctx context.Context, authInfo authorization.Info, message AssignSpaceIsolationSegmentMessage) (string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpace, err := r.getSpace(ctx, userClient, message.SpaceGUID)
+	if err != nil {
+		return "", err
+	}
+
+	if message.IsolationSegmentGUID != "" {
+		notEntitledDetail := fmt.Sprintf(
+			"Unable to assign isolation segment with guid '%s'. Ensure it has been entitled to the organization that this space belongs to.",
+			message.IsolationSegmentGUID,
+		)
+
+		cfIsolationSegment, getErr := r.getIsolationSegment(ctx, userClient, message.IsolationSegmentGUID)
+		if getErr != nil {
+			return "", apierrors.AsUnprocessableEntity(getErr, notEntitledDetail, apierrors.NotFoundError{}, apierrors.ForbiddenError{})
+		}
+
+		if !slices.Contains(cfIsolationSegment.Spec.Organizations, cfSpace.Namespace) {
+			return "", apierrors.NewUnprocessableEntityError(nil, notEntitledDetail)
+		}
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpace, func() {
+		cfSpace.Spec.IsolationSegmentRef.Name = message.IsolationSegmentGUID
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to assign isolation segment: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	return cfSpace.Spec.IsolationSegmentRef.Name, nil
+}
+
+func (r *IsolationSegmentRepo) getSpace(ctx context.Context, userClient client.WithWatch, spaceGUID string) (*korifiv1alpha1.CFSpace, error) {
+	orgNamespace, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	cfSpace := new(korifiv1alpha1.CFSpace)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: orgNamespace, Name: spaceGUID}, cfSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	return cfSpace, nil
+}
+
+func (r *IsolationSegmentRepo) getIsolationSegment(ctx context.Context, userClient client.WithWatch, guid string) (*korifiv1alpha1.CFIsolationSegment, error) {
+	cfIsolationSegment := new(korifiv1alpha1.CFIsolationSegment)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfIsolationSegment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return cfIsolationSegment, nil
+}
+
+func cfIsolationSegmentToIsolationSegmentRecord(cfIsolationSegment *korifiv1alpha1.CFIsolationSegment) IsolationSegmentRecord {
+	orgGUIDs := slices.Clone(cfIsolationSegment.Spec.Organizations)
+	if orgGUIDs == nil {
+		orgGUIDs = []string{}
+	}
+	slices.Sort(orgGUIDs)
+
+	return IsolationSegmentRecord{
+		GUID:              cfIsolationSegment.Name,
+		Name:              cfIsolationSegment.Spec.DisplayName,
+		OrganizationGUIDs: orgGUIDs,
+		CreatedAt:         cfIsolationSegment.CreationTimestamp.Time,
+		UpdatedAt:         getLastUpdatedTime(cfIsolationSegment),
+	}
+}
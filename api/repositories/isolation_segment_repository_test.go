@@ -0,0 +1,439 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("IsolationSegmentRepository", func() {
+	var (
+		isolationSegmentRepo *repositories.IsolationSegmentRepo
+		cfIsolationSegment   *korifiv1alpha1.CFIsolationSegment
+		cfOrg                *korifiv1alpha1.CFOrg
+	)
+
+	BeforeEach(func() {
+		isolationSegmentRepo = repositories.NewIsolationSegmentRepo(rootNamespace, namespaceRetriever, userClientFactory)
+
+		cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+
+		cfIsolationSegment = &korifiv1alpha1.CFIsolationSegment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+				DisplayName:  "my-segment",
+				NodeSelector: map[string]string{"pool": "regulated"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfIsolationSegment)).To(Succeed())
+	})
+
+	Describe("CreateIsolationSegment", func() {
+		var (
+			isolationSegment repositories.IsolationSegmentRecord
+			createErr        error
+		)
+
+		JustBeforeEach(func() {
+			isolationSegment, createErr = isolationSegmentRepo.CreateIsolationSegment(ctx, authInfo, repositories.CreateIsolationSegmentMessage{
+				Name: "new-segment",
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the isolation segment", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(isolationSegment.Name).To(Equal("new-segment"))
+				Expect(isolationSegment.OrganizationGUIDs).To(BeEmpty())
+
+				createdSegment := new(korifiv1alpha1.CFIsolationSegment)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: isolationSegment.GUID}, createdSegment)).To(Succeed())
+				Expect(createdSegment.Spec.DisplayName).To(Equal("new-segment"))
+			})
+		})
+	})
+
+	Describe("GetIsolationSegment", func() {
+		var (
+			isolationSegment repositories.IsolationSegmentRecord
+			getErr           error
+		)
+
+		JustBeforeEach(func() {
+			isolationSegment, getErr = isolationSegmentRepo.GetIsolationSegment(ctx, authInfo, cfIsolationSegment.Name)
+		})
+
+		It("returns the isolation segment", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(isolationSegment.GUID).To(Equal(cfIsolationSegment.Name))
+			Expect(isolationSegment.Name).To(Equal("my-segment"))
+			Expect(isolationSegment.OrganizationGUIDs).To(BeEmpty())
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Delete(ctx, cfIsolationSegment)).To(Succeed())
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListIsolationSegments", func() {
+		var (
+			message           repositories.ListIsolationSegmentsMessage
+			isolationSegments []repositories.IsolationSegmentRecord
+			listErr           error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListIsolationSegmentsMessage{}
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFIsolationSegment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+					DisplayName:   "entitled-segment",
+					Organizations: []string{cfOrg.Name},
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			isolationSegments, listErr = isolationSegmentRepo.ListIsolationSegments(ctx, authInfo, message)
+		})
+
+		It("lists the isolation segments", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(isolationSegments).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"Name": Equal("my-segment")}),
+				MatchFields(IgnoreExtras, Fields{"Name": Equal("entitled-segment"), "OrganizationGUIDs": Equal([]string{cfOrg.Name})}),
+			))
+		})
+
+		When("filtering by organization guids", func() {
+			BeforeEach(func() {
+				message.OrganizationGUIDs = []string{cfOrg.Name}
+			})
+
+			It("returns the isolation segments entitled to the orgs", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(isolationSegments).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Name": Equal("entitled-segment")}),
+				))
+			})
+		})
+
+		When("filtering by names", func() {
+			BeforeEach(func() {
+				message.Names = []string{"my-segment"}
+			})
+
+			It("returns the isolation segments with the names", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(isolationSegments).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfIsolationSegment.Name)}),
+				))
+			})
+		})
+	})
+
+	Describe("PatchIsolationSegment", func() {
+		var (
+			isolationSegment repositories.IsolationSegmentRecord
+			patchErr         error
+		)
+
+		JustBeforeEach(func() {
+			isolationSegment, patchErr = isolationSegmentRepo.PatchIsolationSegment(ctx, authInfo, repositories.PatchIsolationSegmentMessage{
+				GUID: cfIsolationSegment.Name,
+				Name: tools.PtrTo("renamed-segment"),
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("renames the isolation segment and keeps its placement", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(isolationSegment.Name).To(Equal("renamed-segment"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(Succeed())
+				Expect(cfIsolationSegment.Spec.DisplayName).To(Equal("renamed-segment"))
+				Expect(cfIsolationSegment.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+			})
+		})
+	})
+
+	Describe("EntitleIsolationSegment", func() {
+		var (
+			orgGUIDs   []string
+			entitled   []string
+			entitleErr error
+		)
+
+		BeforeEach(func() {
+			orgGUIDs = []string{cfOrg.Name}
+		})
+
+		JustBeforeEach(func() {
+			entitled, entitleErr = isolationSegmentRepo.EntitleIsolationSegment(ctx, authInfo, repositories.EntitleIsolationSegmentMessage{
+				GUID:              cfIsolationSegment.Name,
+				OrganizationGUIDs: orgGUIDs,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(entitleErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("entitles the orgs to the isolation segment", func() {
+				Expect(entitleErr).NotTo(HaveOccurred())
+				Expect(entitled).To(Equal([]string{cfOrg.Name}))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(Succeed())
+				Expect(cfIsolationSegment.Spec.Organizations).To(ConsistOf(cfOrg.Name))
+			})
+
+			When("the org is already entitled", func() {
+				BeforeEach(func() {
+					cfIsolationSegment.Spec.Organizations = []string{cfOrg.Name}
+					Expect(k8sClient.Update(ctx, cfIsolationSegment)).To(Succeed())
+				})
+
+				It("does not entitle it twice", func() {
+					Expect(entitleErr).NotTo(HaveOccurred())
+					Expect(entitled).To(Equal([]string{cfOrg.Name}))
+				})
+			})
+
+			When("the org does not exist", func() {
+				BeforeEach(func() {
+					orgGUIDs = []string{"i-do-not-exist"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(entitleErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(entitleErr.(apierrors.UnprocessableEntityError).Detail()).To(
+						Equal("Organization with guid 'i-do-not-exist' does not exist, or you do not have access to it."),
+					)
+				})
+			})
+		})
+	})
+
+	Describe("RevokeIsolationSegment", func() {
+		var revokeErr error
+
+		BeforeEach(func() {
+			cfIsolationSegment.Spec.Organizations = []string{cfOrg.Name}
+			Expect(k8sClient.Update(ctx, cfIsolationSegment)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			revokeErr = isolationSegmentRepo.RevokeIsolationSegment(ctx, authInfo, repositories.RevokeIsolationSegmentMessage{
+				GUID:             cfIsolationSegment.Name,
+				OrganizationGUID: cfOrg.Name,
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("revokes the org entitlement", func() {
+				Expect(revokeErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(Succeed())
+				Expect(cfIsolationSegment.Spec.Organizations).To(BeEmpty())
+			})
+
+			When("a space in the org is assigned the isolation segment", func() {
+				BeforeEach(func() {
+					cfSpace := createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+					cfSpace.Spec.IsolationSegmentRef.Name = cfIsolationSegment.Name
+					Expect(k8sClient.Update(ctx, cfSpace)).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(revokeErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("DeleteIsolationSegment", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = isolationSegmentRepo.DeleteIsolationSegment(ctx, authInfo, cfIsolationSegment.Name)
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the isolation segment", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			When("orgs are entitled to the isolation segment", func() {
+				BeforeEach(func() {
+					cfIsolationSegment.Spec.Organizations = []string{cfOrg.Name}
+					Expect(k8sClient.Update(ctx, cfIsolationSegment)).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("space isolation segment", func() {
+		var cfSpace *korifiv1alpha1.CFSpace
+
+		BeforeEach(func() {
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+		})
+
+		Describe("GetSpaceIsolationSegment", func() {
+			var (
+				isolationSegmentGUID string
+				getErr               error
+			)
+
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+				cfSpace.Spec.IsolationSegmentRef.Name = cfIsolationSegment.Name
+				Expect(k8sClient.Update(ctx, cfSpace)).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				isolationSegmentGUID, getErr = isolationSegmentRepo.GetSpaceIsolationSegment(ctx, authInfo, cfSpace.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user can get the space", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				})
+
+				It("returns the isolation segment of the space", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(isolationSegmentGUID).To(Equal(cfIsolationSegment.Name))
+				})
+			})
+		})
+
+		Describe("AssignSpaceIsolationSegment", func() {
+			var (
+				isolationSegmentGUID string
+				assigned             string
+				assignErr            error
+			)
+
+			BeforeEach(func() {
+				isolationSegmentGUID = cfIsolationSegment.Name
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			JustBeforeEach(func() {
+				assigned, assignErr = isolationSegmentRepo.AssignSpaceIsolationSegment(ctx, authInfo, repositories.AssignSpaceIsolationSegmentMessage{
+					SpaceGUID:            cfSpace.Name,
+					IsolationSegmentGUID: isolationSegmentGUID,
+				})
+			})
+
+			It("returns an unprocessable entity error as the org is not entitled", func() {
+				Expect(assignErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+
+			When("the org is entitled to the isolation segment", func() {
+				BeforeEach(func() {
+					cfIsolationSegment.Spec.Organizations = []string{cfOrg.Name}
+					Expect(k8sClient.Update(ctx, cfIsolationSegment)).To(Succeed())
+				})
+
+				It("assigns the isolation segment to the space", func() {
+					Expect(assignErr).NotTo(HaveOccurred())
+					Expect(assigned).To(Equal(cfIsolationSegment.Name))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+					Expect(cfSpace.Spec.IsolationSegmentRef.Name).To(Equal(cfIsolationSegment.Name))
+				})
+			})
+
+			When("the isolation segment does not exist", func() {
+				BeforeEach(func() {
+					isolationSegmentGUID = "i-do-not-exist"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(assignErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the isolation segment is unassigned", func() {
+				BeforeEach(func() {
+					isolationSegmentGUID = ""
+					cfSpace.Spec.IsolationSegmentRef.Name = cfIsolationSegment.Name
+					Expect(k8sClient.Update(ctx, cfSpace)).To(Succeed())
+				})
+
+				It("clears the isolation segment of the space", func() {
+					Expect(assignErr).NotTo(HaveOccurred())
+					Expect(assigned).To(BeEmpty())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+					Expect(cfSpace.Spec.IsolationSegmentRef.Name).To(BeEmpty())
+				})
+			})
+		})
+	})
+})
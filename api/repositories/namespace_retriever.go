@@ -11,7 +11,7 @@ import (
 	"k8s.io/client-go/dynamic"
 )
 
-//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfpackages;cfprocesses;cfspaces;cftasks,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfdeployments;cfpackages;cfprocesses;cfrevisions;cfspacequotas;cfspaces;cftasks,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains;cfroutes,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings;cfserviceinstances,verbs=list
 
@@ -28,6 +28,12 @@ var (
 		Resource: "cfbuilds",
 	}
 
+	CFDeploymentsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfdeployments",
+	}
+
 	CFDomainsGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -52,6 +58,12 @@ var (
 		Resource: "cfprocesses",
 	}
 
+	CFRevisionsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfrevisions",
+	}
+
 	CFRoutesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -76,6 +88,12 @@ var (
 		Resource: "cfspaces",
 	}
 
+	CFSpaceQuotasGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfspacequotas",
+	}
+
 	CFTasksGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -85,14 +103,17 @@ var (
 	ResourceMap = map[string]schema.GroupVersionResource{
 		AppResourceType:             CFAppsGVR,
 		BuildResourceType:           CFBuildsGVR,
+		DeploymentResourceType:      CFDeploymentsGVR,
 		DropletResourceType:         CFDropletsGVR,
 		DomainResourceType:          CFDomainsGVR,
 		PackageResourceType:         CFPackagesGVR,
 		ProcessResourceType:         CFProcessesGVR,
+		RevisionResourceType:        CFRevisionsGVR,
 		RouteResourceType:           CFRoutesGVR,
 		ServiceBindingResourceType:  CFServiceBindingsGVR,
 		ServiceInstanceResourceType: CFServiceInstancesGVR,
 		SpaceResourceType:           CFSpacesGVR,
+		SpaceQuotaResourceType:      CFSpaceQuotasGVR,
 		TaskResourceType:            CFTasksGVR,
 	}
 )
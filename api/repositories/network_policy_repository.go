@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const NetworkPolicyResourceType = "Network Policy"
+
+type NetworkPolicyRecord struct {
+	SourceAppGUID      string
+	DestinationAppGUID string
+	Protocol           string
+	StartPort          int32
+	EndPort            int32
+}
+
+type CreateNetworkPolicyMessage struct {
+	SourceAppGUID        string
+	SourceSpaceGUID      string
+	DestinationAppGUID   string
+	DestinationSpaceGUID string
+	Protocol             string
+	StartPort            int32
+	EndPort              int32
+}
+
+type DeleteNetworkPolicyMessage struct {
+	SourceAppGUID        string
+	DestinationAppGUID   string
+	DestinationSpaceGUID string
+	Protocol             string
+	StartPort            int32
+	EndPort              int32
+}
+
+type ListNetworkPoliciesMessage struct {
+	AppGUIDs []string
+}
+
+type NetworkPolicyRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+}
+
+func NewNetworkPolicyRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+) *NetworkPolicyRepo {
+	return &NetworkPolicyRepo{
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+	}
+}
+
+// CreateNetworkPolicy stores the policy in the namespace of the destination
+// app. As in CF, creating a policy that already exists is not an error
+func (r *NetworkPolicyRepo) CreateNetworkPolicy(ctx context.Context, authInfo authorization.Info, message CreateNetworkPolicyMessage) (NetworkPolicyRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return NetworkPolicyRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfNetworkPolicy := &korifiv1alpha1.CFNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      networkPolicyName(message.SourceAppGUID, message.DestinationAppGUID, message.Protocol, message.StartPort, message.EndPort),
+			Namespace: message.DestinationSpaceGUID,
+		},
+		Spec: korifiv1alpha1.CFNetworkPolicySpec{
+			Source: korifiv1alpha1.NetworkPolicySource{
+				AppGUID:   message.SourceAppGUID,
+				SpaceGUID: message.SourceSpaceGUID,
+			},
+			DestinationAppRef: corev1.LocalObjectReference{Name: message.DestinationAppGUID},
+			Protocol:          message.Protocol,
+			StartPort:         message.StartPort,
+			EndPort:           message.EndPort,
+		},
+	}
+
+	err = userClient.Create(ctx, cfNetworkPolicy)
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return NetworkPolicyRecord{}, apierrors.FromK8sError(err, NetworkPolicyResourceType)
+	}
+
+	return cfNetworkPolicyToRecord(*cfNetworkPolicy), nil
+}
+
+// ListNetworkPolicies lists the policies in the spaces the user has a role in.
+// When app guids are given, only the policies with one of them as source or
+// destination are returned
+func (r *NetworkPolicyRepo) ListNetworkPolicies(ctx context.Context, authInfo authorization.Info, message ListNetworkPoliciesMessage) ([]NetworkPolicyRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	appGUIDs := NewSet(message.AppGUIDs...)
+	matchesApps := func(p korifiv1alpha1.CFNetworkPolicy) bool {
+		return len(appGUIDs) == 0 ||
+			appGUIDs.Includes(p.Spec.Source.AppGUID) ||
+			appGUIDs.Includes(p.Spec.DestinationAppRef.Name)
+	}
+
+	records := []NetworkPolicyRecord{}
+	for _, ns := range orderedNamespaces(nsList) {
+		cfNetworkPolicyList := &korifiv1alpha1.CFNetworkPolicyList{}
+		err := listInChunks(ctx, userClient, cfNetworkPolicyList, func(l *korifiv1alpha1.CFNetworkPolicyList) {
+			for _, p := range Filter(l.Items, matchesApps) {
+				records = append(records, cfNetworkPolicyToRecord(p))
+			}
+		}, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list network policies in namespace %s: %w", ns, apierrors.FromK8sError(err, NetworkPolicyResourceType))
+		}
+	}
+
+	return records, nil
+}
+
+// DeleteNetworkPolicy deletes the policy if it exists. As in CF, deleting a
+// policy that does not exist is not an error
+func (r *NetworkPolicyRepo) DeleteNetworkPolicy(ctx context.Context, authInfo authorization.Info, message DeleteNetworkPolicyMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Delete(ctx, &korifiv1alpha1.CFNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      networkPolicyName(message.SourceAppGUID, message.DestinationAppGUID, message.Protocol, message.StartPort, message.EndPort),
+			Namespace: message.DestinationSpaceGUID,
+		},
+	})
+
+	return apierrors.FromK8sError(client.IgnoreNotFound(err), NetworkPolicyResourceType)
+}
+
+// networkPolicyName derives the name of the policy from its content, so that
+// the same policy always maps to the same CFNetworkPolicy
+func networkPolicyName(sourceAppGUID, destinationAppGUID, protocol string, startPort, endPort int32) string {
+	content := fmt.Sprintf("%s:%s:%s:%d-%d", sourceAppGUID, destinationAppGUID, protocol, startPort, endPort)
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(content)).String()
+}
+
+func cfNetworkPolicyToRecord(cfNetworkPolicy korifiv1alpha1.CFNetworkPolicy) NetworkPolicyRecord {
+	return NetworkPolicyRecord{
+		SourceAppGUID:      cfNetworkPolicy.Spec.Source.AppGUID,
+		DestinationAppGUID: cfNetworkPolicy.Spec.DestinationAppRef.Name,
+		Protocol:           cfNetworkPolicy.Spec.Protocol,
+		StartPort:          cfNetworkPolicy.Spec.StartPort,
+		EndPort:            cfNetworkPolicy.Spec.EndPort,
+	}
+}
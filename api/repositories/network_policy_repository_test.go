@@ -0,0 +1,248 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("NetworkPolicyRepository", func() {
+	var (
+		networkPolicyRepo *NetworkPolicyRepo
+		sourceSpace       *korifiv1alpha1.CFSpace
+		destinationSpace  *korifiv1alpha1.CFSpace
+		sourceApp         *korifiv1alpha1.CFApp
+		destinationApp    *korifiv1alpha1.CFApp
+	)
+
+	BeforeEach(func() {
+		networkPolicyRepo = NewNetworkPolicyRepo(userClientFactory, nsPerms)
+
+		cfOrg := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		sourceSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("source-space"))
+		destinationSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("destination-space"))
+		sourceApp = createApp(sourceSpace.Name)
+		destinationApp = createApp(destinationSpace.Name)
+	})
+
+	Describe("CreateNetworkPolicy", func() {
+		var (
+			message   CreateNetworkPolicyMessage
+			record    NetworkPolicyRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = CreateNetworkPolicyMessage{
+				SourceAppGUID:        sourceApp.Name,
+				SourceSpaceGUID:      sourceSpace.Name,
+				DestinationAppGUID:   destinationApp.Name,
+				DestinationSpaceGUID: destinationSpace.Name,
+				Protocol:             "tcp",
+				StartPort:            8080,
+				EndPort:              8090,
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in the destination space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, destinationSpace.Name)
+			})
+
+			It("creates the policy in the destination space", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record).To(Equal(NetworkPolicyRecord{
+					SourceAppGUID:      sourceApp.Name,
+					DestinationAppGUID: destinationApp.Name,
+					Protocol:           "tcp",
+					StartPort:          8080,
+					EndPort:            8090,
+				}))
+
+				cfNetworkPolicyList := &korifiv1alpha1.CFNetworkPolicyList{}
+				Expect(k8sClient.List(ctx, cfNetworkPolicyList, client.InNamespace(destinationSpace.Name))).To(Succeed())
+				Expect(cfNetworkPolicyList.Items).To(HaveLen(1))
+				Expect(cfNetworkPolicyList.Items[0].Spec).To(Equal(korifiv1alpha1.CFNetworkPolicySpec{
+					Source: korifiv1alpha1.NetworkPolicySource{
+						AppGUID:   sourceApp.Name,
+						SpaceGUID: sourceSpace.Name,
+					},
+					DestinationAppRef: corev1.LocalObjectReference{Name: destinationApp.Name},
+					Protocol:          "tcp",
+					StartPort:         8080,
+					EndPort:           8090,
+				}))
+			})
+
+			When("the policy already exists", func() {
+				BeforeEach(func() {
+					_, err := networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, message)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not create a duplicate", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					cfNetworkPolicyList := &korifiv1alpha1.CFNetworkPolicyList{}
+					Expect(k8sClient.List(ctx, cfNetworkPolicyList, client.InNamespace(destinationSpace.Name))).To(Succeed())
+					Expect(cfNetworkPolicyList.Items).To(HaveLen(1))
+				})
+			})
+		})
+	})
+
+	Describe("ListNetworkPolicies", func() {
+		var (
+			message  ListNetworkPoliciesMessage
+			records  []NetworkPolicyRecord
+			listErr  error
+			otherApp *korifiv1alpha1.CFApp
+		)
+
+		BeforeEach(func() {
+			otherApp = createApp(destinationSpace.Name)
+			message = ListNetworkPoliciesMessage{}
+
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, destinationSpace.Name)
+			for _, m := range []CreateNetworkPolicyMessage{
+				{
+					SourceAppGUID:        sourceApp.Name,
+					SourceSpaceGUID:      sourceSpace.Name,
+					DestinationAppGUID:   destinationApp.Name,
+					DestinationSpaceGUID: destinationSpace.Name,
+					Protocol:             "tcp",
+					StartPort:            8080,
+					EndPort:              8080,
+				},
+				{
+					SourceAppGUID:        destinationApp.Name,
+					SourceSpaceGUID:      destinationSpace.Name,
+					DestinationAppGUID:   otherApp.Name,
+					DestinationSpaceGUID: destinationSpace.Name,
+					Protocol:             "udp",
+					StartPort:            53,
+					EndPort:              53,
+				},
+			} {
+				_, err := networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, m)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = networkPolicyRepo.ListNetworkPolicies(ctx, authInfo, message)
+		})
+
+		It("lists the policies in the spaces the user has a role in", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(
+				NetworkPolicyRecord{
+					SourceAppGUID:      sourceApp.Name,
+					DestinationAppGUID: destinationApp.Name,
+					Protocol:           "tcp",
+					StartPort:          8080,
+					EndPort:            8080,
+				},
+				NetworkPolicyRecord{
+					SourceAppGUID:      destinationApp.Name,
+					DestinationAppGUID: otherApp.Name,
+					Protocol:           "udp",
+					StartPort:          53,
+					EndPort:            53,
+				},
+			))
+		})
+
+		When("filtering by app guids", func() {
+			BeforeEach(func() {
+				message.AppGUIDs = []string{sourceApp.Name}
+			})
+
+			It("returns the policies the apps are source or destination of", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(NetworkPolicyRecord{
+					SourceAppGUID:      sourceApp.Name,
+					DestinationAppGUID: destinationApp.Name,
+					Protocol:           "tcp",
+					StartPort:          8080,
+					EndPort:            8080,
+				}))
+			})
+		})
+	})
+
+	Describe("DeleteNetworkPolicy", func() {
+		var (
+			message   DeleteNetworkPolicyMessage
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			message = DeleteNetworkPolicyMessage{
+				SourceAppGUID:        sourceApp.Name,
+				DestinationAppGUID:   destinationApp.Name,
+				DestinationSpaceGUID: destinationSpace.Name,
+				Protocol:             "tcp",
+				StartPort:            8080,
+				EndPort:              8090,
+			}
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = networkPolicyRepo.DeleteNetworkPolicy(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in the destination space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, destinationSpace.Name)
+
+				_, err := networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, CreateNetworkPolicyMessage{
+					SourceAppGUID:        sourceApp.Name,
+					SourceSpaceGUID:      sourceSpace.Name,
+					DestinationAppGUID:   destinationApp.Name,
+					DestinationSpaceGUID: destinationSpace.Name,
+					Protocol:             "tcp",
+					StartPort:            8080,
+					EndPort:              8090,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("deletes the policy", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				cfNetworkPolicyList := &korifiv1alpha1.CFNetworkPolicyList{}
+				Expect(k8sClient.List(ctx, cfNetworkPolicyList, client.InNamespace(destinationSpace.Name))).To(Succeed())
+				Expect(cfNetworkPolicyList.Items).To(BeEmpty())
+			})
+
+			When("the policy does not exist", func() {
+				BeforeEach(func() {
+					message.EndPort = 9000
+				})
+
+				It("succeeds", func() {
+					Expect(deleteErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+	})
+})
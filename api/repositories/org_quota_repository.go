@@ -0,0 +1,317 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	OrgQuotaResourceType = "Organization Quota"
+)
+
+type OrgQuotaRecord struct {
+	GUID              string
+	Name              string
+	Limits            QuotaLimits
+	OrganizationGUIDs []string
+	CreatedAt         time.Time
+	UpdatedAt         *time.Time
+	DeletedAt         *time.Time
+}
+
+type CreateOrgQuotaMessage struct {
+	Name              string
+	Limits            QuotaLimits
+	OrganizationGUIDs []string
+}
+
+type ListOrgQuotasMessage struct {
+	GUIDs             []string
+	Names             []string
+	OrganizationGUIDs []string
+}
+
+type PatchOrgQuotaMessage struct {
+	GUID   string
+	Name   *string
+	Limits QuotaLimitsPatch
+}
+
+type ApplyOrgQuotaMessage struct {
+	GUID              string
+	OrganizationGUIDs []string
+}
+
+type OrgQuotaRepo struct {
+	rootNamespace     string
+	userClientFactory authorization.UserK8sClientFactory
+	nsPerms           *authorization.NamespacePermissions
+}
+
+func NewOrgQuotaRepo(
+	rootNamespace string,
+	userClientFactory authorization.UserK8sClientFactory,
+	nsPerms *authorization.NamespacePermissions,
+) *OrgQuotaRepo {
+	return &OrgQuotaRepo{
+		rootNamespace:     rootNamespace,
+		userClientFactory: userClientFactory,
+		nsPerms:           nsPerms,
+	}
+}
+
+func (r *OrgQuotaRepo) CreateOrgQuota(ctx context.Context, authInfo authorization.Info, message CreateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFOrgQuotaSpec{
+			DisplayName: message.Name,
+			Limits:      message.Limits.toCFQuotaLimits(),
+		},
+	}
+
+	err = userClient.Create(ctx, cfOrgQuota)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to create org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	orgGUIDs, err := r.applyOrgQuota(ctx, authInfo, userClient, cfOrgQuota.Name, message.OrganizationGUIDs)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return cfOrgQuotaToOrgQuotaRecord(cfOrgQuota, orgGUIDs), nil
+}
+
+func (r *OrgQuotaRepo) GetOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota := new(korifiv1alpha1.CFOrgQuota)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfOrgQuota)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to get org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	quotaOrgs, err := r.listQuotaOrgGUIDs(ctx, authInfo, userClient)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return cfOrgQuotaToOrgQuotaRecord(cfOrgQuota, quotaOrgs[guid]), nil
+}
+
+func (r *OrgQuotaRepo) ListOrgQuotas(ctx context.Context, authInfo authorization.Info, message ListOrgQuotasMessage) ([]OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuotaList := new(korifiv1alpha1.CFOrgQuotaList)
+	err = userClient.List(ctx, cfOrgQuotaList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []OrgQuotaRecord{}, nil
+		}
+		return nil, fmt.Errorf("failed to list org quotas: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	quotaOrgs, err := r.listQuotaOrgGUIDs(ctx, authInfo, userClient)
+	if err != nil {
+		return nil, err
+	}
+
+	orgGUIDs := NewSet(message.OrganizationGUIDs...)
+	preds := []func(korifiv1alpha1.CFOrgQuota) bool{
+		SetPredicate(message.GUIDs, func(q korifiv1alpha1.CFOrgQuota) string { return q.Name }),
+		SetPredicate(message.Names, func(q korifiv1alpha1.CFOrgQuota) string { return q.Spec.DisplayName }),
+		func(q korifiv1alpha1.CFOrgQuota) bool {
+			if len(orgGUIDs) == 0 {
+				return true
+			}
+			for _, orgGUID := range quotaOrgs[q.Name] {
+				if orgGUIDs.Includes(orgGUID) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+
+	filtered := Filter(cfOrgQuotaList.Items, preds...)
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
+	})
+
+	records := make([]OrgQuotaRecord, 0, len(filtered))
+	for i := range filtered {
+		records = append(records, cfOrgQuotaToOrgQuotaRecord(&filtered[i], quotaOrgs[filtered[i].Name]))
+	}
+
+	return records, nil
+}
+
+func (r *OrgQuotaRepo) PatchOrgQuota(ctx context.Context, authInfo authorization.Info, message PatchOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota := new(korifiv1alpha1.CFOrgQuota)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: message.GUID}, cfOrgQuota)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to get org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfOrgQuota, func() {
+		if message.Name != nil {
+			cfOrgQuota.Spec.DisplayName = *message.Name
+		}
+		message.Limits.Apply(&cfOrgQuota.Spec.Limits)
+	})
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to patch org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	quotaOrgs, err := r.listQuotaOrgGUIDs(ctx, authInfo, userClient)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return cfOrgQuotaToOrgQuotaRecord(cfOrgQuota, quotaOrgs[message.GUID]), nil
+}
+
+// ApplyOrgQuota sets the quota on the orgs and returns all the orgs the quota is applied to
+func (r *OrgQuotaRepo) ApplyOrgQuota(ctx context.Context, authInfo authorization.Info, message ApplyOrgQuotaMessage) ([]string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: message.GUID}, new(korifiv1alpha1.CFOrgQuota))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return r.applyOrgQuota(ctx, authInfo, userClient, message.GUID, message.OrganizationGUIDs)
+}
+
+func (r *OrgQuotaRepo) applyOrgQuota(ctx context.Context, authInfo authorization.Info, userClient client.WithWatch, quotaGUID string, orgGUIDs []string) ([]string, error) {
+	for _, orgGUID := range orgGUIDs {
+		cfOrg := new(korifiv1alpha1.CFOrg)
+		err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, cfOrg)
+		if err != nil {
+			if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+				return nil, apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Organization with guid '%s' does not exist, or you do not have access to it.", orgGUID))
+			}
+			return nil, fmt.Errorf("failed to get org: %w", apierrors.FromK8sError(err, OrgResourceType))
+		}
+
+		err = k8s.PatchResource(ctx, userClient, cfOrg, func() {
+			cfOrg.Spec.QuotaRef.Name = quotaGUID
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply org quota: %w", apierrors.FromK8sError(err, OrgResourceType))
+		}
+	}
+
+	quotaOrgs, err := r.listQuotaOrgGUIDs(ctx, authInfo, userClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return quotaOrgs[quotaGUID], nil
+}
+
+func (r *OrgQuotaRepo) DeleteOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	quotaOrgs, err := r.listQuotaOrgGUIDs(ctx, authInfo, userClient)
+	if err != nil {
+		return err
+	}
+
+	if len(quotaOrgs[guid]) > 0 {
+		return apierrors.NewUnprocessableEntityError(nil, "This quota is applied to one or more organizations. Remove this quota from all organizations before deleting.")
+	}
+
+	err = userClient.Delete(ctx, &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: r.rootNamespace,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return nil
+}
+
+func (r *OrgQuotaRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	orgQuota, err := r.GetOrgQuota(ctx, authInfo, guid)
+	return orgQuota.DeletedAt, err
+}
+
+// listQuotaOrgGUIDs returns the guids of the orgs visible to the user, grouped by the quota applied to them
+func (r *OrgQuotaRepo) listQuotaOrgGUIDs(ctx context.Context, authInfo authorization.Info, userClient client.WithWatch) (map[string][]string, error) {
+	authorizedOrgNamespaces, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	cfOrgList := new(korifiv1alpha1.CFOrgList)
+	err = userClient.List(ctx, cfOrgList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return map[string][]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to list orgs: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	quotaOrgs := map[string][]string{}
+	for _, cfOrg := range cfOrgList.Items {
+		if !authorizedOrgNamespaces[cfOrg.Name] || cfOrg.Spec.QuotaRef.Name == "" {
+			continue
+		}
+		quotaOrgs[cfOrg.Spec.QuotaRef.Name] = append(quotaOrgs[cfOrg.Spec.QuotaRef.Name], cfOrg.Name)
+	}
+
+	return quotaOrgs, nil
+}
+
+func cfOrgQuotaToOrgQuotaRecord(cfOrgQuota *korifiv1alpha1.CFOrgQuota, orgGUIDs []string) OrgQuotaRecord {
+	return OrgQuotaRecord{
+		GUID:              cfOrgQuota.Name,
+		Name:              cfOrgQuota.Spec.DisplayName,
+		Limits:            cfQuotaLimitsToQuotaLimits(cfOrgQuota.Spec.Limits),
+		OrganizationGUIDs: orgGUIDs,
+		CreatedAt:         cfOrgQuota.CreationTimestamp.Time,
+		UpdatedAt:         getLastUpdatedTime(cfOrgQuota),
+		DeletedAt:         golangTime(cfOrgQuota.DeletionTimestamp),
+	}
+}
@@ -0,0 +1,303 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("OrgQuotaRepository", func() {
+	var (
+		orgQuotaRepo *repositories.OrgQuotaRepo
+		cfOrgQuota   *korifiv1alpha1.CFOrgQuota
+	)
+
+	BeforeEach(func() {
+		orgQuotaRepo = repositories.NewOrgQuotaRepo(rootNamespace, userClientFactory, nsPerms)
+
+		cfOrgQuota = &korifiv1alpha1.CFOrgQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFOrgQuotaSpec{
+				DisplayName: "my-quota",
+				Limits: korifiv1alpha1.QuotaLimits{
+					TotalMemoryMB: tools.PtrTo[int64](1024),
+					TotalRoutes:   tools.PtrTo[int64](10),
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfOrgQuota)).To(Succeed())
+	})
+
+	Describe("CreateOrgQuota", func() {
+		var (
+			message      repositories.CreateOrgQuotaMessage
+			orgQuota     repositories.OrgQuotaRecord
+			createErr    error
+			cfOrg        *korifiv1alpha1.CFOrg
+			quotaLimits  repositories.QuotaLimits
+			expectedOrgs []string
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+			quotaLimits = repositories.QuotaLimits{
+				TotalAppInstances: tools.PtrTo[int64](5),
+			}
+			message = repositories.CreateOrgQuotaMessage{
+				Name:              "new-quota",
+				Limits:            quotaLimits,
+				OrganizationGUIDs: []string{cfOrg.Name},
+			}
+			expectedOrgs = []string{cfOrg.Name}
+		})
+
+		JustBeforeEach(func() {
+			orgQuota, createErr = orgQuotaRepo.CreateOrgQuota(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("creates the org quota and applies it to the orgs", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(orgQuota.Name).To(Equal("new-quota"))
+				Expect(orgQuota.Limits).To(Equal(quotaLimits))
+				Expect(orgQuota.OrganizationGUIDs).To(Equal(expectedOrgs))
+
+				createdQuota := new(korifiv1alpha1.CFOrgQuota)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: orgQuota.GUID}, createdQuota)).To(Succeed())
+				Expect(createdQuota.Spec.DisplayName).To(Equal("new-quota"))
+				Expect(createdQuota.Spec.Limits.TotalAppInstances).To(Equal(tools.PtrTo[int64](5)))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrg), cfOrg)).To(Succeed())
+				Expect(cfOrg.Spec.QuotaRef.Name).To(Equal(orgQuota.GUID))
+			})
+
+			When("the org does not exist", func() {
+				BeforeEach(func() {
+					message.OrganizationGUIDs = []string{"i-do-not-exist"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(createErr.(apierrors.UnprocessableEntityError).Detail()).To(
+						Equal("Organization with guid 'i-do-not-exist' does not exist, or you do not have access to it."),
+					)
+				})
+			})
+		})
+	})
+
+	Describe("GetOrgQuota", func() {
+		var (
+			orgQuota repositories.OrgQuotaRecord
+			getErr   error
+		)
+
+		JustBeforeEach(func() {
+			orgQuota, getErr = orgQuotaRepo.GetOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+		})
+
+		It("returns the org quota", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(orgQuota.GUID).To(Equal(cfOrgQuota.Name))
+			Expect(orgQuota.Name).To(Equal("my-quota"))
+			Expect(orgQuota.Limits).To(Equal(repositories.QuotaLimits{
+				TotalMemoryMB: tools.PtrTo[int64](1024),
+				TotalRoutes:   tools.PtrTo[int64](10),
+			}))
+			Expect(orgQuota.OrganizationGUIDs).To(BeEmpty())
+		})
+
+		When("the quota is applied to an org the user can see", func() {
+			var cfOrg *korifiv1alpha1.CFOrg
+
+			BeforeEach(func() {
+				cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+				cfOrg.Spec.QuotaRef.Name = cfOrgQuota.Name
+				Expect(k8sClient.Update(ctx, cfOrg)).To(Succeed())
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+
+				invisibleOrg := createOrgWithCleanup(ctx, uuid.NewString())
+				invisibleOrg.Spec.QuotaRef.Name = cfOrgQuota.Name
+				Expect(k8sClient.Update(ctx, invisibleOrg)).To(Succeed())
+			})
+
+			It("only returns the visible org", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(orgQuota.OrganizationGUIDs).To(ConsistOf(cfOrg.Name))
+			})
+		})
+
+		When("the org quota does not exist", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Delete(ctx, cfOrgQuota)).To(Succeed())
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListOrgQuotas", func() {
+		var (
+			message        repositories.ListOrgQuotasMessage
+			orgQuotas      []repositories.OrgQuotaRecord
+			listErr        error
+			anotherQuota   *korifiv1alpha1.CFOrgQuota
+			anotherOrgGUID string
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListOrgQuotasMessage{}
+
+			anotherQuota = &korifiv1alpha1.CFOrgQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFOrgQuotaSpec{
+					DisplayName: "another-quota",
+				},
+			}
+			Expect(k8sClient.Create(ctx, anotherQuota)).To(Succeed())
+
+			cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
+			cfOrg.Spec.QuotaRef.Name = anotherQuota.Name
+			Expect(k8sClient.Update(ctx, cfOrg)).To(Succeed())
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			anotherOrgGUID = cfOrg.Name
+		})
+
+		JustBeforeEach(func() {
+			orgQuotas, listErr = orgQuotaRepo.ListOrgQuotas(ctx, authInfo, message)
+		})
+
+		It("lists all org quotas", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(orgQuotas).To(HaveLen(2))
+			Expect(orgQuotas[0].GUID).To(Equal(cfOrgQuota.Name))
+			Expect(orgQuotas[1].GUID).To(Equal(anotherQuota.Name))
+			Expect(orgQuotas[1].OrganizationGUIDs).To(ConsistOf(anotherOrgGUID))
+		})
+
+		When("filtering by name", func() {
+			BeforeEach(func() {
+				message.Names = []string{"my-quota"}
+			})
+
+			It("returns the matching quota", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(orgQuotas).To(HaveLen(1))
+				Expect(orgQuotas[0].GUID).To(Equal(cfOrgQuota.Name))
+			})
+		})
+
+		When("filtering by organization guid", func() {
+			BeforeEach(func() {
+				message.OrganizationGUIDs = []string{anotherOrgGUID}
+			})
+
+			It("returns the quota applied to the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(orgQuotas).To(HaveLen(1))
+				Expect(orgQuotas[0].GUID).To(Equal(anotherQuota.Name))
+			})
+		})
+	})
+
+	Describe("PatchOrgQuota", func() {
+		var (
+			message  repositories.PatchOrgQuotaMessage
+			orgQuota repositories.OrgQuotaRecord
+			patchErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.PatchOrgQuotaMessage{
+				GUID: cfOrgQuota.Name,
+				Name: tools.PtrTo("new-name"),
+				Limits: repositories.QuotaLimitsPatch{
+					TotalMemoryMB: &repositories.QuotaLimitPatch{Value: tools.PtrTo[int64](2048)},
+					TotalRoutes:   &repositories.QuotaLimitPatch{},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			orgQuota, patchErr = orgQuotaRepo.PatchOrgQuota(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("patches the org quota", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(orgQuota.Name).To(Equal("new-name"))
+				Expect(orgQuota.Limits).To(Equal(repositories.QuotaLimits{
+					TotalMemoryMB: tools.PtrTo[int64](2048),
+				}))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.Limits.TotalRoutes).To(BeNil())
+			})
+		})
+	})
+
+	Describe("DeleteOrgQuota", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = orgQuotaRepo.DeleteOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+		})
+
+		It("deletes the org quota", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		When("the quota is applied to an org", func() {
+			BeforeEach(func() {
+				cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
+				cfOrg.Spec.QuotaRef.Name = cfOrgQuota.Name
+				Expect(k8sClient.Update(ctx, cfOrg)).To(Succeed())
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+})
@@ -310,16 +310,17 @@ func (r *PackageRepo) ListPackages(ctx context.Context, authInfo authorization.I
 	}
 
 	var filteredPackages []korifiv1alpha1.CFPackage
-	for ns := range nsList {
+	for _, ns := range orderedNamespaces(nsList) {
 		packageList := &korifiv1alpha1.CFPackageList{}
-		err = userClient.List(ctx, packageList, client.InNamespace(ns))
+		err = listInChunks(ctx, userClient, packageList, func(l *korifiv1alpha1.CFPackageList) {
+			filteredPackages = append(filteredPackages, Filter(l.Items, preds...)...)
+		}, client.InNamespace(ns))
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
 		if err != nil {
 			return []PackageRecord{}, fmt.Errorf("failed to list packages in namespace %s: %w", ns, apierrors.FromK8sError(err, PackageResourceType))
 		}
-		filteredPackages = append(filteredPackages, Filter(packageList.Items, preds...)...)
 	}
 	return r.convertToPackageRecords(filteredPackages), nil
 }
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -14,12 +16,19 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	k8sclient "k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	appLogSourceType = "APP"
+
+	appContainerName           = "application"
+	sidecarContainerNamePrefix = "sidecar-"
+	envCFInstanceIndex         = "CF_INSTANCE_INDEX"
 )
 
 type PodRepo struct {
@@ -52,16 +61,26 @@ type RuntimeLogsMessage struct {
 	AppGUID     string
 	AppRevision string
 	Limit       int64
+	SinceTime   *time.Time
 }
 
-func (r *PodRepo) GetRuntimeLogsForApp(ctx context.Context, logger logr.Logger, authInfo authorization.Info, message RuntimeLogsMessage) ([]LogRecord, error) {
+func appPodsSelector(appGUID, appRevision string) (labels.Selector, error) {
 	labelSelector, err := labels.ValidatedSelectorFromSet(map[string]string{
-		korifiv1alpha1.CFAppGUIDLabelKey:  message.AppGUID,
-		"korifi.cloudfoundry.org/version": message.AppRevision,
+		korifiv1alpha1.CFAppGUIDLabelKey:  appGUID,
+		"korifi.cloudfoundry.org/version": appRevision,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to build labelSelector: %w", err)
 	}
+
+	return labelSelector, nil
+}
+
+func (r *PodRepo) GetRuntimeLogsForApp(ctx context.Context, logger logr.Logger, authInfo authorization.Info, message RuntimeLogsMessage) ([]LogRecord, error) {
+	labelSelector, err := appPodsSelector(message.AppGUID, message.AppRevision)
+	if err != nil {
+		return nil, err
+	}
 	listOpts := client.ListOptions{Namespace: message.SpaceGUID, LabelSelector: labelSelector}
 
 	pods, err := r.listPods(ctx, authInfo, listOpts)
@@ -77,39 +96,338 @@ func (r *PodRepo) GetRuntimeLogsForApp(ctx context.Context, logger logr.Logger,
 	}
 
 	for _, pod := range pods {
-		var logReadCloser io.ReadCloser
-		logReadCloser, err = k8sClient.CoreV1().Pods(message.SpaceGUID).GetLogs(pod.Name, &corev1.PodLogOptions{Timestamps: true, TailLines: &message.Limit}).Stream(ctx)
+		for _, instance := range appContainersOf(pod) {
+			var containerLogs []LogRecord
+			containerLogs, err = readContainerLogs(ctx, logger, k8sClient, pod, instance, message)
+			if err != nil {
+				return nil, err
+			}
+
+			appLogs = append(appLogs, containerLogs...)
+		}
+	}
+
+	return appLogs, nil
+}
+
+func readContainerLogs(ctx context.Context, logger logr.Logger, k8sClient k8sclient.Interface, pod corev1.Pod, instance appInstance, message RuntimeLogsMessage) ([]LogRecord, error) {
+	logOptions := &corev1.PodLogOptions{
+		Container:  instance.container,
+		Timestamps: true,
+		TailLines:  &message.Limit,
+	}
+	if message.SinceTime != nil {
+		logOptions.SinceTime = &metav1.Time{Time: *message.SinceTime}
+	}
+
+	logReadCloser, err := k8sClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+	if err != nil {
+		// untested
+		logger.Info("failed to fetch logs", "pod", pod.Name, "container", instance.container, "reason", err)
+		return nil, nil
+	}
+	defer logReadCloser.Close()
+
+	var logs []LogRecord
+	r := bufio.NewReader(logReadCloser)
+	for {
+		line, err := r.ReadBytes('\n')
 		if err != nil {
-			// untested
-			logger.Info("failed to fetch logs", "pod", pod.Name, "reason", err)
-			continue
+			if err == io.EOF {
+				return logs, nil
+			}
+			return nil, fmt.Errorf("failed to parse pod logs: %w", err)
 		}
 
-		r := bufio.NewReader(logReadCloser)
-		for {
-			var line []byte
-			line, err = r.ReadBytes('\n')
-			if err != nil {
-				if err == io.EOF {
-					break
-				} else {
-					_ = logReadCloser.Close()
-					return nil, fmt.Errorf("failed to parse pod logs: %w", err)
-				}
+		logs = append(logs, lineToAppLogRecord(line, instance))
+	}
+}
+
+type StreamRuntimeLogsMessage struct {
+	SpaceGUID   string
+	AppGUID     string
+	AppRevision string
+	SinceTime   *time.Time
+}
+
+// StreamRuntimeLogsForApp follows the logs of all the app instances,
+// including the ones started after the stream has been opened, and merges
+// them into the returned channel. The channel is closed once the context is
+// done.
+func (r *PodRepo) StreamRuntimeLogsForApp(ctx context.Context, logger logr.Logger, authInfo authorization.Info, message StreamRuntimeLogsMessage) (<-chan LogRecord, error) {
+	labelSelector, err := appPodsSelector(message.AppGUID, message.AppRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	k8sClient, err := r.userClientFactory.BuildK8sClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	podWatch, err := userClient.Watch(ctx, &corev1.PodList{}, client.InNamespace(message.SpaceGUID), client.MatchingLabelsSelector{Selector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch pods: %w", apierrors.FromK8sError(err, PodResourceType))
+	}
+
+	follower := &podLogsFollower{
+		k8sClient: k8sClient,
+		logger:    logger,
+		sinceTime: message.SinceTime,
+		logs:      make(chan LogRecord),
+		following: map[string]bool{},
+		since:     map[string]time.Time{},
+	}
+	go follower.run(ctx, podWatch)
+
+	return follower.logs, nil
+}
+
+// podLogsFollower follows the logs of every app container of the watched
+// pods
+type podLogsFollower struct {
+	k8sClient k8sclient.Interface
+	logger    logr.Logger
+	sinceTime *time.Time
+	logs      chan LogRecord
+
+	mu        sync.Mutex
+	wg        sync.WaitGroup
+	following map[string]bool
+	since     map[string]time.Time
+}
+
+func (f *podLogsFollower) run(ctx context.Context, podWatch watch.Interface) {
+	defer close(f.logs)
+	defer f.wg.Wait()
+	defer podWatch.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-podWatch.ResultChan():
+			if !ok {
+				return
 			}
 
-			logRecord := lineToAppLogRecord(line)
+			pod, ok := event.Object.(*corev1.Pod)
+			if !ok || event.Type == watch.Deleted || pod.Status.Phase != corev1.PodRunning {
+				continue
+			}
 
-			appLogs = append(appLogs, logRecord)
+			for _, instance := range appContainersOf(*pod) {
+				f.follow(ctx, *pod, instance)
+			}
 		}
+	}
+}
+
+// follow starts following the container logs unless they are already being
+// followed. Containers are followed again when they restart, starting from
+// the last log line received.
+func (f *podLogsFollower) follow(ctx context.Context, pod corev1.Pod, instance appInstance) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 
-		_ = logReadCloser.Close()
+	key := pod.Name + "/" + instance.container
+	if f.following[key] {
+		return
 	}
+	f.following[key] = true
 
-	return appLogs, nil
+	sinceTime := f.sinceTime
+	if since, ok := f.since[key]; ok {
+		sinceTime = &since
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		lastTimestamp := f.streamContainerLogs(ctx, pod, instance, sinceTime)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.following, key)
+		if lastTimestamp != 0 {
+			f.since[key] = time.Unix(0, lastTimestamp+1)
+		}
+	}()
+}
+
+func (f *podLogsFollower) streamContainerLogs(ctx context.Context, pod corev1.Pod, instance appInstance, sinceTime *time.Time) int64 {
+	logOptions := &corev1.PodLogOptions{
+		Container:  instance.container,
+		Timestamps: true,
+		Follow:     true,
+	}
+	var sinceNanos int64
+	if sinceTime != nil {
+		logOptions.SinceTime = &metav1.Time{Time: *sinceTime}
+		sinceNanos = sinceTime.UnixNano()
+	}
+
+	logReadCloser, err := f.k8sClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+	if err != nil {
+		f.logger.Info("failed to follow logs", "pod", pod.Name, "container", instance.container, "reason", err)
+		return 0
+	}
+	defer logReadCloser.Close()
+
+	var lastTimestamp int64
+	reader := bufio.NewReader(logReadCloser)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				f.logger.Info("failed to read pod logs", "pod", pod.Name, "container", instance.container, "reason", err)
+			}
+			return lastTimestamp
+		}
+
+		logRecord := lineToAppLogRecord(line, instance)
+		// the pod logs since time has a precision of seconds
+		if logRecord.Timestamp < sinceNanos {
+			continue
+		}
+		lastTimestamp = logRecord.Timestamp
+
+		select {
+		case f.logs <- logRecord:
+		case <-ctx.Done():
+			return lastTimestamp
+		}
+	}
+}
+
+type GetAppInstancePodMessage struct {
+	SpaceGUID     string
+	AppGUID       string
+	ProcessType   string
+	InstanceIndex int
+}
+
+type AppInstancePodRecord struct {
+	Name          string
+	Namespace     string
+	ContainerName string
+}
+
+// GetAppInstancePod returns the pod running the given instance of an app
+// process
+func (r *PodRepo) GetAppInstancePod(ctx context.Context, authInfo authorization.Info, message GetAppInstancePodMessage) (AppInstancePodRecord, error) {
+	pods, err := r.listPods(ctx, authInfo, client.ListOptions{
+		Namespace: message.SpaceGUID,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey:     message.AppGUID,
+			korifiv1alpha1.CFProcessTypeLabelKey: message.ProcessType,
+		}),
+	})
+	if err != nil {
+		return AppInstancePodRecord{}, err
+	}
+
+	index := strconv.Itoa(message.InstanceIndex)
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && instanceIndexOf(pod) == index {
+			return AppInstancePodRecord{
+				Name:          pod.Name,
+				Namespace:     pod.Namespace,
+				ContainerName: appContainerName,
+			}, nil
+		}
+	}
+
+	return AppInstancePodRecord{}, apierrors.NewNotFoundError(fmt.Errorf("no instance %d of process %q of app %q", message.InstanceIndex, message.ProcessType, message.AppGUID), PodResourceType)
+}
+
+// appInstance identifies the app instance running in a pod container
+type appInstance struct {
+	processType string
+	index       string
+	container   string
+	sidecar     string
+}
+
+func appInstanceOf(pod corev1.Pod) appInstance {
+	return appInstance{
+		processType: pod.Labels[korifiv1alpha1.CFProcessTypeLabelKey],
+		index:       instanceIndexOf(pod),
+		container:   appContainerName,
+	}
+}
+
+// appContainersOf returns the application container of the pod, followed by
+// its sidecar containers
+func appContainersOf(pod corev1.Pod) []appInstance {
+	instance := appInstanceOf(pod)
+	containers := []appInstance{instance}
+
+	for _, container := range pod.Spec.Containers {
+		sidecarName, isSidecar := strings.CutPrefix(container.Name, sidecarContainerNamePrefix)
+		if !isSidecar {
+			continue
+		}
+
+		sidecar := instance
+		sidecar.container = container.Name
+		sidecar.sidecar = sidecarName
+		containers = append(containers, sidecar)
+	}
+
+	return containers
+}
+
+// instanceIndexOf returns the CF_INSTANCE_INDEX of the app container,
+// falling back to the StatefulSet pod ordinal for workloads that do not set it
+func instanceIndexOf(pod corev1.Pod) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != appContainerName {
+			continue
+		}
+
+		for _, env := range container.Env {
+			if env.Name == envCFInstanceIndex && env.Value != "" {
+				return env.Value
+			}
+		}
+	}
+
+	ordinalIdx := strings.LastIndex(pod.Name, "-")
+	if ordinalIdx < 0 {
+		return ""
+	}
+
+	ordinal := pod.Name[ordinalIdx+1:]
+	if _, err := strconv.Atoi(ordinal); err != nil {
+		return ""
+	}
+
+	return ordinal
+}
+
+// sourceType mirrors the source type of Loggregator app envelopes, e.g.
+// APP/PROC/WEB or APP/PROC/WEB/SIDECAR/CONFIG-SERVER
+func (i appInstance) sourceType() string {
+	sourceType := appLogSourceType
+	if i.processType != "" {
+		sourceType += "/PROC/" + strings.ToUpper(i.processType)
+	}
+
+	if i.sidecar != "" {
+		sourceType += "/SIDECAR/" + strings.ToUpper(i.sidecar)
+	}
+
+	return sourceType
 }
 
-func lineToAppLogRecord(line []byte) LogRecord {
+func lineToAppLogRecord(line []byte, instance appInstance) LogRecord {
 	logLine := string(line)
 	var logTime int64
 	logLine, logTime, _ = parseRFC3339NanoTime(logLine)
@@ -118,10 +436,13 @@ func lineToAppLogRecord(line []byte) LogRecord {
 	logLine = strings.TrimRight(logLine, "\r\n")
 
 	logRecord := LogRecord{
-		Message:   logLine,
-		Timestamp: logTime,
+		Message:       logLine,
+		Timestamp:     logTime,
+		InstanceIndex: instance.index,
 		Tags: map[string]string{
-			"source_type": appLogSourceType,
+			"source_type":     instance.sourceType(),
+			"source_instance": instance.index,
+			"process_type":    instance.processType,
 		},
 	}
 	return logRecord
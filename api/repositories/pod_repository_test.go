@@ -0,0 +1,312 @@
+package repositories_test
+
+import (
+	"context"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("PodRepository", func() {
+	var (
+		podRepo *PodRepo
+		cfSpace *korifiv1alpha1.CFSpace
+		appGUID string
+	)
+
+	// createPod creates an app pod, overriding its default labels with the
+	// given ones. Labels overridden with an empty value are removed.
+	createPod := func(name string, labels map[string]string, containers ...corev1.Container) {
+		GinkgoHelper()
+
+		podLabels := map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+			korifiv1alpha1.VersionLabelKey:       "1",
+			korifiv1alpha1.CFProcessTypeLabelKey: "web",
+		}
+		for k, v := range labels {
+			if v == "" {
+				delete(podLabels, k)
+				continue
+			}
+			podLabels[k] = v
+		}
+
+		Expect(k8sClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      name,
+				Labels:    podLabels,
+			},
+			Spec: corev1.PodSpec{
+				Containers: containers,
+			},
+		})).To(Succeed())
+	}
+
+	appContainer := func(env ...corev1.EnvVar) corev1.Container {
+		return corev1.Container{Name: "application", Image: "my-image", Env: env}
+	}
+
+	BeforeEach(func() {
+		podRepo = NewPodRepo(userClientFactory)
+
+		cfOrg := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		appGUID = uuid.NewString()
+	})
+
+	Describe("GetRuntimeLogsForApp", func() {
+		var (
+			logs    []LogRecord
+			logsErr error
+		)
+
+		BeforeEach(func() {
+			podRepo = NewPodRepo(newLogsClientFactory("2024-01-02T03:04:05.000000000Z hello\n"))
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+		})
+
+		JustBeforeEach(func() {
+			logs, logsErr = podRepo.GetRuntimeLogsForApp(ctx, logr.Discard(), authInfo, RuntimeLogsMessage{
+				SpaceGUID:   cfSpace.Name,
+				AppGUID:     appGUID,
+				AppRevision: "1",
+				Limit:       10,
+			})
+		})
+
+		When("the app instance runs a sidecar", func() {
+			BeforeEach(func() {
+				createPod("my-app-web-2", nil,
+					appContainer(),
+					corev1.Container{Name: "sidecar-config-server", Image: "my-image"},
+				)
+			})
+
+			It("tags the logs with the process type, the instance index and the sidecar", func() {
+				Expect(logsErr).NotTo(HaveOccurred())
+				Expect(logs).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"Message":       Equal("hello"),
+						"InstanceIndex": Equal("2"),
+						"Tags": Equal(map[string]string{
+							"source_type":     "APP/PROC/WEB",
+							"source_instance": "2",
+							"process_type":    "web",
+						}),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"Message": Equal("hello"),
+						"Tags": Equal(map[string]string{
+							"source_type":     "APP/PROC/WEB/SIDECAR/CONFIG-SERVER",
+							"source_instance": "2",
+							"process_type":    "web",
+						}),
+					}),
+				))
+			})
+		})
+
+		When("the app container sets the instance index", func() {
+			BeforeEach(func() {
+				createPod("my-app-web-2", nil, appContainer(corev1.EnvVar{Name: "CF_INSTANCE_INDEX", Value: "5"}))
+			})
+
+			It("tags the logs with the instance index of the container", func() {
+				Expect(logsErr).NotTo(HaveOccurred())
+				Expect(logs).To(ConsistOf(HaveField("InstanceIndex", "5")))
+			})
+		})
+
+		When("the pod name does not end with a numeric ordinal", func() {
+			BeforeEach(func() {
+				createPod("my-app-web-abc", nil, appContainer())
+			})
+
+			It("does not tag the logs with an instance index", func() {
+				Expect(logsErr).NotTo(HaveOccurred())
+				Expect(logs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"InstanceIndex": BeEmpty(),
+					"Tags":          HaveKeyWithValue("source_instance", ""),
+				})))
+			})
+		})
+
+		When("the pod has no process type label", func() {
+			BeforeEach(func() {
+				createPod("my-app-web-0", map[string]string{korifiv1alpha1.CFProcessTypeLabelKey: ""}, appContainer())
+			})
+
+			It("tags the logs as app logs without a process type", func() {
+				Expect(logsErr).NotTo(HaveOccurred())
+				Expect(logs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Tags": Equal(map[string]string{
+						"source_type":     "APP",
+						"source_instance": "0",
+						"process_type":    "",
+					}),
+				})))
+			})
+		})
+	})
+
+	Describe("StreamRuntimeLogsForApp", func() {
+		var (
+			message   StreamRuntimeLogsMessage
+			logs      <-chan LogRecord
+			streamErr error
+
+			cancelStream context.CancelFunc
+		)
+
+		BeforeEach(func() {
+			podRepo = NewPodRepo(newLogsClientFactory("2024-01-02T03:04:05.000000000Z hello\n"))
+
+			createPod("my-app-web-0", nil, appContainer())
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: cfSpace.Name, Name: "my-app-web-0"}}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+			pod.Status.Phase = corev1.PodRunning
+			Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+
+			message = StreamRuntimeLogsMessage{
+				SpaceGUID:   cfSpace.Name,
+				AppGUID:     appGUID,
+				AppRevision: "1",
+			}
+		})
+
+		JustBeforeEach(func() {
+			var streamCtx context.Context
+			streamCtx, cancelStream = context.WithCancel(ctx)
+			DeferCleanup(cancelStream)
+
+			logs, streamErr = podRepo.StreamRuntimeLogsForApp(streamCtx, logr.Discard(), authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(streamErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("follows the logs of the running app instances", func() {
+				Expect(streamErr).NotTo(HaveOccurred())
+				Eventually(logs).Should(Receive(MatchFields(IgnoreExtras, Fields{
+					"Message":       Equal("hello"),
+					"Timestamp":     Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixNano()),
+					"InstanceIndex": Equal("0"),
+				})))
+			})
+
+			When("the logs were emitted before the since time", func() {
+				BeforeEach(func() {
+					message.SinceTime = tools.PtrTo(time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC))
+				})
+
+				It("does not stream them", func() {
+					Expect(streamErr).NotTo(HaveOccurred())
+					Consistently(logs).ShouldNot(Receive())
+				})
+			})
+
+			When("the stream context is done", func() {
+				JustBeforeEach(func() {
+					Eventually(logs).Should(Receive())
+					cancelStream()
+				})
+
+				It("closes the logs channel", func() {
+					Expect(streamErr).NotTo(HaveOccurred())
+					Eventually(logs).Should(BeClosed())
+				})
+			})
+		})
+	})
+
+	Describe("GetAppInstancePod", func() {
+		var (
+			message GetAppInstancePodMessage
+			pod     AppInstancePodRecord
+			getErr  error
+		)
+
+		BeforeEach(func() {
+			message = GetAppInstancePodMessage{
+				SpaceGUID:     cfSpace.Name,
+				AppGUID:       appGUID,
+				ProcessType:   "web",
+				InstanceIndex: 1,
+			}
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+		})
+
+		JustBeforeEach(func() {
+			pod, getErr = podRepo.GetAppInstancePod(ctx, authInfo, message)
+		})
+
+		When("the instance index is the pod ordinal", func() {
+			BeforeEach(func() {
+				createPod("my-app-web-0", nil, appContainer())
+				createPod("my-app-web-1", nil, appContainer())
+			})
+
+			It("returns the pod of the instance", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(pod).To(Equal(AppInstancePodRecord{
+					Name:          "my-app-web-1",
+					Namespace:     cfSpace.Name,
+					ContainerName: "application",
+				}))
+			})
+		})
+
+		When("the app container sets the instance index", func() {
+			BeforeEach(func() {
+				createPod("my-app-web-0", nil, appContainer(corev1.EnvVar{Name: "CF_INSTANCE_INDEX", Value: "1"}))
+				createPod("my-app-web-1", nil, appContainer(corev1.EnvVar{Name: "CF_INSTANCE_INDEX", Value: "0"}))
+			})
+
+			It("prefers the index of the container to the pod ordinal", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(pod.Name).To(Equal("my-app-web-0"))
+			})
+		})
+
+		When("the pod name does not end with a numeric ordinal", func() {
+			BeforeEach(func() {
+				createPod("my-app-web-one", nil, appContainer())
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+
+		When("the pod has no process type label", func() {
+			BeforeEach(func() {
+				createPod("my-app-web-1", map[string]string{korifiv1alpha1.CFProcessTypeLabelKey: ""}, appContainer())
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
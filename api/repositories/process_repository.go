@@ -141,7 +141,7 @@ func (r *ProcessRepo) ListProcesses(ctx context.Context, authInfo authorization.
 
 	processList := &korifiv1alpha1.CFProcessList{}
 	var matches []korifiv1alpha1.CFProcess
-	for ns := range nsList {
+	for _, ns := range orderedNamespaces(nsList) {
 		if message.SpaceGUID != "" && message.SpaceGUID != ns {
 			continue
 		}
@@ -0,0 +1,68 @@
+package repositories
+
+import korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+// QuotaLimits are the limits of an org or space quota. A nil limit means
+// that the quota does not limit the resource.
+type QuotaLimits struct {
+	TotalMemoryMB         *int64
+	InstanceMemoryMB      *int64
+	TotalAppInstances     *int64
+	AppTasks              *int64
+	TotalServiceInstances *int64
+	TotalRoutes           *int64
+}
+
+// QuotaLimitPatch sets a quota limit to Value, a nil Value removes the limit
+type QuotaLimitPatch struct {
+	Value *int64
+}
+
+// QuotaLimitsPatch only updates the limits that are not nil
+type QuotaLimitsPatch struct {
+	TotalMemoryMB         *QuotaLimitPatch
+	InstanceMemoryMB      *QuotaLimitPatch
+	TotalAppInstances     *QuotaLimitPatch
+	AppTasks              *QuotaLimitPatch
+	TotalServiceInstances *QuotaLimitPatch
+	TotalRoutes           *QuotaLimitPatch
+}
+
+func (p QuotaLimitsPatch) Apply(limits *korifiv1alpha1.QuotaLimits) {
+	applyLimitPatch(&limits.TotalMemoryMB, p.TotalMemoryMB)
+	applyLimitPatch(&limits.InstanceMemoryMB, p.InstanceMemoryMB)
+	applyLimitPatch(&limits.TotalAppInstances, p.TotalAppInstances)
+	applyLimitPatch(&limits.AppTasks, p.AppTasks)
+	applyLimitPatch(&limits.TotalServiceInstances, p.TotalServiceInstances)
+	applyLimitPatch(&limits.TotalRoutes, p.TotalRoutes)
+}
+
+func applyLimitPatch(limit **int64, patch *QuotaLimitPatch) {
+	if patch == nil {
+		return
+	}
+
+	*limit = patch.Value
+}
+
+func (l QuotaLimits) toCFQuotaLimits() korifiv1alpha1.QuotaLimits {
+	return korifiv1alpha1.QuotaLimits{
+		TotalMemoryMB:         l.TotalMemoryMB,
+		InstanceMemoryMB:      l.InstanceMemoryMB,
+		TotalAppInstances:     l.TotalAppInstances,
+		AppTasks:              l.AppTasks,
+		TotalServiceInstances: l.TotalServiceInstances,
+		TotalRoutes:           l.TotalRoutes,
+	}
+}
+
+func cfQuotaLimitsToQuotaLimits(limits korifiv1alpha1.QuotaLimits) QuotaLimits {
+	return QuotaLimits{
+		TotalMemoryMB:         limits.TotalMemoryMB,
+		InstanceMemoryMB:      limits.InstanceMemoryMB,
+		TotalAppInstances:     limits.TotalAppInstances,
+		AppTasks:              limits.AppTasks,
+		TotalServiceInstances: limits.TotalServiceInstances,
+		TotalRoutes:           limits.TotalRoutes,
+	}
+}
@@ -2,8 +2,11 @@ package repositories_test
 
 import (
 	"context"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,8 +29,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/client-go/dynamic"
+	k8sclient "k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	fakerest "k8s.io/client-go/rest/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -468,3 +475,61 @@ func createAppWithGUID(space, guid string) *korifiv1alpha1.CFApp {
 
 	return cfApp
 }
+
+// logsClientFactory builds clientsets serving the given logs for every pod
+// container, as envtest does not run pods
+type logsClientFactory struct {
+	authorization.UserK8sClientFactory
+	k8sClient k8sclient.Interface
+}
+
+func newLogsClientFactory(logs string, objects ...runtime.Object) logsClientFactory {
+	return logsClientFactory{
+		UserK8sClientFactory: userClientFactory,
+		k8sClient: logsClientset{
+			Clientset: k8sfake.NewSimpleClientset(objects...),
+			logs:      logs,
+		},
+	}
+}
+
+func (f logsClientFactory) BuildK8sClient(authorization.Info) (k8sclient.Interface, error) {
+	return f.k8sClient, nil
+}
+
+type logsClientset struct {
+	*k8sfake.Clientset
+	logs string
+}
+
+func (c logsClientset) CoreV1() corev1client.CoreV1Interface {
+	return logsCoreV1{CoreV1Interface: c.Clientset.CoreV1(), logs: c.logs}
+}
+
+type logsCoreV1 struct {
+	corev1client.CoreV1Interface
+	logs string
+}
+
+func (c logsCoreV1) Pods(namespace string) corev1client.PodInterface {
+	return logsPods{PodInterface: c.CoreV1Interface.Pods(namespace), logs: c.logs}
+}
+
+type logsPods struct {
+	corev1client.PodInterface
+	logs string
+}
+
+func (p logsPods) GetLogs(string, *corev1.PodLogOptions) *rest.Request {
+	restClient := &fakerest.RESTClient{
+		Client: fakerest.CreateHTTPClient(func(*http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(p.logs)),
+			}, nil
+		}),
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+	}
+
+	return restClient.Request()
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const RevisionResourceType = "Revision"
+
+type RevisionRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
+}
+
+type RevisionRecord struct {
+	GUID        string
+	SpaceGUID   string
+	AppGUID     string
+	Version     int
+	DropletGUID string
+	Description string
+	// Processes maps the app process types to their custom commands. The
+	// command is empty when the process runs the command detected by the
+	// build.
+	Processes   map[string]string
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+type ListRevisionsMessage struct {
+	AppGUIDs []string
+	Versions []int
+}
+
+func NewRevisionRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
+) *RevisionRepo {
+	return &RevisionRepo{
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
+	}
+}
+
+func (r *RevisionRepo) GetRevision(ctx context.Context, authInfo authorization.Info, revisionGUID string) (RevisionRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, revisionGUID, RevisionResourceType)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RevisionRecord{}, fmt.Errorf("get-revision failed to create user client: %w", err)
+	}
+
+	revision := &korifiv1alpha1.CFRevision{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: revisionGUID}, revision)
+	if err != nil {
+		return RevisionRecord{}, apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	return revisionToRecord(revision), nil
+}
+
+func (r *RevisionRepo) ListRevisions(ctx context.Context, authInfo authorization.Info, message ListRevisionsMessage) ([]RevisionRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	preds := []func(RevisionRecord) bool{
+		SetPredicate(message.AppGUIDs, func(r RevisionRecord) string { return r.AppGUID }),
+		SetPredicate(message.Versions, func(r RevisionRecord) int { return r.Version }),
+	}
+
+	var revisions []korifiv1alpha1.CFRevision
+	for _, ns := range orderedNamespaces(nsList) {
+		revisionList := &korifiv1alpha1.CFRevisionList{}
+		err := listInChunks(ctx, userClient, revisionList, func(l *korifiv1alpha1.CFRevisionList) {
+			revisions = append(revisions, l.Items...)
+		}, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list revisions in namespace %s: %w", ns, apierrors.FromK8sError(err, RevisionResourceType))
+		}
+	}
+
+	records := []RevisionRecord{}
+	for i := range revisions {
+		records = append(records, revisionToRecord(&revisions[i]))
+	}
+
+	return Filter(records, preds...), nil
+}
+
+func revisionToRecord(revision *korifiv1alpha1.CFRevision) RevisionRecord {
+	processes := map[string]string{}
+	for _, p := range revision.Spec.Processes {
+		processes[p.Type] = p.Command
+	}
+
+	return RevisionRecord{
+		GUID:        revision.Name,
+		SpaceGUID:   revision.Namespace,
+		AppGUID:     revision.Spec.AppRef.Name,
+		Version:     revision.Spec.Version,
+		DropletGUID: revision.Spec.DropletRef.Name,
+		Description: revision.Spec.Description,
+		Processes:   processes,
+		Labels:      revision.Labels,
+		Annotations: revision.Annotations,
+		CreatedAt:   revision.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(revision),
+	}
+}
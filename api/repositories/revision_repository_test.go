@@ -0,0 +1,188 @@
+package repositories_test
+
+import (
+	"fmt"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RevisionRepository", func() {
+	var (
+		revisionRepo *repositories.RevisionRepo
+		cfOrg        *korifiv1alpha1.CFOrg
+		cfSpace      *korifiv1alpha1.CFSpace
+		cfApp        *korifiv1alpha1.CFApp
+	)
+
+	BeforeEach(func() {
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space1"))
+		cfApp = createApp(cfSpace.Name)
+
+		revisionRepo = repositories.NewRevisionRepo(userClientFactory, namespaceRetriever, nsPerms)
+	})
+
+	Describe("GetRevision", func() {
+		var (
+			cfRevision *korifiv1alpha1.CFRevision
+			revision   repositories.RevisionRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			cfRevision = createRevision(cfApp, 2, "the-droplet", map[string]string{"FOO": "foo"})
+		})
+
+		JustBeforeEach(func() {
+			revision, getErr = revisionRepo.GetRevision(ctx, authInfo, cfRevision.Name)
+		})
+
+		It("returns a forbidden error (as the user is not allowed to get revisions)", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("fetches the revision", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+
+				Expect(revision.GUID).To(Equal(cfRevision.Name))
+				Expect(revision.AppGUID).To(Equal(cfApp.Name))
+				Expect(revision.SpaceGUID).To(Equal(cfSpace.Name))
+				Expect(revision.Version).To(Equal(2))
+				Expect(revision.DropletGUID).To(Equal("the-droplet"))
+				Expect(revision.Description).To(Equal("Revision 2"))
+				Expect(revision.Processes).To(Equal(map[string]string{"web": "revision-command"}))
+				Expect(revision.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
+				Expect(revision.UpdatedAt).To(gstruct.PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
+			})
+		})
+
+		When("the revision does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := revisionRepo.GetRevision(ctx, authInfo, "i-do-not-exist")
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListRevisions", func() {
+		var (
+			revision1     *korifiv1alpha1.CFRevision
+			revision2     *korifiv1alpha1.CFRevision
+			otherRevision *korifiv1alpha1.CFRevision
+			message       repositories.ListRevisionsMessage
+			revisionGUIDs []string
+			listErr       error
+		)
+
+		BeforeEach(func() {
+			revision1 = createRevision(cfApp, 1, "droplet-1", nil)
+			revision2 = createRevision(cfApp, 2, "droplet-2", nil)
+			otherRevision = createRevision(createApp(cfSpace.Name), 1, "droplet-1", nil)
+
+			message = repositories.ListRevisionsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			var revisions []repositories.RevisionRecord
+			revisions, listErr = revisionRepo.ListRevisions(ctx, authInfo, message)
+
+			revisionGUIDs = nil
+			for _, r := range revisions {
+				revisionGUIDs = append(revisionGUIDs, r.GUID)
+			}
+		})
+
+		It("returns an empty list as the user is not authorized in the space", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(revisionGUIDs).To(BeEmpty())
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("lists all the revisions in the space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(revisionGUIDs).To(ConsistOf(revision1.Name, revision2.Name, otherRevision.Name))
+			})
+
+			When("filtering by app guids", func() {
+				BeforeEach(func() {
+					message.AppGUIDs = []string{cfApp.Name}
+				})
+
+				It("returns the revisions of the app", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(revisionGUIDs).To(ConsistOf(revision1.Name, revision2.Name))
+				})
+			})
+
+			When("filtering by versions", func() {
+				BeforeEach(func() {
+					message.Versions = []int{2}
+				})
+
+				It("returns the revisions with the version", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(revisionGUIDs).To(ConsistOf(revision2.Name))
+				})
+			})
+		})
+	})
+})
+
+func createRevision(cfApp *korifiv1alpha1.CFApp, version int, dropletGUID string, envVars map[string]string) *korifiv1alpha1.CFRevision {
+	GinkgoHelper()
+
+	revisionGUID := uuid.NewString()
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      revisionGUID + "-env",
+		},
+		StringData: envVars,
+	}
+	Expect(k8sClient.Create(ctx, envSecret)).To(Succeed())
+
+	cfRevision := &korifiv1alpha1.CFRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      revisionGUID,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFRevisionSpec{
+			AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+			Version:       version,
+			DropletRef:    corev1.LocalObjectReference{Name: dropletGUID},
+			EnvSecretName: envSecret.Name,
+			Processes: []korifiv1alpha1.RevisionProcess{
+				{Type: "web", Command: "revision-command"},
+			},
+			Description: fmt.Sprintf("Revision %d", version),
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfRevision)).To(Succeed())
+
+	return cfRevision
+}
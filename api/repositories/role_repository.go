@@ -237,13 +237,7 @@ func (r *RoleRepo) ListRoles(ctx context.Context, authInfo authorization.Info) (
 		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
 	}
 
-	var nsList []string
-	for k := range spaceList {
-		nsList = append(nsList, k)
-	}
-	for k := range orgList {
-		nsList = append(nsList, k)
-	}
+	nsList := append(orderedNamespaces(orgList), orderedNamespaces(spaceList)...)
 
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -8,6 +8,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
@@ -52,6 +54,7 @@ type RouteRecord struct {
 	Host         string
 	Path         string
 	Protocol     string
+	Port         *int
 	Destinations []DestinationRecord
 	Labels       map[string]string
 	Annotations  map[string]string
@@ -105,11 +108,13 @@ type ListRoutesMessage struct {
 	DomainGUIDs []string
 	Hosts       []string
 	Paths       []string
+	Pagination  Pagination
 }
 
 type CreateRouteMessage struct {
 	Host            string
 	Path            string
+	Port            int
 	SpaceGUID       string
 	DomainGUID      string
 	DomainName      string
@@ -138,7 +143,8 @@ func (m CreateRouteMessage) toCFRoute() korifiv1alpha1.CFRoute {
 		Spec: korifiv1alpha1.CFRouteSpec{
 			Host:     m.Host,
 			Path:     m.Path,
-			Protocol: "http",
+			Protocol: m.protocol(),
+			Port:     m.Port,
 			DomainRef: v1.ObjectReference{
 				Name:      m.DomainGUID,
 				Namespace: m.DomainNamespace,
@@ -147,6 +153,14 @@ func (m CreateRouteMessage) toCFRoute() korifiv1alpha1.CFRoute {
 	}
 }
 
+func (m CreateRouteMessage) protocol() korifiv1alpha1.Protocol {
+	if m.Port != 0 {
+		return korifiv1alpha1.ProtocolTCP
+	}
+
+	return korifiv1alpha1.ProtocolHTTP
+}
+
 func (r *RouteRepo) GetRoute(ctx context.Context, authInfo authorization.Info, routeGUID string) (RouteRecord, error) {
 	ns, err := r.namespaceRetriever.NamespaceFor(ctx, routeGUID, RouteResourceType)
 	if err != nil {
@@ -167,15 +181,15 @@ func (r *RouteRepo) GetRoute(ctx context.Context, authInfo authorization.Info, r
 	return cfRouteToRouteRecord(route), nil
 }
 
-func (r *RouteRepo) ListRoutes(ctx context.Context, authInfo authorization.Info, message ListRoutesMessage) ([]RouteRecord, error) {
+func (r *RouteRepo) ListRoutes(ctx context.Context, authInfo authorization.Info, message ListRoutesMessage) (ListResult[RouteRecord], error) {
 	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+		return ListResult[RouteRecord]{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
 	}
 
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return []RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
+		return ListResult[RouteRecord]{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
 	preds := []func(korifiv1alpha1.CFRoute) bool{
@@ -195,25 +209,29 @@ func (r *RouteRepo) ListRoutes(ctx context.Context, authInfo authorization.Info,
 		})
 	}
 
-	filteredRoutes := []korifiv1alpha1.CFRoute{}
+	routes := newPageCollector[korifiv1alpha1.CFRoute](message.Pagination)
 	spaceGUIDSet := NewSet(message.SpaceGUIDs...)
-	for ns := range nsList {
+	for _, ns := range orderedNamespaces(nsList) {
 		if len(spaceGUIDSet) > 0 && !spaceGUIDSet.Includes(ns) {
 			continue
 		}
 
 		cfRouteList := &korifiv1alpha1.CFRouteList{}
-		err := userClient.List(ctx, cfRouteList, client.InNamespace(ns))
+		err := listInChunks(ctx, userClient, cfRouteList, func(l *korifiv1alpha1.CFRouteList) {
+			routes.collect(Filter(l.Items, preds...))
+		}, client.InNamespace(ns))
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
 		if err != nil {
-			return []RouteRecord{}, fmt.Errorf("failed to list routes namespace %s: %w", ns, apierrors.FromK8sError(err, RouteResourceType))
+			return ListResult[RouteRecord]{}, fmt.Errorf("failed to list routes namespace %s: %w", ns, apierrors.FromK8sError(err, RouteResourceType))
 		}
-		filteredRoutes = append(filteredRoutes, Filter(cfRouteList.Items, preds...)...)
 	}
 
-	return returnRouteList(filteredRoutes), nil
+	return ListResult[RouteRecord]{
+		PageInfo: routes.pageInfo(),
+		Records:  returnRouteList(routes.items),
+	}, nil
 }
 
 func (r *RouteRepo) ListRoutesForApp(ctx context.Context, authInfo authorization.Info, appGUID string, spaceGUID string) ([]RouteRecord, error) {
@@ -260,7 +278,7 @@ func returnRouteList(routeList []korifiv1alpha1.CFRoute) []RouteRecord {
 }
 
 func cfRouteToRouteRecord(cfRoute korifiv1alpha1.CFRoute) RouteRecord {
-	return RouteRecord{
+	routeRecord := RouteRecord{
 		GUID:      cfRoute.Name,
 		SpaceGUID: cfRoute.Namespace,
 		Domain: DomainRecord{
@@ -276,6 +294,13 @@ func cfRouteToRouteRecord(cfRoute korifiv1alpha1.CFRoute) RouteRecord {
 		Labels:       cfRoute.Labels,
 		Annotations:  cfRoute.Annotations,
 	}
+
+	if cfRoute.Spec.Protocol == korifiv1alpha1.ProtocolTCP {
+		routeRecord.Protocol = string(korifiv1alpha1.ProtocolTCP)
+		routeRecord.Port = tools.PtrTo(cfRoute.Spec.Port)
+	}
+
+	return routeRecord
 }
 
 func cfRouteDestinationsToDestinationRecords(cfRoute korifiv1alpha1.CFRoute) []DestinationRecord {
@@ -321,14 +346,50 @@ func (r *RouteRepo) CreateRoute(ctx context.Context, authInfo authorization.Info
 		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	err = r.validateDomainIsAvailableInSpace(ctx, userClient, message)
+	if err != nil {
+		return RouteRecord{}, err
+	}
+
 	err = userClient.Create(ctx, &cfRoute)
 	if err != nil {
+		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == validation.DuplicateNameErrorType {
+				return RouteRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
+			}
+		}
+
 		return RouteRecord{}, apierrors.FromK8sError(err, RouteResourceType)
 	}
 
 	return cfRouteToRouteRecord(cfRoute), nil
 }
 
+// validateDomainIsAvailableInSpace checks that private domains are owned by
+// or shared with the org of the space the route is created in
+func (r *RouteRepo) validateDomainIsAvailableInSpace(ctx context.Context, userClient client.Client, message CreateRouteMessage) error {
+	cfDomain := &korifiv1alpha1.CFDomain{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: message.DomainNamespace, Name: message.DomainGUID}, cfDomain)
+	if err != nil {
+		return fmt.Errorf("failed to get domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	if cfDomain.Spec.OrganizationGUID == "" {
+		return nil
+	}
+
+	orgGUID, err := r.namespaceRetriever.NamespaceFor(ctx, message.SpaceGUID, SpaceResourceType)
+	if err != nil {
+		return err
+	}
+
+	if !isDomainAvailableInOrg(*cfDomain, orgGUID) {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Invalid domain. Domain '%s' is not available in organization '%s'.", cfDomain.Spec.Name, orgGUID))
+	}
+
+	return nil
+}
+
 func (r *RouteRepo) DeleteRoute(ctx context.Context, authInfo authorization.Info, message DeleteRouteMessage) error {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -468,11 +529,11 @@ func (r *RouteRepo) fetchRouteByFields(ctx context.Context, authInfo authorizati
 		return RouteRecord{}, false, err
 	}
 
-	if len(matches) == 0 {
+	if len(matches.Records) == 0 {
 		return RouteRecord{}, false, nil
 	}
 
-	return matches[0], true, nil
+	return matches.Records[0], true, nil
 }
 
 func destinationRecordsToCFDestinations(destinationRecords []DestinationRecord) []korifiv1alpha1.Destination {
@@ -203,6 +203,7 @@ var _ = Describe("RouteRepository", func() {
 			cfRoute2A            *korifiv1alpha1.CFRoute
 
 			routeRecords []RouteRecord
+			pageInfo     PageInfo
 			message      ListRoutesMessage
 		)
 
@@ -291,9 +292,9 @@ var _ = Describe("RouteRepository", func() {
 		})
 
 		JustBeforeEach(func() {
-			var err error
-			routeRecords, err = routeRepo.ListRoutes(ctx, authInfo, message)
+			result, err := routeRepo.ListRoutes(ctx, authInfo, message)
 			Expect(err).NotTo(HaveOccurred())
+			routeRecords, pageInfo = result.Records, result.PageInfo
 		})
 
 		It("returns an empty list as the user is not authorized", func() {
@@ -312,6 +313,18 @@ var _ = Describe("RouteRepository", func() {
 					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfRoute1B.Name)}),
 					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfRoute2A.Name)}),
 				))
+				Expect(pageInfo.TotalResults).To(Equal(3))
+			})
+
+			When("a page is requested", func() {
+				BeforeEach(func() {
+					message = ListRoutesMessage{Pagination: Pagination{Page: 2, PerPage: 2}}
+				})
+
+				It("returns the routes on that page along with the total number of routes", func() {
+					Expect(routeRecords).To(HaveLen(1))
+					Expect(pageInfo).To(Equal(PageInfo{TotalResults: 3, Page: 2, PerPage: 2}))
+				})
 			})
 
 			When("space_guid filters are provided", func() {
@@ -530,6 +543,7 @@ var _ = Describe("RouteRepository", func() {
 			createdRouteErr    error
 			routeHost          string
 			routePath          string
+			routePort          int
 			routeNamespace     string
 		)
 
@@ -537,6 +551,7 @@ var _ = Describe("RouteRepository", func() {
 			routeNamespace = space.Name
 			routeHost = prefixedGUID("route-host-")
 			routePath = prefixedGUID("/test/route/")
+			routePort = 0
 			createdRouteRecord = RouteRecord{}
 			createdRouteErr = nil
 		})
@@ -545,6 +560,7 @@ var _ = Describe("RouteRepository", func() {
 			createdRouteRecord, createdRouteErr = routeRepo.CreateRoute(ctx, authInfo, CreateRouteMessage{
 				Host:            routeHost,
 				Path:            routePath,
+				Port:            routePort,
 				SpaceGUID:       routeNamespace,
 				DomainGUID:      domainGUID,
 				DomainNamespace: rootNamespace,
@@ -585,6 +601,53 @@ var _ = Describe("RouteRepository", func() {
 				Expect(createdRouteRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
 			})
 
+			When("the domain is private to another org", func() {
+				BeforeEach(func() {
+					cfDomain := &korifiv1alpha1.CFDomain{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: domainGUID, Namespace: rootNamespace}, cfDomain)).To(Succeed())
+					Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+						cfDomain.Spec.OrganizationGUID = "another-org-guid"
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createdRouteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+
+				When("the domain is shared with the org of the space", func() {
+					BeforeEach(func() {
+						cfDomain := &korifiv1alpha1.CFDomain{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: domainGUID, Namespace: rootNamespace}, cfDomain)).To(Succeed())
+						Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+							cfDomain.Spec.SharedOrganizations = []string{org.Name}
+						})).To(Succeed())
+					})
+
+					It("creates the route", func() {
+						Expect(createdRouteErr).NotTo(HaveOccurred())
+					})
+				})
+			})
+
+			When("the route has a port", func() {
+				BeforeEach(func() {
+					routeHost = ""
+					routePath = ""
+					routePort = 1024
+				})
+
+				It("creates a tcp route", func() {
+					Expect(createdRouteErr).NotTo(HaveOccurred())
+					Expect(createdRouteRecord.Protocol).To(Equal("tcp"))
+					Expect(createdRouteRecord.Port).To(PointTo(Equal(1024)))
+
+					createdCFRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdRouteRecord.GUID, Namespace: space.Name}, createdCFRoute)).To(Succeed())
+					Expect(createdCFRoute.Spec.Protocol).To(Equal(korifiv1alpha1.ProtocolTCP))
+					Expect(createdCFRoute.Spec.Port).To(Equal(1024))
+				})
+			})
+
 			When("target namespace isn't set", func() {
 				BeforeEach(func() {
 					routeNamespace = ""
@@ -893,7 +956,7 @@ var _ = Describe("RouteRepository", func() {
 				})
 
 				It("returns an error", func() {
-					Expect(addDestinationErr.Error()).To(ContainSubstring("Unsupported value: \"bad-protocol\": supported values: \"http1\""))
+					Expect(addDestinationErr.Error()).To(ContainSubstring("Unsupported value: \"bad-protocol\": supported values: \"http1\", \"http2\""))
 				})
 			})
 
@@ -0,0 +1,454 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SecurityGroupResourceType = "Security Group"
+
+	SecurityGroupRunningWorkload = "running"
+	SecurityGroupStagingWorkload = "staging"
+)
+
+type SecurityGroupRecord struct {
+	GUID              string
+	Name              string
+	GloballyEnabled   SecurityGroupWorkloads
+	Rules             []SecurityGroupRule
+	RunningSpaceGUIDs []string
+	StagingSpaceGUIDs []string
+	CreatedAt         time.Time
+	UpdatedAt         *time.Time
+	DeletedAt         *time.Time
+}
+
+type SecurityGroupWorkloads struct {
+	Running bool
+	Staging bool
+}
+
+type SecurityGroupRule struct {
+	Protocol    string
+	Destination string
+	Ports       string
+	Type        *int32
+	Code        *int32
+	Description string
+	Log         bool
+}
+
+type CreateSecurityGroupMessage struct {
+	Name              string
+	GloballyEnabled   SecurityGroupWorkloads
+	Rules             []SecurityGroupRule
+	RunningSpaceGUIDs []string
+	StagingSpaceGUIDs []string
+}
+
+type ListSecurityGroupsMessage struct {
+	GUIDs                  []string
+	Names                  []string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      []string
+	StagingSpaceGUIDs      []string
+}
+
+type PatchSecurityGroupMessage struct {
+	GUID                   string
+	Name                   *string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	Rules                  *[]SecurityGroupRule
+}
+
+type BindSecurityGroupMessage struct {
+	GUID       string
+	Workload   string
+	SpaceGUIDs []string
+}
+
+type UnbindSecurityGroupMessage struct {
+	GUID      string
+	Workload  string
+	SpaceGUID string
+}
+
+type SecurityGroupRepo struct {
+	rootNamespace      string
+	namespaceRetriever NamespaceRetriever
+	userClientFactory  authorization.UserK8sClientFactory
+}
+
+func NewSecurityGroupRepo(
+	rootNamespace string,
+	namespaceRetriever NamespaceRetriever,
+	userClientFactory authorization.UserK8sClientFactory,
+) *SecurityGroupRepo {
+	return &SecurityGroupRepo{
+		rootNamespace:      rootNamespace,
+		namespaceRetriever: namespaceRetriever,
+		userClientFactory:  userClientFactory,
+	}
+}
+
+func (r *SecurityGroupRepo) CreateSecurityGroup(ctx context.Context, authInfo authorization.Info, message CreateSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	spaces := map[string]korifiv1alpha1.SecurityGroupWorkloads{}
+	err = r.bindSpaces(ctx, userClient, spaces, SecurityGroupRunningWorkload, message.RunningSpaceGUIDs)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+	err = r.bindSpaces(ctx, userClient, spaces, SecurityGroupStagingWorkload, message.StagingSpaceGUIDs)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFSecurityGroupSpec{
+			DisplayName: message.Name,
+			Rules:       toCFSecurityGroupRules(message.Rules),
+			GloballyEnabled: korifiv1alpha1.SecurityGroupWorkloads{
+				Running: message.GloballyEnabled.Running,
+				Staging: message.GloballyEnabled.Staging,
+			},
+			Spaces: spaces,
+		},
+	}
+
+	err = userClient.Create(ctx, cfSecurityGroup)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to create security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToSecurityGroupRecord(cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) GetSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getSecurityGroup(ctx, userClient, guid)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	return cfSecurityGroupToSecurityGroupRecord(cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) ListSecurityGroups(ctx context.Context, authInfo authorization.Info, message ListSecurityGroupsMessage) ([]SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroupList := new(korifiv1alpha1.CFSecurityGroupList)
+	err = userClient.List(ctx, cfSecurityGroupList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []SecurityGroupRecord{}, nil
+		}
+		return nil, fmt.Errorf("failed to list security groups: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	preds := []func(korifiv1alpha1.CFSecurityGroup) bool{
+		SetPredicate(message.GUIDs, func(g korifiv1alpha1.CFSecurityGroup) string { return g.Name }),
+		SetPredicate(message.Names, func(g korifiv1alpha1.CFSecurityGroup) string { return g.Spec.DisplayName }),
+		func(g korifiv1alpha1.CFSecurityGroup) bool {
+			return message.GloballyEnabledRunning == nil || *message.GloballyEnabledRunning == g.Spec.GloballyEnabled.Running
+		},
+		func(g korifiv1alpha1.CFSecurityGroup) bool {
+			return message.GloballyEnabledStaging == nil || *message.GloballyEnabledStaging == g.Spec.GloballyEnabled.Staging
+		},
+		boundSpacesPredicate(message.RunningSpaceGUIDs, func(w korifiv1alpha1.SecurityGroupWorkloads) bool { return w.Running }),
+		boundSpacesPredicate(message.StagingSpaceGUIDs, func(w korifiv1alpha1.SecurityGroupWorkloads) bool { return w.Staging }),
+	}
+
+	filtered := Filter(cfSecurityGroupList.Items, preds...)
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
+	})
+
+	records := make([]SecurityGroupRecord, 0, len(filtered))
+	for i := range filtered {
+		records = append(records, cfSecurityGroupToSecurityGroupRecord(&filtered[i]))
+	}
+
+	return records, nil
+}
+
+func boundSpacesPredicate(spaceGUIDs []string, isBound func(korifiv1alpha1.SecurityGroupWorkloads) bool) func(korifiv1alpha1.CFSecurityGroup) bool {
+	return func(g korifiv1alpha1.CFSecurityGroup) bool {
+		if len(spaceGUIDs) == 0 {
+			return true
+		}
+		for _, spaceGUID := range spaceGUIDs {
+			if isBound(g.Spec.Spaces[spaceGUID]) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (r *SecurityGroupRepo) PatchSecurityGroup(ctx context.Context, authInfo authorization.Info, message PatchSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getSecurityGroup(ctx, userClient, message.GUID)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSecurityGroup, func() {
+		if message.Name != nil {
+			cfSecurityGroup.Spec.DisplayName = *message.Name
+		}
+		if message.GloballyEnabledRunning != nil {
+			cfSecurityGroup.Spec.GloballyEnabled.Running = *message.GloballyEnabledRunning
+		}
+		if message.GloballyEnabledStaging != nil {
+			cfSecurityGroup.Spec.GloballyEnabled.Staging = *message.GloballyEnabledStaging
+		}
+		if message.Rules != nil {
+			cfSecurityGroup.Spec.Rules = toCFSecurityGroupRules(*message.Rules)
+		}
+	})
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to patch security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToSecurityGroupRecord(cfSecurityGroup), nil
+}
+
+// BindSecurityGroup binds the security group to the spaces for the given
+// workload and returns all the spaces it is bound to for that workload
+func (r *SecurityGroupRepo) BindSecurityGroup(ctx context.Context, authInfo authorization.Info, message BindSecurityGroupMessage) ([]string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getSecurityGroup(ctx, userClient, message.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	spaces := map[string]korifiv1alpha1.SecurityGroupWorkloads{}
+	for spaceGUID, workloads := range cfSecurityGroup.Spec.Spaces {
+		spaces[spaceGUID] = workloads
+	}
+
+	err = r.bindSpaces(ctx, userClient, spaces, message.Workload, message.SpaceGUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSecurityGroup, func() {
+		cfSecurityGroup.Spec.Spaces = spaces
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	record := cfSecurityGroupToSecurityGroupRecord(cfSecurityGroup)
+	if message.Workload == SecurityGroupStagingWorkload {
+		return record.StagingSpaceGUIDs, nil
+	}
+	return record.RunningSpaceGUIDs, nil
+}
+
+func (r *SecurityGroupRepo) bindSpaces(ctx context.Context, userClient client.WithWatch, spaces map[string]korifiv1alpha1.SecurityGroupWorkloads, workload string, spaceGUIDs []string) error {
+	for _, spaceGUID := range spaceGUIDs {
+		err := r.checkSpaceExists(ctx, userClient, spaceGUID)
+		if err != nil {
+			return err
+		}
+
+		workloads := spaces[spaceGUID]
+		setWorkload(&workloads, workload, true)
+		spaces[spaceGUID] = workloads
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) checkSpaceExists(ctx context.Context, userClient client.WithWatch, spaceGUID string) error {
+	notFoundDetail := fmt.Sprintf("Space with guid '%s' does not exist, or you do not have access to it.", spaceGUID)
+
+	orgNamespace, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+	if err != nil {
+		return apierrors.AsUnprocessableEntity(err, notFoundDetail, apierrors.NotFoundError{}, apierrors.ForbiddenError{})
+	}
+
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: orgNamespace, Name: spaceGUID}, new(korifiv1alpha1.CFSpace))
+	if err != nil {
+		return apierrors.AsUnprocessableEntity(
+			fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType)),
+			notFoundDetail,
+			apierrors.NotFoundError{},
+			apierrors.ForbiddenError{},
+		)
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) UnbindSecurityGroup(ctx context.Context, authInfo authorization.Info, message UnbindSecurityGroupMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getSecurityGroup(ctx, userClient, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSecurityGroup, func() {
+		workloads, ok := cfSecurityGroup.Spec.Spaces[message.SpaceGUID]
+		if !ok {
+			return
+		}
+
+		setWorkload(&workloads, message.Workload, false)
+		if workloads.Running || workloads.Staging {
+			cfSecurityGroup.Spec.Spaces[message.SpaceGUID] = workloads
+		} else {
+			delete(cfSecurityGroup.Spec.Spaces, message.SpaceGUID)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unbind security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return nil
+}
+
+func setWorkload(workloads *korifiv1alpha1.SecurityGroupWorkloads, workload string, enabled bool) {
+	if workload == SecurityGroupStagingWorkload {
+		workloads.Staging = enabled
+	} else {
+		workloads.Running = enabled
+	}
+}
+
+func (r *SecurityGroupRepo) DeleteSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Delete(ctx, &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: r.rootNamespace,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	securityGroup, err := r.GetSecurityGroup(ctx, authInfo, guid)
+	return securityGroup.DeletedAt, err
+}
+
+func (r *SecurityGroupRepo) getSecurityGroup(ctx context.Context, userClient client.WithWatch, guid string) (*korifiv1alpha1.CFSecurityGroup, error) {
+	cfSecurityGroup := new(korifiv1alpha1.CFSecurityGroup)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfSecurityGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroup, nil
+}
+
+func toCFSecurityGroupRules(rules []SecurityGroupRule) []korifiv1alpha1.SecurityGroupRule {
+	cfRules := make([]korifiv1alpha1.SecurityGroupRule, 0, len(rules))
+	for _, rule := range rules {
+		cfRules = append(cfRules, korifiv1alpha1.SecurityGroupRule{
+			Protocol:    rule.Protocol,
+			Destination: rule.Destination,
+			Ports:       rule.Ports,
+			Type:        rule.Type,
+			Code:        rule.Code,
+			Description: rule.Description,
+			Log:         rule.Log,
+		})
+	}
+	return cfRules
+}
+
+func cfSecurityGroupToSecurityGroupRecord(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) SecurityGroupRecord {
+	rules := make([]SecurityGroupRule, 0, len(cfSecurityGroup.Spec.Rules))
+	for _, rule := range cfSecurityGroup.Spec.Rules {
+		rules = append(rules, SecurityGroupRule{
+			Protocol:    rule.Protocol,
+			Destination: rule.Destination,
+			Ports:       rule.Ports,
+			Type:        rule.Type,
+			Code:        rule.Code,
+			Description: rule.Description,
+			Log:         rule.Log,
+		})
+	}
+
+	runningSpaceGUIDs := []string{}
+	stagingSpaceGUIDs := []string{}
+	for spaceGUID, workloads := range cfSecurityGroup.Spec.Spaces {
+		if workloads.Running {
+			runningSpaceGUIDs = append(runningSpaceGUIDs, spaceGUID)
+		}
+		if workloads.Staging {
+			stagingSpaceGUIDs = append(stagingSpaceGUIDs, spaceGUID)
+		}
+	}
+	slices.Sort(runningSpaceGUIDs)
+	slices.Sort(stagingSpaceGUIDs)
+
+	return SecurityGroupRecord{
+		GUID: cfSecurityGroup.Name,
+		Name: cfSecurityGroup.Spec.DisplayName,
+		GloballyEnabled: SecurityGroupWorkloads{
+			Running: cfSecurityGroup.Spec.GloballyEnabled.Running,
+			Staging: cfSecurityGroup.Spec.GloballyEnabled.Staging,
+		},
+		Rules:             rules,
+		RunningSpaceGUIDs: runningSpaceGUIDs,
+		StagingSpaceGUIDs: stagingSpaceGUIDs,
+		CreatedAt:         cfSecurityGroup.CreationTimestamp.Time,
+		UpdatedAt:         getLastUpdatedTime(cfSecurityGroup),
+		DeletedAt:         golangTime(cfSecurityGroup.DeletionTimestamp),
+	}
+}
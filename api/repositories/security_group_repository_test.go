@@ -0,0 +1,516 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SecurityGroupRepository", func() {
+	var (
+		securityGroupRepo *repositories.SecurityGroupRepo
+		cfSecurityGroup   *korifiv1alpha1.CFSecurityGroup
+		cfOrg             *korifiv1alpha1.CFOrg
+		cfSpace           *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		securityGroupRepo = repositories.NewSecurityGroupRepo(rootNamespace, namespaceRetriever, userClientFactory)
+
+		cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+
+		cfSecurityGroup = &korifiv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFSecurityGroupSpec{
+				DisplayName: "my-group",
+				Rules: []korifiv1alpha1.SecurityGroupRule{{
+					Protocol:    "tcp",
+					Destination: "10.0.0.0/8",
+					Ports:       "443",
+					Description: "internal https",
+				}},
+				Spaces: map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					cfSpace.Name: {Running: true},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSecurityGroup)).To(Succeed())
+	})
+
+	Describe("CreateSecurityGroup", func() {
+		var (
+			message       repositories.CreateSecurityGroupMessage
+			securityGroup repositories.SecurityGroupRecord
+			createErr     error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateSecurityGroupMessage{
+				Name: "new-group",
+				Rules: []repositories.SecurityGroupRule{{
+					Protocol:    "icmp",
+					Destination: "0.0.0.0/0",
+					Type:        tools.PtrTo[int32](8),
+					Code:        tools.PtrTo[int32](0),
+					Log:         true,
+				}},
+				GloballyEnabled: repositories.SecurityGroupWorkloads{Staging: true},
+			}
+		})
+
+		JustBeforeEach(func() {
+			securityGroup, createErr = securityGroupRepo.CreateSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("creates the security group", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(securityGroup.Name).To(Equal("new-group"))
+				Expect(securityGroup.GloballyEnabled).To(Equal(repositories.SecurityGroupWorkloads{Staging: true}))
+				Expect(securityGroup.Rules).To(Equal(message.Rules))
+				Expect(securityGroup.RunningSpaceGUIDs).To(BeEmpty())
+				Expect(securityGroup.StagingSpaceGUIDs).To(BeEmpty())
+
+				createdGroup := new(korifiv1alpha1.CFSecurityGroup)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: securityGroup.GUID}, createdGroup)).To(Succeed())
+				Expect(createdGroup.Spec.DisplayName).To(Equal("new-group"))
+				Expect(createdGroup.Spec.GloballyEnabled).To(Equal(korifiv1alpha1.SecurityGroupWorkloads{Staging: true}))
+				Expect(createdGroup.Spec.Rules).To(ConsistOf(korifiv1alpha1.SecurityGroupRule{
+					Protocol:    "icmp",
+					Destination: "0.0.0.0/0",
+					Type:        tools.PtrTo[int32](8),
+					Code:        tools.PtrTo[int32](0),
+					Log:         true,
+				}))
+			})
+
+			When("spaces are bound to the security group", func() {
+				BeforeEach(func() {
+					message.RunningSpaceGUIDs = []string{cfSpace.Name}
+					message.StagingSpaceGUIDs = []string{cfSpace.Name}
+				})
+
+				It("binds the spaces for both workloads", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(securityGroup.RunningSpaceGUIDs).To(Equal([]string{cfSpace.Name}))
+					Expect(securityGroup.StagingSpaceGUIDs).To(Equal([]string{cfSpace.Name}))
+
+					createdGroup := new(korifiv1alpha1.CFSecurityGroup)
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: securityGroup.GUID}, createdGroup)).To(Succeed())
+					Expect(createdGroup.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+						cfSpace.Name: {Running: true, Staging: true},
+					}))
+				})
+			})
+
+			When("a space does not exist", func() {
+				BeforeEach(func() {
+					message.RunningSpaceGUIDs = []string{"i-do-not-exist"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(createErr.(apierrors.UnprocessableEntityError).Detail()).To(
+						Equal("Space with guid 'i-do-not-exist' does not exist, or you do not have access to it."),
+					)
+				})
+			})
+		})
+	})
+
+	Describe("GetSecurityGroup", func() {
+		var (
+			guid          string
+			securityGroup repositories.SecurityGroupRecord
+			getErr        error
+		)
+
+		BeforeEach(func() {
+			guid = cfSecurityGroup.Name
+		})
+
+		JustBeforeEach(func() {
+			securityGroup, getErr = securityGroupRepo.GetSecurityGroup(ctx, authInfo, guid)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the security group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(securityGroup.GUID).To(Equal(cfSecurityGroup.Name))
+				Expect(securityGroup.Name).To(Equal("my-group"))
+				Expect(securityGroup.GloballyEnabled).To(Equal(repositories.SecurityGroupWorkloads{}))
+				Expect(securityGroup.Rules).To(ConsistOf(repositories.SecurityGroupRule{
+					Protocol:    "tcp",
+					Destination: "10.0.0.0/8",
+					Ports:       "443",
+					Description: "internal https",
+				}))
+				Expect(securityGroup.RunningSpaceGUIDs).To(Equal([]string{cfSpace.Name}))
+				Expect(securityGroup.StagingSpaceGUIDs).To(BeEmpty())
+				Expect(securityGroup.DeletedAt).To(BeNil())
+			})
+
+			When("the security group does not exist", func() {
+				BeforeEach(func() {
+					guid = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListSecurityGroups", func() {
+		var (
+			message        repositories.ListSecurityGroupsMessage
+			securityGroups []repositories.SecurityGroupRecord
+			listErr        error
+			globalGroup    *korifiv1alpha1.CFSecurityGroup
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListSecurityGroupsMessage{}
+
+			globalGroup = &korifiv1alpha1.CFSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFSecurityGroupSpec{
+					DisplayName:     "global-group",
+					GloballyEnabled: korifiv1alpha1.SecurityGroupWorkloads{Running: true, Staging: true},
+					Spaces: map[string]korifiv1alpha1.SecurityGroupWorkloads{
+						cfSpace.Name: {Staging: true},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, globalGroup)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			securityGroups, listErr = securityGroupRepo.ListSecurityGroups(ctx, authInfo, message)
+		})
+
+		It("returns an empty list", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(securityGroups).To(BeEmpty())
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("lists the security groups", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(securityGroups).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":              Equal(cfSecurityGroup.Name),
+						"Name":              Equal("my-group"),
+						"RunningSpaceGUIDs": Equal([]string{cfSpace.Name}),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":              Equal(globalGroup.Name),
+						"Name":              Equal("global-group"),
+						"GloballyEnabled":   Equal(repositories.SecurityGroupWorkloads{Running: true, Staging: true}),
+						"StagingSpaceGUIDs": Equal([]string{cfSpace.Name}),
+					}),
+				))
+			})
+
+			When("filtering by guids", func() {
+				BeforeEach(func() {
+					message.GUIDs = []string{globalGroup.Name}
+				})
+
+				It("returns the security groups with the guids", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(securityGroups).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(globalGroup.Name)}),
+					))
+				})
+			})
+
+			When("filtering by names", func() {
+				BeforeEach(func() {
+					message.Names = []string{"my-group"}
+				})
+
+				It("returns the security groups with the names", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(securityGroups).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfSecurityGroup.Name)}),
+					))
+				})
+			})
+
+			When("filtering by globally enabled running", func() {
+				BeforeEach(func() {
+					message.GloballyEnabledRunning = tools.PtrTo(true)
+				})
+
+				It("returns the security groups enabled for running workloads", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(securityGroups).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(globalGroup.Name)}),
+					))
+				})
+			})
+
+			When("filtering by globally disabled staging", func() {
+				BeforeEach(func() {
+					message.GloballyEnabledStaging = tools.PtrTo(false)
+				})
+
+				It("returns the security groups not enabled for staging workloads", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(securityGroups).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfSecurityGroup.Name)}),
+					))
+				})
+			})
+
+			When("filtering by running space guids", func() {
+				BeforeEach(func() {
+					message.RunningSpaceGUIDs = []string{cfSpace.Name}
+				})
+
+				It("returns the security groups bound to the spaces for running workloads", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(securityGroups).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfSecurityGroup.Name)}),
+					))
+				})
+			})
+
+			When("filtering by staging space guids", func() {
+				BeforeEach(func() {
+					message.StagingSpaceGUIDs = []string{cfSpace.Name}
+				})
+
+				It("returns the security groups bound to the spaces for staging workloads", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(securityGroups).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(globalGroup.Name)}),
+					))
+				})
+			})
+		})
+	})
+
+	Describe("BindSecurityGroup", func() {
+		var (
+			message    repositories.BindSecurityGroupMessage
+			otherSpace *korifiv1alpha1.CFSpace
+			bound      []string
+			bindErr    error
+		)
+
+		BeforeEach(func() {
+			otherSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+
+			message = repositories.BindSecurityGroupMessage{
+				GUID:       cfSecurityGroup.Name,
+				Workload:   repositories.SecurityGroupRunningWorkload,
+				SpaceGUIDs: []string{otherSpace.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			bound, bindErr = securityGroupRepo.BindSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(bindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("binds the space for running workloads", func() {
+				Expect(bindErr).NotTo(HaveOccurred())
+				Expect(bound).To(ConsistOf(cfSpace.Name, otherSpace.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+				Expect(cfSecurityGroup.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					cfSpace.Name:    {Running: true},
+					otherSpace.Name: {Running: true},
+				}))
+			})
+
+			When("binding the space for staging workloads", func() {
+				BeforeEach(func() {
+					message.Workload = repositories.SecurityGroupStagingWorkload
+					message.SpaceGUIDs = []string{cfSpace.Name}
+				})
+
+				It("binds the space for staging workloads and keeps its running binding", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					Expect(bound).To(Equal([]string{cfSpace.Name}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+					Expect(cfSecurityGroup.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+						cfSpace.Name: {Running: true, Staging: true},
+					}))
+				})
+			})
+
+			When("the space is already bound", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{cfSpace.Name}
+				})
+
+				It("does not bind it twice", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					Expect(bound).To(Equal([]string{cfSpace.Name}))
+				})
+			})
+
+			When("the space does not exist", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{"i-do-not-exist"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(bindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(bindErr.(apierrors.UnprocessableEntityError).Detail()).To(
+						Equal("Space with guid 'i-do-not-exist' does not exist, or you do not have access to it."),
+					)
+				})
+
+				It("does not change the bound spaces", func() {
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+					Expect(cfSecurityGroup.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+						cfSpace.Name: {Running: true},
+					}))
+				})
+			})
+
+			When("the security group does not exist", func() {
+				BeforeEach(func() {
+					message.GUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(bindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("UnbindSecurityGroup", func() {
+		var (
+			message   repositories.UnbindSecurityGroupMessage
+			unbindErr error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.Spaces[cfSpace.Name] = korifiv1alpha1.SecurityGroupWorkloads{Running: true, Staging: true}
+			Expect(k8sClient.Update(ctx, cfSecurityGroup)).To(Succeed())
+
+			message = repositories.UnbindSecurityGroupMessage{
+				GUID:      cfSecurityGroup.Name,
+				Workload:  repositories.SecurityGroupRunningWorkload,
+				SpaceGUID: cfSpace.Name,
+			}
+		})
+
+		JustBeforeEach(func() {
+			unbindErr = securityGroupRepo.UnbindSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(unbindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("unbinds the space for running workloads only", func() {
+				Expect(unbindErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+				Expect(cfSecurityGroup.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					cfSpace.Name: {Staging: true},
+				}))
+			})
+
+			When("the space is only bound for staging workloads", func() {
+				BeforeEach(func() {
+					cfSecurityGroup.Spec.Spaces[cfSpace.Name] = korifiv1alpha1.SecurityGroupWorkloads{Staging: true}
+					Expect(k8sClient.Update(ctx, cfSecurityGroup)).To(Succeed())
+
+					message.Workload = repositories.SecurityGroupStagingWorkload
+				})
+
+				It("removes the space from the security group", func() {
+					Expect(unbindErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+					Expect(cfSecurityGroup.Spec.Spaces).To(BeEmpty())
+				})
+			})
+
+			When("the space is not bound", func() {
+				BeforeEach(func() {
+					message.SpaceGUID = "not-bound"
+				})
+
+				It("leaves the bound spaces unchanged", func() {
+					Expect(unbindErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+					Expect(cfSecurityGroup.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+						cfSpace.Name: {Running: true, Staging: true},
+					}))
+				})
+			})
+
+			When("the security group does not exist", func() {
+				BeforeEach(func() {
+					message.GUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(unbindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+})
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services/bindings"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -26,6 +27,7 @@ const (
 	LabelServiceBindingProvisionedService = "servicebinding.io/provisioned-service"
 	ServiceBindingResourceType            = "Service Binding"
 	ServiceBindingTypeApp                 = "app"
+	ServiceBindingTypeKey                 = "key"
 )
 
 type ServiceBindingRepo struct {
@@ -71,7 +73,12 @@ type ServiceBindingLastOperation struct {
 	UpdatedAt   *time.Time
 }
 
+type ServiceBindingDetailsRecord struct {
+	Credentials map[string]any
+}
+
 type CreateServiceBindingMessage struct {
+	Type                string
 	Name                *string
 	ServiceInstanceGUID string
 	AppGUID             string
@@ -83,9 +90,11 @@ type DeleteServiceBindingMessage struct {
 }
 
 type ListServiceBindingsMessage struct {
+	Types                []string
 	AppGUIDs             []string
 	ServiceInstanceGUIDs []string
 	LabelSelector        string
+	Pagination           Pagination
 }
 
 func (m CreateServiceBindingMessage) toCFServiceBinding() *korifiv1alpha1.CFServiceBinding {
@@ -97,6 +106,7 @@ func (m CreateServiceBindingMessage) toCFServiceBinding() *korifiv1alpha1.CFServ
 			Labels:    map[string]string{LabelServiceBindingProvisionedService: "true"},
 		},
 		Spec: korifiv1alpha1.CFServiceBindingSpec{
+			Type:        m.Type,
 			DisplayName: m.Name,
 			Service: corev1.ObjectReference{
 				Kind:       "CFServiceInstance",
@@ -121,16 +131,18 @@ func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo
 
 	cfServiceBinding := message.toCFServiceBinding()
 
-	cfApp := new(korifiv1alpha1.CFApp)
-	err = userClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
-	if err != nil {
-		return ServiceBindingRecord{},
-			apierrors.AsUnprocessableEntity(
-				apierrors.FromK8sError(err, ServiceBindingResourceType),
-				"Unable to use app. Ensure that the app exists and you have access to it.",
-				apierrors.ForbiddenError{},
-				apierrors.NotFoundError{},
-			)
+	if !cfServiceBinding.IsKey() {
+		cfApp := new(korifiv1alpha1.CFApp)
+		err = userClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
+		if err != nil {
+			return ServiceBindingRecord{},
+				apierrors.AsUnprocessableEntity(
+					apierrors.FromK8sError(err, ServiceBindingResourceType),
+					"Unable to use app. Ensure that the app exists and you have access to it.",
+					apierrors.ForbiddenError{},
+					apierrors.NotFoundError{},
+				)
+		}
 	}
 
 	err = userClient.Create(ctx, cfServiceBinding)
@@ -170,6 +182,14 @@ func (r *ServiceBindingRepo) DeleteServiceBinding(ctx context.Context, authInfo
 		return apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, ServiceBindingResourceType))
 	}
 
+	if isDeletionFailed(binding, binding.Status.LastOperation) {
+		err = retryDeletion(ctx, userClient, binding)
+		if err != nil {
+			return apierrors.FromK8sError(err, ServiceBindingResourceType)
+		}
+		return nil
+	}
+
 	err = userClient.Delete(ctx, binding)
 	if err != nil {
 		return apierrors.FromK8sError(err, ServiceBindingResourceType)
@@ -197,6 +217,43 @@ func (r *ServiceBindingRepo) GetServiceBinding(ctx context.Context, authInfo aut
 	return cfServiceBindingToRecord(serviceBinding), nil
 }
 
+// GetServiceBindingDetails returns the credentials of the binding. Bindings
+// whose credentials are not available yet are reported as not found
+func (r *ServiceBindingRepo) GetServiceBindingDetails(ctx context.Context, authInfo authorization.Info, guid string) (ServiceBindingDetailsRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceBindingResourceType)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, fmt.Errorf("get-service-binding-details failed to create user client: %w", err)
+	}
+
+	serviceBinding := &korifiv1alpha1.CFServiceBinding{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, serviceBinding)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
+	}
+
+	if serviceBinding.Status.Credentials.Name == "" {
+		return ServiceBindingDetailsRecord{}, apierrors.NewNotFoundError(nil, ServiceBindingResourceType)
+	}
+
+	credentialsSecret := &corev1.Secret{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: serviceBinding.Status.Credentials.Name}, credentialsSecret)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, fmt.Errorf("failed to get binding credentials secret: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	creds, err := credentials.GetCredentials(credentialsSecret)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, fmt.Errorf("failed to read binding credentials: %w", err)
+	}
+
+	return ServiceBindingDetailsRecord{Credentials: creds}, nil
+}
+
 func (r *ServiceBindingRepo) UpdateServiceBinding(ctx context.Context, authInfo authorization.Info, updateMsg UpdateServiceBindingMessage) (ServiceBindingRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -230,10 +287,20 @@ func (r *ServiceBindingRepo) UpdateServiceBinding(ctx context.Context, authInfo
 	return cfServiceBindingToRecord(serviceBinding), nil
 }
 
+// serviceBindingType treats bindings created before key bindings were
+// supported, which have no type, as app bindings
+func serviceBindingType(binding korifiv1alpha1.CFServiceBinding) string {
+	if binding.IsKey() {
+		return ServiceBindingTypeKey
+	}
+
+	return ServiceBindingTypeApp
+}
+
 func cfServiceBindingToRecord(binding *korifiv1alpha1.CFServiceBinding) ServiceBindingRecord {
 	return ServiceBindingRecord{
 		GUID:                binding.Name,
-		Type:                ServiceBindingTypeApp,
+		Type:                serviceBindingType(*binding),
 		Name:                binding.Spec.DisplayName,
 		AppGUID:             binding.Spec.AppRef.Name,
 		ServiceInstanceGUID: binding.Spec.Service.Name,
@@ -253,44 +320,49 @@ func cfServiceBindingToRecord(binding *korifiv1alpha1.CFServiceBinding) ServiceB
 }
 
 // nolint:dupl
-func (r *ServiceBindingRepo) ListServiceBindings(ctx context.Context, authInfo authorization.Info, message ListServiceBindingsMessage) ([]ServiceBindingRecord, error) {
+func (r *ServiceBindingRepo) ListServiceBindings(ctx context.Context, authInfo authorization.Info, message ListServiceBindingsMessage) (ListResult[ServiceBindingRecord], error) {
 	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+		return ListResult[ServiceBindingRecord]{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
 	}
 
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return []ServiceBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+		return ListResult[ServiceBindingRecord]{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
 	preds := []func(korifiv1alpha1.CFServiceBinding) bool{
 		SetPredicate(message.ServiceInstanceGUIDs, func(s korifiv1alpha1.CFServiceBinding) string { return s.Spec.Service.Name }),
 		SetPredicate(message.AppGUIDs, func(s korifiv1alpha1.CFServiceBinding) string { return s.Spec.AppRef.Name }),
+		SetPredicate(message.Types, serviceBindingType),
 	}
 
 	labelSelector, err := labels.Parse(message.LabelSelector)
 	if err != nil {
-		return []ServiceBindingRecord{}, apierrors.NewUnprocessableEntityError(err, "invalid label selector")
+		return ListResult[ServiceBindingRecord]{}, apierrors.NewUnprocessableEntityError(err, "invalid label selector")
 	}
 
-	var filteredServiceBindings []korifiv1alpha1.CFServiceBinding
-	for ns := range nsList {
+	serviceBindings := newPageCollector[korifiv1alpha1.CFServiceBinding](message.Pagination)
+	for _, ns := range orderedNamespaces(nsList) {
 		serviceBindingList := new(korifiv1alpha1.CFServiceBindingList)
-		err = userClient.List(ctx, serviceBindingList, client.InNamespace(ns), &client.ListOptions{LabelSelector: labelSelector})
+		err = listInChunks(ctx, userClient, serviceBindingList, func(l *korifiv1alpha1.CFServiceBindingList) {
+			serviceBindings.collect(Filter(l.Items, preds...))
+		}, client.InNamespace(ns), &client.ListOptions{LabelSelector: labelSelector})
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
 		if err != nil {
-			return []ServiceBindingRecord{}, fmt.Errorf("failed to list service instances in namespace %s: %w",
+			return ListResult[ServiceBindingRecord]{}, fmt.Errorf("failed to list service instances in namespace %s: %w",
 				ns,
 				apierrors.FromK8sError(err, ServiceBindingResourceType),
 			)
 		}
-		filteredServiceBindings = append(filteredServiceBindings, Filter(serviceBindingList.Items, preds...)...)
 	}
 
-	return toServiceBindingRecords(filteredServiceBindings), nil
+	return ListResult[ServiceBindingRecord]{
+		PageInfo: serviceBindings.pageInfo(),
+		Records:  toServiceBindingRecords(serviceBindings.items),
+	}, nil
 }
 
 func toServiceBindingRecords(serviceBindings []korifiv1alpha1.CFServiceBinding) []ServiceBindingRecord {
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -80,8 +81,13 @@ var _ = Describe("ServiceBindingRepo", func() {
 		var (
 			serviceBindingRecord repositories.ServiceBindingRecord
 			createErr            error
+			bindingType          string
+			bindingAppGUID       string
 		)
 		BeforeEach(func() {
+			bindingType = repositories.ServiceBindingTypeApp
+			bindingAppGUID = appGUID
+
 			conditionAwaiter.AwaitConditionStub = func(ctx context.Context, _ client.WithWatch, object client.Object, _ string) (*korifiv1alpha1.CFServiceBinding, error) {
 				cfServiceBinding, ok := object.(*korifiv1alpha1.CFServiceBinding)
 				Expect(ok).To(BeTrue())
@@ -104,9 +110,10 @@ var _ = Describe("ServiceBindingRepo", func() {
 
 		JustBeforeEach(func() {
 			serviceBindingRecord, createErr = repo.CreateServiceBinding(testCtx, authInfo, repositories.CreateServiceBindingMessage{
+				Type:                bindingType,
 				Name:                bindingName,
 				ServiceInstanceGUID: serviceInstanceGUID,
-				AppGUID:             appGUID,
+				AppGUID:             bindingAppGUID,
 				SpaceGUID:           space.Name,
 			})
 		})
@@ -146,6 +153,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 				Expect(serviceBinding.Labels).To(HaveKeyWithValue("servicebinding.io/provisioned-service", "true"))
 				Expect(serviceBinding.Spec).To(Equal(
 					korifiv1alpha1.CFServiceBindingSpec{
+						Type:        "app",
 						DisplayName: nil,
 						Service: corev1.ObjectReference{
 							Kind:       "CFServiceInstance",
@@ -184,7 +192,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 
 			When("the app does not exist", func() {
 				BeforeEach(func() {
-					appGUID = "i-do-not-exits"
+					bindingAppGUID = "i-do-not-exits"
 				})
 
 				It("reuturns an UnprocessableEntity error", func() {
@@ -202,6 +210,28 @@ var _ = Describe("ServiceBindingRepo", func() {
 					Expect(serviceBindingRecord.Name).To(Equal(bindingName))
 				})
 			})
+
+			When("the binding is a key", func() {
+				BeforeEach(func() {
+					bindingType = repositories.ServiceBindingTypeKey
+					bindingAppGUID = ""
+					bindingName = tools.PtrTo("my-key")
+				})
+
+				It("creates a key binding that does not reference an app", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(serviceBindingRecord.Type).To(Equal("key"))
+					Expect(serviceBindingRecord.Name).To(Equal(tools.PtrTo("my-key")))
+					Expect(serviceBindingRecord.AppGUID).To(BeEmpty())
+
+					serviceBinding := new(korifiv1alpha1.CFServiceBinding)
+					Expect(
+						k8sClient.Get(testCtx, types.NamespacedName{Name: serviceBindingRecord.GUID, Namespace: space.Name}, serviceBinding),
+					).To(Succeed())
+					Expect(serviceBinding.Spec.Type).To(Equal(korifiv1alpha1.CFServiceBindingTypeKey))
+					Expect(serviceBinding.Spec.AppRef.Name).To(BeEmpty())
+				})
+			})
 		})
 	})
 
@@ -209,6 +239,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 		var (
 			ret                error
 			serviceBindingGUID string
+			serviceBinding     *korifiv1alpha1.CFServiceBinding
 		)
 
 		BeforeEach(func() {
@@ -228,7 +259,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 				k8sClient.Create(testCtx, serviceInstance),
 			).To(Succeed())
 
-			serviceBinding := &korifiv1alpha1.CFServiceBinding{
+			serviceBinding = &korifiv1alpha1.CFServiceBinding{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      serviceBindingGUID,
 					Namespace: space.Name,
@@ -276,6 +307,34 @@ var _ = Describe("ServiceBindingRepo", func() {
 				Expect(ret).NotTo(HaveOccurred())
 			})
 
+			When("the broker has failed to delete the binding", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(testCtx, k8sClient, serviceBinding, func() {
+						serviceBinding.Finalizers = []string{korifiv1alpha1.CFServiceBindingFinalizerName}
+					})).To(Succeed())
+					DeferCleanup(func() {
+						Expect(k8s.PatchResource(testCtx, k8sClient, serviceBinding, func() {
+							serviceBinding.Finalizers = nil
+						})).To(Succeed())
+					})
+
+					Expect(k8sClient.Delete(testCtx, serviceBinding)).To(Succeed())
+					Expect(k8s.Patch(testCtx, k8sClient, serviceBinding, func() {
+						serviceBinding.Status.LastOperation = &services.LastOperation{
+							Type:  services.LastOperationTypeDelete,
+							State: services.LastOperationStateFailed,
+						}
+					})).To(Succeed())
+				})
+
+				It("requests the deletion to be retried", func() {
+					Expect(ret).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(serviceBinding), serviceBinding)).To(Succeed())
+					Expect(serviceBinding.Annotations).To(HaveKey(korifiv1alpha1.RetryDeletionAnnotation))
+				})
+			})
+
 			When("the binding doesn't exist", func() {
 				BeforeEach(func() {
 					serviceBindingGUID = "something-that-does-not-match"
@@ -297,6 +356,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 
 			requestMessage          repositories.ListServiceBindingsMessage
 			responseServiceBindings []repositories.ServiceBindingRecord
+			responsePageInfo        repositories.PageInfo
 			listErr                 error
 		)
 
@@ -322,7 +382,9 @@ var _ = Describe("ServiceBindingRepo", func() {
 		})
 
 		JustBeforeEach(func() {
-			responseServiceBindings, listErr = repo.ListServiceBindings(context.Background(), authInfo, requestMessage)
+			var result repositories.ListResult[repositories.ServiceBindingRecord]
+			result, listErr = repo.ListServiceBindings(context.Background(), authInfo, requestMessage)
+			responseServiceBindings, responsePageInfo = result.Records, result.PageInfo
 		})
 
 		When("the user has access to both namespaces", func() {
@@ -378,6 +440,19 @@ var _ = Describe("ServiceBindingRepo", func() {
 				})
 			})
 
+			When("a page is requested", func() {
+				BeforeEach(func() {
+					requestMessage = repositories.ListServiceBindingsMessage{
+						Pagination: repositories.Pagination{Page: 1, PerPage: 2},
+					}
+				})
+
+				It("returns the service bindings on that page along with the total number of bindings", func() {
+					Expect(responseServiceBindings).To(HaveLen(2))
+					Expect(responsePageInfo).To(Equal(repositories.PageInfo{TotalResults: 3, Page: 1, PerPage: 2}))
+				})
+			})
+
 			When("filtered by service instance GUID", func() {
 				BeforeEach(func() {
 					requestMessage = repositories.ListServiceBindingsMessage{
@@ -444,7 +519,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 							matchers = append(matchers, MatchFields(IgnoreExtras, Fields{"GUID": HavePrefix(prefix)}))
 						}
 
-						Expect(serviceBindings).To(ConsistOf(matchers...))
+						Expect(serviceBindings.Records).To(ConsistOf(matchers...))
 					},
 					Entry("key", "foo", "binding-1", "binding-2"),
 					Entry("!key", "!foo", "binding-3"),
@@ -566,6 +641,68 @@ var _ = Describe("ServiceBindingRepo", func() {
 		})
 	})
 
+	Describe("GetServiceBindingDetails", func() {
+		var (
+			serviceBindingGUID string
+			details            repositories.ServiceBindingDetailsRecord
+			getErr             error
+		)
+
+		BeforeEach(func() {
+			serviceBindingGUID = prefixedGUID("binding")
+
+			credentialsSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      prefixedGUID("credentials"),
+					Namespace: space.Name,
+				},
+				Data: map[string][]byte{
+					korifiv1alpha1.CredentialsSecretKey: []byte(`{"username":"admin"}`),
+				},
+			}
+			Expect(k8sClient.Create(testCtx, credentialsSecret)).To(Succeed())
+
+			serviceBinding := &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceBindingGUID,
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type:        korifiv1alpha1.CFServiceBindingTypeKey,
+					DisplayName: tools.PtrTo("my-key"),
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+						Name:       serviceInstanceGUID,
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, serviceBinding)).To(Succeed())
+			Expect(k8s.Patch(testCtx, k8sClient, serviceBinding, func() {
+				serviceBinding.Status.Credentials.Name = credentialsSecret.Name
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			details, getErr = repo.GetServiceBindingDetails(ctx, authInfo, serviceBindingGUID)
+		})
+
+		It("returns a forbidden error as no user bindings are in place", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the binding credentials", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(details.Credentials).To(Equal(map[string]any{"username": "admin"}))
+			})
+		})
+	})
+
 	Describe("UpdateServiceBinding", func() {
 		var (
 			serviceBinding        *korifiv1alpha1.CFServiceBinding
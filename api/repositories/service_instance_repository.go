@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -10,6 +11,8 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
@@ -17,6 +20,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -57,6 +61,8 @@ type CreateServiceInstanceMessage struct {
 	SpaceGUID   string
 	Credentials map[string]any
 	Type        string
+	PlanGUID    string
+	Parameters  map[string]any
 	Tags        []string
 	Labels      map[string]string
 	Annotations map[string]string
@@ -94,16 +100,19 @@ type DeleteServiceInstanceMessage struct {
 }
 
 type ServiceInstanceRecord struct {
-	Name        string
-	GUID        string
-	SpaceGUID   string
-	SecretName  string
-	Tags        []string
-	Type        string
-	Labels      map[string]string
-	Annotations map[string]string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
+	Name          string
+	GUID          string
+	SpaceGUID     string
+	SecretName    string
+	Tags          []string
+	Type          string
+	PlanGUID      string
+	LastOperation services.LastOperation
+	Labels        map[string]string
+	Annotations   map[string]string
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+	DeletedAt     *time.Time
 }
 
 func (r *ServiceInstanceRepo) CreateServiceInstance(ctx context.Context, authInfo authorization.Info, message CreateServiceInstanceMessage) (ServiceInstanceRecord, error) {
@@ -113,12 +122,20 @@ func (r *ServiceInstanceRepo) CreateServiceInstance(ctx context.Context, authInf
 		return ServiceInstanceRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
-	cfServiceInstance := message.toCFServiceInstance()
+	cfServiceInstance, err := message.toCFServiceInstance()
+	if err != nil {
+		return ServiceInstanceRecord{}, err
+	}
+
 	err = userClient.Create(ctx, cfServiceInstance)
 	if err != nil {
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
+	if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		return cfServiceInstanceToServiceInstanceRecord(cfServiceInstance), nil
+	}
+
 	err = r.createCredentialsSecret(ctx, userClient, cfServiceInstance, message.Credentials)
 	if err != nil {
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
@@ -252,13 +269,15 @@ func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo
 
 	spaceGUIDSet := NewSet(message.SpaceGUIDs...)
 	var filteredServiceInstances []korifiv1alpha1.CFServiceInstance
-	for ns := range nsList {
+	for _, ns := range orderedNamespaces(nsList) {
 		if len(spaceGUIDSet) > 0 && !spaceGUIDSet.Includes(ns) {
 			continue
 		}
 
 		serviceInstanceList := new(korifiv1alpha1.CFServiceInstanceList)
-		err = userClient.List(ctx, serviceInstanceList, client.InNamespace(ns), &client.ListOptions{LabelSelector: labelSelector})
+		err = listInChunks(ctx, userClient, serviceInstanceList, func(l *korifiv1alpha1.CFServiceInstanceList) {
+			filteredServiceInstances = append(filteredServiceInstances, Filter(l.Items, preds...)...)
+		}, client.InNamespace(ns), &client.ListOptions{LabelSelector: labelSelector})
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
@@ -268,7 +287,6 @@ func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo
 				apierrors.FromK8sError(err, ServiceInstanceResourceType),
 			)
 		}
-		filteredServiceInstances = append(filteredServiceInstances, Filter(serviceInstanceList.Items, preds...)...)
 	}
 
 	return returnServiceInstanceList(filteredServiceInstances), nil
@@ -299,11 +317,18 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 		return fmt.Errorf("failed to build user client: %w", err)
 	}
 
-	serviceInstance := &korifiv1alpha1.CFServiceInstance{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      message.GUID,
-			Namespace: message.SpaceGUID,
-		},
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, serviceInstance)
+	if err != nil {
+		return fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	if isDeletionFailed(serviceInstance, serviceInstance.Status.LastOperation) {
+		err = retryDeletion(ctx, userClient, serviceInstance)
+		if err != nil {
+			return fmt.Errorf("failed to retry deleting service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+		}
+		return nil
 	}
 
 	if err := userClient.Delete(ctx, serviceInstance); err != nil {
@@ -313,9 +338,59 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 	return nil
 }
 
-func (m CreateServiceInstanceMessage) toCFServiceInstance() *korifiv1alpha1.CFServiceInstance {
+// isDeletionFailed tells whether the broker has failed to delete an object
+// that is being deleted
+func isDeletionFailed(obj client.Object, lastOperation *services.LastOperation) bool {
+	return !obj.GetDeletionTimestamp().IsZero() &&
+		lastOperation != nil &&
+		lastOperation.Type == services.LastOperationTypeDelete &&
+		lastOperation.State == services.LastOperationStateFailed
+}
+
+// retryDeletion asks the controllers to send the failed delete request to the
+// broker again
+func retryDeletion[T any, PT k8s.ObjectWithDeepCopy[T]](ctx context.Context, userClient client.Client, obj PT) error {
+	return k8s.PatchResource(ctx, userClient, obj, func() {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[korifiv1alpha1.RetryDeletionAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		obj.SetAnnotations(annotations)
+	})
+}
+
+func (r *ServiceInstanceRepo) GetState(ctx context.Context, authInfo authorization.Info, guid string) (model.CFResourceState, error) {
+	serviceInstance, err := r.GetServiceInstance(ctx, authInfo, guid)
+	if err != nil {
+		return model.CFResourceState{}, err
+	}
+
+	switch serviceInstance.LastOperation.State {
+	case services.LastOperationStateSucceeded:
+		return model.CFResourceState{Status: model.CFResourceStatusReady}, nil
+	case services.LastOperationStateFailed:
+		return model.CFResourceState{
+			Status:  model.CFResourceStatusFailed,
+			Details: serviceInstance.LastOperation.Description,
+		}, nil
+	default:
+		return model.CFResourceState{}, nil
+	}
+}
+
+func (r *ServiceInstanceRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	serviceInstance, err := r.GetServiceInstance(ctx, authInfo, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	return serviceInstance.DeletedAt, nil
+}
+
+func (m CreateServiceInstanceMessage) toCFServiceInstance() (*korifiv1alpha1.CFServiceInstance, error) {
 	guid := uuid.NewString()
-	return &korifiv1alpha1.CFServiceInstance{
+	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        guid,
 			Namespace:   m.SpaceGUID,
@@ -324,25 +399,48 @@ func (m CreateServiceInstanceMessage) toCFServiceInstance() *korifiv1alpha1.CFSe
 		},
 		Spec: korifiv1alpha1.CFServiceInstanceSpec{
 			DisplayName: m.Name,
-			SecretName:  guid,
 			Type:        korifiv1alpha1.InstanceType(m.Type),
 			Tags:        m.Tags,
 		},
 	}
+
+	if cfServiceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		cfServiceInstance.Spec.SecretName = guid
+		return cfServiceInstance, nil
+	}
+
+	cfServiceInstance.Spec.PlanGUID = m.PlanGUID
+	if m.Parameters != nil {
+		rawParameters, err := json.Marshal(m.Parameters)
+		if err != nil {
+			return nil, apierrors.NewUnprocessableEntityError(err, "invalid service instance parameters")
+		}
+		cfServiceInstance.Spec.Parameters = &runtime.RawExtension{Raw: rawParameters}
+	}
+
+	return cfServiceInstance, nil
 }
 
 func cfServiceInstanceToServiceInstanceRecord(cfServiceInstance *korifiv1alpha1.CFServiceInstance) ServiceInstanceRecord {
+	var lastOperation services.LastOperation
+	if cfServiceInstance.Status.LastOperation != nil {
+		lastOperation = *cfServiceInstance.Status.LastOperation
+	}
+
 	return ServiceInstanceRecord{
-		Name:        cfServiceInstance.Spec.DisplayName,
-		GUID:        cfServiceInstance.Name,
-		SpaceGUID:   cfServiceInstance.Namespace,
-		SecretName:  cfServiceInstance.Spec.SecretName,
-		Tags:        cfServiceInstance.Spec.Tags,
-		Type:        string(cfServiceInstance.Spec.Type),
-		Labels:      cfServiceInstance.Labels,
-		Annotations: cfServiceInstance.Annotations,
-		CreatedAt:   cfServiceInstance.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(cfServiceInstance),
+		Name:          cfServiceInstance.Spec.DisplayName,
+		GUID:          cfServiceInstance.Name,
+		SpaceGUID:     cfServiceInstance.Namespace,
+		SecretName:    cfServiceInstance.Spec.SecretName,
+		Tags:          cfServiceInstance.Spec.Tags,
+		Type:          string(cfServiceInstance.Spec.Type),
+		PlanGUID:      cfServiceInstance.Spec.PlanGUID,
+		LastOperation: lastOperation,
+		Labels:        cfServiceInstance.Labels,
+		Annotations:   cfServiceInstance.Annotations,
+		CreatedAt:     cfServiceInstance.CreationTimestamp.Time,
+		UpdatedAt:     getLastUpdatedTime(cfServiceInstance),
+		DeletedAt:     golangTime(cfServiceInstance.DeletionTimestamp),
 	}
 }
 
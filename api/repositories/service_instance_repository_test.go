@@ -11,6 +11,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -113,6 +115,40 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			})
 		})
 
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+
+				serviceInstanceCreateMessage.Type = korifiv1alpha1.ManagedType
+				serviceInstanceCreateMessage.Credentials = nil
+				serviceInstanceCreateMessage.PlanGUID = "plan-guid"
+				serviceInstanceCreateMessage.Parameters = map[string]any{
+					"foo": "bar",
+				}
+			})
+
+			It("creates a managed ServiceInstance CR", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(createdServiceInstanceRecord.Type).To(Equal(korifiv1alpha1.ManagedType))
+				Expect(createdServiceInstanceRecord.PlanGUID).To(Equal("plan-guid"))
+				Expect(createdServiceInstanceRecord.SecretName).To(BeEmpty())
+
+				cfServiceInstance := &korifiv1alpha1.CFServiceInstance{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: space.Name, Name: createdServiceInstanceRecord.GUID}, cfServiceInstance)).To(Succeed())
+				Expect(cfServiceInstance.Spec.PlanGUID).To(Equal("plan-guid"))
+				Expect(cfServiceInstance.Spec.Parameters).NotTo(BeNil())
+				Expect(cfServiceInstance.Spec.Parameters.Raw).To(MatchJSON(`{"foo":"bar"}`))
+			})
+
+			It("does not create a credentials secret", func() {
+				secrets := &corev1.SecretList{}
+				Expect(k8sClient.List(ctx, secrets, client.InNamespace(space.Name), client.MatchingLabels{
+					repositories.CFServiceInstanceGUIDLabel: createdServiceInstanceRecord.GUID,
+				})).To(Succeed())
+				Expect(secrets.Items).To(BeEmpty())
+			})
+		})
+
 		When("user does not have permissions to create ServiceInstances", func() {
 			It("returns a Forbidden error", func() {
 				Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
@@ -563,6 +599,101 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
+	Describe("GetState", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			state           model.CFResourceState
+			stateErr        error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			serviceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+		})
+
+		JustBeforeEach(func() {
+			state, stateErr = serviceInstanceRepo.GetState(testCtx, authInfo, serviceInstance.Name)
+		})
+
+		It("returns unknown state", func() {
+			Expect(stateErr).NotTo(HaveOccurred())
+			Expect(state).To(Equal(model.CFResourceState{}))
+		})
+
+		When("the last operation has succeeded", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, serviceInstance, func() {
+					serviceInstance.Status.LastOperation = &services.LastOperation{
+						Type:  "create",
+						State: "succeeded",
+					}
+				})).To(Succeed())
+			})
+
+			It("returns ready state", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(model.CFResourceState{Status: model.CFResourceStatusReady}))
+			})
+		})
+
+		When("the last operation has failed", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, serviceInstance, func() {
+					serviceInstance.Status.LastOperation = &services.LastOperation{
+						Type:        "create",
+						State:       "failed",
+						Description: "broker said no",
+					}
+				})).To(Succeed())
+			})
+
+			It("returns failed state", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(model.CFResourceState{
+					Status:  model.CFResourceStatusFailed,
+					Details: "broker said no",
+				}))
+			})
+		})
+	})
+
+	Describe("GetDeletedAt", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			deletedAt       *time.Time
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			serviceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+		})
+
+		JustBeforeEach(func() {
+			deletedAt, getErr = serviceInstanceRepo.GetDeletedAt(testCtx, authInfo, serviceInstance.Name)
+		})
+
+		It("returns nil", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(deletedAt).To(BeNil())
+		})
+
+		When("the service instance is being deleted", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+					serviceInstance.Finalizers = append(serviceInstance.Finalizers, "kubernetes")
+				})).To(Succeed())
+
+				Expect(k8sClient.Delete(ctx, serviceInstance)).To(Succeed())
+			})
+
+			It("returns the deletion time", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(deletedAt).To(PointTo(BeTemporally("~", time.Now(), time.Minute)))
+			})
+		})
+	})
+
 	Describe("DeleteServiceInstance", func() {
 		var (
 			serviceInstance *korifiv1alpha1.CFServiceInstance
@@ -600,6 +731,34 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(k8serrors.IsNotFound(err)).To(BeTrue(), fmt.Sprintf("error: %+v", err))
 			})
 
+			When("the broker has failed to delete the service instance", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(testCtx, k8sClient, serviceInstance, func() {
+						serviceInstance.Finalizers = []string{korifiv1alpha1.CFServiceInstanceFinalizerName}
+					})).To(Succeed())
+					DeferCleanup(func() {
+						Expect(k8s.PatchResource(testCtx, k8sClient, serviceInstance, func() {
+							serviceInstance.Finalizers = nil
+						})).To(Succeed())
+					})
+
+					Expect(k8sClient.Delete(testCtx, serviceInstance)).To(Succeed())
+					Expect(k8s.Patch(testCtx, k8sClient, serviceInstance, func() {
+						serviceInstance.Status.LastOperation = &services.LastOperation{
+							Type:  services.LastOperationTypeDelete,
+							State: services.LastOperationStateFailed,
+						}
+					})).To(Succeed())
+				})
+
+				It("requests the deletion to be retried", func() {
+					Expect(deleteErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Annotations).To(HaveKey(korifiv1alpha1.RetryDeletionAnnotation))
+				})
+			})
+
 			When("the service instances does not exist", func() {
 				BeforeEach(func() {
 					deleteMessage.GUID = "does-not-exist"
@@ -9,6 +9,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/model/services"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -52,25 +53,44 @@ func (r *ServicePlanRepo) ListPlans(ctx context.Context, authInfo authorization.
 
 	var result []ServicePlanResource
 	for _, plan := range cfServicePlans.Items {
-		result = append(result, ServicePlanResource{
-			ServicePlan: plan.Spec.ServicePlan,
-			CFResource: model.CFResource{
-				GUID:      plan.Name,
-				CreatedAt: plan.CreationTimestamp.Time,
-				Metadata: model.Metadata{
-					Labels:      plan.Labels,
-					Annotations: plan.Annotations,
-				},
+		result = append(result, toServicePlanResource(plan))
+	}
+
+	return result, nil
+}
+
+func (r *ServicePlanRepo) GetPlan(ctx context.Context, authInfo authorization.Info, planGUID string) (ServicePlanResource, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServicePlanResource{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServicePlan := &korifiv1alpha1.CFServicePlan{}
+	err = userClient.Get(ctx, types.NamespacedName{Namespace: r.rootNamespace, Name: planGUID}, cfServicePlan)
+	if err != nil {
+		return ServicePlanResource{}, apierrors.FromK8sError(err, ServicePlanResourceType)
+	}
+
+	return toServicePlanResource(*cfServicePlan), nil
+}
+
+func toServicePlanResource(plan korifiv1alpha1.CFServicePlan) ServicePlanResource {
+	return ServicePlanResource{
+		ServicePlan: plan.Spec.ServicePlan,
+		CFResource: model.CFResource{
+			GUID:      plan.Name,
+			CreatedAt: plan.CreationTimestamp.Time,
+			Metadata: model.Metadata{
+				Labels:      plan.Labels,
+				Annotations: plan.Annotations,
 			},
-			Relationships: ServicePlanRelationships{
-				ServiceOffering: model.ToOneRelationship{
-					Data: model.Relationship{
-						GUID: plan.Labels[korifiv1alpha1.RelServiceOfferingLabel],
-					},
+		},
+		Relationships: ServicePlanRelationships{
+			ServiceOffering: model.ToOneRelationship{
+				Data: model.Relationship{
+					GUID: plan.Labels[korifiv1alpha1.RelServiceOfferingLabel],
 				},
 			},
-		})
+		},
 	}
-
-	return result, nil
 }
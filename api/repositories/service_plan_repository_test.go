@@ -1,6 +1,7 @@
 package repositories_test
 
 import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/model"
@@ -149,4 +150,56 @@ var _ = Describe("ServicePlanRepo", func() {
 			})))
 		})
 	})
+
+	Describe("Get", func() {
+		var (
+			planGUID string
+			plan     repositories.ServicePlanResource
+			getErr   error
+		)
+
+		BeforeEach(func() {
+			planGUID = uuid.NewString()
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      planGUID,
+					Labels: map[string]string{
+						korifiv1alpha1.RelServiceOfferingLabel: "offering-guid",
+					},
+				},
+				Spec: korifiv1alpha1.CFServicePlanSpec{
+					ServicePlan: services.ServicePlan{
+						BrokerServicePlan: services.BrokerServicePlan{
+							Name: "my-service-plan",
+							BrokerCatalog: services.ServicePlanBrokerCatalog{
+								ID: "broker-plan-guid",
+							},
+						},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			plan, getErr = repo.GetPlan(ctx, authInfo, planGUID)
+		})
+
+		It("gets the service plan", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(plan.GUID).To(Equal(planGUID))
+			Expect(plan.Name).To(Equal("my-service-plan"))
+			Expect(plan.Relationships.ServiceOffering.Data.GUID).To(Equal("offering-guid"))
+		})
+
+		When("the plan does not exist", func() {
+			BeforeEach(func() {
+				planGUID = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
 })
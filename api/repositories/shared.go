@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"context"
+	"slices"
 	"time"
 
+	"golang.org/x/exp/maps"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -17,6 +19,97 @@ type RepositoryCreator interface {
 	CreateRepository(ctx context.Context, name string) error
 }
 
+// listChunkSize is the maximum number of objects requested from the
+// Kubernetes API in a single list call
+const listChunkSize = 500
+
+// listInChunks lists objects using the Kubernetes Limit/Continue mechanism
+// and calls onChunk for every chunk received. The list object is reused
+// between chunks, so callers should only retain the items they need.
+func listInChunks[L client.ObjectList](ctx context.Context, k8sClient client.Reader, list L, onChunk func(L), opts ...client.ListOption) error {
+	continueToken := ""
+	for {
+		listOpts := append([]client.ListOption{client.Limit(listChunkSize), client.Continue(continueToken)}, opts...)
+		if err := k8sClient.List(ctx, list, listOpts...); err != nil {
+			return err
+		}
+
+		onChunk(list)
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
+
+// Pagination selects a page of a list. The zero value selects the whole list.
+type Pagination struct {
+	Page    int
+	PerPage int
+}
+
+// PageInfo describes the page of a list returned by a repository
+type PageInfo struct {
+	TotalResults int
+	Page         int
+	PerPage      int
+}
+
+// ListResult holds a page of records along with the number of records across
+// all pages
+type ListResult[T any] struct {
+	PageInfo PageInfo
+	Records  []T
+}
+
+// pageCollector counts all the items it is given but only retains the ones
+// that belong to the requested page. Combined with listInChunks this keeps
+// at most a chunk and a page of objects in memory while listing.
+type pageCollector[T any] struct {
+	pagination Pagination
+	total      int
+	items      []T
+}
+
+func newPageCollector[T any](pagination Pagination) *pageCollector[T] {
+	return &pageCollector[T]{pagination: pagination}
+}
+
+func (c *pageCollector[T]) collect(items []T) {
+	for _, item := range items {
+		if c.inPage(c.total) {
+			c.items = append(c.items, item)
+		}
+		c.total++
+	}
+}
+
+func (c *pageCollector[T]) inPage(index int) bool {
+	if c.pagination.PerPage < 1 {
+		return true
+	}
+
+	start := (max(c.pagination.Page, 1) - 1) * c.pagination.PerPage
+	return index >= start && index < start+c.pagination.PerPage
+}
+
+func (c *pageCollector[T]) pageInfo() PageInfo {
+	if c.pagination.PerPage < 1 {
+		return PageInfo{TotalResults: c.total, Page: 1, PerPage: c.total}
+	}
+
+	return PageInfo{TotalResults: c.total, Page: max(c.pagination.Page, 1), PerPage: c.pagination.PerPage}
+}
+
+// orderedNamespaces returns the namespaces sorted by name, so that lists
+// spanning several namespaces come back in the same order on every request
+func orderedNamespaces(nsList map[string]bool) []string {
+	namespaces := maps.Keys(nsList)
+	slices.Sort(namespaces)
+	return namespaces
+}
+
 type Awaiter[T runtime.Object] interface {
 	AwaitCondition(context.Context, client.WithWatch, client.Object, string) (T, error)
 	AwaitState(context.Context, client.WithWatch, client.Object, func(T) error) (T, error)
@@ -0,0 +1,297 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SidecarResourceType = "Sidecar"
+	SidecarOriginUser   = "user"
+)
+
+// SidecarRepo manages the sidecars of apps. Sidecars are not resources of
+// their own, they are stored in the spec of the CFApp they belong to
+type SidecarRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+}
+
+func NewSidecarRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+) *SidecarRepo {
+	return &SidecarRepo{
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+	}
+}
+
+type SidecarRecord struct {
+	GUID         string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     *int64
+	Origin       string
+	AppGUID      string
+	SpaceGUID    string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+}
+
+type CreateSidecarMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     *int64
+}
+
+type PatchSidecarMessage struct {
+	GUID         string
+	AppGUID      string
+	SpaceGUID    string
+	Name         *string
+	Command      *string
+	ProcessTypes []string
+	MemoryMB     *int64
+}
+
+func (m PatchSidecarMessage) apply(sidecar *korifiv1alpha1.Sidecar) {
+	if m.Name != nil {
+		sidecar.Name = *m.Name
+	}
+	if m.Command != nil {
+		sidecar.Command = *m.Command
+	}
+	if m.ProcessTypes != nil {
+		sidecar.ProcessTypes = m.ProcessTypes
+	}
+	if m.MemoryMB != nil {
+		sidecar.MemoryMB = *m.MemoryMB
+	}
+}
+
+type DeleteSidecarMessage struct {
+	GUID      string
+	AppGUID   string
+	SpaceGUID string
+}
+
+type ListSidecarsMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	ProcessTypes []string
+}
+
+func (r *SidecarRepo) CreateSidecar(ctx context.Context, authInfo authorization.Info, message CreateSidecarMessage) (SidecarRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+	if err != nil {
+		return SidecarRecord{}, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	sidecar := korifiv1alpha1.Sidecar{
+		GUID:         uuid.NewString(),
+		Name:         message.Name,
+		Command:      message.Command,
+		ProcessTypes: message.ProcessTypes,
+	}
+	if message.MemoryMB != nil {
+		sidecar.MemoryMB = *message.MemoryMB
+	}
+
+	if err = validateSidecarNameIsUnique(cfApp, sidecar); err != nil {
+		return SidecarRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		cfApp.Spec.Sidecars = append(cfApp.Spec.Sidecars, sidecar)
+	})
+	if err != nil {
+		return SidecarRecord{}, apierrors.FromK8sError(err, SidecarResourceType)
+	}
+
+	return sidecarToSidecarRecord(*cfApp, sidecar), nil
+}
+
+func (r *SidecarRepo) GetSidecar(ctx context.Context, authInfo authorization.Info, guid string) (SidecarRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	for _, ns := range orderedNamespaces(nsList) {
+		appList := &korifiv1alpha1.CFAppList{}
+		err = userClient.List(ctx, appList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return SidecarRecord{}, fmt.Errorf("failed to list apps in namespace %s: %w", ns, apierrors.FromK8sError(err, AppResourceType))
+		}
+
+		for _, cfApp := range appList.Items {
+			for _, sidecar := range cfApp.Spec.Sidecars {
+				if sidecar.GUID == guid {
+					return sidecarToSidecarRecord(cfApp, sidecar), nil
+				}
+			}
+		}
+	}
+
+	return SidecarRecord{}, apierrors.NewNotFoundError(fmt.Errorf("sidecar %q not found", guid), SidecarResourceType)
+}
+
+func (r *SidecarRepo) ListSidecars(ctx context.Context, authInfo authorization.Info, message ListSidecarsMessage) ([]SidecarRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	sidecars := Filter(cfApp.Spec.Sidecars, func(s korifiv1alpha1.Sidecar) bool {
+		if len(message.ProcessTypes) == 0 {
+			return true
+		}
+
+		for _, processType := range message.ProcessTypes {
+			if slices.Contains(s.ProcessTypes, processType) {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	sidecarRecords := make([]SidecarRecord, 0, len(sidecars))
+	for _, sidecar := range sidecars {
+		sidecarRecords = append(sidecarRecords, sidecarToSidecarRecord(*cfApp, sidecar))
+	}
+
+	sort.Slice(sidecarRecords, func(i, j int) bool {
+		return sidecarRecords[i].Name < sidecarRecords[j].Name
+	})
+
+	return sidecarRecords, nil
+}
+
+func (r *SidecarRepo) PatchSidecar(ctx context.Context, authInfo authorization.Info, message PatchSidecarMessage) (SidecarRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+	if err != nil {
+		return SidecarRecord{}, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	index := slices.IndexFunc(cfApp.Spec.Sidecars, func(s korifiv1alpha1.Sidecar) bool { return s.GUID == message.GUID })
+	if index < 0 {
+		return SidecarRecord{}, apierrors.NewNotFoundError(fmt.Errorf("sidecar %q not found", message.GUID), SidecarResourceType)
+	}
+
+	sidecar := *cfApp.Spec.Sidecars[index].DeepCopy()
+	message.apply(&sidecar)
+
+	if err = validateSidecarNameIsUnique(cfApp, sidecar); err != nil {
+		return SidecarRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		cfApp.Spec.Sidecars[index] = sidecar
+	})
+	if err != nil {
+		return SidecarRecord{}, apierrors.FromK8sError(err, SidecarResourceType)
+	}
+
+	return sidecarToSidecarRecord(*cfApp, sidecar), nil
+}
+
+func (r *SidecarRepo) DeleteSidecar(ctx context.Context, authInfo authorization.Info, message DeleteSidecarMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+	if err != nil {
+		return apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		cfApp.Spec.Sidecars = slices.DeleteFunc(cfApp.Spec.Sidecars, func(s korifiv1alpha1.Sidecar) bool { return s.GUID == message.GUID })
+	})
+	if err != nil {
+		return apierrors.FromK8sError(err, SidecarResourceType)
+	}
+
+	return nil
+}
+
+func validateSidecarNameIsUnique(cfApp *korifiv1alpha1.CFApp, sidecar korifiv1alpha1.Sidecar) error {
+	for _, s := range cfApp.Spec.Sidecars {
+		if s.GUID != sidecar.GUID && s.Name == sidecar.Name {
+			return apierrors.NewUniquenessError(
+				fmt.Errorf("sidecar %q already exists for app %q", sidecar.Name, cfApp.Name),
+				fmt.Sprintf("Sidecar with name '%s' already exists for given app", sidecar.Name),
+			)
+		}
+	}
+
+	return nil
+}
+
+// sidecarToSidecarRecord converts a sidecar of the app into a record. Sidecars
+// are part of the app spec, so they share the timestamps of the app
+func sidecarToSidecarRecord(cfApp korifiv1alpha1.CFApp, sidecar korifiv1alpha1.Sidecar) SidecarRecord {
+	record := SidecarRecord{
+		GUID:         sidecar.GUID,
+		Name:         sidecar.Name,
+		Command:      sidecar.Command,
+		ProcessTypes: sidecar.ProcessTypes,
+		Origin:       SidecarOriginUser,
+		AppGUID:      cfApp.Name,
+		SpaceGUID:    cfApp.Namespace,
+		CreatedAt:    cfApp.CreationTimestamp.Time,
+		UpdatedAt:    getLastUpdatedTime(&cfApp),
+	}
+	if sidecar.MemoryMB > 0 {
+		record.MemoryMB = tools.PtrTo(sidecar.MemoryMB)
+	}
+
+	return record
+}
@@ -0,0 +1,279 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SidecarRepository", func() {
+	var (
+		sidecarRepo *SidecarRepo
+		cfSpace     *korifiv1alpha1.CFSpace
+		cfApp       *korifiv1alpha1.CFApp
+	)
+
+	BeforeEach(func() {
+		sidecarRepo = NewSidecarRepo(userClientFactory, nsPerms)
+
+		cfOrg := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		cfApp = createApp(cfSpace.Name)
+
+		Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+			cfApp.Spec.Sidecars = []korifiv1alpha1.Sidecar{
+				{
+					GUID:         "log-shipper-guid",
+					Name:         "log-shipper",
+					Command:      "ship-logs",
+					ProcessTypes: []string{"web"},
+					MemoryMB:     64,
+				},
+				{
+					GUID:         "config-agent-guid",
+					Name:         "config-agent",
+					Command:      "watch-config",
+					ProcessTypes: []string{"worker"},
+				},
+			}
+		})).To(Succeed())
+	})
+
+	Describe("CreateSidecar", func() {
+		var (
+			message   CreateSidecarMessage
+			sidecar   SidecarRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = CreateSidecarMessage{
+				AppGUID:      cfApp.Name,
+				SpaceGUID:    cfSpace.Name,
+				Name:         "new-sidecar",
+				Command:      "run-sidecar",
+				ProcessTypes: []string{"web", "worker"},
+				MemoryMB:     tools.PtrTo[int64](128),
+			}
+		})
+
+		JustBeforeEach(func() {
+			sidecar, createErr = sidecarRepo.CreateSidecar(ctx, authInfo, message)
+		})
+
+		It("returns a not found error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("adds the sidecar to the app", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(sidecar.GUID).NotTo(BeEmpty())
+				Expect(sidecar.Name).To(Equal("new-sidecar"))
+				Expect(sidecar.Command).To(Equal("run-sidecar"))
+				Expect(sidecar.ProcessTypes).To(Equal([]string{"web", "worker"}))
+				Expect(sidecar.MemoryMB).To(PointTo(BeEquivalentTo(128)))
+				Expect(sidecar.Origin).To(Equal("user"))
+				Expect(sidecar.AppGUID).To(Equal(cfApp.Name))
+				Expect(sidecar.SpaceGUID).To(Equal(cfSpace.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Sidecars).To(ContainElement(korifiv1alpha1.Sidecar{
+					GUID:         sidecar.GUID,
+					Name:         "new-sidecar",
+					Command:      "run-sidecar",
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     128,
+				}))
+			})
+
+			When("the app already has a sidecar with the same name", func() {
+				BeforeEach(func() {
+					message.Name = "log-shipper"
+				})
+
+				It("returns a uniqueness error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UniquenessError{}))
+				})
+			})
+		})
+
+		When("the user is a space manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceManagerRole.Name, cfSpace.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("GetSidecar", func() {
+		var (
+			sidecar SidecarRecord
+			getErr  error
+		)
+
+		JustBeforeEach(func() {
+			sidecar, getErr = sidecarRepo.GetSidecar(ctx, authInfo, "log-shipper-guid")
+		})
+
+		It("returns a not found error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the sidecar", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(sidecar).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":         Equal("log-shipper-guid"),
+					"Name":         Equal("log-shipper"),
+					"Command":      Equal("ship-logs"),
+					"ProcessTypes": Equal([]string{"web"}),
+					"MemoryMB":     PointTo(BeEquivalentTo(64)),
+					"AppGUID":      Equal(cfApp.Name),
+					"SpaceGUID":    Equal(cfSpace.Name),
+				}))
+			})
+		})
+	})
+
+	Describe("ListSidecars", func() {
+		var (
+			message  ListSidecarsMessage
+			sidecars []SidecarRecord
+			listErr  error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			message = ListSidecarsMessage{
+				AppGUID:   cfApp.Name,
+				SpaceGUID: cfSpace.Name,
+			}
+		})
+
+		JustBeforeEach(func() {
+			sidecars, listErr = sidecarRepo.ListSidecars(ctx, authInfo, message)
+		})
+
+		It("returns the app sidecars sorted by name", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(sidecars).To(HaveLen(2))
+			Expect(sidecars[0].Name).To(Equal("config-agent"))
+			Expect(sidecars[0].MemoryMB).To(BeNil())
+			Expect(sidecars[1].Name).To(Equal("log-shipper"))
+		})
+
+		When("filtering by process type", func() {
+			BeforeEach(func() {
+				message.ProcessTypes = []string{"worker"}
+			})
+
+			It("returns the sidecars of the process type only", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(sidecars).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"Name": Equal("config-agent")})))
+			})
+		})
+	})
+
+	Describe("PatchSidecar", func() {
+		var (
+			message  PatchSidecarMessage
+			sidecar  SidecarRecord
+			patchErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			message = PatchSidecarMessage{
+				GUID:      "config-agent-guid",
+				AppGUID:   cfApp.Name,
+				SpaceGUID: cfSpace.Name,
+				Command:   tools.PtrTo("watch-config --verbose"),
+				MemoryMB:  tools.PtrTo[int64](32),
+			}
+		})
+
+		JustBeforeEach(func() {
+			sidecar, patchErr = sidecarRepo.PatchSidecar(ctx, authInfo, message)
+		})
+
+		It("updates the sidecar", func() {
+			Expect(patchErr).NotTo(HaveOccurred())
+			Expect(sidecar.Name).To(Equal("config-agent"))
+			Expect(sidecar.Command).To(Equal("watch-config --verbose"))
+			Expect(sidecar.ProcessTypes).To(Equal([]string{"worker"}))
+			Expect(sidecar.MemoryMB).To(PointTo(BeEquivalentTo(32)))
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+			Expect(cfApp.Spec.Sidecars).To(ContainElement(korifiv1alpha1.Sidecar{
+				GUID:         "config-agent-guid",
+				Name:         "config-agent",
+				Command:      "watch-config --verbose",
+				ProcessTypes: []string{"worker"},
+				MemoryMB:     32,
+			}))
+		})
+
+		When("the sidecar is renamed to the name of another sidecar", func() {
+			BeforeEach(func() {
+				message.Name = tools.PtrTo("log-shipper")
+			})
+
+			It("returns a uniqueness error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UniquenessError{}))
+			})
+		})
+
+		When("the sidecar does not exist", func() {
+			BeforeEach(func() {
+				message.GUID = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("DeleteSidecar", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = sidecarRepo.DeleteSidecar(ctx, authInfo, DeleteSidecarMessage{
+				GUID:      "log-shipper-guid",
+				AppGUID:   cfApp.Name,
+				SpaceGUID: cfSpace.Name,
+			})
+		})
+
+		It("removes the sidecar from the app", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+			Expect(cfApp.Spec.Sidecars).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal("config-agent-guid")})))
+		})
+	})
+})
@@ -0,0 +1,385 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SpaceQuotaResourceType = "Space Quota"
+)
+
+type SpaceQuotaRecord struct {
+	GUID             string
+	Name             string
+	Limits           QuotaLimits
+	OrganizationGUID string
+	SpaceGUIDs       []string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+	DeletedAt        *time.Time
+}
+
+type CreateSpaceQuotaMessage struct {
+	Name             string
+	Limits           QuotaLimits
+	OrganizationGUID string
+	SpaceGUIDs       []string
+}
+
+type ListSpaceQuotasMessage struct {
+	GUIDs             []string
+	Names             []string
+	OrganizationGUIDs []string
+	SpaceGUIDs        []string
+}
+
+type PatchSpaceQuotaMessage struct {
+	GUID   string
+	Name   *string
+	Limits QuotaLimitsPatch
+}
+
+type ApplySpaceQuotaMessage struct {
+	GUID       string
+	SpaceGUIDs []string
+}
+
+type RemoveSpaceQuotaMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
+type SpaceQuotaRepo struct {
+	namespaceRetriever NamespaceRetriever
+	orgRepo            *OrgRepo
+	userClientFactory  authorization.UserK8sClientFactory
+	nsPerms            *authorization.NamespacePermissions
+}
+
+func NewSpaceQuotaRepo(
+	namespaceRetriever NamespaceRetriever,
+	orgRepo *OrgRepo,
+	userClientFactory authorization.UserK8sClientFactory,
+	nsPerms *authorization.NamespacePermissions,
+) *SpaceQuotaRepo {
+	return &SpaceQuotaRepo{
+		namespaceRetriever: namespaceRetriever,
+		orgRepo:            orgRepo,
+		userClientFactory:  userClientFactory,
+		nsPerms:            nsPerms,
+	}
+}
+
+func (r *SpaceQuotaRepo) CreateSpaceQuota(ctx context.Context, authInfo authorization.Info, message CreateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	_, err := r.orgRepo.GetOrg(ctx, authInfo, message.OrganizationGUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to get parent organization: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: message.OrganizationGUID,
+		},
+		Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+			DisplayName: message.Name,
+			Limits:      message.Limits.toCFQuotaLimits(),
+		},
+	}
+
+	err = userClient.Create(ctx, cfSpaceQuota)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to create space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	spaceGUIDs, err := r.applySpaceQuota(ctx, userClient, cfSpaceQuota, message.SpaceGUIDs)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return cfSpaceQuotaToSpaceQuotaRecord(cfSpaceQuota, spaceGUIDs), nil
+}
+
+func (r *SpaceQuotaRepo) GetSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getSpaceQuota(ctx, userClient, guid)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	quotaSpaces, err := r.listQuotaSpaceGUIDs(ctx, userClient, cfSpaceQuota.Namespace)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return cfSpaceQuotaToSpaceQuotaRecord(cfSpaceQuota, quotaSpaces[guid]), nil
+}
+
+func (r *SpaceQuotaRepo) ListSpaceQuotas(ctx context.Context, authInfo authorization.Info, message ListSpaceQuotasMessage) ([]SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	authorizedOrgNamespaces, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	spaceGUIDs := NewSet(message.SpaceGUIDs...)
+	orgGUIDs := NewSet(message.OrganizationGUIDs...)
+	records := []SpaceQuotaRecord{}
+	for org := range authorizedOrgNamespaces {
+		if len(orgGUIDs) > 0 && !orgGUIDs.Includes(org) {
+			continue
+		}
+
+		cfSpaceQuotaList := new(korifiv1alpha1.CFSpaceQuotaList)
+		err = userClient.List(ctx, cfSpaceQuotaList, client.InNamespace(org))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list space quotas: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+		}
+
+		quotaSpaces, err := r.listQuotaSpaceGUIDs(ctx, userClient, org)
+		if err != nil {
+			return nil, err
+		}
+
+		preds := []func(korifiv1alpha1.CFSpaceQuota) bool{
+			SetPredicate(message.GUIDs, func(q korifiv1alpha1.CFSpaceQuota) string { return q.Name }),
+			SetPredicate(message.Names, func(q korifiv1alpha1.CFSpaceQuota) string { return q.Spec.DisplayName }),
+			func(q korifiv1alpha1.CFSpaceQuota) bool {
+				if len(spaceGUIDs) == 0 {
+					return true
+				}
+				for _, spaceGUID := range quotaSpaces[q.Name] {
+					if spaceGUIDs.Includes(spaceGUID) {
+						return true
+					}
+				}
+				return false
+			},
+		}
+
+		filtered := Filter(cfSpaceQuotaList.Items, preds...)
+		for i := range filtered {
+			records = append(records, cfSpaceQuotaToSpaceQuotaRecord(&filtered[i], quotaSpaces[filtered[i].Name]))
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+
+	return records, nil
+}
+
+func (r *SpaceQuotaRepo) PatchSpaceQuota(ctx context.Context, authInfo authorization.Info, message PatchSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getSpaceQuota(ctx, userClient, message.GUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpaceQuota, func() {
+		if message.Name != nil {
+			cfSpaceQuota.Spec.DisplayName = *message.Name
+		}
+		message.Limits.Apply(&cfSpaceQuota.Spec.Limits)
+	})
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to patch space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	quotaSpaces, err := r.listQuotaSpaceGUIDs(ctx, userClient, cfSpaceQuota.Namespace)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return cfSpaceQuotaToSpaceQuotaRecord(cfSpaceQuota, quotaSpaces[message.GUID]), nil
+}
+
+// ApplySpaceQuota sets the quota on the spaces and returns all the spaces the quota is applied to
+func (r *SpaceQuotaRepo) ApplySpaceQuota(ctx context.Context, authInfo authorization.Info, message ApplySpaceQuotaMessage) ([]string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getSpaceQuota(ctx, userClient, message.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.applySpaceQuota(ctx, userClient, cfSpaceQuota, message.SpaceGUIDs)
+}
+
+func (r *SpaceQuotaRepo) applySpaceQuota(ctx context.Context, userClient client.WithWatch, cfSpaceQuota *korifiv1alpha1.CFSpaceQuota, spaceGUIDs []string) ([]string, error) {
+	for _, spaceGUID := range spaceGUIDs {
+		cfSpace := new(korifiv1alpha1.CFSpace)
+		err := userClient.Get(ctx, client.ObjectKey{Namespace: cfSpaceQuota.Namespace, Name: spaceGUID}, cfSpace)
+		if err != nil {
+			if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+				return nil, apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Space with guid '%s' does not exist within the organization, or you do not have access to it.", spaceGUID))
+			}
+			return nil, fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+		}
+
+		err = k8s.PatchResource(ctx, userClient, cfSpace, func() {
+			cfSpace.Spec.QuotaRef.Name = cfSpaceQuota.Name
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply space quota: %w", apierrors.FromK8sError(err, SpaceResourceType))
+		}
+	}
+
+	quotaSpaces, err := r.listQuotaSpaceGUIDs(ctx, userClient, cfSpaceQuota.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return quotaSpaces[cfSpaceQuota.Name], nil
+}
+
+func (r *SpaceQuotaRepo) RemoveSpaceQuota(ctx context.Context, authInfo authorization.Info, message RemoveSpaceQuotaMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getSpaceQuota(ctx, userClient, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	cfSpace := new(korifiv1alpha1.CFSpace)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: cfSpaceQuota.Namespace, Name: message.SpaceGUID}, cfSpace)
+	if err != nil {
+		return fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	if cfSpace.Spec.QuotaRef.Name != cfSpaceQuota.Name {
+		return nil
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpace, func() {
+		cfSpace.Spec.QuotaRef.Name = ""
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove space quota: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) DeleteSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getSpaceQuota(ctx, userClient, guid)
+	if err != nil {
+		return err
+	}
+
+	quotaSpaces, err := r.listQuotaSpaceGUIDs(ctx, userClient, cfSpaceQuota.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if len(quotaSpaces[guid]) > 0 {
+		return apierrors.NewUnprocessableEntityError(nil, "This quota is applied to one or more spaces. Remove this quota from all spaces before deleting.")
+	}
+
+	err = userClient.Delete(ctx, cfSpaceQuota)
+	if err != nil {
+		return fmt.Errorf("failed to delete space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	spaceQuota, err := r.GetSpaceQuota(ctx, authInfo, guid)
+	return spaceQuota.DeletedAt, err
+}
+
+func (r *SpaceQuotaRepo) getSpaceQuota(ctx context.Context, userClient client.WithWatch, guid string) (*korifiv1alpha1.CFSpaceQuota, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, SpaceQuotaResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	cfSpaceQuota := new(korifiv1alpha1.CFSpaceQuota)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, cfSpaceQuota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuota, nil
+}
+
+// listQuotaSpaceGUIDs returns the guids of the org spaces, grouped by the quota applied to them
+func (r *SpaceQuotaRepo) listQuotaSpaceGUIDs(ctx context.Context, userClient client.WithWatch, orgGUID string) (map[string][]string, error) {
+	cfSpaceList := new(korifiv1alpha1.CFSpaceList)
+	err := userClient.List(ctx, cfSpaceList, client.InNamespace(orgGUID))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return map[string][]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to list spaces: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	quotaSpaces := map[string][]string{}
+	for _, cfSpace := range cfSpaceList.Items {
+		if cfSpace.Spec.QuotaRef.Name == "" {
+			continue
+		}
+		quotaSpaces[cfSpace.Spec.QuotaRef.Name] = append(quotaSpaces[cfSpace.Spec.QuotaRef.Name], cfSpace.Name)
+	}
+
+	return quotaSpaces, nil
+}
+
+func cfSpaceQuotaToSpaceQuotaRecord(cfSpaceQuota *korifiv1alpha1.CFSpaceQuota, spaceGUIDs []string) SpaceQuotaRecord {
+	return SpaceQuotaRecord{
+		GUID:             cfSpaceQuota.Name,
+		Name:             cfSpaceQuota.Spec.DisplayName,
+		Limits:           cfQuotaLimitsToQuotaLimits(cfSpaceQuota.Spec.Limits),
+		OrganizationGUID: cfSpaceQuota.Namespace,
+		SpaceGUIDs:       spaceGUIDs,
+		CreatedAt:        cfSpaceQuota.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(cfSpaceQuota),
+		DeletedAt:        golangTime(cfSpaceQuota.DeletionTimestamp),
+	}
+}
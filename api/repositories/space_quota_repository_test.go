@@ -0,0 +1,284 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SpaceQuotaRepository", func() {
+	var (
+		spaceQuotaRepo *repositories.SpaceQuotaRepo
+		cfOrg          *korifiv1alpha1.CFOrg
+		cfSpace        *korifiv1alpha1.CFSpace
+		cfSpaceQuota   *korifiv1alpha1.CFSpaceQuota
+	)
+
+	BeforeEach(func() {
+		orgRepo := repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, &fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFOrg,
+			korifiv1alpha1.CFOrgList,
+			*korifiv1alpha1.CFOrgList,
+		]{})
+		spaceQuotaRepo = repositories.NewSpaceQuotaRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms)
+
+		cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+
+		cfSpaceQuota = &korifiv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: cfOrg.Name,
+			},
+			Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+				DisplayName: "my-quota",
+				Limits: korifiv1alpha1.QuotaLimits{
+					AppTasks: tools.PtrTo[int64](3),
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSpaceQuota)).To(Succeed())
+	})
+
+	Describe("CreateSpaceQuota", func() {
+		var (
+			message    repositories.CreateSpaceQuotaMessage
+			spaceQuota repositories.SpaceQuotaRecord
+			createErr  error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateSpaceQuotaMessage{
+				Name: "new-quota",
+				Limits: repositories.QuotaLimits{
+					TotalMemoryMB: tools.PtrTo[int64](512),
+				},
+				OrganizationGUID: cfOrg.Name,
+				SpaceGUIDs:       []string{cfSpace.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			spaceQuota, createErr = spaceQuotaRepo.CreateSpaceQuota(ctx, authInfo, message)
+		})
+
+		It("returns a not found error for the org", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("creates the space quota and applies it to the spaces", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(spaceQuota.Name).To(Equal("new-quota"))
+				Expect(spaceQuota.OrganizationGUID).To(Equal(cfOrg.Name))
+				Expect(spaceQuota.SpaceGUIDs).To(ConsistOf(cfSpace.Name))
+
+				createdQuota := new(korifiv1alpha1.CFSpaceQuota)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfOrg.Name, Name: spaceQuota.GUID}, createdQuota)).To(Succeed())
+				Expect(createdQuota.Spec.Limits.TotalMemoryMB).To(Equal(tools.PtrTo[int64](512)))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+				Expect(cfSpace.Spec.QuotaRef.Name).To(Equal(spaceQuota.GUID))
+			})
+
+			When("the space does not exist", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{"i-do-not-exist"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetSpaceQuota", func() {
+		var (
+			spaceQuota repositories.SpaceQuotaRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			cfSpace.Spec.QuotaRef.Name = cfSpaceQuota.Name
+			Expect(k8sClient.Update(ctx, cfSpace)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			spaceQuota, getErr = spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, cfSpaceQuota.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an org user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			})
+
+			It("returns the space quota", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(spaceQuota.GUID).To(Equal(cfSpaceQuota.Name))
+				Expect(spaceQuota.Name).To(Equal("my-quota"))
+				Expect(spaceQuota.Limits).To(Equal(repositories.QuotaLimits{
+					AppTasks: tools.PtrTo[int64](3),
+				}))
+				Expect(spaceQuota.OrganizationGUID).To(Equal(cfOrg.Name))
+				Expect(spaceQuota.SpaceGUIDs).To(ConsistOf(cfSpace.Name))
+			})
+		})
+
+		When("the space quota does not exist", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Delete(ctx, cfSpaceQuota)).To(Succeed())
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListSpaceQuotas", func() {
+		var (
+			message     repositories.ListSpaceQuotasMessage
+			spaceQuotas []repositories.SpaceQuotaRecord
+			listErr     error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListSpaceQuotasMessage{}
+
+			otherOrg := createOrgWithCleanup(ctx, uuid.NewString())
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFSpaceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: otherOrg.Name,
+				},
+				Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+					DisplayName: "invisible-quota",
+				},
+			})).To(Succeed())
+
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			spaceQuotas, listErr = spaceQuotaRepo.ListSpaceQuotas(ctx, authInfo, message)
+		})
+
+		It("lists the space quotas in the orgs the user can see", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(spaceQuotas).To(HaveLen(1))
+			Expect(spaceQuotas[0].GUID).To(Equal(cfSpaceQuota.Name))
+		})
+
+		When("filtering by space guid", func() {
+			BeforeEach(func() {
+				message.SpaceGUIDs = []string{cfSpace.Name}
+			})
+
+			It("returns nothing when the quota is not applied to the space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(spaceQuotas).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("PatchSpaceQuota", func() {
+		var (
+			spaceQuota repositories.SpaceQuotaRecord
+			patchErr   error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			spaceQuota, patchErr = spaceQuotaRepo.PatchSpaceQuota(ctx, authInfo, repositories.PatchSpaceQuotaMessage{
+				GUID: cfSpaceQuota.Name,
+				Limits: repositories.QuotaLimitsPatch{
+					AppTasks:    &repositories.QuotaLimitPatch{},
+					TotalRoutes: &repositories.QuotaLimitPatch{Value: tools.PtrTo[int64](7)},
+				},
+			})
+		})
+
+		It("patches the space quota", func() {
+			Expect(patchErr).NotTo(HaveOccurred())
+			Expect(spaceQuota.Name).To(Equal("my-quota"))
+			Expect(spaceQuota.Limits).To(Equal(repositories.QuotaLimits{
+				TotalRoutes: tools.PtrTo[int64](7),
+			}))
+		})
+	})
+
+	Describe("RemoveSpaceQuota", func() {
+		var removeErr error
+
+		BeforeEach(func() {
+			cfSpace.Spec.QuotaRef.Name = cfSpaceQuota.Name
+			Expect(k8sClient.Update(ctx, cfSpace)).To(Succeed())
+			createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			removeErr = spaceQuotaRepo.RemoveSpaceQuota(ctx, authInfo, repositories.RemoveSpaceQuotaMessage{
+				GUID:      cfSpaceQuota.Name,
+				SpaceGUID: cfSpace.Name,
+			})
+		})
+
+		It("removes the quota from the space", func() {
+			Expect(removeErr).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+			Expect(cfSpace.Spec.QuotaRef.Name).To(BeEmpty())
+		})
+	})
+
+	Describe("DeleteSpaceQuota", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = spaceQuotaRepo.DeleteSpaceQuota(ctx, authInfo, cfSpaceQuota.Name)
+		})
+
+		It("deletes the space quota", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		When("the quota is applied to a space", func() {
+			BeforeEach(func() {
+				cfSpace.Spec.QuotaRef.Name = cfSpaceQuota.Name
+				Expect(k8sClient.Update(ctx, cfSpace)).To(Succeed())
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+})
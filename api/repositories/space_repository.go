@@ -44,10 +44,23 @@ type PatchSpaceMetadataMessage struct {
 	OrgGUID string
 }
 
+type PatchSpaceFeaturesMessage struct {
+	GUID     string
+	OrgGUID  string
+	AllowSSH *bool
+}
+
+func (m *PatchSpaceFeaturesMessage) Apply(space *korifiv1alpha1.CFSpace) {
+	if m.AllowSSH != nil {
+		space.Spec.AllowSSH = *m.AllowSSH
+	}
+}
+
 type SpaceRecord struct {
 	Name             string
 	GUID             string
 	OrganizationGUID string
+	AllowSSH         bool
 	Labels           map[string]string
 	Annotations      map[string]string
 	CreatedAt        time.Time
@@ -97,6 +110,7 @@ func (r *SpaceRepo) CreateSpace(ctx context.Context, info authorization.Info, me
 		},
 		Spec: korifiv1alpha1.CFSpaceSpec{
 			DisplayName: message.Name,
+			AllowSSH:    true,
 		},
 	}
 	err = userClient.Create(ctx, cfSpace)
@@ -191,6 +205,7 @@ func cfSpaceToSpaceRecord(cfSpace *korifiv1alpha1.CFSpace) SpaceRecord {
 		Name:             cfSpace.Spec.DisplayName,
 		GUID:             cfSpace.Name,
 		OrganizationGUID: cfSpace.Namespace,
+		AllowSSH:         cfSpace.Spec.AllowSSH,
 		Annotations:      cfSpace.Annotations,
 		Labels:           cfSpace.Labels,
 		CreatedAt:        cfSpace.CreationTimestamp.Time,
@@ -237,6 +252,28 @@ func (r *SpaceRepo) PatchSpaceMetadata(ctx context.Context, authInfo authorizati
 	return cfSpaceToSpaceRecord(cfSpace), nil
 }
 
+func (r *SpaceRepo) PatchSpaceFeatures(ctx context.Context, authInfo authorization.Info, message PatchSpaceFeaturesMessage) (SpaceRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpace := new(korifiv1alpha1.CFSpace)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.OrgGUID, Name: message.GUID}, cfSpace)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpace, func() {
+		message.Apply(cfSpace)
+	})
+	if err != nil {
+		return SpaceRecord{}, apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	return cfSpaceToSpaceRecord(cfSpace), nil
+}
+
 func (r *SpaceRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, spaceGUID string) (*time.Time, error) {
 	space, err := r.GetSpace(ctx, authInfo, spaceGUID)
 	if err != nil {
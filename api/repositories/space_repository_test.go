@@ -139,6 +139,15 @@ var _ = Describe("SpaceRepository", func() {
 				Expect(spaceRecord.DeletedAt).To(BeNil())
 			})
 
+			It("allows ssh in the space", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(spaceRecord.AllowSSH).To(BeTrue())
+
+				spaceCR := new(korifiv1alpha1.CFSpace)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: orgGUID, Name: spaceRecord.GUID}, spaceCR)).To(Succeed())
+				Expect(spaceCR.Spec.AllowSSH).To(BeTrue())
+			})
+
 			When("the space does not become ready", func() {
 				BeforeEach(func() {
 					conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFSpace{}, errors.New("time-out-err"))
@@ -683,6 +692,51 @@ var _ = Describe("SpaceRepository", func() {
 		})
 	})
 
+	Describe("PatchSpaceFeatures", func() {
+		var (
+			orgGUID     string
+			cfSpace     *korifiv1alpha1.CFSpace
+			patchErr    error
+			spaceRecord repositories.SpaceRecord
+		)
+
+		BeforeEach(func() {
+			cfOrg := createOrgWithCleanup(ctx, prefixedGUID("org"))
+			orgGUID = cfOrg.Name
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, "the-space")
+		})
+
+		JustBeforeEach(func() {
+			spaceRecord, patchErr = spaceRepo.PatchSpaceFeatures(ctx, authInfo, repositories.PatchSpaceFeaturesMessage{
+				GUID:     cfSpace.Name,
+				OrgGUID:  orgGUID,
+				AllowSSH: tools.PtrTo(true),
+			})
+		})
+
+		When("the user is authorized", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, orgGUID)
+			})
+
+			It("allows ssh in the space", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(spaceRecord.GUID).To(Equal(cfSpace.Name))
+				Expect(spaceRecord.AllowSSH).To(BeTrue())
+
+				updatedCFSpace := new(korifiv1alpha1.CFSpace)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), updatedCFSpace)).To(Succeed())
+				Expect(updatedCFSpace.Spec.AllowSSH).To(BeTrue())
+			})
+		})
+
+		When("the user is not authorized", func() {
+			It("return a forbidden error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("GetDeletedAt", func() {
 		var (
 			cfSpace      *korifiv1alpha1.CFSpace
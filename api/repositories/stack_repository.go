@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+const (
+	StackResourceType = "Stack"
+)
+
+type StackRepository struct {
+	builderName       string
+	userClientFactory authorization.UserK8sClientFactory
+	rootNamespace     string
+	defaultStack      string
+}
+
+// StackRecord is a stack supported by the builder. Stacks have no guid of
+// their own, so the name of the stack is used as its guid
+type StackRecord struct {
+	GUID        string
+	Name        string
+	Description string
+	Default     bool
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+type ListStacksMessage struct {
+	Names []string
+}
+
+func NewStackRepository(builderName string, userClientFactory authorization.UserK8sClientFactory, rootNamespace string, defaultStack string) *StackRepository {
+	return &StackRepository{
+		builderName:       builderName,
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+		defaultStack:      defaultStack,
+	}
+}
+
+func (r *StackRepository) ListStacks(ctx context.Context, authInfo authorization.Info, message ListStacksMessage) ([]StackRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	builderInfo, err := getReadyBuilderInfo(ctx, userClient, r.rootNamespace, r.builderName, StackResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	stacks := Filter(builderInfo.Status.Stacks,
+		SetPredicate(message.Names, func(s korifiv1alpha1.BuilderInfoStatusStack) string { return s.Name }),
+	)
+
+	stackRecords := make([]StackRecord, 0, len(stacks))
+	for _, s := range stacks {
+		stackRecords = append(stackRecords, r.builderInfoStackToStackRecord(s))
+	}
+
+	return stackRecords, nil
+}
+
+func (r *StackRepository) GetStack(ctx context.Context, authInfo authorization.Info, guid string) (StackRecord, error) {
+	stacks, err := r.ListStacks(ctx, authInfo, ListStacksMessage{Names: []string{guid}})
+	if err != nil {
+		return StackRecord{}, err
+	}
+
+	if len(stacks) == 0 {
+		return StackRecord{}, apierrors.NewNotFoundError(fmt.Errorf("stack %q not found", guid), StackResourceType)
+	}
+
+	return stacks[0], nil
+}
+
+func (r *StackRepository) builderInfoStackToStackRecord(stack korifiv1alpha1.BuilderInfoStatusStack) StackRecord {
+	return StackRecord{
+		GUID:        stack.Name,
+		Name:        stack.Name,
+		Description: stack.Description,
+		Default:     stack.Name == r.defaultStack,
+		CreatedAt:   stack.CreationTimestamp.Time,
+		UpdatedAt:   &stack.UpdatedTimestamp.Time,
+	}
+}
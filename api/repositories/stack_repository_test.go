@@ -0,0 +1,105 @@
+package repositories_test
+
+import (
+	"fmt"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("StackRepository", func() {
+	var stackRepo *StackRepository
+
+	BeforeEach(func() {
+		stackRepo = NewStackRepository(builderName, userClientFactory, rootNamespace, "io.buildpacks.stacks.jammy")
+	})
+
+	When("the BuilderInfo resource with the configured BuilderName exists", func() {
+		BeforeEach(func() {
+			builderInfo := createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.jammy", nil)
+			builderInfo.Status.Stacks = append(builderInfo.Status.Stacks, korifiv1alpha1.BuilderInfoStatusStack{
+				Name:              "io.buildpacks.stacks.bionic",
+				Description:       "the old stack",
+				CreationTimestamp: metav1.Now(),
+				UpdatedTimestamp:  metav1.Now(),
+			})
+			Expect(k8sClient.Status().Update(ctx, builderInfo)).To(Succeed())
+		})
+
+		Describe("ListStacks", func() {
+			It("returns all stacks, flagging the default one", func() {
+				stackRecords, err := stackRepo.ListStacks(ctx, authInfo, ListStacksMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stackRecords).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":    Equal("io.buildpacks.stacks.jammy"),
+						"Name":    Equal("io.buildpacks.stacks.jammy"),
+						"Default": BeTrue(),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":        Equal("io.buildpacks.stacks.bionic"),
+						"Name":        Equal("io.buildpacks.stacks.bionic"),
+						"Description": Equal("the old stack"),
+						"Default":     BeFalse(),
+					}),
+				))
+			})
+
+			It("filters the stacks by name", func() {
+				stackRecords, err := stackRepo.ListStacks(ctx, authInfo, ListStacksMessage{Names: []string{"io.buildpacks.stacks.bionic"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stackRecords).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Name": Equal("io.buildpacks.stacks.bionic")}),
+				))
+			})
+		})
+
+		Describe("GetStack", func() {
+			It("returns the stack", func() {
+				stackRecord, err := stackRepo.GetStack(ctx, authInfo, "io.buildpacks.stacks.bionic")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stackRecord.Name).To(Equal("io.buildpacks.stacks.bionic"))
+			})
+
+			When("the stack does not exist", func() {
+				It("returns a not found error", func() {
+					_, err := stackRepo.GetStack(ctx, authInfo, "i-do-not-exist")
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	When("no BuilderInfo resource exists", func() {
+		It("errors", func() {
+			_, err := stackRepo.ListStacks(ctx, authInfo, ListStacksMessage{})
+			Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("BuilderInfo %q not found in namespace %q", builderName, rootNamespace))))
+		})
+	})
+
+	When("the BuilderInfo resource is not ready", func() {
+		BeforeEach(func() {
+			builderInfo := createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.jammy", nil)
+			meta.SetStatusCondition(&builderInfo.Status.Conditions, metav1.Condition{
+				Type:    korifiv1alpha1.StatusConditionReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  "testing",
+				Message: "this is a test",
+			})
+			Expect(k8sClient.Status().Update(ctx, builderInfo)).To(Succeed())
+		})
+
+		It("errors", func() {
+			_, err := stackRepo.ListStacks(ctx, authInfo, ListStacksMessage{})
+			Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("BuilderInfo %q not ready: this is a test", builderName))))
+		})
+	})
+})
@@ -57,6 +57,7 @@ type CreateTaskMessage struct {
 type ListTaskMessage struct {
 	AppGUIDs    []string
 	SequenceIDs []int64
+	Pagination  Pagination
 }
 
 type PatchTaskMetadataMessage struct {
@@ -160,15 +161,15 @@ func (r *TaskRepo) awaitCondition(ctx context.Context, userClient client.WithWat
 	return awaitedTask, nil
 }
 
-func (r *TaskRepo) ListTasks(ctx context.Context, authInfo authorization.Info, msg ListTaskMessage) ([]TaskRecord, error) {
+func (r *TaskRepo) ListTasks(ctx context.Context, authInfo authorization.Info, msg ListTaskMessage) (ListResult[TaskRecord], error) {
 	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+		return ListResult[TaskRecord]{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
 	}
 
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build user client: %w", err)
+		return ListResult[TaskRecord]{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
 	preds := []func(korifiv1alpha1.CFTask) bool{
@@ -176,25 +177,29 @@ func (r *TaskRepo) ListTasks(ctx context.Context, authInfo authorization.Info, m
 		SetPredicate(msg.AppGUIDs, func(s korifiv1alpha1.CFTask) string { return s.Spec.AppRef.Name }),
 	}
 
-	var tasks []korifiv1alpha1.CFTask
-	for ns := range nsList {
+	tasks := newPageCollector[korifiv1alpha1.CFTask](msg.Pagination)
+	for _, ns := range orderedNamespaces(nsList) {
 		taskList := &korifiv1alpha1.CFTaskList{}
-		err := userClient.List(ctx, taskList, client.InNamespace(ns))
+		err := listInChunks(ctx, userClient, taskList, func(l *korifiv1alpha1.CFTaskList) {
+			tasks.collect(Filter(l.Items, preds...))
+		}, client.InNamespace(ns))
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to list tasks in namespace %s: %w", ns, apierrors.FromK8sError(err, TaskResourceType))
+			return ListResult[TaskRecord]{}, fmt.Errorf("failed to list tasks in namespace %s: %w", ns, apierrors.FromK8sError(err, TaskResourceType))
 		}
-		tasks = append(tasks, Filter(taskList.Items, preds...)...)
 	}
 
 	taskRecords := []TaskRecord{}
-	for i := range tasks {
-		taskRecords = append(taskRecords, taskToRecord(&tasks[i]))
+	for i := range tasks.items {
+		taskRecords = append(taskRecords, taskToRecord(&tasks.items[i]))
 	}
 
-	return taskRecords, nil
+	return ListResult[TaskRecord]{
+		PageInfo: tasks.pageInfo(),
+		Records:  taskRecords,
+	}, nil
 }
 
 func (r *TaskRepo) CancelTask(ctx context.Context, authInfo authorization.Info, taskGUID string) (TaskRecord, error) {
@@ -353,6 +353,7 @@ var _ = Describe("TaskRepository", func() {
 			listTaskMsg repositories.ListTaskMessage
 
 			listedTasks []repositories.TaskRecord
+			pageInfo    repositories.PageInfo
 			listErr     error
 		)
 
@@ -391,7 +392,9 @@ var _ = Describe("TaskRepository", func() {
 		})
 
 		JustBeforeEach(func() {
-			listedTasks, listErr = taskRepo.ListTasks(ctx, authInfo, listTaskMsg)
+			var result repositories.ListResult[repositories.TaskRecord]
+			result, listErr = taskRepo.ListTasks(ctx, authInfo, listTaskMsg)
+			listedTasks, pageInfo = result.Records, result.PageInfo
 		})
 
 		It("returns an empty list due to no permissions", func() {
@@ -427,6 +430,18 @@ var _ = Describe("TaskRepository", func() {
 					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
 				})
 
+				When("a page is requested", func() {
+					BeforeEach(func() {
+						listTaskMsg.Pagination = repositories.Pagination{Page: 2, PerPage: 1}
+					})
+
+					It("lists the tasks on that page along with the total number of tasks", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(listedTasks).To(HaveLen(1))
+						Expect(pageInfo).To(Equal(repositories.PageInfo{TotalResults: 2, Page: 2, PerPage: 1}))
+					})
+				})
+
 				When("the app1 guid is passed as a filter", func() {
 					BeforeEach(func() {
 						listTaskMsg.AppGUIDs = []string{cfApp.Name}
@@ -0,0 +1,243 @@
+package sshproxy
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;create;delete,namespace=ROOT_NAMESPACE
+
+const (
+	CodeSecretType          corev1.SecretType = "korifi.cloudfoundry.org/ssh-code"
+	CodeLabelKey                              = "korifi.cloudfoundry.org/ssh-code"
+	CodeExpiresAtKey                          = "korifi.cloudfoundry.org/ssh-code-expires-at"
+	codeSecretPrefix                          = "ssh-code-"
+	codeCredentialsKey                        = "credentials"
+	codeEncryptionKeyPrefix                   = "korifi-ssh-code-key:"
+	DefaultCodeValidity                       = 5 * time.Minute
+)
+
+var ErrInvalidCode = errors.New("invalid or expired code")
+
+// CodeStore issues one-time codes the users authenticate to the proxy with.
+// Codes are stored as secrets in the root namespace so that they can be
+// redeemed by any API instance. The secret is named after a hash of the code
+// and holds the credentials of the user that requested it encrypted with a
+// key derived from the code, so the credentials cannot be read back without
+// the code itself. The secret is deleted when the code is redeemed or, at the
+// latest, by the garbage collection once it has expired.
+type CodeStore struct {
+	privilegedClient client.Client
+	rootNamespace    string
+	validity         time.Duration
+}
+
+func NewCodeStore(privilegedClient client.Client, rootNamespace string, validity time.Duration) *CodeStore {
+	return &CodeStore{
+		privilegedClient: privilegedClient,
+		rootNamespace:    rootNamespace,
+		validity:         validity,
+	}
+}
+
+func (s *CodeStore) Issue(ctx context.Context, authInfo authorization.Info) (string, error) {
+	codeBytes := make([]byte, 32)
+	if _, err := rand.Read(codeBytes); err != nil {
+		return "", fmt.Errorf("failed to generate code: %w", err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(codeBytes)
+
+	credentials, err := encryptCredentials(code, authInfo)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.privilegedClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.rootNamespace,
+			Name:      codeSecretName(code),
+			Labels: map[string]string{
+				CodeLabelKey: "true",
+			},
+			Annotations: map[string]string{
+				CodeExpiresAtKey: time.Now().Add(s.validity).UTC().Format(time.RFC3339),
+			},
+		},
+		Type: CodeSecretType,
+		Data: map[string][]byte{
+			codeCredentialsKey: credentials,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store code: %w", err)
+	}
+
+	return code, nil
+}
+
+// Redeem returns the credentials of the user the code has been issued to.
+// Each code can only be redeemed once.
+func (s *CodeStore) Redeem(ctx context.Context, code string) (authorization.Info, error) {
+	secret := new(corev1.Secret)
+	err := s.privilegedClient.Get(ctx, client.ObjectKey{Namespace: s.rootNamespace, Name: codeSecretName(code)}, secret)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return authorization.Info{}, ErrInvalidCode
+		}
+		return authorization.Info{}, fmt.Errorf("failed to get code: %w", err)
+	}
+
+	err = s.privilegedClient.Delete(ctx, secret, client.Preconditions{UID: &secret.UID})
+	if err != nil {
+		if k8serrors.IsNotFound(err) || k8serrors.IsConflict(err) {
+			return authorization.Info{}, ErrInvalidCode
+		}
+		return authorization.Info{}, fmt.Errorf("failed to delete code: %w", err)
+	}
+
+	if isExpired(*secret) {
+		return authorization.Info{}, ErrInvalidCode
+	}
+
+	authInfo, err := decryptCredentials(code, secret.Data[codeCredentialsKey])
+	if err != nil {
+		return authorization.Info{}, ErrInvalidCode
+	}
+
+	return authInfo, nil
+}
+
+// CollectGarbage deletes the expired codes every interval until the context
+// is done, so that codes that are never redeemed do not outlive their
+// validity
+func (s *CodeStore) CollectGarbage(ctx context.Context, interval time.Duration) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("ssh-code-gc")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.deleteExpiredCodes(ctx); err != nil {
+				logger.Info("failed to delete expired ssh codes", "reason", err.Error())
+			}
+		}
+	}
+}
+
+func (s *CodeStore) deleteExpiredCodes(ctx context.Context) error {
+	secrets := new(corev1.SecretList)
+	err := s.privilegedClient.List(ctx, secrets, client.InNamespace(s.rootNamespace), client.MatchingLabels{CodeLabelKey: "true"})
+	if err != nil {
+		return fmt.Errorf("failed to list codes: %w", err)
+	}
+
+	for i := range secrets.Items {
+		if !isExpired(secrets.Items[i]) {
+			continue
+		}
+
+		err = s.privilegedClient.Delete(ctx, &secrets.Items[i])
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete expired code: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func isExpired(secret corev1.Secret) bool {
+	expiresAt, err := time.Parse(time.RFC3339, secret.Annotations[CodeExpiresAtKey])
+	if err != nil {
+		return true
+	}
+
+	return time.Now().After(expiresAt)
+}
+
+type codeCredentials struct {
+	Token    string `json:"token,omitempty"`
+	CertData []byte `json:"certData,omitempty"`
+}
+
+func encryptCredentials(code string, authInfo authorization.Info) ([]byte, error) {
+	plaintext, err := json.Marshal(codeCredentials{Token: authInfo.Token, CertData: authInfo.CertData})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	aead, err := codeCipher(code)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decryptCredentials(code string, ciphertext []byte) (authorization.Info, error) {
+	aead, err := codeCipher(code)
+	if err != nil {
+		return authorization.Info{}, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return authorization.Info{}, errors.New("credentials are too short")
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return authorization.Info{}, fmt.Errorf("failed to decrypt credentials: %w", err)
+	}
+
+	credentials := codeCredentials{}
+	if err = json.Unmarshal(plaintext, &credentials); err != nil {
+		return authorization.Info{}, fmt.Errorf("failed to unmarshal credentials: %w", err)
+	}
+
+	return authorization.Info{Token: credentials.Token, CertData: credentials.CertData}, nil
+}
+
+// codeCipher derives the encryption key from the code. The key is hashed with
+// a different prefix than the secret name, so it cannot be computed from the
+// name.
+func codeCipher(code string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(codeEncryptionKeyPrefix + code))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func codeSecretName(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return codeSecretPrefix + hex.EncodeToString(hash[:])
+}
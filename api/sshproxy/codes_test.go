@@ -0,0 +1,120 @@
+package sshproxy_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CodeStore", func() {
+	var (
+		codeStore *sshproxy.CodeStore
+		validity  time.Duration
+		authInfo  authorization.Info
+		code      string
+	)
+
+	BeforeEach(func() {
+		validity = time.Minute
+		authInfo = authorization.Info{Token: "a-token"}
+	})
+
+	JustBeforeEach(func() {
+		codeStore = sshproxy.NewCodeStore(k8sClient, rootNamespace, validity)
+
+		var err error
+		code, err = codeStore.Issue(ctx, authInfo)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("stores a hash of the code with the encrypted user credentials", func() {
+		secrets := new(corev1.SecretList)
+		Expect(k8sClient.List(ctx, secrets, client.InNamespace(rootNamespace))).To(Succeed())
+		Expect(secrets.Items).To(HaveLen(1))
+
+		secret := secrets.Items[0]
+		Expect(secret.Type).To(Equal(sshproxy.CodeSecretType))
+		Expect(secret.Name).NotTo(ContainSubstring(code))
+		Expect(secret.Labels).To(HaveKeyWithValue(sshproxy.CodeLabelKey, "true"))
+		Expect(secret.Annotations).To(HaveKey(sshproxy.CodeExpiresAtKey))
+		Expect(secret.Data).To(HaveKey("credentials"))
+		Expect(string(secret.Data["credentials"])).NotTo(ContainSubstring("a-token"))
+	})
+
+	It("can be redeemed once", func() {
+		redeemedAuthInfo, err := codeStore.Redeem(ctx, code)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(redeemedAuthInfo.Token).To(Equal("a-token"))
+		Expect(redeemedAuthInfo.CertData).To(BeEmpty())
+
+		_, err = codeStore.Redeem(ctx, code)
+		Expect(err).To(MatchError(sshproxy.ErrInvalidCode))
+	})
+
+	When("the user authenticates with a certificate", func() {
+		BeforeEach(func() {
+			authInfo = authorization.Info{CertData: []byte("cert-data")}
+		})
+
+		It("redeems the certificate", func() {
+			redeemedAuthInfo, err := codeStore.Redeem(ctx, code)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(redeemedAuthInfo.Token).To(BeEmpty())
+			Expect(redeemedAuthInfo.CertData).To(Equal([]byte("cert-data")))
+		})
+	})
+
+	When("the stored credentials have been tampered with", func() {
+		JustBeforeEach(func() {
+			secrets := new(corev1.SecretList)
+			Expect(k8sClient.List(ctx, secrets, client.InNamespace(rootNamespace))).To(Succeed())
+			Expect(secrets.Items).To(HaveLen(1))
+
+			secret := secrets.Items[0]
+			secret.Data["credentials"] = []byte("not-the-credentials")
+			Expect(k8sClient.Update(ctx, &secret)).To(Succeed())
+		})
+
+		It("fails to redeem the code", func() {
+			_, err := codeStore.Redeem(ctx, code)
+			Expect(err).To(MatchError(sshproxy.ErrInvalidCode))
+		})
+	})
+
+	When("the code is unknown", func() {
+		It("fails to redeem it", func() {
+			_, err := codeStore.Redeem(ctx, "not-a-code")
+			Expect(err).To(MatchError(sshproxy.ErrInvalidCode))
+		})
+	})
+
+	When("the code has expired", func() {
+		BeforeEach(func() {
+			validity = -time.Minute
+		})
+
+		It("fails to redeem it", func() {
+			_, err := codeStore.Redeem(ctx, code)
+			Expect(err).To(MatchError(sshproxy.ErrInvalidCode))
+		})
+
+		It("is garbage collected", func() {
+			gcCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go codeStore.CollectGarbage(gcCtx, 100*time.Millisecond)
+
+			Eventually(func(g Gomega) {
+				secrets := new(corev1.SecretList)
+				g.Expect(k8sClient.List(ctx, secrets, client.InNamespace(rootNamespace))).To(Succeed())
+				g.Expect(secrets.Items).To(BeEmpty())
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,246 @@
+package sshproxy
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"golang.org/x/net/websocket"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// Channel protocols of the pod exec subresource, in order of preference
+const (
+	execProtocolV5 = "v5.channel.k8s.io"
+	execProtocolV4 = "v4.channel.k8s.io"
+
+	streamStdin  byte = 0
+	streamStdout byte = 1
+	streamStderr byte = 2
+	streamError  byte = 3
+	streamResize byte = 4
+	streamClose  byte = 255
+)
+
+type TerminalSize struct {
+	Width  uint16
+	Height uint16
+}
+
+type ExecRequest struct {
+	Command []string
+	TTY     bool
+	Stdin   io.Reader
+	Stdout  io.Writer
+	Stderr  io.Writer
+	Resize  <-chan TerminalSize
+}
+
+// WebsocketExecutor runs commands in app instances through the pod exec
+// subresource, authenticating as the user
+type WebsocketExecutor struct {
+	userClientFactory authorization.UserK8sClientFactory
+}
+
+func NewWebsocketExecutor(userClientFactory authorization.UserK8sClientFactory) *WebsocketExecutor {
+	return &WebsocketExecutor{
+		userClientFactory: userClientFactory,
+	}
+}
+
+// Exec runs the command to completion and returns its exit code
+func (e *WebsocketExecutor) Exec(ctx context.Context, authInfo authorization.Info, pod repositories.AppInstancePodRecord, request ExecRequest) (int, error) {
+	ws, err := e.dial(ctx, authInfo, pod, request)
+	if err != nil {
+		return 0, err
+	}
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		ws.Close()
+	}()
+
+	go forwardStdin(ws, request.Stdin)
+	go forwardResize(ctx, ws, request.Resize)
+
+	for {
+		var message []byte
+		if err = websocket.Message.Receive(ws, &message); err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, errors.New("exec stream closed without reporting the command status")
+			}
+			return 0, fmt.Errorf("failed to read exec stream: %w", err)
+		}
+
+		if len(message) == 0 {
+			continue
+		}
+
+		switch message[0] {
+		case streamStdout:
+			_, err = request.Stdout.Write(message[1:])
+		case streamStderr:
+			_, err = request.Stderr.Write(message[1:])
+		case streamError:
+			return exitCode(message[1:])
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to forward command output: %w", err)
+		}
+	}
+}
+
+func (e *WebsocketExecutor) dial(ctx context.Context, authInfo authorization.Info, pod repositories.AppInstancePodRecord, request ExecRequest) (*websocket.Conn, error) {
+	restConfig, err := e.userClientFactory.BuildRESTConfig(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user config: %w", err)
+	}
+
+	tlsConfig, err := rest.TLSConfigFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tls config: %w", err)
+	}
+
+	serverURL, _, err := rest.DefaultServerUrlFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the api server url: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("container", pod.ContainerName)
+	query.Set("stdin", "true")
+	query.Set("stdout", "true")
+	query.Set("stderr", strconv.FormatBool(!request.TTY))
+	query.Set("tty", strconv.FormatBool(request.TTY))
+	for _, arg := range request.Command {
+		query.Add("command", arg)
+	}
+
+	execURL := *serverURL
+	execURL.Path = fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/exec", pod.Namespace, pod.Name)
+	execURL.RawQuery = query.Encode()
+	execURL.Scheme = "wss"
+	if serverURL.Scheme == "http" {
+		execURL.Scheme = "ws"
+	}
+
+	wsConfig, err := websocket.NewConfig(execURL.String(), serverURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to build websocket config: %w", err)
+	}
+	wsConfig.Protocol = []string{execProtocolV5, execProtocolV4}
+	wsConfig.Header = http.Header{}
+	if restConfig.BearerToken != "" {
+		wsConfig.Header.Set("Authorization", "Bearer "+restConfig.BearerToken)
+	}
+
+	conn, err := dialServer(ctx, execURL, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the api server: %w", err)
+	}
+
+	ws, err := websocket.NewClient(wsConfig, conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open exec stream: %w", err)
+	}
+	ws.PayloadType = websocket.BinaryFrame
+
+	return ws, nil
+}
+
+func dialServer(ctx context.Context, serverURL url.URL, tlsConfig *tls.Config) (net.Conn, error) {
+	host := serverURL.Host
+	if serverURL.Port() == "" {
+		port := "443"
+		if serverURL.Scheme == "ws" {
+			port = "80"
+		}
+		host = net.JoinHostPort(serverURL.Hostname(), port)
+	}
+
+	if serverURL.Scheme == "ws" {
+		return new(net.Dialer).DialContext(ctx, "tcp", host)
+	}
+
+	return (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", host)
+}
+
+func forwardStdin(ws *websocket.Conn, stdin io.Reader) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			if sendErr := websocket.Message.Send(ws, append([]byte{streamStdin}, buf[:n]...)); sendErr != nil {
+				return
+			}
+		}
+
+		if err != nil {
+			// Only v5 can tell the command that its input is over
+			if ws.Config().Protocol[0] == execProtocolV5 {
+				_ = websocket.Message.Send(ws, []byte{streamClose, streamStdin})
+			}
+			return
+		}
+	}
+}
+
+func forwardResize(ctx context.Context, ws *websocket.Conn, resize <-chan TerminalSize) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case size, ok := <-resize:
+			if !ok {
+				return
+			}
+
+			sizeBytes, err := json.Marshal(size)
+			if err != nil {
+				return
+			}
+
+			if err = websocket.Message.Send(ws, append([]byte{streamResize}, sizeBytes...)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// exitCode extracts the exit code of the command from the status reported
+// on the error stream
+func exitCode(statusBytes []byte) (int, error) {
+	status := metav1.Status{}
+	if err := json.Unmarshal(statusBytes, &status); err != nil {
+		return 0, fmt.Errorf("failed to decode the command status: %w", err)
+	}
+
+	if status.Status == metav1.StatusSuccess {
+		return 0, nil
+	}
+
+	if status.Reason == "NonZeroExitCode" && status.Details != nil {
+		for _, cause := range status.Details.Causes {
+			if cause.Type == "ExitCode" {
+				return strconv.Atoi(cause.Message)
+			}
+		}
+	}
+
+	return 0, errors.New(status.Message)
+}
@@ -0,0 +1,6 @@
+// Package sshproxy grants SSH access to app instances. Users authenticate with
+// a one-time code issued by the API and get a shell, or run a command, in the
+// application container of the requested app instance.
+package sshproxy
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -0,0 +1,383 @@
+package sshproxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// OAuthClient is the client the cf cli requests one-time codes for
+	OAuthClient = "ssh-proxy"
+
+	userPrefix         = "cf:"
+	defaultShell       = "/bin/sh"
+	extensionToken     = "token"
+	extensionCertData  = "cert-data"
+	extensionPod       = "pod"
+	extensionNamespace = "namespace"
+	extensionContainer = "container"
+)
+
+//counterfeiter:generate -o fake -fake-name CodeRedeemer . CodeRedeemer
+//counterfeiter:generate -o fake -fake-name CFProcessRepository . CFProcessRepository
+//counterfeiter:generate -o fake -fake-name CFAppRepository . CFAppRepository
+//counterfeiter:generate -o fake -fake-name CFSpaceRepository . CFSpaceRepository
+//counterfeiter:generate -o fake -fake-name PodRepository . PodRepository
+//counterfeiter:generate -o fake -fake-name Executor . Executor
+
+type CodeRedeemer interface {
+	Redeem(context.Context, string) (authorization.Info, error)
+}
+
+type CFProcessRepository interface {
+	GetProcess(context.Context, authorization.Info, string) (repositories.ProcessRecord, error)
+}
+
+type CFAppRepository interface {
+	GetApp(context.Context, authorization.Info, string) (repositories.AppRecord, error)
+}
+
+type CFSpaceRepository interface {
+	GetSpace(context.Context, authorization.Info, string) (repositories.SpaceRecord, error)
+}
+
+type PodRepository interface {
+	GetAppInstancePod(context.Context, authorization.Info, repositories.GetAppInstancePodMessage) (repositories.AppInstancePodRecord, error)
+}
+
+type Executor interface {
+	Exec(context.Context, authorization.Info, repositories.AppInstancePodRecord, ExecRequest) (int, error)
+}
+
+// Server is an SSH server proxying sessions of the users authenticated with
+// a one-time code to the process instance identified by the user name, which
+// has the form cf:<process-guid>/<instance-index>
+type Server struct {
+	hostKey     ssh.Signer
+	codes       CodeRedeemer
+	processRepo CFProcessRepository
+	appRepo     CFAppRepository
+	spaceRepo   CFSpaceRepository
+	podRepo     PodRepository
+	executor    Executor
+	logger      logr.Logger
+}
+
+func NewServer(
+	hostKey ssh.Signer,
+	codes CodeRedeemer,
+	processRepo CFProcessRepository,
+	appRepo CFAppRepository,
+	spaceRepo CFSpaceRepository,
+	podRepo PodRepository,
+	executor Executor,
+	logger logr.Logger,
+) *Server {
+	return &Server{
+		hostKey:     hostKey,
+		codes:       codes,
+		processRepo: processRepo,
+		appRepo:     appRepo,
+		spaceRepo:   spaceRepo,
+		podRepo:     podRepo,
+		executor:    executor,
+		logger:      logger,
+	}
+}
+
+// HostKeyFingerprint returns the fingerprint of the host key in the format
+// advertised to the cf cli, i.e. the unpadded base64 encoding of its SHA256
+// hash
+func HostKeyFingerprint(hostKey ssh.PublicKey) string {
+	hash := sha256.Sum256(hostKey.Marshal())
+	return base64.RawStdEncoding.EncodeToString(hash[:])
+}
+
+// Serve accepts connections on the listener until the context is done
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		go s.handleConn(ctx, conn)
+	}
+}
+
+func (s *Server) handleConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(connMeta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			perms, err := s.authenticate(ctx, connMeta.User(), string(password))
+			if err != nil {
+				s.logger.Info("ssh authentication failed", "user", connMeta.User(), "remote", connMeta.RemoteAddr().String(), "reason", err.Error())
+				return nil, err
+			}
+			return perms, nil
+		},
+	}
+	config.AddHostKey(s.hostKey)
+
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	defer serverConn.Close()
+	go ssh.DiscardRequests(requests)
+
+	authInfo := authorization.Info{
+		Token: serverConn.Permissions.Extensions[extensionToken],
+	}
+	if certData := serverConn.Permissions.Extensions[extensionCertData]; certData != "" {
+		authInfo.CertData = []byte(certData)
+	}
+	pod := repositories.AppInstancePodRecord{
+		Name:          serverConn.Permissions.Extensions[extensionPod],
+		Namespace:     serverConn.Permissions.Extensions[extensionNamespace],
+		ContainerName: serverConn.Permissions.Extensions[extensionContainer],
+	}
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "only session channels are supported")
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go s.handleSession(ctx, authInfo, pod, channel, channelRequests)
+	}
+}
+
+// authenticate checks that the code is valid, and that the user is allowed to
+// SSH into the requested process instance
+func (s *Server) authenticate(ctx context.Context, user, code string) (*ssh.Permissions, error) {
+	processGUID, index, err := parseUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	authInfo, err := s.codes.Redeem(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	process, err := s.processRepo.GetProcess(ctx, authInfo, processGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get process: %w", err)
+	}
+
+	app, err := s.appRepo.GetApp(ctx, authInfo, process.AppGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app: %w", err)
+	}
+
+	space, err := s.spaceRepo.GetSpace(ctx, authInfo, app.SpaceGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	if !space.AllowSSH {
+		return nil, errors.New("ssh is disabled for the space")
+	}
+
+	if !app.EnableSSH {
+		return nil, errors.New("ssh is disabled for the app")
+	}
+
+	pod, err := s.podRepo.GetAppInstancePod(ctx, authInfo, repositories.GetAppInstancePodMessage{
+		SpaceGUID:     app.SpaceGUID,
+		AppGUID:       app.GUID,
+		ProcessType:   process.Type,
+		InstanceIndex: index,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app instance: %w", err)
+	}
+
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			extensionToken:     authInfo.Token,
+			extensionCertData:  string(authInfo.CertData),
+			extensionPod:       pod.Name,
+			extensionNamespace: pod.Namespace,
+			extensionContainer: pod.ContainerName,
+		},
+	}, nil
+}
+
+func parseUser(user string) (string, int, error) {
+	processInstance, found := strings.CutPrefix(user, userPrefix)
+	if !found {
+		return "", 0, fmt.Errorf("user %q must have the form cf:<process-guid>/<instance-index>", user)
+	}
+
+	processGUID, indexString, found := strings.Cut(processInstance, "/")
+	if !found || processGUID == "" {
+		return "", 0, fmt.Errorf("user %q must have the form cf:<process-guid>/<instance-index>", user)
+	}
+
+	index, err := strconv.Atoi(indexString)
+	if err != nil || index < 0 {
+		return "", 0, fmt.Errorf("invalid instance index %q", indexString)
+	}
+
+	return processGUID, index, nil
+}
+
+type ptyRequest struct {
+	Term    string
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+	Modes   string
+}
+
+type windowChangeRequest struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+type execRequest struct {
+	Command string
+}
+
+type exitStatus struct {
+	Status uint32
+}
+
+func (s *Server) handleSession(ctx context.Context, authInfo authorization.Info, pod repositories.AppInstancePodRecord, channel ssh.Channel, requests <-chan *ssh.Request) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// stop the command when the client goes away
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var tty, started bool
+	resize := newResizeQueue()
+
+	for req := range requests {
+		switch req.Type {
+		case "pty-req":
+			ptyReq := ptyRequest{}
+			if err := ssh.Unmarshal(req.Payload, &ptyReq); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			tty = true
+			resize.push(TerminalSize{Width: uint16(ptyReq.Columns), Height: uint16(ptyReq.Rows)})
+			_ = req.Reply(true, nil)
+
+		case "window-change":
+			windowChange := windowChangeRequest{}
+			if err := ssh.Unmarshal(req.Payload, &windowChange); err == nil {
+				resize.push(TerminalSize{Width: uint16(windowChange.Columns), Height: uint16(windowChange.Rows)})
+			}
+			_ = req.Reply(true, nil)
+
+		case "env":
+			// the environment of the app instance cannot be changed
+			_ = req.Reply(true, nil)
+
+		case "shell", "exec":
+			if started {
+				_ = req.Reply(false, nil)
+				continue
+			}
+
+			command := []string{defaultShell}
+			if req.Type == "exec" {
+				execReq := execRequest{}
+				if err := ssh.Unmarshal(req.Payload, &execReq); err != nil {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				command = []string{defaultShell, "-c", execReq.Command}
+			}
+
+			started = true
+			_ = req.Reply(true, nil)
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.exec(ctx, authInfo, pod, channel, ExecRequest{
+					Command: command,
+					TTY:     tty,
+					Stdin:   channel,
+					Stdout:  channel,
+					Stderr:  channel.Stderr(),
+					Resize:  resize.sizes,
+				})
+			}()
+
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+func (s *Server) exec(ctx context.Context, authInfo authorization.Info, pod repositories.AppInstancePodRecord, channel ssh.Channel, request ExecRequest) {
+	defer channel.Close()
+
+	status, err := s.executor.Exec(ctx, authInfo, pod, request)
+	if err != nil {
+		s.logger.Info("ssh session failed", "pod", pod.Name, "namespace", pod.Namespace, "reason", err.Error())
+		_, _ = fmt.Fprintf(channel.Stderr(), "%s\r\n", err.Error())
+		status = 1
+	}
+
+	_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(exitStatus{Status: uint32(status)}))
+}
+
+// resizeQueue only keeps the latest terminal size, so that window changes
+// never block the session while the command is not reading them
+type resizeQueue struct {
+	sizes chan TerminalSize
+}
+
+func newResizeQueue() *resizeQueue {
+	return &resizeQueue{sizes: make(chan TerminalSize, 1)}
+}
+
+func (q *resizeQueue) push(size TerminalSize) {
+	select {
+	case <-q.sizes:
+	default:
+	}
+
+	select {
+	case q.sizes <- size:
+	default:
+	}
+}
@@ -0,0 +1,297 @@
+package sshproxy_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+	"code.cloudfoundry.org/korifi/api/sshproxy/fake"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		codes       *fake.CodeRedeemer
+		processRepo *fake.CFProcessRepository
+		appRepo     *fake.CFAppRepository
+		spaceRepo   *fake.CFSpaceRepository
+		podRepo     *fake.PodRepository
+		executor    *fake.Executor
+
+		hostKey    ssh.Signer
+		cancel     context.CancelFunc
+		serverAddr string
+		user       string
+
+		sshClient *ssh.Client
+		dialErr   error
+	)
+
+	BeforeEach(func() {
+		codes = new(fake.CodeRedeemer)
+		codes.RedeemReturns(authorization.Info{Token: "a-token"}, nil)
+
+		processRepo = new(fake.CFProcessRepository)
+		processRepo.GetProcessReturns(repositories.ProcessRecord{
+			GUID:      "cf-proc-app-guid-worker",
+			AppGUID:   "app-guid",
+			SpaceGUID: "space-guid",
+			Type:      "worker",
+		}, nil)
+
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid", EnableSSH: true}, nil)
+
+		spaceRepo = new(fake.CFSpaceRepository)
+		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{GUID: "space-guid", AllowSSH: true}, nil)
+
+		podRepo = new(fake.PodRepository)
+		podRepo.GetAppInstancePodReturns(repositories.AppInstancePodRecord{
+			Name:          "app-pod-1",
+			Namespace:     "space-guid",
+			ContainerName: "application",
+		}, nil)
+
+		executor = new(fake.Executor)
+		executor.ExecStub = func(_ context.Context, _ authorization.Info, _ repositories.AppInstancePodRecord, request sshproxy.ExecRequest) (int, error) {
+			_, err := io.WriteString(request.Stdout, "hello")
+			return 0, err
+		}
+
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		hostKey, err = ssh.NewSignerFromKey(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		serverAddr = listener.Addr().String()
+
+		var serverCtx context.Context
+		serverCtx, cancel = context.WithCancel(ctx)
+		server := sshproxy.NewServer(hostKey, codes, processRepo, appRepo, spaceRepo, podRepo, executor, logr.Discard())
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.Serve(serverCtx, listener)).To(Succeed())
+		}()
+
+		user = "cf:cf-proc-app-guid-worker/1"
+	})
+
+	JustBeforeEach(func() {
+		sshClient, dialErr = ssh.Dial("tcp", serverAddr, &ssh.ClientConfig{
+			User:            user,
+			Auth:            []ssh.AuthMethod{ssh.Password("the-code")},
+			HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+		})
+	})
+
+	AfterEach(func() {
+		if sshClient != nil {
+			sshClient.Close()
+		}
+		cancel()
+	})
+
+	It("authenticates the user with the code", func() {
+		Expect(dialErr).NotTo(HaveOccurred())
+
+		Expect(codes.RedeemCallCount()).To(Equal(1))
+		_, actualCode := codes.RedeemArgsForCall(0)
+		Expect(actualCode).To(Equal("the-code"))
+
+		Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "a-token"}))
+		Expect(actualProcessGUID).To(Equal("cf-proc-app-guid-worker"))
+
+		Expect(appRepo.GetAppCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "a-token"}))
+		Expect(actualAppGUID).To(Equal("app-guid"))
+
+		Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "a-token"}))
+		Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+		Expect(podRepo.GetAppInstancePodCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualMessage := podRepo.GetAppInstancePodArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "a-token"}))
+		Expect(actualMessage).To(Equal(repositories.GetAppInstancePodMessage{
+			SpaceGUID:     "space-guid",
+			AppGUID:       "app-guid",
+			ProcessType:   "worker",
+			InstanceIndex: 1,
+		}))
+	})
+
+	It("runs commands in the app instance as the user", func() {
+		Expect(dialErr).NotTo(HaveOccurred())
+
+		session, err := sshClient.NewSession()
+		Expect(err).NotTo(HaveOccurred())
+		output, err := session.Output("echo hello")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(output)).To(Equal("hello"))
+
+		Expect(executor.ExecCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualPod, actualRequest := executor.ExecArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "a-token"}))
+		Expect(actualPod).To(Equal(repositories.AppInstancePodRecord{
+			Name:          "app-pod-1",
+			Namespace:     "space-guid",
+			ContainerName: "application",
+		}))
+		Expect(actualRequest.Command).To(Equal([]string{"/bin/sh", "-c", "echo hello"}))
+		Expect(actualRequest.TTY).To(BeFalse())
+	})
+
+	It("opens an interactive shell when a terminal is requested", func() {
+		Expect(dialErr).NotTo(HaveOccurred())
+
+		session, err := sshClient.NewSession()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(session.RequestPty("xterm", 40, 80, ssh.TerminalModes{})).To(Succeed())
+		Expect(session.Shell()).To(Succeed())
+		Expect(session.Wait()).To(Succeed())
+
+		Expect(executor.ExecCallCount()).To(Equal(1))
+		_, _, _, actualRequest := executor.ExecArgsForCall(0)
+		Expect(actualRequest.Command).To(Equal([]string{"/bin/sh"}))
+		Expect(actualRequest.TTY).To(BeTrue())
+		Expect(actualRequest.Resize).To(Receive(Equal(sshproxy.TerminalSize{Width: 80, Height: 40})))
+	})
+
+	When("the command exits with a non-zero status", func() {
+		BeforeEach(func() {
+			executor.ExecReturns(42, nil)
+		})
+
+		It("reports the exit status", func() {
+			session, err := sshClient.NewSession()
+			Expect(err).NotTo(HaveOccurred())
+
+			var exitErr *ssh.ExitError
+			Expect(errors.As(session.Run("false"), &exitErr)).To(BeTrue())
+			Expect(exitErr.ExitStatus()).To(Equal(42))
+		})
+	})
+
+	When("the command cannot be run", func() {
+		BeforeEach(func() {
+			executor.ExecReturns(0, errors.New("exec-failed"))
+		})
+
+		It("reports the error and fails", func() {
+			session, err := sshClient.NewSession()
+			Expect(err).NotTo(HaveOccurred())
+
+			output, err := session.CombinedOutput("true")
+			Expect(err).To(HaveOccurred())
+			Expect(string(output)).To(ContainSubstring("exec-failed"))
+		})
+	})
+
+	DescribeTable("invalid users",
+		func(invalidUser string) {
+			_, err := ssh.Dial("tcp", serverAddr, &ssh.ClientConfig{
+				User:            invalidUser,
+				Auth:            []ssh.AuthMethod{ssh.Password("the-code")},
+				HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+			})
+			Expect(err).To(MatchError(ContainSubstring("unable to authenticate")))
+			Expect(codes.RedeemCallCount()).To(Equal(1), fmt.Sprintf("only the valid user of the outer test should redeem the code, not %q", invalidUser))
+		},
+		Entry("missing prefix", "cf-proc-app-guid-web/0"),
+		Entry("missing index", "cf:cf-proc-app-guid-web"),
+		Entry("missing process guid", "cf:/0"),
+		Entry("invalid index", "cf:cf-proc-app-guid-web/first"),
+		Entry("negative index", "cf:cf-proc-app-guid-web/-1"),
+	)
+
+	When("the code is invalid", func() {
+		BeforeEach(func() {
+			codes.RedeemReturns(authorization.Info{}, sshproxy.ErrInvalidCode)
+		})
+
+		It("fails to authenticate", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+		})
+	})
+
+	When("the user cannot get the process", func() {
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{}, errors.New("not-found"))
+		})
+
+		It("fails to authenticate", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+			Expect(appRepo.GetAppCallCount()).To(BeZero())
+		})
+	})
+
+	When("the user cannot get the app", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("forbidden"))
+		})
+
+		It("fails to authenticate", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+		})
+	})
+
+	When("ssh is disabled for the space", func() {
+		BeforeEach(func() {
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{GUID: "space-guid", AllowSSH: false}, nil)
+		})
+
+		It("fails to authenticate", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+		})
+	})
+
+	When("ssh is disabled for the app", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid", EnableSSH: false}, nil)
+		})
+
+		It("fails to authenticate", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+		})
+	})
+
+	When("the app instance does not exist", func() {
+		BeforeEach(func() {
+			podRepo.GetAppInstancePodReturns(repositories.AppInstancePodRecord{}, errors.New("not-found"))
+		})
+
+		It("fails to authenticate", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+		})
+	})
+})
+
+var _ = Describe("HostKeyFingerprint", func() {
+	It("returns the unpadded base64 encoded sha256 of the key", func() {
+		publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		sshPublicKey, err := ssh.NewPublicKey(publicKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		fingerprint := sshproxy.HostKeyFingerprint(sshPublicKey)
+		Expect(fingerprint).To(HaveLen(43))
+		Expect("SHA256:" + fingerprint).To(Equal(ssh.FingerprintSHA256(sshPublicKey)))
+	})
+})
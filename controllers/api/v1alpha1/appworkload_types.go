@@ -21,6 +21,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// VersionLabelKey is the label runners set on the AppWorkload instances
+	// to the AppWorkload version, i.e. the app revision the instances run
+	VersionLabelKey = "korifi.cloudfoundry.org/version"
+)
+
 // AppWorkloadSpec defines the desired state of AppWorkload
 type AppWorkloadSpec struct {
 	// +kubebuilder:validation:Required
@@ -52,6 +58,34 @@ type AppWorkloadSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Whether the AppWorkload runs the canary instances of a new app revision. Runners should run these next to the instances of the current revision of the process
+	// +kubebuilder:validation:Optional
+	Canary bool `json:"canary,omitempty"`
+
+	// The node labels the instances are required to match, as defined by the isolation segment of the space
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations of the instances, as defined by the isolation segment of the space
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Additional containers run next to the application container of every instance
+	// +kubebuilder:validation:Optional
+	Sidecars []AppWorkloadSidecar `json:"sidecars,omitempty"`
+}
+
+// AppWorkloadSidecar describes an additional container of the AppWorkload instances. Sidecars run the same image and environment as the application container
+type AppWorkloadSidecar struct {
+	// The name of the sidecar
+	Name string `json:"name"`
+
+	// The command run by the sidecar container
+	Command []string `json:"command"`
+
+	// +kubebuilder:validation:Optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // AppWorkloadStatus defines the observed state of AppWorkload
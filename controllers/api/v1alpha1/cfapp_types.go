@@ -44,6 +44,55 @@ type CFAppSpec struct {
 
 	// A reference to the CFBuild currently assigned to the app. The CFBuild must be in the same namespace.
 	CurrentDropletRef v1.LocalObjectReference `json:"currentDropletRef,omitempty"`
+
+	// The new app revision run next to the current one while a canary deployment is paused
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+
+	// Whether SSH access to the app instances is enabled. SSH access also requires the app space to allow it
+	// +optional
+	EnableSSH bool `json:"enableSSH,omitempty"`
+
+	// Whether a CFRevision is recorded every time the droplet, the environment variables or the process commands of the app change
+	// +optional
+	EnableRevisions bool `json:"enableRevisions,omitempty"`
+
+	// Additional commands run next to the processes of the app, in their own containers
+	// +optional
+	Sidecars []Sidecar `json:"sidecars,omitempty"`
+}
+
+// Sidecar describes an additional command run next to the app processes of the listed types
+type Sidecar struct {
+	// The immutable identifier of the sidecar, used by the CF API
+	GUID string `json:"guid"`
+
+	// The name of the sidecar, unique within the app
+	Name string `json:"name"`
+
+	// The command run by the sidecar
+	Command string `json:"command"`
+
+	// The process types the sidecar runs next to
+	// +kubebuilder:validation:MinItems=1
+	ProcessTypes []string `json:"processTypes"`
+
+	// The memory reserved for the sidecar. When not set, the sidecar shares the memory of the process
+	// +optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+}
+
+// CanaryInstances is the number of instances each process of the app runs
+// with the canary revision
+const CanaryInstances int32 = 1
+
+// CanarySpec describes the canary revision of a CFApp
+type CanarySpec struct {
+	// A reference to the CFBuild the canary instances run. The CFBuild must be in the same namespace.
+	DropletRef v1.LocalObjectReference `json:"dropletRef"`
+
+	// The app revision of the canary instances
+	Revision string `json:"revision"`
 }
 
 // AppState defines the desired state of CFApp.
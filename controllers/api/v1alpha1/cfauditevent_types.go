@@ -0,0 +1,90 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	CFAuditEventTypeLabelKey       = "korifi.cloudfoundry.org/audit-event-type"
+	CFAuditEventTargetGUIDLabelKey = "korifi.cloudfoundry.org/audit-event-target-guid"
+	CFAuditEventSpaceGUIDLabelKey  = "korifi.cloudfoundry.org/audit-event-space-guid"
+	CFAuditEventOrgGUIDLabelKey    = "korifi.cloudfoundry.org/audit-event-org-guid"
+)
+
+// CFAuditEventSpec records an action performed through the CF API
+type CFAuditEventSpec struct {
+	// The type of the event, e.g. `audit.app.create`
+	Type string `json:"type"`
+
+	// The identity that performed the action
+	Actor AuditEventActor `json:"actor"`
+
+	// The resource the action was performed on
+	Target AuditEventTarget `json:"target"`
+
+	// The guid of the space the target belongs to
+	// +optional
+	SpaceGUID string `json:"spaceGuid,omitempty"`
+
+	// The guid of the org the target belongs to
+	// +optional
+	OrganizationGUID string `json:"organizationGuid,omitempty"`
+
+	// The correlation ID of the request that performed the action
+	// +optional
+	CorrelationID string `json:"correlationId,omitempty"`
+
+	// Additional information about the action
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Data *runtime.RawExtension `json:"data,omitempty"`
+}
+
+type AuditEventActor struct {
+	GUID string `json:"guid"`
+
+	// The kind of identity, e.g. `user` or `service_account`
+	Type string `json:"type"`
+
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+type AuditEventTarget struct {
+	GUID string `json:"guid"`
+
+	// The kind of resource, e.g. `app` or `service_binding`
+	Type string `json:"type"`
+
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Actor",type=string,JSONPath=`.spec.actor.name`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target.name`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFAuditEvent is the Schema for the cfauditevents API. Audit events are
+// immutable records created by the API in the root namespace
+type CFAuditEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAuditEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFAuditEventList contains a list of CFAuditEvent
+type CFAuditEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAuditEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAuditEvent{}, &CFAuditEventList{})
+}
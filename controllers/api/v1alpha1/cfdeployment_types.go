@@ -0,0 +1,103 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DeploymentFinalizedConditionType = "Finalized"
+
+	DeploymentDeployingReason  = "Deploying"
+	DeploymentPausedReason     = "Paused"
+	DeploymentDeployedReason   = "Deployed"
+	DeploymentCancelingReason  = "Canceling"
+	DeploymentCanceledReason   = "Canceled"
+	DeploymentSupersededReason = "Superseded"
+
+	DeploymentStrategyRolling DeploymentStrategy = "rolling"
+	DeploymentStrategyCanary  DeploymentStrategy = "canary"
+)
+
+// DeploymentStrategy defines how the new app revision is rolled out
+type DeploymentStrategy string
+
+// CFDeploymentSpec defines the desired state of CFDeployment
+type CFDeploymentSpec struct {
+	// A reference to the CFApp being deployed
+	AppRef corev1.LocalObjectReference `json:"appRef"`
+
+	// A reference to the CFBuild deployed
+	DropletRef corev1.LocalObjectReference `json:"dropletRef"`
+
+	// The app revision the deployment rolls out
+	Revision string `json:"revision"`
+
+	// A reference to the CFRevision the deployment rolls the app back to. The environment variables and process commands of the revision are applied to the app once it runs the deployment revision
+	// +optional
+	RollbackRevisionRef corev1.LocalObjectReference `json:"rollbackRevisionRef,omitempty"`
+
+	// A reference to the CFBuild the app was running before the deployment. The app is rolled back to it when the deployment is canceled
+	// +optional
+	PreviousDropletRef corev1.LocalObjectReference `json:"previousDropletRef,omitempty"`
+
+	// The app revision before the deployment. The app is rolled back to it when the deployment is canceled
+	// +optional
+	PreviousRevision string `json:"previousRevision,omitempty"`
+
+	// The strategy used to roll out the new app revision. A canary deployment pauses after starting a single instance of the new revision per process, until it is continued
+	// +kubebuilder:validation:Enum=rolling;canary
+	// +kubebuilder:default:=rolling
+	// +optional
+	Strategy DeploymentStrategy `json:"strategy,omitempty"`
+
+	// A boolean describing whether the CFDeployment has been canceled
+	// +optional
+	Canceled bool `json:"canceled"`
+
+	// A boolean describing whether the paused canary CFDeployment has been continued
+	// +optional
+	Continued bool `json:"continued"`
+}
+
+// CFDeploymentStatus defines the observed state of CFDeployment
+type CFDeploymentStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFDeployment that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Revision",type=string,JSONPath=`.spec.revision`
+//+kubebuilder:printcolumn:name="Strategy",type=string,JSONPath=`.spec.strategy`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFDeployment is the Schema for the cfdeployments API
+type CFDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFDeploymentSpec   `json:"spec,omitempty"`
+	Status CFDeploymentStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFDeploymentList contains a list of CFDeployment
+type CFDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFDeployment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFDeployment{}, &CFDeploymentList{})
+}
+
+func (d CFDeployment) StatusConditions() []metav1.Condition {
+	return d.Status.Conditions
+}
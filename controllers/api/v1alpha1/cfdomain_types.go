@@ -28,6 +28,25 @@ const (
 type CFDomainSpec struct {
 	// The domain name. It is required and must conform to RFC 1035
 	Name string `json:"name"`
+
+	// Internal domains are only resolvable from within the cluster. Routes
+	// on internal domains are not exposed through the gateway
+	//+kubebuilder:validation:Optional
+	Internal bool `json:"internal,omitempty"`
+
+	// The guid of the organization owning a private domain. Domains without
+	// an owning organization are shared with all organizations
+	//+kubebuilder:validation:Optional
+	OrganizationGUID string `json:"organizationGUID,omitempty"`
+
+	// The guids of the organizations a private domain is shared with
+	//+kubebuilder:validation:Optional
+	SharedOrganizations []string `json:"sharedOrganizations,omitempty"`
+
+	// The name of the router group of a tcp domain. Routes on tcp domains
+	// are exposed on a dedicated gateway port instead of a host and path
+	//+kubebuilder:validation:Optional
+	RouterGroup string `json:"routerGroup,omitempty"`
 }
 
 // CFDomainStatus defines the observed state of CFDomain
@@ -66,3 +85,10 @@ type CFDomainList struct {
 func init() {
 	SchemeBuilder.Register(&CFDomain{}, &CFDomainList{})
 }
+
+// InternalRoutesNamespace is the namespace of the services resolving the
+// routes of an internal domain. Every route host has its own service, so that
+// cluster DNS resolves <host>.<domain> with a single rewrite rule per domain.
+func (d CFDomain) InternalRoutesNamespace() string {
+	return d.Name
+}
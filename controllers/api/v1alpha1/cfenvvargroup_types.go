@@ -0,0 +1,44 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RunningEnvVarGroupName = "running"
+	StagingEnvVarGroupName = "staging"
+)
+
+// CFEnvVarGroupSpec defines the desired state of CFEnvVarGroup
+type CFEnvVarGroupSpec struct {
+	// The environment variables injected into the workloads the group applies to
+	// +optional
+	Var map[string]string `json:"var,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFEnvVarGroup is the Schema for the cfenvvargroups API. Environment
+// variable groups live in the root namespace and are named after the
+// workloads they apply to: the `running` group is injected into every app
+// process and task, the `staging` group into every build
+type CFEnvVarGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFEnvVarGroupSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFEnvVarGroupList contains a list of CFEnvVarGroup
+type CFEnvVarGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFEnvVarGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFEnvVarGroup{}, &CFEnvVarGroupList{})
+}
@@ -0,0 +1,49 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	FeatureFlagDiegoDocker  = "diego_docker"
+	FeatureFlagAppScaling   = "app_scaling"
+	FeatureFlagTaskCreation = "task_creation"
+)
+
+// CFFeatureFlagSpec defines the desired state of CFFeatureFlag
+type CFFeatureFlagSpec struct {
+	// Whether the feature is enabled
+	Enabled bool `json:"enabled"`
+
+	// The message returned to the users when they attempt an operation the
+	// disabled feature guards
+	// +optional
+	CustomErrorMessage string `json:"customErrorMessage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Enabled",type=boolean,JSONPath=`.spec.enabled`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFFeatureFlag is the Schema for the cffeatureflags API. Feature flags live
+// in the root namespace and are named after the feature they toggle. Flags
+// that do not exist take their default value
+type CFFeatureFlag struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFFeatureFlagSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFFeatureFlagList contains a list of CFFeatureFlag
+type CFFeatureFlagList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFFeatureFlag `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFFeatureFlag{}, &CFFeatureFlagList{})
+}
@@ -0,0 +1,70 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IsolationSegmentGUIDLabelKey is the label set on space namespaces to the
+// guid of the isolation segment assigned to the space. It is empty when the
+// space has no isolation segment
+const IsolationSegmentGUIDLabelKey = "korifi.cloudfoundry.org/isolation-segment-guid"
+
+// CFIsolationSegmentSpec defines the desired state of CFIsolationSegment
+type CFIsolationSegmentSpec struct {
+	// The mutable, user-friendly name of the isolation segment
+	DisplayName string `json:"displayName"`
+
+	// The node labels the instances of workloads in spaces assigned to the
+	// isolation segment are required to match
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations set on the instances of workloads in spaces assigned
+	// to the isolation segment, usually matching the taints of a dedicated
+	// node pool
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// The guids of the organizations entitled to the isolation segment
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFIsolationSegment is the Schema for the cfisolationsegments API. Isolation
+// segments live in the root namespace and are assigned to spaces via
+// CFSpace.Spec.IsolationSegmentRef
+type CFIsolationSegment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFIsolationSegmentSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFIsolationSegmentList contains a list of CFIsolationSegment
+type CFIsolationSegmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFIsolationSegment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFIsolationSegment{}, &CFIsolationSegmentList{})
+}
+
+func (s CFIsolationSegment) UniqueName() string {
+	return strings.ToLower(s.Spec.DisplayName)
+}
+
+func (s CFIsolationSegment) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("The isolation segment name is taken: %s", s.Spec.DisplayName)
+}
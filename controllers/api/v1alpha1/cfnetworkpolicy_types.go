@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NetworkPolicyProtocolTCP = "tcp"
+	NetworkPolicyProtocolUDP = "udp"
+)
+
+// CFNetworkPolicySpec defines the desired state of CFNetworkPolicy
+type CFNetworkPolicySpec struct {
+	// The app the traffic is allowed from
+	Source NetworkPolicySource `json:"source"`
+
+	// A reference to the CFApp in the namespace of the network policy the
+	// traffic is allowed to
+	DestinationAppRef v1.LocalObjectReference `json:"destinationAppRef"`
+
+	// +kubebuilder:validation:Enum=tcp;udp
+	Protocol string `json:"protocol"`
+
+	// The first port of the destination port range
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	StartPort int32 `json:"startPort"`
+
+	// The last port of the destination port range
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	EndPort int32 `json:"endPort"`
+}
+
+type NetworkPolicySource struct {
+	// The guid of the source CFApp
+	AppGUID string `json:"appGUID"`
+
+	// The guid of the space of the source CFApp
+	SpaceGUID string `json:"spaceGUID"`
+}
+
+// CFNetworkPolicyStatus defines the observed state of CFNetworkPolicy
+type CFNetworkPolicyStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFNetworkPolicy that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.source.appGUID`
+//+kubebuilder:printcolumn:name="Destination",type=string,JSONPath=`.spec.destinationAppRef.name`
+//+kubebuilder:printcolumn:name="Protocol",type=string,JSONPath=`.spec.protocol`
+//+kubebuilder:printcolumn:name="Start Port",type=integer,JSONPath=`.spec.startPort`
+//+kubebuilder:printcolumn:name="End Port",type=integer,JSONPath=`.spec.endPort`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFNetworkPolicy is the Schema for the cfnetworkpolicies API. Network
+// policies live in the namespace of the destination app and are translated
+// into NetworkPolicies allowing ingress traffic from the source app
+type CFNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFNetworkPolicySpec   `json:"spec,omitempty"`
+	Status CFNetworkPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFNetworkPolicyList contains a list of CFNetworkPolicy
+type CFNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFNetworkPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFNetworkPolicy{}, &CFNetworkPolicyList{})
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1/status"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -37,6 +38,10 @@ type CFOrgSpec struct {
 	// The mutable, user-friendly name of the CFOrg. Unlike metadata.name, the user can change this field.
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// A reference to the CFOrgQuota applied to it, living in the root namespace. No limits are enforced when empty
+	// +optional
+	QuotaRef corev1.LocalObjectReference `json:"quotaRef,omitempty"`
 }
 
 // CFOrgStatus defines the observed state of CFOrg
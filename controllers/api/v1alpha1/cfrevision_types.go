@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CFAppRollbackRevisionKey is set on a CFApp while it is being rolled
+	// back to the CFRevision it names. No revision is recorded until the app
+	// matches that revision again.
+	CFAppRollbackRevisionKey = "korifi.cloudfoundry.org/rollback-revision"
+)
+
+// CFRevisionSpec defines the state of a CFApp recorded by a CFRevision
+type CFRevisionSpec struct {
+	// A reference to the CFApp the revision belongs to
+	AppRef corev1.LocalObjectReference `json:"appRef"`
+
+	// The version of the revision. Versions of the revisions of an app start at 1 and are incremented for every new revision
+	Version int `json:"version"`
+
+	// A reference to the CFBuild the app was running
+	DropletRef corev1.LocalObjectReference `json:"dropletRef"`
+
+	// The name of a Secret in the same namespace, which contains a copy of the app environment variables
+	EnvSecretName string `json:"envSecretName"`
+
+	// The commands of the app processes
+	// +optional
+	Processes []RevisionProcess `json:"processes,omitempty"`
+
+	// A human readable description of what changed since the previous revision
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// RevisionProcess records the command of an app process
+type RevisionProcess struct {
+	// The process type
+	Type string `json:"type"`
+
+	// The custom command of the process. Empty when the process runs the command detected by the build
+	// +optional
+	Command string `json:"command,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Version",type=integer,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFRevision is the Schema for the cfrevisions API. Revisions are immutable
+// records of the droplet, environment variables and process commands of a
+// CFApp.
+type CFRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFRevisionSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFRevisionList contains a list of CFRevision
+type CFRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFRevision{}, &CFRevisionList{})
+}
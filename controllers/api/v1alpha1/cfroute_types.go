@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
@@ -42,8 +43,8 @@ type Destination struct {
 	AppRef v1.LocalObjectReference `json:"appRef"`
 	// The process type on the CFApp app which will receive traffic
 	ProcessType string `json:"processType"`
-	// Protocol is optional, when set must be "http1"
-	// +kubebuilder:validation:Enum=http1
+	// Protocol is optional, when set must be "http1" or "http2"
+	// +kubebuilder:validation:Enum=http1;http2
 	//+kubebuilder:validation:Optional
 	Protocol *string `json:"protocol,omitempty"`
 }
@@ -52,6 +53,11 @@ type Destination struct {
 // +kubebuilder:validation:Enum=http;tcp
 type Protocol string
 
+const (
+	ProtocolHTTP Protocol = "http"
+	ProtocolTCP  Protocol = "tcp"
+)
+
 // CFRouteSpec defines the desired state of CFRoute
 type CFRouteSpec struct {
 	// The subdomain of the route within the domain. Host is optional and defaults to empty.
@@ -59,8 +65,13 @@ type CFRouteSpec struct {
 	Host string `json:"host,omitempty"`
 	// Path is optional, defaults to empty
 	Path string `json:"path,omitempty"`
-	// Protocol is optional and defaults to http. Currently only http is supported
+	// Protocol is optional and defaults to http. Routes on domains assigned
+	// to a router group must be tcp
 	Protocol Protocol `json:"protocol,omitempty"`
+	// The port of a tcp route on the gateway. Required for tcp routes, must
+	// be empty for http routes
+	//+kubebuilder:validation:Optional
+	Port int `json:"port,omitempty"`
 	// A reference to the CFDomain this CFRoute is assigned to, including name and namespace
 	DomainRef v1.ObjectReference `json:"domainRef"`
 	// Destinations are optional. A route can exist without any destinations, independently of any CFApps
@@ -113,10 +124,20 @@ func init() {
 }
 
 func (r CFRoute) UniqueName() string {
+	// tcp routes are exposed on a dedicated gateway listener, so their ports
+	// must be unique across all domains
+	if r.Spec.Protocol == ProtocolTCP {
+		return strings.Join([]string{string(ProtocolTCP), strconv.Itoa(r.Spec.Port)}, "::")
+	}
+
 	return strings.Join([]string{strings.ToLower(r.Spec.Host), r.Spec.DomainRef.Namespace, r.Spec.DomainRef.Name, r.Spec.Path}, "::")
 }
 
 func (r CFRoute) UniqueValidationErrorMessage() string {
+	if r.Spec.Protocol == ProtocolTCP {
+		return fmt.Sprintf("Port %d is not available. Try a different port or use a different domain.", r.Spec.Port)
+	}
+
 	pathDetails := ""
 
 	if r.Spec.Path != "" {
@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SecurityGroupProtocolTCP  = "tcp"
+	SecurityGroupProtocolUDP  = "udp"
+	SecurityGroupProtocolICMP = "icmp"
+	SecurityGroupProtocolAll  = "all"
+)
+
+// CFSecurityGroupSpec defines the desired state of CFSecurityGroup
+type CFSecurityGroupSpec struct {
+	// The mutable, user-friendly name of the security group
+	DisplayName string `json:"displayName"`
+
+	// The egress rules allowed by the security group
+	// +optional
+	Rules []SecurityGroupRule `json:"rules,omitempty"`
+
+	// The workloads the security group applies to in every space
+	// +optional
+	GloballyEnabled SecurityGroupWorkloads `json:"globallyEnabled,omitempty"`
+
+	// The spaces the security group is bound to, keyed by space guid
+	// +optional
+	Spaces map[string]SecurityGroupWorkloads `json:"spaces,omitempty"`
+}
+
+type SecurityGroupRule struct {
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;all
+	Protocol string `json:"protocol"`
+
+	// An IP address, a CIDR or a range of IP addresses separated by a dash.
+	// Several destinations can be separated by commas
+	Destination string `json:"destination"`
+
+	// A single port, a range of ports separated by a dash or a comma
+	// separated list of ports. Only used by the tcp and udp protocols
+	// +optional
+	Ports string `json:"ports,omitempty"`
+
+	// The ICMP type. Only used by the icmp protocol
+	// +optional
+	Type *int32 `json:"type,omitempty"`
+
+	// The ICMP code. Only used by the icmp protocol
+	// +optional
+	Code *int32 `json:"code,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// +optional
+	Log bool `json:"log,omitempty"`
+}
+
+type SecurityGroupWorkloads struct {
+	// Whether the security group applies to running app instances
+	// +optional
+	Running bool `json:"running,omitempty"`
+
+	// Whether the security group applies to staging builds
+	// +optional
+	Staging bool `json:"staging,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Running",type=boolean,JSONPath=`.spec.globallyEnabled.running`
+//+kubebuilder:printcolumn:name="Staging",type=boolean,JSONPath=`.spec.globallyEnabled.staging`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFSecurityGroup is the Schema for the cfsecuritygroups API. Security
+// groups live in the root namespace and are compiled into NetworkPolicies in
+// the namespaces of the spaces they apply to
+type CFSecurityGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFSecurityGroupSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFSecurityGroupList contains a list of CFSecurityGroup
+type CFSecurityGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSecurityGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSecurityGroup{}, &CFSecurityGroupList{})
+}
+
+// AppliesTo returns the workloads the security group applies to in the given space
+func (g *CFSecurityGroup) AppliesTo(spaceGUID string) SecurityGroupWorkloads {
+	bound := g.Spec.Spaces[spaceGUID]
+	return SecurityGroupWorkloads{
+		Running: g.Spec.GloballyEnabled.Running || bound.Running,
+		Staging: g.Spec.GloballyEnabled.Staging || bound.Staging,
+	}
+}
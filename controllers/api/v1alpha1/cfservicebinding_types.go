@@ -19,10 +19,20 @@ package v1alpha1
 import (
 	"fmt"
 
+	"code.cloudfoundry.org/korifi/model/services"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	CFServiceBindingFinalizerName = "cfServiceBinding.korifi.cloudfoundry.org"
+
+	BoundCondition = "Bound"
+
+	CFServiceBindingTypeApp = "app"
+	CFServiceBindingTypeKey = "key"
+)
+
 // CFServiceBindingSpec defines the desired state of CFServiceBinding
 type CFServiceBindingSpec struct {
 	// The mutable, user-friendly name of the service binding. Unlike metadata.name, the user can change this field
@@ -31,8 +41,17 @@ type CFServiceBindingSpec struct {
 	// The Service this binding uses. When created by the korifi API, this will refer to a CFServiceInstance
 	Service v1.ObjectReference `json:"service"`
 
-	// A reference to the CFApp that owns this service binding. The CFApp must be in the same namespace
-	AppRef v1.LocalObjectReference `json:"appRef"`
+	// The type of the binding. App bindings expose the service credentials to
+	// the referenced app, key bindings (service keys) are not attached to any app
+	// +kubebuilder:validation:Enum=app;key
+	// +kubebuilder:default=app
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// A reference to the CFApp that owns this service binding. The CFApp must be in the same namespace.
+	// Only applicable to bindings of type `app`
+	// +optional
+	AppRef v1.LocalObjectReference `json:"appRef,omitempty"`
 }
 
 // CFServiceBindingStatus defines the observed state of CFServiceBinding
@@ -46,7 +65,8 @@ type CFServiceBindingStatus struct {
 
 	// A reference to the Secret containing the binding Credentials object. For
 	// bindings to user-provided services this refers to the credentials secret
-	// from the service instance
+	// from the service instance. For bindings to managed services this refers
+	// to a secret containing the credentials returned by the service broker
 	// +optional
 	Credentials v1.LocalObjectReference `json:"credentials"`
 
@@ -55,6 +75,16 @@ type CFServiceBindingStatus struct {
 
 	// ObservedGeneration captures the latest generation of the CFServiceBinding that has been reconciled
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The last broker operation performed on the service binding
+	// Only applicable to bindings to `managed` service instances
+	//+kubebuilder:validation:Optional
+	LastOperation *services.LastOperation `json:"lastOperation,omitempty"`
+
+	// The token of the asynchronous broker operation in progress, used to poll its state
+	// Only applicable to bindings to `managed` service instances
+	//+kubebuilder:validation:Optional
+	BrokerOperation string `json:"brokerOperation,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -85,14 +115,34 @@ func (b CFServiceBinding) StatusConditions() []metav1.Condition {
 	return b.Status.Conditions
 }
 
+func (b CFServiceBinding) IsKey() bool {
+	return b.Spec.Type == CFServiceBindingTypeKey
+}
+
 func (b CFServiceBinding) UniqueName() string {
+	if b.IsKey() {
+		return fmt.Sprintf("sk::%s::%s::%s", b.Spec.Service.Namespace, b.Spec.Service.Name, displayNameOrEmpty(b.Spec.DisplayName))
+	}
+
 	return fmt.Sprintf("sb::%s::%s::%s", b.Spec.AppRef.Name, b.Spec.Service.Namespace, b.Spec.Service.Name)
 }
 
 func (b CFServiceBinding) UniqueValidationErrorMessage() string {
+	if b.IsKey() {
+		return fmt.Sprintf("The binding name is invalid. Key binding names must be unique. The service instance already has a key binding with name '%s'.", displayNameOrEmpty(b.Spec.DisplayName))
+	}
+
 	return fmt.Sprintf("Service binding already exists: App: %s Service Instance: %s", b.Spec.AppRef.Name, b.Spec.Service.Name)
 }
 
+func displayNameOrEmpty(displayName *string) string {
+	if displayName == nil {
+		return ""
+	}
+
+	return *displayName
+}
+
 func init() {
 	SchemeBuilder.Register(&CFServiceBinding{}, &CFServiceBindingList{})
 }
@@ -19,12 +19,19 @@ package v1alpha1
 import (
 	"fmt"
 
+	"code.cloudfoundry.org/korifi/model/services"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
 	UserProvidedType = "user-provided"
+	ManagedType      = "managed"
+
+	CFServiceInstanceFinalizerName = "cfServiceInstance.korifi.cloudfoundry.org"
+
+	ProvisionedCondition = "Provisioned"
 )
 
 // CFServiceInstanceSpec defines the desired state of CFServiceInstance
@@ -33,11 +40,23 @@ type CFServiceInstanceSpec struct {
 	DisplayName string `json:"displayName"`
 
 	// Name of a secret containing the service credentials. The Secret must be in the same namespace
+	// Only applicable to `user-provided` service instances
+	// +optional
 	SecretName string `json:"secretName"`
 
-	// Type of the Service Instance. Must be `user-provided`
+	// Type of the Service Instance. Must be `user-provided` or `managed`
 	Type InstanceType `json:"type"`
 
+	// The GUID of the CFServicePlan the instance is provisioned with
+	// Only applicable to `managed` service instances
+	// +optional
+	PlanGUID string `json:"planGuid,omitempty"`
+
+	// Parameters passed to the broker when provisioning the instance
+	// Only applicable to `managed` service instances
+	// +optional
+	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
+
 	// Service label to use when adding this instance to VCAP_Services
 	// Defaults to `user-provided` when this field is not set
 	// +optional
@@ -48,7 +67,7 @@ type CFServiceInstanceSpec struct {
 }
 
 // InstanceType defines the type of the Service Instance
-// +kubebuilder:validation:Enum=user-provided
+// +kubebuilder:validation:Enum=user-provided;managed
 type InstanceType string
 
 // CFServiceInstanceStatus defines the observed state of CFServiceInstance
@@ -68,6 +87,16 @@ type CFServiceInstanceStatus struct {
 	// This will ensure that interested contollers are notified on instance credentials change
 	//+kubebuilder:validation:Optional
 	CredentialsObservedVersion string `json:"credentialsObservedVersion,omitempty"`
+
+	// The last broker operation performed on the service instance
+	// Only applicable to `managed` service instances
+	//+kubebuilder:validation:Optional
+	LastOperation *services.LastOperation `json:"lastOperation,omitempty"`
+
+	// The token of the asynchronous broker operation in progress, used to poll its state
+	// Only applicable to `managed` service instances
+	//+kubebuilder:validation:Optional
+	BrokerOperation string `json:"brokerOperation,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1/status"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -36,6 +37,18 @@ type CFSpaceSpec struct {
 	// The mutable, user-friendly name of the space. Unlike metadata.name, the user can change this field
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// A reference to the CFSpaceQuota applied to it, living in the org namespace. No limits are enforced when empty
+	// +optional
+	QuotaRef corev1.LocalObjectReference `json:"quotaRef,omitempty"`
+
+	// Whether SSH access to the instances of the apps in the space is allowed
+	// +optional
+	AllowSSH bool `json:"allowSSH,omitempty"`
+
+	// A reference to the CFIsolationSegment the apps in the space run on, living in the root namespace. Apps run on the shared nodes when empty
+	// +optional
+	IsolationSegmentRef corev1.LocalObjectReference `json:"isolationSegmentRef,omitempty"`
 }
 
 // CFSpaceStatus defines the observed state of CFSpace
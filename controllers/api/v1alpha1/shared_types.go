@@ -25,6 +25,11 @@ const (
 	PropagateDeletionAnnotation       = "cloudfoundry.org/propagate-deletion"
 	PropagatedFromLabel               = "cloudfoundry.org/propagated-from"
 
+	// RetryDeletionAnnotation is set on a service instance or binding that is
+	// deleted again after the broker has failed to delete it, so that the
+	// controllers retry the request to the broker
+	RetryDeletionAnnotation = "korifi.cloudfoundry.org/retry-deletion"
+
 	CredentialsSecretKey = "credentials"
 
 	RelationshipsLabelPrefix = "korifi.cloudfoundry.org/rel-"
@@ -68,3 +73,36 @@ type Registry struct {
 type RequiredLocalObjectReference struct {
 	Name string `json:"name"`
 }
+
+// QuotaLimits is shared by CFOrgQuota and CFSpaceQuota. A missing limit means unlimited
+type QuotaLimits struct {
+	// The maximum amount of memory in MB all the started app instances can use
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TotalMemoryMB *int64 `json:"totalMemoryMB,omitempty"`
+
+	// The maximum amount of memory in MB a single app instance or task can use
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InstanceMemoryMB *int64 `json:"instanceMemoryMB,omitempty"`
+
+	// The maximum number of started app instances
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TotalAppInstances *int64 `json:"totalAppInstances,omitempty"`
+
+	// The maximum number of tasks each app can run at the same time
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AppTasks *int64 `json:"appTasks,omitempty"`
+
+	// The maximum number of service instances
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TotalServiceInstances *int64 `json:"totalServiceInstances,omitempty"`
+
+	// The maximum number of routes
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TotalRoutes *int64 `json:"totalRoutes,omitempty"`
+}
@@ -106,6 +106,7 @@ var _ = BeforeSuite(func() {
 	Expect((&korifiv1alpha1.CFApp{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(apps.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType)),
+		validation.NewQuotaValidator(uncachedClient, namespace, 1024),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect((&korifiv1alpha1.CFRoute{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
@@ -113,6 +114,7 @@ var _ = BeforeSuite(func() {
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routes.RouteEntityType)),
 		namespace,
 		uncachedClient,
+		validation.NewQuotaValidator(uncachedClient, namespace, 1024),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect(domains.NewValidator(uncachedClient).SetupWebhookWithManager(k8sManager)).To(Succeed())
@@ -20,6 +20,7 @@ type ControllerConfig struct {
 	CFRootNamespace                  string             `yaml:"cfRootNamespace"`
 	ContainerRegistrySecretNames     []string           `yaml:"containerRegistrySecretNames"`
 	TaskTTL                          string             `yaml:"taskTTL"`
+	AuditEventTTL                    string             `yaml:"auditEventTTL"`
 	BuilderName                      string             `yaml:"builderName"`
 	RunnerName                       string             `yaml:"runnerName"`
 	NamespaceLabels                  map[string]string  `yaml:"namespaceLabels"`
@@ -66,10 +67,11 @@ type Networking struct {
 }
 
 const (
-	defaultTaskTTL            = 30 * 24 * time.Hour
-	defaultTimeout      int64 = 60
-	defaultJobTTL             = 24 * time.Hour
-	defaultBuildCacheMB       = 2048
+	defaultTaskTTL             = 30 * 24 * time.Hour
+	defaultAuditEventTTL       = 31 * 24 * time.Hour
+	defaultTimeout       int64 = 60
+	defaultJobTTL              = 24 * time.Hour
+	defaultBuildCacheMB        = 2048
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -111,6 +113,14 @@ func (c ControllerConfig) ParseTaskTTL() (time.Duration, error) {
 	return tools.ParseDuration(c.TaskTTL)
 }
 
+func (c ControllerConfig) ParseAuditEventTTL() (time.Duration, error) {
+	if c.AuditEventTTL == "" {
+		return defaultAuditEventTTL, nil
+	}
+
+	return tools.ParseDuration(c.AuditEventTTL)
+}
+
 func (c ControllerConfig) ParseBuilderReadinessTimeout() (time.Duration, error) {
 	return tools.ParseDuration(c.BuilderReadinessTimeout)
 }
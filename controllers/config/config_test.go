@@ -188,6 +188,52 @@ var _ = Describe("ParseTaskTTL", func() {
 	})
 })
 
+var _ = Describe("ParseAuditEventTTL", func() {
+	var (
+		auditEventTTLString string
+		auditEventTTL       time.Duration
+		parseErr            error
+	)
+
+	BeforeEach(func() {
+		auditEventTTLString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			AuditEventTTL: auditEventTTLString,
+		}
+
+		auditEventTTL, parseErr = cfg.ParseAuditEventTTL()
+	})
+
+	It("return 31 days by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(auditEventTTL).To(Equal(31 * 24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			auditEventTTLString = "7d12h"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(auditEventTTL).To(Equal(7*24*time.Hour + 12*time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			auditEventTTLString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
 var _ = Describe("ParseJobTTL", func() {
 	var (
 		jobTTL    time.Duration
@@ -25,7 +25,10 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -57,6 +60,8 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains/status,verbs=patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains/finalizers,verbs=update
 
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;patch;delete
+
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -73,6 +78,15 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfDomain *korifiv1al
 	cfDomain.Status.ObservedGeneration = cfDomain.Generation
 	log.V(1).Info("set observed generation", "generation", cfDomain.Status.ObservedGeneration)
 
+	if cfDomain.Spec.Internal {
+		err = r.reconcileInternalRoutesNamespace(ctx, cfDomain)
+		if err != nil {
+			log.Info("failed to reconcile internal routes namespace", "reason", err)
+			readyConditionBuilder.WithReason("InternalRoutesNamespace")
+			return ctrl.Result{}, err
+		}
+	}
+
 	readyConditionBuilder.Ready()
 	return ctrl.Result{}, nil
 }
@@ -93,6 +107,11 @@ func (r *Reconciler) finalizeCFDomain(ctx context.Context, cfDomain *korifiv1alp
 	log.Info("routes", "len", len(domainRoutes))
 
 	if len(domainRoutes) == 0 {
+		if err = r.deleteInternalRoutesNamespace(ctx, cfDomain); err != nil {
+			log.Info("failed to delete internal routes namespace", "reason", err)
+			return ctrl.Result{}, err
+		}
+
 		if controllerutil.RemoveFinalizer(cfDomain, korifiv1alpha1.CFDomainFinalizerName) {
 			log.V(1).Info("finalizer removed")
 		}
@@ -120,3 +139,41 @@ func (r *Reconciler) listRoutesForDomain(ctx context.Context, cfDomain *korifiv1
 
 	return routesList.Items, nil
 }
+
+// reconcileInternalRoutesNamespace creates the namespace of the services
+// resolving the routes of the internal domain
+func (r *Reconciler) reconcileInternalRoutesNamespace(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: cfDomain.InternalRoutesNamespace(),
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.client, namespace, func() error {
+		if namespace.Labels == nil {
+			namespace.Labels = map[string]string{}
+		}
+		namespace.Labels[korifiv1alpha1.CFDomainGUIDLabelKey] = cfDomain.Name
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *Reconciler) deleteInternalRoutesNamespace(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	if !cfDomain.Spec.Internal {
+		return nil
+	}
+
+	err := r.client.Delete(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: cfDomain.InternalRoutesNamespace(),
+		},
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -35,6 +36,9 @@ var _ = Describe("CFDomainReconciler Integration Tests", func() {
 				Name: "a" + uuid.NewString() + ".com",
 			},
 		}
+	})
+
+	JustBeforeEach(func() {
 		Expect(adminClient.Create(ctx, cfDomain)).To(Succeed())
 	})
 
@@ -45,6 +49,45 @@ var _ = Describe("CFDomainReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	When("the domain is internal", func() {
+		BeforeEach(func() {
+			cfDomain.Spec.Internal = true
+		})
+
+		It("creates the internal routes namespace", func() {
+			Eventually(func(g Gomega) {
+				var namespace corev1.Namespace
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Name: cfDomain.InternalRoutesNamespace()}, &namespace)).To(Succeed())
+				g.Expect(namespace.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFDomainGUIDLabelKey, cfDomain.Name))
+
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), cfDomain)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfDomain.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		When("the domain is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Name: cfDomain.InternalRoutesNamespace()}, new(corev1.Namespace))).To(Succeed())
+				}).Should(Succeed())
+				Expect(adminClient.Delete(ctx, cfDomain)).To(Succeed())
+			})
+
+			It("deletes the internal routes namespace", func() {
+				Eventually(func(g Gomega) {
+					var namespace corev1.Namespace
+					err := adminClient.Get(ctx, client.ObjectKey{Name: cfDomain.InternalRoutesNamespace()}, &namespace)
+					if err == nil {
+						// envtest does not run the namespace controller, so namespaces stay terminating
+						g.Expect(namespace.DeletionTimestamp).NotTo(BeNil())
+						return
+					}
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	Describe("finalization", func() {
 		var (
 			route1Namespace string
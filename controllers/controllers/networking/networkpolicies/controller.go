@@ -0,0 +1,225 @@
+package networkpolicies
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type Reconciler struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	log              logr.Logger
+	gatewayNamespace string
+}
+
+func NewReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	gatewayNamespace string,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFNetworkPolicy, *korifiv1alpha1.CFNetworkPolicy] {
+	networkPolicyReconciler := Reconciler{client: client, scheme: scheme, log: log, gatewayNamespace: gatewayNamespace}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFNetworkPolicy, *korifiv1alpha1.CFNetworkPolicy](log, client, &networkPolicyReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFNetworkPolicy{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Watches(
+			&korifiv1alpha1.CFApp{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFNetworkPolicyRequestsForApp),
+		)
+}
+
+func (r *Reconciler) enqueueCFNetworkPolicyRequestsForApp(ctx context.Context, object client.Object) []reconcile.Request {
+	cfApp, ok := object.(*korifiv1alpha1.CFApp)
+	if !ok {
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	sourcePolicies := &korifiv1alpha1.CFNetworkPolicyList{}
+	err := r.client.List(ctx, sourcePolicies, client.MatchingFields{shared.IndexNetworkPolicySourceAppGUID: cfApp.Name})
+	if err != nil {
+		return nil
+	}
+	for i := range sourcePolicies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&sourcePolicies.Items[i])})
+	}
+
+	destinationPolicies := &korifiv1alpha1.CFNetworkPolicyList{}
+	err = r.client.List(ctx, destinationPolicies, client.InNamespace(cfApp.Namespace))
+	if err != nil {
+		return nil
+	}
+	for i := range destinationPolicies.Items {
+		if destinationPolicies.Items[i].Spec.DestinationAppRef.Name == cfApp.Name {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&destinationPolicies.Items[i])})
+		}
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfnetworkpolicies,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfnetworkpolicies/status,verbs=get;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get;list;watch
+//+kubebuilder:rbac:groups="networking.k8s.io",resources=networkpolicies,verbs=get;list;watch;create;patch;delete
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	var err error
+	readyConditionBuilder := k8s.NewReadyConditionBuilder(cfNetworkPolicy)
+	defer func() {
+		meta.SetStatusCondition(&cfNetworkPolicy.Status.Conditions, readyConditionBuilder.WithError(err).Build())
+	}()
+
+	cfNetworkPolicy.Status.ObservedGeneration = cfNetworkPolicy.Generation
+	log.V(1).Info("set observed generation", "generation", cfNetworkPolicy.Status.ObservedGeneration)
+
+	appsExist, err := r.appsExist(ctx, cfNetworkPolicy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// as in CF, policies go away with the apps they refer to
+	if !appsExist {
+		log.V(1).Info("deleting network policy of deleted app")
+		err = client.IgnoreNotFound(r.client.Delete(ctx, cfNetworkPolicy))
+		if err != nil {
+			log.Info("error deleting network policy", "reason", err)
+		}
+		return ctrl.Result{}, err
+	}
+
+	err = r.reconcileNetworkPolicy(ctx, cfNetworkPolicy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	readyConditionBuilder.Ready()
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) appsExist(ctx context.Context, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) (bool, error) {
+	for _, appKey := range []client.ObjectKey{
+		{Namespace: cfNetworkPolicy.Spec.Source.SpaceGUID, Name: cfNetworkPolicy.Spec.Source.AppGUID},
+		{Namespace: cfNetworkPolicy.Namespace, Name: cfNetworkPolicy.Spec.DestinationAppRef.Name},
+	} {
+		cfApp := new(korifiv1alpha1.CFApp)
+		err := r.client.Get(ctx, appKey, cfApp)
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		if !cfApp.GetDeletionTimestamp().IsZero() {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// reconcileNetworkPolicy creates or patches a NetworkPolicy selecting the
+// destination app pods. Once a NetworkPolicy selects a pod, Kubernetes drops
+// any ingress traffic not allowed by a policy, so besides the traffic from the
+// source app pods it also lets through the traffic from the gateway
+// namespace, so that the app routes keep working.
+func (r *Reconciler) reconcileNetworkPolicy(ctx context.Context, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileNetworkPolicy")
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfNetworkPolicy.Name,
+			Namespace: cfNetworkPolicy.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.DestinationAppRef.Name,
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								corev1.LabelMetadataName: cfNetworkPolicy.Spec.Source.SpaceGUID,
+							},
+						},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.Source.AppGUID,
+							},
+						},
+					}},
+					Ports: []networkingv1.NetworkPolicyPort{compilePort(cfNetworkPolicy.Spec)},
+				},
+				{
+					From: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								corev1.LabelMetadataName: r.gatewayNamespace,
+							},
+						},
+					}},
+				},
+			},
+		}
+
+		return controllerutil.SetControllerReference(cfNetworkPolicy, networkPolicy, r.scheme)
+	})
+	if err != nil {
+		log.Info("error creating/patching network policy", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("network policy reconciled", "operation", result)
+	return nil
+}
+
+func compilePort(spec korifiv1alpha1.CFNetworkPolicySpec) networkingv1.NetworkPolicyPort {
+	protocol := corev1.ProtocolTCP
+	if spec.Protocol == korifiv1alpha1.NetworkPolicyProtocolUDP {
+		protocol = corev1.ProtocolUDP
+	}
+
+	port := networkingv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: spec.StartPort},
+	}
+	if spec.EndPort > spec.StartPort {
+		port.EndPort = tools.PtrTo(spec.EndPort)
+	}
+
+	return port
+}
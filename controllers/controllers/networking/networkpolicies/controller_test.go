@@ -0,0 +1,199 @@
+package networkpolicies_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFNetworkPolicyReconciler Integration Tests", func() {
+	var (
+		sourceNamespace      *corev1.Namespace
+		destinationNamespace *corev1.Namespace
+		sourceApp            *korifiv1alpha1.CFApp
+		destinationApp       *korifiv1alpha1.CFApp
+		cfNetworkPolicy      *korifiv1alpha1.CFNetworkPolicy
+	)
+
+	createNamespace := func() *corev1.Namespace {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: uuid.NewString(),
+			},
+		}
+		Expect(adminClient.Create(ctx, ns)).To(Succeed())
+		return ns
+	}
+
+	createApp := func(namespace string) *korifiv1alpha1.CFApp {
+		cfApp := &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+				DesiredState: "STOPPED",
+				DisplayName:  uuid.NewString(),
+			},
+		}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+		return cfApp
+	}
+
+	BeforeEach(func() {
+		sourceNamespace = createNamespace()
+		destinationNamespace = createNamespace()
+		sourceApp = createApp(sourceNamespace.Name)
+		destinationApp = createApp(destinationNamespace.Name)
+
+		cfNetworkPolicy = &korifiv1alpha1.CFNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: destinationNamespace.Name,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFNetworkPolicySpec{
+				Source: korifiv1alpha1.NetworkPolicySource{
+					AppGUID:   sourceApp.Name,
+					SpaceGUID: sourceNamespace.Name,
+				},
+				DestinationAppRef: corev1.LocalObjectReference{Name: destinationApp.Name},
+				Protocol:          korifiv1alpha1.NetworkPolicyProtocolTCP,
+				StartPort:         8080,
+				EndPort:           8090,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfNetworkPolicy)).To(Succeed())
+	})
+
+	getNetworkPolicy := func(g Gomega) *networkingv1.NetworkPolicy {
+		networkPolicy := new(networkingv1.NetworkPolicy)
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), networkPolicy)).To(Succeed())
+		return networkPolicy
+	}
+
+	It("sets the ready condition", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), cfNetworkPolicy)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(cfNetworkPolicy.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			g.Expect(cfNetworkPolicy.Status.ObservedGeneration).To(Equal(cfNetworkPolicy.Generation))
+		}).Should(Succeed())
+	})
+
+	It("creates a network policy selecting the destination app pods", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := getNetworkPolicy(g)
+			g.Expect(networkPolicy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: destinationApp.Name,
+			}))
+			g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+			g.Expect(networkPolicy.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
+				APIVersion:         "korifi.cloudfoundry.org/v1alpha1",
+				Kind:               "CFNetworkPolicy",
+				Name:               cfNetworkPolicy.Name,
+				UID:                cfNetworkPolicy.UID,
+				Controller:         tools.PtrTo(true),
+				BlockOwnerDeletion: tools.PtrTo(true),
+			}))
+		}).Should(Succeed())
+	})
+
+	It("allows ingress from the source app pods on the policy ports", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := getNetworkPolicy(g)
+			g.Expect(networkPolicy.Spec.Ingress).To(ContainElement(networkingv1.NetworkPolicyIngressRule{
+				From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{corev1.LabelMetadataName: sourceNamespace.Name},
+					},
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{korifiv1alpha1.CFAppGUIDLabelKey: sourceApp.Name},
+					},
+				}},
+				Ports: []networkingv1.NetworkPolicyPort{{
+					Protocol: tools.PtrTo(corev1.ProtocolTCP),
+					Port:     tools.PtrTo(intstr.FromInt32(8080)),
+					EndPort:  tools.PtrTo[int32](8090),
+				}},
+			}))
+		}).Should(Succeed())
+	})
+
+	It("allows ingress from the gateway namespace only", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := getNetworkPolicy(g)
+			g.Expect(networkPolicy.Spec.Ingress).To(HaveLen(2))
+			g.Expect(networkPolicy.Spec.Ingress).To(ContainElement(networkingv1.NetworkPolicyIngressRule{
+				From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							corev1.LabelMetadataName: "korifi-gateway",
+						},
+					},
+				}},
+			}))
+		}).Should(Succeed())
+	})
+
+	When("the policy is for a single udp port", func() {
+		BeforeEach(func() {
+			cfNetworkPolicy.Spec.Protocol = korifiv1alpha1.NetworkPolicyProtocolUDP
+			cfNetworkPolicy.Spec.EndPort = cfNetworkPolicy.Spec.StartPort
+		})
+
+		It("does not set an end port", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := getNetworkPolicy(g)
+				g.Expect(networkPolicy.Spec.Ingress[0].Ports).To(ConsistOf(networkingv1.NetworkPolicyPort{
+					Protocol: tools.PtrTo(corev1.ProtocolUDP),
+					Port:     tools.PtrTo(intstr.FromInt32(8080)),
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the source app is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				getNetworkPolicy(g)
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, sourceApp)).To(Succeed())
+		})
+
+		It("deletes the network policy", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), cfNetworkPolicy)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the destination app does not exist", func() {
+		BeforeEach(func() {
+			cfNetworkPolicy.Spec.DestinationAppRef.Name = "i-do-not-exist"
+		})
+
+		It("deletes the network policy", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), cfNetworkPolicy)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+})
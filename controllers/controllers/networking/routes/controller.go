@@ -29,6 +29,8 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -41,9 +43,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
+const (
+	internalRouteEndpointSliceManager = "korifi.cloudfoundry.org"
+	h2cAppProtocol                    = "kubernetes.io/h2c"
+)
+
 type Reconciler struct {
 	client           client.Client
 	scheme           *runtime.Scheme
@@ -67,23 +75,108 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFApp{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFAppRequests),
+		).
+		Watches(
+			&korifiv1alpha1.CFProcess{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequests),
+		).
+		Watches(
+			&discoveryv1.EndpointSlice{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueEndpointSliceRequests),
+		).
+		Watches(
+			&gatewayv1beta1.Gateway{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueGatewayRequests),
 		)
 }
 
 func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object) []reconcile.Request {
-	var requests []reconcile.Request
-
 	cfApp, ok := o.(*korifiv1alpha1.CFApp)
 	if !ok {
 		return []reconcile.Request{}
 	}
 
+	return r.cfRouteRequestsForApp(ctx, cfApp.Namespace, cfApp.Name)
+}
+
+// enqueueCFProcessRequests keeps the route weights in sync with the process
+// instances while the app runs a canary revision
+func (r *Reconciler) enqueueCFProcessRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	cfProcess, ok := o.(*korifiv1alpha1.CFProcess)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return r.cfRouteRequestsForApp(ctx, cfProcess.Namespace, cfProcess.Spec.AppRef.Name)
+}
+
+// enqueueEndpointSliceRequests keeps the endpoints of internal routes in sync
+// with the endpoints of their destination services. The endpoint slices
+// inherit the route GUID label from the destination services.
+func (r *Reconciler) enqueueEndpointSliceRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	routeGUID, ok := o.GetLabels()[korifiv1alpha1.CFRouteGUIDLabelKey]
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      routeGUID,
+			Namespace: o.GetNamespace(),
+		},
+	}}
+}
+
+// enqueueGatewayRequests re-adds the listeners of the tcp routes whenever
+// they go missing from the gateway, e.g. because a helm upgrade has reset the
+// gateway listeners
+func (r *Reconciler) enqueueGatewayRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	gateway, ok := o.(*gatewayv1beta1.Gateway)
+	if !ok ||
+		gateway.Namespace != r.controllerConfig.Networking.GatewayNamespace ||
+		gateway.Name != r.controllerConfig.Networking.GatewayName {
+		return []reconcile.Request{}
+	}
+
+	listenerNames := map[gatewayv1.SectionName]bool{}
+	for _, listener := range gateway.Spec.Listeners {
+		listenerNames[listener.Name] = true
+	}
+
+	var cfRoutes korifiv1alpha1.CFRouteList
+	err := r.client.List(ctx, &cfRoutes)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, cfRoute := range cfRoutes.Items {
+		if cfRoute.Spec.Protocol != korifiv1alpha1.ProtocolTCP ||
+			!cfRoute.DeletionTimestamp.IsZero() ||
+			listenerNames[gatewayv1.SectionName(generateTCPListenerName(&cfRoute))] {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      cfRoute.Name,
+				Namespace: cfRoute.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
+func (r *Reconciler) cfRouteRequestsForApp(ctx context.Context, appNamespace, appName string) []reconcile.Request {
+	var requests []reconcile.Request
+
 	var appRoutes korifiv1alpha1.CFRouteList
 	err := r.client.List(
 		ctx,
 		&appRoutes,
-		client.InNamespace(cfApp.Namespace),
-		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
+		client.InNamespace(appNamespace),
+		client.MatchingFields{shared.IndexRouteDestinationAppName: appName},
 	)
 	if err != nil {
 		return []reconcile.Request{}
@@ -107,8 +200,13 @@ func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object)
 
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status,verbs=get
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;update;patch
 
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;update;patch;delete
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -138,21 +236,44 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 		return ctrl.Result{}, err
 	}
 
-	err = r.createOrPatchServices(ctx, cfRoute)
+	canaries, err := r.getDestinationCanaries(ctx, cfRoute)
 	if err != nil {
-		readyConditionBuilder.WithReason("CreatePatchServices")
+		readyConditionBuilder.WithReason("GetDestinationCanaries")
 		return ctrl.Result{}, err
 	}
 
-	err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain)
+	err = r.createOrPatchServices(ctx, cfRoute, canaries)
 	if err != nil {
-		readyConditionBuilder.WithReason("ReconcileHTTPRoute")
+		readyConditionBuilder.WithReason("CreatePatchServices")
 		return ctrl.Result{}, err
 	}
 
+	if cfDomain.Spec.Internal {
+		err = r.reconcileInternalRoute(ctx, cfRoute, cfDomain)
+		if err != nil {
+			readyConditionBuilder.WithReason("ReconcileInternalRoute")
+			return ctrl.Result{}, err
+		}
+	} else if cfDomain.Spec.RouterGroup != "" {
+		err = r.reconcileTCPRoute(ctx, cfRoute, canaries)
+		if err != nil {
+			readyConditionBuilder.WithReason("ReconcileTCPRoute")
+			return ctrl.Result{}, err
+		}
+	} else {
+		err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain, canaries)
+		if err != nil {
+			readyConditionBuilder.WithReason("ReconcileHTTPRoute")
+			return ctrl.Result{}, err
+		}
+	}
+
 	fqdn := buildFQDN(cfRoute, cfDomain)
 	cfRoute.Status.FQDN = fqdn
 	cfRoute.Status.URI = fqdn + cfRoute.Spec.Path
+	if cfRoute.Spec.Protocol == korifiv1alpha1.ProtocolTCP {
+		cfRoute.Status.URI = fmt.Sprintf("%s:%d", fqdn, cfRoute.Spec.Port)
+	}
 
 	effectiveDestinations, err := r.buildEffectiveDestinations(ctx, cfRoute)
 	if err != nil {
@@ -163,7 +284,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 
 	readyConditionBuilder.Ready()
 
-	if cleanupErr := r.deleteOrphanedServices(ctx, cfRoute); cleanupErr != nil {
+	if cleanupErr := r.deleteOrphanedServices(ctx, cfRoute, canaries); cleanupErr != nil {
 		// technically, failing to delete the orphaned services does not make
 		// the CFRoute invalid or not ready so we don't mess with the cfRoute
 		// ready status condition here
@@ -180,6 +301,20 @@ func (r *Reconciler) finalizeCFRoute(ctx context.Context, cfRoute *korifiv1alpha
 		return nil
 	}
 
+	// internal route services live in the namespace of the internal domain
+	// and cannot be garbage collected via owner references
+	if err := r.deleteInternalRouteServicesOfDomain(ctx, cfRoute); err != nil {
+		log.Info("failed to delete internal route services", "reason", err)
+		return err
+	}
+
+	// the gateway listener of a tcp route is shared infrastructure that is
+	// not owned by the route
+	if err := r.removeTCPListener(ctx, cfRoute); err != nil {
+		log.Info("failed to remove tcp route listener", "reason", err)
+		return err
+	}
+
 	if controllerutil.RemoveFinalizer(cfRoute, korifiv1alpha1.CFRouteFinalizerName) {
 		log.V(1).Info("finalizer removed")
 	}
@@ -187,53 +322,157 @@ func (r *Reconciler) finalizeCFRoute(ctx context.Context, cfRoute *korifiv1alpha
 	return nil
 }
 
-func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
-	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchServices")
+// deleteInternalRouteServicesOfDomain deletes the internal services of the
+// route when its domain is internal
+func (r *Reconciler) deleteInternalRouteServicesOfDomain(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	cfDomain := &korifiv1alpha1.CFDomain{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: cfRoute.Spec.DomainRef.Name, Namespace: cfRoute.Spec.DomainRef.Namespace}, cfDomain)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !cfDomain.Spec.Internal {
+		return nil
+	}
+
+	return r.deleteInternalRouteServices(ctx, cfRoute, cfDomain.InternalRoutesNamespace())
+}
+
+// destinationCanary describes how the traffic to a route destination is
+// split between the current and the canary revision of the app
+type destinationCanary struct {
+	currentRevision string
+	canaryRevision  string
+	currentWeight   int32
+	canaryWeight    int32
+}
+
+// getDestinationCanaries returns the canaries of the route destinations
+// whose app runs a canary revision, keyed by destination GUID
+func (r *Reconciler) getDestinationCanaries(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (map[string]destinationCanary, error) {
+	canaries := map[string]destinationCanary{}
 
 	for _, destination := range cfRoute.Status.Destinations {
-		serviceName := generateServiceName(destination)
-		loopLog := log.WithValues("processType", destination.ProcessType, "appRef", destination.AppRef.Name, "serviceName", serviceName)
+		cfApp := &korifiv1alpha1.CFApp{}
+		err := r.client.Get(ctx, types.NamespacedName{Namespace: cfRoute.Namespace, Name: destination.AppRef.Name}, cfApp)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
 
+		if cfApp.Spec.Canary == nil {
+			continue
+		}
+
+		var processes korifiv1alpha1.CFProcessList
+		err = r.client.List(ctx, &processes, client.InNamespace(cfRoute.Namespace), client.MatchingLabels{
+			korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+			korifiv1alpha1.CFProcessTypeLabelKey: destination.ProcessType,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(processes.Items) == 0 || processes.Items[0].Spec.DesiredInstances == nil || *processes.Items[0].Spec.DesiredInstances == 0 {
+			continue
+		}
+
+		canaries[destination.GUID] = destinationCanary{
+			currentRevision: cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey],
+			canaryRevision:  cfApp.Spec.Canary.Revision,
+			currentWeight:   int32(*processes.Items[0].Spec.DesiredInstances),
+			canaryWeight:    korifiv1alpha1.CanaryInstances,
+		}
+	}
+
+	return canaries, nil
+}
+
+func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, canaries map[string]destinationCanary) error {
+	for _, destination := range cfRoute.Status.Destinations {
 		if destination.Port == nil {
 			continue
 		}
 
-		service := &corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      serviceName,
-				Namespace: cfRoute.Namespace,
-			},
+		canary, hasCanary := canaries[destination.GUID]
+
+		// while the app runs a canary, each revision gets its own service, so
+		// that traffic can be split between them
+		var revision string
+		if hasCanary {
+			revision = canary.currentRevision
 		}
 
-		result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
-			service.Labels = map[string]string{
-				korifiv1alpha1.CFAppGUIDLabelKey:   destination.AppRef.Name,
-				korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
-			}
+		err := r.createOrPatchService(ctx, cfRoute, destination, generateServiceName(destination), revision)
+		if err != nil {
+			return err
+		}
 
-			err := controllerutil.SetControllerReference(cfRoute, service, r.scheme)
+		if hasCanary {
+			err = r.createOrPatchService(ctx, cfRoute, destination, generateCanaryServiceName(destination), canary.canaryRevision)
 			if err != nil {
-				loopLog.Info("failed to set OwnerRef on Service", "reason", err)
 				return err
 			}
+		}
+	}
 
-			service.Spec.Ports = []corev1.ServicePort{{
-				Port: int32(*destination.Port),
-			}}
+	return nil
+}
 
-			service.Spec.Selector = map[string]string{
-				korifiv1alpha1.CFAppGUIDLabelKey:     destination.AppRef.Name,
-				korifiv1alpha1.CFProcessTypeLabelKey: destination.ProcessType,
-			}
+func (r *Reconciler) createOrPatchService(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, destination korifiv1alpha1.Destination, serviceName, revision string) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchServices")
+	loopLog := log.WithValues("processType", destination.ProcessType, "appRef", destination.AppRef.Name, "serviceName", serviceName)
 
-			return nil
-		})
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
+		service.Labels = map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey:   destination.AppRef.Name,
+			korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+		}
+
+		err := controllerutil.SetControllerReference(cfRoute, service, r.scheme)
 		if err != nil {
-			log.Info("failed to patch Service", "reason", err)
-			return fmt.Errorf("service reconciliation failed for CFRoute/%s destinations", cfRoute.Name)
+			loopLog.Info("failed to set OwnerRef on Service", "reason", err)
+			return err
 		}
 
-		log.V(1).Info("Service reconciled", "operation", result)
+		service.Spec.Ports = []corev1.ServicePort{{
+			Port:        int32(*destination.Port),
+			AppProtocol: appProtocol(destination),
+		}}
+
+		service.Spec.Selector = map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey:     destination.AppRef.Name,
+			korifiv1alpha1.CFProcessTypeLabelKey: destination.ProcessType,
+		}
+		if revision != "" {
+			service.Spec.Selector[korifiv1alpha1.VersionLabelKey] = revision
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to patch Service", "reason", err)
+		return fmt.Errorf("service reconciliation failed for CFRoute/%s destinations", cfRoute.Name)
+	}
+
+	log.V(1).Info("Service reconciled", "operation", result)
+	return nil
+}
+
+// appProtocol tells the gateway to talk cleartext HTTP/2 to http2
+// destinations, which is what gRPC apps need
+func appProtocol(destination korifiv1alpha1.Destination) *string {
+	if destination.Protocol != nil && *destination.Protocol == "http2" {
+		return tools.PtrTo(h2cAppProtocol)
 	}
 
 	return nil
@@ -301,7 +540,7 @@ func (r *Reconciler) getAppCurrentDroplet(ctx context.Context, appNamespace, app
 	return cfBuild.Status.Droplet, nil
 }
 
-func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain, canaries map[string]destinationCanary) error {
 	fqdn := buildFQDN(cfRoute, cfDomain)
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchHTTPRoute").WithValues("fqdn", fqdn, "path", cfRoute.Spec.Path)
 
@@ -334,7 +573,7 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 		}
 
 		httpRoute.Spec.Rules = []gatewayv1beta1.HTTPRouteRule{{
-			BackendRefs: toBackendRefs(cfRoute.Status.Destinations),
+			BackendRefs: toBackendRefs(cfRoute.Status.Destinations, canaries),
 		}}
 		if cfRoute.Spec.Path != "" {
 			httpRoute.Spec.Rules[0].Matches = []gatewayv1beta1.HTTPRouteMatch{{
@@ -356,7 +595,250 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 	return nil
 }
 
-func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+// reconcileInternalRoute makes the route resolvable by cluster DNS instead of
+// exposing it on the gateway. A headless service named after the route host,
+// in the namespace of the internal domain, resolves to the endpoints of the
+// destination services, so that apps can talk to each other directly on any
+// port.
+func (r *Reconciler) reconcileInternalRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+	fqdn := buildFQDN(cfRoute, cfDomain)
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileInternalRoute").WithValues("fqdn", fqdn)
+
+	if len(cfRoute.Status.Destinations) == 0 {
+		return r.deleteInternalRouteServices(ctx, cfRoute, cfDomain.InternalRoutesNamespace())
+	}
+
+	controllerutil.AddFinalizer(cfRoute, korifiv1alpha1.CFRouteFinalizerName)
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generateInternalServiceName(cfRoute),
+			Namespace: cfDomain.InternalRoutesNamespace(),
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
+		service.Labels = map[string]string{
+			korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+		}
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+		service.Spec.Selector = nil
+
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create/patch internal route Service", "reason", err)
+		return err
+	}
+	log.V(1).Info("internal route Service reconciled", "operation", result)
+
+	endpoints, err := r.getDestinationEndpoints(ctx, cfRoute)
+	if err != nil {
+		log.Info("failed to get destination endpoints", "reason", err)
+		return err
+	}
+
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service.Name,
+			Namespace: service.Namespace,
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+
+	result, err = controllerutil.CreateOrPatch(ctx, r.client, endpointSlice, func() error {
+		endpointSlice.Labels = map[string]string{
+			discoveryv1.LabelServiceName: service.Name,
+			discoveryv1.LabelManagedBy:   internalRouteEndpointSliceManager,
+		}
+		// no ports means that all the ports of the endpoints are exposed
+		endpointSlice.Ports = nil
+		endpointSlice.Endpoints = endpoints
+
+		return controllerutil.SetControllerReference(service, endpointSlice, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create/patch internal route EndpointSlice", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("internal route EndpointSlice reconciled", "operation", result)
+	return nil
+}
+
+// reconcileTCPRoute exposes the route on a dedicated TCP listener of the
+// gateway, named after the route port
+func (r *Reconciler) reconcileTCPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, canaries map[string]destinationCanary) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileTCPRoute").WithValues("port", cfRoute.Spec.Port)
+
+	controllerutil.AddFinalizer(cfRoute, korifiv1alpha1.CFRouteFinalizerName)
+
+	err := r.addTCPListener(ctx, cfRoute)
+	if err != nil {
+		log.Info("failed to add tcp listener to the gateway", "reason", err)
+		return err
+	}
+
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	if len(cfRoute.Status.Destinations) == 0 {
+		err = r.client.Delete(ctx, tcpRoute)
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete existing TCPRoute", "reason", err)
+			return err
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, tcpRoute, func() error {
+		tcpRoute.Spec.ParentRefs = []gatewayv1alpha2.ParentReference{{
+			Group:       tools.PtrTo(gatewayv1.Group("gateway.networking.k8s.io")),
+			Kind:        tools.PtrTo(gatewayv1.Kind("Gateway")),
+			Namespace:   tools.PtrTo(gatewayv1.Namespace(r.controllerConfig.Networking.GatewayNamespace)),
+			Name:        gatewayv1.ObjectName(r.controllerConfig.Networking.GatewayName),
+			SectionName: tools.PtrTo(gatewayv1.SectionName(generateTCPListenerName(cfRoute))),
+		}}
+
+		backendRefs := []gatewayv1alpha2.BackendRef{}
+		for _, httpBackendRef := range toBackendRefs(cfRoute.Status.Destinations, canaries) {
+			backendRefs = append(backendRefs, httpBackendRef.BackendRef)
+		}
+		tcpRoute.Spec.Rules = []gatewayv1alpha2.TCPRouteRule{{
+			BackendRefs: backendRefs,
+		}}
+
+		return controllerutil.SetControllerReference(cfRoute, tcpRoute, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create/patch TCPRoute", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("TCPRoute reconciled", "operation", result)
+	return nil
+}
+
+func (r *Reconciler) addTCPListener(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	listenerName := gatewayv1.SectionName(generateTCPListenerName(cfRoute))
+
+	return r.patchGatewayListeners(ctx, func(listeners []gatewayv1.Listener) []gatewayv1.Listener {
+		for _, listener := range listeners {
+			if listener.Name == listenerName {
+				return listeners
+			}
+		}
+
+		return append(listeners, gatewayv1.Listener{
+			Name:     listenerName,
+			Port:     gatewayv1.PortNumber(cfRoute.Spec.Port),
+			Protocol: gatewayv1.TCPProtocolType,
+			AllowedRoutes: &gatewayv1.AllowedRoutes{
+				Namespaces: &gatewayv1.RouteNamespaces{
+					From: tools.PtrTo(gatewayv1.NamespacesFromAll),
+				},
+				Kinds: []gatewayv1.RouteGroupKind{{
+					Kind: gatewayv1.Kind("TCPRoute"),
+				}},
+			},
+		})
+	})
+}
+
+func (r *Reconciler) removeTCPListener(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	if cfRoute.Spec.Protocol != korifiv1alpha1.ProtocolTCP {
+		return nil
+	}
+
+	listenerName := gatewayv1.SectionName(generateTCPListenerName(cfRoute))
+
+	return r.patchGatewayListeners(ctx, func(listeners []gatewayv1.Listener) []gatewayv1.Listener {
+		result := []gatewayv1.Listener{}
+		for _, listener := range listeners {
+			if listener.Name != listenerName {
+				result = append(result, listener)
+			}
+		}
+
+		return result
+	})
+}
+
+// patchGatewayListeners updates the listeners of the configured gateway. The
+// gateway is shared by all tcp routes, so the patch uses optimistic locking
+// in order not to lose concurrent listener updates.
+func (r *Reconciler) patchGatewayListeners(ctx context.Context, updateListeners func([]gatewayv1.Listener) []gatewayv1.Listener) error {
+	gateway := &gatewayv1beta1.Gateway{}
+	err := r.client.Get(ctx, types.NamespacedName{
+		Namespace: r.controllerConfig.Networking.GatewayNamespace,
+		Name:      r.controllerConfig.Networking.GatewayName,
+	}, gateway)
+	if err != nil {
+		return err
+	}
+
+	originalGateway := gateway.DeepCopy()
+	gateway.Spec.Listeners = updateListeners(gateway.Spec.Listeners)
+	if len(gateway.Spec.Listeners) == len(originalGateway.Spec.Listeners) {
+		return nil
+	}
+
+	return r.client.Patch(ctx, gateway, client.MergeFromWithOptions(originalGateway, client.MergeFromWithOptimisticLock{}))
+}
+
+// getDestinationEndpoints returns the endpoints of the route destination
+// services, including the canary ones
+func (r *Reconciler) getDestinationEndpoints(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) ([]discoveryv1.Endpoint, error) {
+	var endpointSlices discoveryv1.EndpointSliceList
+	err := r.client.List(ctx, &endpointSlices, client.InNamespace(cfRoute.Namespace), client.MatchingLabels{
+		korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []discoveryv1.Endpoint{}
+	for _, endpointSlice := range endpointSlices.Items {
+		if endpointSlice.AddressType != discoveryv1.AddressTypeIPv4 {
+			continue
+		}
+
+		for _, endpoint := range endpointSlice.Endpoints {
+			endpoints = append(endpoints, discoveryv1.Endpoint{
+				Addresses:  endpoint.Addresses,
+				Conditions: endpoint.Conditions,
+			})
+		}
+	}
+
+	return endpoints, nil
+}
+
+// deleteInternalRouteServices deletes the internal services of the route in
+// the given namespace
+func (r *Reconciler) deleteInternalRouteServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, namespace string) error {
+	serviceList, err := r.fetchServicesByMatchingLabels(ctx, map[string]string{
+		korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+	}, namespace)
+	if err != nil {
+		return err
+	}
+
+	for i := range serviceList.Items {
+		err = r.client.Delete(ctx, &serviceList.Items[i])
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, canaries map[string]destinationCanary) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("deleteOrphanedServices")
 
 	matchingLabelSet := map[string]string{
@@ -378,6 +860,11 @@ func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifi
 				isOrphan = false
 				break
 			}
+
+			if _, hasCanary := canaries[destination.GUID]; hasCanary && service.Name == generateCanaryServiceName(destination) {
+				isOrphan = false
+				break
+			}
 		}
 
 		if isOrphan {
@@ -415,24 +902,55 @@ func generateServiceName(destination korifiv1alpha1.Destination) string {
 	return fmt.Sprintf("s-%s", destination.GUID)
 }
 
+func generateCanaryServiceName(destination korifiv1alpha1.Destination) string {
+	return fmt.Sprintf("s-%s-canary", destination.GUID)
+}
+
+func generateInternalServiceName(cfRoute *korifiv1alpha1.CFRoute) string {
+	return strings.ToLower(cfRoute.Spec.Host)
+}
+
+func generateTCPListenerName(cfRoute *korifiv1alpha1.CFRoute) string {
+	return fmt.Sprintf("tcp-%d", cfRoute.Spec.Port)
+}
+
 func buildFQDN(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) string {
+	if cfRoute.Spec.Host == "" {
+		return cfDomain.Spec.Name
+	}
+
 	return fmt.Sprintf("%s.%s", strings.ToLower(cfRoute.Spec.Host), cfDomain.Spec.Name)
 }
 
-func toBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1beta1.HTTPBackendRef {
+func toBackendRefs(destinations []korifiv1alpha1.Destination, canaries map[string]destinationCanary) []gatewayv1beta1.HTTPBackendRef {
 	backendRefs := []gatewayv1beta1.HTTPBackendRef{}
 
 	for _, destination := range destinations {
-		backendRefs = append(backendRefs, gatewayv1beta1.HTTPBackendRef{
-			BackendRef: gatewayv1beta1.BackendRef{
-				BackendObjectReference: gatewayv1beta1.BackendObjectReference{
-					Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
-					Name: gatewayv1beta1.ObjectName(generateServiceName(destination)),
-					Port: tools.PtrTo(gatewayv1beta1.PortNumber(*destination.Port)),
-				},
-			},
-		})
+		canary, hasCanary := canaries[destination.GUID]
+		if !hasCanary {
+			backendRefs = append(backendRefs, toBackendRef(generateServiceName(destination), destination, nil))
+			continue
+		}
+
+		// the traffic is split between the revisions proportionally to their instances
+		backendRefs = append(backendRefs,
+			toBackendRef(generateServiceName(destination), destination, tools.PtrTo(canary.currentWeight)),
+			toBackendRef(generateCanaryServiceName(destination), destination, tools.PtrTo(canary.canaryWeight)),
+		)
 	}
 
 	return backendRefs
 }
+
+func toBackendRef(serviceName string, destination korifiv1alpha1.Destination, weight *int32) gatewayv1beta1.HTTPBackendRef {
+	return gatewayv1beta1.HTTPBackendRef{
+		BackendRef: gatewayv1beta1.BackendRef{
+			BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+				Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
+				Name: gatewayv1beta1.ObjectName(serviceName),
+				Port: tools.PtrTo(gatewayv1beta1.PortNumber(*destination.Port)),
+			},
+			Weight: weight,
+		},
+	}
+}
@@ -2,6 +2,8 @@ package routes_test
 
 import (
 	"fmt"
+	"math/rand"
+	"slices"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -13,12 +15,14 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -233,6 +237,24 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 
+		When("the destination protocol is http2", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations[0].Protocol = tools.PtrTo("http2")
+			})
+
+			It("sets the h2c app protocol on the destination service", func() {
+				serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: ns.Name}, &svc)).To(Succeed())
+					g.Expect(svc.Spec.Ports).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Port":        BeEquivalentTo(80),
+						"AppProtocol": PointTo(Equal("kubernetes.io/h2c")),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
 		It("sets effective destinations to the cfroute status", func() {
 			Eventually(func(g Gomega) {
 				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
@@ -386,6 +408,296 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the destination app runs a canary revision", func() {
+			BeforeEach(func() {
+				cfProcess := &korifiv1alpha1.CFProcess{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns.Name,
+						Name:      uuid.NewString(),
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+							korifiv1alpha1.CFProcessTypeLabelKey: "web",
+						},
+					},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef:           corev1.LocalObjectReference{Name: cfApp.Name},
+						ProcessType:      "web",
+						DesiredInstances: tools.PtrTo(3),
+					},
+				}
+				Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations = map[string]string{korifiv1alpha1.CFAppRevisionKey: "1"}
+					cfApp.Spec.Canary = &korifiv1alpha1.CanarySpec{
+						DropletRef: corev1.LocalObjectReference{Name: "canary-droplet"},
+						Revision:   "2",
+					}
+				})).To(Succeed())
+			})
+
+			It("creates a service per app revision", func() {
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID), Namespace: ns.Name}, &svc)).To(Succeed())
+					g.Expect(svc.Spec.Selector).To(Equal(map[string]string{
+						"korifi.cloudfoundry.org/app-guid":     cfApp.Name,
+						"korifi.cloudfoundry.org/process-type": "web",
+						"korifi.cloudfoundry.org/version":      "1",
+					}))
+
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID), Namespace: ns.Name}, &svc)).To(Succeed())
+					g.Expect(svc.Spec.Selector).To(Equal(map[string]string{
+						"korifi.cloudfoundry.org/app-guid":     cfApp.Name,
+						"korifi.cloudfoundry.org/process-type": "web",
+						"korifi.cloudfoundry.org/version":      "2",
+					}))
+				}).Should(Succeed())
+			})
+
+			It("splits the traffic between the revisions proportionally to their instances", func() {
+				Eventually(func(g Gomega) {
+					httpRoute := getHTTPRoute()
+					g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"BackendRef": MatchFields(IgnoreExtras, Fields{
+							"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+								"Name": BeEquivalentTo(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+							}),
+							"Weight": PointTo(BeEquivalentTo(3)),
+						})}),
+						MatchFields(IgnoreExtras, Fields{"BackendRef": MatchFields(IgnoreExtras, Fields{
+							"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+								"Name": BeEquivalentTo(fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID)),
+							}),
+							"Weight": PointTo(BeEquivalentTo(1)),
+						})}),
+					))
+				}).Should(Succeed())
+			})
+
+			When("the canary is removed from the app", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID), Namespace: ns.Name}, new(corev1.Service))).To(Succeed())
+					}).Should(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.Canary = nil
+					})).To(Succeed())
+				})
+
+				It("deletes the canary service", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID), Namespace: ns.Name}, new(corev1.Service))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+
+				It("routes all the traffic to the app service", func() {
+					Eventually(func(g Gomega) {
+						httpRoute := getHTTPRoute()
+						g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+						g.Expect(httpRoute.Spec.Rules[0].BackendRefs[0].Name).To(BeEquivalentTo(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the route is on an internal domain", func() {
+			var internalServiceName string
+
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfDomain, func() {
+					cfDomain.Spec.Internal = true
+				})).To(Succeed())
+				Expect(adminClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: cfDomain.InternalRoutesNamespace(),
+					},
+				})).To(Succeed())
+
+				cfRoute.Spec.Path = ""
+				internalServiceName = cfRoute.Spec.Host
+			})
+
+			JustBeforeEach(func() {
+				Expect(adminClient.Create(ctx, &discoveryv1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: ns.Name,
+						Labels: map[string]string{
+							discoveryv1.LabelServiceName:       fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID),
+							korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+						},
+					},
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{
+						{Addresses: []string{"10.0.0.1"}},
+						{Addresses: []string{"10.0.0.2"}},
+					},
+				})).To(Succeed())
+			})
+
+			It("does not create an HTTPRoute", func() {
+				Consistently(func(g Gomega) {
+					httpRoutes := &gatewayv1beta1.HTTPRouteList{}
+					g.Expect(adminClient.List(ctx, httpRoutes, client.InNamespace(ns.Name))).To(Succeed())
+					g.Expect(httpRoutes.Items).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			It("creates a headless service named after the route host in the domain namespace", func() {
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: internalServiceName, Namespace: cfDomain.InternalRoutesNamespace()}, &svc)).To(Succeed())
+					g.Expect(svc.Labels).To(HaveKeyWithValue("korifi.cloudfoundry.org/route-guid", cfRoute.Name))
+					g.Expect(svc.Spec.ClusterIP).To(Equal(corev1.ClusterIPNone))
+					g.Expect(svc.Spec.Selector).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			It("resolves the internal service to the destination endpoints", func() {
+				Eventually(func(g Gomega) {
+					var endpointSlice discoveryv1.EndpointSlice
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: internalServiceName, Namespace: cfDomain.InternalRoutesNamespace()}, &endpointSlice)).To(Succeed())
+					g.Expect(endpointSlice.Labels).To(HaveKeyWithValue(discoveryv1.LabelServiceName, internalServiceName))
+					g.Expect(endpointSlice.Ports).To(BeEmpty())
+					g.Expect(endpointSlice.Endpoints).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"Addresses": ConsistOf("10.0.0.1")}),
+						MatchFields(IgnoreExtras, Fields{"Addresses": ConsistOf("10.0.0.2")}),
+					))
+				}).Should(Succeed())
+			})
+
+			It("adds a finalizer to the route", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					g.Expect(cfRoute.Finalizers).To(ConsistOf(korifiv1alpha1.CFRouteFinalizerName))
+				}).Should(Succeed())
+			})
+
+			When("the route is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: internalServiceName, Namespace: cfDomain.InternalRoutesNamespace()}, new(corev1.Service))).To(Succeed())
+					}).Should(Succeed())
+					Expect(adminClient.Delete(ctx, cfRoute)).To(Succeed())
+				})
+
+				It("deletes the internal service", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, types.NamespacedName{Name: internalServiceName, Namespace: cfDomain.InternalRoutesNamespace()}, new(corev1.Service))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the route is on a tcp domain", func() {
+			var listenerName gatewayv1.SectionName
+
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfDomain, func() {
+					cfDomain.Spec.RouterGroup = "default-tcp"
+				})).To(Succeed())
+
+				cfRoute.Spec.Host = ""
+				cfRoute.Spec.Path = ""
+				cfRoute.Spec.Protocol = korifiv1alpha1.ProtocolTCP
+				cfRoute.Spec.Port = 1024 + GinkgoParallelProcess()*100 + rand.Intn(100)
+				listenerName = gatewayv1.SectionName(fmt.Sprintf("tcp-%d", cfRoute.Spec.Port))
+			})
+
+			getGatewayListenerNames := func(g Gomega) []gatewayv1.SectionName {
+				gateway := &gatewayv1beta1.Gateway{}
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: "korifi", Namespace: "korifi-gateway"}, gateway)).To(Succeed())
+
+				names := []gatewayv1.SectionName{}
+				for _, listener := range gateway.Spec.Listeners {
+					names = append(names, listener.Name)
+				}
+				return names
+			}
+
+			It("does not create an HTTPRoute", func() {
+				Consistently(func(g Gomega) {
+					httpRoutes := &gatewayv1beta1.HTTPRouteList{}
+					g.Expect(adminClient.List(ctx, httpRoutes, client.InNamespace(ns.Name))).To(Succeed())
+					g.Expect(httpRoutes.Items).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			It("adds a tcp listener for the route port to the gateway", func() {
+				Eventually(func(g Gomega) {
+					gateway := &gatewayv1beta1.Gateway{}
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: "korifi", Namespace: "korifi-gateway"}, gateway)).To(Succeed())
+					g.Expect(gateway.Spec.Listeners).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Name":     Equal(listenerName),
+						"Port":     BeEquivalentTo(cfRoute.Spec.Port),
+						"Protocol": Equal(gatewayv1.TCPProtocolType),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("creates a TCPRoute attached to the listener", func() {
+				Eventually(func(g Gomega) {
+					tcpRoute := &gatewayv1alpha2.TCPRoute{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), tcpRoute)).To(Succeed())
+					g.Expect(tcpRoute.Spec.ParentRefs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Name":        BeEquivalentTo("korifi"),
+						"Namespace":   PointTo(BeEquivalentTo("korifi-gateway")),
+						"SectionName": PointTo(Equal(listenerName)),
+					})))
+					g.Expect(tcpRoute.Spec.Rules).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"BackendRefs": ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+								"Name": BeEquivalentTo(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+								"Port": PointTo(BeEquivalentTo(80)),
+							}),
+						})),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("sets the route uri to the domain and port", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					g.Expect(cfRoute.Status.FQDN).To(Equal(cfDomain.Spec.Name))
+					g.Expect(cfRoute.Status.URI).To(Equal(fmt.Sprintf("%s:%d", cfDomain.Spec.Name, cfRoute.Spec.Port)))
+				}).Should(Succeed())
+			})
+
+			When("the gateway listeners are reset", func() {
+				JustBeforeEach(func() {
+					Eventually(getGatewayListenerNames).Should(ContainElement(listenerName))
+
+					gateway := &gatewayv1beta1.Gateway{}
+					Expect(adminClient.Get(ctx, types.NamespacedName{Name: "korifi", Namespace: "korifi-gateway"}, gateway)).To(Succeed())
+					Expect(k8s.PatchResource(ctx, adminClient, gateway, func() {
+						gateway.Spec.Listeners = slices.DeleteFunc(gateway.Spec.Listeners, func(listener gatewayv1.Listener) bool {
+							return listener.Name == listenerName
+						})
+					})).To(Succeed())
+				})
+
+				It("adds the tcp listener back", func() {
+					Eventually(getGatewayListenerNames).Should(ContainElement(listenerName))
+				})
+			})
+
+			When("the route is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(getGatewayListenerNames).Should(ContainElement(listenerName))
+					Expect(adminClient.Delete(ctx, cfRoute)).To(Succeed())
+				})
+
+				It("removes the listener from the gateway", func() {
+					Eventually(getGatewayListenerNames).ShouldNot(ContainElement(listenerName))
+				})
+			})
+		})
+
 		When("the destinations are deleted from the route", func() {
 			var (
 				httpRoute   *gatewayv1beta1.HTTPRoute
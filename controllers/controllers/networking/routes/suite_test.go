@@ -14,12 +14,16 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	//+kubebuilder:scaffold:imports
 )
@@ -30,6 +34,7 @@ var (
 	testEnv         *envtest.Environment
 	adminClient     client.Client
 	ctx             context.Context
+	rootNamespace   string
 )
 
 func TestNetworkingControllers(t *testing.T) {
@@ -59,17 +64,46 @@ var _ = BeforeSuite(func() {
 
 	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(gatewayv1beta1.Install(scheme.Scheme)).To(Succeed())
+	Expect(gatewayv1alpha2.Install(scheme.Scheme)).To(Succeed())
 
 	k8sManager := helpers.NewK8sManager(testEnv, filepath.Join("helm", "korifi", "controllers", "role.yaml"))
 	Expect(shared.SetupIndexWithManager(k8sManager)).To(Succeed())
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	rootNamespace = "cf"
+	Expect(adminClient.Create(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rootNamespace,
+		},
+	})).To(Succeed())
+
+	Expect(adminClient.Create(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "korifi-gateway",
+		},
+	})).To(Succeed())
+	Expect(adminClient.Create(context.Background(), &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "korifi",
+			Namespace: "korifi-gateway",
+		},
+		Spec: gatewayv1beta1.GatewaySpec{
+			GatewayClassName: "korifi",
+			Listeners: []gatewayv1.Listener{{
+				Name:     "http-apps",
+				Port:     80,
+				Protocol: gatewayv1.HTTPProtocolType,
+			}},
+		},
+	})).To(Succeed())
+
 	Expect(routes.NewReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFRoute"),
 		&config.ControllerConfig{
+			CFRootNamespace: rootNamespace,
 			CFProcessDefaults: config.CFProcessDefaults{
 				MemoryMB:    500,
 				DiskQuotaMB: 512,
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -49,18 +50,35 @@ const (
 	ServiceBindingSecretTypePrefix    = "servicebinding.io/"
 )
 
+type BrokerClient interface {
+	Bind(context.Context, *korifiv1alpha1.CFServiceBroker, osbapi.BindPayload) (osbapi.BindResponse, error)
+	Unbind(context.Context, *korifiv1alpha1.CFServiceBroker, osbapi.BindingPayload) (osbapi.UnbindResponse, error)
+	GetServiceBinding(context.Context, *korifiv1alpha1.CFServiceBroker, osbapi.BindingPayload) (osbapi.BindingResponse, error)
+	GetServiceBindingLastOperation(context.Context, *korifiv1alpha1.CFServiceBroker, osbapi.BindingLastOperationPayload) (osbapi.LastOperationResponse, error)
+}
+
 type Reconciler struct {
-	k8sClient client.Client
-	scheme    *runtime.Scheme
-	log       logr.Logger
+	k8sClient     client.Client
+	brokerClient  BrokerClient
+	scheme        *runtime.Scheme
+	rootNamespace string
+	log           logr.Logger
 }
 
 func NewReconciler(
 	k8sClient client.Client,
+	brokerClient BrokerClient,
 	scheme *runtime.Scheme,
+	rootNamespace string,
 	log logr.Logger,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceBinding, *korifiv1alpha1.CFServiceBinding] {
-	cfBindingReconciler := &Reconciler{k8sClient: k8sClient, scheme: scheme, log: log}
+	cfBindingReconciler := &Reconciler{
+		k8sClient:     k8sClient,
+		brokerClient:  brokerClient,
+		scheme:        scheme,
+		rootNamespace: rootNamespace,
+		log:           log,
+	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFServiceBinding, *korifiv1alpha1.CFServiceBinding](log, k8sClient, cfBindingReconciler)
 }
 
@@ -130,6 +148,7 @@ func (r *Reconciler) appToServiceBindings(ctx context.Context, o client.Object)
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=servicebinding.io,resources=servicebindings,verbs=get;list;create;update;patch;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans;cfserviceofferings;cfservicebrokers,verbs=get;list;watch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -143,6 +162,12 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, readyConditionBuilder.WithError(err).Build())
 	}()
 
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		var result ctrl.Result
+		result, err = r.finalizeCFServiceBinding(ctx, cfServiceBinding, readyConditionBuilder)
+		return result, err
+	}
+
 	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
 	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, cfServiceInstance)
 	if err != nil {
@@ -156,11 +181,28 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 		return ctrl.Result{}, err
 	}
 
-	if cfServiceInstance.Status.Credentials.Name == "" {
-		readyConditionBuilder.
-			WithReason("CredentialsSecretNotAvailable").
-			WithMessage("Service instance credentials not available yet")
-		return ctrl.Result{RequeueAfter: time.Second}, nil
+	if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		var result ctrl.Result
+		result, err = r.reconcileManagedBinding(ctx, cfServiceInstance, cfServiceBinding, readyConditionBuilder)
+		if err != nil || !meta.IsStatusConditionTrue(cfServiceBinding.Status.Conditions, korifiv1alpha1.BoundCondition) {
+			return result, err
+		}
+	} else {
+		if cfServiceInstance.Status.Credentials.Name == "" {
+			readyConditionBuilder.
+				WithReason("CredentialsSecretNotAvailable").
+				WithMessage("Service instance credentials not available yet")
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		}
+
+		cfServiceBinding.Status.Credentials.Name = cfServiceInstance.Status.Credentials.Name
+	}
+
+	if cfServiceBinding.IsKey() {
+		// Service keys only expose their credentials through the API, there
+		// is no app workload to project them into
+		readyConditionBuilder.Ready()
+		return ctrl.Result{}, nil
 	}
 
 	credentialsSecret, err := r.reconcileCredentials(ctx, cfServiceInstance, cfServiceBinding)
@@ -215,8 +257,6 @@ func isSbServiceBindingReady(sbServiceBinding *servicebindingv1beta1.ServiceBind
 }
 
 func (r *Reconciler) reconcileCredentials(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (*corev1.Secret, error) {
-	cfServiceBinding.Status.Credentials.Name = cfServiceInstance.Status.Credentials.Name
-
 	if isLegacyServiceBinding(cfServiceBinding, cfServiceInstance) {
 		bindingSecret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
@@ -237,13 +277,13 @@ func (r *Reconciler) reconcileCredentials(ctx context.Context, cfServiceInstance
 
 	credentialsSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: cfServiceInstance.Namespace,
-			Name:      cfServiceInstance.Status.Credentials.Name,
+			Namespace: cfServiceBinding.Namespace,
+			Name:      cfServiceBinding.Status.Credentials.Name,
 		},
 	}
 	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get service instance credentials secret %q: %w", cfServiceInstance.Status.Credentials.Name, err)
+		return nil, fmt.Errorf("failed to get credentials secret %q: %w", cfServiceBinding.Status.Credentials.Name, err)
 	}
 
 	bindingSecret := &corev1.Secret{
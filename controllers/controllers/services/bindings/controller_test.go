@@ -569,4 +569,49 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 	})
+
+	When("the binding is a key", func() {
+		var keyBinding *korifiv1alpha1.CFServiceBinding
+
+		BeforeEach(func() {
+			keyBinding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type:        korifiv1alpha1.CFServiceBindingTypeKey,
+					DisplayName: tools.PtrTo("my-key"),
+					Service: corev1.ObjectReference{
+						Kind:       "ServiceInstance",
+						Name:       instance.Name,
+						APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, keyBinding)).To(Succeed())
+		})
+
+		It("references the instance credentials and becomes ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(keyBinding), keyBinding)).To(Succeed())
+				g.Expect(keyBinding.Status.Credentials.Name).To(Equal(instanceCredentialsSecret.Name))
+				g.Expect(meta.IsStatusConditionTrue(keyBinding.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		It("does not project the credentials into a workload", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(keyBinding), keyBinding)).To(Succeed())
+				g.Expect(keyBinding.Status.Binding.Name).To(BeEmpty())
+
+				sbServiceBindings := &servicebindingv1beta1.ServiceBindingList{}
+				g.Expect(adminClient.List(ctx, sbServiceBindings,
+					client.InNamespace(testNamespace),
+					client.MatchingLabels{bindings.ServiceBindingGUIDLabel: keyBinding.Name},
+				)).To(Succeed())
+				g.Expect(sbServiceBindings.Items).To(BeEmpty())
+			}).Should(Succeed())
+		})
+	})
 })
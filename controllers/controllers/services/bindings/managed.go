@@ -0,0 +1,378 @@
+package bindings
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers/osbapi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
+	"code.cloudfoundry.org/korifi/model/services"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func (r *Reconciler) reconcileManagedBinding(
+	ctx context.Context,
+	cfServiceInstance *korifiv1alpha1.CFServiceInstance,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	readyConditionBuilder *k8s.ReadyConditionBuilder,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileManagedBinding")
+
+	boundCondition := meta.FindStatusCondition(cfServiceBinding.Status.Conditions, korifiv1alpha1.BoundCondition)
+	if boundCondition != nil && boundCondition.Status == metav1.ConditionTrue {
+		return ctrl.Result{}, nil
+	}
+
+	if boundCondition != nil && boundCondition.Status == metav1.ConditionFalse {
+		readyConditionBuilder.WithReason(boundCondition.Reason).WithMessage(boundCondition.Message)
+		return ctrl.Result{}, nil
+	}
+
+	if !meta.IsStatusConditionTrue(cfServiceInstance.Status.Conditions, korifiv1alpha1.ProvisionedCondition) {
+		readyConditionBuilder.
+			WithReason("ServiceInstanceNotProvisioned").
+			WithMessage("Service instance has not been provisioned yet")
+		return ctrl.Result{}, nil
+	}
+
+	resources, err := osbapi.GetBrokerResources(ctx, r.k8sClient, r.rootNamespace, cfServiceInstance)
+	if err != nil {
+		log.Info("failed to resolve broker resources", "reason", err)
+		readyConditionBuilder.WithReason("BrokerResourcesNotAvailable")
+		return ctrl.Result{}, err
+	}
+
+	if osbapi.IsOperationInProgress(cfServiceBinding.Status.LastOperation, services.LastOperationTypeCreate) {
+		return r.pollBindOperation(ctx, cfServiceBinding, resources, readyConditionBuilder)
+	}
+
+	bindResponse, err := r.brokerClient.Bind(ctx, resources.Broker, osbapi.BindPayload{
+		InstanceID: cfServiceInstance.Name,
+		BindingID:  cfServiceBinding.Name,
+		BindRequest: osbapi.BindRequest{
+			ServiceId: resources.Offering.Spec.BrokerCatalog.Id,
+			PlanID:    resources.Plan.Spec.BrokerCatalog.ID,
+			AppGUID:   cfServiceBinding.Spec.AppRef.Name,
+			BindResource: osbapi.BindResource{
+				AppGUID:   cfServiceBinding.Spec.AppRef.Name,
+				SpaceGUID: cfServiceBinding.Namespace,
+			},
+		},
+	})
+	if err != nil {
+		log.Info("failed to bind service instance", "reason", err)
+
+		var unrecoverableErr osbapi.UnrecoverableError
+		if errors.As(err, &unrecoverableErr) {
+			setBindingFailed(cfServiceBinding, readyConditionBuilder, unrecoverableErr.Error())
+			return ctrl.Result{}, nil
+		}
+
+		readyConditionBuilder.WithReason("BindRequestFailed")
+		return ctrl.Result{}, err
+	}
+
+	if bindResponse.IsAsync {
+		cfServiceBinding.Status.LastOperation = &services.LastOperation{
+			Type:  services.LastOperationTypeCreate,
+			State: services.LastOperationStateInProgress,
+		}
+		cfServiceBinding.Status.BrokerOperation = bindResponse.Operation
+		readyConditionBuilder.WithReason("BindingInProgress")
+		return ctrl.Result{RequeueAfter: osbapi.DefaultLastOperationPollingInterval}, nil
+	}
+
+	err = r.storeBrokerCredentials(ctx, cfServiceBinding, bindResponse.Credentials)
+	if err != nil {
+		log.Info("failed to store binding credentials", "reason", err)
+		readyConditionBuilder.WithReason("FailedToStoreCredentials")
+		return ctrl.Result{}, err
+	}
+
+	setBound(cfServiceBinding)
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) pollBindOperation(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	resources osbapi.BrokerResources,
+	readyConditionBuilder *k8s.ReadyConditionBuilder,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("pollBindOperation")
+
+	lastOperation, err := r.brokerClient.GetServiceBindingLastOperation(ctx, resources.Broker, resources.BindingLastOperationPayload(cfServiceBinding))
+	if errors.Is(err, osbapi.ErrGone) {
+		setBindingFailed(cfServiceBinding, readyConditionBuilder, "the service binding no longer exists in the broker")
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Info("failed to get last operation", "reason", err)
+		readyConditionBuilder.WithReason("GetLastOperationFailed")
+		return ctrl.Result{}, err
+	}
+
+	switch lastOperation.State {
+	case services.LastOperationStateSucceeded:
+		binding, err := r.brokerClient.GetServiceBinding(ctx, resources.Broker, resources.BindingPayload(cfServiceBinding))
+		if err != nil {
+			log.Info("failed to fetch the binding from the broker", "reason", err)
+			readyConditionBuilder.WithReason("GetBindingFailed")
+			return ctrl.Result{}, err
+		}
+
+		err = r.storeBrokerCredentials(ctx, cfServiceBinding, binding.Credentials)
+		if err != nil {
+			log.Info("failed to store binding credentials", "reason", err)
+			readyConditionBuilder.WithReason("FailedToStoreCredentials")
+			return ctrl.Result{}, err
+		}
+
+		setBound(cfServiceBinding)
+		return ctrl.Result{}, nil
+	case services.LastOperationStateFailed:
+		setBindingFailed(cfServiceBinding, readyConditionBuilder, lastOperation.Description)
+		return ctrl.Result{}, nil
+	default:
+		cfServiceBinding.Status.LastOperation.Description = lastOperation.Description
+		readyConditionBuilder.WithReason("BindingInProgress")
+		return ctrl.Result{RequeueAfter: osbapi.PollingInterval(lastOperation)}, nil
+	}
+}
+
+func (r *Reconciler) finalizeCFServiceBinding(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	readyConditionBuilder *k8s.ReadyConditionBuilder,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFServiceBinding")
+
+	if !controllerutil.ContainsFinalizer(cfServiceBinding, korifiv1alpha1.CFServiceBindingFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	if cfServiceBinding.Status.LastOperation == nil || meta.IsStatusConditionFalse(cfServiceBinding.Status.Conditions, korifiv1alpha1.BoundCondition) {
+		removeFinalizer(log, cfServiceBinding)
+		return ctrl.Result{}, nil
+	}
+
+	if isUnbindingFailed(cfServiceBinding) {
+		if !isDeletionRetried(cfServiceBinding) {
+			readyConditionBuilder.WithReason("UnbindingFailed").WithMessage(cfServiceBinding.Status.LastOperation.Description)
+			return ctrl.Result{}, nil
+		}
+
+		log.Info("retrying unbinding")
+		retryUnbinding(cfServiceBinding)
+	}
+
+	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, cfServiceInstance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("service instance no longer exists, skipping unbinding", "reason", err)
+			removeFinalizer(log, cfServiceBinding)
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	resources, err := osbapi.GetBrokerResources(ctx, r.k8sClient, r.rootNamespace, cfServiceInstance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("broker resources no longer exist, skipping unbinding", "reason", err)
+			removeFinalizer(log, cfServiceBinding)
+			return ctrl.Result{}, nil
+		}
+
+		readyConditionBuilder.WithReason("BrokerResourcesNotAvailable")
+		return ctrl.Result{}, err
+	}
+
+	if osbapi.IsOperationInProgress(cfServiceBinding.Status.LastOperation, services.LastOperationTypeDelete) {
+		return r.pollUnbindOperation(ctx, cfServiceBinding, resources, readyConditionBuilder)
+	}
+
+	unbindResponse, err := r.brokerClient.Unbind(ctx, resources.Broker, resources.BindingPayload(cfServiceBinding))
+	if err != nil {
+		log.Info("failed to unbind service binding", "reason", err)
+
+		var unrecoverableErr osbapi.UnrecoverableError
+		if errors.As(err, &unrecoverableErr) {
+			setUnbindingFailed(cfServiceBinding, readyConditionBuilder, unrecoverableErr.Error())
+			return ctrl.Result{}, nil
+		}
+
+		readyConditionBuilder.WithReason("UnbindRequestFailed")
+		return ctrl.Result{}, err
+	}
+
+	if unbindResponse.IsAsync {
+		cfServiceBinding.Status.LastOperation = &services.LastOperation{
+			Type:  services.LastOperationTypeDelete,
+			State: services.LastOperationStateInProgress,
+		}
+		cfServiceBinding.Status.BrokerOperation = unbindResponse.Operation
+		readyConditionBuilder.WithReason("UnbindingInProgress")
+		return ctrl.Result{RequeueAfter: osbapi.DefaultLastOperationPollingInterval}, nil
+	}
+
+	removeFinalizer(log, cfServiceBinding)
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) pollUnbindOperation(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	resources osbapi.BrokerResources,
+	readyConditionBuilder *k8s.ReadyConditionBuilder,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("pollUnbindOperation")
+
+	lastOperation, err := r.brokerClient.GetServiceBindingLastOperation(ctx, resources.Broker, resources.BindingLastOperationPayload(cfServiceBinding))
+	if errors.Is(err, osbapi.ErrGone) {
+		removeFinalizer(log, cfServiceBinding)
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Info("failed to get last operation", "reason", err)
+		readyConditionBuilder.WithReason("GetLastOperationFailed")
+		return ctrl.Result{}, err
+	}
+
+	switch lastOperation.State {
+	case services.LastOperationStateSucceeded:
+		removeFinalizer(log, cfServiceBinding)
+		return ctrl.Result{}, nil
+	case services.LastOperationStateFailed:
+		setUnbindingFailed(cfServiceBinding, readyConditionBuilder, lastOperation.Description)
+		return ctrl.Result{}, nil
+	default:
+		cfServiceBinding.Status.LastOperation.Description = lastOperation.Description
+		readyConditionBuilder.WithReason("UnbindingInProgress")
+		return ctrl.Result{RequeueAfter: osbapi.PollingInterval(lastOperation)}, nil
+	}
+}
+
+func (r *Reconciler) storeBrokerCredentials(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, brokerCredentials map[string]any) error {
+	if brokerCredentials == nil {
+		brokerCredentials = map[string]any{}
+	}
+
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      managedCredentialsSecretName(cfServiceBinding),
+			Namespace: cfServiceBinding.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, credentialsSecret, func() error {
+		var err error
+		credentialsSecret.Data, err = credentials.ToCredentialsSecretData(brokerCredentials)
+		if err != nil {
+			return err
+		}
+
+		return controllerutil.SetControllerReference(cfServiceBinding, credentialsSecret, r.scheme)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create binding credentials secret")
+	}
+
+	cfServiceBinding.Status.Credentials.Name = credentialsSecret.Name
+
+	return nil
+}
+
+func managedCredentialsSecretName(cfServiceBinding *korifiv1alpha1.CFServiceBinding) string {
+	return cfServiceBinding.Name + "-credentials"
+}
+
+func setBound(cfServiceBinding *korifiv1alpha1.CFServiceBinding) {
+	cfServiceBinding.Status.LastOperation = &services.LastOperation{
+		Type:  services.LastOperationTypeCreate,
+		State: services.LastOperationStateSucceeded,
+	}
+	cfServiceBinding.Status.BrokerOperation = ""
+
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.BoundCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Bound",
+	})
+}
+
+func setBindingFailed(cfServiceBinding *korifiv1alpha1.CFServiceBinding, readyConditionBuilder *k8s.ReadyConditionBuilder, message string) {
+	cfServiceBinding.Status.LastOperation = &services.LastOperation{
+		Type:        services.LastOperationTypeCreate,
+		State:       services.LastOperationStateFailed,
+		Description: message,
+	}
+	cfServiceBinding.Status.BrokerOperation = ""
+
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.BoundCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "BindingFailed",
+		Message:            message,
+	})
+
+	readyConditionBuilder.WithReason("BindingFailed").WithMessage(message)
+}
+
+func setUnbindingFailed(cfServiceBinding *korifiv1alpha1.CFServiceBinding, readyConditionBuilder *k8s.ReadyConditionBuilder, message string) {
+	cfServiceBinding.Status.LastOperation = &services.LastOperation{
+		Type:        services.LastOperationTypeDelete,
+		State:       services.LastOperationStateFailed,
+		Description: message,
+	}
+	cfServiceBinding.Status.BrokerOperation = ""
+
+	readyConditionBuilder.WithReason("UnbindingFailed").WithMessage(message)
+}
+
+// isUnbindingFailed tells whether the broker has already given up on
+// unbinding the binding, in which case the request is only retried once the
+// binding is deleted again
+func isUnbindingFailed(cfServiceBinding *korifiv1alpha1.CFServiceBinding) bool {
+	lastOperation := cfServiceBinding.Status.LastOperation
+	return lastOperation != nil &&
+		lastOperation.Type == services.LastOperationTypeDelete &&
+		lastOperation.State == services.LastOperationStateFailed
+}
+
+// isDeletionRetried tells whether the binding has been deleted again since
+// the broker failed to unbind it
+func isDeletionRetried(cfServiceBinding *korifiv1alpha1.CFServiceBinding) bool {
+	_, ok := cfServiceBinding.Annotations[korifiv1alpha1.RetryDeletionAnnotation]
+	return ok
+}
+
+func retryUnbinding(cfServiceBinding *korifiv1alpha1.CFServiceBinding) {
+	delete(cfServiceBinding.Annotations, korifiv1alpha1.RetryDeletionAnnotation)
+	cfServiceBinding.Status.LastOperation = &services.LastOperation{
+		Type:  services.LastOperationTypeDelete,
+		State: services.LastOperationStateInitial,
+	}
+}
+
+func removeFinalizer(log logr.Logger, cfServiceBinding *korifiv1alpha1.CFServiceBinding) {
+	if controllerutil.RemoveFinalizer(cfServiceBinding, korifiv1alpha1.CFServiceBindingFinalizerName) {
+		log.V(1).Info("finalizer removed")
+	}
+}
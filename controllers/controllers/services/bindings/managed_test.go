@@ -0,0 +1,636 @@
+package bindings_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/services"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tests/helpers/broker"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFServiceBinding managed", func() {
+	var (
+		testNamespace string
+		brokerServer  *broker.BrokerServer
+		requestsLock  sync.Mutex
+		requestBodies []map[string]any
+		instance      *korifiv1alpha1.CFServiceInstance
+		binding       *korifiv1alpha1.CFServiceBinding
+		appGUID       string
+	)
+
+	recordingHandler := func(status int, body string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsLock.Lock()
+			defer requestsLock.Unlock()
+
+			reqBody, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			if len(reqBody) > 0 {
+				reqBodyMap := map[string]any{}
+				Expect(json.Unmarshal(reqBody, &reqBodyMap)).To(Succeed())
+				requestBodies = append(requestBodies, reqBodyMap)
+			}
+
+			if r.Method == http.MethodDelete {
+				_, _ = w.Write([]byte("{}"))
+				return
+			}
+
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		})
+	}
+
+	servedRequests := func() []*http.Request {
+		requestsLock.Lock()
+		defer requestsLock.Unlock()
+
+		return brokerServer.ServedRequests()
+	}
+
+	BeforeEach(func() {
+		requestBodies = nil
+		brokerServer = broker.NewServer().WithHandler(
+			"/v2/service_instances/{id}/service_bindings/{binding_id}",
+			recordingHandler(http.StatusCreated, `{"credentials": {"user": "bob", "port": 5432}}`),
+		)
+
+		testNamespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testNamespace,
+			},
+		})).To(Succeed())
+
+		cfApp := &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  uuid.NewString(),
+				DesiredState: korifiv1alpha1.StoppedState,
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "docker",
+				},
+			},
+		}
+		helpers.EnsureCreate(adminClient, cfApp)
+		appGUID = cfApp.Name
+	})
+
+	JustBeforeEach(func() {
+		brokerServer.Start()
+		DeferCleanup(func() {
+			brokerServer.Stop()
+		})
+
+		creds := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Data: map[string][]byte{
+				korifiv1alpha1.CredentialsSecretKey: []byte(`{"username":"broker-user","password":"broker-password"}`),
+			},
+		}
+		helpers.EnsureCreate(adminClient, creds)
+
+		serviceBroker := &korifiv1alpha1.CFServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceBrokerSpec{
+				ServiceBroker: services.ServiceBroker{
+					Name: uuid.NewString(),
+					URL:  brokerServer.URL(),
+				},
+				Credentials: corev1.LocalObjectReference{Name: creds.Name},
+			},
+		}
+		helpers.EnsureCreate(adminClient, serviceBroker)
+
+		offering := &korifiv1alpha1.CFServiceOffering{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.RelServiceBrokerLabel: serviceBroker.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFServiceOfferingSpec{
+				ServiceOffering: services.ServiceOffering{
+					Name: "my-offering",
+					BrokerCatalog: services.ServiceBrokerCatalog{
+						Id: "broker-offering-id",
+					},
+				},
+			},
+		}
+		helpers.EnsureCreate(adminClient, offering)
+
+		plan := &korifiv1alpha1.CFServicePlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.RelServiceBrokerLabel:   serviceBroker.Name,
+					korifiv1alpha1.RelServiceOfferingLabel: offering.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFServicePlanSpec{
+				ServicePlan: services.ServicePlan{
+					BrokerServicePlan: services.BrokerServicePlan{
+						Name: "my-plan",
+						BrokerCatalog: services.ServicePlanBrokerCatalog{
+							ID: "broker-plan-id",
+						},
+					},
+				},
+			},
+		}
+		helpers.EnsureCreate(adminClient, plan)
+
+		instance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName: "service-instance-name",
+				Type:        korifiv1alpha1.ManagedType,
+				PlanGUID:    plan.Name,
+			},
+		}
+		helpers.EnsureCreate(adminClient, instance)
+		helpers.EnsurePatch(adminClient, instance, func(i *korifiv1alpha1.CFServiceInstance) {
+			meta.SetStatusCondition(&i.Status.Conditions, metav1.Condition{
+				Type:               korifiv1alpha1.ProvisionedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Provisioned",
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+
+		binding = &korifiv1alpha1.CFServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       uuid.NewString(),
+				Namespace:  testNamespace,
+				Finalizers: []string{korifiv1alpha1.CFServiceBindingFinalizerName},
+			},
+			Spec: korifiv1alpha1.CFServiceBindingSpec{
+				Service: corev1.ObjectReference{
+					Kind:       "ServiceInstance",
+					Name:       instance.Name,
+					APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+				},
+				AppRef: corev1.LocalObjectReference{
+					Name: appGUID,
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, binding)).To(Succeed())
+	})
+
+	It("binds the service instance", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(servedRequests()).To(HaveLen(1))
+		}).Should(Succeed())
+
+		Expect(servedRequests()[0].Method).To(Equal(http.MethodPut))
+		Expect(servedRequests()[0].URL.Path).To(Equal("/v2/service_instances/" + instance.Name + "/service_bindings/" + binding.Name))
+		Expect(requestBodies).To(ConsistOf(Equal(map[string]any{
+			"service_id": "broker-offering-id",
+			"plan_id":    "broker-plan-id",
+			"app_guid":   appGUID,
+			"bind_resource": map[string]any{
+				"app_guid":   appGUID,
+				"space_guid": testNamespace,
+			},
+		})))
+	})
+
+	It("sets the Bound condition and the last operation", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+			g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+				HasType(Equal(korifiv1alpha1.BoundCondition)),
+				HasStatus(Equal(metav1.ConditionTrue)),
+			)))
+			g.Expect(binding.Status.LastOperation).To(PointTo(Equal(services.LastOperation{
+				Type:  "create",
+				State: "succeeded",
+			})))
+		}).Should(Succeed())
+	})
+
+	It("stores the broker credentials in the binding credentials secret", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+			g.Expect(binding.Status.Credentials.Name).NotTo(BeEmpty())
+
+			credentialsSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      binding.Status.Credentials.Name,
+				},
+			}
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)).To(Succeed())
+			g.Expect(credentialsSecret.Data).To(MatchAllKeys(Keys{
+				korifiv1alpha1.CredentialsSecretKey: MatchJSON(`{"user": "bob", "port": 5432}`),
+			}))
+			g.Expect(credentialsSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("CFServiceBinding"),
+				"Name": Equal(binding.Name),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("creates the servicebinding.io binding secret", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+			g.Expect(binding.Status.Binding.Name).NotTo(BeEmpty())
+
+			bindingSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      binding.Status.Binding.Name,
+				},
+			}
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(bindingSecret), bindingSecret)).To(Succeed())
+			g.Expect(bindingSecret.Data).To(MatchKeys(IgnoreExtras, Keys{
+				"user": BeEquivalentTo("bob"),
+				"port": BeEquivalentTo("5432"),
+			}))
+		}).Should(Succeed())
+	})
+
+	It("binds the service instance only once", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BoundCondition)).To(BeTrue())
+		}).Should(Succeed())
+
+		Expect(k8s.PatchResource(ctx, adminClient, binding, func() {
+			binding.Spec.DisplayName = tools.PtrTo("new-name")
+		})).To(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(servedRequests()).To(HaveLen(1))
+		}).Should(Succeed())
+	})
+
+	When("the service instance has not been provisioned yet", func() {
+		JustBeforeEach(func() {
+			helpers.EnsurePatch(adminClient, instance, func(i *korifiv1alpha1.CFServiceInstance) {
+				meta.RemoveStatusCondition(&i.Status.Conditions, korifiv1alpha1.ProvisionedCondition)
+			})
+		})
+
+		It("does not bind the service instance", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BoundCondition)).To(BeFalse())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the broker binds asynchronously", func() {
+		var lastOperationState string
+
+		BeforeEach(func() {
+			lastOperationState = "in progress"
+			brokerServer = broker.NewServer().
+				WithHandler(
+					"/v2/service_instances/{id}/service_bindings/{binding_id}",
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.Method == http.MethodGet {
+							_, _ = w.Write([]byte(`{"credentials": {"user": "alice"}}`))
+							return
+						}
+
+						w.WriteHeader(http.StatusAccepted)
+						_, _ = w.Write([]byte(`{"operation": "bind-op"}`))
+					}),
+				).
+				WithHandler(
+					"/v2/service_instances/{id}/service_bindings/{binding_id}/last_operation",
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						requestsLock.Lock()
+						defer requestsLock.Unlock()
+
+						w.Header().Set("Retry-After", "1")
+						_, _ = w.Write([]byte(`{"state": "` + lastOperationState + `", "description": "binding"}`))
+					}),
+				)
+		})
+
+		It("sets the last operation to in progress", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(binding.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal("create"),
+					"State": Equal("in progress"),
+				})))
+				g.Expect(binding.Status.BrokerOperation).To(Equal("bind-op"))
+				g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionFalse)),
+					HasReason(Equal("BindingInProgress")),
+				)))
+			}).Should(Succeed())
+		})
+
+		When("the operation succeeds", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.BrokerOperation).To(Equal("bind-op"))
+				}).Should(Succeed())
+
+				requestsLock.Lock()
+				lastOperationState = "succeeded"
+				requestsLock.Unlock()
+			})
+
+			It("fetches the binding credentials from the broker", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BoundCondition)).To(BeTrue())
+
+					credentialsSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: testNamespace,
+							Name:      binding.Status.Credentials.Name,
+						},
+					}
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)).To(Succeed())
+					g.Expect(credentialsSecret.Data).To(MatchAllKeys(Keys{
+						korifiv1alpha1.CredentialsSecretKey: MatchJSON(`{"user": "alice"}`),
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the operation fails", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.BrokerOperation).To(Equal("bind-op"))
+				}).Should(Succeed())
+
+				requestsLock.Lock()
+				lastOperationState = "failed"
+				requestsLock.Unlock()
+			})
+
+			It("sets the Bound condition to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.LastOperation).To(PointTo(Equal(services.LastOperation{
+						Type:        "create",
+						State:       "failed",
+						Description: "binding",
+					})))
+					g.Expect(meta.IsStatusConditionFalse(binding.Status.Conditions, korifiv1alpha1.BoundCondition)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the broker rejects the bind request", func() {
+		BeforeEach(func() {
+			brokerServer = broker.NewServer().WithHandler(
+				"/v2/service_instances/{id}/service_bindings/{binding_id}",
+				recordingHandler(http.StatusUnprocessableEntity, `{"description": "app is required"}`),
+			)
+		})
+
+		It("sets the Bound condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(binding.Status.Conditions).To(ContainElements(
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.BoundCondition)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("BindingFailed")),
+					),
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("BindingFailed")),
+						HasMessage(ContainSubstring("app is required")),
+					),
+				))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the binding is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BoundCondition)).To(BeTrue())
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, binding)).To(Succeed())
+		})
+
+		It("unbinds the service instance and deletes the binding", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+
+			Expect(servedRequests()).To(HaveLen(2))
+			unbindRequest := servedRequests()[1]
+			Expect(unbindRequest.Method).To(Equal(http.MethodDelete))
+			Expect(unbindRequest.URL.Path).To(Equal("/v2/service_instances/" + instance.Name + "/service_bindings/" + binding.Name))
+			Expect(unbindRequest.URL.Query()).To(SatisfyAll(
+				HaveKeyWithValue("service_id", ConsistOf("broker-offering-id")),
+				HaveKeyWithValue("plan_id", ConsistOf("broker-plan-id")),
+			))
+		})
+
+		unbindRequests := func() []*http.Request {
+			var requests []*http.Request
+			for _, r := range servedRequests() {
+				if r.Method == http.MethodDelete {
+					requests = append(requests, r)
+				}
+			}
+			return requests
+		}
+
+		When("the broker rejects the unbind request", func() {
+			var rejectUnbind atomic.Bool
+
+			BeforeEach(func() {
+				rejectUnbind.Store(true)
+				brokerServer = broker.NewServer().WithHandler(
+					"/v2/service_instances/{id}/service_bindings/{binding_id}",
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.Method == http.MethodDelete && rejectUnbind.Load() {
+							w.WriteHeader(http.StatusUnprocessableEntity)
+							_, _ = w.Write([]byte(`{"description": "binding is in use"}`))
+							return
+						}
+
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write([]byte(`{"credentials": {"user": "bob"}}`))
+					}),
+				)
+			})
+
+			It("marks the unbinding as failed and does not retry it", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(services.LastOperationTypeDelete),
+						"State": Equal(services.LastOperationStateFailed),
+					})))
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("UnbindingFailed")),
+						HasMessage(ContainSubstring("binding is in use")),
+					)))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					g.Expect(unbindRequests()).To(HaveLen(1))
+				}, "2s").Should(Succeed())
+			})
+
+			When("the binding is deleted again", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(services.LastOperationTypeDelete),
+							"State": Equal(services.LastOperationStateFailed),
+						})))
+					}).Should(Succeed())
+
+					rejectUnbind.Store(false)
+					Expect(k8s.PatchResource(ctx, adminClient, binding, func() {
+						binding.Annotations = map[string]string{korifiv1alpha1.RetryDeletionAnnotation: "true"}
+					})).To(Succeed())
+				})
+
+				It("retries the unbinding and deletes the binding", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+
+					Expect(unbindRequests()).To(HaveLen(2))
+				})
+			})
+		})
+
+		When("the broker fails to unbind asynchronously", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().
+					WithHandler(
+						"/v2/service_instances/{id}/service_bindings/{binding_id}",
+						http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							if r.Method == http.MethodDelete {
+								w.WriteHeader(http.StatusAccepted)
+								_, _ = w.Write([]byte(`{"operation": "unbind-op"}`))
+								return
+							}
+
+							w.WriteHeader(http.StatusCreated)
+							_, _ = w.Write([]byte(`{"credentials": {"user": "bob"}}`))
+						}),
+					).
+					WithHandler(
+						"/v2/service_instances/{id}/service_bindings/{binding_id}/last_operation",
+						http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							_, _ = w.Write([]byte(`{"state": "failed", "description": "unbinding"}`))
+						}),
+					)
+			})
+
+			lastOperationRequests := func() []*http.Request {
+				var requests []*http.Request
+				for _, r := range servedRequests() {
+					if r.URL.Path == "/v2/service_instances/"+instance.Name+"/service_bindings/"+binding.Name+"/last_operation" {
+						requests = append(requests, r)
+					}
+				}
+				return requests
+			}
+
+			It("marks the unbinding as failed and stops polling", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.LastOperation).To(PointTo(Equal(services.LastOperation{
+						Type:        services.LastOperationTypeDelete,
+						State:       services.LastOperationStateFailed,
+						Description: "unbinding",
+					})))
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("UnbindingFailed")),
+					)))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					g.Expect(unbindRequests()).To(HaveLen(1))
+					g.Expect(lastOperationRequests()).To(HaveLen(1))
+				}, "2s").Should(Succeed())
+			})
+		})
+
+		When("the broker fails to unbind the binding", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler(
+					"/v2/service_instances/{id}/service_bindings/{binding_id}",
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.Method == http.MethodDelete {
+							w.WriteHeader(http.StatusInternalServerError)
+							_, _ = w.Write([]byte("{}"))
+							return
+						}
+
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write([]byte(`{"credentials": {"user": "bob"}}`))
+					}),
+				)
+			})
+
+			It("keeps retrying the unbinding", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("UnbindRequestFailed")),
+					)))
+				}).Should(Succeed())
+			})
+		})
+	})
+})
@@ -6,10 +6,13 @@ import (
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
@@ -53,6 +56,105 @@ type Plan struct {
 	Schemas          services.ServicePlanSchemas `json:"schemas"`
 }
 
+type InstanceProvisionPayload struct {
+	InstanceID string
+	InstanceProvisionRequest
+}
+
+type InstanceProvisionRequest struct {
+	ServiceId  string         `json:"service_id"`
+	PlanID     string         `json:"plan_id"`
+	SpaceGUID  string         `json:"space_guid"`
+	OrgGUID    string         `json:"organization_guid"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+type InstanceDeprovisionPayload struct {
+	InstanceID string
+	ServiceId  string
+	PlanID     string
+}
+
+type InstanceLastOperationPayload struct {
+	InstanceID string
+	ServiceId  string
+	PlanID     string
+	Operation  string
+}
+
+type BindPayload struct {
+	InstanceID string
+	BindingID  string
+	BindRequest
+}
+
+type BindRequest struct {
+	ServiceId    string         `json:"service_id"`
+	PlanID       string         `json:"plan_id"`
+	AppGUID      string         `json:"app_guid,omitempty"`
+	BindResource BindResource   `json:"bind_resource"`
+	Parameters   map[string]any `json:"parameters,omitempty"`
+}
+
+type BindResource struct {
+	AppGUID   string `json:"app_guid,omitempty"`
+	SpaceGUID string `json:"space_guid"`
+}
+
+type BindingPayload struct {
+	InstanceID string
+	BindingID  string
+	ServiceId  string
+	PlanID     string
+}
+
+type BindingLastOperationPayload struct {
+	BindingPayload
+	Operation string
+}
+
+type ServiceInstanceOperationResponse struct {
+	DashboardURL string `json:"dashboard_url,omitempty"`
+	Operation    string `json:"operation,omitempty"`
+	IsAsync      bool   `json:"-"`
+}
+
+type BindResponse struct {
+	Credentials map[string]any `json:"credentials,omitempty"`
+	Operation   string         `json:"operation,omitempty"`
+	IsAsync     bool           `json:"-"`
+}
+
+type UnbindResponse struct {
+	Operation string `json:"operation,omitempty"`
+	IsAsync   bool   `json:"-"`
+}
+
+type BindingResponse struct {
+	Credentials map[string]any `json:"credentials,omitempty"`
+}
+
+type LastOperationResponse struct {
+	State       string        `json:"state"`
+	Description string        `json:"description,omitempty"`
+	RetryAfter  time.Duration `json:"-"`
+}
+
+// ErrGone is returned when the broker responds with 410 Gone to a last
+// operation request, i.e. the resource does not exist anymore
+var ErrGone = errors.New("the resource is gone")
+
+// UnrecoverableError is returned when the broker rejects a request in a way
+// that retrying the very same request would not help (e.g. 400, 409 or 422)
+type UnrecoverableError struct {
+	Status  int
+	Message string
+}
+
+func (e UnrecoverableError) Error() string {
+	return fmt.Sprintf("broker rejected the request with status %d: %s", e.Status, e.Message)
+}
+
 type Client struct {
 	k8sClient client.Client
 	insecure  bool
@@ -63,13 +165,13 @@ func NewClient(k8sClient client.Client, insecure bool) *Client {
 }
 
 func (c *Client) GetCatalog(ctx context.Context, broker *korifiv1alpha1.CFServiceBroker) (*Catalog, error) {
-	_, resp, err := c.newBrokerRequester().forBroker(broker).sendRequest(ctx, "/v2/catalog", http.MethodGet, nil)
+	resp, err := c.newBrokerRequester().forBroker(broker).sendRequest(ctx, "/v2/catalog", http.MethodGet, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("get catalog request failed: %w", err)
 	}
 
 	catalog := &Catalog{}
-	err = json.Unmarshal(resp, catalog)
+	err = json.Unmarshal(resp.body, catalog)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal catalog: %w", err)
 	}
@@ -77,8 +179,246 @@ func (c *Client) GetCatalog(ctx context.Context, broker *korifiv1alpha1.CFServic
 	return catalog, nil
 }
 
-func payloadToReader(payload map[string]any) (io.Reader, error) {
-	if len(payload) == 0 {
+func (c *Client) Provision(ctx context.Context, broker *korifiv1alpha1.CFServiceBroker, payload InstanceProvisionPayload) (ServiceInstanceOperationResponse, error) {
+	resp, err := c.newBrokerRequester().forBroker(broker).sendRequest(
+		ctx,
+		"/v2/service_instances/"+payload.InstanceID,
+		http.MethodPut,
+		map[string]string{"accepts_incomplete": "true"},
+		payload.InstanceProvisionRequest,
+	)
+	if err != nil {
+		return ServiceInstanceOperationResponse{}, toClientError(resp, fmt.Errorf("provision request failed: %w", err))
+	}
+
+	return toServiceInstanceOperationResponse(resp, "provision")
+}
+
+func (c *Client) Deprovision(ctx context.Context, broker *korifiv1alpha1.CFServiceBroker, payload InstanceDeprovisionPayload) (ServiceInstanceOperationResponse, error) {
+	resp, err := c.newBrokerRequester().forBroker(broker).sendRequest(
+		ctx,
+		"/v2/service_instances/"+payload.InstanceID,
+		http.MethodDelete,
+		map[string]string{
+			"service_id":         payload.ServiceId,
+			"plan_id":            payload.PlanID,
+			"accepts_incomplete": "true",
+		},
+		nil,
+	)
+	if resp.statusCode == http.StatusGone {
+		return ServiceInstanceOperationResponse{}, nil
+	}
+	if err != nil {
+		return ServiceInstanceOperationResponse{}, toClientError(resp, fmt.Errorf("deprovision request failed: %w", err))
+	}
+
+	return toServiceInstanceOperationResponse(resp, "deprovision")
+}
+
+func (c *Client) GetServiceInstanceLastOperation(ctx context.Context, broker *korifiv1alpha1.CFServiceBroker, payload InstanceLastOperationPayload) (LastOperationResponse, error) {
+	queryParams := map[string]string{
+		"service_id": payload.ServiceId,
+		"plan_id":    payload.PlanID,
+	}
+	if payload.Operation != "" {
+		queryParams["operation"] = payload.Operation
+	}
+
+	resp, err := c.newBrokerRequester().forBroker(broker).sendRequest(
+		ctx,
+		"/v2/service_instances/"+payload.InstanceID+"/last_operation",
+		http.MethodGet,
+		queryParams,
+		nil,
+	)
+	if resp.statusCode == http.StatusGone {
+		return LastOperationResponse{}, ErrGone
+	}
+	if err != nil {
+		return LastOperationResponse{}, fmt.Errorf("last operation request failed: %w", err)
+	}
+
+	response := LastOperationResponse{}
+	err = json.Unmarshal(resp.body, &response)
+	if err != nil {
+		return LastOperationResponse{}, fmt.Errorf("failed to unmarshal last operation response: %w", err)
+	}
+	response.RetryAfter = parseRetryAfter(resp.header.Get("Retry-After"))
+
+	return response, nil
+}
+
+func (c *Client) Bind(ctx context.Context, broker *korifiv1alpha1.CFServiceBroker, payload BindPayload) (BindResponse, error) {
+	resp, err := c.newBrokerRequester().forBroker(broker).sendRequest(
+		ctx,
+		bindingPath(payload.InstanceID, payload.BindingID),
+		http.MethodPut,
+		map[string]string{"accepts_incomplete": "true"},
+		payload.BindRequest,
+	)
+	if err != nil {
+		return BindResponse{}, toClientError(resp, fmt.Errorf("bind request failed: %w", err))
+	}
+
+	response := BindResponse{}
+	err = unmarshalResponse(resp.body, &response)
+	if err != nil {
+		return BindResponse{}, fmt.Errorf("failed to unmarshal bind response: %w", err)
+	}
+	response.IsAsync = resp.statusCode == http.StatusAccepted
+
+	return response, nil
+}
+
+func (c *Client) Unbind(ctx context.Context, broker *korifiv1alpha1.CFServiceBroker, payload BindingPayload) (UnbindResponse, error) {
+	resp, err := c.newBrokerRequester().forBroker(broker).sendRequest(
+		ctx,
+		bindingPath(payload.InstanceID, payload.BindingID),
+		http.MethodDelete,
+		map[string]string{
+			"service_id":         payload.ServiceId,
+			"plan_id":            payload.PlanID,
+			"accepts_incomplete": "true",
+		},
+		nil,
+	)
+	if resp.statusCode == http.StatusGone {
+		return UnbindResponse{}, nil
+	}
+	if err != nil {
+		return UnbindResponse{}, toClientError(resp, fmt.Errorf("unbind request failed: %w", err))
+	}
+
+	response := UnbindResponse{}
+	err = unmarshalResponse(resp.body, &response)
+	if err != nil {
+		return UnbindResponse{}, fmt.Errorf("failed to unmarshal unbind response: %w", err)
+	}
+	response.IsAsync = resp.statusCode == http.StatusAccepted
+
+	return response, nil
+}
+
+func (c *Client) GetServiceBinding(ctx context.Context, broker *korifiv1alpha1.CFServiceBroker, payload BindingPayload) (BindingResponse, error) {
+	resp, err := c.newBrokerRequester().forBroker(broker).sendRequest(
+		ctx,
+		bindingPath(payload.InstanceID, payload.BindingID),
+		http.MethodGet,
+		map[string]string{
+			"service_id": payload.ServiceId,
+			"plan_id":    payload.PlanID,
+		},
+		nil,
+	)
+	if err != nil {
+		return BindingResponse{}, fmt.Errorf("get binding request failed: %w", err)
+	}
+
+	response := BindingResponse{}
+	err = json.Unmarshal(resp.body, &response)
+	if err != nil {
+		return BindingResponse{}, fmt.Errorf("failed to unmarshal binding response: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *Client) GetServiceBindingLastOperation(ctx context.Context, broker *korifiv1alpha1.CFServiceBroker, payload BindingLastOperationPayload) (LastOperationResponse, error) {
+	queryParams := map[string]string{
+		"service_id": payload.ServiceId,
+		"plan_id":    payload.PlanID,
+	}
+	if payload.Operation != "" {
+		queryParams["operation"] = payload.Operation
+	}
+
+	resp, err := c.newBrokerRequester().forBroker(broker).sendRequest(
+		ctx,
+		bindingPath(payload.InstanceID, payload.BindingID)+"/last_operation",
+		http.MethodGet,
+		queryParams,
+		nil,
+	)
+	if resp.statusCode == http.StatusGone {
+		return LastOperationResponse{}, ErrGone
+	}
+	if err != nil {
+		return LastOperationResponse{}, fmt.Errorf("binding last operation request failed: %w", err)
+	}
+
+	response := LastOperationResponse{}
+	err = json.Unmarshal(resp.body, &response)
+	if err != nil {
+		return LastOperationResponse{}, fmt.Errorf("failed to unmarshal binding last operation response: %w", err)
+	}
+	response.RetryAfter = parseRetryAfter(resp.header.Get("Retry-After"))
+
+	return response, nil
+}
+
+func bindingPath(instanceID, bindingID string) string {
+	return "/v2/service_instances/" + instanceID + "/service_bindings/" + bindingID
+}
+
+func toServiceInstanceOperationResponse(resp brokerResponse, operationName string) (ServiceInstanceOperationResponse, error) {
+	response := ServiceInstanceOperationResponse{}
+	err := unmarshalResponse(resp.body, &response)
+	if err != nil {
+		return ServiceInstanceOperationResponse{}, fmt.Errorf("failed to unmarshal %s response: %w", operationName, err)
+	}
+	response.IsAsync = resp.statusCode == http.StatusAccepted
+
+	return response, nil
+}
+
+func parseRetryAfter(retryAfter string) time.Duration {
+	if retryAfter == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if retryAt, err := http.ParseTime(retryAfter); err == nil {
+		return time.Until(retryAt)
+	}
+
+	return 0
+}
+
+func unmarshalResponse(resp []byte, response any) error {
+	if len(bytes.TrimSpace(resp)) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(resp, response)
+}
+
+func toClientError(resp brokerResponse, err error) error {
+	switch resp.statusCode {
+	case http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity:
+		return UnrecoverableError{Status: resp.statusCode, Message: brokerErrorMessage(resp.body)}
+	default:
+		return err
+	}
+}
+
+func brokerErrorMessage(respBody []byte) string {
+	brokerErr := struct {
+		Error       string `json:"error"`
+		Description string `json:"description"`
+	}{}
+	if err := json.Unmarshal(respBody, &brokerErr); err != nil || brokerErr.Description == "" {
+		return string(respBody)
+	}
+
+	return brokerErr.Description
+}
+
+func payloadToReader(payload any) (io.Reader, error) {
+	if payload == nil {
 		return nil, nil
 	}
 
@@ -105,26 +445,35 @@ func (r *brokerRequester) forBroker(broker *korifiv1alpha1.CFServiceBroker) *bro
 	return r
 }
 
-func (r *brokerRequester) sendRequest(ctx context.Context, requestPath string, method string, payload map[string]any) (int, []byte, error) {
-	requestUrl, err := url.JoinPath(r.broker.Spec.URL, requestPath)
+type brokerResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func (r *brokerRequester) sendRequest(ctx context.Context, requestPath string, method string, queryParams map[string]string, payload any) (brokerResponse, error) {
+	requestUrl, err := r.buildRequestURL(requestPath, queryParams)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to build broker requestUrl for path %q: %w", requestPath, err)
+		return brokerResponse{}, fmt.Errorf("failed to build broker requestUrl for path %q: %w", requestPath, err)
 	}
 
 	payloadReader, err := payloadToReader(payload)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed create payload reader: %w", err)
+		return brokerResponse{}, fmt.Errorf("failed create payload reader: %w", err)
 	}
 
-	req, err := http.NewRequest(method, requestUrl, payloadReader)
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, payloadReader)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to create new HTTP request: %w", err)
+		return brokerResponse{}, fmt.Errorf("failed to create new HTTP request: %w", err)
 	}
 	req.Header.Add("X-Broker-API-Version", osbapiVersion)
+	if payloadReader != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
 
 	authHeader, err := r.buildAuthorizationHeaderValue(ctx)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to build Authorization request header value: %w", err)
+		return brokerResponse{}, fmt.Errorf("failed to build Authorization request header value: %w", err)
 	}
 	req.Header.Add("Authorization", authHeader)
 
@@ -133,20 +482,50 @@ func (r *brokerRequester) sendRequest(ctx context.Context, requestPath string, m
 	}}
 	resp, err := client.Do(req)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to execute HTTP request: %w", err)
+		return brokerResponse{}, fmt.Errorf("failed to execute HTTP request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to read body: %w", err)
+		return brokerResponse{}, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	brokerResp := brokerResponse{
+		statusCode: resp.StatusCode,
+		header:     resp.Header,
+		body:       respBody,
 	}
 
 	if resp.StatusCode > 299 {
-		return resp.StatusCode, respBody, fmt.Errorf("request returned non-OK status %d: %s", resp.StatusCode, string(respBody))
+		return brokerResp, fmt.Errorf("request returned non-OK status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return brokerResp, nil
+}
+
+func (r *brokerRequester) buildRequestURL(requestPath string, queryParams map[string]string) (string, error) {
+	requestUrl, err := url.JoinPath(r.broker.Spec.URL, requestPath)
+	if err != nil {
+		return "", err
+	}
+
+	if len(queryParams) == 0 {
+		return requestUrl, nil
+	}
+
+	parsedUrl, err := url.Parse(requestUrl)
+	if err != nil {
+		return "", err
+	}
+
+	query := parsedUrl.Query()
+	for k, v := range queryParams {
+		query.Set(k, v)
 	}
+	parsedUrl.RawQuery = query.Encode()
 
-	return resp.StatusCode, respBody, nil
+	return parsedUrl.String(), nil
 }
 
 func (r *brokerRequester) buildAuthorizationHeaderValue(ctx context.Context) (string, error) {
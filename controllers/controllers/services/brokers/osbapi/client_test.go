@@ -2,8 +2,10 @@ package osbapi_test
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers/osbapi"
@@ -194,4 +196,537 @@ var _ = Describe("OSBAPI Client", func() {
 			})
 		})
 	})
+
+	Describe("Provision", func() {
+		var (
+			provisionResp osbapi.ServiceInstanceOperationResponse
+			provisionErr  error
+			requestBody   map[string]any
+		)
+
+		BeforeEach(func() {
+			requestBody = nil
+			brokerServer.WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(json.NewDecoder(r.Body).Decode(&requestBody)).To(Succeed())
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"dashboard_url": "https://my.dashboard"}`))
+			}))
+		})
+
+		JustBeforeEach(func() {
+			provisionResp, provisionErr = brokerClient.Provision(ctx, serviceBroker, osbapi.InstanceProvisionPayload{
+				InstanceID: "my-service-instance",
+				InstanceProvisionRequest: osbapi.InstanceProvisionRequest{
+					ServiceId: "service-guid",
+					PlanID:    "plan-guid",
+					SpaceGUID: "space-guid",
+					OrgGUID:   "org-guid",
+					Parameters: map[string]any{
+						"foo": "bar",
+					},
+				},
+			})
+		})
+
+		It("provisions the service instance", func() {
+			Expect(provisionErr).NotTo(HaveOccurred())
+			Expect(provisionResp).To(Equal(osbapi.ServiceInstanceOperationResponse{
+				DashboardURL: "https://my.dashboard",
+			}))
+		})
+
+		It("sends the provision request to the broker", func() {
+			Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Method": Equal(http.MethodPut),
+				"URL": PointTo(MatchFields(IgnoreExtras, Fields{
+					"Path":     Equal("/v2/service_instances/my-service-instance"),
+					"RawQuery": Equal("accepts_incomplete=true"),
+				})),
+			}))))
+
+			Expect(requestBody).To(Equal(map[string]any{
+				"service_id":        "service-guid",
+				"plan_id":           "plan-guid",
+				"space_guid":        "space-guid",
+				"organization_guid": "org-guid",
+				"parameters": map[string]any{
+					"foo": "bar",
+				},
+			}))
+		})
+
+		When("the broker provisions the instance asynchronously", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusAccepted)
+					_, _ = w.Write([]byte(`{"operation": "provision-op"}`))
+				}))
+			})
+
+			It("returns an async operation response", func() {
+				Expect(provisionErr).NotTo(HaveOccurred())
+				Expect(provisionResp).To(Equal(osbapi.ServiceInstanceOperationResponse{
+					Operation: "provision-op",
+					IsAsync:   true,
+				}))
+			})
+		})
+
+		When("the broker rejects the request", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"description": "invalid parameters"}`))
+				}))
+			})
+
+			It("returns an unrecoverable error", func() {
+				Expect(provisionErr).To(MatchError(osbapi.UnrecoverableError{
+					Status:  http.StatusBadRequest,
+					Message: "invalid parameters",
+				}))
+			})
+		})
+
+		When("the provision request fails", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}))
+			})
+
+			It("returns an error", func() {
+				Expect(provisionErr).To(MatchError(ContainSubstring(strconv.Itoa(http.StatusTeapot))))
+			})
+		})
+	})
+
+	Describe("Deprovision", func() {
+		var (
+			deprovisionResp osbapi.ServiceInstanceOperationResponse
+			deprovisionErr  error
+		)
+
+		BeforeEach(func() {
+			brokerServer.WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{}`))
+			}))
+		})
+
+		JustBeforeEach(func() {
+			deprovisionResp, deprovisionErr = brokerClient.Deprovision(ctx, serviceBroker, osbapi.InstanceDeprovisionPayload{
+				InstanceID: "my-service-instance",
+				ServiceId:  "service-guid",
+				PlanID:     "plan-guid",
+			})
+		})
+
+		It("deprovisions the service instance", func() {
+			Expect(deprovisionErr).NotTo(HaveOccurred())
+			Expect(deprovisionResp.IsAsync).To(BeFalse())
+			Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Method": Equal(http.MethodDelete),
+				"URL": PointTo(MatchFields(IgnoreExtras, Fields{
+					"Path":     Equal("/v2/service_instances/my-service-instance"),
+					"RawQuery": Equal("accepts_incomplete=true&plan_id=plan-guid&service_id=service-guid"),
+				})),
+			}))))
+		})
+
+		When("the broker deprovisions the instance asynchronously", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusAccepted)
+					_, _ = w.Write([]byte(`{"operation": "deprovision-op"}`))
+				}))
+			})
+
+			It("returns an async operation response", func() {
+				Expect(deprovisionErr).NotTo(HaveOccurred())
+				Expect(deprovisionResp).To(Equal(osbapi.ServiceInstanceOperationResponse{
+					Operation: "deprovision-op",
+					IsAsync:   true,
+				}))
+			})
+		})
+
+		When("the service instance no longer exists in the broker", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusGone)
+					_, _ = w.Write([]byte(`{}`))
+				}))
+			})
+
+			It("succeeds", func() {
+				Expect(deprovisionErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the deprovision request fails", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}))
+			})
+
+			It("returns an error", func() {
+				Expect(deprovisionErr).To(MatchError(ContainSubstring(strconv.Itoa(http.StatusTeapot))))
+			})
+		})
+	})
+
+	Describe("GetServiceInstanceLastOperation", func() {
+		var (
+			lastOperation    osbapi.LastOperationResponse
+			lastOperationErr error
+		)
+
+		BeforeEach(func() {
+			brokerServer.WithHandler("/v2/service_instances/{id}/last_operation", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Retry-After", "7")
+				_, _ = w.Write([]byte(`{"state": "in progress", "description": "provisioning"}`))
+			}))
+		})
+
+		JustBeforeEach(func() {
+			lastOperation, lastOperationErr = brokerClient.GetServiceInstanceLastOperation(ctx, serviceBroker, osbapi.InstanceLastOperationPayload{
+				InstanceID: "my-service-instance",
+				ServiceId:  "service-guid",
+				PlanID:     "plan-guid",
+				Operation:  "op-guid",
+			})
+		})
+
+		It("gets the last operation", func() {
+			Expect(lastOperationErr).NotTo(HaveOccurred())
+			Expect(lastOperation).To(Equal(osbapi.LastOperationResponse{
+				State:       "in progress",
+				Description: "provisioning",
+				RetryAfter:  7 * time.Second,
+			}))
+		})
+
+		It("sends the last operation request to the broker", func() {
+			Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Method": Equal(http.MethodGet),
+				"URL": PointTo(MatchFields(IgnoreExtras, Fields{
+					"Path":     Equal("/v2/service_instances/my-service-instance/last_operation"),
+					"RawQuery": Equal("operation=op-guid&plan_id=plan-guid&service_id=service-guid"),
+				})),
+			}))))
+		})
+
+		When("the service instance is gone", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/{id}/last_operation", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusGone)
+					_, _ = w.Write([]byte(`{}`))
+				}))
+			})
+
+			It("returns a gone error", func() {
+				Expect(lastOperationErr).To(MatchError(osbapi.ErrGone))
+			})
+		})
+
+		When("the last operation request fails", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/{id}/last_operation", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}))
+			})
+
+			It("returns an error", func() {
+				Expect(lastOperationErr).To(MatchError(ContainSubstring(strconv.Itoa(http.StatusTeapot))))
+			})
+		})
+	})
+
+	Describe("Bind", func() {
+		var (
+			bindResp    osbapi.BindResponse
+			bindErr     error
+			requestBody map[string]any
+		)
+
+		BeforeEach(func() {
+			requestBody = nil
+			brokerServer.WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(json.NewDecoder(r.Body).Decode(&requestBody)).To(Succeed())
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"credentials": {"username": "bob", "port": 5432}}`))
+			}))
+		})
+
+		JustBeforeEach(func() {
+			bindResp, bindErr = brokerClient.Bind(ctx, serviceBroker, osbapi.BindPayload{
+				InstanceID: "my-service-instance",
+				BindingID:  "my-binding",
+				BindRequest: osbapi.BindRequest{
+					ServiceId: "service-guid",
+					PlanID:    "plan-guid",
+					AppGUID:   "app-guid",
+					BindResource: osbapi.BindResource{
+						AppGUID:   "app-guid",
+						SpaceGUID: "space-guid",
+					},
+				},
+			})
+		})
+
+		It("binds the service instance", func() {
+			Expect(bindErr).NotTo(HaveOccurred())
+			Expect(bindResp).To(Equal(osbapi.BindResponse{
+				Credentials: map[string]any{
+					"username": "bob",
+					"port":     float64(5432),
+				},
+			}))
+		})
+
+		It("sends the bind request to the broker", func() {
+			Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Method": Equal(http.MethodPut),
+				"URL": PointTo(MatchFields(IgnoreExtras, Fields{
+					"Path":     Equal("/v2/service_instances/my-service-instance/service_bindings/my-binding"),
+					"RawQuery": Equal("accepts_incomplete=true"),
+				})),
+			}))))
+
+			Expect(requestBody).To(Equal(map[string]any{
+				"service_id": "service-guid",
+				"plan_id":    "plan-guid",
+				"app_guid":   "app-guid",
+				"bind_resource": map[string]any{
+					"app_guid":   "app-guid",
+					"space_guid": "space-guid",
+				},
+			}))
+		})
+
+		When("the broker binds asynchronously", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusAccepted)
+					_, _ = w.Write([]byte(`{"operation": "bind-op"}`))
+				}))
+			})
+
+			It("returns an async operation response", func() {
+				Expect(bindErr).NotTo(HaveOccurred())
+				Expect(bindResp).To(Equal(osbapi.BindResponse{
+					Operation: "bind-op",
+					IsAsync:   true,
+				}))
+			})
+		})
+
+		When("the broker rejects the request", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusConflict)
+					_, _ = w.Write([]byte(`{"description": "binding already exists"}`))
+				}))
+			})
+
+			It("returns an unrecoverable error", func() {
+				Expect(bindErr).To(MatchError(osbapi.UnrecoverableError{
+					Status:  http.StatusConflict,
+					Message: "binding already exists",
+				}))
+			})
+		})
+
+		When("the bind request fails", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}))
+			})
+
+			It("returns an error", func() {
+				Expect(bindErr).To(MatchError(ContainSubstring(strconv.Itoa(http.StatusTeapot))))
+			})
+		})
+	})
+
+	Describe("Unbind", func() {
+		var (
+			unbindResp osbapi.UnbindResponse
+			unbindErr  error
+		)
+
+		BeforeEach(func() {
+			brokerServer.WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{}`))
+			}))
+		})
+
+		JustBeforeEach(func() {
+			unbindResp, unbindErr = brokerClient.Unbind(ctx, serviceBroker, osbapi.BindingPayload{
+				InstanceID: "my-service-instance",
+				BindingID:  "my-binding",
+				ServiceId:  "service-guid",
+				PlanID:     "plan-guid",
+			})
+		})
+
+		It("unbinds the service instance", func() {
+			Expect(unbindErr).NotTo(HaveOccurred())
+			Expect(unbindResp.IsAsync).To(BeFalse())
+			Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Method": Equal(http.MethodDelete),
+				"URL": PointTo(MatchFields(IgnoreExtras, Fields{
+					"Path":     Equal("/v2/service_instances/my-service-instance/service_bindings/my-binding"),
+					"RawQuery": Equal("accepts_incomplete=true&plan_id=plan-guid&service_id=service-guid"),
+				})),
+			}))))
+		})
+
+		When("the broker unbinds asynchronously", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusAccepted)
+					_, _ = w.Write([]byte(`{"operation": "unbind-op"}`))
+				}))
+			})
+
+			It("returns an async operation response", func() {
+				Expect(unbindErr).NotTo(HaveOccurred())
+				Expect(unbindResp).To(Equal(osbapi.UnbindResponse{
+					Operation: "unbind-op",
+					IsAsync:   true,
+				}))
+			})
+		})
+
+		When("the binding no longer exists in the broker", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusGone)
+					_, _ = w.Write([]byte(`{}`))
+				}))
+			})
+
+			It("succeeds", func() {
+				Expect(unbindErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the unbind request fails", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}))
+			})
+
+			It("returns an error", func() {
+				Expect(unbindErr).To(MatchError(ContainSubstring(strconv.Itoa(http.StatusTeapot))))
+			})
+		})
+	})
+
+	Describe("GetServiceBinding", func() {
+		var (
+			binding    osbapi.BindingResponse
+			bindingErr error
+		)
+
+		BeforeEach(func() {
+			brokerServer.WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"credentials": {"username": "bob"}}`))
+			}))
+		})
+
+		JustBeforeEach(func() {
+			binding, bindingErr = brokerClient.GetServiceBinding(ctx, serviceBroker, osbapi.BindingPayload{
+				InstanceID: "my-service-instance",
+				BindingID:  "my-binding",
+				ServiceId:  "service-guid",
+				PlanID:     "plan-guid",
+			})
+		})
+
+		It("gets the binding", func() {
+			Expect(bindingErr).NotTo(HaveOccurred())
+			Expect(binding).To(Equal(osbapi.BindingResponse{
+				Credentials: map[string]any{"username": "bob"},
+			}))
+			Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Method": Equal(http.MethodGet),
+				"URL": PointTo(MatchFields(IgnoreExtras, Fields{
+					"Path":     Equal("/v2/service_instances/my-service-instance/service_bindings/my-binding"),
+					"RawQuery": Equal("plan_id=plan-guid&service_id=service-guid"),
+				})),
+			}))))
+		})
+
+		When("the get binding request fails", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}))
+			})
+
+			It("returns an error", func() {
+				Expect(bindingErr).To(MatchError(ContainSubstring(strconv.Itoa(http.StatusTeapot))))
+			})
+		})
+	})
+
+	Describe("GetServiceBindingLastOperation", func() {
+		var (
+			lastOperation    osbapi.LastOperationResponse
+			lastOperationErr error
+		)
+
+		BeforeEach(func() {
+			brokerServer.WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}/last_operation", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Retry-After", "3")
+				_, _ = w.Write([]byte(`{"state": "in progress", "description": "binding"}`))
+			}))
+		})
+
+		JustBeforeEach(func() {
+			lastOperation, lastOperationErr = brokerClient.GetServiceBindingLastOperation(ctx, serviceBroker, osbapi.BindingLastOperationPayload{
+				BindingPayload: osbapi.BindingPayload{
+					InstanceID: "my-service-instance",
+					BindingID:  "my-binding",
+					ServiceId:  "service-guid",
+					PlanID:     "plan-guid",
+				},
+				Operation: "op-guid",
+			})
+		})
+
+		It("gets the last operation", func() {
+			Expect(lastOperationErr).NotTo(HaveOccurred())
+			Expect(lastOperation).To(Equal(osbapi.LastOperationResponse{
+				State:       "in progress",
+				Description: "binding",
+				RetryAfter:  3 * time.Second,
+			}))
+			Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Method": Equal(http.MethodGet),
+				"URL": PointTo(MatchFields(IgnoreExtras, Fields{
+					"Path":     Equal("/v2/service_instances/my-service-instance/service_bindings/my-binding/last_operation"),
+					"RawQuery": Equal("operation=op-guid&plan_id=plan-guid&service_id=service-guid"),
+				})),
+			}))))
+		})
+
+		When("the binding is gone", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().WithHandler("/v2/service_instances/{id}/service_bindings/{binding_id}/last_operation", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusGone)
+					_, _ = w.Write([]byte(`{}`))
+				}))
+			})
+
+			It("returns a gone error", func() {
+				Expect(lastOperationErr).To(MatchError(osbapi.ErrGone))
+			})
+		})
+	})
 })
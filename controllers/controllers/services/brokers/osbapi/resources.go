@@ -0,0 +1,94 @@
+package osbapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/services"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultLastOperationPollingInterval is used to poll the state of
+// asynchronous broker operations when the broker does not specify a
+// Retry-After interval
+const DefaultLastOperationPollingInterval = 5 * time.Second
+
+// BrokerResources are the catalog resources a managed service instance has
+// been created from
+type BrokerResources struct {
+	Plan     *korifiv1alpha1.CFServicePlan
+	Offering *korifiv1alpha1.CFServiceOffering
+	Broker   *korifiv1alpha1.CFServiceBroker
+}
+
+func GetBrokerResources(ctx context.Context, k8sClient client.Client, rootNamespace string, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (BrokerResources, error) {
+	plan := &korifiv1alpha1.CFServicePlan{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: cfServiceInstance.Spec.PlanGUID}, plan)
+	if err != nil {
+		return BrokerResources{}, fmt.Errorf("failed to get service plan %q: %w", cfServiceInstance.Spec.PlanGUID, err)
+	}
+
+	offering := &korifiv1alpha1.CFServiceOffering{}
+	err = k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: plan.Labels[korifiv1alpha1.RelServiceOfferingLabel]}, offering)
+	if err != nil {
+		return BrokerResources{}, fmt.Errorf("failed to get service offering for plan %q: %w", plan.Name, err)
+	}
+
+	broker := &korifiv1alpha1.CFServiceBroker{}
+	err = k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: plan.Labels[korifiv1alpha1.RelServiceBrokerLabel]}, broker)
+	if err != nil {
+		return BrokerResources{}, fmt.Errorf("failed to get service broker for plan %q: %w", plan.Name, err)
+	}
+
+	return BrokerResources{
+		Plan:     plan,
+		Offering: offering,
+		Broker:   broker,
+	}, nil
+}
+
+func (r BrokerResources) InstanceLastOperationPayload(cfServiceInstance *korifiv1alpha1.CFServiceInstance) InstanceLastOperationPayload {
+	return InstanceLastOperationPayload{
+		InstanceID: cfServiceInstance.Name,
+		ServiceId:  r.Offering.Spec.BrokerCatalog.Id,
+		PlanID:     r.Plan.Spec.BrokerCatalog.ID,
+		Operation:  cfServiceInstance.Status.BrokerOperation,
+	}
+}
+
+func (r BrokerResources) BindingPayload(cfServiceBinding *korifiv1alpha1.CFServiceBinding) BindingPayload {
+	return BindingPayload{
+		InstanceID: cfServiceBinding.Spec.Service.Name,
+		BindingID:  cfServiceBinding.Name,
+		ServiceId:  r.Offering.Spec.BrokerCatalog.Id,
+		PlanID:     r.Plan.Spec.BrokerCatalog.ID,
+	}
+}
+
+func (r BrokerResources) BindingLastOperationPayload(cfServiceBinding *korifiv1alpha1.CFServiceBinding) BindingLastOperationPayload {
+	return BindingLastOperationPayload{
+		BindingPayload: r.BindingPayload(cfServiceBinding),
+		Operation:      cfServiceBinding.Status.BrokerOperation,
+	}
+}
+
+// IsOperationInProgress tells whether an asynchronous broker operation of the
+// given type has been started and has not completed yet
+func IsOperationInProgress(lastOperation *services.LastOperation, operationType string) bool {
+	return lastOperation != nil &&
+		lastOperation.Type == operationType &&
+		lastOperation.State == services.LastOperationStateInProgress
+}
+
+// PollingInterval returns how long to wait before polling the state of an
+// asynchronous broker operation again
+func PollingInterval(lastOperation LastOperationResponse) time.Duration {
+	if lastOperation.RetryAfter > 0 {
+		return lastOperation.RetryAfter
+	}
+
+	return DefaultLastOperationPollingInterval
+}
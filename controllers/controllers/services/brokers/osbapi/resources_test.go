@@ -0,0 +1,139 @@
+package osbapi_test
+
+import (
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers/osbapi"
+	"code.cloudfoundry.org/korifi/model/services"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Broker resources", func() {
+	Describe("GetBrokerResources", func() {
+		var (
+			rootNamespace string
+			broker        *korifiv1alpha1.CFServiceBroker
+			offering      *korifiv1alpha1.CFServiceOffering
+			plan          *korifiv1alpha1.CFServicePlan
+			instance      *korifiv1alpha1.CFServiceInstance
+			resources     osbapi.BrokerResources
+			getErr        error
+		)
+
+		BeforeEach(func() {
+			rootNamespace = uuid.NewString()
+			Expect(adminClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: rootNamespace,
+				},
+			})).To(Succeed())
+
+			broker = &korifiv1alpha1.CFServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceBrokerSpec{
+					ServiceBroker: services.ServiceBroker{
+						Name: uuid.NewString(),
+					},
+				},
+			}
+			helpers.EnsureCreate(adminClient, broker)
+
+			offering = &korifiv1alpha1.CFServiceOffering{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceOfferingSpec{
+					ServiceOffering: services.ServiceOffering{
+						Name: "my-offering",
+						BrokerCatalog: services.ServiceBrokerCatalog{
+							Id: "broker-offering-id",
+						},
+					},
+				},
+			}
+			helpers.EnsureCreate(adminClient, offering)
+
+			plan = &korifiv1alpha1.CFServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+					Labels: map[string]string{
+						korifiv1alpha1.RelServiceBrokerLabel:   broker.Name,
+						korifiv1alpha1.RelServiceOfferingLabel: offering.Name,
+					},
+				},
+				Spec: korifiv1alpha1.CFServicePlanSpec{
+					ServicePlan: services.ServicePlan{
+						BrokerServicePlan: services.BrokerServicePlan{
+							Name: "my-plan",
+							BrokerCatalog: services.ServicePlanBrokerCatalog{
+								ID: "broker-plan-id",
+							},
+						},
+					},
+				},
+			}
+			helpers.EnsureCreate(adminClient, plan)
+
+			instance = &korifiv1alpha1.CFServiceInstance{
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					PlanGUID: plan.Name,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			resources, getErr = osbapi.GetBrokerResources(ctx, adminClient, rootNamespace, instance)
+		})
+
+		It("returns the plan along with its offering and broker", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(resources.Plan.Name).To(Equal(plan.Name))
+			Expect(resources.Offering.Name).To(Equal(offering.Name))
+			Expect(resources.Broker.Name).To(Equal(broker.Name))
+		})
+
+		When("the plan does not exist", func() {
+			BeforeEach(func() {
+				instance.Spec.PlanGUID = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(k8serrors.IsNotFound(getErr)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("IsOperationInProgress", func() {
+		DescribeTable("operations",
+			func(lastOperation *services.LastOperation, expected bool) {
+				Expect(osbapi.IsOperationInProgress(lastOperation, services.LastOperationTypeCreate)).To(Equal(expected))
+			},
+			Entry("no operation", nil, false),
+			Entry("in progress", &services.LastOperation{Type: services.LastOperationTypeCreate, State: services.LastOperationStateInProgress}, true),
+			Entry("succeeded", &services.LastOperation{Type: services.LastOperationTypeCreate, State: services.LastOperationStateSucceeded}, false),
+			Entry("another type in progress", &services.LastOperation{Type: services.LastOperationTypeDelete, State: services.LastOperationStateInProgress}, false),
+		)
+	})
+
+	Describe("PollingInterval", func() {
+		It("uses the interval requested by the broker", func() {
+			Expect(osbapi.PollingInterval(osbapi.LastOperationResponse{RetryAfter: time.Minute})).To(Equal(time.Minute))
+		})
+
+		It("falls back to the default interval", func() {
+			Expect(osbapi.PollingInterval(osbapi.LastOperationResponse{})).To(Equal(osbapi.DefaultLastOperationPollingInterval))
+		})
+	})
+})
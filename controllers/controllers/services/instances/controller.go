@@ -24,6 +24,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -43,18 +44,34 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+type BrokerClient interface {
+	Provision(context.Context, *korifiv1alpha1.CFServiceBroker, osbapi.InstanceProvisionPayload) (osbapi.ServiceInstanceOperationResponse, error)
+	Deprovision(context.Context, *korifiv1alpha1.CFServiceBroker, osbapi.InstanceDeprovisionPayload) (osbapi.ServiceInstanceOperationResponse, error)
+	GetServiceInstanceLastOperation(context.Context, *korifiv1alpha1.CFServiceBroker, osbapi.InstanceLastOperationPayload) (osbapi.LastOperationResponse, error)
+}
+
 type Reconciler struct {
-	k8sClient client.Client
-	scheme    *runtime.Scheme
-	log       logr.Logger
+	k8sClient     client.Client
+	brokerClient  BrokerClient
+	scheme        *runtime.Scheme
+	rootNamespace string
+	log           logr.Logger
 }
 
 func NewReconciler(
 	client client.Client,
+	brokerClient BrokerClient,
 	scheme *runtime.Scheme,
+	rootNamespace string,
 	log logr.Logger,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance, *korifiv1alpha1.CFServiceInstance] {
-	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log}
+	serviceInstanceReconciler := Reconciler{
+		k8sClient:     client,
+		brokerClient:  brokerClient,
+		scheme:        scheme,
+		rootNamespace: rootNamespace,
+		log:           log,
+	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFServiceInstance, *korifiv1alpha1.CFServiceInstance](log, client, &serviceInstanceReconciler)
 }
 
@@ -93,6 +110,10 @@ func (r *Reconciler) secretToServiceInstance(ctx context.Context, o client.Objec
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/finalizers,verbs=update
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceofferings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -106,6 +127,12 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceInstance *k
 		meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, readyConditionBuilder.WithError(err).Build())
 	}()
 
+	if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		var result ctrl.Result
+		result, err = r.reconcileManagedInstance(ctx, cfServiceInstance, readyConditionBuilder)
+		return result, err
+	}
+
 	credentialsSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfServiceInstance.Namespace,
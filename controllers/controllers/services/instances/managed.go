@@ -0,0 +1,354 @@
+package instances
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers/osbapi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/model/services"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func (r *Reconciler) reconcileManagedInstance(
+	ctx context.Context,
+	cfServiceInstance *korifiv1alpha1.CFServiceInstance,
+	readyConditionBuilder *k8s.ReadyConditionBuilder,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileManagedInstance")
+
+	if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+		return r.finalizeManagedInstance(ctx, cfServiceInstance, readyConditionBuilder)
+	}
+
+	provisionedCondition := meta.FindStatusCondition(cfServiceInstance.Status.Conditions, korifiv1alpha1.ProvisionedCondition)
+	if provisionedCondition != nil && provisionedCondition.Status == metav1.ConditionTrue {
+		readyConditionBuilder.Ready()
+		return ctrl.Result{}, nil
+	}
+
+	if provisionedCondition != nil && provisionedCondition.Status == metav1.ConditionFalse {
+		readyConditionBuilder.WithReason(provisionedCondition.Reason).WithMessage(provisionedCondition.Message)
+		return ctrl.Result{}, nil
+	}
+
+	resources, err := osbapi.GetBrokerResources(ctx, r.k8sClient, r.rootNamespace, cfServiceInstance)
+	if err != nil {
+		log.Info("failed to resolve broker resources", "reason", err)
+		readyConditionBuilder.WithReason("BrokerResourcesNotAvailable")
+		return ctrl.Result{}, err
+	}
+
+	if osbapi.IsOperationInProgress(cfServiceInstance.Status.LastOperation, services.LastOperationTypeCreate) {
+		return r.pollProvisionOperation(ctx, cfServiceInstance, resources, readyConditionBuilder)
+	}
+
+	orgGUID, err := r.getOrgGUID(ctx, cfServiceInstance.Namespace)
+	if err != nil {
+		log.Info("failed to get the org of the service instance space", "reason", err)
+		readyConditionBuilder.WithReason("SpaceNotAvailable")
+		return ctrl.Result{}, err
+	}
+
+	parameters, err := getParameters(cfServiceInstance)
+	if err != nil {
+		log.Info("invalid service instance parameters", "reason", err)
+		readyConditionBuilder.WithReason("InvalidParameters")
+		return ctrl.Result{}, err
+	}
+
+	provisionResponse, err := r.brokerClient.Provision(ctx, resources.Broker, osbapi.InstanceProvisionPayload{
+		InstanceID: cfServiceInstance.Name,
+		InstanceProvisionRequest: osbapi.InstanceProvisionRequest{
+			ServiceId:  resources.Offering.Spec.BrokerCatalog.Id,
+			PlanID:     resources.Plan.Spec.BrokerCatalog.ID,
+			SpaceGUID:  cfServiceInstance.Namespace,
+			OrgGUID:    orgGUID,
+			Parameters: parameters,
+		},
+	})
+	if err != nil {
+		log.Info("failed to provision service instance", "reason", err)
+
+		var unrecoverableErr osbapi.UnrecoverableError
+		if errors.As(err, &unrecoverableErr) {
+			setProvisioningFailed(cfServiceInstance, readyConditionBuilder, unrecoverableErr.Error())
+			return ctrl.Result{}, nil
+		}
+
+		readyConditionBuilder.WithReason("ProvisionRequestFailed")
+		return ctrl.Result{}, err
+	}
+
+	if provisionResponse.IsAsync {
+		cfServiceInstance.Status.LastOperation = &services.LastOperation{
+			Type:  services.LastOperationTypeCreate,
+			State: services.LastOperationStateInProgress,
+		}
+		cfServiceInstance.Status.BrokerOperation = provisionResponse.Operation
+		readyConditionBuilder.WithReason("ProvisioningInProgress")
+		return ctrl.Result{RequeueAfter: osbapi.DefaultLastOperationPollingInterval}, nil
+	}
+
+	setProvisioned(cfServiceInstance, readyConditionBuilder)
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) pollProvisionOperation(
+	ctx context.Context,
+	cfServiceInstance *korifiv1alpha1.CFServiceInstance,
+	resources osbapi.BrokerResources,
+	readyConditionBuilder *k8s.ReadyConditionBuilder,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("pollProvisionOperation")
+
+	lastOperation, err := r.brokerClient.GetServiceInstanceLastOperation(ctx, resources.Broker, resources.InstanceLastOperationPayload(cfServiceInstance))
+	if errors.Is(err, osbapi.ErrGone) {
+		setProvisioningFailed(cfServiceInstance, readyConditionBuilder, "the service instance does not exist in the broker")
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Info("failed to get last operation", "reason", err)
+		readyConditionBuilder.WithReason("GetLastOperationFailed")
+		return ctrl.Result{}, err
+	}
+
+	switch lastOperation.State {
+	case services.LastOperationStateSucceeded:
+		setProvisioned(cfServiceInstance, readyConditionBuilder)
+		return ctrl.Result{}, nil
+	case services.LastOperationStateFailed:
+		setProvisioningFailed(cfServiceInstance, readyConditionBuilder, lastOperation.Description)
+		return ctrl.Result{}, nil
+	default:
+		cfServiceInstance.Status.LastOperation.Description = lastOperation.Description
+		readyConditionBuilder.WithReason("ProvisioningInProgress")
+		return ctrl.Result{RequeueAfter: osbapi.PollingInterval(lastOperation)}, nil
+	}
+}
+
+func (r *Reconciler) finalizeManagedInstance(
+	ctx context.Context,
+	cfServiceInstance *korifiv1alpha1.CFServiceInstance,
+	readyConditionBuilder *k8s.ReadyConditionBuilder,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalizeManagedInstance")
+
+	if !controllerutil.ContainsFinalizer(cfServiceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsStatusConditionFalse(cfServiceInstance.Status.Conditions, korifiv1alpha1.ProvisionedCondition) {
+		removeFinalizer(log, cfServiceInstance)
+		return ctrl.Result{}, nil
+	}
+
+	if isDeprovisioningFailed(cfServiceInstance) {
+		if !isDeletionRetried(cfServiceInstance) {
+			readyConditionBuilder.WithReason("DeprovisioningFailed").WithMessage(cfServiceInstance.Status.LastOperation.Description)
+			return ctrl.Result{}, nil
+		}
+
+		log.Info("retrying deprovisioning")
+		retryDeprovisioning(cfServiceInstance)
+	}
+
+	resources, err := osbapi.GetBrokerResources(ctx, r.k8sClient, r.rootNamespace, cfServiceInstance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("broker resources no longer exist, skipping deprovisioning", "reason", err)
+			removeFinalizer(log, cfServiceInstance)
+			return ctrl.Result{}, nil
+		}
+
+		readyConditionBuilder.WithReason("BrokerResourcesNotAvailable")
+		return ctrl.Result{}, err
+	}
+
+	if osbapi.IsOperationInProgress(cfServiceInstance.Status.LastOperation, services.LastOperationTypeDelete) {
+		return r.pollDeprovisionOperation(ctx, cfServiceInstance, resources, readyConditionBuilder)
+	}
+
+	deprovisionResponse, err := r.brokerClient.Deprovision(ctx, resources.Broker, osbapi.InstanceDeprovisionPayload{
+		InstanceID: cfServiceInstance.Name,
+		ServiceId:  resources.Offering.Spec.BrokerCatalog.Id,
+		PlanID:     resources.Plan.Spec.BrokerCatalog.ID,
+	})
+	if err != nil {
+		log.Info("failed to deprovision service instance", "reason", err)
+
+		var unrecoverableErr osbapi.UnrecoverableError
+		if errors.As(err, &unrecoverableErr) {
+			setDeprovisioningFailed(cfServiceInstance, readyConditionBuilder, unrecoverableErr.Error())
+			return ctrl.Result{}, nil
+		}
+
+		readyConditionBuilder.WithReason("DeprovisionRequestFailed")
+		return ctrl.Result{}, err
+	}
+
+	if deprovisionResponse.IsAsync {
+		cfServiceInstance.Status.LastOperation = &services.LastOperation{
+			Type:  services.LastOperationTypeDelete,
+			State: services.LastOperationStateInProgress,
+		}
+		cfServiceInstance.Status.BrokerOperation = deprovisionResponse.Operation
+		readyConditionBuilder.WithReason("DeprovisioningInProgress")
+		return ctrl.Result{RequeueAfter: osbapi.DefaultLastOperationPollingInterval}, nil
+	}
+
+	removeFinalizer(log, cfServiceInstance)
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) pollDeprovisionOperation(
+	ctx context.Context,
+	cfServiceInstance *korifiv1alpha1.CFServiceInstance,
+	resources osbapi.BrokerResources,
+	readyConditionBuilder *k8s.ReadyConditionBuilder,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("pollDeprovisionOperation")
+
+	lastOperation, err := r.brokerClient.GetServiceInstanceLastOperation(ctx, resources.Broker, resources.InstanceLastOperationPayload(cfServiceInstance))
+	if errors.Is(err, osbapi.ErrGone) {
+		removeFinalizer(log, cfServiceInstance)
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Info("failed to get last operation", "reason", err)
+		readyConditionBuilder.WithReason("GetLastOperationFailed")
+		return ctrl.Result{}, err
+	}
+
+	switch lastOperation.State {
+	case services.LastOperationStateSucceeded:
+		removeFinalizer(log, cfServiceInstance)
+		return ctrl.Result{}, nil
+	case services.LastOperationStateFailed:
+		setDeprovisioningFailed(cfServiceInstance, readyConditionBuilder, lastOperation.Description)
+		return ctrl.Result{}, nil
+	default:
+		cfServiceInstance.Status.LastOperation.Description = lastOperation.Description
+		readyConditionBuilder.WithReason("DeprovisioningInProgress")
+		return ctrl.Result{RequeueAfter: osbapi.PollingInterval(lastOperation)}, nil
+	}
+}
+
+func (r *Reconciler) getOrgGUID(ctx context.Context, spaceGUID string) (string, error) {
+	spaces := korifiv1alpha1.CFSpaceList{}
+	err := r.k8sClient.List(ctx, &spaces, client.MatchingFields{shared.IndexSpaceNamespaceName: spaceGUID})
+	if err != nil {
+		return "", err
+	}
+
+	if len(spaces.Items) != 1 {
+		return "", fmt.Errorf("expected exactly one space with guid %q, found %d", spaceGUID, len(spaces.Items))
+	}
+
+	return spaces.Items[0].Namespace, nil
+}
+
+func getParameters(cfServiceInstance *korifiv1alpha1.CFServiceInstance) (map[string]any, error) {
+	if cfServiceInstance.Spec.Parameters == nil || len(cfServiceInstance.Spec.Parameters.Raw) == 0 {
+		return nil, nil
+	}
+
+	parameters := map[string]any{}
+	err := json.Unmarshal(cfServiceInstance.Spec.Parameters.Raw, &parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters: %w", err)
+	}
+
+	return parameters, nil
+}
+
+func setProvisioned(cfServiceInstance *korifiv1alpha1.CFServiceInstance, readyConditionBuilder *k8s.ReadyConditionBuilder) {
+	cfServiceInstance.Status.LastOperation = &services.LastOperation{
+		Type:  services.LastOperationTypeCreate,
+		State: services.LastOperationStateSucceeded,
+	}
+	cfServiceInstance.Status.BrokerOperation = ""
+
+	meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ProvisionedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfServiceInstance.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Provisioned",
+	})
+
+	readyConditionBuilder.Ready()
+}
+
+func setProvisioningFailed(cfServiceInstance *korifiv1alpha1.CFServiceInstance, readyConditionBuilder *k8s.ReadyConditionBuilder, message string) {
+	cfServiceInstance.Status.LastOperation = &services.LastOperation{
+		Type:        services.LastOperationTypeCreate,
+		State:       services.LastOperationStateFailed,
+		Description: message,
+	}
+	cfServiceInstance.Status.BrokerOperation = ""
+
+	meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ProvisionedCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cfServiceInstance.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "ProvisioningFailed",
+		Message:            message,
+	})
+
+	readyConditionBuilder.WithReason("ProvisioningFailed").WithMessage(message)
+}
+
+func setDeprovisioningFailed(cfServiceInstance *korifiv1alpha1.CFServiceInstance, readyConditionBuilder *k8s.ReadyConditionBuilder, message string) {
+	cfServiceInstance.Status.LastOperation = &services.LastOperation{
+		Type:        services.LastOperationTypeDelete,
+		State:       services.LastOperationStateFailed,
+		Description: message,
+	}
+	cfServiceInstance.Status.BrokerOperation = ""
+
+	readyConditionBuilder.WithReason("DeprovisioningFailed").WithMessage(message)
+}
+
+// isDeprovisioningFailed tells whether the broker has already given up on
+// deprovisioning the instance, in which case the request is only retried
+// once the instance is deleted again
+func isDeprovisioningFailed(cfServiceInstance *korifiv1alpha1.CFServiceInstance) bool {
+	lastOperation := cfServiceInstance.Status.LastOperation
+	return lastOperation != nil &&
+		lastOperation.Type == services.LastOperationTypeDelete &&
+		lastOperation.State == services.LastOperationStateFailed
+}
+
+// isDeletionRetried tells whether the instance has been deleted again since
+// the broker failed to deprovision it
+func isDeletionRetried(cfServiceInstance *korifiv1alpha1.CFServiceInstance) bool {
+	_, ok := cfServiceInstance.Annotations[korifiv1alpha1.RetryDeletionAnnotation]
+	return ok
+}
+
+func retryDeprovisioning(cfServiceInstance *korifiv1alpha1.CFServiceInstance) {
+	delete(cfServiceInstance.Annotations, korifiv1alpha1.RetryDeletionAnnotation)
+	cfServiceInstance.Status.LastOperation = &services.LastOperation{
+		Type:  services.LastOperationTypeDelete,
+		State: services.LastOperationStateInitial,
+	}
+}
+
+func removeFinalizer(log logr.Logger, cfServiceInstance *korifiv1alpha1.CFServiceInstance) {
+	if controllerutil.RemoveFinalizer(cfServiceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName) {
+		log.V(1).Info("finalizer removed")
+	}
+}
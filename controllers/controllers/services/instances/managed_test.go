@@ -0,0 +1,562 @@
+package instances_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/services"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tests/helpers/broker"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFServiceInstance managed", func() {
+	var (
+		testNamespace string
+		orgNamespace  string
+		brokerServer  *broker.BrokerServer
+		requestsLock  sync.Mutex
+		requestBodies []map[string]any
+		plan          *korifiv1alpha1.CFServicePlan
+		instance      *korifiv1alpha1.CFServiceInstance
+	)
+
+	recordingHandler := func(status int, body string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsLock.Lock()
+			defer requestsLock.Unlock()
+
+			reqBody, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			if len(reqBody) > 0 {
+				reqBodyMap := map[string]any{}
+				Expect(json.Unmarshal(reqBody, &reqBodyMap)).To(Succeed())
+				requestBodies = append(requestBodies, reqBodyMap)
+			}
+
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		})
+	}
+
+	servedRequests := func() []*http.Request {
+		requestsLock.Lock()
+		defer requestsLock.Unlock()
+
+		return brokerServer.ServedRequests()
+	}
+
+	BeforeEach(func() {
+		requestBodies = nil
+		brokerServer = broker.NewServer().WithHandler("/v2/service_instances/", recordingHandler(http.StatusCreated, "{}"))
+
+		orgNamespace = uuid.NewString()
+		testNamespace = uuid.NewString()
+		for _, ns := range []string{orgNamespace, testNamespace} {
+			Expect(adminClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: ns,
+				},
+			})).To(Succeed())
+		}
+
+		space := &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: orgNamespace,
+				Name:      testNamespace,
+			},
+			Spec: korifiv1alpha1.CFSpaceSpec{
+				DisplayName: "my-space",
+			},
+		}
+		helpers.EnsureCreate(adminClient, space)
+		helpers.EnsurePatch(adminClient, space, func(s *korifiv1alpha1.CFSpace) {
+			s.Status.GUID = testNamespace
+		})
+	})
+
+	JustBeforeEach(func() {
+		brokerServer.Start()
+		DeferCleanup(func() {
+			brokerServer.Stop()
+		})
+
+		creds := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Data: map[string][]byte{
+				korifiv1alpha1.CredentialsSecretKey: []byte(`{"username":"broker-user","password":"broker-password"}`),
+			},
+		}
+		helpers.EnsureCreate(adminClient, creds)
+
+		serviceBroker := &korifiv1alpha1.CFServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceBrokerSpec{
+				ServiceBroker: services.ServiceBroker{
+					Name: uuid.NewString(),
+					URL:  brokerServer.URL(),
+				},
+				Credentials: corev1.LocalObjectReference{Name: creds.Name},
+			},
+		}
+		helpers.EnsureCreate(adminClient, serviceBroker)
+
+		offering := &korifiv1alpha1.CFServiceOffering{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.RelServiceBrokerLabel: serviceBroker.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFServiceOfferingSpec{
+				ServiceOffering: services.ServiceOffering{
+					Name: "my-offering",
+					BrokerCatalog: services.ServiceBrokerCatalog{
+						Id: "broker-offering-id",
+					},
+				},
+			},
+		}
+		helpers.EnsureCreate(adminClient, offering)
+
+		plan = &korifiv1alpha1.CFServicePlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.RelServiceBrokerLabel:   serviceBroker.Name,
+					korifiv1alpha1.RelServiceOfferingLabel: offering.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFServicePlanSpec{
+				ServicePlan: services.ServicePlan{
+					BrokerServicePlan: services.BrokerServicePlan{
+						Name: "my-plan",
+						BrokerCatalog: services.ServicePlanBrokerCatalog{
+							ID: "broker-plan-id",
+						},
+					},
+				},
+			},
+		}
+		helpers.EnsureCreate(adminClient, plan)
+
+		instance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       uuid.NewString(),
+				Namespace:  testNamespace,
+				Finalizers: []string{korifiv1alpha1.CFServiceInstanceFinalizerName},
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName: "service-instance-name",
+				Type:        korifiv1alpha1.ManagedType,
+				PlanGUID:    plan.Name,
+				Parameters: &runtime.RawExtension{
+					Raw: []byte(`{"foo":"bar"}`),
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, instance)).To(Succeed())
+	})
+
+	It("provisions the service instance", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(servedRequests()).To(HaveLen(1))
+		}).Should(Succeed())
+
+		Expect(servedRequests()[0].Method).To(Equal(http.MethodPut))
+		Expect(servedRequests()[0].URL.Path).To(Equal("/v2/service_instances/" + instance.Name))
+		Expect(requestBodies).To(ConsistOf(Equal(map[string]any{
+			"service_id":        "broker-offering-id",
+			"plan_id":           "broker-plan-id",
+			"space_guid":        testNamespace,
+			"organization_guid": orgNamespace,
+			"parameters": map[string]any{
+				"foo": "bar",
+			},
+		})))
+	})
+
+	It("sets the Provisioned and Ready conditions to true", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+			g.Expect(instance.Status.ObservedGeneration).To(Equal(instance.Generation))
+			g.Expect(instance.Status.Conditions).To(ContainElements(
+				SatisfyAll(
+					HasType(Equal(korifiv1alpha1.ProvisionedCondition)),
+					HasStatus(Equal(metav1.ConditionTrue)),
+				),
+				SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionTrue)),
+				),
+			))
+		}).Should(Succeed())
+	})
+
+	It("sets the last operation", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+			g.Expect(instance.Status.LastOperation).To(PointTo(Equal(services.LastOperation{
+				Type:  "create",
+				State: "succeeded",
+			})))
+		}).Should(Succeed())
+	})
+
+	It("provisions the service instance only once", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+			g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+				HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+				HasStatus(Equal(metav1.ConditionTrue)),
+			)))
+		}).Should(Succeed())
+
+		Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+			instance.Spec.Tags = []string{"foo"}
+		})).To(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(servedRequests()).To(HaveLen(1))
+		}).Should(Succeed())
+	})
+
+	When("the broker provisions the instance asynchronously", func() {
+		var lastOperationState string
+
+		BeforeEach(func() {
+			lastOperationState = "in progress"
+			brokerServer = broker.NewServer().
+				WithHandler("/v2/service_instances/", recordingHandler(http.StatusAccepted, `{"operation": "provision-op"}`)).
+				WithHandler("/v2/service_instances/{id}/last_operation", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requestsLock.Lock()
+					defer requestsLock.Unlock()
+
+					w.Header().Set("Retry-After", "1")
+					_, _ = w.Write([]byte(fmt.Sprintf(`{"state": %q, "description": "provisioning"}`, lastOperationState)))
+				}))
+		})
+
+		It("sets the last operation to in progress", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal("create"),
+					"State": Equal("in progress"),
+				})))
+				g.Expect(instance.Status.BrokerOperation).To(Equal("provision-op"))
+				g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionFalse)),
+					HasReason(Equal("ProvisioningInProgress")),
+				)))
+			}).Should(Succeed())
+		})
+
+		It("polls the last operation with the operation token", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(servedRequests()).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Method": Equal(http.MethodGet),
+					"URL": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Path":     Equal("/v2/service_instances/" + instance.Name + "/last_operation"),
+						"RawQuery": ContainSubstring("operation=provision-op"),
+					})),
+				}))))
+			}).Should(Succeed())
+		})
+
+		When("the operation succeeds", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.BrokerOperation).To(Equal("provision-op"))
+				}).Should(Succeed())
+
+				requestsLock.Lock()
+				lastOperationState = "succeeded"
+				requestsLock.Unlock()
+			})
+
+			It("sets the Provisioned condition to true", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal("create"),
+						"State": Equal("succeeded"),
+					})))
+					g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.ProvisionedCondition)).To(BeTrue())
+					g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the operation fails", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.BrokerOperation).To(Equal("provision-op"))
+				}).Should(Succeed())
+
+				requestsLock.Lock()
+				lastOperationState = "failed"
+				requestsLock.Unlock()
+			})
+
+			It("sets the Provisioned condition to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.LastOperation).To(PointTo(Equal(services.LastOperation{
+						Type:        "create",
+						State:       "failed",
+						Description: "provisioning",
+					})))
+					g.Expect(meta.IsStatusConditionFalse(instance.Status.Conditions, korifiv1alpha1.ProvisionedCondition)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the broker rejects the provision request", func() {
+		BeforeEach(func() {
+			brokerServer = broker.NewServer().WithHandler("/v2/service_instances/", recordingHandler(http.StatusBadRequest, `{"description": "invalid params"}`))
+		})
+
+		It("sets the Provisioned condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.Conditions).To(ContainElements(
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.ProvisionedCondition)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("ProvisioningFailed")),
+					),
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("ProvisioningFailed")),
+						HasMessage(ContainSubstring("invalid params")),
+					),
+				))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the broker fails to provision the instance", func() {
+		BeforeEach(func() {
+			brokerServer = broker.NewServer().WithHandler("/v2/service_instances/", recordingHandler(http.StatusInternalServerError, "{}"))
+		})
+
+		It("keeps retrying", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(len(servedRequests())).To(BeNumerically(">", 1))
+			}).Should(Succeed())
+
+			Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+			Expect(instance.Status.Conditions).NotTo(ContainElement(HasType(Equal(korifiv1alpha1.ProvisionedCondition))))
+		})
+	})
+
+	When("the service plan does not exist", func() {
+		JustBeforeEach(func() {
+			Expect(adminClient.Delete(ctx, plan)).To(Succeed())
+		})
+
+		It("sets the Ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionFalse)),
+				)))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the instance is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.ProvisionedCondition)).To(BeTrue())
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, instance)).To(Succeed())
+		})
+
+		It("deprovisions the instance and deletes it", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+
+			Expect(servedRequests()).To(HaveLen(2))
+			deprovisionRequest := servedRequests()[1]
+			Expect(deprovisionRequest.Method).To(Equal(http.MethodDelete))
+			Expect(deprovisionRequest.URL.Path).To(Equal("/v2/service_instances/" + instance.Name))
+			Expect(deprovisionRequest.URL.Query()).To(SatisfyAll(
+				HaveKeyWithValue("service_id", ConsistOf("broker-offering-id")),
+				HaveKeyWithValue("plan_id", ConsistOf("broker-plan-id")),
+			))
+		})
+
+		When("the broker deprovisions the instance asynchronously", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().
+					WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.Method == http.MethodDelete {
+							w.WriteHeader(http.StatusAccepted)
+							_, _ = w.Write([]byte(`{"operation": "deprovision-op"}`))
+							return
+						}
+
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write([]byte("{}"))
+					})).
+					WithHandler("/v2/service_instances/{id}/last_operation", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Retry-After", "1")
+						_, _ = w.Write([]byte(`{"state": "succeeded"}`))
+					}))
+			})
+
+			It("polls the last operation and deletes the instance once deprovisioned", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+
+				Expect(servedRequests()).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Method": Equal(http.MethodGet),
+					"URL": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Path":     Equal("/v2/service_instances/" + instance.Name + "/last_operation"),
+						"RawQuery": ContainSubstring("operation=deprovision-op"),
+					})),
+				}))))
+			})
+		})
+
+		When("the broker rejects the deprovision request", func() {
+			var rejectDeprovision atomic.Bool
+
+			BeforeEach(func() {
+				rejectDeprovision.Store(true)
+				brokerServer = broker.NewServer().
+					WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.Method == http.MethodDelete && rejectDeprovision.Load() {
+							w.WriteHeader(http.StatusUnprocessableEntity)
+							_, _ = w.Write([]byte(`{"description": "instance is in use"}`))
+							return
+						}
+
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write([]byte("{}"))
+					}))
+			})
+
+			deprovisionRequests := func() []*http.Request {
+				var requests []*http.Request
+				for _, r := range servedRequests() {
+					if r.Method == http.MethodDelete {
+						requests = append(requests, r)
+					}
+				}
+				return requests
+			}
+
+			It("marks the deprovisioning as failed and does not retry it", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(services.LastOperationTypeDelete),
+						"State": Equal(services.LastOperationStateFailed),
+					})))
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("DeprovisioningFailed")),
+						HasMessage(ContainSubstring("instance is in use")),
+					)))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					g.Expect(deprovisionRequests()).To(HaveLen(1))
+				}, "2s").Should(Succeed())
+			})
+
+			When("the instance is deleted again", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(services.LastOperationTypeDelete),
+							"State": Equal(services.LastOperationStateFailed),
+						})))
+					}).Should(Succeed())
+
+					rejectDeprovision.Store(false)
+					Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+						instance.Annotations = map[string]string{korifiv1alpha1.RetryDeletionAnnotation: "true"}
+					})).To(Succeed())
+				})
+
+				It("retries the deprovisioning and deletes the instance", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+
+					Expect(deprovisionRequests()).To(HaveLen(2))
+				})
+			})
+		})
+
+		When("the broker fails to deprovision the instance", func() {
+			BeforeEach(func() {
+				brokerServer = broker.NewServer().
+					WithHandler("/v2/service_instances/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.Method == http.MethodDelete {
+							w.WriteHeader(http.StatusInternalServerError)
+							_, _ = w.Write([]byte("{}"))
+							return
+						}
+
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write([]byte("{}"))
+					}))
+			})
+
+			It("keeps retrying the deprovisioning", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("DeprovisionRequestFailed")),
+					)))
+				}).Should(Succeed())
+			})
+		})
+	})
+})
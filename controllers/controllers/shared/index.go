@@ -23,6 +23,7 @@ const (
 	IndexSpaceNamespaceName                   = "spaceNamespace"
 	IndexOrgNamespaceName                     = "orgNamespace"
 	IndexServiceBrokerCredentialsSecretName   = "serviceBrokerCredentialsSecretName"
+	IndexNetworkPolicySourceAppGUID           = "networkPolicySourceAppGUID"
 )
 
 func SetupIndexWithManager(mgr manager.Manager) error {
@@ -86,6 +87,14 @@ func SetupIndexWithManager(mgr manager.Manager) error {
 		return err
 	}
 
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &korifiv1alpha1.CFNetworkPolicy{}, IndexNetworkPolicySourceAppGUID, func(object client.Object) []string {
+		networkPolicy := object.(*korifiv1alpha1.CFNetworkPolicy)
+		return []string{networkPolicy.Spec.Source.AppGUID}
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -105,6 +114,9 @@ func routeDomainQualifiedNameIndexFn(rawObj client.Object) []string {
 
 func serviceBindingAppGUIDIndexFn(rawObj client.Object) []string {
 	serviceBinding := rawObj.(*korifiv1alpha1.CFServiceBinding)
+	if serviceBinding.IsKey() {
+		return nil
+	}
 	return []string{serviceBinding.Spec.AppRef.Name}
 }
 
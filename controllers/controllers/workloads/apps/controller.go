@@ -56,6 +56,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 			&korifiv1alpha1.CFBuild{},
 			handler.EnqueueRequestsFromMapFunc(buildToApp),
 		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(envSecretToApp),
+		).
 		Watches(
 			&korifiv1alpha1.CFServiceBinding{},
 			handler.EnqueueRequestsFromMapFunc(serviceBindingToApp),
@@ -80,7 +84,7 @@ func buildToApp(ctx context.Context, o client.Object) []reconcile.Request {
 
 func serviceBindingToApp(ctx context.Context, o client.Object) []reconcile.Request {
 	serviceBinding, ok := o.(*korifiv1alpha1.CFServiceBinding)
-	if !ok {
+	if !ok || serviceBinding.IsKey() {
 		return nil
 	}
 
@@ -98,7 +102,7 @@ func serviceBindingToApp(ctx context.Context, o client.Object) []reconcile.Reque
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps/finalizers,verbs=update
 
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;patch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -166,6 +170,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 		return ctrl.Result{}, err
 	}
 
+	if err = r.reconcileRevision(ctx, cfApp, reconciledProcesses); err != nil {
+		readyConditionBuilder.WithReason("RevisionFailed")
+		return ctrl.Result{}, err
+	}
+
 	cfApp.Status.ActualState = getActualState(reconciledProcesses)
 	if cfApp.Status.ActualState != cfApp.Spec.DesiredState {
 		readyConditionBuilder.WithReason("DesiredStateNotReached")
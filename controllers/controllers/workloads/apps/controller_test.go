@@ -412,6 +412,138 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 		})
 	})
 
+	Describe("revisions", func() {
+		var envSecret *corev1.Secret
+
+		listRevisions := func(g Gomega) []korifiv1alpha1.CFRevision {
+			revisions := &korifiv1alpha1.CFRevisionList{}
+			g.Expect(adminClient.List(ctx, revisions,
+				client.InNamespace(cfApp.Namespace),
+				client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+			)).To(Succeed())
+			return revisions.Items
+		}
+
+		BeforeEach(func() {
+			envSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfApp.Name + "-env",
+					Namespace: testNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+					},
+				},
+				StringData: map[string]string{"FOO": "foo"},
+			}
+			Expect(adminClient.Create(ctx, envSecret)).To(Succeed())
+
+			Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.EnvSecretName = envSecret.Name
+				cfApp.Spec.EnableRevisions = true
+			})).To(Succeed())
+		})
+
+		It("records an initial revision", func() {
+			Eventually(func(g Gomega) {
+				revisions := listRevisions(g)
+				g.Expect(revisions).To(HaveLen(1))
+
+				revision := revisions[0]
+				g.Expect(revision.Spec.AppRef.Name).To(Equal(cfApp.Name))
+				g.Expect(revision.Spec.Version).To(Equal(1))
+				g.Expect(revision.Spec.DropletRef.Name).To(Equal(cfBuild.Name))
+				g.Expect(revision.Spec.Processes).To(ConsistOf(korifiv1alpha1.RevisionProcess{Type: "web"}))
+				g.Expect(revision.Spec.Description).To(Equal("Initial revision."))
+				g.Expect(revision.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Name":       Equal(cfApp.Name),
+					"Controller": PointTo(BeTrue()),
+				})))
+
+				revisionEnvSecret := &corev1.Secret{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: revision.Spec.EnvSecretName}, revisionEnvSecret)).To(Succeed())
+				g.Expect(revisionEnvSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("foo")}))
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				g.Expect(listRevisions(g)).To(HaveLen(1))
+			}).Should(Succeed())
+		})
+
+		When("revisions are disabled", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.EnableRevisions = false
+				})).To(Succeed())
+			})
+
+			It("does not record revisions", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(listRevisions(g)).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the environment variables change", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listRevisions(g)).To(HaveLen(1))
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, envSecret, func() {
+					envSecret.Data = map[string][]byte{"FOO": []byte("bar")}
+				})).To(Succeed())
+			})
+
+			It("records a new revision", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listRevisions(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"Version":     Equal(2),
+							"Description": Equal("New environment variables deployed."),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the app is rolled back to a revision", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listRevisions(g)).To(HaveLen(1))
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, envSecret, func() {
+					envSecret.Data = map[string][]byte{"FOO": []byte("bar")}
+				})).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(listRevisions(g)).To(HaveLen(2))
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey] = tools.NamespacedUUID(cfApp.Name, "revision", "1")
+				})).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, envSecret, func() {
+					envSecret.Data = map[string][]byte{"FOO": []byte("foo")}
+				})).To(Succeed())
+			})
+
+			It("records a rollback revision", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listRevisions(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"Version":     Equal(3),
+							"Description": Equal("Rolled back to revision 1."),
+						}),
+					})))
+
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					g.Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppRollbackRevisionKey))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	Describe("finalization", func() {
 		var (
 			cfDomainGUID string
@@ -0,0 +1,330 @@
+package apps
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfrevisions,verbs=get;list;watch;create
+
+// appSnapshot is the part of the app state recorded by a CFRevision
+type appSnapshot struct {
+	dropletGUID string
+	envVars     map[string][]byte
+	processes   []korifiv1alpha1.RevisionProcess
+}
+
+func envSecretToApp(ctx context.Context, o client.Object) []reconcile.Request {
+	appGUID, ok := o.GetLabels()[korifiv1alpha1.CFAppGUIDLabelKey]
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: client.ObjectKey{
+				Name:      appGUID,
+				Namespace: o.GetNamespace(),
+			},
+		},
+	}
+}
+
+// reconcileRevision records a new CFRevision when the droplet, the
+// environment variables or the process commands of the app differ from the
+// ones recorded by its latest revision
+func (r *Reconciler) reconcileRevision(ctx context.Context, cfApp *korifiv1alpha1.CFApp, processes []*korifiv1alpha1.CFProcess) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileRevision")
+
+	if !cfApp.Spec.EnableRevisions {
+		return nil
+	}
+
+	snapshot, err := r.takeSnapshot(ctx, cfApp, processes)
+	if err != nil {
+		return err
+	}
+
+	revisions := &korifiv1alpha1.CFRevisionList{}
+	err = r.k8sClient.List(ctx, revisions, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		log.Info("failed to list revisions", "reason", err)
+		return err
+	}
+
+	latest := latestRevision(revisions.Items)
+	description := describeChanges(nil, snapshot)
+
+	if latest != nil {
+		var latestSnapshot appSnapshot
+		latestSnapshot, err = r.revisionSnapshot(ctx, latest)
+		if err != nil {
+			return err
+		}
+
+		description = describeChanges(&latestSnapshot, snapshot)
+	}
+
+	if rollbackRevisionName, ok := cfApp.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey]; ok {
+		var rolledBack bool
+		description, rolledBack, err = r.describeRollback(ctx, cfApp, rollbackRevisionName, snapshot, description)
+		if err != nil {
+			return err
+		}
+
+		if !rolledBack {
+			log.V(1).Info("waiting for the app to be rolled back", "revision", rollbackRevisionName)
+			return nil
+		}
+
+		delete(cfApp.Annotations, korifiv1alpha1.CFAppRollbackRevisionKey)
+	}
+
+	if description == "" {
+		return nil
+	}
+
+	version := 1
+	if latest != nil {
+		version = latest.Spec.Version + 1
+	}
+
+	return r.createRevision(ctx, cfApp, version, snapshot, description)
+}
+
+func (r *Reconciler) takeSnapshot(ctx context.Context, cfApp *korifiv1alpha1.CFApp, processes []*korifiv1alpha1.CFProcess) (appSnapshot, error) {
+	snapshot := appSnapshot{
+		dropletGUID: cfApp.Spec.CurrentDropletRef.Name,
+		envVars:     map[string][]byte{},
+	}
+
+	for _, process := range processes {
+		snapshot.processes = append(snapshot.processes, korifiv1alpha1.RevisionProcess{
+			Type:    process.Spec.ProcessType,
+			Command: process.Spec.Command,
+		})
+	}
+	slices.SortFunc(snapshot.processes, func(a, b korifiv1alpha1.RevisionProcess) int {
+		return cmp.Compare(a.Type, b.Type)
+	})
+
+	if cfApp.Spec.EnvSecretName == "" {
+		return snapshot, nil
+	}
+
+	envVars, err := r.getSecretData(ctx, cfApp.Namespace, cfApp.Spec.EnvSecretName)
+	if err != nil {
+		return appSnapshot{}, err
+	}
+	snapshot.envVars = envVars
+
+	return snapshot, nil
+}
+
+func (r *Reconciler) revisionSnapshot(ctx context.Context, revision *korifiv1alpha1.CFRevision) (appSnapshot, error) {
+	envVars, err := r.getSecretData(ctx, revision.Namespace, revision.Spec.EnvSecretName)
+	if err != nil {
+		return appSnapshot{}, err
+	}
+
+	return appSnapshot{
+		dropletGUID: revision.Spec.DropletRef.Name,
+		envVars:     envVars,
+		processes:   revision.Spec.Processes,
+	}, nil
+}
+
+func (r *Reconciler) getSecretData(ctx context.Context, namespace, name string) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret)
+	if k8serrors.IsNotFound(err) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %q: %w", name, err)
+	}
+
+	if secret.Data == nil {
+		return map[string][]byte{}, nil
+	}
+
+	return secret.Data, nil
+}
+
+// describeRollback checks whether the app has reached the state of the
+// revision it is rolled back to
+func (r *Reconciler) describeRollback(
+	ctx context.Context,
+	cfApp *korifiv1alpha1.CFApp,
+	revisionName string,
+	snapshot appSnapshot,
+	description string,
+) (string, bool, error) {
+	revision := &korifiv1alpha1.CFRevision{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: revisionName}, revision)
+	if k8serrors.IsNotFound(err) {
+		return description, true, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get revision %q: %w", revisionName, err)
+	}
+
+	revisionSnapshot, err := r.revisionSnapshot(ctx, revision)
+	if err != nil {
+		return "", false, err
+	}
+
+	if !rolledBackTo(revisionSnapshot, snapshot) {
+		return "", false, nil
+	}
+
+	if description == "" {
+		return "", true, nil
+	}
+
+	return fmt.Sprintf("Rolled back to revision %d.", revision.Spec.Version), true, nil
+}
+
+// rolledBackTo checks that the app is running the droplet, the environment
+// variables and the process commands of the revision. Processes the droplet
+// of the revision does not define are ignored.
+func rolledBackTo(revision, actual appSnapshot) bool {
+	if revision.dropletGUID != actual.dropletGUID || !maps.EqualFunc(revision.envVars, actual.envVars, bytesEqual) {
+		return false
+	}
+
+	for _, actualProcess := range actual.processes {
+		for _, revisionProcess := range revision.processes {
+			if revisionProcess.Type == actualProcess.Type && revisionProcess.Command != actualProcess.Command {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (r *Reconciler) createRevision(ctx context.Context, cfApp *korifiv1alpha1.CFApp, version int, snapshot appSnapshot, description string) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("createRevision").WithValues("version", version)
+
+	// revision names are derived from their version, so that a revision is
+	// never recorded twice when the cache does not contain the latest
+	// revision yet
+	revisionName := tools.NamespacedUUID(cfApp.Name, "revision", strconv.Itoa(version))
+
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revisionName + "-env",
+			Namespace: cfApp.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, envSecret, func() error {
+		envSecret.Data = snapshot.envVars
+		return controllerutil.SetOwnerReference(cfApp, envSecret, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create revision env secret", "reason", err)
+		return err
+	}
+
+	revision := &korifiv1alpha1.CFRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revisionName,
+			Namespace: cfApp.Namespace,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFRevisionSpec{
+			AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+			Version:       version,
+			DropletRef:    corev1.LocalObjectReference{Name: snapshot.dropletGUID},
+			EnvSecretName: envSecret.Name,
+			Processes:     snapshot.processes,
+			Description:   description,
+		},
+	}
+	if err = controllerutil.SetControllerReference(cfApp, revision, r.scheme); err != nil {
+		return fmt.Errorf("failed to set OwnerRef on CFRevision: %w", err)
+	}
+
+	err = r.k8sClient.Create(ctx, revision)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		log.Info("failed to create revision", "reason", err)
+		return err
+	}
+
+	return nil
+}
+
+func latestRevision(revisions []korifiv1alpha1.CFRevision) *korifiv1alpha1.CFRevision {
+	var latest *korifiv1alpha1.CFRevision
+	for i := range revisions {
+		if latest == nil || revisions[i].Spec.Version > latest.Spec.Version {
+			latest = &revisions[i]
+		}
+	}
+
+	return latest
+}
+
+// describeChanges returns the description of a revision recording the
+// current snapshot, or an empty string if nothing changed since the previous
+// one
+func describeChanges(previous *appSnapshot, current appSnapshot) string {
+	if previous == nil {
+		return "Initial revision."
+	}
+
+	var changes []string
+	if previous.dropletGUID != current.dropletGUID {
+		changes = append(changes, "New droplet deployed.")
+	}
+
+	if !maps.EqualFunc(previous.envVars, current.envVars, bytesEqual) {
+		changes = append(changes, "New environment variables deployed.")
+	}
+
+	previousCommands := map[string]string{}
+	for _, p := range previous.processes {
+		previousCommands[p.Type] = p.Command
+	}
+
+	for _, p := range current.processes {
+		previousCommand := previousCommands[p.Type]
+		switch {
+		case previousCommand == p.Command:
+		case previousCommand == "":
+			changes = append(changes, fmt.Sprintf("Custom start command added for '%s' process.", p.Type))
+		case p.Command == "":
+			changes = append(changes, fmt.Sprintf("Custom start command removed for '%s' process.", p.Type))
+		default:
+			changes = append(changes, fmt.Sprintf("Custom start command updated for '%s' process.", p.Type))
+		}
+	}
+
+	return strings.Join(changes, " ")
+}
+
+func bytesEqual(a, b []byte) bool {
+	return string(a) == string(b)
+}
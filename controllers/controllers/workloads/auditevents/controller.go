@@ -0,0 +1,60 @@
+package auditevents
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reconciler deletes audit events once they are older than the configured
+// TTL, so that the root namespace does not grow forever
+type Reconciler struct {
+	k8sClient     client.Client
+	log           logr.Logger
+	auditEventTTL time.Duration
+}
+
+func NewReconciler(
+	client client.Client,
+	log logr.Logger,
+	auditEventTTL time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFAuditEvent, *korifiv1alpha1.CFAuditEvent] {
+	auditEventReconciler := Reconciler{
+		k8sClient:     client,
+		log:           log,
+		auditEventTTL: auditEventTTL,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFAuditEvent, *korifiv1alpha1.CFAuditEvent](log, client, &auditEventReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFAuditEvent{})
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=get;list;watch;patch;delete
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfAuditEvent *korifiv1alpha1.CFAuditEvent) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	expiresAt := cfAuditEvent.CreationTimestamp.Add(r.auditEventTTL)
+	if time.Now().Before(expiresAt) {
+		return ctrl.Result{RequeueAfter: time.Until(expiresAt)}, nil
+	}
+
+	log.V(1).Info("deleting expired audit event", "createdAt", cfAuditEvent.CreationTimestamp)
+	err := r.k8sClient.Delete(ctx, cfAuditEvent)
+	if client.IgnoreNotFound(err) != nil {
+		log.Info("failed to delete expired audit event", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
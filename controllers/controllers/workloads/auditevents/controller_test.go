@@ -0,0 +1,50 @@
+package auditevents_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFAuditEventReconciler Integration Tests", func() {
+	var cfAuditEvent *korifiv1alpha1.CFAuditEvent
+
+	BeforeEach(func() {
+		cfAuditEvent = &korifiv1alpha1.CFAuditEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAuditEventSpec{
+				Type: "audit.app.create",
+				Actor: korifiv1alpha1.AuditEventActor{
+					GUID: "user-guid",
+					Type: "user",
+				},
+				Target: korifiv1alpha1.AuditEventTarget{
+					GUID: "app-guid",
+					Type: "app",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfAuditEvent)).To(Succeed())
+	})
+
+	It("keeps the audit event until it expires", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), cfAuditEvent)).To(Succeed())
+		}, "1s").Should(Succeed())
+	})
+
+	It("deletes the audit event once it expires", func() {
+		Eventually(func(g Gomega) {
+			err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), cfAuditEvent)
+			g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		}).Should(Succeed())
+	})
+})
@@ -69,7 +69,8 @@ var _ = BeforeSuite(func() {
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
 	controllerConfig := &config.ControllerConfig{
-		BuilderName: "buildpack-builder-name",
+		BuilderName:     "buildpack-builder-name",
+		CFRootNamespace: "cf",
 	}
 
 	cfBuildpackBuildReconciler := buildpack.NewReconciler(
@@ -78,7 +79,7 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFBuildpackBuild"),
 		controllerConfig,
-		env.NewAppEnvBuilder(k8sManager.GetClient()),
+		env.NewStagingAppEnvBuilder(k8sManager.GetClient(), controllerConfig.CFRootNamespace),
 	)
 	err = (cfBuildpackBuildReconciler).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
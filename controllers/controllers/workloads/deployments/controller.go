@@ -0,0 +1,256 @@
+package deployments
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Reconciler tracks the progress of a CFDeployment by observing the CFApp
+// it rolls out. Once finalized, the deployment status is not updated
+// anymore, so that deployments are kept as a history of the app rollouts.
+type Reconciler struct {
+	k8sClient client.Client
+	scheme    *runtime.Scheme
+	log       logr.Logger
+}
+
+func NewReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFDeployment, *korifiv1alpha1.CFDeployment] {
+	deploymentReconciler := Reconciler{
+		k8sClient: client,
+		scheme:    scheme,
+		log:       log,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFDeployment, *korifiv1alpha1.CFDeployment](log, client, &deploymentReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFDeployment{}).
+		Watches(
+			&korifiv1alpha1.CFApp{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFDeploymentRequestsForApp),
+		).
+		Watches(
+			&korifiv1alpha1.AppWorkload{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFDeploymentRequestsForAppWorkload),
+			builder.WithPredicates(isCanaryAppWorkload()),
+		)
+}
+
+func isCanaryAppWorkload() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(o client.Object) bool {
+		appWorkload, ok := o.(*korifiv1alpha1.AppWorkload)
+		return ok && appWorkload.Spec.Canary
+	})
+}
+
+func (r *Reconciler) enqueueCFDeploymentRequestsForAppWorkload(ctx context.Context, o client.Object) []reconcile.Request {
+	appGUID, ok := o.GetLabels()[korifiv1alpha1.CFAppGUIDLabelKey]
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return r.enqueueCFDeploymentRequestsForApp(ctx, &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: o.GetNamespace(),
+			Name:      appGUID,
+		},
+	})
+}
+
+func (r *Reconciler) enqueueCFDeploymentRequestsForApp(ctx context.Context, o client.Object) []reconcile.Request {
+	deploymentList := &korifiv1alpha1.CFDeploymentList{}
+	err := r.k8sClient.List(ctx, deploymentList, client.InNamespace(o.GetNamespace()), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: o.GetName()})
+	if err != nil {
+		r.log.Error(fmt.Errorf("listing CFDeployments for CFApp guid failed: %w", err), "cfAppGUID", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range deploymentList.Items {
+		if isFinalized(&deploymentList.Items[i]) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&deploymentList.Items[i])})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdeployments,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdeployments/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfDeployment *korifiv1alpha1.CFDeployment) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfDeployment.Status.ObservedGeneration = cfDeployment.Generation
+	log.V(1).Info("set observed generation", "generation", cfDeployment.Status.ObservedGeneration)
+
+	if isFinalized(cfDeployment) {
+		return ctrl.Result{}, nil
+	}
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: cfDeployment.Namespace, Name: cfDeployment.Spec.AppRef.Name}, cfApp)
+	if err != nil {
+		log.Info("error when fetching CFApp", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	err = controllerutil.SetControllerReference(cfApp, cfDeployment, r.scheme)
+	if err != nil {
+		log.Info("unable to set owner reference on CFDeployment", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	configRestored, err := r.applyRollbackRevision(ctx, cfDeployment, cfApp)
+	if err != nil {
+		log.Info("error when rolling the app back to the revision", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	// the app instances restart with the config of the revision, the app
+	// cannot be considered deployed until they do
+	if configRestored {
+		meta.SetStatusCondition(&cfDeployment.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.DeploymentFinalizedConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             korifiv1alpha1.DeploymentDeployingReason,
+			ObservedGeneration: cfDeployment.Generation,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	canaryReady, err := r.isCanaryReady(ctx, cfDeployment, cfApp)
+	if err != nil {
+		log.Info("error when checking canary AppWorkloads", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	meta.SetStatusCondition(&cfDeployment.Status.Conditions, finalizedCondition(cfDeployment, cfApp, canaryReady))
+
+	return ctrl.Result{}, nil
+}
+
+// isCanaryReady tells whether the canary AppWorkloads of the deployment
+// revision exist and run all their instances
+func (r *Reconciler) isCanaryReady(ctx context.Context, cfDeployment *korifiv1alpha1.CFDeployment, cfApp *korifiv1alpha1.CFApp) (bool, error) {
+	if cfApp.Spec.Canary == nil || cfApp.Spec.Canary.Revision != cfDeployment.Spec.Revision {
+		return false, nil
+	}
+
+	appWorkloads := &korifiv1alpha1.AppWorkloadList{}
+	err := r.k8sClient.List(ctx, appWorkloads, client.InNamespace(cfDeployment.Namespace), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+		korifiv1alpha1.CFAppRevisionKey:  cfApp.Spec.Canary.Revision,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list canary AppWorkloads: %w", err)
+	}
+
+	canaryReady := false
+	for i := range appWorkloads.Items {
+		appWorkload := &appWorkloads.Items[i]
+		if !appWorkload.Spec.Canary {
+			continue
+		}
+
+		if !appWorkloadIsReady(appWorkload) {
+			return false, nil
+		}
+		canaryReady = true
+	}
+
+	return canaryReady, nil
+}
+
+func appWorkloadIsReady(appWorkload *korifiv1alpha1.AppWorkload) bool {
+	readyCondition := meta.FindStatusCondition(appWorkload.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+	return readyCondition != nil &&
+		readyCondition.Status == metav1.ConditionTrue &&
+		readyCondition.ObservedGeneration == appWorkload.Generation &&
+		appWorkload.Status.ActualInstances >= appWorkload.Spec.Instances
+}
+
+func finalizedCondition(cfDeployment *korifiv1alpha1.CFDeployment, cfApp *korifiv1alpha1.CFApp, canaryReady bool) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               korifiv1alpha1.DeploymentFinalizedConditionType,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cfDeployment.Generation,
+	}
+
+	// a canceled deployment rolls the app back to the previous revision
+	expectedRevision := cfDeployment.Spec.Revision
+	inProgressReason, doneReason := korifiv1alpha1.DeploymentDeployingReason, korifiv1alpha1.DeploymentDeployedReason
+	if cfDeployment.Spec.Canceled {
+		expectedRevision = cfDeployment.Spec.PreviousRevision
+		inProgressReason, doneReason = korifiv1alpha1.DeploymentCancelingReason, korifiv1alpha1.DeploymentCanceledReason
+	}
+
+	// the app revision moves on when the app is deployed or restarted again
+	appRevision := cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey]
+	if appRevision != cfDeployment.Spec.Revision && appRevision != cfDeployment.Spec.PreviousRevision {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = korifiv1alpha1.DeploymentSupersededReason
+		return condition
+	}
+
+	// a paused canary deployment only runs canary instances next to the
+	// previous revision until it is continued, i.e. until the app is moved
+	// to the new revision. It is only paused once the canary instances are
+	// up and running.
+	if !cfDeployment.Spec.Canceled && !cfDeployment.Spec.Continued && appRevision != cfDeployment.Spec.Revision && cfApp.Spec.Canary != nil {
+		if cfApp.Spec.Canary.Revision != cfDeployment.Spec.Revision {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = korifiv1alpha1.DeploymentSupersededReason
+			return condition
+		}
+
+		condition.Reason = inProgressReason
+		if canaryReady {
+			condition.Reason = korifiv1alpha1.DeploymentPausedReason
+		}
+		return condition
+	}
+
+	condition.Reason = inProgressReason
+	if appRevision == expectedRevision && appIsReady(cfApp) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = doneReason
+	}
+
+	return condition
+}
+
+func appIsReady(cfApp *korifiv1alpha1.CFApp) bool {
+	readyCondition := meta.FindStatusCondition(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+	return readyCondition != nil &&
+		readyCondition.Status == metav1.ConditionTrue &&
+		readyCondition.ObservedGeneration == cfApp.Generation
+}
+
+func isFinalized(cfDeployment *korifiv1alpha1.CFDeployment) bool {
+	return meta.IsStatusConditionTrue(cfDeployment.Status.Conditions, korifiv1alpha1.DeploymentFinalizedConditionType)
+}
@@ -0,0 +1,439 @@
+package deployments_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFDeploymentReconciler Integration Tests", func() {
+	var (
+		cfApp        *korifiv1alpha1.CFApp
+		cfDeployment *korifiv1alpha1.CFDeployment
+	)
+
+	BeforeEach(func() {
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+				Annotations: map[string]string{
+					korifiv1alpha1.CFAppRevisionKey: "2",
+				},
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "my-app",
+				DesiredState: korifiv1alpha1.StartedState,
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+				CurrentDropletRef: corev1.LocalObjectReference{Name: "new-droplet"},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+		cfDeployment = &korifiv1alpha1.CFDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFDeploymentSpec{
+				AppRef:             corev1.LocalObjectReference{Name: cfApp.Name},
+				DropletRef:         corev1.LocalObjectReference{Name: "new-droplet"},
+				Revision:           "2",
+				PreviousDropletRef: corev1.LocalObjectReference{Name: "old-droplet"},
+				PreviousRevision:   "1",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfDeployment)).To(Succeed())
+	})
+
+	setAppReady := func() {
+		Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+			meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
+				Type:               korifiv1alpha1.StatusConditionReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				ObservedGeneration: cfApp.Generation,
+			})
+		})).To(Succeed())
+	}
+
+	createCanaryAppWorkload := func(actualInstances int32) {
+		GinkgoHelper()
+
+		appWorkload := &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+					korifiv1alpha1.CFAppRevisionKey:  "2",
+				},
+			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				GUID:        uuid.NewString(),
+				AppGUID:     cfApp.Name,
+				ProcessType: "web",
+				Image:       "my-image",
+				Instances:   1,
+				RunnerName:  "statefulset-runner",
+				Canary:      true,
+			},
+		}
+		Expect(adminClient.Create(ctx, appWorkload)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, appWorkload, func() {
+			appWorkload.Status.ActualInstances = actualInstances
+			meta.SetStatusCondition(&appWorkload.Status.Conditions, metav1.Condition{
+				Type:               korifiv1alpha1.StatusConditionReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				ObservedGeneration: appWorkload.Generation,
+			})
+		})).To(Succeed())
+	}
+
+	expectFinalizedCondition := func(status metav1.ConditionStatus, reason string) {
+		GinkgoHelper()
+
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfDeployment), cfDeployment)).To(Succeed())
+
+			condition := meta.FindStatusCondition(cfDeployment.Status.Conditions, korifiv1alpha1.DeploymentFinalizedConditionType)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(status))
+			g.Expect(condition.Reason).To(Equal(reason))
+		}).Should(Succeed())
+	}
+
+	It("sets the deployment as deploying", func() {
+		expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentDeployingReason)
+	})
+
+	It("sets the app as the deployment owner", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfDeployment), cfDeployment)).To(Succeed())
+			g.Expect(cfDeployment.OwnerReferences).To(ConsistOf(HaveField("Name", cfApp.Name)))
+		}).Should(Succeed())
+	})
+
+	When("the app becomes ready", func() {
+		JustBeforeEach(func() {
+			expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentDeployingReason)
+			setAppReady()
+		})
+
+		It("finalizes the deployment as deployed", func() {
+			expectFinalizedCondition(metav1.ConditionTrue, korifiv1alpha1.DeploymentDeployedReason)
+		})
+
+		When("the app is deployed again", func() {
+			JustBeforeEach(func() {
+				expectFinalizedCondition(metav1.ConditionTrue, korifiv1alpha1.DeploymentDeployedReason)
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "3"
+				})).To(Succeed())
+			})
+
+			It("keeps the deployment as deployed", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfDeployment), cfDeployment)).To(Succeed())
+					g.Expect(meta.FindStatusCondition(cfDeployment.Status.Conditions, korifiv1alpha1.DeploymentFinalizedConditionType)).To(
+						HaveField("Reason", korifiv1alpha1.DeploymentDeployedReason),
+					)
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the app revision changes before the app becomes ready", func() {
+		JustBeforeEach(func() {
+			expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentDeployingReason)
+			Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+				cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "3"
+			})).To(Succeed())
+		})
+
+		It("finalizes the deployment as superseded", func() {
+			expectFinalizedCondition(metav1.ConditionTrue, korifiv1alpha1.DeploymentSupersededReason)
+		})
+	})
+
+	When("the deployment is a paused canary deployment", func() {
+		BeforeEach(func() {
+			cfDeployment.Spec.Strategy = korifiv1alpha1.DeploymentStrategyCanary
+			Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+				cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "1"
+				cfApp.Spec.CurrentDropletRef.Name = "old-droplet"
+				cfApp.Spec.Canary = &korifiv1alpha1.CanarySpec{
+					DropletRef: corev1.LocalObjectReference{Name: "new-droplet"},
+					Revision:   "2",
+				}
+			})).To(Succeed())
+		})
+
+		It("sets the deployment as deploying", func() {
+			expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentDeployingReason)
+		})
+
+		When("the canary instances are not ready yet", func() {
+			JustBeforeEach(func() {
+				createCanaryAppWorkload(0)
+			})
+
+			It("keeps the deployment as deploying", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfDeployment), cfDeployment)).To(Succeed())
+					g.Expect(meta.FindStatusCondition(cfDeployment.Status.Conditions, korifiv1alpha1.DeploymentFinalizedConditionType)).To(
+						HaveField("Reason", korifiv1alpha1.DeploymentDeployingReason),
+					)
+				}).Should(Succeed())
+			})
+		})
+
+		When("the canary instances are ready", func() {
+			JustBeforeEach(func() {
+				createCanaryAppWorkload(1)
+			})
+
+			It("sets the deployment as paused", func() {
+				expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentPausedReason)
+			})
+		})
+
+		When("the deployment is continued", func() {
+			JustBeforeEach(func() {
+				createCanaryAppWorkload(1)
+				expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentPausedReason)
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "2"
+					cfApp.Spec.CurrentDropletRef.Name = "new-droplet"
+					cfApp.Spec.Canary = nil
+				})).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, cfDeployment, func() {
+					cfDeployment.Spec.Continued = true
+				})).To(Succeed())
+			})
+
+			It("sets the deployment as deploying", func() {
+				expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentDeployingReason)
+			})
+
+			When("the app becomes ready", func() {
+				JustBeforeEach(func() {
+					expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentDeployingReason)
+					setAppReady()
+				})
+
+				It("finalizes the deployment as deployed", func() {
+					expectFinalizedCondition(metav1.ConditionTrue, korifiv1alpha1.DeploymentDeployedReason)
+				})
+			})
+		})
+
+		When("another canary deployment replaces the canary", func() {
+			JustBeforeEach(func() {
+				createCanaryAppWorkload(1)
+				expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentPausedReason)
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.Canary.Revision = "3"
+				})).To(Succeed())
+			})
+
+			It("finalizes the deployment as superseded", func() {
+				expectFinalizedCondition(metav1.ConditionTrue, korifiv1alpha1.DeploymentSupersededReason)
+			})
+		})
+	})
+
+	When("the deployment rolls the app back to a revision", func() {
+		var (
+			envSecret *corev1.Secret
+			cfProcess *korifiv1alpha1.CFProcess
+		)
+
+		BeforeEach(func() {
+			envSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      uuid.NewString(),
+				},
+				Data: map[string][]byte{"FOO": []byte("new-foo"), "BAR": []byte("bar")},
+			}
+			Expect(adminClient.Create(ctx, envSecret)).To(Succeed())
+
+			revisionEnvSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      uuid.NewString(),
+				},
+				Data: map[string][]byte{"FOO": []byte("foo")},
+			}
+			Expect(adminClient.Create(ctx, revisionEnvSecret)).To(Succeed())
+
+			cfRevision := &korifiv1alpha1.CFRevision{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      uuid.NewString(),
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+					},
+				},
+				Spec: korifiv1alpha1.CFRevisionSpec{
+					AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+					Version:       1,
+					DropletRef:    corev1.LocalObjectReference{Name: "new-droplet"},
+					EnvSecretName: revisionEnvSecret.Name,
+					Processes: []korifiv1alpha1.RevisionProcess{
+						{Type: "web", Command: "revision-command"},
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, cfRevision)).To(Succeed())
+
+			cfProcess = &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      uuid.NewString(),
+					Labels: map[string]string{
+						korifiv1alpha1.CFProcessTypeLabelKey: "web",
+						korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+					},
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+					ProcessType: "web",
+					Command:     "new-command",
+					MemoryMB:    768,
+					HealthCheck: korifiv1alpha1.HealthCheck{
+						Type: "process",
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+
+			Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.EnvSecretName = envSecret.Name
+				cfApp.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey] = cfRevision.Name
+			})).To(Succeed())
+
+			cfDeployment.Spec.RollbackRevisionRef.Name = cfRevision.Name
+		})
+
+		expectAppConfig := func(g Gomega, envVars map[string][]byte, command string) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(envSecret), envSecret)).To(Succeed())
+			g.Expect(envSecret.Data).To(Equal(envVars))
+
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+			g.Expect(cfProcess.Spec.Command).To(Equal(command))
+		}
+
+		It("restores the environment variables and process commands of the revision", func() {
+			Eventually(func(g Gomega) {
+				expectAppConfig(g, map[string][]byte{"FOO": []byte("foo")}, "revision-command")
+			}).Should(Succeed())
+		})
+
+		When("the deployment is a paused canary deployment", func() {
+			BeforeEach(func() {
+				cfDeployment.Spec.Strategy = korifiv1alpha1.DeploymentStrategyCanary
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "1"
+					cfApp.Spec.CurrentDropletRef.Name = "old-droplet"
+					cfApp.Spec.Canary = &korifiv1alpha1.CanarySpec{
+						DropletRef: corev1.LocalObjectReference{Name: "new-droplet"},
+						Revision:   "2",
+					}
+				})).To(Succeed())
+			})
+
+			It("does not restart the instances of the previous revision", func() {
+				Consistently(func(g Gomega) {
+					expectAppConfig(g, map[string][]byte{"FOO": []byte("new-foo"), "BAR": []byte("bar")}, "new-command")
+				}).Should(Succeed())
+			})
+
+			When("the deployment is continued", func() {
+				JustBeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "2"
+						cfApp.Spec.CurrentDropletRef.Name = "new-droplet"
+						cfApp.Spec.Canary = nil
+					})).To(Succeed())
+					Expect(k8s.Patch(ctx, adminClient, cfDeployment, func() {
+						cfDeployment.Spec.Continued = true
+					})).To(Succeed())
+				})
+
+				It("restores the environment variables and process commands of the revision", func() {
+					Eventually(func(g Gomega) {
+						expectAppConfig(g, map[string][]byte{"FOO": []byte("foo")}, "revision-command")
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the deployment is canceled", func() {
+			BeforeEach(func() {
+				cfDeployment.Spec.Canceled = true
+			})
+
+			It("does not restore the config of the revision", func() {
+				Consistently(func(g Gomega) {
+					expectAppConfig(g, map[string][]byte{"FOO": []byte("new-foo"), "BAR": []byte("bar")}, "new-command")
+				}).Should(Succeed())
+			})
+		})
+
+		When("the app is no longer rolled back to the revision", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					delete(cfApp.Annotations, korifiv1alpha1.CFAppRollbackRevisionKey)
+				})).To(Succeed())
+			})
+
+			It("does not restore the config of the revision", func() {
+				Consistently(func(g Gomega) {
+					expectAppConfig(g, map[string][]byte{"FOO": []byte("new-foo"), "BAR": []byte("bar")}, "new-command")
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the deployment is canceled", func() {
+		BeforeEach(func() {
+			cfDeployment.Spec.Canceled = true
+		})
+
+		It("sets the deployment as canceling", func() {
+			expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentCancelingReason)
+		})
+
+		When("the app is rolled back to the previous revision and becomes ready", func() {
+			JustBeforeEach(func() {
+				expectFinalizedCondition(metav1.ConditionFalse, korifiv1alpha1.DeploymentCancelingReason)
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "1"
+				})).To(Succeed())
+				setAppReady()
+			})
+
+			It("finalizes the deployment as canceled", func() {
+				expectFinalizedCondition(metav1.ConditionTrue, korifiv1alpha1.DeploymentCanceledReason)
+			})
+		})
+	})
+})
@@ -0,0 +1,125 @@
+package deployments
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfrevisions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+
+// applyRollbackRevision restores the environment variables and process
+// commands of the revision a deployment rolls the app back to. They are only
+// applied once the app runs the deployment revision, so that the instances of
+// the previous revision are not restarted while a canary deployment is
+// paused, and as long as the app is annotated with the revision, i.e. until
+// the app controller has seen the app matching it. It tells whether the app
+// config has been changed.
+func (r *Reconciler) applyRollbackRevision(ctx context.Context, cfDeployment *korifiv1alpha1.CFDeployment, cfApp *korifiv1alpha1.CFApp) (bool, error) {
+	revisionName := cfDeployment.Spec.RollbackRevisionRef.Name
+	if revisionName == "" || cfDeployment.Spec.Canceled {
+		return false, nil
+	}
+
+	if cfApp.Annotations[korifiv1alpha1.CFAppRollbackRevisionKey] != revisionName ||
+		cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] != cfDeployment.Spec.Revision {
+		return false, nil
+	}
+
+	revision := &korifiv1alpha1.CFRevision{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: cfDeployment.Namespace, Name: revisionName}, revision)
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get revision %q: %w", revisionName, err)
+	}
+
+	envVarsChanged, err := r.restoreEnvVars(ctx, cfApp, revision)
+	if err != nil {
+		return false, err
+	}
+
+	commandsChanged, err := r.restoreCommands(ctx, cfApp, revision)
+	if err != nil {
+		return false, err
+	}
+
+	return envVarsChanged || commandsChanged, nil
+}
+
+func (r *Reconciler) restoreEnvVars(ctx context.Context, cfApp *korifiv1alpha1.CFApp, revision *korifiv1alpha1.CFRevision) (bool, error) {
+	if cfApp.Spec.EnvSecretName == "" {
+		return false, nil
+	}
+
+	revisionEnvSecret := &corev1.Secret{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: revision.Namespace, Name: revision.Spec.EnvSecretName}, revisionEnvSecret)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the env secret of revision %q: %w", revision.Name, err)
+	}
+
+	envSecret := &corev1.Secret{}
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Namespace: cfApp.Namespace, Name: cfApp.Spec.EnvSecretName}, envSecret)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the env secret of app %q: %w", cfApp.Name, err)
+	}
+
+	if maps.EqualFunc(envSecret.Data, revisionEnvSecret.Data, bytes.Equal) {
+		return false, nil
+	}
+
+	err = k8s.PatchResource(ctx, r.k8sClient, envSecret, func() {
+		envSecret.Data = revisionEnvSecret.Data
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to restore the env secret of app %q: %w", cfApp.Name, err)
+	}
+
+	return true, nil
+}
+
+// restoreCommands restores the commands of the app processes. Processes the
+// revision does not record are left untouched.
+func (r *Reconciler) restoreCommands(ctx context.Context, cfApp *korifiv1alpha1.CFApp, revision *korifiv1alpha1.CFRevision) (bool, error) {
+	revisionCommands := map[string]string{}
+	for _, p := range revision.Spec.Processes {
+		revisionCommands[p.Type] = p.Command
+	}
+
+	processes := &korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, processes, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		return false, fmt.Errorf("failed to list the processes of app %q: %w", cfApp.Name, err)
+	}
+
+	changed := false
+	for i := range processes.Items {
+		process := &processes.Items[i]
+		command, ok := revisionCommands[process.Spec.ProcessType]
+		if !ok || command == process.Spec.Command {
+			continue
+		}
+
+		err = k8s.PatchResource(ctx, r.k8sClient, process, func() {
+			process.Spec.Command = command
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to restore the command of process %q: %w", process.Name, err)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
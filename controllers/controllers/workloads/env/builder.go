@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/ports"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -20,6 +21,7 @@ type VCAPServices map[string][]ServiceDetails
 
 type ServiceDetails struct {
 	Label          string         `json:"label"`
+	Plan           string         `json:"plan,omitempty"`
 	Name           string         `json:"name"`
 	Tags           []string       `json:"tags"`
 	InstanceGUID   string         `json:"instance_guid"`
@@ -32,13 +34,33 @@ type ServiceDetails struct {
 }
 
 type AppEnvBuilder struct {
-	k8sClient client.Client
+	k8sClient       client.Client
+	rootNamespace   string
+	envVarGroupName string
 }
 
-func NewAppEnvBuilder(k8sClient client.Client) *AppEnvBuilder {
-	return &AppEnvBuilder{k8sClient: k8sClient}
+// NewAppEnvBuilder creates a builder for the environment of running app
+// workloads, which includes the running environment variable group
+func NewAppEnvBuilder(k8sClient client.Client, rootNamespace string) *AppEnvBuilder {
+	return &AppEnvBuilder{
+		k8sClient:       k8sClient,
+		rootNamespace:   rootNamespace,
+		envVarGroupName: korifiv1alpha1.RunningEnvVarGroupName,
+	}
+}
+
+// NewStagingAppEnvBuilder creates a builder for the environment of app
+// builds, which includes the staging environment variable group
+func NewStagingAppEnvBuilder(k8sClient client.Client, rootNamespace string) *AppEnvBuilder {
+	return &AppEnvBuilder{
+		k8sClient:       k8sClient,
+		rootNamespace:   rootNamespace,
+		envVarGroupName: korifiv1alpha1.StagingEnvVarGroupName,
+	}
 }
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfenvvargroups,verbs=get;list;watch
+
 func (b *AppEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error) {
 	var appEnvSecret, vcapServicesSecret, vcapApplicationSecret corev1.Secret
 
@@ -64,7 +86,40 @@ func (b *AppEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp)
 	}
 
 	// We explicitly order the vcapServicesSecret last so that its "VCAP_*" contents win
-	return sortEnvVars(envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)), nil
+	envVars := envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)
+
+	groupEnvVars, err := b.envVarGroupEnvVars(ctx, envVars)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortEnvVars(append(groupEnvVars, envVars...)), nil
+}
+
+// envVarGroupEnvVars returns the env vars of the environment variable group
+// that are not overridden by the app env vars, as the group has the lowest
+// precedence. The group lives in the root namespace so its values cannot be
+// referenced from the app namespace and are inlined instead
+func (b *AppEnvBuilder) envVarGroupEnvVars(ctx context.Context, appEnvVars []corev1.EnvVar) ([]corev1.EnvVar, error) {
+	envVarGroup := new(korifiv1alpha1.CFEnvVarGroup)
+	err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: b.rootNamespace, Name: b.envVarGroupName}, envVarGroup)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error when trying to fetch the %s environment variable group: %w", b.envVarGroupName, err)
+	}
+
+	var envVars []corev1.EnvVar
+	for name, value := range envVarGroup.Spec.Var {
+		if slices.ContainsFunc(appEnvVars, func(envVar corev1.EnvVar) bool { return envVar.Name == name }) {
+			continue
+		}
+
+		envVars = append(envVars, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	return envVars, nil
 }
 
 func sortEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
@@ -98,9 +153,9 @@ type ProcessEnvBuilder struct {
 	k8sClient     client.Client
 }
 
-func NewProcessEnvBuilder(k8sClient client.Client) *ProcessEnvBuilder {
+func NewProcessEnvBuilder(k8sClient client.Client, rootNamespace string) *ProcessEnvBuilder {
 	return &ProcessEnvBuilder{
-		appEnvBuilder: NewAppEnvBuilder(k8sClient),
+		appEnvBuilder: NewAppEnvBuilder(k8sClient, rootNamespace),
 		k8sClient:     k8sClient,
 	}
 }
@@ -100,7 +100,7 @@ var _ = Describe("EnvBuilder", func() {
 		var builder *env.AppEnvBuilder
 
 		BeforeEach(func() {
-			builder = env.NewAppEnvBuilder(controllersClient)
+			builder = env.NewAppEnvBuilder(controllersClient, rootNamespace)
 		})
 
 		JustBeforeEach(func() {
@@ -256,6 +256,60 @@ var _ = Describe("EnvBuilder", func() {
 				))
 			})
 		})
+
+		When("environment variable groups exist", func() {
+			BeforeEach(func() {
+				helpers.EnsureCreate(controllersClient, &korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.RunningEnvVarGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+						Var: map[string]string{
+							"HTTP_PROXY": "http://running-proxy",
+							"app-secret": "group-value",
+						},
+					},
+				})
+				helpers.EnsureCreate(controllersClient, &korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.StagingEnvVarGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+						Var: map[string]string{
+							"HTTP_PROXY": "http://staging-proxy",
+						},
+					},
+				})
+			})
+
+			It("includes the running group env vars not overridden by the app", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(envVars).To(ConsistOf(
+					appSecretEnv,
+					vcapServicesEnv,
+					vcapApplicationEnv,
+					Equal(corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://running-proxy"}),
+				))
+			})
+
+			When("building the staging environment", func() {
+				BeforeEach(func() {
+					builder = env.NewStagingAppEnvBuilder(controllersClient, rootNamespace)
+				})
+
+				It("includes the staging group env vars instead", func() {
+					Expect(buildErr).NotTo(HaveOccurred())
+					Expect(envVars).To(ConsistOf(
+						appSecretEnv,
+						vcapServicesEnv,
+						vcapApplicationEnv,
+						Equal(corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://staging-proxy"}),
+					))
+				})
+			})
+		})
 	})
 
 	Describe("ProcessEnvBuilder", func() {
@@ -276,7 +330,7 @@ var _ = Describe("EnvBuilder", func() {
 				},
 			}
 			helpers.EnsureCreate(controllersClient, cfProcess)
-			builder = env.NewProcessEnvBuilder(controllersClient)
+			builder = env.NewProcessEnvBuilder(controllersClient, rootNamespace)
 		})
 
 		JustBeforeEach(func() {
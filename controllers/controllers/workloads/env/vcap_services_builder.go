@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 
@@ -18,11 +19,15 @@ import (
 const UserProvided = "user-provided"
 
 type VCAPServicesEnvValueBuilder struct {
-	k8sClient client.Client
+	k8sClient     client.Client
+	rootNamespace string
 }
 
-func NewVCAPServicesEnvValueBuilder(k8sClient client.Client) *VCAPServicesEnvValueBuilder {
-	return &VCAPServicesEnvValueBuilder{k8sClient: k8sClient}
+func NewVCAPServicesEnvValueBuilder(k8sClient client.Client, rootNamespace string) *VCAPServicesEnvValueBuilder {
+	return &VCAPServicesEnvValueBuilder{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+	}
 }
 
 func (b *VCAPServicesEnvValueBuilder) BuildEnvValue(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (map[string][]byte, error) {
@@ -48,7 +53,7 @@ func (b *VCAPServicesEnvValueBuilder) BuildEnvValue(ctx context.Context, cfApp *
 
 		var serviceEnv ServiceDetails
 		var serviceLabel string
-		serviceEnv, serviceLabel, err = buildSingleServiceEnv(ctx, b.k8sClient, currentServiceBinding)
+		serviceEnv, serviceLabel, err = b.buildSingleServiceEnv(ctx, currentServiceBinding)
 		if err != nil {
 			return nil, err
 		}
@@ -66,7 +71,7 @@ func (b *VCAPServicesEnvValueBuilder) BuildEnvValue(ctx context.Context, cfApp *
 	}, nil
 }
 
-func buildSingleServiceEnv(ctx context.Context, k8sClient client.Client, serviceBinding korifiv1alpha1.CFServiceBinding) (ServiceDetails, string, error) {
+func (b *VCAPServicesEnvValueBuilder) buildSingleServiceEnv(ctx context.Context, serviceBinding korifiv1alpha1.CFServiceBinding) (ServiceDetails, string, error) {
 	if serviceBinding.Status.Credentials.Name == "" {
 		return ServiceDetails{}, "", fmt.Errorf("credentials secret name not set for service binding %q", serviceBinding.Name)
 	}
@@ -74,7 +79,7 @@ func buildSingleServiceEnv(ctx context.Context, k8sClient client.Client, service
 	serviceLabel := UserProvided
 
 	serviceInstance := korifiv1alpha1.CFServiceInstance{}
-	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: serviceBinding.Spec.Service.Name}, &serviceInstance)
+	err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: serviceBinding.Spec.Service.Name}, &serviceInstance)
 	if err != nil {
 		return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceInstance: %w", err)
 	}
@@ -85,16 +90,27 @@ func buildSingleServiceEnv(ctx context.Context, k8sClient client.Client, service
 			Name:      serviceBinding.Status.Credentials.Name,
 		},
 	}
-	err = k8sClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)
+	err = b.k8sClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)
 	if err != nil {
 		return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceBinding Secret: %w", err)
 	}
 
-	if serviceInstance.Spec.ServiceLabel != nil && *serviceInstance.Spec.ServiceLabel != "" {
+	var servicePlan string
+	if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		// managed services are labelled with the name of their offering, as in CF
+		var brokerResources osbapi.BrokerResources
+		brokerResources, err = osbapi.GetBrokerResources(ctx, b.k8sClient, b.rootNamespace, &serviceInstance)
+		if err != nil {
+			return ServiceDetails{}, "", fmt.Errorf("error fetching service offering and plan of CFServiceInstance %q: %w", serviceInstance.Name, err)
+		}
+
+		serviceLabel = brokerResources.Offering.Spec.Name
+		servicePlan = brokerResources.Plan.Spec.Name
+	} else if serviceInstance.Spec.ServiceLabel != nil && *serviceInstance.Spec.ServiceLabel != "" {
 		serviceLabel = *serviceInstance.Spec.ServiceLabel
 	}
 
-	serviceDetails, err := fromServiceBinding(serviceBinding, serviceInstance, credentialsSecret, serviceLabel)
+	serviceDetails, err := fromServiceBinding(serviceBinding, serviceInstance, credentialsSecret, serviceLabel, servicePlan)
 	if err != nil {
 		return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceBinding details: %w", err)
 	}
@@ -107,6 +123,7 @@ func fromServiceBinding(
 	serviceInstance korifiv1alpha1.CFServiceInstance,
 	credentialsSecret *corev1.Secret,
 	serviceLabel string,
+	servicePlan string,
 ) (ServiceDetails, error) {
 	var serviceName string
 	var bindingName *string
@@ -131,6 +148,7 @@ func fromServiceBinding(
 
 	return ServiceDetails{
 		Label:          serviceLabel,
+		Plan:           servicePlan,
 		Name:           serviceName,
 		Tags:           tags,
 		InstanceGUID:   serviceInstance.Name,
@@ -5,6 +5,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
+	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 	"code.cloudfoundry.org/korifi/tools"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,7 +28,7 @@ var _ = Describe("Builder", func() {
 	)
 
 	BeforeEach(func() {
-		builder = env.NewVCAPServicesEnvValueBuilder(controllersClient)
+		builder = env.NewVCAPServicesEnvValueBuilder(controllersClient, rootNamespace)
 
 		serviceInstance = &korifiv1alpha1.CFServiceInstance{
 			ObjectMeta: metav1.ObjectMeta{
@@ -244,6 +245,101 @@ var _ = Describe("Builder", func() {
 			})
 		})
 
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				broker := &korifiv1alpha1.CFServiceBroker{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFServiceBrokerSpec{
+						ServiceBroker: services.ServiceBroker{
+							Name: uuid.NewString(),
+							URL:  "https://my.broker",
+						},
+						Credentials: corev1.LocalObjectReference{Name: "broker-credentials"},
+					},
+				}
+				helpers.EnsureCreate(controllersClient, broker)
+
+				offering := &korifiv1alpha1.CFServiceOffering{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      uuid.NewString(),
+						Labels: map[string]string{
+							korifiv1alpha1.RelServiceBrokerLabel: broker.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFServiceOfferingSpec{
+						ServiceOffering: services.ServiceOffering{
+							Name: "my-offering",
+						},
+					},
+				}
+				helpers.EnsureCreate(controllersClient, offering)
+
+				plan := &korifiv1alpha1.CFServicePlan{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      uuid.NewString(),
+						Labels: map[string]string{
+							korifiv1alpha1.RelServiceBrokerLabel:   broker.Name,
+							korifiv1alpha1.RelServiceOfferingLabel: offering.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFServicePlanSpec{
+						ServicePlan: services.ServicePlan{
+							BrokerServicePlan: services.BrokerServicePlan{
+								Name: "my-plan",
+							},
+						},
+					},
+				}
+				helpers.EnsureCreate(controllersClient, plan)
+
+				helpers.EnsurePatch(controllersClient, serviceInstance, func(s *korifiv1alpha1.CFServiceInstance) {
+					s.Spec.Type = korifiv1alpha1.ManagedType
+					s.Spec.PlanGUID = plan.Name
+				})
+			})
+
+			It("labels the service with the offering name and sets the plan", func() {
+				Expect(buildVCAPServicesEnvValueErr).NotTo(HaveOccurred())
+				Expect(parseVcapServices(vcapServices)).To(MatchAllKeys(Keys{
+					"my-offering": ConsistOf(MatchAllKeys(Keys{
+						"label":         Equal("my-offering"),
+						"plan":          Equal("my-plan"),
+						"name":          Equal("my-service-binding"),
+						"tags":          ConsistOf("t1", "t2"),
+						"instance_guid": Equal("my-service-instance-guid"),
+						"instance_name": Equal("my-service-instance"),
+						"binding_guid":  Equal("my-service-binding-guid"),
+						"binding_name":  Equal("my-service-binding"),
+						"credentials": MatchAllKeys(Keys{
+							"foo": Equal("bar"),
+						}),
+						"syslog_drain_url": BeNil(),
+						"volume_mounts":    BeEmpty(),
+					})),
+					"custom-service-2": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"label": Equal("custom-service-2"),
+					})),
+				}))
+			})
+
+			When("the service plan does not exist", func() {
+				BeforeEach(func() {
+					helpers.EnsurePatch(controllersClient, serviceInstance, func(s *korifiv1alpha1.CFServiceInstance) {
+						s.Spec.PlanGUID = "does-not-exist"
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(buildVCAPServicesEnvValueErr).To(MatchError(ContainSubstring("error fetching service offering and plan")))
+				})
+			})
+		})
+
 		When("there are no service bindings for the app", func() {
 			BeforeEach(func() {
 				Expect(adminClient.DeleteAllOf(ctx, &korifiv1alpha1.CFServiceBinding{}, client.InNamespace(cfSpace.Status.GUID))).To(Succeed())
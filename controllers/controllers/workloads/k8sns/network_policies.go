@@ -0,0 +1,15 @@
+package k8sns
+
+import (
+	"context"
+)
+
+type NetworkPolicyReconciler[T any, NS NamespaceObject[T]] interface {
+	ReconcileNetworkPolicies(ctx context.Context, obj NS) error
+}
+
+type NoopNetworkPolicyReconciler[T any, NS NamespaceObject[T]] struct{}
+
+func (r *NoopNetworkPolicyReconciler[T, NS]) ReconcileNetworkPolicies(ctx context.Context, obj NS) error {
+	return nil
+}
@@ -52,6 +52,7 @@ type MetadataCompiler[T any, NS NamespaceObject[T]] interface {
 type Reconciler[T any, NS NamespaceObject[T]] struct {
 	client                       client.Client
 	finalizer                    Finalizer[T, NS]
+	networkPolicyReconciler      NetworkPolicyReconciler[T, NS]
 	containerRegistrySecretNames []string
 	metadataCompiler             MetadataCompiler[T, NS]
 }
@@ -59,12 +60,14 @@ type Reconciler[T any, NS NamespaceObject[T]] struct {
 func NewReconciler[T any, NS NamespaceObject[T]](
 	client client.Client,
 	finalizer Finalizer[T, NS],
+	networkPolicyReconciler NetworkPolicyReconciler[T, NS],
 	metadataCompiler MetadataCompiler[T, NS],
 	containerRegistrySecretNames []string,
 ) *Reconciler[T, NS] {
 	return &Reconciler[T, NS]{
 		client:                       client,
 		finalizer:                    finalizer,
+		networkPolicyReconciler:      networkPolicyReconciler,
 		metadataCompiler:             metadataCompiler,
 		containerRegistrySecretNames: containerRegistrySecretNames,
 	}
@@ -104,6 +107,11 @@ func (r *Reconciler[T, NS]) ReconcileResource(ctx context.Context, obj NS) (ctrl
 		return r.setNotReady(log, obj, fmt.Errorf("error propagating role-bindings: %w", err), "RoleBindingPropagation")
 	}
 
+	err = r.networkPolicyReconciler.ReconcileNetworkPolicies(ctx, obj)
+	if err != nil {
+		return r.setNotReady(log, obj, fmt.Errorf("error reconciling network policies: %w", err), "NetworkPolicies")
+	}
+
 	return ctrl.Result{}, nil
 }
 
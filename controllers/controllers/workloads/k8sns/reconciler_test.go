@@ -45,9 +45,10 @@ var _ = Describe("K8S NS Reconciler Integration Tests", func() {
 		orgGUID string
 		nsObj   *korifiv1alpha1.CFOrg
 
-		reconciler       *k8sns.Reconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]
-		finalizer        *mockFinalizer[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]
-		metadataCompiler *mockMetadataCompiler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]
+		reconciler              *k8sns.Reconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]
+		finalizer               *mockFinalizer[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]
+		networkPolicyReconciler *mockNetworkPolicyReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]
+		metadataCompiler        *mockMetadataCompiler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]
 
 		result       ctrl.Result
 		reconcileErr error
@@ -57,6 +58,7 @@ var _ = Describe("K8S NS Reconciler Integration Tests", func() {
 		createNamespace(rootNamespace)
 
 		finalizer = &mockFinalizer[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]{}
+		networkPolicyReconciler = &mockNetworkPolicyReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]{}
 		metadataCompiler = &mockMetadataCompiler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]{
 			processedObjects: map[*korifiv1alpha1.CFOrg]any{},
 			labels: map[string]string{
@@ -66,7 +68,7 @@ var _ = Describe("K8S NS Reconciler Integration Tests", func() {
 				"org-annotation": "org-annotation-value",
 			},
 		}
-		reconciler = k8sns.NewReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg](controllersClient, finalizer, metadataCompiler, []string{})
+		reconciler = k8sns.NewReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg](controllersClient, finalizer, networkPolicyReconciler, metadataCompiler, []string{})
 
 		orgGUID = uuid.NewString()
 		nsObj = &korifiv1alpha1.CFOrg{
@@ -151,7 +153,7 @@ var _ = Describe("K8S NS Reconciler Integration Tests", func() {
 
 		BeforeEach(func() {
 			imageRegistrySecret = createImageRegistrySecret(ctx, "container-registry-secret", rootNamespace)
-			reconciler = k8sns.NewReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg](controllersClient, finalizer, metadataCompiler, []string{imageRegistrySecret.Name})
+			reconciler = k8sns.NewReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg](controllersClient, finalizer, networkPolicyReconciler, metadataCompiler, []string{imageRegistrySecret.Name})
 		})
 
 		It("propagates the image-registry-credentials secrets from root-ns to the underlying namespace", func() {
@@ -171,7 +173,7 @@ var _ = Describe("K8S NS Reconciler Integration Tests", func() {
 
 		When("the image-registry-credentials secret does not exist in the root-ns", Serial, func() {
 			BeforeEach(func() {
-				reconciler = k8sns.NewReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg](controllersClient, finalizer, metadataCompiler, []string{"i-do-not-exist"})
+				reconciler = k8sns.NewReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg](controllersClient, finalizer, networkPolicyReconciler, metadataCompiler, []string{"i-do-not-exist"})
 			})
 
 			It("sets the NSObj's Ready condition to 'False'", func() {
@@ -296,6 +298,28 @@ var _ = Describe("K8S NS Reconciler Integration Tests", func() {
 		})
 	})
 
+	Describe("network policies", func() {
+		It("reconciles the network policies of the NSObj", func() {
+			expectToHaveSucceeded()
+			Expect(networkPolicyReconciler.reconciledObjects).To(ConsistOf(nsObj))
+		})
+
+		When("reconciling the network policies fails", func() {
+			BeforeEach(func() {
+				networkPolicyReconciler.reconcileErr = errors.New("network-policies-err")
+			})
+
+			It("sets the NSObj's Ready condition to 'False'", func() {
+				Expect(reconcileErr).To(MatchError(ContainSubstring("network-policies-err")))
+
+				readyCondition := meta.FindStatusCondition(nsObj.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+				Expect(readyCondition).NotTo(BeNil())
+				Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(readyCondition.Reason).To(Equal("NetworkPolicies"))
+			})
+		})
+	})
+
 	Describe("deletion", func() {
 		BeforeEach(func() {
 			nsObj.DeletionTimestamp = tools.PtrTo(metav1.Now())
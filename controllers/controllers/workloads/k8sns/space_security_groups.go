@@ -0,0 +1,203 @@
+package k8sns
+
+import (
+	"context"
+	"sort"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/securitygroups"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	RunningSecurityGroupsPolicyName = "korifi-running-security-groups"
+	StagingSecurityGroupsPolicyName = "korifi-staging-security-groups"
+
+	buildWorkloadLabelKey = "korifi.cloudfoundry.org/build-workload-name"
+)
+
+type SpaceSecurityGroupsReconciler struct {
+	client        client.Client
+	rootNamespace string
+}
+
+func NewSpaceSecurityGroupsReconciler(
+	client client.Client,
+	rootNamespace string,
+) *SpaceSecurityGroupsReconciler {
+	return &SpaceSecurityGroupsReconciler{
+		client:        client,
+		rootNamespace: rootNamespace,
+	}
+}
+
+// ReconcileNetworkPolicies compiles the rules of the security groups applying
+// to the space into egress NetworkPolicies in the space namespace, one
+// selecting the running app pods and one selecting the staging build pods.
+// As in CF, once a security group applies to a pod only the egress traffic
+// allowed by a rule is let through. Pods no security group applies to are not
+// restricted.
+func (r *SpaceSecurityGroupsReconciler) ReconcileNetworkPolicies(ctx context.Context, cfSpace *korifiv1alpha1.CFSpace) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileNetworkPolicies")
+
+	securityGroups := new(korifiv1alpha1.CFSecurityGroupList)
+	err := r.client.List(ctx, securityGroups, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		log.Info("error listing security groups", "reason", err)
+		return err
+	}
+
+	sort.Slice(securityGroups.Items, func(i, j int) bool {
+		return securityGroups.Items[i].Name < securityGroups.Items[j].Name
+	})
+
+	var runningRules, stagingRules []networkingv1.NetworkPolicyEgressRule
+	var runningEnabled, stagingEnabled bool
+	for i := range securityGroups.Items {
+		securityGroup := &securityGroups.Items[i]
+		appliesTo := securityGroup.AppliesTo(cfSpace.Name)
+		if !appliesTo.Running && !appliesTo.Staging {
+			continue
+		}
+
+		rules := compileEgressRules(log.WithValues("securityGroup", securityGroup.Name), securityGroup.Spec.Rules)
+		if appliesTo.Running {
+			runningEnabled = true
+			runningRules = append(runningRules, rules...)
+		}
+		if appliesTo.Staging {
+			stagingEnabled = true
+			stagingRules = append(stagingRules, rules...)
+		}
+	}
+
+	err = r.reconcileNetworkPolicy(ctx, cfSpace.Name, RunningSecurityGroupsPolicyName, metav1.LabelSelectorOpDoesNotExist, runningEnabled, runningRules)
+	if err != nil {
+		return err
+	}
+
+	return r.reconcileNetworkPolicy(ctx, cfSpace.Name, StagingSecurityGroupsPolicyName, metav1.LabelSelectorOpExists, stagingEnabled, stagingRules)
+}
+
+// reconcileNetworkPolicy creates or patches the policy selecting the app pods
+// whose build workload label matches buildWorkloadOp. The policy is deleted
+// when no security group applies
+func (r *SpaceSecurityGroupsReconciler) reconcileNetworkPolicy(
+	ctx context.Context,
+	namespace string,
+	name string,
+	buildWorkloadOp metav1.LabelSelectorOperator,
+	enabled bool,
+	egress []networkingv1.NetworkPolicyEgressRule,
+) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileNetworkPolicy").WithValues("name", name)
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	if !enabled {
+		return client.IgnoreNotFound(r.client.Delete(ctx, networkPolicy))
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: korifiv1alpha1.CFAppGUIDLabelKey, Operator: metav1.LabelSelectorOpExists},
+					{Key: buildWorkloadLabelKey, Operator: buildWorkloadOp},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      egress,
+		}
+		return nil
+	})
+	if err != nil {
+		log.Info("error creating/patching network policy", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("network policy reconciled", "operation", result)
+	return nil
+}
+
+// compileEgressRules translates security group rules into NetworkPolicy
+// egress rules. ICMP rules cannot be expressed by NetworkPolicies and are
+// skipped, as are rules that cannot be parsed
+func compileEgressRules(log logr.Logger, rules []korifiv1alpha1.SecurityGroupRule) []networkingv1.NetworkPolicyEgressRule {
+	var egressRules []networkingv1.NetworkPolicyEgressRule
+	for _, rule := range rules {
+		if rule.Protocol == korifiv1alpha1.SecurityGroupProtocolICMP {
+			log.V(1).Info("skipping icmp rule", "destination", rule.Destination)
+			continue
+		}
+
+		cidrs, err := securitygroups.ParseDestination(rule.Destination)
+		if err != nil {
+			log.Info("skipping rule with invalid destination", "reason", err)
+			continue
+		}
+
+		ports, err := compilePorts(rule)
+		if err != nil {
+			log.Info("skipping rule with invalid ports", "reason", err)
+			continue
+		}
+
+		egressRule := networkingv1.NetworkPolicyEgressRule{Ports: ports}
+		for _, cidr := range cidrs {
+			egressRule.To = append(egressRule.To, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+			})
+		}
+		egressRules = append(egressRules, egressRule)
+	}
+
+	return egressRules
+}
+
+func compilePorts(rule korifiv1alpha1.SecurityGroupRule) ([]networkingv1.NetworkPolicyPort, error) {
+	if rule.Protocol == korifiv1alpha1.SecurityGroupProtocolAll {
+		return nil, nil
+	}
+
+	protocol := corev1.ProtocolTCP
+	if rule.Protocol == korifiv1alpha1.SecurityGroupProtocolUDP {
+		protocol = corev1.ProtocolUDP
+	}
+
+	if rule.Ports == "" {
+		return []networkingv1.NetworkPolicyPort{{Protocol: &protocol}}, nil
+	}
+
+	portRanges, err := securitygroups.ParsePorts(rule.Ports)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []networkingv1.NetworkPolicyPort
+	for _, portRange := range portRanges {
+		port := networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: portRange.Start},
+		}
+		if portRange.End != portRange.Start {
+			port.EndPort = tools.PtrTo(portRange.End)
+		}
+		ports = append(ports, port)
+	}
+
+	return ports, nil
+}
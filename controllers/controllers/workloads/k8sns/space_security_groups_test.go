@@ -0,0 +1,185 @@
+package k8sns_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/k8sns"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("SpaceSecurityGroupsReconciler", func() {
+	var (
+		securityGroupsReconciler *k8sns.SpaceSecurityGroupsReconciler
+		securityGroupsNamespace  string
+		cfSpace                  *korifiv1alpha1.CFSpace
+		securityGroup            *korifiv1alpha1.CFSecurityGroup
+
+		reconcileErr error
+	)
+
+	getNetworkPolicy := func(name string) (*networkingv1.NetworkPolicy, error) {
+		networkPolicy := new(networkingv1.NetworkPolicy)
+		err := controllersClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: name}, networkPolicy)
+		return networkPolicy, err
+	}
+
+	BeforeEach(func() {
+		securityGroupsNamespace = uuid.NewString()
+		createNamespace(securityGroupsNamespace)
+
+		cfSpace = &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+		}
+		createNamespace(cfSpace.Name)
+
+		securityGroup = &korifiv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: securityGroupsNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFSecurityGroupSpec{
+				DisplayName: "my-security-group",
+				Rules: []korifiv1alpha1.SecurityGroupRule{
+					{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "443"},
+					{Protocol: "udp", Destination: "10.0.0.1-10.0.0.2", Ports: "8000-9000"},
+					{Protocol: "all", Destination: "192.168.0.1"},
+					{Protocol: "icmp", Destination: "0.0.0.0/0", Type: tools.PtrTo[int32](0), Code: tools.PtrTo[int32](0)},
+				},
+			},
+		}
+
+		securityGroupsReconciler = k8sns.NewSpaceSecurityGroupsReconciler(controllersClient, securityGroupsNamespace)
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, securityGroup)).To(Succeed())
+		reconcileErr = securityGroupsReconciler.ReconcileNetworkPolicies(ctx, cfSpace)
+	})
+
+	It("does not create any network policies", func() {
+		Expect(reconcileErr).NotTo(HaveOccurred())
+
+		_, err := getNetworkPolicy(k8sns.RunningSecurityGroupsPolicyName)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		_, err = getNetworkPolicy(k8sns.StagingSecurityGroupsPolicyName)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	When("the security group is bound to the space for running", func() {
+		BeforeEach(func() {
+			securityGroup.Spec.Spaces = map[string]korifiv1alpha1.SecurityGroupWorkloads{
+				cfSpace.Name: {Running: true},
+			}
+		})
+
+		It("creates a network policy selecting the running app pods", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+
+			networkPolicy, err := getNetworkPolicy(k8sns.RunningSecurityGroupsPolicyName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networkPolicy.Spec.PodSelector.MatchExpressions).To(ConsistOf(
+				metav1.LabelSelectorRequirement{Key: korifiv1alpha1.CFAppGUIDLabelKey, Operator: metav1.LabelSelectorOpExists},
+				metav1.LabelSelectorRequirement{Key: "korifi.cloudfoundry.org/build-workload-name", Operator: metav1.LabelSelectorOpDoesNotExist},
+			))
+			Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+		})
+
+		It("compiles the security group rules into egress rules", func() {
+			networkPolicy, err := getNetworkPolicy(k8sns.RunningSecurityGroupsPolicyName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networkPolicy.Spec.Egress).To(Equal([]networkingv1.NetworkPolicyEgressRule{
+				{
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}},
+					Ports: []networkingv1.NetworkPolicyPort{{
+						Protocol: tools.PtrTo(corev1.ProtocolTCP),
+						Port:     tools.PtrTo(intstr.FromInt32(443)),
+					}},
+				},
+				{
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}}, {IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.2/32"}}},
+					Ports: []networkingv1.NetworkPolicyPort{{
+						Protocol: tools.PtrTo(corev1.ProtocolUDP),
+						Port:     tools.PtrTo(intstr.FromInt32(8000)),
+						EndPort:  tools.PtrTo[int32](9000),
+					}},
+				},
+				{
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.1/32"}}},
+				},
+			}))
+		})
+
+		It("does not create the staging network policy", func() {
+			_, err := getNetworkPolicy(k8sns.StagingSecurityGroupsPolicyName)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		When("the security group has no rules that can be compiled", func() {
+			BeforeEach(func() {
+				securityGroup.Spec.Rules = []korifiv1alpha1.SecurityGroupRule{
+					{Protocol: "icmp", Destination: "0.0.0.0/0"},
+					{Protocol: "tcp", Destination: "not-an-ip"},
+				}
+			})
+
+			It("creates a network policy denying all egress", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+
+				networkPolicy, err := getNetworkPolicy(k8sns.RunningSecurityGroupsPolicyName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+				Expect(networkPolicy.Spec.Egress).To(BeEmpty())
+			})
+		})
+
+		When("the security group is no longer bound to the space", func() {
+			JustBeforeEach(func() {
+				Expect(adminClient.Delete(ctx, securityGroup)).To(Succeed())
+				Expect(securityGroupsReconciler.ReconcileNetworkPolicies(ctx, cfSpace)).To(Succeed())
+			})
+
+			It("deletes the network policy", func() {
+				_, err := getNetworkPolicy(k8sns.RunningSecurityGroupsPolicyName)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	When("the security group is globally enabled for staging", func() {
+		BeforeEach(func() {
+			securityGroup.Spec.GloballyEnabled.Staging = true
+		})
+
+		It("creates a network policy selecting the build pods", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+
+			networkPolicy, err := getNetworkPolicy(k8sns.StagingSecurityGroupsPolicyName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networkPolicy.Spec.PodSelector.MatchExpressions).To(ConsistOf(
+				metav1.LabelSelectorRequirement{Key: korifiv1alpha1.CFAppGUIDLabelKey, Operator: metav1.LabelSelectorOpExists},
+				metav1.LabelSelectorRequirement{Key: "korifi.cloudfoundry.org/build-workload-name", Operator: metav1.LabelSelectorOpExists},
+			))
+			Expect(networkPolicy.Spec.Egress).To(HaveLen(3))
+			Expect(networkPolicy.Spec.Egress[0]).To(MatchFields(IgnoreExtras, Fields{
+				"To": ConsistOf(networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}),
+			}))
+		})
+
+		It("does not create the running network policy", func() {
+			_, err := getNetworkPolicy(k8sns.RunningSecurityGroupsPolicyName)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -94,6 +94,16 @@ func (f *mockFinalizer[T, NS]) Finalize(ctx context.Context, obj NS) (ctrl.Resul
 	return f.result, f.finalizeErr
 }
 
+type mockNetworkPolicyReconciler[T any, NS k8sns.NamespaceObject[T]] struct {
+	reconciledObjects []NS
+	reconcileErr      error
+}
+
+func (r *mockNetworkPolicyReconciler[T, NS]) ReconcileNetworkPolicies(ctx context.Context, obj NS) error {
+	r.reconciledObjects = append(r.reconciledObjects, obj)
+	return r.reconcileErr
+}
+
 func createNamespace(name string) *corev1.Namespace {
 	GinkgoHelper()
 
@@ -0,0 +1,62 @@
+package placement
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Placement describes the nodes the instances of a workload are scheduled on
+type Placement struct {
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
+}
+
+type Resolver struct {
+	k8sClient     client.Client
+	rootNamespace string
+}
+
+// NewResolver creates a resolver for the placement of the workloads in a
+// space namespace, as defined by the isolation segment assigned to the space
+func NewResolver(k8sClient client.Client, rootNamespace string) *Resolver {
+	return &Resolver{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfisolationsegments,verbs=get;list;watch
+
+// Resolve returns the placement of the workloads in the space namespace. The
+// placement is empty when the space has no isolation segment, i.e. workloads
+// run on the shared nodes
+func (r *Resolver) Resolve(ctx context.Context, spaceNamespace string) (Placement, error) {
+	namespace := new(corev1.Namespace)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: spaceNamespace}, namespace)
+	if err != nil {
+		return Placement{}, fmt.Errorf("error when trying to fetch namespace %s: %w", spaceNamespace, err)
+	}
+
+	isolationSegmentGUID := namespace.Labels[korifiv1alpha1.IsolationSegmentGUIDLabelKey]
+	if isolationSegmentGUID == "" {
+		return Placement{}, nil
+	}
+
+	isolationSegment := new(korifiv1alpha1.CFIsolationSegment)
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Namespace: r.rootNamespace, Name: isolationSegmentGUID}, isolationSegment)
+	if err != nil {
+		return Placement{}, fmt.Errorf("error when trying to fetch isolation segment %s/%s: %w", r.rootNamespace, isolationSegmentGUID, err)
+	}
+
+	return Placement{
+		NodeSelector: isolationSegment.Spec.NodeSelector,
+		Tolerations:  isolationSegment.Spec.Tolerations,
+	}, nil
+}
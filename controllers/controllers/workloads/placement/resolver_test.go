@@ -0,0 +1,106 @@
+package placement_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Resolver", func() {
+	var (
+		rootNamespace    string
+		spaceNamespace   *corev1.Namespace
+		isolationSegment *korifiv1alpha1.CFIsolationSegment
+		resolver         *placement.Resolver
+	)
+
+	BeforeEach(func() {
+		rootNamespace = uuid.NewString()
+		helpers.EnsureCreate(adminClient, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: rootNamespace},
+		})
+
+		isolationSegment = &korifiv1alpha1.CFIsolationSegment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+				DisplayName:  "regulated",
+				NodeSelector: map[string]string{"pool": "regulated"},
+				Tolerations: []corev1.Toleration{{
+					Key:      "pool",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "regulated",
+					Effect:   corev1.TaintEffectNoSchedule,
+				}},
+			},
+		}
+		helpers.EnsureCreate(adminClient, isolationSegment)
+
+		spaceNamespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: uuid.NewString(),
+			},
+		}
+		helpers.EnsureCreate(adminClient, spaceNamespace)
+
+		resolver = placement.NewResolver(adminClient, rootNamespace)
+	})
+
+	It("returns an empty placement", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(resolver.Resolve(ctx, spaceNamespace.Name)).To(Equal(placement.Placement{}))
+		}).Should(Succeed())
+	})
+
+	When("the space has an isolation segment", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, spaceNamespace, func(ns *corev1.Namespace) {
+				ns.Labels = map[string]string{
+					korifiv1alpha1.IsolationSegmentGUIDLabelKey: isolationSegment.Name,
+				}
+			})
+		})
+
+		It("returns the placement of the isolation segment", func() {
+			Eventually(func(g Gomega) {
+				p, err := resolver.Resolve(ctx, spaceNamespace.Name)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(p.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+				g.Expect(p.Tolerations).To(ConsistOf(corev1.Toleration{
+					Key:      "pool",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "regulated",
+					Effect:   corev1.TaintEffectNoSchedule,
+				}))
+			}).Should(Succeed())
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				Expect(adminClient.Delete(ctx, isolationSegment)).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				Eventually(func(g Gomega) {
+					_, err := resolver.Resolve(ctx, spaceNamespace.Name)
+					g.Expect(err).To(MatchError(ContainSubstring("isolation segment")))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the space namespace does not exist", func() {
+		It("returns an error", func() {
+			_, err := resolver.Resolve(ctx, "not-a-namespace")
+			Expect(err).To(MatchError(ContainSubstring("namespace")))
+		})
+	})
+})
@@ -21,10 +21,12 @@ import (
 	"crypto/sha1"
 	"errors"
 	"fmt"
+	"slices"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/ports"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -40,7 +42,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -48,12 +52,17 @@ type ProcessEnvBuilder interface {
 	Build(context.Context, *korifiv1alpha1.CFApp, *korifiv1alpha1.CFProcess) ([]corev1.EnvVar, error)
 }
 
+type PlacementResolver interface {
+	Resolve(context.Context, string) (placement.Placement, error)
+}
+
 type Reconciler struct {
-	k8sClient        client.Client
-	scheme           *runtime.Scheme
-	log              logr.Logger
-	controllerConfig *config.ControllerConfig
-	envBuilder       ProcessEnvBuilder
+	k8sClient         client.Client
+	scheme            *runtime.Scheme
+	log               logr.Logger
+	controllerConfig  *config.ControllerConfig
+	envBuilder        ProcessEnvBuilder
+	placementResolver PlacementResolver
 }
 
 func NewReconciler(
@@ -62,8 +71,9 @@ func NewReconciler(
 	log logr.Logger,
 	controllerConfig *config.ControllerConfig,
 	envBuilder ProcessEnvBuilder,
+	placementResolver PlacementResolver,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFProcess, *korifiv1alpha1.CFProcess] {
-	processReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder}
+	processReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder, placementResolver: placementResolver}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFProcess, *korifiv1alpha1.CFProcess](log, client, &processReconciler)
 }
 
@@ -78,9 +88,32 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFRoute{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForRoute),
+		).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForNamespace),
+			builder.WithPredicates(isolationSegmentLabelChanged()),
+		).
+		Watches(
+			&korifiv1alpha1.CFIsolationSegment{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForIsolationSegment),
 		)
 }
 
+// isolationSegmentLabelChanged only lets through namespace updates that assign
+// the space to another isolation segment, as other namespace events do not
+// affect the placement of the processes in it
+func isolationSegmentLabelChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetLabels()[korifiv1alpha1.IsolationSegmentGUIDLabelKey] != e.ObjectNew.GetLabels()[korifiv1alpha1.IsolationSegmentGUIDLabelKey]
+		},
+	}
+}
+
 func (r *Reconciler) enqueueCFProcessRequestsForApp(ctx context.Context, o client.Object) []reconcile.Request {
 	return r.cfProcessRequestsForAppGUID(ctx, o.GetNamespace(), o.GetName())
 }
@@ -116,6 +149,44 @@ func (r *Reconciler) enqueueCFProcessRequestsForRoute(ctx context.Context, o cli
 	return result
 }
 
+// enqueueCFProcessRequestsForNamespace reconciles the processes in a space
+// namespace, so that they follow the isolation segment assigned to the space
+func (r *Reconciler) enqueueCFProcessRequestsForNamespace(ctx context.Context, o client.Object) []reconcile.Request {
+	return r.cfProcessRequestsInNamespace(ctx, o.GetName())
+}
+
+func (r *Reconciler) enqueueCFProcessRequestsForIsolationSegment(ctx context.Context, o client.Object) []reconcile.Request {
+	namespaceList := &corev1.NamespaceList{}
+	err := r.k8sClient.List(ctx, namespaceList, client.MatchingLabels{korifiv1alpha1.IsolationSegmentGUIDLabelKey: o.GetName()})
+	if err != nil {
+		r.log.Error(fmt.Errorf("listing namespaces for isolation segment failed: %w", err), "isolationSegmentGUID", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, namespace := range namespaceList.Items {
+		requests = append(requests, r.cfProcessRequestsInNamespace(ctx, namespace.Name)...)
+	}
+
+	return requests
+}
+
+func (r *Reconciler) cfProcessRequestsInNamespace(ctx context.Context, namespace string) []reconcile.Request {
+	processList := &korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, processList, client.InNamespace(namespace))
+	if err != nil {
+		r.log.Error(fmt.Errorf("listing CFProcesses in namespace failed: %w", err), "namespace", namespace)
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range processList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&processList.Items[i])})
+	}
+
+	return requests
+}
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/finalizers,verbs=update
@@ -165,9 +236,16 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+
+		if cfApp.Spec.Canary != nil {
+			err = r.createOrPatchCanaryAppWorkload(ctx, cfApp, cfProcess, cfLastStopAppRev)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 	}
 
-	err = r.cleanUpAppWorkloads(ctx, cfProcess, cfApp.Spec.DesiredState, cfLastStopAppRev)
+	err = r.cleanUpAppWorkloads(ctx, cfProcess, cfApp, cfLastStopAppRev)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -201,17 +279,41 @@ func needsAppWorkload(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFP
 }
 
 func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, cfAppRev, cfLastStopAppRev string) error {
-	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchAppWorkload")
+	actualAppWorkload := &korifiv1alpha1.AppWorkload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfProcess.Namespace,
+			Name:      generateAppWorkloadName(cfLastStopAppRev, cfProcess.Name),
+		},
+	}
+
+	return r.createOrPatchAppWorkloadForDroplet(ctx, actualAppWorkload, cfApp, cfProcess, cfApp.Spec.CurrentDropletRef.Name, cfAppRev, cfLastStopAppRev)
+}
+
+// createOrPatchCanaryAppWorkload runs the canary revision of the app next to
+// the current one, while a canary deployment is paused
+func (r *Reconciler) createOrPatchCanaryAppWorkload(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, cfLastStopAppRev string) error {
+	actualAppWorkload := &korifiv1alpha1.AppWorkload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfProcess.Namespace,
+			Name:      generateCanaryAppWorkloadName(cfApp.Spec.Canary.Revision, cfProcess.Name),
+		},
+	}
+
+	return r.createOrPatchAppWorkloadForDroplet(ctx, actualAppWorkload, cfApp, cfProcess, cfApp.Spec.Canary.DropletRef.Name, cfApp.Spec.Canary.Revision, cfLastStopAppRev)
+}
+
+func (r *Reconciler) createOrPatchAppWorkloadForDroplet(ctx context.Context, actualAppWorkload *korifiv1alpha1.AppWorkload, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, dropletName, cfAppRev, cfLastStopAppRev string) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchAppWorkload").WithValues("appWorkloadName", actualAppWorkload.Name)
 
 	cfBuild := new(korifiv1alpha1.CFBuild)
-	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfApp.Spec.CurrentDropletRef.Name, Namespace: cfProcess.Namespace}, cfBuild)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: dropletName, Namespace: cfProcess.Namespace}, cfBuild)
 	if err != nil {
-		log.Info("error when trying to fetch CFBuild", "namespace", cfProcess.Namespace, "name", cfApp.Spec.CurrentDropletRef.Name, "reason", err)
+		log.Info("error when trying to fetch CFBuild", "namespace", cfProcess.Namespace, "name", dropletName, "reason", err)
 		return err
 	}
 
 	if cfBuild.Status.Droplet == nil {
-		log.Info("no build droplet status on CFBuild", "namespace", cfProcess.Namespace, "name", cfApp.Spec.CurrentDropletRef.Name, "reason", err)
+		log.Info("no build droplet status on CFBuild", "namespace", cfProcess.Namespace, "name", dropletName, "reason", err)
 		return errors.New("no build droplet status on CFBuild")
 	}
 
@@ -232,15 +334,14 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 		return err
 	}
 
-	actualAppWorkload := &korifiv1alpha1.AppWorkload{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: cfProcess.Namespace,
-			Name:      generateAppWorkloadName(cfLastStopAppRev, cfProcess.Name),
-		},
+	appPlacement, err := r.placementResolver.Resolve(ctx, cfProcess.Namespace)
+	if err != nil {
+		log.Info("error when trying to resolve the placement of the process", "namespace", cfProcess.Namespace, "reason", err)
+		return err
 	}
 
 	var desiredAppWorkload *korifiv1alpha1.AppWorkload
-	desiredAppWorkload, err = r.generateAppWorkload(actualAppWorkload, cfApp, cfProcess, cfBuild, appPorts, envVars, cfAppRev, cfLastStopAppRev)
+	desiredAppWorkload, err = r.generateAppWorkload(actualAppWorkload, cfApp, cfProcess, cfBuild, appPorts, envVars, appPlacement, cfAppRev, cfLastStopAppRev)
 	if err != nil { // untested
 		log.Info("error when initializing AppWorkload", "reason", err)
 		return err
@@ -254,7 +355,7 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 	return nil
 }
 
-func (r *Reconciler) cleanUpAppWorkloads(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess, desiredState korifiv1alpha1.AppState, cfLastStopAppRev string) error {
+func (r *Reconciler) cleanUpAppWorkloads(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess, cfApp *korifiv1alpha1.CFApp, cfLastStopAppRev string) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("cleanUpAppWorkloads")
 
 	appWorkloadsForProcess, err := r.fetchAppWorkloadsForProcess(ctx, cfProcess)
@@ -264,7 +365,7 @@ func (r *Reconciler) cleanUpAppWorkloads(ctx context.Context, cfProcess *korifiv
 	}
 
 	for i, currentAppWorkload := range appWorkloadsForProcess {
-		if needsToDeleteAppWorkload(desiredState, cfProcess, currentAppWorkload, cfLastStopAppRev) {
+		if needsToDeleteAppWorkload(cfApp, cfProcess, currentAppWorkload, cfLastStopAppRev) {
 			err := r.k8sClient.Delete(ctx, &appWorkloadsForProcess[i])
 			if err != nil {
 				log.Info("error occurred deleting AppWorkload", "name", currentAppWorkload.Name, "reason", err)
@@ -276,14 +377,21 @@ func (r *Reconciler) cleanUpAppWorkloads(ctx context.Context, cfProcess *korifiv
 }
 
 func needsToDeleteAppWorkload(
-	desiredState korifiv1alpha1.AppState,
+	cfApp *korifiv1alpha1.CFApp,
 	cfProcess *korifiv1alpha1.CFProcess,
 	appWorkload korifiv1alpha1.AppWorkload,
 	cfLastStopAppRev string,
 ) bool {
-	return desiredState == korifiv1alpha1.StoppedState ||
-		(cfProcess.Spec.DesiredInstances != nil && *cfProcess.Spec.DesiredInstances == 0) ||
-		appWorkload.Name != generateAppWorkloadName(cfLastStopAppRev, cfProcess.Name)
+	if cfApp.Spec.DesiredState == korifiv1alpha1.StoppedState ||
+		(cfProcess.Spec.DesiredInstances != nil && *cfProcess.Spec.DesiredInstances == 0) {
+		return true
+	}
+
+	if cfApp.Spec.Canary != nil && appWorkload.Name == generateCanaryAppWorkloadName(cfApp.Spec.Canary.Revision, cfProcess.Name) {
+		return false
+	}
+
+	return appWorkload.Name != generateAppWorkloadName(cfLastStopAppRev, cfProcess.Name)
 }
 
 func appWorkloadMutateFunction(actualAppWorkload, desiredAppWorkload *korifiv1alpha1.AppWorkload) controllerutil.MutateFn {
@@ -296,7 +404,7 @@ func appWorkloadMutateFunction(actualAppWorkload, desiredAppWorkload *korifiv1al
 	}
 }
 
-func (r *Reconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWorkload, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, cfBuild *korifiv1alpha1.CFBuild, appPorts []int32, envVars []corev1.EnvVar, cfAppRev, cfLastStopAppRev string) (*korifiv1alpha1.AppWorkload, error) {
+func (r *Reconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWorkload, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, cfBuild *korifiv1alpha1.CFBuild, appPorts []int32, envVars []corev1.EnvVar, appPlacement placement.Placement, cfAppRev, cfLastStopAppRev string) (*korifiv1alpha1.AppWorkload, error) {
 	var desiredAppWorkload korifiv1alpha1.AppWorkload
 	actualAppWorkload.DeepCopyInto(&desiredAppWorkload)
 
@@ -311,17 +419,18 @@ func (r *Reconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWo
 
 	desiredAppWorkload.Spec.GUID = cfProcess.Name
 	desiredAppWorkload.Spec.Version = cfAppRev
+	sidecars, appMemoryMB := sidecarsForProcess(cfProcess, cfApp)
 	desiredAppWorkload.Spec.Resources.Requests = corev1.ResourceList{
-		corev1.ResourceCPU:              calculateCPURequest(cfProcess.Spec.MemoryMB),
+		corev1.ResourceCPU:              calculateCPURequest(appMemoryMB),
 		corev1.ResourceEphemeralStorage: mebibyteQuantity(cfProcess.Spec.DiskQuotaMB),
-		corev1.ResourceMemory:           mebibyteQuantity(cfProcess.Spec.MemoryMB),
+		corev1.ResourceMemory:           mebibyteQuantity(appMemoryMB),
 	}
 	desiredAppWorkload.Spec.Resources.Limits = corev1.ResourceList{
 		corev1.ResourceEphemeralStorage: mebibyteQuantity(cfProcess.Spec.DiskQuotaMB),
-		corev1.ResourceMemory:           mebibyteQuantity(cfProcess.Spec.MemoryMB),
+		corev1.ResourceMemory:           mebibyteQuantity(appMemoryMB),
 	}
 	desiredAppWorkload.Spec.ProcessType = cfProcess.Spec.ProcessType
-	desiredAppWorkload.Spec.Command = commandForProcess(cfProcess, cfApp)
+	desiredAppWorkload.Spec.Command = commandForProcess(cfProcess, cfApp, cfBuild)
 	desiredAppWorkload.Spec.AppGUID = cfApp.Name
 	desiredAppWorkload.Spec.Image = cfBuild.Status.Droplet.Registry.Image
 	desiredAppWorkload.Spec.ImagePullSecrets = cfBuild.Status.Droplet.Registry.ImagePullSecrets
@@ -331,11 +440,19 @@ func (r *Reconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWo
 		desiredAppWorkload.Spec.Instances = int32(*cfProcess.Spec.DesiredInstances)
 	}
 
+	if isCanary(cfApp, cfAppRev) {
+		desiredAppWorkload.Spec.Canary = true
+		desiredAppWorkload.Spec.Instances = korifiv1alpha1.CanaryInstances
+	}
+
 	desiredAppWorkload.Spec.Env = envVars
 
 	desiredAppWorkload.Spec.StartupProbe = startupProbe(cfProcess, appPorts)
 	desiredAppWorkload.Spec.LivenessProbe = livenessProbe(cfProcess, appPorts)
 	desiredAppWorkload.Spec.RunnerName = r.controllerConfig.RunnerName
+	desiredAppWorkload.Spec.NodeSelector = appPlacement.NodeSelector
+	desiredAppWorkload.Spec.Tolerations = appPlacement.Tolerations
+	desiredAppWorkload.Spec.Sidecars = sidecars
 
 	err := controllerutil.SetControllerReference(cfProcess, &desiredAppWorkload, r.scheme)
 	if err != nil {
@@ -365,6 +482,14 @@ func generateAppWorkloadName(cfAppRev string, processGUID string) string {
 	return appWorkloadName
 }
 
+func generateCanaryAppWorkloadName(canaryAppRev string, processGUID string) string {
+	return generateAppWorkloadName("canary-"+canaryAppRev, processGUID)
+}
+
+func isCanary(cfApp *korifiv1alpha1.CFApp, cfAppRev string) bool {
+	return cfApp.Spec.Canary != nil && cfApp.Spec.Canary.Revision == cfAppRev
+}
+
 func (r *Reconciler) fetchAppWorkloadsForProcess(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) ([]korifiv1alpha1.AppWorkload, error) {
 	allAppWorkloads := &korifiv1alpha1.AppWorkloadList{}
 	err := r.k8sClient.List(ctx, allAppWorkloads, client.InNamespace(cfProcess.Namespace))
@@ -382,16 +507,20 @@ func (r *Reconciler) fetchAppWorkloadsForProcess(ctx context.Context, cfProcess
 	return appWorkloadsForProcess, err
 }
 
-func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp) []string {
+func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp, build *korifiv1alpha1.CFBuild) []string {
 	cmd := process.Spec.Command
 	if cmd == "" {
-		cmd = process.Spec.DetectedCommand
+		cmd = detectedCommand(process, app, build)
 	}
 
 	if cmd == "" {
 		return []string{}
 	}
 
+	return wrapCommand(app, cmd)
+}
+
+func wrapCommand(app *korifiv1alpha1.CFApp, cmd string) []string {
 	if app.Spec.Lifecycle.Type == korifiv1alpha1.BuildpackLifecycle {
 		return []string{"/cnb/lifecycle/launcher", cmd}
 	}
@@ -399,6 +528,85 @@ func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CF
 	return []string{"/bin/sh", "-c", cmd}
 }
 
+// sidecarsForProcess returns the app sidecars that run next to the process
+// and the memory left to the application container. As in CF, sidecar memory
+// is carved out of the process memory: sidecars with memory of their own get
+// it, while the remaining memory is split evenly between the application
+// container and the sidecars without memory. Sidecars that do not fit in the
+// process memory are treated as sidecars without memory.
+func sidecarsForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp) ([]korifiv1alpha1.AppWorkloadSidecar, int64) {
+	var processSidecars []korifiv1alpha1.Sidecar
+	var reservedMemoryMB int64
+	for _, sidecar := range app.Spec.Sidecars {
+		if !slices.Contains(sidecar.ProcessTypes, process.Spec.ProcessType) {
+			continue
+		}
+
+		processSidecars = append(processSidecars, sidecar)
+		reservedMemoryMB += sidecar.MemoryMB
+	}
+
+	if len(processSidecars) == 0 {
+		return nil, process.Spec.MemoryMB
+	}
+
+	ownMemory := reservedMemoryMB < process.Spec.MemoryMB
+	if !ownMemory {
+		reservedMemoryMB = 0
+	}
+
+	sharingCount := int64(1)
+	for _, sidecar := range processSidecars {
+		if !ownMemory || sidecar.MemoryMB == 0 {
+			sharingCount++
+		}
+	}
+	sharedMemoryMB := (process.Spec.MemoryMB - reservedMemoryMB) / sharingCount
+
+	var sidecars []korifiv1alpha1.AppWorkloadSidecar
+	appMemoryMB := process.Spec.MemoryMB
+	for _, sidecar := range processSidecars {
+		memoryMB := sharedMemoryMB
+		if ownMemory && sidecar.MemoryMB > 0 {
+			memoryMB = sidecar.MemoryMB
+		}
+		appMemoryMB -= memoryMB
+
+		sidecars = append(sidecars, korifiv1alpha1.AppWorkloadSidecar{
+			Name:    sidecar.Name,
+			Command: wrapCommand(app, sidecar.Command),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    calculateCPURequest(memoryMB),
+					corev1.ResourceMemory: mebibyteQuantity(memoryMB),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: mebibyteQuantity(memoryMB),
+				},
+			},
+		})
+	}
+
+	return sidecars, appMemoryMB
+}
+
+// detectedCommand returns the command detected for the process type. The
+// process only keeps track of the command detected in the current droplet,
+// so the command for a canary is taken from the canary droplet instead.
+func detectedCommand(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp, build *korifiv1alpha1.CFBuild) string {
+	if app.Spec.Canary == nil || app.Spec.Canary.DropletRef.Name != build.Name {
+		return process.Spec.DetectedCommand
+	}
+
+	for _, processType := range build.Status.Droplet.ProcessTypes {
+		if processType.Type == process.Spec.ProcessType {
+			return processType.Command
+		}
+	}
+
+	return process.Spec.DetectedCommand
+}
+
 func makeProbeHandler(cfProcess *korifiv1alpha1.CFProcess, port int32) corev1.ProbeHandler {
 	var probeHandler corev1.ProbeHandler
 
@@ -238,6 +238,115 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				))
 
 				g.Expect(appWorkload.Spec.RunnerName).To(Equal("cf-process-controller-test"))
+				g.Expect(appWorkload.Spec.NodeSelector).To(BeEmpty())
+				g.Expect(appWorkload.Spec.Tolerations).To(BeEmpty())
+			})
+		})
+
+		When("the space is assigned an isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegment := &korifiv1alpha1.CFIsolationSegment{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "cf",
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+						DisplayName:  "regulated",
+						NodeSelector: map[string]string{"pool": "regulated"},
+						Tolerations: []corev1.Toleration{{
+							Key:      "pool",
+							Operator: corev1.TolerationOpEqual,
+							Value:    "regulated",
+							Effect:   corev1.TaintEffectNoSchedule,
+						}},
+					},
+				}
+				Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+
+				namespace := &corev1.Namespace{}
+				Expect(adminClient.Get(ctx, client.ObjectKey{Name: testNamespace}, namespace)).To(Succeed())
+				Expect(k8s.PatchResource(ctx, adminClient, namespace, func() {
+					namespace.Labels = map[string]string{korifiv1alpha1.IsolationSegmentGUIDLabelKey: isolationSegment.Name}
+				})).To(Succeed())
+			})
+
+			It("places the AppWorkload on the isolation segment nodes", func() {
+				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+					g.Expect(appWorkload.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+						Key:      "pool",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "regulated",
+						Effect:   corev1.TaintEffectNoSchedule,
+					}))
+				})
+			})
+		})
+
+		When("the app has sidecars", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.Sidecars = []korifiv1alpha1.Sidecar{
+						{
+							GUID:         uuid.NewString(),
+							Name:         "log-shipper",
+							Command:      "ship-logs",
+							ProcessTypes: []string{korifiv1alpha1.ProcessTypeWeb},
+							MemoryMB:     64,
+						},
+						{
+							GUID:         uuid.NewString(),
+							Name:         "config-agent",
+							Command:      "watch-config",
+							ProcessTypes: []string{korifiv1alpha1.ProcessTypeWeb, "worker"},
+						},
+						{
+							GUID:         uuid.NewString(),
+							Name:         "worker-only",
+							Command:      "do-work",
+							ProcessTypes: []string{"worker"},
+						},
+					}
+				})).To(Succeed())
+			})
+
+			It("runs the sidecars of the process type next to the application", func() {
+				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Sidecars).To(HaveLen(2))
+
+					g.Expect(appWorkload.Spec.Sidecars[0].Name).To(Equal("log-shipper"))
+					g.Expect(appWorkload.Spec.Sidecars[0].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "ship-logs"}))
+					g.Expect(appWorkload.Spec.Sidecars[0].Resources.Limits.Memory()).To(matchers.RepresentResourceQuantity(64, "Mi"))
+					g.Expect(appWorkload.Spec.Sidecars[0].Resources.Requests.Memory()).To(matchers.RepresentResourceQuantity(64, "Mi"))
+
+					g.Expect(appWorkload.Spec.Sidecars[1].Name).To(Equal("config-agent"))
+					g.Expect(appWorkload.Spec.Sidecars[1].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "watch-config"}))
+					g.Expect(appWorkload.Spec.Sidecars[1].Resources.Limits.Memory()).To(matchers.RepresentResourceQuantity(480, "Mi"))
+					g.Expect(appWorkload.Spec.Sidecars[1].Resources.Requests.Memory()).To(matchers.RepresentResourceQuantity(480, "Mi"))
+				})
+			})
+
+			It("carves the sidecar memory out of the process memory", func() {
+				eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Resources.Limits.Memory()).To(matchers.RepresentResourceQuantity(480, "Mi"))
+					g.Expect(appWorkload.Spec.Resources.Requests.Memory()).To(matchers.RepresentResourceQuantity(480, "Mi"))
+				})
+			})
+
+			When("the sidecars do not fit in the process memory", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.Sidecars[0].MemoryMB = 1024
+					})).To(Succeed())
+				})
+
+				It("splits the process memory evenly", func() {
+					eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.Resources.Limits.Memory()).To(matchers.RepresentResourceQuantity(342, "Mi"))
+						g.Expect(appWorkload.Spec.Sidecars[0].Resources.Limits.Memory()).To(matchers.RepresentResourceQuantity(341, "Mi"))
+						g.Expect(appWorkload.Spec.Sidecars[1].Resources.Limits.Memory()).To(matchers.RepresentResourceQuantity(341, "Mi"))
+					})
+				})
 			})
 		})
 
@@ -460,6 +569,98 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				}, "1s").Should(Succeed())
 			})
 		})
+
+		When("the app runs a canary revision", func() {
+			var canaryBuild *korifiv1alpha1.CFBuild
+
+			BeforeEach(func() {
+				cfProcess.Spec.Command = ""
+				cfProcess.Spec.DesiredInstances = tools.PtrTo(3)
+
+				canaryBuild = &korifiv1alpha1.CFBuild{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFBuildSpec{
+						Lifecycle: korifiv1alpha1.Lifecycle{
+							Type: "buildpack",
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, canaryBuild)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, canaryBuild, func() {
+					canaryBuild.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+						Registry: korifiv1alpha1.Registry{
+							Image: "image/registry/canary",
+						},
+						ProcessTypes: []korifiv1alpha1.ProcessType{{
+							Type:    korifiv1alpha1.ProcessTypeWeb,
+							Command: "canary-command",
+						}},
+					}
+				})).To(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.Canary = &korifiv1alpha1.CanarySpec{
+						DropletRef: corev1.LocalObjectReference{Name: canaryBuild.Name},
+						Revision:   "6",
+					}
+				})).To(Succeed())
+			})
+
+			listAppWorkloads := func(g Gomega) []korifiv1alpha1.AppWorkload {
+				var appWorkloads korifiv1alpha1.AppWorkloadList
+				g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(testNamespace))).To(Succeed())
+				return appWorkloads.Items
+			}
+
+			It("runs a single canary instance next to the current app workload", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppWorkloads(g)).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"Version":   Equal("5"),
+								"Image":     Equal("image/registry/url"),
+								"Instances": BeEquivalentTo(3),
+								"Command":   ConsistOf("/cnb/lifecycle/launcher", "detected-command"),
+								"Canary":    BeFalse(),
+							}),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+								"Labels": HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "6"),
+							}),
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"Version":   Equal("6"),
+								"Image":     Equal("image/registry/canary"),
+								"Instances": BeEquivalentTo(1),
+								"Command":   ConsistOf("/cnb/lifecycle/launcher", "canary-command"),
+								"Canary":    BeTrue(),
+							}),
+						}),
+					))
+				}).Should(Succeed())
+			})
+
+			When("the canary is removed from the app", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(listAppWorkloads(g)).To(HaveLen(2))
+					}).Should(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.Canary = nil
+					})).To(Succeed())
+				})
+
+				It("deletes the canary app workload", func() {
+					eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.Canary).To(BeFalse())
+					})
+				})
+			})
+		})
 	})
 })
 
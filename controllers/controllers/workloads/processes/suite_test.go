@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/processes"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
@@ -68,7 +69,8 @@ var _ = BeforeSuite(func() {
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
 	controllerConfig := &config.ControllerConfig{
-		RunnerName: "cf-process-controller-test",
+		RunnerName:      "cf-process-controller-test",
+		CFRootNamespace: "cf",
 	}
 
 	err = processes.NewReconciler(
@@ -76,11 +78,18 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFProcess"),
 		controllerConfig,
-		env.NewProcessEnvBuilder(k8sManager.GetClient()),
+		env.NewProcessEnvBuilder(k8sManager.GetClient(), controllerConfig.CFRootNamespace),
+		placement.NewResolver(k8sManager.GetClient(), controllerConfig.CFRootNamespace),
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
+
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: controllerConfig.CFRootNamespace,
+		},
+	})).To(Succeed())
 })
 
 var _ = BeforeEach(func() {
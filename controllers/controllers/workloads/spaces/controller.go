@@ -63,6 +63,7 @@ func NewReconciler(
 			k8sns.NewSpaceAppsFinalizer(client, appDeletionTimeout),
 			korifiv1alpha1.CFSpaceFinalizerName,
 		),
+		k8sns.NewSpaceSecurityGroupsReconciler(client, rootNamespace),
 		&cfSpaceMetadataCompiler{
 			labelCompiler: labelCompiler,
 		},
@@ -92,6 +93,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&corev1.ServiceAccount{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForServiceAccount),
+		).
+		Watches(
+			&korifiv1alpha1.CFSecurityGroup{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForSecurityGroup),
 		)
 }
 
@@ -129,6 +134,29 @@ func (r *Reconciler) enqueueCFSpaceRequestsForServiceAccount(ctx context.Context
 	return requests
 }
 
+func (r *Reconciler) enqueueCFSpaceRequestsForSecurityGroup(ctx context.Context, object client.Object) []reconcile.Request {
+	securityGroup, ok := object.(*korifiv1alpha1.CFSecurityGroup)
+	if !ok {
+		return nil
+	}
+
+	cfSpaceList := &korifiv1alpha1.CFSpaceList{}
+	err := r.client.List(ctx, cfSpaceList)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range cfSpaceList.Items {
+		appliesTo := securityGroup.AppliesTo(cfSpaceList.Items[i].Name)
+		if appliesTo.Running || appliesTo.Staging {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfSpaceList.Items[i])})
+		}
+	}
+
+	return requests
+}
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces/finalizers,verbs=update
@@ -137,6 +165,8 @@ func (r *Reconciler) enqueueCFSpaceRequestsForServiceAccount(ctx context.Context
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=create;patch;delete;get;list;watch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="networking.k8s.io",resources=networkpolicies,verbs=get;list;watch;create;patch;delete
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfSpace *korifiv1alpha1.CFSpace) (ctrl.Result, error) {
 	var err error
@@ -296,6 +326,8 @@ func (c *cfSpaceMetadataCompiler) CompileLabels(cfSpace *korifiv1alpha1.CFSpace)
 	return c.labelCompiler.Compile(map[string]string{
 		korifiv1alpha1.SpaceNameKey: korifiv1alpha1.OrgSpaceDeprecatedName,
 		korifiv1alpha1.SpaceGUIDKey: cfSpace.Name,
+		// always set, so that the label is cleared when the isolation segment is unassigned
+		korifiv1alpha1.IsolationSegmentGUIDLabelKey: cfSpace.Spec.IsolationSegmentRef.Name,
 	})
 }
 
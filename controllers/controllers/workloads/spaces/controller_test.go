@@ -4,6 +4,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/k8sns"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
@@ -12,6 +13,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -50,6 +52,30 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	It("sets an empty isolation segment label on the namespace", func() {
+		Eventually(func(g Gomega) {
+			var ns corev1.Namespace
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, &ns)).To(Succeed())
+			g.Expect(ns.Labels).To(HaveKeyWithValue(korifiv1alpha1.IsolationSegmentGUIDLabelKey, ""))
+		}).Should(Succeed())
+	})
+
+	When("the space is assigned an isolation segment", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, cfSpace, func() {
+				cfSpace.Spec.IsolationSegmentRef.Name = "my-isolation-segment"
+			})).To(Succeed())
+		})
+
+		It("labels the namespace with the isolation segment guid", func() {
+			Eventually(func(g Gomega) {
+				var ns corev1.Namespace
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, &ns)).To(Succeed())
+				g.Expect(ns.Labels).To(HaveKeyWithValue(korifiv1alpha1.IsolationSegmentGUIDLabelKey, "my-isolation-segment"))
+			}).Should(Succeed())
+		})
+	})
+
 	It("propagates the image-registry-credentials secrets to CFSpace", func() {
 		Eventually(func(g Gomega) {
 			g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: packageRegistrySecretName}, &corev1.Secret{})).To(Succeed())
@@ -319,4 +345,64 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 	})
+
+	Describe("security groups", func() {
+		var securityGroup *korifiv1alpha1.CFSecurityGroup
+
+		BeforeEach(func() {
+			securityGroup = &korifiv1alpha1.CFSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfRootNamespace,
+				},
+				Spec: korifiv1alpha1.CFSecurityGroupSpec{
+					DisplayName: uuid.NewString(),
+					Rules: []korifiv1alpha1.SecurityGroupRule{
+						{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "443"},
+					},
+					Spaces: map[string]korifiv1alpha1.SecurityGroupWorkloads{
+						cfSpace.Name: {Running: true},
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, securityGroup)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(adminClient.Delete(ctx, securityGroup))).To(Succeed())
+		})
+
+		getRunningNetworkPolicy := func(g Gomega) *networkingv1.NetworkPolicy {
+			networkPolicy := new(networkingv1.NetworkPolicy)
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: k8sns.RunningSecurityGroupsPolicyName}, networkPolicy)).To(Succeed())
+			return networkPolicy
+		}
+
+		It("compiles the security group into a network policy in the space namespace", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := getRunningNetworkPolicy(g)
+				g.Expect(networkPolicy.Spec.Egress).To(HaveLen(1))
+				g.Expect(networkPolicy.Spec.Egress[0].To).To(ConsistOf(networkingv1.NetworkPolicyPeer{
+					IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"},
+				}))
+			}).Should(Succeed())
+		})
+
+		When("the security group is unbound from the space", func() {
+			BeforeEach(func() {
+				Eventually(getRunningNetworkPolicy).Should(Not(BeNil()))
+
+				Expect(k8s.PatchResource(ctx, adminClient, securityGroup, func() {
+					securityGroup.Spec.Spaces = nil
+				})).To(Succeed())
+			})
+
+			It("deletes the network policy", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: k8sns.RunningSecurityGroupsPolicyName}, new(networkingv1.NetworkPolicy))
+					g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
 })
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
@@ -49,13 +50,18 @@ type TaskEnvBuilder interface {
 	Build(context.Context, *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error)
 }
 
+type PlacementResolver interface {
+	Resolve(context.Context, string) (placement.Placement, error)
+}
+
 type Reconciler struct {
-	k8sClient       client.Client
-	scheme          *runtime.Scheme
-	recorder        record.EventRecorder
-	log             logr.Logger
-	envBuilder      TaskEnvBuilder
-	taskTTLDuration time.Duration
+	k8sClient         client.Client
+	scheme            *runtime.Scheme
+	recorder          record.EventRecorder
+	log               logr.Logger
+	envBuilder        TaskEnvBuilder
+	placementResolver PlacementResolver
+	taskTTLDuration   time.Duration
 }
 
 func NewReconciler(
@@ -64,15 +70,17 @@ func NewReconciler(
 	recorder record.EventRecorder,
 	log logr.Logger,
 	envBuilder TaskEnvBuilder,
+	placementResolver PlacementResolver,
 	taskTTLDuration time.Duration,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFTask, *korifiv1alpha1.CFTask] {
 	taskReconciler := Reconciler{
-		k8sClient:       client,
-		scheme:          scheme,
-		recorder:        recorder,
-		log:             log,
-		envBuilder:      envBuilder,
-		taskTTLDuration: taskTTLDuration,
+		k8sClient:         client,
+		scheme:            scheme,
+		recorder:          recorder,
+		log:               log,
+		envBuilder:        envBuilder,
+		placementResolver: placementResolver,
+		taskTTLDuration:   taskTTLDuration,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFTask, *korifiv1alpha1.CFTask](log, client, &taskReconciler)
 }
@@ -139,7 +147,13 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfTask *korifiv1alph
 		return r.reconcileResult(cfTask, err)
 	}
 
-	taskWorkload, err := r.createOrPatchTaskWorkload(ctx, cfTask, cfDroplet, webProcess, env)
+	taskPlacement, err := r.placementResolver.Resolve(ctx, cfTask.Namespace)
+	if err != nil {
+		log.Info("failed to resolve placement", "reason", err)
+		return r.reconcileResult(cfTask, err)
+	}
+
+	taskWorkload, err := r.createOrPatchTaskWorkload(ctx, cfTask, cfDroplet, webProcess, env, taskPlacement)
 	if err != nil {
 		return r.reconcileResult(cfTask, err)
 	}
@@ -244,7 +258,7 @@ func (r *Reconciler) getWebProcess(ctx context.Context, cfApp *korifiv1alpha1.CF
 	return processList.Items[0], nil
 }
 
-func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild, webProcess korifiv1alpha1.CFProcess, env []corev1.EnvVar) (*korifiv1alpha1.TaskWorkload, error) {
+func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild, webProcess korifiv1alpha1.CFProcess, env []corev1.EnvVar, taskPlacement placement.Placement) (*korifiv1alpha1.TaskWorkload, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchTaskWorkload")
 
 	taskWorkload := &korifiv1alpha1.TaskWorkload{
@@ -260,6 +274,7 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 		}
 
 		taskWorkload.Labels[korifiv1alpha1.CFTaskGUIDLabelKey] = cfTask.Name
+		taskWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey] = cfTask.Spec.AppRef.Name
 
 		taskWorkload.Spec.Command = []string{LifecycleLauncherPath, cfTask.Spec.Command}
 		taskWorkload.Spec.Image = cfDroplet.Status.Droplet.Registry.Image
@@ -279,6 +294,8 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 		taskWorkload.Spec.Resources.Limits[corev1.ResourceEphemeralStorage] = *resource.NewScaledQuantity(cfTask.Status.DiskQuotaMB, resource.Mega)
 		taskWorkload.Spec.Resources.Requests[corev1.ResourceCPU] = *resource.NewScaledQuantity(calculateDefaultCPURequestMillicores(webProcess.Spec.MemoryMB), resource.Milli)
 		taskWorkload.Spec.Env = env
+		taskWorkload.Spec.NodeSelector = taskPlacement.NodeSelector
+		taskWorkload.Spec.Tolerations = taskPlacement.Tolerations
 
 		if err := ctrl.SetControllerReference(cfTask, taskWorkload, r.scheme); err != nil {
 			log.Info("failed to set owner ref", "reason", err)
@@ -212,6 +212,7 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 
 				taskWorkload = taskWorkloads.Items[0]
 				g.Expect(taskWorkload.Name).To(Equal(cfTask.Name))
+				g.Expect(taskWorkload.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name))
 				g.Expect(taskWorkload.Spec.Command).To(Equal([]string{"/cnb/lifecycle/launcher", "echo hello"}))
 				g.Expect(taskWorkload.Spec.Image).To(Equal("registry.io/my/image"))
 				g.Expect(taskWorkload.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "registry-secret"}}))
@@ -277,6 +278,50 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 			))
 		})
 
+		When("the space is assigned an isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegment := &korifiv1alpha1.CFIsolationSegment{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "cf",
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+						DisplayName:  "regulated",
+						NodeSelector: map[string]string{"pool": "regulated"},
+						Tolerations: []corev1.Toleration{{
+							Key:      "pool",
+							Operator: corev1.TolerationOpExists,
+							Effect:   corev1.TaintEffectNoSchedule,
+						}},
+					},
+				}
+				Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+
+				namespace := &corev1.Namespace{}
+				Expect(adminClient.Get(ctx, client.ObjectKey{Name: testNamespace}, namespace)).To(Succeed())
+				Expect(k8s.PatchResource(ctx, adminClient, namespace, func() {
+					namespace.Labels = map[string]string{korifiv1alpha1.IsolationSegmentGUIDLabelKey: isolationSegment.Name}
+				})).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfTask, func() {
+					cfTask.Labels = map[string]string{"trigger": "reconcile"}
+				})).To(Succeed())
+			})
+
+			It("places the TaskWorkload on the isolation segment nodes", func() {
+				Eventually(func(g Gomega) {
+					taskWorkload := new(korifiv1alpha1.TaskWorkload)
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfTask), taskWorkload)).To(Succeed())
+					g.Expect(taskWorkload.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+					g.Expect(taskWorkload.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+						Key:      "pool",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					}))
+				}).Should(Succeed())
+			})
+		})
+
 		It("records a TaskWorkloadCreated event", func() {
 			Expect(eventRecorder.EventfCallCount()).To(Equal(eventCallCount+1), "eventRecorder.Eventf call count mismatch")
 			eventTaskObj, eventType, eventReason, eventMessage, eventMessageArgs := eventRecorder.EventfArgsForCall(eventCallCount)
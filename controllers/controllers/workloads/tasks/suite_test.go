@@ -9,6 +9,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/tasks"
 	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/tests/helpers"
@@ -75,12 +76,19 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetScheme(),
 		eventRecorder,
 		ctrl.Log.WithName("controllers").WithName("CFTask"),
-		env.NewAppEnvBuilder(k8sManager.GetClient()),
+		env.NewAppEnvBuilder(k8sManager.GetClient(), "cf"),
+		placement.NewResolver(k8sManager.GetClient(), "cf"),
 		2*time.Second,
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
+
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cf",
+		},
+	})).To(Succeed())
 })
 
 var _ = BeforeEach(func() {
@@ -28,6 +28,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/cleanup"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/domains"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/networkpolicies"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers"
@@ -35,12 +36,15 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/auditevents"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/buildpack"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/docker"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/deployments"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/labels"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/orgs"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/packages"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/processes"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/spaces"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/tasks"
@@ -54,8 +58,10 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	versionwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/version"
 	appswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/apps"
+	isolationsegmentswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/isolationsegments"
 	orgswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/orgs"
 	packageswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/packages"
+	processeswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes"
 	spaceswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/spaces"
 	taskswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
 	jobtaskrunnercontrollers "code.cloudfoundry.org/korifi/job-task-runner/controllers"
@@ -81,6 +87,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -98,6 +105,7 @@ func init() {
 	utilruntime.Must(buildv1alpha2.AddToScheme(scheme))
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(gatewayv1beta1.Install(scheme))
+	utilruntime.Must(gatewayv1alpha2.Install(scheme))
 	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(servicebindingv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
@@ -173,7 +181,7 @@ func main() {
 			mgr.GetClient(),
 			mgr.GetScheme(),
 			ctrl.Log.WithName("controllers").WithName("CFApp"),
-			env.NewVCAPServicesEnvValueBuilder(mgr.GetClient()),
+			env.NewVCAPServicesEnvValueBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace),
 			env.NewVCAPApplicationEnvValueBuilder(mgr.GetClient(), controllerConfig.ExtraVCAPApplicationValues),
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFApp")
@@ -187,7 +195,7 @@ func main() {
 			mgr.GetScheme(),
 			ctrl.Log.WithName("controllers").WithName("CFBuildpackBuild"),
 			controllerConfig,
-			env.NewAppEnvBuilder(mgr.GetClient()),
+			env.NewStagingAppEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace),
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFBuildpackBuild")
 			os.Exit(1)
@@ -221,7 +229,8 @@ func main() {
 			mgr.GetScheme(),
 			ctrl.Log.WithName("controllers").WithName("CFProcess"),
 			controllerConfig,
-			env.NewProcessEnvBuilder(mgr.GetClient()),
+			env.NewProcessEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace),
+			placement.NewResolver(mgr.GetClient(), controllerConfig.CFRootNamespace),
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFProcess")
 			os.Exit(1)
@@ -229,7 +238,9 @@ func main() {
 
 		if err = (instances.NewReconciler(
 			mgr.GetClient(),
+			osbapi.NewClient(mgr.GetClient(), controllerConfig.TrustInsecureServiceBrokers),
 			mgr.GetScheme(),
+			controllerConfig.CFRootNamespace,
 			ctrl.Log.WithName("controllers").WithName("CFServiceInstance"),
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFServiceInstance")
@@ -238,7 +249,9 @@ func main() {
 
 		if err = (bindings.NewReconciler(
 			mgr.GetClient(),
+			osbapi.NewClient(mgr.GetClient(), controllerConfig.TrustInsecureServiceBrokers),
 			mgr.GetScheme(),
+			controllerConfig.CFRootNamespace,
 			ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFServiceBinding")
@@ -286,13 +299,38 @@ func main() {
 			mgr.GetScheme(),
 			mgr.GetEventRecorderFor("cftask-controller"),
 			ctrl.Log.WithName("controllers").WithName("CFTask"),
-			env.NewAppEnvBuilder(mgr.GetClient()),
+			env.NewAppEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace),
+			placement.NewResolver(mgr.GetClient(), controllerConfig.CFRootNamespace),
 			taskTTL,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFTask")
 			os.Exit(1)
 		}
 
+		var auditEventTTL time.Duration
+		auditEventTTL, err = controllerConfig.ParseAuditEventTTL()
+		if err != nil {
+			setupLog.Error(err, "failed to parse audit event TTL", "controller", "CFAuditEvent", "auditEventTTL", controllerConfig.AuditEventTTL)
+			os.Exit(1)
+		}
+		if err = auditevents.NewReconciler(
+			mgr.GetClient(),
+			ctrl.Log.WithName("controllers").WithName("CFAuditEvent"),
+			auditEventTTL,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFAuditEvent")
+			os.Exit(1)
+		}
+
+		if err = deployments.NewReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			ctrl.Log.WithName("controllers").WithName("CFDeployment"),
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFDeployment")
+			os.Exit(1)
+		}
+
 		if err = domains.NewReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
@@ -302,6 +340,16 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = networkpolicies.NewReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			ctrl.Log.WithName("controllers").WithName("CFNetworkPolicy"),
+			controllerConfig.Networking.GatewayNamespace,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFNetworkPolicy")
+			os.Exit(1)
+		}
+
 		if controllerConfig.ExperimentalManagedServicesEnabled {
 			if err = brokers.NewReconciler(
 				mgr.GetClient(),
@@ -463,8 +511,11 @@ func main() {
 			os.Exit(1)
 		}
 
+		quotaValidator := validation.NewQuotaValidator(uncachedClient, controllerConfig.CFRootNamespace, controllerConfig.CFProcessDefaults.MemoryMB)
+
 		if err = appswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, appswebhook.AppEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFApp")
 			os.Exit(1)
@@ -474,6 +525,7 @@ func main() {
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routeswebhook.RouteEntityType)),
 			controllerConfig.CFRootNamespace,
 			uncachedClient,
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFRoute")
 			os.Exit(1)
@@ -481,6 +533,7 @@ func main() {
 
 		if err = instanceswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, instanceswebhook.ServiceInstanceEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceInstance")
 			os.Exit(1)
@@ -523,6 +576,13 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = isolationsegmentswebhook.NewValidator(
+			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, isolationsegmentswebhook.IsolationSegmentEntityType)),
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFIsolationSegment")
+			os.Exit(1)
+		}
+
 		if err = (&korifiv1alpha1.CFRoute{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFRoute")
 			os.Exit(1)
@@ -533,11 +593,16 @@ func main() {
 			os.Exit(1)
 		}
 
-		if err = taskswebhook.NewValidator().SetupWebhookWithManager(mgr); err != nil {
+		if err = taskswebhook.NewValidator(quotaValidator).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFTask")
 			os.Exit(1)
 		}
 
+		if err = processeswebhook.NewValidator(quotaValidator).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFProcess")
+			os.Exit(1)
+		}
+
 		versionwebhook.NewVersionWebhook(version.Version).SetupWebhookWithManager(mgr)
 		controllersfinalizer.NewControllersFinalizerWebhook().SetupWebhookWithManager(mgr)
 
@@ -88,6 +88,7 @@ var _ = BeforeSuite(func() {
 	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
 	Expect(apps.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType)),
+		validation.NewQuotaValidator(uncachedClient, rootNamespace, 1024),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	orgNameDuplicateValidator := validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, orgs.CFOrgEntityType))
@@ -107,6 +108,7 @@ var _ = BeforeSuite(func() {
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routes.RouteEntityType)),
 		rootNamespace,
 		uncachedClient,
+		validation.NewQuotaValidator(uncachedClient, rootNamespace, 1024),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(packages.NewValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
 
@@ -101,6 +101,50 @@ var _ = Describe("Controllers Finalizers Webhook", func() {
 			},
 			korifiv1alpha1.CFDomainFinalizerName,
 		),
+		Entry("managed cfserviceinstance",
+			&korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-org-" + uuid.NewString(),
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "managed-instance",
+					Type:        korifiv1alpha1.ManagedType,
+					PlanGUID:    uuid.NewString(),
+				},
+			},
+			korifiv1alpha1.CFServiceInstanceFinalizerName,
+		),
+		Entry("user-provided cfserviceinstance (no finalizer is added)",
+			&korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-org-" + uuid.NewString(),
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "upsi",
+					Type:        "user-provided",
+					SecretName:  uuid.NewString(),
+				},
+			},
+		),
+		Entry("cfservicebinding",
+			&korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-org-" + uuid.NewString(),
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Service: corev1.ObjectReference{
+						Kind:       "ServiceInstance",
+						Name:       uuid.NewString(),
+						APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+					},
+					AppRef: corev1.LocalObjectReference{Name: uuid.NewString()},
+				},
+			},
+			korifiv1alpha1.CFServiceBindingFinalizerName,
+		),
 		Entry("builderinfo (no finalizer is added)",
 			&korifiv1alpha1.BuilderInfo{
 				ObjectMeta: metav1.ObjectMeta{
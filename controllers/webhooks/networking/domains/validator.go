@@ -124,6 +124,27 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, o
 		}.ExportJSONError()
 	}
 
+	if oldDomain.Spec.Internal != domain.Spec.Internal {
+		return nil, validationwebhook.ValidationError{
+			Type:    validationwebhook.ImmutableFieldErrorType,
+			Message: fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFDomain.Spec.Internal"),
+		}.ExportJSONError()
+	}
+
+	if oldDomain.Spec.OrganizationGUID != domain.Spec.OrganizationGUID {
+		return nil, validationwebhook.ValidationError{
+			Type:    validationwebhook.ImmutableFieldErrorType,
+			Message: fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFDomain.Spec.OrganizationGUID"),
+		}.ExportJSONError()
+	}
+
+	if oldDomain.Spec.RouterGroup != domain.Spec.RouterGroup {
+		return nil, validationwebhook.ValidationError{
+			Type:    validationwebhook.ImmutableFieldErrorType,
+			Message: fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFDomain.Spec.RouterGroup"),
+		}.ExportJSONError()
+	}
+
 	return nil, nil
 }
 
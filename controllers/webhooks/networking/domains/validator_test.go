@@ -174,6 +174,59 @@ var _ = Describe("CFDomainValidator", func() {
 			))
 		})
 
+		When("the internal flag is changed", func() {
+			BeforeEach(func() {
+				updatedCFDomain.Spec.Name = oldCFDomain.Spec.Name
+				updatedCFDomain.Spec.Internal = true
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validation.ImmutableFieldErrorType,
+					Equal("'CFDomain.Spec.Internal' field is immutable"),
+				))
+			})
+		})
+
+		When("the owning organization is changed", func() {
+			BeforeEach(func() {
+				updatedCFDomain.Spec.Name = oldCFDomain.Spec.Name
+				updatedCFDomain.Spec.OrganizationGUID = "another-org"
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validation.ImmutableFieldErrorType,
+					Equal("'CFDomain.Spec.OrganizationGUID' field is immutable"),
+				))
+			})
+		})
+
+		When("the router group is changed", func() {
+			BeforeEach(func() {
+				updatedCFDomain.Spec.Name = oldCFDomain.Spec.Name
+				updatedCFDomain.Spec.RouterGroup = "default-tcp"
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validation.ImmutableFieldErrorType,
+					Equal("'CFDomain.Spec.RouterGroup' field is immutable"),
+				))
+			})
+		})
+
+		When("the shared organizations are changed", func() {
+			BeforeEach(func() {
+				updatedCFDomain.Spec.Name = oldCFDomain.Spec.Name
+				updatedCFDomain.Spec.SharedOrganizations = []string{"another-org"}
+			})
+
+			It("does not return an error", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+		})
+
 		When("the domain is being deleted", func() {
 			BeforeEach(func() {
 				updatedCFDomain.DeletionTimestamp = &metav1.Time{Time: time.Now()}
@@ -32,6 +32,8 @@ const (
 	RoutePathValidationErrorType           = "RoutePathValidationError"
 	RouteSubdomainValidationErrorType      = "RouteSubdomainValidationError"
 	RouteSubdomainValidationErrorMessage   = "Subdomains must each be at most 63 characters"
+	InternalRouteValidationErrorType       = "InternalRouteValidationError"
+	RouteProtocolValidationErrorType       = "RouteProtocolValidationError"
 
 	HostEmptyError  = "host cannot be empty"
 	HostLengthError = "host is too long (maximum is 63 characters)"
@@ -41,6 +43,19 @@ const (
 	PathIsSlashError         = "Path cannot be a single slash"
 	PathHasQuestionMarkError = "Path cannot contain a question mark"
 	PathLengthExceededError  = "Path cannot exceed 128 characters"
+
+	TCPRouteHostError      = "Hosts are not supported for TCP routes"
+	TCPRoutePathError      = "Paths are not supported for TCP routes"
+	TCPRoutePortError      = "TCP routes require a port between 1 and 65535"
+	TCPRouteProtocolError  = "Routes on domains assigned to a router group must have protocol 'tcp'"
+	HTTPRoutePortError     = "Ports are not supported for HTTP routes"
+	HTTPRouteProtocolError = "Routes with protocol 'tcp' require a domain assigned to a router group"
+
+	InternalRoutePathError               = "Paths are not supported for internal domains"
+	InternalRouteHostEmptyError          = "Routes on internal domains require a host"
+	InternalRouteHostFormatErrorTemplate = "Host %q of a route on an internal domain must start with a letter and contain only alphanumeric characters or \"-\""
+
+	maxTCPRoutePort = 65535
 )
 
 var logger = logf.Log.WithName("route-validation")
@@ -51,6 +66,7 @@ type Validator struct {
 	duplicateValidator webhooks.NameValidator
 	rootNamespace      string
 	client             client.Client
+	quotaValidator     webhooks.QuotaValidator
 }
 
 var _ webhook.CustomValidator = &Validator{}
@@ -59,11 +75,13 @@ func NewValidator(
 	nameValidator webhooks.NameValidator,
 	rootNamespace string,
 	client client.Client,
+	quotaValidator webhooks.QuotaValidator,
 ) *Validator {
 	return &Validator{
 		duplicateValidator: nameValidator,
 		rootNamespace:      rootNamespace,
 		client:             client,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -87,6 +105,10 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 
 	route.Status.FQDN = cfDomain.Spec.Name
 
+	if err = v.quotaValidator.ValidateRouteCreate(ctx, route); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, route)
 }
 
@@ -124,6 +146,11 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		return nil, immutableError.ExportJSONError()
 	}
 
+	if route.Spec.Port != oldRoute.Spec.Port {
+		immutableError.Message = fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.Port")
+		return nil, immutableError.ExportJSONError()
+	}
+
 	if route.Spec.DomainRef.Name != oldRoute.Spec.DomainRef.Name {
 		immutableError.Message = fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.DomainRef.Name")
 		return nil, immutableError.ExportJSONError()
@@ -157,10 +184,28 @@ func (v *Validator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFR
 		return domain, err
 	}
 
+	if domain.Spec.RouterGroup != "" {
+		if err = validateTCPRoute(route); err != nil {
+			return nil, err
+		}
+
+		return domain, nil
+	}
+
+	if err = validateHTTPRoute(route); err != nil {
+		return nil, err
+	}
+
 	if err = validateFQDN(route.Spec.Host, domain.Spec.Name); err != nil {
 		return nil, err
 	}
 
+	if domain.Spec.Internal {
+		if err = validateInternalRoute(route); err != nil {
+			return nil, err
+		}
+	}
+
 	if err = validatePath(route.Spec.Path); err != nil {
 		return nil, err
 	}
@@ -202,6 +247,56 @@ func (v *Validator) validateDestinations(ctx context.Context, route *korifiv1alp
 	return nil
 }
 
+func validateTCPRoute(route *korifiv1alpha1.CFRoute) error {
+	var errStrings []string
+
+	if route.Spec.Protocol != korifiv1alpha1.ProtocolTCP {
+		errStrings = append(errStrings, TCPRouteProtocolError)
+	}
+
+	if route.Spec.Host != "" {
+		errStrings = append(errStrings, TCPRouteHostError)
+	}
+
+	if route.Spec.Path != "" {
+		errStrings = append(errStrings, TCPRoutePathError)
+	}
+
+	if route.Spec.Port < 1 || route.Spec.Port > maxTCPRoutePort {
+		errStrings = append(errStrings, TCPRoutePortError)
+	}
+
+	if len(errStrings) == 0 {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RouteProtocolValidationErrorType,
+		Message: strings.Join(errStrings, ", "),
+	}.ExportJSONError()
+}
+
+func validateHTTPRoute(route *korifiv1alpha1.CFRoute) error {
+	var errStrings []string
+
+	if route.Spec.Protocol == korifiv1alpha1.ProtocolTCP {
+		errStrings = append(errStrings, HTTPRouteProtocolError)
+	}
+
+	if route.Spec.Port != 0 {
+		errStrings = append(errStrings, HTTPRoutePortError)
+	}
+
+	if len(errStrings) == 0 {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RouteProtocolValidationErrorType,
+		Message: strings.Join(errStrings, ", "),
+	}.ExportJSONError()
+}
+
 func validateFQDN(host, domain string) error {
 	// we only need to validate that "<host>.<domain>" is not too long and that
 	// <host> is either "*" or a valid dns label. The domain webhook already
@@ -225,6 +320,34 @@ func validateFQDN(host, domain string) error {
 	return nil
 }
 
+// validateInternalRoute checks that the route host can name the service
+// resolving the route in the namespace of its internal domain. As internal
+// routes cannot have paths, route uniqueness guarantees that no two routes
+// share the same service.
+func validateInternalRoute(route *korifiv1alpha1.CFRoute) error {
+	var errStrings []string
+
+	if route.Spec.Path != "" {
+		errStrings = append(errStrings, InternalRoutePathError)
+	}
+
+	host := strings.ToLower(route.Spec.Host)
+	if host == "" {
+		errStrings = append(errStrings, InternalRouteHostEmptyError)
+	} else if len(validation.IsDNS1035Label(host)) > 0 {
+		errStrings = append(errStrings, fmt.Sprintf(InternalRouteHostFormatErrorTemplate, host))
+	}
+
+	if len(errStrings) == 0 {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    InternalRouteValidationErrorType,
+		Message: strings.Join(errStrings, ", "),
+	}.ExportJSONError()
+}
+
 func validateHost(host string) error {
 	if host == "*" {
 		return nil
@@ -29,6 +29,7 @@ var _ = Describe("CFRouteValidator", func() {
 	var (
 		ctx                context.Context
 		duplicateValidator *fake.NameValidator
+		quotaValidator     *fake.QuotaValidator
 		fakeClient         *controllerfake.Client
 		cfRoute            *korifiv1alpha1.CFRoute
 		cfDomain           *korifiv1alpha1.CFDomain
@@ -102,7 +103,8 @@ var _ = Describe("CFRouteValidator", func() {
 			}
 		}
 
-		validatingWebhook = routes.NewValidator(duplicateValidator, rootNamespace, fakeClient)
+		quotaValidator = new(fake.QuotaValidator)
+		validatingWebhook = routes.NewValidator(duplicateValidator, rootNamespace, fakeClient, quotaValidator)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -127,6 +129,26 @@ var _ = Describe("CFRouteValidator", func() {
 			Expect(getDomainCallCount).To(Equal(1), "Expected get domain call count mismatch")
 		})
 
+		It("validates the routes quota", func() {
+			Expect(quotaValidator.ValidateRouteCreateCallCount()).To(Equal(1))
+			_, actualRoute := quotaValidator.ValidateRouteCreateArgsForCall(0)
+			Expect(actualRoute).To(Equal(cfRoute))
+		})
+
+		When("the routes quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateRouteCreateReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+			})
+
+			It("does not reserve the route", func() {
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
 		When("the host is '*'", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Host = "*"
@@ -295,6 +317,165 @@ var _ = Describe("CFRouteValidator", func() {
 				})
 			})
 		})
+		When("the route has a port", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Port = 1024
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteProtocolValidationErrorType,
+					Equal(routes.HTTPRoutePortError),
+				))
+			})
+		})
+
+		When("the route protocol is tcp", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Protocol = korifiv1alpha1.ProtocolTCP
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteProtocolValidationErrorType,
+					Equal(routes.HTTPRouteProtocolError),
+				))
+			})
+		})
+
+		When("the domain is internal", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.Internal = true
+				cfRoute.Spec.Path = ""
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("the route FQDN is longer than 63 characters", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = strings.Repeat("a", 50)
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the route has a path", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Path = "/my-path"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.InternalRouteValidationErrorType,
+						Equal(routes.InternalRoutePathError),
+					))
+				})
+			})
+
+			When("the route has no host", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = ""
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.InternalRouteValidationErrorType,
+						Equal(routes.InternalRouteHostEmptyError),
+					))
+				})
+			})
+
+			When("the route host starts with a digit", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = "1-host"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.InternalRouteValidationErrorType,
+						ContainSubstring(`Host "1-host" of a route on an internal domain must start with a letter`),
+					))
+				})
+			})
+
+			When("the route host is a wildcard", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = "*"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.InternalRouteValidationErrorType,
+						ContainSubstring(`Host "*"`),
+					))
+				})
+			})
+		})
+
+		When("the domain is assigned to a router group", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.RouterGroup = "default-tcp"
+				cfRoute.Spec.Protocol = korifiv1alpha1.ProtocolTCP
+				cfRoute.Spec.Host = ""
+				cfRoute.Spec.Path = ""
+				cfRoute.Spec.Port = 1024
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			It("reserves the port", func() {
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+				_, _, _, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+				Expect(actualResource.UniqueName()).To(Equal("tcp::1024"))
+				Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Port 1024 is not available. Try a different port or use a different domain."))
+			})
+
+			When("the route protocol is http", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Protocol = korifiv1alpha1.ProtocolHTTP
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal(routes.TCPRouteProtocolError),
+					))
+				})
+			})
+
+			When("the route has a host and a path", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = "my-host"
+					cfRoute.Spec.Path = "/my-path"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal(routes.TCPRouteHostError+", "+routes.TCPRoutePathError),
+					))
+				})
+			})
+
+			When("the route has no port", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Port = 0
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal(routes.TCPRoutePortError),
+					))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -391,6 +572,19 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the port is updated", func() {
+			BeforeEach(func() {
+				updatedCFRoute.Spec.Port = 1025
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validationwebhook.ImmutableFieldErrorType,
+					Equal("'CFRoute.Spec.Port' field is immutable"),
+				))
+			})
+		})
+
 		When("the DomainRef is updated", func() {
 			BeforeEach(func() {
 				updatedCFRoute.Spec.DomainRef = v1.ObjectReference{Name: "newDomainRef"}
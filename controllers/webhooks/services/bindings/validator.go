@@ -51,6 +51,22 @@ func (v *CFServiceBindingValidator) ValidateCreate(ctx context.Context, obj runt
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBinding but got a %T", obj))
 	}
 
+	if serviceBinding.IsKey() {
+		if serviceBinding.Spec.DisplayName == nil || *serviceBinding.Spec.DisplayName == "" {
+			return nil, validation.ValidationError{
+				Type:    webhooks.MissingRequredFieldErrorType,
+				Message: fmt.Sprintf("key binding %s:%s is missing required field 'Spec.DisplayName'", serviceBinding.Namespace, serviceBinding.Name),
+			}.ExportJSONError()
+		}
+
+		if serviceBinding.Spec.AppRef.Name != "" {
+			return nil, validation.ValidationError{
+				Type:    webhooks.InvalidFieldValueErrorType,
+				Message: fmt.Sprintf("key binding %s:%s cannot reference an app", serviceBinding.Namespace, serviceBinding.Name),
+			}.ExportJSONError()
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfservicebindinglog, serviceBinding.Namespace, serviceBinding)
 }
 
@@ -69,6 +85,10 @@ func (v *CFServiceBindingValidator) ValidateUpdate(ctx context.Context, oldObj,
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBinding but got a %T", oldObj))
 	}
 
+	if oldServiceBinding.Spec.Type != serviceBinding.Spec.Type {
+		return nil, validation.ValidationError{Type: ServiceBindingErrorType, Message: "Type is immutable"}
+	}
+
 	if oldServiceBinding.Spec.AppRef.Name != serviceBinding.Spec.AppRef.Name {
 		return nil, validation.ValidationError{Type: ServiceBindingErrorType, Message: "AppRef.Name is immutable"}
 	}
@@ -6,8 +6,11 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services/bindings"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -87,6 +90,51 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 				Expect(retErr).To(MatchError("foo"))
 			})
 		})
+
+		When("the service binding is a key", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeKey
+				serviceBinding.Spec.AppRef = v1.LocalObjectReference{}
+				serviceBinding.Spec.DisplayName = tools.PtrTo("my-key")
+			})
+
+			It("allows the creation", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			It("uses the key name and the service instance for the uniqueness lock", func() {
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+				_, _, _, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+				Expect(actualResource.UniqueName()).To(Equal("sk::" + defaultNamespace + "::" + serviceInstanceGUID + "::my-key"))
+				Expect(actualResource.UniqueValidationErrorMessage()).To(ContainSubstring("The service instance already has a key binding with name 'my-key'"))
+			})
+
+			When("the key has no display name", func() {
+				BeforeEach(func() {
+					serviceBinding.Spec.DisplayName = nil
+				})
+
+				It("denies the creation", func() {
+					validationErr, ok := validation.WebhookErrorToValidationError(retErr)
+					Expect(ok).To(BeTrue())
+					Expect(validationErr.Type).To(Equal(webhooks.MissingRequredFieldErrorType))
+					Expect(validationErr.Message).To(ContainSubstring("missing required field 'Spec.DisplayName'"))
+				})
+			})
+
+			When("the key references an app", func() {
+				BeforeEach(func() {
+					serviceBinding.Spec.AppRef.Name = appGUID
+				})
+
+				It("denies the creation", func() {
+					validationErr, ok := validation.WebhookErrorToValidationError(retErr)
+					Expect(ok).To(BeTrue())
+					Expect(validationErr.Type).To(Equal(webhooks.InvalidFieldValueErrorType))
+					Expect(validationErr.Message).To(ContainSubstring("cannot reference an app"))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -116,6 +164,16 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			})
 		})
 
+		When("the type changes", func() {
+			BeforeEach(func() {
+				updatedServiceBinding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeKey
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(MatchError(ContainSubstring("Type is immutable")))
+			})
+		})
+
 		When("the AppRef name changes", func() {
 			BeforeEach(func() {
 				updatedServiceBinding.Spec.AppRef.Name = "updated-app-name"
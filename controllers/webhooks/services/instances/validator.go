@@ -25,13 +25,15 @@ var cfserviceinstancelog = logf.Log.WithName("cfserviceinstance-validate")
 
 type Validator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 }
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+func NewValidator(duplicateValidator webhooks.NameValidator, quotaValidator webhooks.QuotaValidator) *Validator {
 	return &Validator{
 		duplicateValidator: duplicateValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -48,6 +50,10 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceInstance but got a %T", obj))
 	}
 
+	if err := v.quotaValidator.ValidateServiceInstanceCreate(ctx, serviceInstance); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfserviceinstancelog, serviceInstance.Namespace, serviceInstance)
 }
 
@@ -24,6 +24,7 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 	var (
 		ctx                context.Context
 		duplicateValidator *fake.NameValidator
+		quotaValidator     *fake.QuotaValidator
 		serviceInstance    *korifiv1alpha1.CFServiceInstance
 		validatingWebhook  *instances.Validator
 		retErr             error
@@ -48,7 +49,8 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		}
 
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = instances.NewValidator(duplicateValidator)
+		quotaValidator = new(fake.QuotaValidator)
+		validatingWebhook = instances.NewValidator(duplicateValidator, quotaValidator)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -69,6 +71,26 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("The service instance name is taken: " + serviceInstance.Spec.DisplayName))
 		})
 
+		It("validates the service instances quota", func() {
+			Expect(quotaValidator.ValidateServiceInstanceCreateCallCount()).To(Equal(1))
+			_, actualServiceInstance := quotaValidator.ValidateServiceInstanceCreateArgsForCall(0)
+			Expect(actualServiceInstance).To(Equal(serviceInstance))
+		})
+
+		When("the service instances quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateServiceInstanceCreateReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+			})
+
+			It("does not reserve the service instance name", func() {
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
 		When("the serviceInstance name is a duplicate", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
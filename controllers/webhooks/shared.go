@@ -32,6 +32,16 @@ type NamespaceValidator interface {
 	ValidateSpaceCreate(space korifiv1alpha1.CFSpace) error
 }
 
+//counterfeiter:generate -o fake -fake-name QuotaValidator . QuotaValidator
+
+type QuotaValidator interface {
+	ValidateAppStart(ctx context.Context, app *korifiv1alpha1.CFApp) error
+	ValidateProcessScale(ctx context.Context, oldProcess, newProcess *korifiv1alpha1.CFProcess) error
+	ValidateTaskCreate(ctx context.Context, task *korifiv1alpha1.CFTask) error
+	ValidateRouteCreate(ctx context.Context, route *korifiv1alpha1.CFRoute) error
+	ValidateServiceInstanceCreate(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error
+}
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fake -fake-name NameRegistry . NameRegistry
 
@@ -0,0 +1,427 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspacequotas,verbs=get;list;watch
+
+const (
+	QuotaExceededErrorType = "QuotaExceededError"
+
+	OrgMemoryQuotaExceededMessage         = "memory quota_exceeded"
+	SpaceMemoryQuotaExceededMessage       = "memory space_quota_exceeded"
+	OrgInstanceMemoryExceededMessage      = "memory instance_memory_limit_exceeded"
+	SpaceInstanceMemoryExceededMessage    = "memory space_instance_memory_limit_exceeded"
+	OrgAppInstancesQuotaExceededMessage   = "app_instance_limit app_instance_limit_exceeded"
+	SpaceAppInstancesQuotaExceededMessage = "app_instance_limit space_app_instance_limit_exceeded"
+	OrgAppTasksQuotaExceededMessage       = "app_task_limit app_task_limit_exceeded"
+	SpaceAppTasksQuotaExceededMessage     = "app_task_limit space_app_task_limit_exceeded"
+	OrgRoutesQuotaExceededMessage         = "Routes quota exceeded for organization '%s'."
+	SpaceRoutesQuotaExceededMessage       = "Routes quota exceeded for space '%s'."
+	OrgServicesQuotaExceededMessage       = "You have exceeded your organization's services limit."
+	SpaceServicesQuotaExceededMessage     = "You have exceeded your space's services limit."
+
+	spaceNamespaceFieldSelector = "metadata.name"
+)
+
+// QuotaValidator enforces the CFOrgQuota and CFSpaceQuota applied to the
+// org and space owning the namespace of the validated object. It is meant
+// to be used with an uncached client, as usage is computed on every call.
+type QuotaValidator struct {
+	client              client.Client
+	rootNamespace       string
+	defaultTaskMemoryMB int64
+}
+
+// NewQuotaValidator creates a QuotaValidator. The default task memory is the
+// memory tasks get when their status has not been defaulted yet, which is
+// always the case when they are created.
+func NewQuotaValidator(client client.Client, rootNamespace string, defaultTaskMemoryMB int64) *QuotaValidator {
+	return &QuotaValidator{client: client, rootNamespace: rootNamespace, defaultTaskMemoryMB: defaultTaskMemoryMB}
+}
+
+type appliedQuotas struct {
+	orgName        string
+	orgLimits      *korifiv1alpha1.QuotaLimits
+	orgNamespaces  []string
+	spaceName      string
+	spaceLimits    *korifiv1alpha1.QuotaLimits
+	spaceNamespace string
+}
+
+func (q appliedQuotas) isEmpty() bool {
+	return q.orgLimits == nil && q.spaceLimits == nil
+}
+
+func (v *QuotaValidator) ValidateAppStart(ctx context.Context, app *korifiv1alpha1.CFApp) error {
+	quotas, err := v.getQuotas(ctx, app.Namespace)
+	if err != nil || quotas.isEmpty() {
+		return err
+	}
+
+	processes, err := v.listProcesses(ctx, app.Namespace, app.Name)
+	if err != nil {
+		return err
+	}
+
+	var memoryMB, instances int64
+	for _, process := range processes {
+		if err = checkInstanceMemory(quotas, process.Spec.MemoryMB); err != nil {
+			return err
+		}
+		memoryMB += processMemoryMB(process)
+		instances += processInstances(process)
+	}
+
+	return v.checkAppsUsage(ctx, quotas, memoryMB, instances, func(p korifiv1alpha1.CFProcess) bool {
+		return p.Spec.AppRef.Name != app.Name
+	})
+}
+
+func (v *QuotaValidator) ValidateProcessScale(ctx context.Context, oldProcess, newProcess *korifiv1alpha1.CFProcess) error {
+	memoryIncreased := newProcess.Spec.MemoryMB > oldProcess.Spec.MemoryMB
+	if !memoryIncreased && processInstances(*newProcess) <= processInstances(*oldProcess) {
+		return nil
+	}
+
+	quotas, err := v.getQuotas(ctx, newProcess.Namespace)
+	if err != nil || quotas.isEmpty() {
+		return err
+	}
+
+	if memoryIncreased {
+		if err = checkInstanceMemory(quotas, newProcess.Spec.MemoryMB); err != nil {
+			return err
+		}
+	}
+
+	// stopped apps do not count towards the quota
+	app := new(korifiv1alpha1.CFApp)
+	err = v.client.Get(ctx, types.NamespacedName{Namespace: newProcess.Namespace, Name: newProcess.Spec.AppRef.Name}, app)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if app.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return nil
+	}
+
+	return v.checkAppsUsage(ctx, quotas, processMemoryMB(*newProcess), processInstances(*newProcess), func(p korifiv1alpha1.CFProcess) bool {
+		return p.Name != newProcess.Name
+	})
+}
+
+func (v *QuotaValidator) ValidateTaskCreate(ctx context.Context, task *korifiv1alpha1.CFTask) error {
+	quotas, err := v.getQuotas(ctx, task.Namespace)
+	if err != nil || quotas.isEmpty() {
+		return err
+	}
+
+	memoryMB := v.taskMemoryMB(*task)
+	if err = checkInstanceMemory(quotas, memoryMB); err != nil {
+		return err
+	}
+
+	// the task limit applies to each app, hence the usage is the same for org and space quotas
+	tasks := new(korifiv1alpha1.CFTaskList)
+	if err = v.client.List(ctx, tasks, client.InNamespace(task.Namespace)); err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	var runningTasks int64
+	for _, t := range tasks.Items {
+		if t.Spec.AppRef.Name == task.Spec.AppRef.Name && !isTaskFinished(t) {
+			runningTasks++
+		}
+	}
+
+	if exceeds(appTasksLimit(quotas.orgLimits), runningTasks+1) {
+		return quotaExceededError(OrgAppTasksQuotaExceededMessage)
+	}
+
+	if exceeds(appTasksLimit(quotas.spaceLimits), runningTasks+1) {
+		return quotaExceededError(SpaceAppTasksQuotaExceededMessage)
+	}
+
+	// running tasks count towards the memory quota like app instances
+	return v.checkAppsUsage(ctx, quotas, memoryMB, 0, func(korifiv1alpha1.CFProcess) bool {
+		return true
+	})
+}
+
+func (v *QuotaValidator) ValidateRouteCreate(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
+	quotas, err := v.getQuotas(ctx, route.Namespace)
+	if err != nil || quotas.isEmpty() {
+		return err
+	}
+
+	return v.checkCount(ctx, quotas,
+		func(l korifiv1alpha1.QuotaLimits) *int64 { return l.TotalRoutes },
+		func() client.ObjectList { return new(korifiv1alpha1.CFRouteList) },
+		fmt.Sprintf(OrgRoutesQuotaExceededMessage, quotas.orgName),
+		fmt.Sprintf(SpaceRoutesQuotaExceededMessage, quotas.spaceName),
+	)
+}
+
+func (v *QuotaValidator) ValidateServiceInstanceCreate(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	quotas, err := v.getQuotas(ctx, serviceInstance.Namespace)
+	if err != nil || quotas.isEmpty() {
+		return err
+	}
+
+	return v.checkCount(ctx, quotas,
+		func(l korifiv1alpha1.QuotaLimits) *int64 { return l.TotalServiceInstances },
+		func() client.ObjectList { return new(korifiv1alpha1.CFServiceInstanceList) },
+		OrgServicesQuotaExceededMessage,
+		SpaceServicesQuotaExceededMessage,
+	)
+}
+
+// getQuotas returns the quotas applied to the space owning the namespace
+// and to its org. Namespaces that are not space namespaces have no quotas.
+func (v *QuotaValidator) getQuotas(ctx context.Context, namespace string) (appliedQuotas, error) {
+	spaces := new(korifiv1alpha1.CFSpaceList)
+	err := v.client.List(ctx, spaces, client.MatchingFields{spaceNamespaceFieldSelector: namespace})
+	if err != nil {
+		return appliedQuotas{}, fmt.Errorf("failed to list spaces: %w", err)
+	}
+
+	if len(spaces.Items) == 0 {
+		return appliedQuotas{}, nil
+	}
+
+	space := spaces.Items[0]
+	quotas := appliedQuotas{
+		spaceName:      space.Spec.DisplayName,
+		spaceNamespace: namespace,
+	}
+
+	if space.Spec.QuotaRef.Name != "" {
+		spaceQuota := new(korifiv1alpha1.CFSpaceQuota)
+		err = v.client.Get(ctx, types.NamespacedName{Namespace: space.Namespace, Name: space.Spec.QuotaRef.Name}, spaceQuota)
+		if client.IgnoreNotFound(err) != nil {
+			return appliedQuotas{}, fmt.Errorf("failed to get space quota: %w", err)
+		}
+		if err == nil {
+			quotas.spaceLimits = &spaceQuota.Spec.Limits
+		}
+	}
+
+	org := new(korifiv1alpha1.CFOrg)
+	err = v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: space.Namespace}, org)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return quotas, nil
+		}
+		return appliedQuotas{}, fmt.Errorf("failed to get org: %w", err)
+	}
+
+	quotas.orgName = org.Spec.DisplayName
+	if org.Spec.QuotaRef.Name == "" {
+		return quotas, nil
+	}
+
+	orgQuota := new(korifiv1alpha1.CFOrgQuota)
+	err = v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: org.Spec.QuotaRef.Name}, orgQuota)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return quotas, nil
+		}
+		return appliedQuotas{}, fmt.Errorf("failed to get org quota: %w", err)
+	}
+	quotas.orgLimits = &orgQuota.Spec.Limits
+
+	orgSpaces := new(korifiv1alpha1.CFSpaceList)
+	if err = v.client.List(ctx, orgSpaces, client.InNamespace(space.Namespace)); err != nil {
+		return appliedQuotas{}, fmt.Errorf("failed to list org spaces: %w", err)
+	}
+	for _, s := range orgSpaces.Items {
+		quotas.orgNamespaces = append(quotas.orgNamespaces, s.Name)
+	}
+
+	return quotas, nil
+}
+
+func (v *QuotaValidator) checkAppsUsage(ctx context.Context, quotas appliedQuotas, memoryMB, instances int64, include func(korifiv1alpha1.CFProcess) bool) error {
+	check := func(limits *korifiv1alpha1.QuotaLimits, namespaces []string, memoryMessage, instancesMessage string) error {
+		if limits == nil || (limits.TotalMemoryMB == nil && limits.TotalAppInstances == nil) {
+			return nil
+		}
+
+		usedMemoryMB, usedInstances, err := v.appsUsage(ctx, namespaces, include)
+		if err != nil {
+			return err
+		}
+
+		if exceeds(limits.TotalMemoryMB, usedMemoryMB+memoryMB) {
+			return quotaExceededError(memoryMessage)
+		}
+
+		if exceeds(limits.TotalAppInstances, usedInstances+instances) {
+			return quotaExceededError(instancesMessage)
+		}
+
+		return nil
+	}
+
+	if err := check(quotas.orgLimits, quotas.orgNamespaces, OrgMemoryQuotaExceededMessage, OrgAppInstancesQuotaExceededMessage); err != nil {
+		return err
+	}
+
+	return check(quotas.spaceLimits, []string{quotas.spaceNamespace}, SpaceMemoryQuotaExceededMessage, SpaceAppInstancesQuotaExceededMessage)
+}
+
+// appsUsage sums up the memory and instances of the included processes of
+// the started apps in the namespaces. The memory of the running tasks is
+// added to the memory usage.
+func (v *QuotaValidator) appsUsage(ctx context.Context, namespaces []string, include func(korifiv1alpha1.CFProcess) bool) (int64, int64, error) {
+	var memoryMB, instances int64
+
+	for _, ns := range namespaces {
+		apps := new(korifiv1alpha1.CFAppList)
+		if err := v.client.List(ctx, apps, client.InNamespace(ns)); err != nil {
+			return 0, 0, fmt.Errorf("failed to list apps: %w", err)
+		}
+
+		startedApps := map[string]bool{}
+		for _, app := range apps.Items {
+			startedApps[app.Name] = app.Spec.DesiredState == korifiv1alpha1.StartedState
+		}
+
+		processes := new(korifiv1alpha1.CFProcessList)
+		if err := v.client.List(ctx, processes, client.InNamespace(ns)); err != nil {
+			return 0, 0, fmt.Errorf("failed to list processes: %w", err)
+		}
+
+		for _, process := range processes.Items {
+			if !startedApps[process.Spec.AppRef.Name] || !include(process) {
+				continue
+			}
+			memoryMB += processMemoryMB(process)
+			instances += processInstances(process)
+		}
+
+		tasks := new(korifiv1alpha1.CFTaskList)
+		if err := v.client.List(ctx, tasks, client.InNamespace(ns)); err != nil {
+			return 0, 0, fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		for _, task := range tasks.Items {
+			if !isTaskFinished(task) {
+				memoryMB += v.taskMemoryMB(task)
+			}
+		}
+	}
+
+	return memoryMB, instances, nil
+}
+
+func (v *QuotaValidator) checkCount(
+	ctx context.Context,
+	quotas appliedQuotas,
+	limit func(korifiv1alpha1.QuotaLimits) *int64,
+	newList func() client.ObjectList,
+	orgMessage string,
+	spaceMessage string,
+) error {
+	check := func(limits *korifiv1alpha1.QuotaLimits, namespaces []string, message string) error {
+		if limits == nil || limit(*limits) == nil {
+			return nil
+		}
+
+		var count int64
+		for _, ns := range namespaces {
+			list := newList()
+			if err := v.client.List(ctx, list, client.InNamespace(ns)); err != nil {
+				return fmt.Errorf("failed to list %T: %w", list, err)
+			}
+			count += int64(meta.LenList(list))
+		}
+
+		if exceeds(limit(*limits), count+1) {
+			return quotaExceededError(message)
+		}
+
+		return nil
+	}
+
+	if err := check(quotas.orgLimits, quotas.orgNamespaces, orgMessage); err != nil {
+		return err
+	}
+
+	return check(quotas.spaceLimits, []string{quotas.spaceNamespace}, spaceMessage)
+}
+
+func (v *QuotaValidator) listProcesses(ctx context.Context, namespace, appGUID string) ([]korifiv1alpha1.CFProcess, error) {
+	processes := new(korifiv1alpha1.CFProcessList)
+	err := v.client.List(ctx, processes, client.InNamespace(namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: appGUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list app processes: %w", err)
+	}
+
+	return processes.Items, nil
+}
+
+func checkInstanceMemory(quotas appliedQuotas, memoryMB int64) error {
+	if quotas.orgLimits != nil && exceeds(quotas.orgLimits.InstanceMemoryMB, memoryMB) {
+		return quotaExceededError(OrgInstanceMemoryExceededMessage)
+	}
+
+	if quotas.spaceLimits != nil && exceeds(quotas.spaceLimits.InstanceMemoryMB, memoryMB) {
+		return quotaExceededError(SpaceInstanceMemoryExceededMessage)
+	}
+
+	return nil
+}
+
+func appTasksLimit(limits *korifiv1alpha1.QuotaLimits) *int64 {
+	if limits == nil {
+		return nil
+	}
+
+	return limits.AppTasks
+}
+
+func (v *QuotaValidator) taskMemoryMB(task korifiv1alpha1.CFTask) int64 {
+	if task.Status.MemoryMB == 0 {
+		return v.defaultTaskMemoryMB
+	}
+
+	return task.Status.MemoryMB
+}
+
+func isTaskFinished(task korifiv1alpha1.CFTask) bool {
+	return meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskSucceededConditionType) ||
+		meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskFailedConditionType)
+}
+
+func processInstances(process korifiv1alpha1.CFProcess) int64 {
+	if process.Spec.DesiredInstances == nil {
+		return 0
+	}
+
+	return int64(*process.Spec.DesiredInstances)
+}
+
+func processMemoryMB(process korifiv1alpha1.CFProcess) int64 {
+	return process.Spec.MemoryMB * processInstances(process)
+}
+
+func exceeds(limit *int64, value int64) bool {
+	return limit != nil && value > *limit
+}
+
+func quotaExceededError(message string) error {
+	return ValidationError{
+		Type:    QuotaExceededErrorType,
+		Message: message,
+	}.ExportJSONError()
+}
@@ -0,0 +1,396 @@
+package validation_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("QuotaValidator", func() {
+	var (
+		fakeClient     *fake.Client
+		quotaValidator *validation.QuotaValidator
+		validationErr  error
+
+		org        *korifiv1alpha1.CFOrg
+		space      *korifiv1alpha1.CFSpace
+		orgQuota   *korifiv1alpha1.CFOrgQuota
+		spaceQuota *korifiv1alpha1.CFSpaceQuota
+		apps       []korifiv1alpha1.CFApp
+		processes  []korifiv1alpha1.CFProcess
+		routes     []korifiv1alpha1.CFRoute
+		tasks      []korifiv1alpha1.CFTask
+	)
+
+	BeforeEach(func() {
+		org = &korifiv1alpha1.CFOrg{
+			ObjectMeta: metav1.ObjectMeta{Name: "org-guid", Namespace: "cf"},
+			Spec:       korifiv1alpha1.CFOrgSpec{DisplayName: "my-org"},
+		}
+		space = &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{Name: "space-guid", Namespace: "org-guid"},
+			Spec:       korifiv1alpha1.CFSpaceSpec{DisplayName: "my-space"},
+		}
+		orgQuota = &korifiv1alpha1.CFOrgQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "org-quota-guid", Namespace: "cf"},
+		}
+		spaceQuota = &korifiv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "space-quota-guid", Namespace: "org-guid"},
+		}
+		apps = nil
+		processes = nil
+		routes = nil
+		tasks = nil
+
+		fakeClient = new(fake.Client)
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *korifiv1alpha1.CFOrg:
+				if key.Name == org.Name {
+					org.DeepCopyInto(obj)
+					return nil
+				}
+			case *korifiv1alpha1.CFOrgQuota:
+				if key.Name == org.Spec.QuotaRef.Name {
+					orgQuota.DeepCopyInto(obj)
+					return nil
+				}
+			case *korifiv1alpha1.CFSpaceQuota:
+				if key.Name == space.Spec.QuotaRef.Name {
+					spaceQuota.DeepCopyInto(obj)
+					return nil
+				}
+			case *korifiv1alpha1.CFApp:
+				for _, app := range apps {
+					if app.Name == key.Name {
+						app.DeepCopyInto(obj)
+						return nil
+					}
+				}
+			}
+			return k8serrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *korifiv1alpha1.CFSpaceList:
+				list.Items = []korifiv1alpha1.CFSpace{*space}
+			case *korifiv1alpha1.CFAppList:
+				list.Items = apps
+			case *korifiv1alpha1.CFProcessList:
+				list.Items = processes
+			case *korifiv1alpha1.CFRouteList:
+				list.Items = routes
+			case *korifiv1alpha1.CFTaskList:
+				list.Items = tasks
+			}
+			return nil
+		}
+
+		quotaValidator = validation.NewQuotaValidator(fakeClient, "cf", 512)
+	})
+
+	applyOrgQuota := func(limits korifiv1alpha1.QuotaLimits) {
+		org.Spec.QuotaRef.Name = orgQuota.Name
+		orgQuota.Spec.Limits = limits
+	}
+
+	applySpaceQuota := func(limits korifiv1alpha1.QuotaLimits) {
+		space.Spec.QuotaRef.Name = spaceQuota.Name
+		spaceQuota.Spec.Limits = limits
+	}
+
+	Describe("ValidateRouteCreate", func() {
+		BeforeEach(func() {
+			routes = []korifiv1alpha1.CFRoute{{}, {}}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateRouteCreate(context.Background(), &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "space-guid"},
+			})
+		})
+
+		It("succeeds when no quota is applied", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the space quota allows the route", func() {
+			BeforeEach(func() {
+				applySpaceQuota(korifiv1alpha1.QuotaLimits{TotalRoutes: tools.PtrTo[int64](3)})
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the space quota is exceeded", func() {
+			BeforeEach(func() {
+				applySpaceQuota(korifiv1alpha1.QuotaLimits{TotalRoutes: tools.PtrTo[int64](2)})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("Routes quota exceeded for space 'my-space'."),
+				))
+			})
+		})
+
+		When("the org quota is exceeded", func() {
+			BeforeEach(func() {
+				applyOrgQuota(korifiv1alpha1.QuotaLimits{TotalRoutes: tools.PtrTo[int64](1)})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("Routes quota exceeded for organization 'my-org'."),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateTaskCreate", func() {
+		var task *korifiv1alpha1.CFTask
+
+		BeforeEach(func() {
+			task = &korifiv1alpha1.CFTask{
+				ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: "space-guid"},
+				Spec: korifiv1alpha1.CFTaskSpec{
+					AppRef: corev1.LocalObjectReference{Name: "app-guid"},
+				},
+				Status: korifiv1alpha1.CFTaskStatus{MemoryMB: 256},
+			}
+
+			tasks = []korifiv1alpha1.CFTask{
+				{Spec: korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "app-guid"}}},
+				{Spec: korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "another-app-guid"}}},
+				{
+					Spec: korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "app-guid"}},
+					Status: korifiv1alpha1.CFTaskStatus{Conditions: []metav1.Condition{{
+						Type:   korifiv1alpha1.TaskSucceededConditionType,
+						Status: metav1.ConditionTrue,
+					}}},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateTaskCreate(context.Background(), task)
+		})
+
+		When("the running tasks of the app are within the quota", func() {
+			BeforeEach(func() {
+				applySpaceQuota(korifiv1alpha1.QuotaLimits{AppTasks: tools.PtrTo[int64](2)})
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the running tasks of the app exceed the org quota", func() {
+			BeforeEach(func() {
+				applyOrgQuota(korifiv1alpha1.QuotaLimits{AppTasks: tools.PtrTo[int64](1)})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.OrgAppTasksQuotaExceededMessage),
+				))
+			})
+		})
+
+		When("the task memory exceeds the instance memory limit", func() {
+			BeforeEach(func() {
+				applySpaceQuota(korifiv1alpha1.QuotaLimits{InstanceMemoryMB: tools.PtrTo[int64](128)})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.SpaceInstanceMemoryExceededMessage),
+				))
+			})
+		})
+
+		When("the task memory has not been defaulted yet", func() {
+			BeforeEach(func() {
+				task.Status.MemoryMB = 0
+				applySpaceQuota(korifiv1alpha1.QuotaLimits{InstanceMemoryMB: tools.PtrTo[int64](256)})
+			})
+
+			It("checks the default task memory", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.SpaceInstanceMemoryExceededMessage),
+				))
+			})
+		})
+
+		When("the task fits in the memory quota", func() {
+			BeforeEach(func() {
+				applySpaceQuota(korifiv1alpha1.QuotaLimits{TotalMemoryMB: tools.PtrTo[int64](1280)})
+			})
+
+			It("succeeds, ignoring finished tasks", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the task exceeds the memory quota together with the running tasks and apps", func() {
+			BeforeEach(func() {
+				apps = []korifiv1alpha1.CFApp{{
+					ObjectMeta: metav1.ObjectMeta{Name: "app-guid"},
+					Spec:       korifiv1alpha1.CFAppSpec{DesiredState: korifiv1alpha1.StartedState},
+				}}
+				processes = []korifiv1alpha1.CFProcess{{
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef:           corev1.LocalObjectReference{Name: "app-guid"},
+						MemoryMB:         128,
+						DesiredInstances: tools.PtrTo(1),
+					},
+				}}
+				applyOrgQuota(korifiv1alpha1.QuotaLimits{TotalMemoryMB: tools.PtrTo[int64](1280)})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.OrgMemoryQuotaExceededMessage),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateProcessScale", func() {
+		var oldProcess, newProcess *korifiv1alpha1.CFProcess
+
+		BeforeEach(func() {
+			apps = []korifiv1alpha1.CFApp{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "app-guid"},
+					Spec:       korifiv1alpha1.CFAppSpec{DesiredState: korifiv1alpha1.StartedState},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "stopped-app-guid"},
+					Spec:       korifiv1alpha1.CFAppSpec{DesiredState: korifiv1alpha1.StoppedState},
+				},
+			}
+
+			oldProcess = &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{Name: "process-guid", Namespace: "space-guid"},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef:           corev1.LocalObjectReference{Name: "app-guid"},
+					MemoryMB:         256,
+					DesiredInstances: tools.PtrTo(1),
+				},
+			}
+			newProcess = oldProcess.DeepCopy()
+			newProcess.Spec.DesiredInstances = tools.PtrTo(3)
+
+			processes = []korifiv1alpha1.CFProcess{
+				*oldProcess,
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-process-guid"},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef:           corev1.LocalObjectReference{Name: "app-guid"},
+						MemoryMB:         256,
+						DesiredInstances: tools.PtrTo(1),
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "stopped-process-guid"},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef:           corev1.LocalObjectReference{Name: "stopped-app-guid"},
+						MemoryMB:         1024,
+						DesiredInstances: tools.PtrTo(4),
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateProcessScale(context.Background(), oldProcess, newProcess)
+		})
+
+		When("the scaled process fits in the memory quota", func() {
+			BeforeEach(func() {
+				applySpaceQuota(korifiv1alpha1.QuotaLimits{TotalMemoryMB: tools.PtrTo[int64](1024)})
+			})
+
+			It("succeeds, ignoring the processes of stopped apps", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the scaled process exceeds the memory quota", func() {
+			BeforeEach(func() {
+				applySpaceQuota(korifiv1alpha1.QuotaLimits{TotalMemoryMB: tools.PtrTo[int64](768)})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.SpaceMemoryQuotaExceededMessage),
+				))
+			})
+		})
+
+		When("the running tasks use up the memory quota", func() {
+			BeforeEach(func() {
+				tasks = []korifiv1alpha1.CFTask{{
+					Spec:   korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "app-guid"}},
+					Status: korifiv1alpha1.CFTaskStatus{MemoryMB: 256},
+				}}
+				applySpaceQuota(korifiv1alpha1.QuotaLimits{TotalMemoryMB: tools.PtrTo[int64](1024)})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.SpaceMemoryQuotaExceededMessage),
+				))
+			})
+		})
+
+		When("the scaled process exceeds the instances quota", func() {
+			BeforeEach(func() {
+				applyOrgQuota(korifiv1alpha1.QuotaLimits{TotalAppInstances: tools.PtrTo[int64](3)})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.OrgAppInstancesQuotaExceededMessage),
+				))
+			})
+		})
+
+		When("the process is scaled down", func() {
+			BeforeEach(func() {
+				applySpaceQuota(korifiv1alpha1.QuotaLimits{TotalAppInstances: tools.PtrTo[int64](0)})
+				newProcess.Spec.DesiredInstances = tools.PtrTo(0)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
@@ -97,14 +97,16 @@ var _ = BeforeSuite(func() {
 	Expect(domains.NewValidator(uncachedClient).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(instances.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, instances.ServiceInstanceEntityType)),
+		validation.NewQuotaValidator(uncachedClient, rootNamespace, 1024),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(apps.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType)),
+		validation.NewQuotaValidator(uncachedClient, rootNamespace, 1024),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect((&korifiv1alpha1.CFPackage{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
-	Expect(tasks.NewValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(tasks.NewValidator(validation.NewQuotaValidator(uncachedClient, rootNamespace, 1024)).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect(korifiv1alpha1.NewCFProcessDefaulter(128, 256, 60).
 		SetupWebhookWithManager(k8sManager)).To(Succeed())
@@ -114,6 +116,7 @@ var _ = BeforeSuite(func() {
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routes.RouteEntityType)),
 		rootNamespace,
 		uncachedClient,
+		validation.NewQuotaValidator(uncachedClient, rootNamespace, 1024),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(bindings.NewCFServiceBindingValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, bindings.ServiceBindingEntityType)),
@@ -39,6 +39,8 @@ var (
 	testNamespace string
 )
 
+const rootNamespace = "cf"
+
 func TestWorkloadsWebhooks(t *testing.T) {
 	SetDefaultEventuallyTimeout(10 * time.Second)
 	SetDefaultEventuallyPollingInterval(250 * time.Millisecond)
@@ -84,7 +86,7 @@ var _ = BeforeSuite(func() {
 
 	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
 	appNameDuplicateValidator := validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType))
-	Expect(apps.NewValidator(appNameDuplicateValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(apps.NewValidator(appNameDuplicateValidator, validation.NewQuotaValidator(uncachedClient, rootNamespace, 1024)).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
@@ -27,13 +27,15 @@ var cfapplog = logf.Log.WithName("cfapp-validate")
 
 type Validator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 }
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+func NewValidator(duplicateValidator webhooks.NameValidator, quotaValidator webhooks.QuotaValidator) *Validator {
 	return &Validator{
 		duplicateValidator: duplicateValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -75,6 +77,12 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		}.ExportJSONError()
 	}
 
+	if app.Spec.DesiredState == korifiv1alpha1.StartedState && oldApp.Spec.DesiredState != korifiv1alpha1.StartedState {
+		if err := v.quotaValidator.ValidateAppStart(ctx, app); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfapplog, app.Namespace, oldApp, app)
 }
 
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -228,6 +229,60 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 				Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(app), app)).To(Succeed())
 				Expect(app.Spec.DesiredState).To(Equal(korifiv1alpha1.StartedState))
 			})
+
+			When("starting the app exceeds the space memory quota", func() {
+				BeforeEach(func() {
+					orgNamespace := uuid.NewString()
+					Expect(adminClient.Create(ctx, &corev1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{Name: orgNamespace},
+					})).To(Succeed())
+
+					spaceQuota := &korifiv1alpha1.CFSpaceQuota{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: orgNamespace,
+						},
+						Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+							DisplayName: "space-quota",
+							Limits: korifiv1alpha1.QuotaLimits{
+								TotalMemoryMB: tools.PtrTo[int64](512),
+							},
+						},
+					}
+					Expect(adminClient.Create(ctx, spaceQuota)).To(Succeed())
+
+					Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      testNamespace,
+							Namespace: orgNamespace,
+						},
+						Spec: korifiv1alpha1.CFSpaceSpec{
+							DisplayName: "space",
+							QuotaRef:    corev1.LocalObjectReference{Name: spaceQuota.Name},
+						},
+					})).To(Succeed())
+
+					Expect(adminClient.Create(ctx, &korifiv1alpha1.CFProcess{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: testNamespace,
+							Labels: map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey: app.Name,
+							},
+						},
+						Spec: korifiv1alpha1.CFProcessSpec{
+							AppRef:           corev1.LocalObjectReference{Name: app.Name},
+							ProcessType:      "web",
+							MemoryMB:         512,
+							DesiredInstances: tools.PtrTo(2),
+						},
+					})).To(Succeed())
+				})
+
+				It("should fail", func() {
+					Expect(updateErr).To(MatchError(ContainSubstring("memory space_quota_exceeded")))
+				})
+			})
 		})
 
 		Describe("changing the lifecycle type", func() {
@@ -0,0 +1,98 @@
+package processes_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFProcessValidatingWebhook", func() {
+	var (
+		ctx               context.Context
+		quotaValidator    *fake.QuotaValidator
+		oldProcess        *korifiv1alpha1.CFProcess
+		process           *korifiv1alpha1.CFProcess
+		validatingWebhook *processes.Validator
+		retErr            error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		oldProcess = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: "default",
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				ProcessType:      "web",
+				MemoryMB:         256,
+				DesiredInstances: tools.PtrTo(1),
+			},
+		}
+		process = oldProcess.DeepCopy()
+		process.Spec.DesiredInstances = tools.PtrTo(3)
+
+		quotaValidator = new(fake.QuotaValidator)
+		validatingWebhook = processes.NewValidator(quotaValidator)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, process)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(quotaValidator.Invocations()).To(BeEmpty())
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, oldProcess, process)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the scale against the quotas", func() {
+			Expect(quotaValidator.ValidateProcessScaleCallCount()).To(Equal(1))
+			_, actualOldProcess, actualProcess := quotaValidator.ValidateProcessScaleArgsForCall(0)
+			Expect(actualOldProcess).To(Equal(oldProcess))
+			Expect(actualProcess).To(Equal(process))
+		})
+
+		When("the quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateProcessScaleReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+			})
+		})
+
+		When("the process is being deleted", func() {
+			BeforeEach(func() {
+				process.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			It("does not validate the quotas", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(quotaValidator.ValidateProcessScaleCallCount()).To(BeZero())
+			})
+		})
+	})
+})
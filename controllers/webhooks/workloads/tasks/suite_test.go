@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
 	"code.cloudfoundry.org/korifi/controllers/webhooks/finalizer"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/version"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
 	"github.com/google/uuid"
@@ -83,7 +84,9 @@ var _ = BeforeSuite(func() {
 		MemoryMB:    500,
 		DiskQuotaMB: 512,
 	}).SetupWebhookWithManager(k8sManager)).To(Succeed())
-	Expect(tasks.NewValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(tasks.NewValidator(
+		validation.NewQuotaValidator(helpers.NewUncachedClient(k8sManager.GetConfig()), "cf", 500),
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
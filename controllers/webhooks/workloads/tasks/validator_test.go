@@ -7,6 +7,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -74,6 +75,48 @@ var _ = Describe("CFTask Validator", func() {
 			})
 		})
 
+		When("the app tasks exceed the space quota", func() {
+			BeforeEach(func() {
+				orgNamespace := uuid.NewString()
+				Expect(adminClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: orgNamespace},
+				})).To(Succeed())
+
+				spaceQuota := &korifiv1alpha1.CFSpaceQuota{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: orgNamespace,
+					},
+					Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+						DisplayName: "space-quota",
+						Limits: korifiv1alpha1.QuotaLimits{
+							AppTasks: tools.PtrTo[int64](0),
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, spaceQuota)).To(Succeed())
+
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      testNamespace,
+						Namespace: orgNamespace,
+					},
+					Spec: korifiv1alpha1.CFSpaceSpec{
+						DisplayName: "space",
+						QuotaRef:    corev1.LocalObjectReference{Name: spaceQuota.Name},
+					},
+				})).To(Succeed())
+			})
+
+			It("returns a quota exceeded error", func() {
+				validationErr, ok := validation.WebhookErrorToValidationError(creationErr)
+				Expect(ok).To(BeTrue())
+
+				Expect(validationErr.Type).To(Equal(validation.QuotaExceededErrorType))
+				Expect(validationErr.Message).To(Equal(validation.SpaceAppTasksQuotaExceededMessage))
+			})
+		})
+
 		When("the task status is created", func() {
 			var seqId int64
 
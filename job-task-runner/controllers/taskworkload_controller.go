@@ -170,6 +170,9 @@ func WorkloadToJob(
 			Completions:             tools.PtrTo(int32(1)),
 			TTLSecondsAfterFinished: tools.PtrTo(jobTTL),
 			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels(taskWorkload),
+				},
 				Spec: corev1.PodSpec{
 					RestartPolicy: corev1.RestartPolicyNever,
 					SecurityContext: &corev1.PodSecurityContext{
@@ -194,6 +197,8 @@ func WorkloadToJob(
 						},
 					}},
 					ServiceAccountName: ServiceAccountName,
+					NodeSelector:       taskWorkload.Spec.NodeSelector,
+					Tolerations:        taskWorkload.Spec.Tolerations,
 				},
 			},
 		},
@@ -207,6 +212,19 @@ func WorkloadToJob(
 	return job
 }
 
+// podLabels propagates the app and task guids to the task pod, so that the
+// network policies selecting app pods by guid apply to tasks too
+func podLabels(taskWorkload *korifiv1alpha1.TaskWorkload) map[string]string {
+	labels := map[string]string{}
+	for _, key := range []string{korifiv1alpha1.CFAppGUIDLabelKey, korifiv1alpha1.CFTaskGUIDLabelKey} {
+		if value, ok := taskWorkload.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+
+	return labels
+}
+
 func (r *TaskWorkloadReconciler) updateTaskWorkloadStatus(ctx context.Context, taskWorkload *korifiv1alpha1.TaskWorkload, job *batchv1.Job) error {
 	conditions, err := r.statusGetter.GetStatusConditions(ctx, job)
 	if err != nil {
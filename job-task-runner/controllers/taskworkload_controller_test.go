@@ -254,4 +254,60 @@ var _ = Describe("TaskworkloadController", func() {
 			})
 		})
 	})
+
+	Describe("pod labels", func() {
+		var job *batchv1.Job
+
+		BeforeEach(func() {
+			taskWorkload.Labels = map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey:  "my-app-guid",
+				korifiv1alpha1.CFTaskGUIDLabelKey: "my-task-guid",
+				"some-other-label":                "some-value",
+			}
+		})
+
+		JustBeforeEach(func() {
+			job = controllers.WorkloadToJob(taskWorkload, 123, false)
+		})
+
+		It("propagates the app and task guids to the job pod", func() {
+			Expect(job.Spec.Template.Labels).To(Equal(map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey:  "my-app-guid",
+				korifiv1alpha1.CFTaskGUIDLabelKey: "my-task-guid",
+			}))
+		})
+	})
+
+	Describe("placement", func() {
+		var job *batchv1.Job
+
+		JustBeforeEach(func() {
+			job = controllers.WorkloadToJob(taskWorkload, 123, false)
+		})
+
+		It("does not constrain the nodes the job runs on", func() {
+			Expect(job.Spec.Template.Spec.NodeSelector).To(BeEmpty())
+			Expect(job.Spec.Template.Spec.Tolerations).To(BeEmpty())
+		})
+
+		When("the task workload has a node selector and tolerations", func() {
+			BeforeEach(func() {
+				taskWorkload.Spec.NodeSelector = map[string]string{"pool": "regulated"}
+				taskWorkload.Spec.Tolerations = []corev1.Toleration{{
+					Key:      "pool",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}}
+			})
+
+			It("sets them on the job pod", func() {
+				Expect(job.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+				Expect(job.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+					Key:      "pool",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}))
+			})
+		})
+	})
 })
@@ -691,7 +691,8 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 		}
 
 		desiredKpackImage.Labels = map[string]string{
-			BuildWorkloadLabelKey: buildWorkload.Name,
+			BuildWorkloadLabelKey:            buildWorkload.Name,
+			korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
 		}
 
 		desiredKpackImage.Spec = buildv1alpha2.ImageSpec{
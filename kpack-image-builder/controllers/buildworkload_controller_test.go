@@ -215,6 +215,17 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 				}).Should(Succeed())
 			})
 
+			It("labels the kpack.Image with the build workload and app guid", func() {
+				Eventually(func(g Gomega) {
+					kpackImage := new(buildv1alpha2.Image)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+					g.Expect(kpackImage.Labels).To(SatisfyAll(
+						HaveKeyWithValue(controllers.BuildWorkloadLabelKey, buildWorkloadGUID),
+						HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, appGUID),
+					))
+				}).Should(Succeed())
+			})
+
 			It("sets the BuildWorkload to Succeeded='Unknown'", func() {
 				cfBuildLookupKey := types.NamespacedName{Name: buildWorkloadGUID, Namespace: namespaceGUID}
 				updatedBuildWorkload := new(korifiv1alpha1.BuildWorkload)
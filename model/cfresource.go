@@ -7,6 +7,7 @@ type CFResourceStatus int
 const (
 	CFResourceStatusUnknown CFResourceStatus = iota
 	CFResourceStatusReady
+	CFResourceStatusFailed
 )
 
 type CFResourceState struct {
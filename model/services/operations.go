@@ -0,0 +1,23 @@
+package services
+
+const (
+	LastOperationTypeCreate = "create"
+	LastOperationTypeUpdate = "update"
+	LastOperationTypeDelete = "delete"
+
+	LastOperationStateInitial    = "initial"
+	LastOperationStateInProgress = "in progress"
+	LastOperationStateSucceeded  = "succeeded"
+	LastOperationStateFailed     = "failed"
+)
+
+// LastOperation describes the last broker operation performed on a managed
+// service resource, as defined by the OSBAPI spec
+type LastOperation struct {
+	// +kubebuilder:validation:Enum=create;update;delete
+	Type string `json:"type"`
+	// +kubebuilder:validation:Enum=initial;in progress;succeeded;failed
+	State string `json:"state"`
+	// +optional
+	Description string `json:"description,omitempty"`
+}
@@ -52,15 +52,16 @@ const (
 	AnnotationProcessGUID = "korifi.cloudfoundry.org/process-guid"
 
 	LabelGUID                   = "korifi.cloudfoundry.org/guid"
-	LabelVersion                = "korifi.cloudfoundry.org/version"
+	LabelVersion                = korifiv1alpha1.VersionLabelKey
 	LabelAppGUID                = "korifi.cloudfoundry.org/app-guid"
 	LabelAppWorkloadGUID        = "korifi.cloudfoundry.org/appworkload-guid"
 	LabelProcessType            = "korifi.cloudfoundry.org/process-type"
 	LabelStatefulSetRunnerIndex = "korifi.cloudfoundry.org/add-stsr-index"
 
-	ApplicationContainerName  = "application"
-	AppWorkloadReconcilerName = "statefulset-runner"
-	ServiceAccountName        = "korifi-app"
+	ApplicationContainerName   = "application"
+	SidecarContainerNamePrefix = "sidecar-"
+	AppWorkloadReconcilerName  = "statefulset-runner"
+	ServiceAccountName         = "korifi-app"
 
 	LivenessFailureThreshold  = 4
 	ReadinessFailureThreshold = 1
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -37,7 +38,13 @@ func getStatefulSetName(appWorkload *korifiv1alpha1.AppWorkload) (string, error)
 	if annotationVal, ok := appWorkload.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey]; ok {
 		lastStopAppRev = annotationVal
 	}
-	nameSuffix, err := hash(fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, lastStopAppRev))
+	nameSuffixSource := fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, lastStopAppRev)
+	if appWorkload.Spec.Canary {
+		// canary instances run next to the statefulset of the current app revision
+		nameSuffixSource = fmt.Sprintf("%s-canary-%s", appWorkload.Spec.GUID, appWorkload.Spec.Version)
+	}
+
+	nameSuffix, err := hash(nameSuffixSource)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate hash for statefulset name: %w", err)
 	}
@@ -107,21 +114,25 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 			Command:         appWorkload.Spec.Command,
 			Env:             envs,
 			Ports:           ports,
-			SecurityContext: &corev1.SecurityContext{
-				AllowPrivilegeEscalation: tools.PtrTo(false),
-				Capabilities: &corev1.Capabilities{
-					Drop: []corev1.Capability{"ALL"},
-				},
-				SeccompProfile: &corev1.SeccompProfile{
-					Type: corev1.SeccompProfileTypeRuntimeDefault,
-				},
-			},
-			Resources:     appWorkload.Spec.Resources,
-			StartupProbe:  appWorkload.Spec.StartupProbe,
-			LivenessProbe: appWorkload.Spec.LivenessProbe,
+			SecurityContext: containerSecurityContext(),
+			Resources:       appWorkload.Spec.Resources,
+			StartupProbe:    appWorkload.Spec.StartupProbe,
+			LivenessProbe:   appWorkload.Spec.LivenessProbe,
 		},
 	}
 
+	for i, sidecar := range appWorkload.Spec.Sidecars {
+		containers = append(containers, corev1.Container{
+			Name:            SidecarContainerNamePrefix + sanitizeName(sidecar.Name, strconv.Itoa(i)),
+			Image:           appWorkload.Spec.Image,
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         sidecar.Command,
+			Env:             envs,
+			SecurityContext: containerSecurityContext(),
+			Resources:       sidecar.Resources,
+		})
+	}
+
 	statefulsetName, err := getStatefulSetName(appWorkload)
 	if err != nil {
 		return nil, err
@@ -143,6 +154,8 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 						RunAsNonRoot: tools.PtrTo(true),
 					},
 					ServiceAccountName: ServiceAccountName,
+					NodeSelector:       appWorkload.Spec.NodeSelector,
+					Tolerations:        appWorkload.Spec.Tolerations,
 				},
 			},
 		},
@@ -202,6 +215,18 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 	return statefulSet, nil
 }
 
+func containerSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: tools.PtrTo(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
 func sanitizeName(name, fallback string) string {
 	const sanitizedNameMaxLen = 40
 	return sanitizeNameWithMaxStringLen(name, fallback, sanitizedNameMaxLen)
@@ -246,11 +271,21 @@ func toLabelSelectorRequirements(selector *metav1.LabelSelector) []metav1.LabelS
 }
 
 func statefulSetLabelSelector(appWorkload *korifiv1alpha1.AppWorkload) *metav1.LabelSelector {
-	return &metav1.LabelSelector{
+	selector := &metav1.LabelSelector{
 		MatchLabels: map[string]string{
 			LabelGUID: appWorkload.Spec.GUID,
 		},
 	}
+
+	// the selector of the current statefulset also matches the canary pods,
+	// which it does not own, so they are not adopted by it. The canary
+	// selector has to be narrower for its pod disruption budget and pod
+	// anti-affinity not to account for the pods of the current revision.
+	if appWorkload.Spec.Canary {
+		selector.MatchLabels[LabelAppWorkloadGUID] = appWorkload.Name
+	}
+
+	return selector
 }
 
 func hash(s string) (string, error) {
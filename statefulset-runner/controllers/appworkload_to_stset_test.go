@@ -384,6 +384,77 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Spec.SecurityContext.SeccompProfile).To(BeNil())
 	})
 
+	It("does not constrain the nodes the instances run on", func() {
+		Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(BeEmpty())
+		Expect(statefulSet.Spec.Template.Spec.Tolerations).To(BeEmpty())
+	})
+
+	When("the appworkload has a node selector and tolerations", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.NodeSelector = map[string]string{"pool": "regulated"}
+			appWorkload.Spec.Tolerations = []corev1.Toleration{{
+				Key:      "pool",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "regulated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}}
+		})
+
+		It("places the instances on the matching nodes", func() {
+			Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+			Expect(statefulSet.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+				Key:      "pool",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "regulated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}))
+		})
+
+		It("keeps the soft inter-pod anti-affinity", func() {
+			Expect(statefulSet.Spec.Template.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+		})
+	})
+
+	It("runs the application container only", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers).To(HaveLen(1))
+	})
+
+	When("the appworkload has sidecars", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Env = []corev1.EnvVar{{Name: "FOO", Value: "bar"}}
+			appWorkload.Spec.Sidecars = []korifiv1alpha1.AppWorkloadSidecar{{
+				Name:    "Log_Shipper",
+				Command: []string{"/bin/sh", "-c", "ship-logs"},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+				},
+			}}
+		})
+
+		It("runs a container per sidecar next to the application container", func() {
+			containers := statefulSet.Spec.Template.Spec.Containers
+			Expect(containers).To(HaveLen(2))
+			Expect(containers[0].Name).To(Equal(controllers.ApplicationContainerName))
+
+			sidecar := containers[1]
+			Expect(sidecar.Name).To(Equal("sidecar-log-shipper"))
+			Expect(sidecar.Image).To(Equal("gcr.io/foo/bar"))
+			Expect(sidecar.Command).To(Equal([]string{"/bin/sh", "-c", "ship-logs"}))
+			Expect(sidecar.Env).To(Equal(containers[0].Env))
+			Expect(sidecar.Ports).To(BeEmpty())
+			Expect(sidecar.StartupProbe).To(BeNil())
+			Expect(sidecar.LivenessProbe).To(BeNil())
+			Expect(sidecar.Resources.Limits.Memory().String()).To(Equal("64Mi"))
+			Expect(sidecar.Resources.Requests.Memory().String()).To(Equal("64Mi"))
+			Expect(sidecar.SecurityContext).To(Equal(containers[0].SecurityContext))
+		})
+	})
+
 	When("statefulsetRunnerTemporarySetPodSeccompProfile is set to true", func() {
 		BeforeEach(func() {
 			statefulsetRunnerTemporarySetPodSeccompProfile = true
@@ -393,4 +464,42 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 			Expect(statefulSet.Spec.Template.Spec.SecurityContext.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
 		})
 	})
+
+	When("the appworkload runs canary instances", func() {
+		var currentStatefulSet *appsv1.StatefulSet
+
+		BeforeEach(func() {
+			currentWorkload := appWorkload.DeepCopy()
+			currentWorkload.Spec.Version = "current_version"
+
+			var err error
+			currentStatefulSet, err = controllers.NewAppWorkloadToStatefulsetConverter(scheme.Scheme, false).Convert(currentWorkload)
+			Expect(err).NotTo(HaveOccurred())
+
+			appWorkload.Name = "guid_1234-canary"
+			appWorkload.Spec.Canary = true
+		})
+
+		It("does not reuse the name of the statefulset of the current version", func() {
+			Expect(statefulSet.Name).NotTo(Equal(currentStatefulSet.Name))
+		})
+
+		It("has a new name when the canary version changes", func() {
+			originalName := statefulSet.Name
+
+			appWorkload.Spec.Version = "another_version"
+			var err error
+			statefulSet, err = converter.Convert(appWorkload)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(statefulSet.Name).NotTo(Equal(originalName))
+		})
+
+		It("selects the canary pods only", func() {
+			Expect(statefulSet.Spec.Selector.MatchLabels).To(Equal(map[string]string{
+				controllers.LabelGUID:            "guid_1234",
+				controllers.LabelAppWorkloadGUID: "guid_1234-canary",
+			}))
+		})
+	})
 })
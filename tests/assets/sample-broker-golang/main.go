@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 
 	"sample-broker/osbapi"
 )
@@ -12,6 +13,7 @@ import (
 func main() {
 	http.HandleFunc("/", helloWorldHandler)
 	http.HandleFunc("/v2/catalog", getCatalogHandler)
+	http.HandleFunc("/v2/service_instances/", serviceInstanceHandler)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -50,3 +52,35 @@ func getCatalogHandler(w http.ResponseWriter, _ *http.Request) {
 
 	fmt.Fprintln(w, string(catalogBytes))
 }
+
+func serviceInstanceHandler(w http.ResponseWriter, r *http.Request) {
+	if strings.Contains(r.URL.Path, "/service_bindings/") {
+		serviceBindingHandler(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	fmt.Fprintln(w, "{}")
+}
+
+func serviceBindingHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintln(w, `{"credentials": {"user": "sample-user", "password": "sample-password"}}`)
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "{}")
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -860,7 +860,11 @@ var _ = Describe("Apps", func() {
 	})
 
 	Describe("query SSH enabled", func() {
-		It("always returns false", func() {
+		BeforeEach(func() {
+			appGUID = createBuildpackApp(space1GUID, generateGUID("app"))
+		})
+
+		It("returns false when the ssh proxy is not deployed", func() {
 			var respObj struct {
 				Enabled bool   `json:"enabled"`
 				Reason  string `json:"reason"`
@@ -868,7 +872,7 @@ var _ = Describe("Apps", func() {
 
 			resp, err := adminClient.R().
 				SetResult(&respObj).
-				Get("/v3/apps/any-guid/ssh_enabled")
+				Get("/v3/apps/" + appGUID + "/ssh_enabled")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
@@ -76,4 +76,123 @@ var _ = Describe("Deployments", func() {
 			Expect(deploymentResource.GUID).NotTo(BeEmpty())
 		})
 	})
+
+	Describe("List", func() {
+		var (
+			deploymentGUID string
+			listResp       resourceList[responseResource]
+		)
+
+		BeforeEach(func() {
+			deploymentGUID = createDeployment(appGUID)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetResult(&listResp).
+				Get("/v3/deployments?app_guids=" + appGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the app deployments", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(listResp.Resources).To(ContainElement(HaveField("GUID", deploymentGUID)))
+		})
+	})
+
+	Describe("Cancel", func() {
+		var (
+			deploymentGUID string
+			deploymentResp responseResource
+		)
+
+		BeforeEach(func() {
+			deploymentGUID = createDeployment(appGUID)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetResult(&deploymentResp).
+				Post("/v3/deployments/" + deploymentGUID + "/actions/cancel")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 200 OK", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(deploymentResp.GUID).To(Equal(deploymentGUID))
+		})
+	})
+
+	Describe("Continue", func() {
+		type deploymentStatus struct {
+			Reason string `json:"reason"`
+		}
+
+		type deploymentResource struct {
+			GUID   string           `json:"guid"`
+			Status deploymentStatus `json:"status"`
+		}
+
+		var (
+			deploymentGUID string
+			deploymentResp deploymentResource
+		)
+
+		BeforeEach(func() {
+			var createdDeployment deploymentResource
+			createResp, err := adminClient.R().
+				SetBody(map[string]any{
+					"strategy": "canary",
+					"relationships": relationships{
+						"app": relationship{
+							Data: resource{
+								GUID: appGUID,
+							},
+						},
+					},
+				}).
+				SetResult(&createdDeployment).
+				Post("/v3/deployments")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createResp).To(HaveRestyStatusCode(http.StatusCreated))
+			deploymentGUID = createdDeployment.GUID
+
+			Eventually(func(g Gomega) {
+				var pausedDeployment deploymentResource
+				getResp, err := adminClient.R().
+					SetResult(&pausedDeployment).
+					Get("/v3/deployments/" + deploymentGUID)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(getResp).To(HaveRestyStatusCode(http.StatusOK))
+				g.Expect(pausedDeployment.Status.Reason).To(Equal("PAUSED"))
+			}).Should(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetResult(&deploymentResp).
+				Post("/v3/deployments/" + deploymentGUID + "/actions/continue")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 200 OK", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(deploymentResp.GUID).To(Equal(deploymentGUID))
+			Expect(deploymentResp.Status.Reason).To(Equal("DEPLOYING"))
+		})
+
+		It("eventually finalizes the deployment", func() {
+			Eventually(func(g Gomega) {
+				getResp, err := adminClient.R().
+					SetResult(&deploymentResp).
+					Get("/v3/deployments/" + deploymentGUID)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(getResp).To(HaveRestyStatusCode(http.StatusOK))
+				g.Expect(deploymentResp.Status.Reason).To(Equal("DEPLOYED"))
+			}).Should(Succeed())
+		})
+	})
 })
@@ -0,0 +1,145 @@
+package securitygroups
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+type PortRange struct {
+	Start int32
+	End   int32
+}
+
+// ParseDestination parses a security group rule destination into a list of
+// CIDRs. A destination is a comma separated list of IP addresses, CIDRs or
+// IP ranges such as "10.0.0.1-10.0.0.255"
+func ParseDestination(destination string) ([]string, error) {
+	var cidrs []string
+	for _, dest := range strings.Split(destination, ",") {
+		dest = strings.TrimSpace(dest)
+
+		if start, end, isRange := strings.Cut(dest, "-"); isRange {
+			rangeCIDRs, err := parseRange(start, end)
+			if err != nil {
+				return nil, err
+			}
+			cidrs = append(cidrs, rangeCIDRs...)
+			continue
+		}
+
+		if strings.Contains(dest, "/") {
+			prefix, err := netip.ParsePrefix(dest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid destination %q: %w", dest, err)
+			}
+			cidrs = append(cidrs, prefix.Masked().String())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(dest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination %q: %w", dest, err)
+		}
+		cidrs = append(cidrs, netip.PrefixFrom(addr, addr.BitLen()).String())
+	}
+
+	return cidrs, nil
+}
+
+func parseRange(start, end string) ([]string, error) {
+	startAddr, err := netip.ParseAddr(strings.TrimSpace(start))
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination range start %q: %w", start, err)
+	}
+
+	endAddr, err := netip.ParseAddr(strings.TrimSpace(end))
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination range end %q: %w", end, err)
+	}
+
+	if startAddr.BitLen() != endAddr.BitLen() || startAddr.Compare(endAddr) > 0 {
+		return nil, fmt.Errorf("invalid destination range %s-%s", startAddr, endAddr)
+	}
+
+	return rangeToCIDRs(startAddr, endAddr), nil
+}
+
+// rangeToCIDRs returns the smallest list of CIDRs covering exactly the
+// addresses between start and end
+func rangeToCIDRs(start, end netip.Addr) []string {
+	var cidrs []string
+	for {
+		bits := start.BitLen()
+		for bits > 0 {
+			candidate, _ := start.Prefix(bits - 1)
+			if candidate.Addr() != start || lastAddr(candidate).Compare(end) > 0 {
+				break
+			}
+			bits--
+		}
+
+		prefix := netip.PrefixFrom(start, bits)
+		cidrs = append(cidrs, prefix.String())
+
+		last := lastAddr(prefix)
+		if last.Compare(end) >= 0 {
+			return cidrs
+		}
+		start = last.Next()
+	}
+}
+
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(addr)*8; i++ {
+		addr[i/8] |= 1 << (7 - i%8)
+	}
+
+	last, _ := netip.AddrFromSlice(addr)
+	return last
+}
+
+// ParsePorts parses the ports of a security group rule. Ports are either a
+// single port, a range of ports such as "8080-8090" or a comma separated
+// list of ports
+func ParsePorts(ports string) ([]PortRange, error) {
+	if start, end, isRange := strings.Cut(ports, "-"); isRange {
+		startPort, err := parsePort(start)
+		if err != nil {
+			return nil, err
+		}
+
+		endPort, err := parsePort(end)
+		if err != nil {
+			return nil, err
+		}
+
+		if startPort > endPort {
+			return nil, fmt.Errorf("invalid port range %q", ports)
+		}
+
+		return []PortRange{{Start: startPort, End: endPort}}, nil
+	}
+
+	var portRanges []PortRange
+	for _, p := range strings.Split(ports, ",") {
+		port, err := parsePort(p)
+		if err != nil {
+			return nil, err
+		}
+		portRanges = append(portRanges, PortRange{Start: port, End: port})
+	}
+
+	return portRanges, nil
+}
+
+func parsePort(port string) (int32, error) {
+	parsed, err := strconv.ParseInt(strings.TrimSpace(port), 10, 32)
+	if err != nil || parsed < 1 || parsed > 65535 {
+		return 0, fmt.Errorf("invalid port %q", port)
+	}
+
+	return int32(parsed), nil
+}
@@ -0,0 +1,63 @@
+package securitygroups_test
+
+import (
+	"code.cloudfoundry.org/korifi/tools/securitygroups"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseDestination", func() {
+	DescribeTable("valid destinations",
+		func(destination string, expectedCIDRs []string) {
+			cidrs, err := securitygroups.ParseDestination(destination)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrs).To(Equal(expectedCIDRs))
+		},
+		Entry("an IP address", "10.0.0.1", []string{"10.0.0.1/32"}),
+		Entry("an IPv6 address", "2001:db8::1", []string{"2001:db8::1/128"}),
+		Entry("a CIDR", "10.0.0.0/8", []string{"10.0.0.0/8"}),
+		Entry("a CIDR with host bits", "10.1.2.3/16", []string{"10.1.0.0/16"}),
+		Entry("an aligned range", "10.0.0.0-10.0.0.255", []string{"10.0.0.0/24"}),
+		Entry("an unaligned range", "10.0.0.1-10.0.0.6", []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"}),
+		Entry("a single address range", "10.0.0.1-10.0.0.1", []string{"10.0.0.1/32"}),
+		Entry("the whole address space", "0.0.0.0-255.255.255.255", []string{"0.0.0.0/0"}),
+		Entry("a list of destinations", "10.0.0.1, 192.168.0.0/16", []string{"10.0.0.1/32", "192.168.0.0/16"}),
+	)
+
+	DescribeTable("invalid destinations",
+		func(destination string) {
+			_, err := securitygroups.ParseDestination(destination)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("not an IP", "example.com"),
+		Entry("invalid CIDR", "10.0.0.0/33"),
+		Entry("reversed range", "10.0.0.5-10.0.0.1"),
+		Entry("mixed family range", "10.0.0.1-2001:db8::1"),
+	)
+})
+
+var _ = Describe("ParsePorts", func() {
+	DescribeTable("valid ports",
+		func(ports string, expectedRanges []securitygroups.PortRange) {
+			portRanges, err := securitygroups.ParsePorts(ports)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(portRanges).To(Equal(expectedRanges))
+		},
+		Entry("a single port", "443", []securitygroups.PortRange{{Start: 443, End: 443}}),
+		Entry("a list of ports", "80, 443", []securitygroups.PortRange{{Start: 80, End: 80}, {Start: 443, End: 443}}),
+		Entry("a range", "8000-9000", []securitygroups.PortRange{{Start: 8000, End: 9000}}),
+	)
+
+	DescribeTable("invalid ports",
+		func(ports string) {
+			_, err := securitygroups.ParsePorts(ports)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("not a number", "http"),
+		Entry("zero", "0"),
+		Entry("too large", "65536"),
+		Entry("reversed range", "9000-8000"),
+	)
+})